@@ -1,6 +1,10 @@
 package schema
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 )
@@ -76,6 +80,56 @@ func TestTupleSchema_Basic(t *testing.T) {
 	}
 }
 
+func TestTupleSchema_Rest(t *testing.T) {
+	ctx := DefaultValidationContext()
+	schema := Tuple(String()).Rest(Int().Min(0))
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+		path     string // Path of the first nested error
+	}{
+		{"positions only", []interface{}{"sum"}, true, ""},
+		{"valid rest items", []interface{}{"sum", 1, 2, 3}, true, ""},
+		{"invalid rest item", []interface{}{"sum", 1, -2}, false, "[2]"},
+		{"wrong rest type", []interface{}{"sum", "x"}, false, "[1]"},
+		{"missing position", []interface{}{}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Tuple.Parse(%v) = %v, want %v: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.path != "" && result.Errors[0].Path.String() != tt.path {
+				t.Errorf("error path = %s, want %s", result.Errors[0].Path, tt.path)
+			}
+		})
+	}
+
+	t.Run("json schema", func(t *testing.T) {
+		doc := JSONSchemaWithOptions(schema, JSONSchemaOptions{})
+		if prefix, ok := doc["prefixItems"].([]interface{}); !ok || len(prefix) != 1 {
+			t.Errorf("prefixItems = %v, want the string position", doc["prefixItems"])
+		}
+		if items, ok := doc["items"].(map[string]interface{}); !ok || items["type"] != "integer" {
+			t.Errorf("items = %v, want the rest schema", doc["items"])
+		}
+		if _, ok := doc["maxItems"]; ok {
+			t.Error("a tuple with rest items should not have maxItems")
+		}
+	})
+
+	t.Run("strict clears rest", func(t *testing.T) {
+		strict := Tuple(String()).Rest(Int()).Strict()
+		if strict.GetRest() != nil || strict.Parse([]interface{}{"a", 1}, ctx).Valid {
+			t.Error("Strict should drop the rest schema and reject extra items")
+		}
+	})
+}
+
 // Test AllOf Schema
 func TestAllOfSchema_Basic(t *testing.T) {
 	ctx := DefaultValidationContext()
@@ -312,6 +366,229 @@ func TestConditionalSchema_Basic(t *testing.T) {
 	}
 }
 
+func TestConditionalSchema_Cases(t *testing.T) {
+	ctx := DefaultValidationContext()
+	payment := Conditional().
+		Case(FieldEquals("method", "card"), Object().Passthrough().Property("number", String().MinLength(12))).
+		Case(FieldEquals("method", "iban"), Object().Passthrough().Property("iban", String())).
+		Case(Predicate(func(v interface{}) bool {
+			m, ok := v.(map[string]interface{})
+			return ok && m["amount"] == 0
+		}), nil).
+		Else(Never())
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+		code     ErrorCode
+	}{
+		{"first case", map[string]interface{}{"method": "card", "number": "4242424242424242"}, true, ""},
+		{"first case fails", map[string]interface{}{"method": "card", "number": "42"}, false, CodeThenFailed},
+		{"second case", map[string]interface{}{"method": "iban", "iban": "DE89"}, true, ""},
+		{"second case fails", map[string]interface{}{"method": "iban"}, false, CodeThenFailed},
+		{"predicate case without schema", map[string]interface{}{"method": "cash", "amount": 0}, true, ""},
+		{"no case matches", map[string]interface{}{"method": "cash", "amount": 5}, false, CodeElseFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := payment.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if !result.Valid && result.Errors[0].Code != tt.code {
+				t.Errorf("first error code = %s, want %s", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	t.Run("first matching case wins", func(t *testing.T) {
+		s := Conditional().
+			Case(Int().Min(0), Int().Max(10)).
+			Case(Int(), Int().Min(100))
+		if result := s.Parse(50, ctx); result.Valid {
+			t.Error("50 matches the first case and should fail its schema")
+		}
+		if result := s.Parse(-5, ctx); result.Valid {
+			t.Error("-5 matches the second case only and should fail its schema")
+		}
+	})
+
+	t.Run("json schema chain", func(t *testing.T) {
+		s := Conditional(String()).Then(String().MinLength(1)).
+			Case(FieldEquals("kind", "a"), Object()).
+			Else(Int())
+		got := s.JSON()
+		if _, ok := got["if"].(map[string]interface{}); !ok {
+			t.Fatalf("JSON() = %v, want an if/then/else chain", got)
+		}
+		next, ok := got["else"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("else = %v, want the second case", got["else"])
+		}
+		condition := next["if"].(map[string]interface{})
+		properties := condition["properties"].(map[string]interface{})
+		if kind := properties["kind"].(map[string]interface{}); kind["const"] != "a" {
+			t.Errorf("case condition = %v, want kind const a", condition)
+		}
+		if required := condition["required"]; !reflect.DeepEqual(required, []string{"kind"}) {
+			t.Errorf("case condition required = %v, want [kind]", required)
+		}
+		if _, ok := next["then"]; !ok {
+			t.Error("second case should have a then schema")
+		}
+		if last := next["else"].(map[string]interface{}); last["type"] != "integer" {
+			t.Errorf("final else = %v, want the Else schema", last)
+		}
+	})
+}
+
+// Test the shared surface of the combinator schemas
+func TestCombinatorSchemas_Surface(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	t.Run("optional properties", func(t *testing.T) {
+		obj := Object().
+			Property("not", Not(String()).Optional()).
+			Property("if", Conditional(String()).Then(String().MinLength(2)).Optional()).
+			Property("any", AnyOf(String(), Int()).Optional()).
+			Property("all", AllOf(Int(), Int().Min(0)).Optional())
+		if result := obj.Parse(map[string]interface{}{}, ctx); !result.Valid {
+			t.Errorf("missing optional properties should be valid: %v", result.Errors)
+		}
+		if result := obj.Parse(map[string]interface{}{"not": "text"}, ctx); result.Valid {
+			t.Error("not property should reject a string")
+		}
+	})
+
+	t.Run("required by default", func(t *testing.T) {
+		obj := Object().Property("not", Not(String())).Property("if", Conditional(String()))
+		if got := obj.GetRequiredProperties(); len(got) != 2 {
+			t.Errorf("required properties = %v, want both", got)
+		}
+	})
+
+	t.Run("nil handling", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			schema Parseable
+			value  interface{}
+			want   bool
+		}{
+			{"nullable not", Not(Any()).Nullable(), nil, true},
+			{"required not negates nil", Not(Any()), nil, false},
+			{"not default", Not(Int()).Default("fallback"), nil, true},
+			{"not invalid default", Not(Int()).Default(1), nil, false},
+			{"nullable conditional", Conditional(String()).Then(String()).Else(Int()).Nullable(), nil, true},
+			{"optional conditional", Conditional(String()).Then(String()).Else(Int()).Optional(), nil, true},
+			{"conditional default", Conditional(String()).Then(String().MinLength(3)).Default("abc"), nil, true},
+		}
+		for _, tt := range tests {
+			if result := tt.schema.Parse(tt.value, ctx); result.Valid != tt.want {
+				t.Errorf("%s: valid = %v, want %v (%v)", tt.name, result.Valid, tt.want, result.Errors)
+			}
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		not := Not(String()).Title("Not a string").Description("Anything else").Nullable().JSON()
+		inner := not["anyOf"].([]interface{})[0].(map[string]interface{})
+		if inner["title"] != "Not a string" || inner["description"] != "Anything else" || inner["not"] == nil {
+			t.Errorf("unexpected not JSON: %v", not)
+		}
+		cond := Conditional(String()).Then(String().MinLength(1)).Title("Conditional").Default("x").JSON()
+		if cond["title"] != "Conditional" || cond["default"] != "x" || cond["then"] == nil {
+			t.Errorf("unexpected conditional JSON: %v", cond)
+		}
+	})
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		for _, s := range []Parseable{Not(String()), Conditional(String()).Then(Int())} {
+			if _, err := json.Marshal(s); err != nil {
+				t.Errorf("marshal %T: %v", s, err)
+			}
+		}
+	})
+}
+
+// Test Null, Never and Unknown schemas
+func TestNullNeverUnknownSchemas(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		value  interface{}
+		code   ErrorCode // "" when valid
+	}{
+		{"null accepts nil", Null(), nil, ""},
+		{"null rejects a value", Null(), "x", "invalid_type"},
+		{"optional null rejects a value", Null().Optional(), 0, "invalid_type"},
+		{"never rejects a value", Never(), "x", "never"},
+		{"never rejects nil", Never(), nil, "never"},
+		{"unknown accepts a value", Unknown(), map[string]interface{}{"a": 1}, ""},
+		{"unknown accepts nil", Unknown(), nil, ""},
+		{"union with null accepts nil", Union(String(), Null()), nil, ""},
+		{"union with null accepts a string", Union(String(), Null()), "x", ""},
+		{"union without null requires a value", Union(String(), Int()), nil, "required"},
+		{"anyOf with null accepts nil", AnyOf(Int(), Null()), nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != (tt.code == "") {
+				t.Fatalf("Parse(%v) valid = %v (%v)", tt.value, result.Valid, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	t.Run("never forbids a property", func(t *testing.T) {
+		obj := Object().Property("name", String()).Property("password", Never("passwords are not accepted here"))
+		if result := obj.Parse(map[string]interface{}{"name": "ann"}, ctx); !result.Valid {
+			t.Errorf("missing forbidden property should be valid: %v", result.Errors)
+		}
+		result := obj.Parse(map[string]interface{}{"name": "ann", "password": "secret"}, ctx)
+		if got := errorKeys(result.Errors); !reflect.DeepEqual(got, []string{"password never", "password property_invalid"}) {
+			t.Errorf("errors = %v", got)
+		}
+		for _, err := range result.Errors {
+			if err.Code == "never" && err.Message != "passwords are not accepted here" {
+				t.Errorf("message = %q", err.Message)
+			}
+		}
+	})
+
+	t.Run("unknown values are untrusted", func(t *testing.T) {
+		result := Object().Property("extra", Unknown()).Parse(map[string]interface{}{"extra": "42"}, ctx)
+		extra, ok := result.Value.(map[string]interface{})["extra"].(Untrusted)
+		if !ok {
+			t.Fatalf("extra = %#v, want an Untrusted", result.Value)
+		}
+		if extra.Raw() != "42" {
+			t.Errorf("Raw() = %v", extra.Raw())
+		}
+		if narrowed := extra.Narrow(String().Pattern(`^\d+$`), ctx); !narrowed.Valid || narrowed.Value != "42" {
+			t.Errorf("Narrow() = %v", narrowed)
+		}
+		if data, err := json.Marshal(result.Value); err != nil || string(data) != `{"extra":"42"}` {
+			t.Errorf("json = %s, %v", data, err)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		if doc := Never().JSON(); !reflect.DeepEqual(doc["not"], map[string]interface{}{}) {
+			t.Errorf("Never JSON = %v", doc)
+		}
+		if doc := Unknown().Description("anything").JSON(); len(doc) != 1 {
+			t.Errorf("Unknown JSON = %v", doc)
+		}
+	})
+}
+
 // Test Ref Schema
 func TestRefSchema_Basic(t *testing.T) {
 	ctx := DefaultValidationContext()
@@ -569,3 +846,178 @@ func TestAdvancedSchemas_Complex(t *testing.T) {
 		})
 	}
 }
+
+// Test Lazy Schema
+func TestLazySchema_Recursive(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	var category *ObjectSchema
+	category = Object().
+		Property("name", String().MinLength(1)).
+		Property("children", Array(Lazy(func() Parseable { return category })).Optional())
+
+	leaf := func(name string) map[string]interface{} {
+		return map[string]interface{}{"name": name}
+	}
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{"leaf", leaf("root"), true},
+		{"nested", map[string]interface{}{
+			"name": "root",
+			"children": []interface{}{
+				map[string]interface{}{"name": "a", "children": []interface{}{leaf("a1"), leaf("a2")}},
+				leaf("b"),
+			},
+		}, true},
+		{"invalid deep child", map[string]interface{}{
+			"name": "root",
+			"children": []interface{}{
+				map[string]interface{}{"name": "a", "children": []interface{}{leaf("")}},
+			},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := category.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Errorf("Lazy.Parse(%v) = %v, want %v. Errors: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+		})
+	}
+}
+
+func TestLazySchema_MaxDepth(t *testing.T) {
+	var node *ObjectSchema
+	node = Object().
+		OptionalProperty("next", Lazy(func() Parseable { return node }).Nullable())
+
+	// Build a linked list five nodes deep
+	var value interface{}
+	for i := 0; i < 5; i++ {
+		value = map[string]interface{}{"next": value}
+	}
+
+	if result := node.Parse(value, DefaultValidationContext()); !result.Valid {
+		t.Errorf("expected valid list, got %v", result.Errors)
+	}
+
+	result := node.Parse(value, DefaultValidationContext().WithMaxDepth(2))
+	if result.Valid {
+		t.Fatal("expected max depth to be exceeded")
+	}
+	found := false
+	for _, err := range result.Errors {
+		if err.Code == "max_depth" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected max_depth error, got %v", result.Errors)
+	}
+}
+
+func TestLazySchema_JSON(t *testing.T) {
+	var category *ObjectSchema
+	category = Object().Title("Category").
+		Property("name", String()).
+		Property("children", Array(Lazy(func() Parseable { return category })))
+
+	doc := normalizeJSONSchema(category.JSON())
+	children := doc["properties"].(map[string]interface{})["children"].(map[string]interface{})
+	if ref := children["items"]; !reflect.DeepEqual(ref, map[string]interface{}{"$ref": "#/$defs/Category"}) {
+		t.Errorf("children.items = %v, want a $ref to the Category definition", ref)
+	}
+	def, ok := doc["$defs"].(map[string]interface{})["Category"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("$defs = %v, want the Category definition", doc["$defs"])
+	}
+	if !reflect.DeepEqual(def["properties"].(map[string]interface{})["children"], children) {
+		t.Errorf("$defs.Category.children = %v, want %v", def["properties"], children)
+	}
+
+	// A Lazy schema generated as the document references itself with "#"
+	var node *LazySchema
+	node = Lazy(func() Parseable { return Object().OptionalProperty("next", node) })
+	want := map[string]interface{}{
+		"type":                 "object",
+		"properties":           map[string]interface{}{"next": map[string]interface{}{"$ref": "#"}},
+		"additionalProperties": false,
+	}
+	if got := normalizeJSONSchema(node.JSON()); !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON() = %v, want %v", got, want)
+	}
+
+	// Concurrent generations do not see each other's recursion
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := normalizeJSONSchema(category.JSON()); !reflect.DeepEqual(got, doc) {
+				t.Errorf("concurrent JSON() = %v, want %v", got, doc)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRefSchema_Recursive(t *testing.T) {
+	ctx := DefaultValidationContext()
+	registry := NewSchemaRegistry()
+
+	registry.Define("Node", Object().
+		Property("value", Int()).
+		Property("children", Array(Ref("#/Node", registry)).Optional()))
+
+	tree := map[string]interface{}{
+		"value": 1,
+		"children": []interface{}{
+			map[string]interface{}{"value": 2, "children": []interface{}{
+				map[string]interface{}{"value": 3},
+			}},
+		},
+	}
+
+	if result := Ref("#/Node", registry).Parse(tree, ctx); !result.Valid {
+		t.Errorf("expected recursive ref to validate nested data, got %v", result.Errors)
+	}
+}
+
+func TestSchema_DefaultFunc(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	calls := 0
+	next := func() interface{} {
+		calls++
+		return fmt.Sprintf("id-%d", calls)
+	}
+	s := String().Optional().DefaultFunc(next)
+
+	for i := 1; i <= 2; i++ {
+		result := s.Parse(nil, ctx)
+		if !result.Valid {
+			t.Fatalf("expected valid, got %v", result.Errors)
+		}
+		if want := fmt.Sprintf("id-%d", i); result.Value != want {
+			t.Errorf("parse %d: value = %v, want %v", i, result.Value, want)
+		}
+	}
+
+	if result := s.Parse("given", ctx); calls != 2 || !result.Valid {
+		t.Errorf("default func should not run when a value is present (calls = %d)", calls)
+	}
+
+	json := String().DefaultFunc(next).JSON()
+	if _, ok := json["default"]; ok {
+		t.Errorf("computed defaults should not be emitted, got %v", json["default"])
+	}
+
+	if got := String().DefaultFunc(next).Default("fixed").GetDefault(); got != "fixed" {
+		t.Errorf("Default after DefaultFunc = %v, want fixed", got)
+	}
+}
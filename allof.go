@@ -3,6 +3,7 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/nyxstack/i18n"
 )
@@ -41,28 +42,125 @@ func AllOf(schemas ...Parseable) *AllOfSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *AllOfSchema) Clone() *AllOfSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.schemas = slices.Clone(s.schemas)
+	return &c
+}
+
+// Freeze makes the schema and its schemas immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *AllOfSchema) Freeze() *AllOfSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and its schemas
+func (s *AllOfSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.schemas...)
+}
+
+// Children returns the schemas of the allOf, which apply to the value itself
+func (s *AllOfSchema) Children() []SchemaChild {
+	return sameValueChildren(s.schemas...)
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *AllOfSchema) Title(title string) *AllOfSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *AllOfSchema) Description(description string) *AllOfSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *AllOfSchema) ReadOnly() *AllOfSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *AllOfSchema) WriteOnly() *AllOfSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *AllOfSchema) Sensitive() *AllOfSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *AllOfSchema) Deprecated(reason string) *AllOfSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *AllOfSchema) Meta(key string, value interface{}) *AllOfSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *AllOfSchema) AsWarning(codes ...ErrorCode) *AllOfSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *AllOfSchema) AsInfo(codes ...ErrorCode) *AllOfSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *AllOfSchema) Default(value interface{}) *AllOfSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *AllOfSchema) DefaultFunc(fn func() interface{}) *AllOfSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *AllOfSchema) Example(example interface{}) *AllOfSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
@@ -71,6 +169,7 @@ func (s *AllOfSchema) Example(example interface{}) *AllOfSchema {
 
 // Add appends additional schemas to the allof (all must match)
 func (s *AllOfSchema) Add(schemas ...Parseable) *AllOfSchema {
+	s.checkMutable()
 	s.schemas = append(s.schemas, schemas...)
 	return s
 }
@@ -84,12 +183,14 @@ func (s *AllOfSchema) Schemas() []Parseable {
 
 // Optional marks the schema as optional
 func (s *AllOfSchema) Optional() *AllOfSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *AllOfSchema) Required(errorMessage ...interface{}) *AllOfSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -99,6 +200,7 @@ func (s *AllOfSchema) Required(errorMessage ...interface{}) *AllOfSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *AllOfSchema) Nullable() *AllOfSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
@@ -107,12 +209,14 @@ func (s *AllOfSchema) Nullable() *AllOfSchema {
 
 // NotAllMatchError sets a custom error message when not all schemas match
 func (s *AllOfSchema) NotAllMatchError(message string) *AllOfSchema {
+	s.checkMutable()
 	s.notAllMatchError = toErrorMessage(message)
 	return s
 }
 
 // TypeError sets a custom error message for type mismatch validation
 func (s *AllOfSchema) TypeError(message string) *AllOfSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -139,10 +243,36 @@ func (s *AllOfSchema) GetSchemaCount() int {
 	return len(s.schemas)
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *AllOfSchema) Transform(fn TransformFunc) *AllOfSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *AllOfSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *AllOfSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *AllOfSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *AllOfSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses an allof value, returning the final parsed value
 func (s *AllOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the allOf constraints; Parse runs the refine/transform pipeline on top
+func (s *AllOfSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
 	// Handle nil values
@@ -155,7 +285,7 @@ func (s *AllOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := allofRequiredError(ctx.Locale)
@@ -165,12 +295,12 @@ func (s *AllOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -179,21 +309,29 @@ func (s *AllOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 	// Validate against ALL schemas in the allof
 	var finalValue interface{} = value
 	var allErrors []ValidationError
+	var warnings []ValidationError
 
 	for i, schema := range s.schemas {
+		if ctx.stopCollecting(len(errors) + len(allErrors)) {
+			break
+		}
 		result := schema.Parse(value, ctx)
+		warnings = append(warnings, result.Warnings...)
 		if !result.Valid {
 			// This schema failed - collect errors
 			message := allofSchemaError(i)(ctx.Locale)
-			errors = append(errors, NewPrimitiveError(value, message, "allof_schema_failed"))
+			errors = append(errors, NewPrimitiveError(value, message, CodeAllOfSchemaFailed))
 
 			// Add context about which schema failed
 			for _, err := range result.Errors {
 				contextualErr := ValidationError{
-					Path:    append([]string{fmt.Sprintf("allOf[%d]", i)}, err.Path...),
+					Path:    append(Path{FieldSegment(fmt.Sprintf("allOf[%d]", i))}, err.Path...),
 					Value:   err.Value,
 					Message: err.Message,
 					Code:    err.Code,
+					Params:  err.Params,
+
+					rendered: err.rendered,
 				}
 				allErrors = append(allErrors, contextualErr)
 			}
@@ -214,34 +352,41 @@ func (s *AllOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		}
 
 		// Return the main error plus all schema-specific errors
-		mainError := NewPrimitiveError(value, message, "allof_not_all_match")
+		mainError := NewPrimitiveError(value, message, CodeAllOfNotAllMatch)
 		allErrorsList := append([]ValidationError{mainError}, errors...)
 		allErrorsList = append(allErrorsList, allErrors...)
 
 		return ParseResult{
-			Valid:  false,
-			Value:  nil,
-			Errors: allErrorsList,
+			Valid:    false,
+			Value:    nil,
+			Errors:   allErrorsList,
+			Warnings: warnings,
 		}
 	}
 
 	// All schemas matched
 	return ParseResult{
-		Valid:  true,
-		Value:  finalValue,
-		Errors: nil,
+		Valid:    true,
+		Value:    finalValue,
+		Errors:   nil,
+		Warnings: warnings,
 	}
 }
 
 // JSON generates JSON Schema representation
 func (s *AllOfSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *AllOfSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	schema := make(map[string]interface{})
 
 	// Generate allOf array with all schemas
 	allOfSchemas := make([]interface{}, len(s.schemas))
 	for i, subSchema := range s.schemas {
-		if jsonSchema, ok := subSchema.(interface{ JSON() map[string]interface{} }); ok {
-			allOfSchemas[i] = jsonSchema.JSON()
+		if subJSON, ok := childJSON(subSchema, g); ok {
+			allOfSchemas[i] = subJSON
 		} else {
 			// Fallback for schemas that don't implement JSON method
 			allOfSchemas[i] = map[string]interface{}{"type": "unknown"}
@@ -252,7 +397,8 @@ func (s *AllOfSchema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 
 	// Add nullable if true
@@ -37,40 +37,123 @@ func Any(errorMessage ...interface{}) *AnySchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *AnySchema) Clone() *AnySchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *AnySchema) Freeze() *AnySchema {
+	s.freeze()
+	return s
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *AnySchema) Title(title string) *AnySchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *AnySchema) Description(description string) *AnySchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *AnySchema) ReadOnly() *AnySchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *AnySchema) WriteOnly() *AnySchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *AnySchema) Sensitive() *AnySchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *AnySchema) Deprecated(reason string) *AnySchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *AnySchema) Meta(key string, value interface{}) *AnySchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *AnySchema) AsWarning(codes ...ErrorCode) *AnySchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *AnySchema) AsInfo(codes ...ErrorCode) *AnySchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *AnySchema) Default(value interface{}) *AnySchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *AnySchema) DefaultFunc(fn func() interface{}) *AnySchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *AnySchema) Example(example interface{}) *AnySchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 // Enum sets the allowed enum values (any types allowed)
 func (s *AnySchema) Enum(values []interface{}) *AnySchema {
+	s.checkMutable()
 	s.Schema.enum = values
 	return s
 }
 
 // Const sets a constant value
 func (s *AnySchema) Const(value interface{}) *AnySchema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	return s
 }
@@ -79,12 +162,14 @@ func (s *AnySchema) Const(value interface{}) *AnySchema {
 
 // Optional marks the schema as optional
 func (s *AnySchema) Optional() *AnySchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *AnySchema) Required(errorMessage ...interface{}) *AnySchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -94,6 +179,7 @@ func (s *AnySchema) Required(errorMessage ...interface{}) *AnySchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *AnySchema) Nullable() *AnySchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
@@ -115,10 +201,36 @@ func (s *AnySchema) IsNullable() bool {
 	return s.nullable
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *AnySchema) Transform(fn TransformFunc) *AnySchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *AnySchema) Refine(fn RefineFunc, errorMessage ...interface{}) *AnySchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *AnySchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *AnySchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses any value, returning the final parsed value
 func (s *AnySchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the any constraints; Parse runs the refine/transform pipeline on top
+func (s *AnySchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
 	// Handle nil values
@@ -135,7 +247,7 @@ func (s *AnySchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := anyRequiredError(ctx.Locale)
@@ -145,7 +257,7 @@ func (s *AnySchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 	}
@@ -164,14 +276,14 @@ func (s *AnySchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 		}
 		if !valid {
 			message := anyEnumError(ctx.Locale)
-			errors = append(errors, NewPrimitiveError(value, message, "enum"))
+			errors = append(errors, NewPrimitiveError(value, message, CodeEnum))
 		}
 	}
 
 	// Check const constraint if present
 	if s.Schema.constVal != nil && s.Schema.constVal != value {
 		message := anyConstError(ctx.Locale)
-		errors = append(errors, NewPrimitiveError(value, message, "const"))
+		errors = append(errors, NewPrimitiveError(value, message, CodeConst))
 	}
 
 	return ParseResult{
@@ -191,7 +303,8 @@ func (s *AnySchema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
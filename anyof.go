@@ -3,6 +3,7 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/nyxstack/i18n"
 )
@@ -37,28 +38,125 @@ func AnyOf(schemas ...Parseable) *AnyOfSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *AnyOfSchema) Clone() *AnyOfSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.schemas = slices.Clone(s.schemas)
+	return &c
+}
+
+// Freeze makes the schema and its schemas immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *AnyOfSchema) Freeze() *AnyOfSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and its schemas
+func (s *AnyOfSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.schemas...)
+}
+
+// Children returns the schemas of the anyOf, which apply to the value itself
+func (s *AnyOfSchema) Children() []SchemaChild {
+	return sameValueChildren(s.schemas...)
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *AnyOfSchema) Title(title string) *AnyOfSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *AnyOfSchema) Description(description string) *AnyOfSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *AnyOfSchema) ReadOnly() *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *AnyOfSchema) WriteOnly() *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *AnyOfSchema) Sensitive() *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *AnyOfSchema) Deprecated(reason string) *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *AnyOfSchema) Meta(key string, value interface{}) *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *AnyOfSchema) AsWarning(codes ...ErrorCode) *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *AnyOfSchema) AsInfo(codes ...ErrorCode) *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *AnyOfSchema) Default(value interface{}) *AnyOfSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *AnyOfSchema) DefaultFunc(fn func() interface{}) *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *AnyOfSchema) Example(example interface{}) *AnyOfSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
@@ -67,6 +165,7 @@ func (s *AnyOfSchema) Example(example interface{}) *AnyOfSchema {
 
 // Add appends additional schemas to the anyof
 func (s *AnyOfSchema) Add(schemas ...Parseable) *AnyOfSchema {
+	s.checkMutable()
 	s.schemas = append(s.schemas, schemas...)
 	return s
 }
@@ -80,12 +179,14 @@ func (s *AnyOfSchema) Schemas() []Parseable {
 
 // Optional marks the schema as optional
 func (s *AnyOfSchema) Optional() *AnyOfSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *AnyOfSchema) Required(errorMessage ...interface{}) *AnyOfSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -95,6 +196,7 @@ func (s *AnyOfSchema) Required(errorMessage ...interface{}) *AnyOfSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *AnyOfSchema) Nullable() *AnyOfSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
@@ -103,12 +205,14 @@ func (s *AnyOfSchema) Nullable() *AnyOfSchema {
 
 // NoMatchError sets a custom error message when no schemas match
 func (s *AnyOfSchema) NoMatchError(message string) *AnyOfSchema {
+	s.checkMutable()
 	s.noMatchError = toErrorMessage(message)
 	return s
 }
 
 // TypeError sets a custom error message for type mismatch validation
 func (s *AnyOfSchema) TypeError(message string) *AnyOfSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -135,14 +239,40 @@ func (s *AnyOfSchema) GetSchemaCount() int {
 	return len(s.schemas)
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *AnyOfSchema) Transform(fn TransformFunc) *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *AnyOfSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *AnyOfSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *AnyOfSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses an anyof value, returning the final parsed value
 func (s *AnyOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the anyOf constraints; Parse runs the refine/transform pipeline on top
+func (s *AnyOfSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
-	// Handle nil values
-	if value == nil {
+	// Handle nil values, unless one of the schemas is a null schema that validates them
+	if value == nil && !hasNullSchema(s.schemas) {
 		if s.nullable {
 			// For nullable schemas, nil is a valid value
 			return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -151,7 +281,7 @@ func (s *AnyOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := anyofRequiredError(ctx.Locale)
@@ -161,12 +291,12 @@ func (s *AnyOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -185,10 +315,13 @@ func (s *AnyOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			for _, err := range result.Errors {
 				// Add context about which schema failed
 				contextualErr := ValidationError{
-					Path:    append([]string{fmt.Sprintf("anyOf[%d]", i)}, err.Path...),
+					Path:    append(Path{FieldSegment(fmt.Sprintf("anyOf[%d]", i))}, err.Path...),
 					Value:   err.Value,
 					Message: err.Message,
 					Code:    err.Code,
+					Params:  err.Params,
+
+					rendered: err.rendered,
 				}
 				allErrors = append(allErrors, contextualErr)
 			}
@@ -203,7 +336,7 @@ func (s *AnyOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			message = resolveErrorMessage(s.noMatchError, ctx)
 		}
 		// Return the original value with no match error, plus all schema errors for context
-		errors = append(errors, NewPrimitiveError(value, message, "anyof_no_match"))
+		errors = append(errors, NewPrimitiveError(value, message, CodeAnyOfNoMatch))
 		// Also include all the individual schema errors for debugging
 		errors = append(errors, allErrors...)
 		return ParseResult{
@@ -221,13 +354,18 @@ func (s *AnyOfSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 
 // JSON generates JSON Schema representation
 func (s *AnyOfSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *AnyOfSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	schema := make(map[string]interface{})
 
 	// Generate anyOf array with all schemas
 	anyOfSchemas := make([]interface{}, len(s.schemas))
 	for i, subSchema := range s.schemas {
-		if jsonSchema, ok := subSchema.(interface{ JSON() map[string]interface{} }); ok {
-			anyOfSchemas[i] = jsonSchema.JSON()
+		if subJSON, ok := childJSON(subSchema, g); ok {
+			anyOfSchemas[i] = subJSON
 		} else {
 			// Fallback for schemas that don't implement JSON method
 			anyOfSchemas[i] = map[string]interface{}{"type": "unknown"}
@@ -238,7 +376,8 @@ func (s *AnyOfSchema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 
 	// Add nullable if true
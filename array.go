@@ -2,7 +2,6 @@ package schema
 
 import (
 	"encoding/json"
-	"fmt"
 	"reflect"
 
 	"github.com/nyxstack/i18n"
@@ -61,28 +60,127 @@ func Array(itemSchema Parseable, errorMessage ...interface{}) *ArraySchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *ArraySchema) Clone() *ArraySchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema and its item schema immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *ArraySchema) Freeze() *ArraySchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and its item schema
+func (s *ArraySchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.itemSchema)
+}
+
+// Children returns the item schema
+func (s *ArraySchema) Children() []SchemaChild {
+	if s.itemSchema == nil {
+		return nil
+	}
+	return []SchemaChild{{Segment: ItemsSegment, Schema: s.itemSchema}}
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *ArraySchema) Title(title string) *ArraySchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *ArraySchema) Description(description string) *ArraySchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *ArraySchema) ReadOnly() *ArraySchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *ArraySchema) WriteOnly() *ArraySchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *ArraySchema) Sensitive() *ArraySchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *ArraySchema) Deprecated(reason string) *ArraySchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *ArraySchema) Meta(key string, value interface{}) *ArraySchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *ArraySchema) AsWarning(codes ...ErrorCode) *ArraySchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *ArraySchema) AsInfo(codes ...ErrorCode) *ArraySchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *ArraySchema) Default(value interface{}) *ArraySchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *ArraySchema) DefaultFunc(fn func() interface{}) *ArraySchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *ArraySchema) Example(example []interface{}) *ArraySchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
@@ -91,12 +189,14 @@ func (s *ArraySchema) Example(example []interface{}) *ArraySchema {
 
 // Items sets the schema for array items
 func (s *ArraySchema) Items(itemSchema Parseable) *ArraySchema {
+	s.checkMutable()
 	s.itemSchema = itemSchema
 	return s
 }
 
 // MinItems sets the minimum number of items with optional custom error message
 func (s *ArraySchema) MinItems(min int, errorMessage ...interface{}) *ArraySchema {
+	s.checkMutable()
 	s.minItems = &min
 	if len(errorMessage) > 0 {
 		s.minItemsError = toErrorMessage(errorMessage[0])
@@ -106,6 +206,7 @@ func (s *ArraySchema) MinItems(min int, errorMessage ...interface{}) *ArraySchem
 
 // MaxItems sets the maximum number of items with optional custom error message
 func (s *ArraySchema) MaxItems(max int, errorMessage ...interface{}) *ArraySchema {
+	s.checkMutable()
 	s.maxItems = &max
 	if len(errorMessage) > 0 {
 		s.maxItemsError = toErrorMessage(errorMessage[0])
@@ -115,6 +216,7 @@ func (s *ArraySchema) MaxItems(max int, errorMessage ...interface{}) *ArraySchem
 
 // Length sets both min and max items to the same value
 func (s *ArraySchema) Length(length int) *ArraySchema {
+	s.checkMutable()
 	s.minItems = &length
 	s.maxItems = &length
 	return s
@@ -122,6 +224,7 @@ func (s *ArraySchema) Length(length int) *ArraySchema {
 
 // UniqueItems requires all items to be unique with optional custom error message
 func (s *ArraySchema) UniqueItems(errorMessage ...interface{}) *ArraySchema {
+	s.checkMutable()
 	s.uniqueItems = true
 	if len(errorMessage) > 0 {
 		s.uniqueItemsError = toErrorMessage(errorMessage[0])
@@ -133,12 +236,14 @@ func (s *ArraySchema) UniqueItems(errorMessage ...interface{}) *ArraySchema {
 
 // Optional marks the schema as optional
 func (s *ArraySchema) Optional() *ArraySchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *ArraySchema) Required(errorMessage ...interface{}) *ArraySchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -148,18 +253,21 @@ func (s *ArraySchema) Required(errorMessage ...interface{}) *ArraySchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *ArraySchema) Nullable() *ArraySchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
 // TypeError sets a custom error message for type mismatch validation
 func (s *ArraySchema) TypeError(message string) *ArraySchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
 
 // ItemError sets a custom error message for item validation failures
 func (s *ArraySchema) ItemError(message string) *ArraySchema {
+	s.checkMutable()
 	s.itemError = toErrorMessage(message)
 	return s
 }
@@ -232,10 +340,36 @@ func getComparableKey(item interface{}) interface{} {
 	}
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *ArraySchema) Transform(fn TransformFunc) *ArraySchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *ArraySchema) Refine(fn RefineFunc, errorMessage ...interface{}) *ArraySchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *ArraySchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *ArraySchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses an array value, returning the final parsed value
 func (s *ArraySchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the array constraints; Parse runs the refine/transform pipeline on top
+func (s *ArraySchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
 	// Handle nil values
@@ -248,7 +382,7 @@ func (s *ArraySchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := arrayRequiredError(ctx.Locale)
@@ -258,12 +392,12 @@ func (s *ArraySchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -280,18 +414,37 @@ func (s *ArraySchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
-	// Convert to []interface{}
-	arrayValue = make([]interface{}, v.Len())
-	for i := 0; i < v.Len(); i++ {
-		arrayValue[i] = v.Index(i).Interface()
+	// Reject oversized and too deeply nested input before looking at its items
+	if err, exceeded := ctx.checkCollectionSize(value, v.Len()); exceeded {
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{err}}
+	}
+	child, ok := ctx.descend(value)
+	if !ok {
+		return ctx.depthExceeded(value)
+	}
+	defer child.release()
+
+	// Convert to []interface{}; decoded JSON needs no conversion since it is only read
+	if items, ok := value.([]interface{}); ok {
+		arrayValue = items
+	} else {
+		arrayValue = make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			arrayValue[i] = v.Index(i).Interface()
+		}
 	}
 
-	// Now validate the array against all constraints
-	finalValue := make([]interface{}, len(arrayValue)) // This will be our parsed array
+	// Now validate the array against all constraints, building the parsed array
+	// unless only validity is needed
+	keepValue := ctx.keepValues()
+	var finalValue []interface{}
+	if keepValue {
+		finalValue = make([]interface{}, len(arrayValue))
+	}
 
 	// Validate length constraints
 	length := len(arrayValue)
@@ -300,7 +453,7 @@ func (s *ArraySchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.minItemsError) {
 			message = resolveErrorMessage(s.minItemsError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(arrayValue, message, "min_items"))
+		errors = append(errors, NewPrimitiveError(arrayValue, message, CodeMinItems))
 	}
 
 	if s.maxItems != nil && length > *s.maxItems {
@@ -308,13 +461,22 @@ func (s *ArraySchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.maxItemsError) {
 			message = resolveErrorMessage(s.maxItemsError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(arrayValue, message, "max_items"))
+		errors = append(errors, NewPrimitiveError(arrayValue, message, CodeMaxItems))
 	}
 
-	// Validate each item using the item schema
+	// Validate each item using the item schema, collecting the warnings of the items
+	var warnings []ValidationError
 	for i, item := range arrayValue {
+		if ctx.stopCollecting(len(errors)) {
+			break
+		}
+		if err, exceeded := ctx.checkStringLength(item, IndexSegment(i)); exceeded {
+			errors = append(errors, err)
+			continue
+		}
 		if s.itemSchema != nil {
-			itemResult := s.itemSchema.Parse(item, ctx)
+			itemResult := s.itemSchema.Parse(item, child.at(IndexSegment(i)))
+			warnings = nestedWarnings(warnings, IndexSegment(i), itemResult)
 			if !itemResult.Valid {
 				// Create error for this item
 				message := arrayItemError(i)(ctx.Locale)
@@ -322,17 +484,17 @@ func (s *ArraySchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 					message = resolveErrorMessage(s.itemError, ctx)
 				}
 				// Add the main item error
-				errors = append(errors, NewFieldError([]string{fmt.Sprintf("[%d]", i)}, item, message, "item_invalid"))
+				errors = append(errors, NewFieldError(Path{IndexSegment(i)}, item, message, CodeItemInvalid))
 				// Also add the specific validation errors for this item
 				for _, itemErr := range itemResult.Errors {
 					// Prefix the path with array index
-					errors = append(errors, NewFieldError(append([]string{fmt.Sprintf("[%d]", i)}, itemErr.Path...), itemErr.Value, itemErr.Message, itemErr.Code))
+					errors = append(errors, nestedError(append(Path{IndexSegment(i)}, itemErr.Path...), itemErr))
 				}
-			} else {
+			} else if keepValue {
 				// Use the parsed value from item validation
 				finalValue[i] = itemResult.Value
 			}
-		} else {
+		} else if keepValue {
 			// No item schema, use original value
 			finalValue[i] = item
 		}
@@ -344,32 +506,39 @@ func (s *ArraySchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.uniqueItemsError) {
 			message = resolveErrorMessage(s.uniqueItemsError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(arrayValue, message, "unique_items"))
+		errors = append(errors, NewPrimitiveError(arrayValue, message, CodeUniqueItems))
 	}
 
 	return ParseResult{
-		Valid:  len(errors) == 0,
-		Value:  finalValue,
-		Errors: errors,
+		Valid:    len(errors) == 0,
+		Value:    finalValue,
+		Errors:   errors,
+		Warnings: warnings,
 	}
 }
 
 // JSON generates JSON Schema representation
 func (s *ArraySchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *ArraySchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	schema := baseJSONSchema("array")
 
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
 
 	// Add array-specific fields
 	if s.itemSchema != nil {
-		if jsonSchema, ok := s.itemSchema.(interface{ JSON() map[string]interface{} }); ok {
-			schema["items"] = jsonSchema.JSON()
+		if itemJSON, ok := childJSON(s.itemSchema, g); ok {
+			schema["items"] = itemJSON
 		}
 	}
 
@@ -393,6 +562,12 @@ func (s *ArraySchema) JSON() map[string]interface{} {
 	return schema
 }
 
+// JSONWithRefs generates JSON Schema in which sub-schemas registered in registry are
+// emitted once under $defs and referenced with $ref instead of being inlined
+func (s *ArraySchema) JSONWithRefs(registry *SchemaRegistry) map[string]interface{} {
+	return jsonWithRefs(s.JSON(), registry)
+}
+
 // MarshalJSON implements json.Marshaler to properly serialize ArraySchema for JSON schema generation
 func (s *ArraySchema) MarshalJSON() ([]byte, error) {
 	type jsonArraySchema struct {
@@ -505,3 +505,50 @@ func TestArraySchema_ErrorMessages(t *testing.T) {
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+// Test FailFast and MaxErrors
+func TestArraySchema_ErrorLimits(t *testing.T) {
+	items := make([]interface{}, 100)
+	for i := range items {
+		items[i] = map[string]interface{}{"id": "not a number"}
+	}
+
+	calls := 0
+	counted := Int().Refine(func(v interface{}) bool { calls++; return true })
+	s := Array(Object().Property("id", counted))
+
+	tests := []struct {
+		name      string
+		ctx       *ValidationContext
+		maxErrors int
+	}{
+		{"collect all", DefaultValidationContext(), 300},
+		{"fail fast", DefaultValidationContext().WithFailFast(), 4},
+		{"max errors", DefaultValidationContext().WithMaxErrors(10), 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Parse(items, tt.ctx)
+			if result.Valid {
+				t.Fatal("expected invalid result")
+			}
+			if len(result.Errors) > tt.maxErrors {
+				t.Errorf("got %d errors, want at most %d", len(result.Errors), tt.maxErrors)
+			}
+		})
+	}
+
+	result := s.Parse(items, DefaultValidationContext().WithFailFast())
+	for _, err := range result.Errors {
+		if len(err.Path) == 0 || err.Path[0] != IndexSegment(0) {
+			t.Errorf("fail fast should only report the first item, got %s", err.Path)
+		}
+	}
+	if last := result.Errors[len(result.Errors)-1]; last.Code != "invalid_type" {
+		t.Errorf("fail fast should keep the nested cause, got %q", last.Code)
+	}
+	if calls != 0 {
+		t.Errorf("item schemas ran %d refinements for invalid values", calls)
+	}
+}
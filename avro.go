@@ -0,0 +1,302 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// defaultDecimalPrecision is the number of digits of decimals whose magnitude is not
+// bounded by a minimum and a maximum
+const defaultDecimalPrecision = 38
+
+// avroName matches the names Avro accepts for records, enums, fields and symbols
+var avroName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ExportAvro generates an Avro schema from an object schema so validated models can
+// be written to Avro pipelines without a second schema definition. The record is
+// named after the title of s ("Record" without one):
+//
+//   - objects become records, with their fields in property name order, and nested
+//     records named after their parent and property (UserAddress)
+//   - arrays become arrays and records/maps become maps
+//   - unions (OneOf/AnyOf) become Avro unions
+//   - optional and nullable properties become unions with "null", which come first
+//     and default to null unless the schema has a default
+//   - string enums become enums, binary strings bytes
+//   - date, date-time, time and uuid formats use the date, timestamp-millis,
+//     time-millis and uuid logical types
+//   - numbers limited to a number of decimal places with Precision use the decimal
+//     logical type, with that scale and a precision covering their minimum and
+//     maximum (38 digits when unbounded)
+//
+// Constructs that Avro cannot express, such as tuples, schemas accepting any value and
+// names that are not valid Avro names, are reported as errors.
+func ExportAvro(s Parseable) ([]byte, error) {
+	doc, err := jsonDocument(s)
+	if err != nil {
+		return nil, err
+	}
+	if !jsonHasType(doc, "object") {
+		return nil, fmt.Errorf("only object schemas can be exported as Avro records")
+	}
+
+	w := &avroWriter{decimals: map[string]int{}}
+	Walk(s, func(path []string, s Parseable) bool {
+		if number, ok := s.(*NumberSchema); ok && number.GetPrecision() != nil {
+			w.decimals[strings.Join(path, "/")] = *number.GetPrecision()
+		}
+		return true
+	})
+
+	name := "Record"
+	if title, ok := doc["title"].(string); ok && title != "" {
+		name = protoTypeName(title)
+	}
+	record, err := w.record(name, doc, nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(record, "", "  ")
+}
+
+// avroWriter converts JSON Schema documents to Avro types
+type avroWriter struct {
+	decimals map[string]int // Decimal places of Precision-limited numbers, by Walk path
+}
+
+// record converts an object document to an Avro record. path is the Walk path of the
+// object, used to find the decimals of its number properties.
+func (w *avroWriter) record(name string, doc map[string]interface{}, path []string) (map[string]interface{}, error) {
+	if !avroName.MatchString(name) {
+		return nil, fmt.Errorf("%q is not a valid Avro name", name)
+	}
+	properties, _ := doc["properties"].(map[string]interface{})
+	required := map[string]bool{}
+	for _, r := range jsonStrings(doc["required"]) {
+		required[r] = true
+	}
+
+	fields := make([]interface{}, 0, len(properties))
+	for _, prop := range sortedMapKeys(properties) {
+		if !avroName.MatchString(prop) {
+			return nil, fmt.Errorf("%s: property %q is not a valid Avro name", name, prop)
+		}
+		propDoc, _ := properties[prop].(map[string]interface{})
+		fieldType, err := w.fieldType(name+protoTypeName(prop), propDoc, append(path[:len(path):len(path)], prop))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", name, prop, err)
+		}
+
+		field := map[string]interface{}{"name": prop}
+		defaultValue, hasDefault := propDoc["default"]
+		if (!required[prop] || jsonNullable(propDoc)) && fieldType != "null" {
+			// The default of a union must match its first type
+			if hasDefault && defaultValue != nil {
+				fieldType = avroUnion(fieldType, "null")
+			} else {
+				fieldType = avroUnion("null", fieldType)
+				defaultValue, hasDefault = nil, true
+			}
+		}
+		field["type"] = fieldType
+		if hasDefault {
+			field["default"] = defaultValue
+		}
+		if description, ok := propDoc["description"].(string); ok && description != "" {
+			field["doc"] = description
+		}
+		fields = append(fields, field)
+	}
+
+	record := map[string]interface{}{"type": "record", "name": name, "fields": fields}
+	if description, ok := doc["description"].(string); ok && description != "" {
+		record["doc"] = description
+	}
+	return record, nil
+}
+
+// fieldType returns the Avro type of a document, without the "null" of nullable
+// documents. name is used for the records and enums it declares.
+func (w *avroWriter) fieldType(name string, doc map[string]interface{}, path []string) (interface{}, error) {
+	if _, ok := doc["$ref"]; ok {
+		return nil, fmt.Errorf("references cannot be expressed in Avro")
+	}
+	if members := jsonUnionMembers(doc); members != nil {
+		types := make([]interface{}, 0, len(members))
+		for i, member := range members {
+			memberType, err := w.fieldType(name+"Option"+strconv.Itoa(i+1), member, path)
+			if err != nil {
+				return nil, err
+			}
+			types = append(types, memberType)
+		}
+		union := avroUnion(types...)
+		if err := checkAvroUnion(union); err != nil {
+			return nil, err
+		}
+		return union, nil
+	}
+
+	switch {
+	case jsonHasType(doc, "object"):
+		properties, _ := doc["properties"].(map[string]interface{})
+		if values, ok := doc["additionalProperties"].(map[string]interface{}); ok && len(properties) == 0 {
+			valueType, err := w.fieldType(name+"Value", values, append(path[:len(path):len(path)], ValuesSegment))
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"type": "map", "values": valueType}, nil
+		}
+		return w.record(name, doc, path)
+
+	case jsonHasType(doc, "array"):
+		items, ok := doc["items"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("tuples cannot be expressed in Avro")
+		}
+		itemType, err := w.fieldType(name+"Item", items, append(path[:len(path):len(path)], ItemsSegment))
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": itemType}, nil
+
+	case doc["enum"] != nil && jsonHasType(doc, "string"):
+		symbols := make([]string, 0)
+		for _, value := range doc["enum"].([]interface{}) {
+			symbol, ok := value.(string)
+			if !ok {
+				continue
+			}
+			if !avroName.MatchString(symbol) {
+				return nil, fmt.Errorf("enum value %q is not a valid Avro symbol", symbol)
+			}
+			symbols = append(symbols, symbol)
+		}
+		return map[string]interface{}{"type": "enum", "name": name, "symbols": symbols}, nil
+
+	case jsonHasType(doc, "string"):
+		if doc["contentEncoding"] != nil {
+			return "bytes", nil
+		}
+		switch doc["format"] {
+		case "date":
+			return map[string]interface{}{"type": "int", "logicalType": "date"}, nil
+		case "date-time":
+			return map[string]interface{}{"type": "long", "logicalType": "timestamp-millis"}, nil
+		case "time":
+			return map[string]interface{}{"type": "int", "logicalType": "time-millis"}, nil
+		case "uuid":
+			return map[string]interface{}{"type": "string", "logicalType": "uuid"}, nil
+		}
+		return "string", nil
+
+	case jsonHasType(doc, "integer"):
+		switch doc["format"] {
+		case "int8", "int16", "int32", "uint8", "uint16":
+			return "int", nil
+		}
+		return "long", nil
+
+	case jsonHasType(doc, "number"):
+		if scale, ok := w.decimals[strings.Join(path, "/")]; ok {
+			return map[string]interface{}{
+				"type":        "bytes",
+				"logicalType": "decimal",
+				"precision":   decimalPrecision(doc, scale),
+				"scale":       scale,
+			}, nil
+		}
+		if doc["format"] == "float" {
+			return "float", nil
+		}
+		return "double", nil
+
+	case jsonHasType(doc, "boolean"):
+		return "boolean", nil
+
+	case jsonHasType(doc, "null"):
+		return "null", nil
+	}
+	return nil, fmt.Errorf("type %v cannot be expressed in Avro", doc["type"])
+}
+
+// jsonNullable reports whether a document accepts null
+func jsonNullable(doc map[string]interface{}) bool {
+	if jsonHasType(doc, "null") {
+		return true
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		members, _ := doc[key].([]interface{})
+		for _, member := range members {
+			if m, ok := member.(map[string]interface{}); ok && m["type"] == "null" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// avroUnion builds a union of types, flattening nested unions since Avro unions
+// cannot contain unions
+func avroUnion(types ...interface{}) []interface{} {
+	union := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		if members, ok := t.([]interface{}); ok {
+			union = append(union, members...)
+		} else {
+			union = append(union, t)
+		}
+	}
+	return union
+}
+
+// checkAvroUnion rejects unions with two members of the same unnamed type, which
+// Avro cannot tell apart
+func checkAvroUnion(union []interface{}) error {
+	seen := map[string]bool{}
+	for _, member := range union {
+		key := ""
+		switch m := member.(type) {
+		case string:
+			key = m
+		case map[string]interface{}:
+			if name, ok := m["name"].(string); ok {
+				key = "name:" + name
+			} else {
+				key, _ = m["type"].(string)
+			}
+		}
+		if seen[key] {
+			return fmt.Errorf("union has several %s members, which Avro cannot tell apart", strings.TrimPrefix(key, "name:"))
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// decimalPrecision returns the number of digits of a decimal with scale decimal places,
+// enough for the values between the lower and upper bounds of doc
+func decimalPrecision(doc map[string]interface{}, scale int) int {
+	bound := func(keys ...string) (float64, bool) {
+		for _, key := range keys {
+			if value, ok := doc[key].(float64); ok {
+				return math.Abs(value), true
+			}
+		}
+		return 0, false
+	}
+	lower, hasLower := bound("minimum", "exclusiveMinimum")
+	upper, hasUpper := bound("maximum", "exclusiveMaximum")
+	if !hasLower || !hasUpper {
+		return defaultDecimalPrecision
+	}
+	digits := 1
+	if magnitude := math.Max(lower, upper); magnitude >= 1 {
+		digits = int(math.Floor(math.Log10(magnitude))) + 1
+	}
+	return digits + scale
+}
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExportAvro(t *testing.T) {
+	address := Object().
+		Property("city", String()).
+		Property("zip", String().Optional())
+	order := Object().
+		Title("order").
+		Description("A customer order").
+		Property("id", UUID()).
+		Property("quantity", Int32().Default(1).Optional()).
+		Property("total", Number().Min(0).Max(99999).Precision(2)).
+		Property("ratio", Number().Optional()).
+		Property("placed_at", DateTime()).
+		Property("due", Date().Nullable()).
+		Property("status", Enum("OPEN", "SHIPPED")).
+		Property("tags", Array(String()).Description("Free-form labels")).
+		Property("prices", Record(String(), Number().Precision(4))).
+		Property("address", address).
+		Property("reference", OneOf(String(), Int()).Optional()).
+		Property("attachment", Binary())
+
+	out, err := ExportAvro(order)
+	if err != nil {
+		t.Fatalf("ExportAvro() error = %v", err)
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal(out, &record); err != nil {
+		t.Fatal(err)
+	}
+	if record["type"] != "record" || record["name"] != "Order" || record["doc"] != "A customer order" {
+		t.Errorf("record = %v", record)
+	}
+
+	fields := map[string]string{}
+	var names []string
+	for _, raw := range record["fields"].([]interface{}) {
+		field := raw.(map[string]interface{})
+		name := field["name"].(string)
+		names = append(names, name)
+		delete(field, "name")
+		data, _ := json.Marshal(field)
+		fields[name] = string(data)
+	}
+	if got := strings.Join(names, ","); got != "address,attachment,due,id,placed_at,prices,quantity,ratio,reference,status,tags,total" {
+		t.Errorf("field order = %s", got)
+	}
+
+	want := map[string]string{
+		"address":    `{"type":{"fields":[{"name":"city","type":"string"},{"default":null,"name":"zip","type":["null","string"]}],"name":"OrderAddress","type":"record"}}`,
+		"attachment": `{"default":null,"type":["null","bytes"]}`,
+		"due":        `{"default":null,"type":["null",{"logicalType":"date","type":"int"}]}`,
+		"id":         `{"type":{"logicalType":"uuid","type":"string"}}`,
+		"placed_at":  `{"type":{"logicalType":"timestamp-millis","type":"long"}}`,
+		"prices":     `{"type":{"type":"map","values":{"logicalType":"decimal","precision":38,"scale":4,"type":"bytes"}}}`,
+		"quantity":   `{"default":1,"type":["int","null"]}`,
+		"ratio":      `{"default":null,"type":["null","double"]}`,
+		"reference":  `{"default":null,"type":["null","string","long"]}`,
+		"status":     `{"type":{"name":"OrderStatus","symbols":["OPEN","SHIPPED"],"type":"enum"}}`,
+		"tags":       `{"doc":"Free-form labels","type":{"items":"string","type":"array"}}`,
+		"total":      `{"type":{"logicalType":"decimal","precision":7,"scale":2,"type":"bytes"}}`,
+	}
+	for name, expected := range want {
+		if fields[name] != expected {
+			t.Errorf("field %s = %s, want %s", name, fields[name], expected)
+		}
+	}
+}
+
+func TestExportAvro_Unsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema Parseable
+		want   string
+	}{
+		{"top-level string", String(), "only object schemas"},
+		{"tuple", Object().Property("point", Tuple(Number(), Number())), "Record.point: tuples cannot be expressed in Avro"},
+		{"any", Object().Property("extra", Any()), "cannot be expressed in Avro"},
+		{"invalid property name", Object().Property("zip-code", String()), `property "zip-code" is not a valid Avro name`},
+		{"invalid symbol", Object().Property("size", Enum("x-large")), `enum value "x-large" is not a valid Avro symbol`},
+		{"ambiguous union", Object().Property("id", OneOf(Int(), Int64())), "union has several long members"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExportAvro(tt.schema)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ExportAvro() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
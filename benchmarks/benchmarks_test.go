@@ -0,0 +1,381 @@
+package benchmarks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+// benchmarkParse reports the time and allocations of parsing a valid value with s
+func benchmarkParse(b *testing.B, s schema.Parseable, value interface{}) {
+	b.Helper()
+	ctx := schema.DefaultValidationContext()
+	if result := s.Parse(value, ctx); !result.Valid {
+		b.Fatal(result.Errors)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Parse(value, ctx)
+	}
+}
+
+// benchmarkCorpus reports the parsing of a corpus
+func benchmarkCorpus(b *testing.B, corpus Corpus) {
+	b.Helper()
+	benchmarkParse(b, corpus.Schema, corpus.Value)
+}
+
+// Corpora
+
+func BenchmarkParseDeepObject(b *testing.B) {
+	for _, depth := range []int{4, 16, 64} {
+		corpus := DeepObject(depth)
+		b.Run(strconv.Itoa(depth), func(b *testing.B) { benchmarkCorpus(b, corpus) })
+	}
+}
+
+func BenchmarkParseLargeArray(b *testing.B) {
+	for _, n := range []int{10, 1000} {
+		corpus := LargeArray(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) { benchmarkCorpus(b, corpus) })
+	}
+}
+
+func BenchmarkParseStringHeavy(b *testing.B) {
+	for _, n := range []int{1, 20} {
+		corpus := StringHeavy(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) { benchmarkCorpus(b, corpus) })
+	}
+}
+
+func BenchmarkParseLargeArrayInvalid(b *testing.B) {
+	corpus := LargeArray(100)
+	for _, order := range corpus.Value.([]interface{}) {
+		order.(map[string]interface{})["paid"] = "yes"
+	}
+	ctx := schema.DefaultValidationContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if corpus.Schema.Parse(corpus.Value, ctx).Valid {
+			b.Fatal("orders should be invalid")
+		}
+	}
+}
+
+func BenchmarkParseDeepInvalid(b *testing.B) {
+	var node *schema.ArraySchema
+	node = schema.Array(schema.Lazy(func() schema.Parseable { return node }))
+	var value interface{} = "leaf"
+	for i := 0; i < 4000; i++ {
+		value = []interface{}{value}
+	}
+	ctx := schema.DefaultValidationContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if node.Parse(value, ctx).Valid {
+			b.Fatal("input deeper than MaxDepth should be invalid")
+		}
+	}
+}
+
+// Primitive types
+
+func BenchmarkParseString(b *testing.B) {
+	benchmarkParse(b, schema.String().MinLength(1).MaxLength(64), "Ada Lovelace")
+}
+
+func BenchmarkParseInt(b *testing.B) {
+	benchmarkParse(b, schema.Int().Min(0).Max(100), float64(42))
+}
+
+func BenchmarkParseInt8(b *testing.B) {
+	benchmarkParse(b, schema.Int8(), float64(-42))
+}
+
+func BenchmarkParseInt16(b *testing.B) {
+	benchmarkParse(b, schema.Int16(), float64(-4200))
+}
+
+func BenchmarkParseInt32(b *testing.B) {
+	benchmarkParse(b, schema.Int32(), float64(-420000))
+}
+
+func BenchmarkParseInt64(b *testing.B) {
+	benchmarkParse(b, schema.Int64(), float64(-42000000000))
+}
+
+func BenchmarkParseUint(b *testing.B) {
+	benchmarkParse(b, schema.Uint(), float64(42))
+}
+
+func BenchmarkParseUint8(b *testing.B) {
+	benchmarkParse(b, schema.Uint8(), float64(42))
+}
+
+func BenchmarkParseUint16(b *testing.B) {
+	benchmarkParse(b, schema.Uint16(), float64(4200))
+}
+
+func BenchmarkParseUint32(b *testing.B) {
+	benchmarkParse(b, schema.Uint32(), float64(420000))
+}
+
+func BenchmarkParseUint64(b *testing.B) {
+	benchmarkParse(b, schema.Uint64(), float64(42000000000))
+}
+
+func BenchmarkParseNumber(b *testing.B) {
+	benchmarkParse(b, schema.Number().Min(0).Max(10), 3.14)
+}
+
+func BenchmarkParseFloat(b *testing.B) {
+	benchmarkParse(b, schema.Float(), 3.14)
+}
+
+func BenchmarkParseBool(b *testing.B) {
+	benchmarkParse(b, schema.Bool(), true)
+}
+
+func BenchmarkParseNull(b *testing.B) {
+	benchmarkParse(b, schema.Null(), nil)
+}
+
+func BenchmarkParseAny(b *testing.B) {
+	benchmarkParse(b, schema.Any(), "anything")
+}
+
+func BenchmarkParseUnknown(b *testing.B) {
+	benchmarkParse(b, schema.Unknown(), "anything")
+}
+
+func BenchmarkParseLiteral(b *testing.B) {
+	benchmarkParse(b, schema.Literal("circle"), "circle")
+}
+
+func BenchmarkParseEnum(b *testing.B) {
+	benchmarkParse(b, schema.Enum("red", "green", "blue"), "blue")
+}
+
+// Collections
+
+func BenchmarkParseArray(b *testing.B) {
+	items := make([]interface{}, 100)
+	for i := range items {
+		items[i] = float64(i)
+	}
+	benchmarkParse(b, schema.Array(schema.Int().Min(0)).MaxItems(100), items)
+}
+
+func BenchmarkParseObject(b *testing.B) {
+	user := schema.Object().
+		Property("name", schema.String().MinLength(1)).
+		Property("age", schema.Int().Min(0)).
+		Property("admin", schema.Bool().Optional())
+	benchmarkParse(b, user, map[string]interface{}{"name": "Ada", "age": float64(36)})
+}
+
+func BenchmarkParseRecord(b *testing.B) {
+	scores := make(map[string]interface{}, 20)
+	for i := 0; i < 20; i++ {
+		scores["player"+strconv.Itoa(i)] = float64(i)
+	}
+	benchmarkParse(b, schema.Record(schema.String().MinLength(1), schema.Int().Min(0)), scores)
+}
+
+func BenchmarkParseMap(b *testing.B) {
+	scores := make(map[string]int, 20)
+	for i := 0; i < 20; i++ {
+		scores["player"+strconv.Itoa(i)] = i
+	}
+	benchmarkParse(b, schema.Map(schema.String().MinLength(1), schema.Int().Min(0)), scores)
+}
+
+func BenchmarkParseTuple(b *testing.B) {
+	benchmarkParse(b, schema.Tuple(schema.String(), schema.Int(), schema.Bool()), []interface{}{"a", float64(1), true})
+}
+
+// Composition
+
+func BenchmarkParseUnion(b *testing.B) {
+	benchmarkParse(b, schema.Union(schema.String(), schema.Int(), schema.Bool()), true)
+}
+
+func BenchmarkParseAnyOf(b *testing.B) {
+	benchmarkParse(b, schema.AnyOf(schema.String().Email(), schema.String().URL()), "https://example.com")
+}
+
+func BenchmarkParseAllOf(b *testing.B) {
+	named := schema.Object().Property("name", schema.String()).Passthrough()
+	aged := schema.Object().Property("age", schema.Int()).Passthrough()
+	benchmarkParse(b, schema.AllOf(named, aged), map[string]interface{}{"name": "Ada", "age": float64(36)})
+}
+
+func BenchmarkParseNot(b *testing.B) {
+	benchmarkParse(b, schema.Not(schema.String()), float64(42))
+}
+
+func BenchmarkParseConditional(b *testing.B) {
+	s := schema.Conditional(schema.Object().Property("kind", schema.Literal("company")).Passthrough()).
+		Then(schema.Object().Property("kind", schema.String()).Property("vat", schema.String().MinLength(8))).
+		Else(schema.Object().Property("kind", schema.String()))
+	benchmarkParse(b, s, map[string]interface{}{"kind": "company", "vat": "DE123456789"})
+}
+
+func BenchmarkParseLazy(b *testing.B) {
+	var category *schema.ObjectSchema
+	category = schema.Object().
+		Property("name", schema.String()).
+		Property("children", schema.Array(schema.Lazy(func() schema.Parseable { return category })).Optional())
+	leaf := map[string]interface{}{"name": "leaf"}
+	tree := map[string]interface{}{"name": "root", "children": []interface{}{
+		map[string]interface{}{"name": "a", "children": []interface{}{leaf, leaf}},
+		map[string]interface{}{"name": "b", "children": []interface{}{leaf}},
+	}}
+	benchmarkParse(b, category, tree)
+}
+
+func BenchmarkParseRef(b *testing.B) {
+	registry := schema.NewSchemaRegistry()
+	registry.Define("Email", schema.String().Email())
+	benchmarkParse(b, schema.Object().Property("email", schema.Ref("#/Email", registry)), map[string]interface{}{"email": "ada@example.com"})
+}
+
+func BenchmarkParseTransform(b *testing.B) {
+	s := schema.Transform(schema.String().Pattern(`^[0-9]+$`), schema.Int().Min(0), func(input interface{}) (interface{}, error) {
+		return strconv.Atoi(input.(string))
+	})
+	benchmarkParse(b, s, "1042")
+}
+
+// Formats
+
+func BenchmarkParseStringEmail(b *testing.B) {
+	benchmarkParse(b, schema.String().Email(), "ada@example.com")
+}
+
+func BenchmarkParseStringPattern(b *testing.B) {
+	benchmarkParse(b, schema.String().Pattern(`^[a-z0-9]+(-[a-z0-9]+)*$`), "user-profile-42")
+}
+
+func BenchmarkParseUUID(b *testing.B) {
+	benchmarkParse(b, schema.UUID(), "123e4567-e89b-42d3-a456-426614174000")
+}
+
+func BenchmarkParseDate(b *testing.B) {
+	benchmarkParse(b, schema.Date(), "2025-03-14")
+}
+
+func BenchmarkParseDateTime(b *testing.B) {
+	benchmarkParse(b, schema.DateTime(), "2025-03-14T15:09:26Z")
+}
+
+func BenchmarkParseBinary(b *testing.B) {
+	benchmarkParse(b, schema.Base64().Required(), base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0xAB}, 256)))
+}
+
+func BenchmarkParseIP(b *testing.B) {
+	benchmarkParse(b, schema.IP(), "2001:db8::68")
+}
+
+func BenchmarkParseCIDR(b *testing.B) {
+	benchmarkParse(b, schema.CIDR(), "10.0.0.0/8")
+}
+
+func BenchmarkParseMACAddress(b *testing.B) {
+	benchmarkParse(b, schema.MACAddress(), "00:1a:2b:3c:4d:5e")
+}
+
+func BenchmarkParseURL(b *testing.B) {
+	benchmarkParse(b, schema.URL(), "https://example.com/users/42?tab=profile")
+}
+
+func BenchmarkParseEmail(b *testing.B) {
+	benchmarkParse(b, schema.Email(), "ada.lovelace@example.com")
+}
+
+func BenchmarkParsePhone(b *testing.B) {
+	benchmarkParse(b, schema.Phone(), "+14155552671")
+}
+
+func BenchmarkParseSemver(b *testing.B) {
+	benchmarkParse(b, schema.Semver(), "1.24.2-rc.1+build.5")
+}
+
+func BenchmarkParseCountryCode(b *testing.B) {
+	benchmarkParse(b, schema.CountryCode(), "NL")
+}
+
+func BenchmarkParseCurrencyCode(b *testing.B) {
+	benchmarkParse(b, schema.CurrencyCode(), "EUR")
+}
+
+func BenchmarkParseLanguageTag(b *testing.B) {
+	benchmarkParse(b, schema.LanguageTag(), "en-US")
+}
+
+func BenchmarkParseColor(b *testing.B) {
+	benchmarkParse(b, schema.Color(), "#ff8800")
+}
+
+func BenchmarkParseMIMEType(b *testing.B) {
+	benchmarkParse(b, schema.MIMEType(), "application/json; charset=utf-8")
+}
+
+func BenchmarkParseFilename(b *testing.B) {
+	benchmarkParse(b, schema.Filename(), "report-2025.pdf")
+}
+
+func BenchmarkParseFilePath(b *testing.B) {
+	benchmarkParse(b, schema.FilePath(), "docs/reports/report-2025.pdf")
+}
+
+func BenchmarkParsePassword(b *testing.B) {
+	benchmarkParse(b, schema.Password(), "correct horse battery staple 42!")
+}
+
+func BenchmarkParseJWT(b *testing.B) {
+	key := []byte("benchmark-secret")
+	encode := base64.RawURLEncoding.EncodeToString
+	unsigned := encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode([]byte(`{"sub":"42","name":"Ada"}`))
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	benchmarkParse(b, schema.JWT().Key(key), unsigned+"."+encode(mac.Sum(nil)))
+}
+
+func BenchmarkParseHeaders(b *testing.B) {
+	s := schema.Headers(schema.Shape{
+		"Authorization": schema.BearerToken(),
+		"Accept":        schema.AcceptList().Optional(),
+	})
+	header := http.Header{
+		"Authorization": {"Bearer mF_9.B5f-4.1JqM"},
+		"Accept":        {"text/html, application/*;q=0.9, */*;q=0.8"},
+	}
+	benchmarkParse(b, s, header)
+}
+
+func BenchmarkParseFile(b *testing.B) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "notes.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	part.Write(bytes.Repeat([]byte("meeting notes\n"), 100))
+	writer.Close()
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkParse(b, schema.File().MaxSize(1<<20).AllowedTypes("text/plain"), form.File["file"][0])
+}
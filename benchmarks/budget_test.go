@@ -0,0 +1,45 @@
+//go:build !race
+
+package benchmarks
+
+import (
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+// TestAllocationBudget fails when parsing a corpus allocates more than its budget.
+// Budgets are the allocations measured when they were last updated: a change that
+// raises one needs a reason, and a change that lowers one should lower the budget.
+// The race detector allocates on its own, so race builds skip this test.
+func TestAllocationBudget(t *testing.T) {
+	tests := []struct {
+		corpus Corpus
+		budget float64 // allocs/op
+	}{
+		{Corpus{"String", schema.String().MinLength(1).MaxLength(64), "Ada Lovelace"}, 0},
+		{Corpus{"Int", schema.Int().Min(0).Max(100), float64(42)}, 0},
+		{Corpus{"Bool", schema.Bool(), true}, 0},
+		{Corpus{"Enum", schema.Enum("red", "green", "blue"), "blue"}, 9},
+		{Corpus{"StringEmail", schema.String().Email(), "ada@example.com"}, 0},
+		{Corpus{"UUID", schema.UUID(), "123e4567-e89b-42d3-a456-426614174000"}, 1},
+		{DeepObject(16), 66},
+		{LargeArray(100), 1202},
+		{StringHeavy(10), 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.corpus.Name, func(t *testing.T) {
+			ctx := schema.DefaultValidationContext()
+			if result := tt.corpus.Schema.Parse(tt.corpus.Value, ctx); !result.Valid {
+				t.Fatal(result.Errors)
+			}
+			allocs := testing.AllocsPerRun(20, func() {
+				tt.corpus.Schema.Parse(tt.corpus.Value, ctx)
+			})
+			if allocs > tt.budget {
+				t.Errorf("%s allocates %v times per parse, budget is %v", tt.corpus.Name, allocs, tt.budget)
+			}
+		})
+	}
+}
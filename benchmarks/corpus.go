@@ -0,0 +1,106 @@
+// Package benchmarks holds the performance suite of the schema package: corpora of
+// realistic payloads, a BenchmarkParse function per schema type and allocation
+// budgets that fail the tests when a change makes parsing allocate more.
+//
+//	go test ./benchmarks -bench . -benchmem
+//
+// Compare runs before and after a change with benchstat. When a change lowers the
+// allocations of a budgeted case, lower its budget in the same change.
+package benchmarks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyxstack/schema"
+)
+
+// Corpus is a schema and a valid value for it, holding the types encoding/json decodes
+// documents into (map[string]interface{}, []interface{}, float64, string and bool)
+type Corpus struct {
+	Name   string
+	Schema schema.Parseable
+	Value  interface{}
+}
+
+// DeepObject builds a corpus of objects nested depth levels deep, each level holding
+// a few scalar properties, a short array and the next level
+func DeepObject(depth int) Corpus {
+	var s schema.Parseable = schema.Object().
+		Property("id", schema.String().MinLength(1)).
+		Property("leaf", schema.Bool())
+	var value interface{} = map[string]interface{}{"id": "leaf", "leaf": true}
+	for level := depth - 1; level >= 0; level-- {
+		s = schema.Object().
+			Property("id", schema.String().MinLength(1)).
+			Property("level", schema.Int().Min(0)).
+			Property("weight", schema.Number().Min(0).Max(1)).
+			Property("labels", schema.Array(schema.String()).MaxItems(8)).
+			Property("child", s)
+		value = map[string]interface{}{
+			"id":     fmt.Sprintf("node-%d", level),
+			"level":  float64(level),
+			"weight": 0.5,
+			"labels": []interface{}{"a", "b"},
+			"child":  value,
+		}
+	}
+	return Corpus{Name: fmt.Sprintf("DeepObject/%d", depth), Schema: s, Value: value}
+}
+
+// LargeArray builds a corpus of n order records, each with a nested customer, line
+// items and tags
+func LargeArray(n int) Corpus {
+	item := schema.Object().
+		Property("sku", schema.String().Pattern(`^[A-Z]-[0-9]+$`)).
+		Property("quantity", schema.Int().Min(1)).
+		Property("price", schema.Number().Min(0))
+	order := schema.Object().
+		Property("id", schema.String()).
+		Property("customer", schema.Object().
+			Property("name", schema.String().MinLength(1)).
+			Property("email", schema.String().Email())).
+		Property("items", schema.Array(item).MinItems(1)).
+		Property("tags", schema.Array(schema.String()).Optional()).
+		Property("paid", schema.Bool())
+
+	orders := make([]interface{}, n)
+	for i := range orders {
+		orders[i] = map[string]interface{}{
+			"id": fmt.Sprintf("ord-%d", i),
+			"customer": map[string]interface{}{
+				"name":  "Ada Lovelace",
+				"email": fmt.Sprintf("ada%d@example.com", i),
+			},
+			"items": []interface{}{
+				map[string]interface{}{"sku": "A-1", "quantity": float64(2), "price": 9.99},
+				map[string]interface{}{"sku": "B-22", "quantity": float64(1), "price": 24.5},
+			},
+			"tags": []interface{}{"priority", "gift"},
+			"paid": i%2 == 0,
+		}
+	}
+	return Corpus{Name: fmt.Sprintf("LargeArray/%d", n), Schema: schema.Array(order).MaxItems(n), Value: orders}
+}
+
+// StringHeavy builds a corpus of an object with n groups of string properties checked
+// by formats, patterns, length limits and transforms
+func StringHeavy(n int) Corpus {
+	s := schema.Object()
+	value := make(map[string]interface{}, 6*n)
+	for i := 0; i < n; i++ {
+		s.Property(fmt.Sprintf("email%d", i), schema.String().Email()).
+			Property(fmt.Sprintf("website%d", i), schema.String().URL()).
+			Property(fmt.Sprintf("id%d", i), schema.String().UUID()).
+			Property(fmt.Sprintf("slug%d", i), schema.String().Pattern(`^[a-z0-9]+(-[a-z0-9]+)*$`)).
+			Property(fmt.Sprintf("bio%d", i), schema.String().MaxLength(2000)).
+			Property(fmt.Sprintf("name%d", i), schema.String().Trim().MinLength(1).MaxLength(100))
+		value[fmt.Sprintf("email%d", i)] = fmt.Sprintf("user%d@example.com", i)
+		value[fmt.Sprintf("website%d", i)] = fmt.Sprintf("https://example.com/users/%d", i)
+		value[fmt.Sprintf("id%d", i)] = fmt.Sprintf("123e4567-e89b-42d3-a456-%012d", i)
+		value[fmt.Sprintf("slug%d", i)] = fmt.Sprintf("user-profile-%d", i)
+		value[fmt.Sprintf("bio%d", i)] = strings.Repeat("Lorem ipsum dolor sit amet. ", 40)
+		value[fmt.Sprintf("name%d", i)] = "  Ada Lovelace  "
+	}
+	return Corpus{Name: fmt.Sprintf("StringHeavy/%d", n), Schema: s, Value: value}
+}
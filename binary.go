@@ -56,6 +56,20 @@ func Binary() *BinarySchema {
 	}
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *BinarySchema) Clone() *BinarySchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *BinarySchema) Freeze() *BinarySchema {
+	s.freeze()
+	return s
+}
+
 // Base64 creates a new binary schema with standard base64 encoding
 func Base64() *BinarySchema {
 	return &BinarySchema{
@@ -79,24 +93,28 @@ func Hex() *BinarySchema {
 
 // Format sets the binary encoding format
 func (s *BinarySchema) Format(format BinaryFormat) *BinarySchema {
+	s.checkMutable()
 	s.format = format
 	return s
 }
 
 // MinSize sets the minimum size constraint in bytes
 func (s *BinarySchema) MinSize(min int) *BinarySchema {
+	s.checkMutable()
 	s.minSize = &min
 	return s
 }
 
 // MaxSize sets the maximum size constraint in bytes
 func (s *BinarySchema) MaxSize(max int) *BinarySchema {
+	s.checkMutable()
 	s.maxSize = &max
 	return s
 }
 
 // Size sets both minimum and maximum size constraints in bytes
 func (s *BinarySchema) Size(min, max int) *BinarySchema {
+	s.checkMutable()
 	s.minSize = &min
 	s.maxSize = &max
 	return s
@@ -104,43 +122,72 @@ func (s *BinarySchema) Size(min, max int) *BinarySchema {
 
 // FormatError sets custom error message for format validation
 func (s *BinarySchema) FormatError(err ErrorMessage) *BinarySchema {
+	s.checkMutable()
 	s.formatError = err
 	return s
 }
 
 // SizeError sets custom error message for size validation
 func (s *BinarySchema) SizeError(err ErrorMessage) *BinarySchema {
+	s.checkMutable()
 	s.sizeError = err
 	return s
 }
 
 // Required marks the binary data as required (non-empty)
 func (s *BinarySchema) Required() *BinarySchema {
+	s.checkMutable()
 	s.Schema.required = true
 	return s
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *BinarySchema) Transform(fn TransformFunc) *BinarySchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *BinarySchema) Refine(fn RefineFunc, errorMessage ...interface{}) *BinarySchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *BinarySchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *BinarySchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Parse validates binary data
 func (s *BinarySchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the binary constraints; Parse runs the refine/transform pipeline on top
+func (s *BinarySchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
 	// Convert to string
 	binaryStr, ok := value.(string)
 	if !ok {
 		message := binaryTypeError(ctx.Locale)
-		errors = append(errors, NewPrimitiveError(value, message, "invalid_type"))
+		errors = append(errors, NewPrimitiveError(value, message, CodeInvalidType))
 		return ParseResult{Valid: false, Value: value, Errors: errors}
 	}
 
 	// Required validation
-	if s.Schema.required && binaryStr == "" {
+	if s.Schema.required && binaryStr == "" && !ctx.EmptyIsPresent {
 		message := binaryRequiredError(ctx.Locale)
-		errors = append(errors, NewPrimitiveError(binaryStr, message, "required"))
+		errors = append(errors, NewPrimitiveError(binaryStr, message, CodeRequired))
 		return ParseResult{Valid: false, Value: value, Errors: errors}
 	}
 
 	// If empty and not required, return early
-	if binaryStr == "" {
+	if binaryStr == "" && !ctx.EmptyIsPresent {
 		return ParseResult{Valid: true, Value: binaryStr, Errors: nil}
 	}
 
@@ -148,7 +195,7 @@ func (s *BinarySchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 	decodedData, err := s.validateAndDecode(binaryStr, ctx)
 	if err != nil {
 		// err is already a localized error message
-		errors = append(errors, NewPrimitiveError(binaryStr, err.Error(), "format"))
+		errors = append(errors, NewPrimitiveError(binaryStr, err.Error(), CodeFormat))
 		return ParseResult{Valid: false, Value: value, Errors: errors}
 	}
 
@@ -160,7 +207,7 @@ func (s *BinarySchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		if !isEmptyErrorMessage(s.sizeError) {
 			message = resolveErrorMessage(s.sizeError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(binaryStr, message, "min_size"))
+		errors = append(errors, NewPrimitiveError(binaryStr, message, CodeMinSize))
 	}
 
 	if s.maxSize != nil && dataSize > *s.maxSize {
@@ -168,7 +215,7 @@ func (s *BinarySchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		if !isEmptyErrorMessage(s.sizeError) {
 			message = resolveErrorMessage(s.sizeError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(binaryStr, message, "max_size"))
+		errors = append(errors, NewPrimitiveError(binaryStr, message, CodeMaxSize))
 	}
 
 	// Return result
@@ -0,0 +1,308 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bindTimeLayouts are the layouts tried when binding a string to a time.Time field,
+// covering the outputs of Date(), DateTime() and Time()
+var bindTimeLayouts = []string{time.RFC3339Nano, "2006-01-02", "15:04:05", "15:04:05.000"}
+
+// textUnmarshalerType is used to bind strings to types such as net.IP or custom IDs
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// ParseInto validates value and, if it is valid, populates dest (a non-nil pointer,
+// usually to a struct) with the parsed result. Struct fields are matched by their json
+// tag (or field name), embedded structs are flattened, and nested objects, slices, maps
+// and pointers are bound recursively. Validation failures are returned as ValidationErrors.
+func (s *ObjectSchema) ParseInto(value interface{}, dest interface{}, ctx *ValidationContext) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("schema: ParseInto destination must be a non-nil pointer, got %T", dest)
+	}
+
+	result := s.Parse(value, ctx)
+	if !result.Valid {
+		return ValidationErrors(result.Errors)
+	}
+	return bindValue(rv.Elem(), result.Value, nil)
+}
+
+// bindValue assigns a parsed value to dst, converting between compatible types
+func bindValue(dst reflect.Value, src interface{}, path Path) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	srcValue := reflect.ValueOf(src)
+
+	// Exact or interface matches need no conversion
+	if srcValue.Type().AssignableTo(dst.Type()) {
+		dst.Set(srcValue)
+		return nil
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem())
+		if err := bindValue(elem.Elem(), src, path); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	if dst.Type() == timeType {
+		return bindTime(dst, src, path)
+	}
+
+	if str, ok := src.(string); ok && reflect.PointerTo(dst.Type()).Implements(textUnmarshalerType) {
+		if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str)); err != nil {
+			return bindError(path, src, dst.Type(), err)
+		}
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		fields, ok := src.(map[string]interface{})
+		if !ok {
+			return bindError(path, src, dst.Type(), nil)
+		}
+		return bindStruct(dst, fields, path)
+
+	case reflect.Map:
+		return bindMap(dst, srcValue, path)
+
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			if str, ok := src.(string); ok {
+				// []byte fields hold base64 text, as with encoding/json
+				decoded, err := base64.StdEncoding.DecodeString(str)
+				if err != nil {
+					return bindError(path, src, dst.Type(), err)
+				}
+				dst.SetBytes(decoded)
+				return nil
+			}
+		}
+		if srcValue.Kind() != reflect.Slice && srcValue.Kind() != reflect.Array {
+			return bindError(path, src, dst.Type(), nil)
+		}
+		slice := reflect.MakeSlice(dst.Type(), srcValue.Len(), srcValue.Len())
+		for i := 0; i < srcValue.Len(); i++ {
+			if err := bindValue(slice.Index(i), srcValue.Index(i).Interface(), path.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+
+	case reflect.Array:
+		if (srcValue.Kind() != reflect.Slice && srcValue.Kind() != reflect.Array) || srcValue.Len() != dst.Len() {
+			return bindError(path, src, dst.Type(), nil)
+		}
+		for i := 0; i < srcValue.Len(); i++ {
+			if err := bindValue(dst.Index(i), srcValue.Index(i).Interface(), path.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := toInt64(src)
+		if !ok || dst.OverflowInt(n) {
+			return bindError(path, src, dst.Type(), nil)
+		}
+		dst.SetInt(n)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := toUint64(src)
+		if !ok || dst.OverflowUint(n) {
+			return bindError(path, src, dst.Type(), nil)
+		}
+		dst.SetUint(n)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat64(src)
+		if !ok || dst.OverflowFloat(f) {
+			return bindError(path, src, dst.Type(), nil)
+		}
+		dst.SetFloat(f)
+		return nil
+	}
+
+	// Named string/bool types and other convertible values
+	if srcValue.Type().ConvertibleTo(dst.Type()) && srcValue.Kind() == dst.Kind() {
+		dst.Set(srcValue.Convert(dst.Type()))
+		return nil
+	}
+	return bindError(path, src, dst.Type(), nil)
+}
+
+// bindStruct assigns object properties to the exported fields of a struct
+func bindStruct(dst reflect.Value, fields map[string]interface{}, path Path) error {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := bindFieldName(field)
+		if skip {
+			continue
+		}
+
+		// Embedded structs without an explicit name are flattened, as with encoding/json
+		if jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ","); field.Anonymous && jsonName == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				target := dst.Field(i)
+				if target.Kind() == reflect.Ptr {
+					if target.IsNil() {
+						if !target.CanSet() {
+							continue
+						}
+						target.Set(reflect.New(ft))
+					}
+					target = target.Elem()
+				}
+				if err := bindStruct(target, fields, path); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if err := bindValue(dst.Field(i), raw, path.Field(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindMap assigns the entries of a parsed map to a Go map with string or integer keys
+func bindMap(dst reflect.Value, src reflect.Value, path Path) error {
+	if src.Kind() != reflect.Map {
+		return bindError(path, src.Interface(), dst.Type(), nil)
+	}
+
+	keyType, elemType := dst.Type().Key(), dst.Type().Elem()
+	result := reflect.MakeMapWithSize(dst.Type(), src.Len())
+	iter := src.MapRange()
+	for iter.Next() {
+		keyStr := fmt.Sprintf("%v", iter.Key().Interface())
+		key := reflect.New(keyType).Elem()
+		switch keyType.Kind() {
+		case reflect.String:
+			key.SetString(keyStr)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(keyStr, 10, keyType.Bits())
+			if err != nil {
+				return bindError(path.Field(keyStr), keyStr, keyType, err)
+			}
+			key.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, err := strconv.ParseUint(keyStr, 10, keyType.Bits())
+			if err != nil {
+				return bindError(path.Field(keyStr), keyStr, keyType, err)
+			}
+			key.SetUint(n)
+		default:
+			if err := bindValue(key, iter.Key().Interface(), path); err != nil {
+				return err
+			}
+		}
+
+		elem := reflect.New(elemType).Elem()
+		if err := bindValue(elem, iter.Value().Interface(), path.Field(keyStr)); err != nil {
+			return err
+		}
+		result.SetMapIndex(key, elem)
+	}
+	dst.Set(result)
+	return nil
+}
+
+// bindTime assigns a time.Time from a time value or a date/time string
+func bindTime(dst reflect.Value, src interface{}, path Path) error {
+	switch v := src.(type) {
+	case time.Time:
+		dst.Set(reflect.ValueOf(v))
+		return nil
+	case string:
+		for _, layout := range bindTimeLayouts {
+			if parsed, err := time.Parse(layout, v); err == nil {
+				dst.Set(reflect.ValueOf(parsed))
+				return nil
+			}
+		}
+		if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+			dst.Set(reflect.ValueOf(time.Unix(unix, 0).UTC()))
+			return nil
+		}
+	}
+	return bindError(path, src, dst.Type(), nil)
+}
+
+// bindFieldName returns the property name for a struct field and whether to skip it
+func bindFieldName(field reflect.StructField) (string, bool) {
+	name := field.Name
+	if jsonTag := field.Tag.Get("json"); jsonTag != "" {
+		jsonName, _, _ := strings.Cut(jsonTag, ",")
+		if jsonName == "-" {
+			return "", true
+		}
+		if jsonName != "" {
+			name = jsonName
+		}
+	}
+	return name, false
+}
+
+// bindError describes a value that could not be assigned to its destination
+func bindError(path Path, src interface{}, dstType reflect.Type, cause error) error {
+	location := path.DotPath()
+	if location == "" {
+		location = "value"
+	}
+	if cause != nil {
+		return fmt.Errorf("schema: cannot bind %T to %s at %s: %w", src, dstType, location, cause)
+	}
+	return fmt.Errorf("schema: cannot bind %T to %s at %s", src, dstType, location)
+}
+
+// toInt64 converts an integer, or a whole float, to int64 without losing precision. It
+// returns false for values outside the int64 range.
+func toInt64(value interface{}) (int64, bool) {
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+		return rv.Int(), true
+	case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr:
+		return int64(rv.Uint()), rv.Uint() <= math.MaxInt64
+	case rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64:
+		f := rv.Float()
+		if f < -(1<<63) || f >= 1<<63 || f != math.Trunc(f) {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
@@ -0,0 +1,149 @@
+package schema
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type bindTestAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type bindTestAudit struct {
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type bindTestUser struct {
+	bindTestAudit
+	Name     string            `json:"name"`
+	Age      int               `json:"age"`
+	Score    *float64          `json:"score"`
+	Tags     []string          `json:"tags"`
+	Address  *bindTestAddress  `json:"address"`
+	Friends  []bindTestAddress `json:"friends"`
+	Counts   map[string]int    `json:"counts"`
+	Birthday time.Time         `json:"birthday"`
+	Ignored  string            `json:"-"`
+}
+
+func bindTestSchema() *ObjectSchema {
+	return Object().
+		Property("name", String().MinLength(2)).
+		Property("age", Int().Min(0)).
+		Property("score", Number().Optional()).
+		Property("tags", Array(String()).Optional()).
+		Property("address", Object().Property("city", String()).Property("zip", String()).Optional()).
+		Property("friends", Array(Object().Property("city", String())).Optional()).
+		Property("counts", Record(String(), Int()).Optional()).
+		Property("birthday", Date().Optional()).
+		Property("createdAt", DateTime().Optional())
+}
+
+func TestObjectSchema_ParseInto(t *testing.T) {
+	var user bindTestUser
+	err := bindTestSchema().ParseInto(map[string]interface{}{
+		"name":      "Ada",
+		"age":       36.0,
+		"score":     9.5,
+		"tags":      []interface{}{"math", "code"},
+		"address":   map[string]interface{}{"city": "London", "zip": "N1"},
+		"friends":   []interface{}{map[string]interface{}{"city": "Paris"}},
+		"counts":    map[string]interface{}{"a": 1},
+		"birthday":  "1815-12-10",
+		"createdAt": "2024-01-02T03:04:05Z",
+	}, &user, DefaultValidationContext())
+	if err != nil {
+		t.Fatalf("ParseInto() error = %v", err)
+	}
+
+	if user.Name != "Ada" || user.Age != 36 {
+		t.Errorf("unexpected scalars: %+v", user)
+	}
+	if user.Score == nil || *user.Score != 9.5 {
+		t.Errorf("expected pointer field to be set, got %v", user.Score)
+	}
+	if len(user.Tags) != 2 || user.Tags[1] != "code" {
+		t.Errorf("unexpected tags: %v", user.Tags)
+	}
+	if user.Address == nil || user.Address.City != "London" {
+		t.Errorf("unexpected address: %+v", user.Address)
+	}
+	if len(user.Friends) != 1 || user.Friends[0].City != "Paris" {
+		t.Errorf("unexpected friends: %+v", user.Friends)
+	}
+	if user.Counts["a"] != 1 {
+		t.Errorf("unexpected counts: %v", user.Counts)
+	}
+	if user.Birthday.Year() != 1815 || user.Birthday.Month() != time.December {
+		t.Errorf("unexpected birthday: %v", user.Birthday)
+	}
+	if user.CreatedAt.Hour() != 3 {
+		t.Errorf("expected embedded struct field to be bound, got %v", user.CreatedAt)
+	}
+}
+
+func TestObjectSchema_ParseInto_Errors(t *testing.T) {
+	ctx := DefaultValidationContext()
+	s := bindTestSchema()
+
+	var user bindTestUser
+	err := s.ParseInto(map[string]interface{}{"name": "A", "age": 1}, &user, ctx)
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) == 0 {
+		t.Errorf("expected ValidationErrors, got %v", err)
+	}
+
+	if err := s.ParseInto(map[string]interface{}{"name": "Ada", "age": 1}, user, ctx); err == nil {
+		t.Error("expected error for non-pointer destination")
+	}
+
+	var wrong struct {
+		Name int `json:"name"`
+	}
+	if err := Object().Property("name", String()).ParseInto(map[string]interface{}{"name": "Ada"}, &wrong, ctx); err == nil {
+		t.Error("expected error for incompatible field type")
+	}
+}
+
+func TestBindValue_Integers(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    int64
+		wantErr bool
+	}{
+		{name: "int64 above 2^53", src: int64(1<<53 + 1), want: 1<<53 + 1},
+		{name: "max int64", src: int64(math.MaxInt64), want: math.MaxInt64},
+		{name: "min int64", src: int64(math.MinInt64), want: math.MinInt64},
+		{name: "uint64 in range", src: uint64(1 << 62), want: 1 << 62},
+		{name: "whole float", src: -42.0, want: -42},
+		{name: "uint64 above max int64", src: uint64(math.MaxUint64), wantErr: true},
+		{name: "float above 2^63", src: 1e19, wantErr: true},
+		{name: "float at 2^63", src: float64(1 << 63), wantErr: true},
+		{name: "float below -2^63", src: -1e19, wantErr: true},
+		{name: "fractional float", src: 1.5, wantErr: true},
+		{name: "NaN", src: math.NaN(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got int64
+			err := bindValue(reflect.ValueOf(&got).Elem(), tt.src, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("bindValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("bindValue() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	var small int8
+	if err := bindValue(reflect.ValueOf(&small).Elem(), 200, nil); err == nil {
+		t.Errorf("bindValue() bound 200 to int8 as %d", small)
+	}
+}
@@ -20,6 +20,7 @@ func boolConstError(value bool) i18n.TranslatedFunc {
 // BoolSchema represents a JSON Schema for boolean values
 type BoolSchema struct {
 	Schema
+	coerce bool // Convert compatible input types before validating
 	// Bool-specific validation (private fields)
 	nullable bool
 
@@ -44,34 +45,116 @@ func Bool(errorMessage ...interface{}) *BoolSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *BoolSchema) Clone() *BoolSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *BoolSchema) Freeze() *BoolSchema {
+	s.freeze()
+	return s
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *BoolSchema) Title(title string) *BoolSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *BoolSchema) Description(description string) *BoolSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *BoolSchema) ReadOnly() *BoolSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *BoolSchema) WriteOnly() *BoolSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *BoolSchema) Sensitive() *BoolSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *BoolSchema) Deprecated(reason string) *BoolSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *BoolSchema) Meta(key string, value interface{}) *BoolSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *BoolSchema) AsWarning(codes ...ErrorCode) *BoolSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *BoolSchema) AsInfo(codes ...ErrorCode) *BoolSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *BoolSchema) Default(value interface{}) *BoolSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *BoolSchema) DefaultFunc(fn func() interface{}) *BoolSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *BoolSchema) Example(example bool) *BoolSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 // Enum sets the allowed enum values with optional custom error message
 func (s *BoolSchema) Enum(values []bool, errorMessage ...interface{}) *BoolSchema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -84,6 +167,7 @@ func (s *BoolSchema) Enum(values []bool, errorMessage ...interface{}) *BoolSchem
 
 // Const sets a constant value with optional custom error message
 func (s *BoolSchema) Const(value bool, errorMessage ...interface{}) *BoolSchema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -95,12 +179,14 @@ func (s *BoolSchema) Const(value bool, errorMessage ...interface{}) *BoolSchema
 
 // Optional marks the schema as optional
 func (s *BoolSchema) Optional() *BoolSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *BoolSchema) Required(errorMessage ...interface{}) *BoolSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -110,12 +196,27 @@ func (s *BoolSchema) Required(errorMessage ...interface{}) *BoolSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *BoolSchema) Nullable() *BoolSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
+// Coerce enables conversion of compatible input types (e.g. strings from query
+// parameters or environment variables) before validation
+func (s *BoolSchema) Coerce() *BoolSchema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *BoolSchema) coerces() bool {
+	return s.coerce
+}
+
 // TypeError sets a custom error message for type mismatch validation
 func (s *BoolSchema) TypeError(message string) *BoolSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -151,18 +252,47 @@ func (s *BoolSchema) IsNullable() bool {
 
 // GetDefault returns the default value as a bool
 func (s *BoolSchema) GetDefaultBool() *bool {
-	if s.GetDefault() != nil {
-		if b, ok := s.GetDefault().(bool); ok {
-			return &b
-		}
+	if b, ok := s.GetDefault().(bool); ok {
+		return &b
 	}
 	return nil
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *BoolSchema) Transform(fn TransformFunc) *BoolSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *BoolSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *BoolSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *BoolSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *BoolSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses a boolean value, returning the final parsed value
 func (s *BoolSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the bool constraints; Parse runs the refine/transform pipeline on top
+func (s *BoolSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceBool(value)
+	}
+
 	var errors []ValidationError
 
 	// Handle nil values
@@ -175,7 +305,7 @@ func (s *BoolSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := boolRequiredError(ctx.Locale)
@@ -185,12 +315,12 @@ func (s *BoolSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -206,7 +336,7 @@ func (s *BoolSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
@@ -227,7 +357,7 @@ func (s *BoolSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(boolValue, message, "enum"))
+			errors = append(errors, NewPrimitiveError(boolValue, message, CodeEnum))
 		}
 	}
 
@@ -238,7 +368,7 @@ func (s *BoolSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.constError) {
 				message = resolveErrorMessage(s.constError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(boolValue, message, "const"))
+			errors = append(errors, NewPrimitiveError(boolValue, message, CodeConst))
 		}
 	}
 
@@ -256,7 +386,8 @@ func (s *BoolSchema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -0,0 +1,394 @@
+package schema
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"hash"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ResultCache stores parse results by key for Cached schemas. Implementations must be
+// safe for concurrent use; LRUCache is the built-in one.
+type ResultCache interface {
+	Get(key string) (ParseResult, bool)
+	Add(key string, result ParseResult)
+}
+
+// WithCache sets the cache consulted by Cached schemas parsed with this context.
+// Without a cache, Cached schemas parse as the schema they wrap.
+func (vc *ValidationContext) WithCache(cache ResultCache) *ValidationContext {
+	vc.Cache = cache
+	return vc
+}
+
+// CacheStats reports the use of an LRUCache
+type CacheStats struct {
+	Hits      uint64 // Lookups that found a result
+	Misses    uint64 // Lookups that did not
+	Evictions uint64 // Results removed to make room for new ones
+	Size      int    // Results currently stored
+}
+
+// HitRate returns the share of lookups that found a result, between 0 and 1
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// String describes the cache statistics, e.g. "hits=90 misses=10 evictions=0 size=10 hit_rate=0.90"
+func (s CacheStats) String() string {
+	return "hits=" + strconv.FormatUint(s.Hits, 10) +
+		" misses=" + strconv.FormatUint(s.Misses, 10) +
+		" evictions=" + strconv.FormatUint(s.Evictions, 10) +
+		" size=" + strconv.Itoa(s.Size) +
+		" hit_rate=" + strconv.FormatFloat(s.HitRate(), 'f', 2, 64)
+}
+
+// LRUCache is a ResultCache holding a bounded number of results, evicting the least
+// recently used one when full. It is safe for concurrent use.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // Most recently used first
+	stats   CacheStats
+}
+
+// lruEntry is a result stored in an LRUCache
+type lruEntry struct {
+	key    string
+	result ParseResult
+}
+
+// NewLRUCache creates a cache holding up to size results (at least one)
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:    max(size, 1),
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get returns the result stored under key and marks it as recently used
+func (c *LRUCache) Get(key string) (ParseResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return ParseResult{}, false
+	}
+	c.stats.Hits++
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry).result, true
+}
+
+// Add stores a result under key, evicting the least recently used result when full
+func (c *LRUCache) Add(key string, result ParseResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruEntry).result = result
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, result: result})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+		c.stats.Evictions++
+	}
+}
+
+// Purge removes every result, for instance when a remote refinement's data changed.
+// The statistics are kept.
+func (c *LRUCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// Stats returns the hits, misses and evictions since the cache was created
+func (c *LRUCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
+
+// CachedSchema memoizes the results of an expensive schema in the cache of the
+// validation context
+type CachedSchema struct {
+	schema      Parseable
+	once        sync.Once
+	fingerprint string
+	frozenState
+}
+
+// Cached wraps an expensive schema, such as a huge enum or a schema with remote
+// refinements, so that parsing a value it has already parsed returns the stored result
+// when the context has a cache (see ValidationContext.WithCache):
+//
+//	var config = schema.Cached(configSchema)
+//	ctx := schema.DefaultValidationContext().WithCache(schema.NewLRUCache(1024))
+//	result := config.Parse(document, ctx) // Parsed once per distinct document
+//
+// Results are keyed by the Fingerprint of the schema, a hash of the value, the
+// position of the value and the options of the context that change results (locale,
+// coercion, error limits, presence tracking, struct tag names, whether empty strings
+// are present). s is frozen, so it cannot change under its fingerprint. Schemas with
+// the same fingerprint share results, so schemas that differ only in their Refine or
+// Transform functions or error messages need separate caches.
+//
+// Contexts with Messages, a Now clock, a Redact function or values set by WithValue,
+// and values that cannot be hashed (functions, channels, cyclic data), are parsed
+// without the cache. The values carried by the Go context of WithContext are not part
+// of the key, so pass those read by RefineCtx validators with WithValue. Cached values
+// are shared between parses and must not be modified.
+func Cached(s Parseable) *CachedSchema {
+	freezeSchemas(s)
+	return &CachedSchema{schema: s}
+}
+
+// Schema returns the wrapped schema
+func (s *CachedSchema) Schema() Parseable {
+	return s.schema
+}
+
+// Children returns the wrapped schema
+func (s *CachedSchema) Children() []SchemaChild {
+	return sameValueChildren(s.schema)
+}
+
+// Freeze makes the schema immutable; the wrapped schema is frozen by Cached
+func (s *CachedSchema) Freeze() *CachedSchema {
+	s.freeze()
+	return s
+}
+
+// IsRequired returns whether the wrapped schema is required
+func (s *CachedSchema) IsRequired() bool {
+	if required, ok := s.schema.(interface{ IsRequired() bool }); ok {
+		return required.IsRequired()
+	}
+	return false
+}
+
+// IsNullable returns whether the wrapped schema allows nil values
+func (s *CachedSchema) IsNullable() bool {
+	if nullable, ok := s.schema.(interface{ IsNullable() bool }); ok {
+		return nullable.IsNullable()
+	}
+	return false
+}
+
+// Parse returns the stored result for the value when the context has one, and
+// otherwise parses the value with the wrapped schema and stores the result
+func (s *CachedSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	if ctx == nil || ctx.Cache == nil || ctx.Messages != nil || ctx.Now != nil || ctx.Redact != nil || ctx.hasValues {
+		return s.schema.Parse(value, ctx)
+	}
+	key, ok := s.cacheKey(value, ctx)
+	if !ok {
+		return s.schema.Parse(value, ctx)
+	}
+	if result, ok := ctx.Cache.Get(key); ok {
+		result.Errors = slices.Clone(result.Errors)
+		result.Warnings = slices.Clone(result.Warnings)
+		return result
+	}
+	result := s.schema.Parse(value, ctx)
+	ctx.Cache.Add(key, result)
+	return result
+}
+
+// JSON generates the JSON Schema of the wrapped schema
+func (s *CachedSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *CachedSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
+	if schema, ok := childJSON(s.schema, g); ok {
+		return schema
+	}
+	return map[string]interface{}{}
+}
+
+// cacheKey hashes the fingerprint of the schema, the options of the context and the
+// value. It returns false when the value cannot be hashed.
+func (s *CachedSchema) cacheKey(value interface{}, ctx *ValidationContext) (string, bool) {
+	s.once.Do(func() {
+		s.fingerprint = Fingerprint(s.schema)
+	})
+
+	h := sha256.New()
+	writeHashString(h, s.fingerprint)
+	writeHashString(h, ctx.Locale)
+	writeHashString(h, ctx.Source)
+	writeHashString(h, ctx.path().DotPath())
+	for _, option := range []int{
+		boolInt(ctx.Coerce), boolInt(ctx.FailFast), boolInt(ctx.validityOnly), boolInt(ctx.EmptyIsPresent),
+		boolInt(ctx.TrackPresence), ctx.MaxErrors, ctx.MaxStringLength, ctx.MaxCollectionSize, ctx.maxDepth() - ctx.depth,
+	} {
+		writeHashUint(h, uint64(option))
+	}
+	writeHashUint(h, uint64(len(ctx.TagNames)))
+	for _, tagName := range ctx.TagNames {
+		writeHashString(h, tagName)
+	}
+	if !hashValue(h, reflect.ValueOf(value), 0) {
+		return "", false
+	}
+	return string(h.Sum(nil)), true
+}
+
+// maxHashDepth bounds the nesting of hashed values, which also stops cyclic data
+const maxHashDepth = 256
+
+// Type tags of hashed values, so values of different kinds never hash alike
+const (
+	hashNil byte = iota
+	hashBool
+	hashInt
+	hashUint
+	hashFloat
+	hashString
+	hashList
+	hashMap
+	hashStruct
+	hashNumber
+)
+
+// hashValue writes an unambiguous encoding of value to h, with map keys sorted. It
+// returns false for values that cannot be hashed.
+func hashValue(h hash.Hash, v reflect.Value, depth int) bool {
+	if depth > maxHashDepth {
+		return false
+	}
+	if !v.IsValid() {
+		h.Write([]byte{hashNil})
+		return true
+	}
+
+	switch value := interfaceOf(v).(type) {
+	case json.Number:
+		h.Write([]byte{hashNumber})
+		writeHashString(h, value.String())
+		return true
+	case time.Time:
+		h.Write([]byte{hashString})
+		writeHashString(h, value.Format(time.RFC3339Nano))
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		h.Write([]byte{hashBool, byte(boolInt(v.Bool()))})
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.Write([]byte{hashInt})
+		writeHashUint(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.Write([]byte{hashUint})
+		writeHashUint(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.Write([]byte{hashFloat})
+		writeHashUint(h, math.Float64bits(v.Float()))
+	case reflect.String:
+		h.Write([]byte{hashString})
+		writeHashString(h, v.String())
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			h.Write([]byte{hashNil})
+			return true
+		}
+		return hashValue(h, v.Elem(), depth+1)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			h.Write([]byte{hashNil})
+			return true
+		}
+		h.Write([]byte{hashList})
+		writeHashUint(h, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			if !hashValue(h, v.Index(i), depth+1) {
+				return false
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			h.Write([]byte{hashNil})
+			return true
+		}
+		// Hash the entries separately, then write them in the order of their hashes
+		entries := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := sha256.New()
+			if !hashValue(entry, iter.Key(), depth+1) || !hashValue(entry, iter.Value(), depth+1) {
+				return false
+			}
+			entries = append(entries, string(entry.Sum(nil)))
+		}
+		sort.Strings(entries)
+		h.Write([]byte{hashMap})
+		writeHashUint(h, uint64(len(entries)))
+		for _, entry := range entries {
+			h.Write([]byte(entry))
+		}
+	case reflect.Struct:
+		h.Write([]byte{hashStruct})
+		writeHashString(h, v.Type().String())
+		for i := 0; i < v.NumField(); i++ {
+			if !hashValue(h, v.Field(i), depth+1) {
+				return false
+			}
+		}
+	default: // Functions, channels, complex numbers and unsafe pointers
+		return false
+	}
+	return true
+}
+
+// interfaceOf returns the value held by v, or nil for unexported struct fields, whose
+// values reflection does not expose
+func interfaceOf(v reflect.Value) interface{} {
+	if !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// writeHashString writes a length-prefixed string to h
+func writeHashString(h hash.Hash, s string) {
+	writeHashUint(h, uint64(len(s)))
+	h.Write([]byte(s))
+}
+
+// writeHashUint writes an integer to h
+func writeHashUint(h hash.Hash, n uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], n)
+	h.Write(b[:])
+}
+
+// boolInt converts a boolean to 0 or 1
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,203 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCachedSchema(t *testing.T) {
+	calls := 0
+	countries := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		countries = append(countries, "C"+strconv.Itoa(i))
+	}
+	expensive := Object().
+		Property("country", Enum(countries...)).
+		Property("tags", Array(String())).
+		Refine(func(value interface{}) bool {
+			calls++
+			return true
+		})
+	cached := Cached(expensive)
+
+	cache := NewLRUCache(2)
+	ctx := DefaultValidationContext().WithCache(cache)
+
+	valid := map[string]interface{}{"country": "C42", "tags": []interface{}{"a", "b"}}
+	same := map[string]interface{}{"tags": []interface{}{"a", "b"}, "country": "C42"}
+	invalid := map[string]interface{}{"country": "XX", "tags": []interface{}{}}
+
+	first := cached.Parse(valid, ctx)
+	second := cached.Parse(same, ctx)
+	if !first.Valid || !second.Valid || !reflect.DeepEqual(first.Value, second.Value) {
+		t.Fatalf("results = %+v, %+v", first, second)
+	}
+	if calls != 1 {
+		t.Errorf("refinement called %d times, want 1", calls)
+	}
+
+	for i := 0; i < 2; i++ {
+		if result := cached.Parse(invalid, ctx); result.Valid || len(result.Errors) == 0 {
+			t.Fatalf("invalid value parsed as %+v", result)
+		}
+	}
+	if errors := cached.Parse(invalid, ctx).Errors; len(errors) > 0 {
+		errors[0].Message = "modified" // Must not change the stored result
+	}
+	if cached.Parse(invalid, ctx).Errors[0].Message == "modified" {
+		t.Error("stored errors were modified through a returned result")
+	}
+
+	// Options that change results are part of the key
+	cached.Parse(valid, DefaultValidationContext().WithCache(cache).WithFailFast())
+	if calls != 2 {
+		t.Errorf("refinement called %d times after changing options, want 2", calls)
+	}
+
+	stats := cache.Stats()
+	want := CacheStats{Hits: 4, Misses: 3, Evictions: 1, Size: 2}
+	if stats != want {
+		t.Errorf("Stats() = %+v, want %+v", stats, want)
+	}
+	if got := stats.HitRate(); got < 0.57 || got > 0.58 {
+		t.Errorf("HitRate() = %v, want 4/7", got)
+	}
+	if got := stats.String(); got != "hits=4 misses=3 evictions=1 size=2 hit_rate=0.57" {
+		t.Errorf("String() = %q", got)
+	}
+
+	// Without a cache, or with values that cannot be hashed, the schema is parsed
+	cached.Parse(valid, DefaultValidationContext())
+	cached.Parse(map[string]interface{}{"country": "C1", "tags": []interface{}{func() {}}}, ctx)
+	if calls != 3 {
+		t.Errorf("refinement called %d times, want 3", calls)
+	}
+
+	cache.Purge()
+	if cache.Stats().Size != 0 {
+		t.Error("Purge() kept results")
+	}
+	if !expensive.IsFrozen() {
+		t.Error("Cached() should freeze the wrapped schema")
+	}
+}
+
+func TestCachedSchema_Nested(t *testing.T) {
+	calls := 0
+	sku := Cached(String().Refine(func(value interface{}) bool {
+		calls++
+		return value != "unknown"
+	}))
+	order := Object().Property("items", Array(sku))
+	ctx := DefaultValidationContext().WithCache(NewLRUCache(100))
+
+	result := order.Parse(map[string]interface{}{"items": []interface{}{"A-1", "A-1", "unknown"}}, ctx)
+	if result.Valid || len(result.Errors) == 0 || result.Errors[len(result.Errors)-1].Path.DotPath() != "items[2]" {
+		t.Fatalf("result = %+v", result)
+	}
+	// The position of the value is part of the key, so each item is parsed once
+	order.Parse(map[string]interface{}{"items": []interface{}{"A-1", "A-1", "unknown"}}, ctx)
+	if calls != 3 {
+		t.Errorf("refinement called %d times, want 3", calls)
+	}
+
+	// Required-ness and the JSON Schema come from the wrapped schema
+	if !sku.IsRequired() {
+		t.Error("cached schema should be required like its wrapped schema")
+	}
+	if sku.JSON()["type"] != "string" {
+		t.Errorf("JSON() = %v", sku.JSON())
+	}
+}
+
+func TestCachedSchema_EmptyIsPresent(t *testing.T) {
+	name := Cached(String())
+	cache := NewLRUCache(10)
+
+	if result := name.Parse("", DefaultValidationContext().WithCache(cache)); result.Valid {
+		t.Fatalf("empty string accepted as a required value: %+v", result)
+	}
+	if result := name.Parse("", DefaultValidationContext().WithCache(cache).WithEmptyIsPresent()); !result.Valid {
+		t.Errorf("empty string rejected with WithEmptyIsPresent: %+v", result.Errors)
+	}
+	if result := name.Parse("", DefaultValidationContext().WithCache(cache)); result.Valid {
+		t.Errorf("result of WithEmptyIsPresent served to the default context: %+v", result)
+	}
+}
+
+func TestCachedSchema_ContextOptions(t *testing.T) {
+	type limitKey struct{}
+	calls := 0
+	quota := Cached(Int().RefineCtx(func(ctx context.Context, value interface{}) error {
+		calls++
+		if limit, ok := ContextValue[int](ctx, limitKey{}); ok && value.(int) > limit {
+			return fmt.Errorf("over the limit of %d", limit)
+		}
+		return nil
+	}))
+	cache := NewLRUCache(10)
+
+	// Values read by RefineCtx validators are not cached
+	if !quota.Parse(5, DefaultValidationContext().WithCache(cache).WithValue(limitKey{}, 10)).Valid {
+		t.Fatal("5 rejected under a limit of 10")
+	}
+	if quota.Parse(5, DefaultValidationContext().WithCache(cache).WithValue(limitKey{}, 3)).Valid {
+		t.Error("5 accepted under a limit of 3")
+	}
+	if size := cache.Stats().Size; size != 0 {
+		t.Errorf("cache holds %d results of contexts with values, want 0", size)
+	}
+
+	// Redaction is not cached, the other options are part of the key
+	quota.Parse(5, DefaultValidationContext().WithCache(cache).WithRedaction(func(Path) bool { return true }))
+	for _, ctx := range []*ValidationContext{
+		DefaultValidationContext().WithCache(cache),
+		DefaultValidationContext().WithCache(cache).WithPresence(),
+		DefaultValidationContext().WithCache(cache).WithTagNames("yaml"),
+		DefaultValidationContext().WithCache(cache).WithTagNames("yaml", "json"),
+	} {
+		quota.Parse(5, ctx)
+	}
+	if calls != 7 {
+		t.Errorf("refinement called %d times, want 7", calls)
+	}
+	if size := cache.Stats().Size; size != 4 {
+		t.Errorf("cache holds %d results, want 4", size)
+	}
+}
+
+func TestHashValue(t *testing.T) {
+	type point struct{ X, y int }
+	tests := []struct {
+		name  string
+		a, b  interface{}
+		equal bool
+	}{
+		{"map order", map[string]interface{}{"a": 1, "b": 2}, map[string]interface{}{"b": 2, "a": 1}, true},
+		{"int and float", 1, 1.0, false},
+		{"string and number", "1", 1, false},
+		{"nil slice and empty slice", []interface{}(nil), []interface{}{}, false},
+		{"nested", []interface{}{map[string]interface{}{"a": []string{"x"}}}, []interface{}{map[string]interface{}{"a": []string{"y"}}}, false},
+		{"concatenation", []string{"ab", "c"}, []string{"a", "bc"}, false},
+		{"struct with unexported field", point{1, 2}, point{1, 3}, false},
+		{"pointers", &point{1, 2}, &point{1, 2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := Cached(Any())
+			ctx := DefaultValidationContext()
+			a, okA := schema.cacheKey(tt.a, ctx)
+			b, okB := schema.cacheKey(tt.b, ctx)
+			if !okA || !okB {
+				t.Fatalf("values should be hashable")
+			}
+			if (a == b) != tt.equal {
+				t.Errorf("keys equal = %v, want %v", a == b, tt.equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+// Command schema validates documents against schemas and converts, compares and
+// samples schemas from the command line.
+//
+//	schema validate --schema user.json data.json [more.yaml ...]
+//	schema generate-example --schema user.json [--seed 1] [--all]
+//	schema diff [--mode backward|forward|full] old.json new.json
+//	schema convert --schema user.json --to jsonschema|openapi|schema [--name User]
+//
+// Schema files hold either a JSON Schema document (draft-07 or 2020-12) or a
+// definition written by schema.MarshalSchema, recognized by its "kind" field.
+// Documents are decoded as JSON, YAML, TOML or INI according to their extension.
+//
+// The exit status is 0 on success, 1 when a document is invalid or a schema change
+// breaks compatibility, and 2 for usage errors and unreadable files.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nyxstack/schema"
+	"github.com/nyxstack/schema/openapi"
+)
+
+// Exit statuses
+const (
+	exitOK      = 0
+	exitFailure = 1 // Invalid documents, breaking changes
+	exitUsage   = 2 // Bad arguments, unreadable or invalid schema files
+)
+
+const usage = `usage: schema <command> [flags] [files]
+
+commands:
+  validate          validate documents against a schema
+  generate-example  print example data that satisfies a schema
+  diff              list the differences between two schemas
+  convert           convert a schema to JSON Schema, OpenAPI or the schema format
+
+run "schema <command> -h" for the flags of a command
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command in args and returns the exit status
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	commands := map[string]func([]string, io.Writer, io.Writer) int{
+		"validate":         validate,
+		"generate-example": generateExample,
+		"diff":             diff,
+		"convert":          convert,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			fmt.Fprint(stdout, usage)
+			return exitOK
+		}
+		fmt.Fprintf(stderr, "schema: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	return command(args[1:], stdout, stderr)
+}
+
+// newFlagSet returns a flag set for a command that reports errors to stderr
+func newFlagSet(name, arguments string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: schema %s %s\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// validate parses each document with the schema and prints its errors and warnings
+func validate(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("validate", "--schema <file> <document>...", stderr)
+	schemaPath := flags.String("schema", "", "schema file (required)")
+	locale := flags.String("locale", "en", "locale of the error messages")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *schemaPath == "" || flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	s, err := loadSchema(*schemaPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	status := exitOK
+	for _, path := range flags.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "schema: %v\n", err)
+			return exitUsage
+		}
+		ctx := schema.NewValidationContext(*locale).WithSource(path)
+		result := parseDocument(s, path, data, ctx)
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(stdout, "%s: warning: %s\n", path, formatError(warning))
+		}
+		if result.Valid {
+			fmt.Fprintf(stdout, "%s: valid\n", path)
+			continue
+		}
+		status = exitFailure
+		fmt.Fprintf(stdout, "%s: %d error(s)\n", path, len(result.Errors))
+		for _, err := range result.Errors {
+			fmt.Fprintf(stdout, "  %s\n", formatError(err))
+		}
+	}
+	return status
+}
+
+// parseDocument decodes data in the format given by the extension of path and
+// validates it against s
+func parseDocument(s schema.Parseable, path string, data []byte, ctx *schema.ValidationContext) schema.ParseResult {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return schema.ParseYAML(s, data, ctx)
+	case ".toml":
+		return schema.ParseTOML(s, data, ctx)
+	case ".ini":
+		return schema.ParseINI(s, data, ctx)
+	default:
+		return schema.ParseJSONWithLocations(s, data, ctx)
+	}
+}
+
+// formatError renders an error with its location and path: "data.json:3:10: age: message"
+func formatError(err schema.ValidationError) string {
+	var b strings.Builder
+	if err.Location != nil {
+		b.WriteString(err.Location.String())
+		b.WriteString(": ")
+	}
+	if len(err.Path) > 0 {
+		b.WriteString(err.Path.DotPath())
+		b.WriteString(": ")
+	}
+	b.WriteString(err.Message)
+	return b.String()
+}
+
+// generateExample prints example data generated from the schema
+func generateExample(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("generate-example", "--schema <file>", stderr)
+	schemaPath := flags.String("schema", "", "schema file (required)")
+	seed := flags.Int64("seed", 0, "seed making the output reproducible (0 for a random seed)")
+	all := flags.Bool("all", false, "include every optional property")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *schemaPath == "" || flags.NArg() > 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	s, err := loadSchema(*schemaPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	example := schema.Generate(s, schema.GenOptions{Seed: *seed, IncludeOptional: *all})
+	return writeJSON(stdout, stderr, example)
+}
+
+// diff prints the differences between two schemas, marking the breaking ones
+func diff(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("diff", "[--mode backward|forward|full] <old> <new>", stderr)
+	mode := flags.String("mode", string(schema.CompatibilityBackward), "compatibility mode the changes are checked against")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	compatibility := schema.CompatibilityMode(*mode)
+	if flags.NArg() != 2 || compatibility != schema.CompatibilityBackward && compatibility != schema.CompatibilityForward && compatibility != schema.CompatibilityFull {
+		flags.Usage()
+		return exitUsage
+	}
+	old, err := loadSchema(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	new, err := loadSchema(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	changes := schema.Diff(old, new)
+	breaking := 0
+	for _, change := range changes {
+		marker := " "
+		if change.Breaks(compatibility) {
+			marker = "!"
+			breaking++
+		}
+		fmt.Fprintf(stdout, "%s %s\n", marker, change)
+	}
+	switch {
+	case len(changes) == 0:
+		fmt.Fprintln(stdout, "no changes")
+	case breaking > 0:
+		fmt.Fprintf(stdout, "%d of %d change(s) break %s compatibility\n", breaking, len(changes), compatibility)
+		return exitFailure
+	default:
+		fmt.Fprintf(stdout, "%d change(s), %s compatible\n", len(changes), compatibility)
+	}
+	return exitOK
+}
+
+// convert prints the schema in another format
+func convert(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("convert", "--schema <file> --to jsonschema|openapi|schema", stderr)
+	schemaPath := flags.String("schema", "", "schema file (required)")
+	to := flags.String("to", "", "output format: jsonschema, openapi or schema (required)")
+	name := flags.String("name", "Schema", "component name of the schema in the OpenAPI document")
+	draft := flags.String("draft", string(schema.Draft2020), "JSON Schema draft: 2020-12 or draft-07")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *schemaPath == "" || flags.NArg() > 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	s, err := loadSchema(*schemaPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	generator, ok := s.(schema.JSONSchemaGenerator)
+	if !ok {
+		fmt.Fprintf(stderr, "schema: %s has no JSON Schema form\n", *schemaPath)
+		return exitUsage
+	}
+
+	switch *to {
+	case "jsonschema":
+		opts := schema.JSONSchemaOptions{Draft: schema.JSONSchemaDraft(*draft), IncludeSchemaURI: true, SortKeys: true}
+		return writeJSON(stdout, stderr, schema.JSONSchemaWithOptions(generator, opts))
+	case "openapi":
+		return writeJSON(stdout, stderr, openapi.NewOpenAPIBuilder().Component(*name, generator).Build())
+	case "schema":
+		data, err := schema.MarshalSchema(s)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+		return writeJSON(stdout, stderr, json.RawMessage(data))
+	default:
+		flags.Usage()
+		return exitUsage
+	}
+}
+
+// loadSchema reads a JSON Schema document or a schema.MarshalSchema definition
+func loadSchema(path string) (schema.Parseable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err == nil {
+		if _, ok := root["kind"].(string); ok {
+			s, err := schema.UnmarshalSchema(data)
+			return s, annotate(path, err)
+		}
+	}
+	s, err := schema.CompileJSONSchema(data)
+	return s, annotate(path, err)
+}
+
+// annotate prefixes the error with the file it is about
+func annotate(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+// writeJSON prints value as indented JSON
+func writeJSON(stdout, stderr io.Writer, value interface{}) int {
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		fmt.Fprintf(stderr, "schema: %v\n", err)
+		return exitFailure
+	}
+	return exitOK
+}
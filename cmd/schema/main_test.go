@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const userSchema = `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "minLength": 2},
+    "age": {"type": "integer", "minimum": 0}
+  },
+  "required": ["name"]
+}`
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	user := write("user.json", userSchema)
+	native := write("native.json", `{"kind": "object", "properties": {"name": {"kind": "string"}}, "required": ["name"]}`)
+	stricter := write("stricter.json", strings.Replace(userSchema, `"minLength": 2`, `"minLength": 5`, 1))
+	valid := write("valid.json", `{"name": "Ann", "age": 30}`)
+	invalid := write("invalid.json", "{\n  \"name\": \"A\",\n  \"age\": -1\n}")
+	yamlDoc := write("valid.yaml", "name: Ann\n")
+
+	tests := []struct {
+		name   string
+		args   []string
+		status int
+		want   []string // Substrings of the output
+	}{
+		{"valid", []string{"validate", "--schema", user, valid, yamlDoc}, exitOK, []string{"valid.json: valid", "valid.yaml: valid"}},
+		{"invalid", []string{"validate", "--schema", user, invalid}, exitFailure, []string{"invalid.json: 4 error(s)", "invalid.json:2:11: name: ", "invalid.json:3:10: age: "}},
+		{"native schema rejects unknown properties", []string{"validate", "--schema", native, valid}, exitFailure, []string{"age: "}},
+		{"missing schema flag", []string{"validate", valid}, exitUsage, []string{"usage: schema validate"}},
+		{"unreadable schema", []string{"validate", "--schema", filepath.Join(dir, "missing.json"), valid}, exitUsage, []string{"missing.json"}},
+		{"diff compatible", []string{"diff", stricter, user}, exitOK, []string{"minLength loosened", "backward compatible"}},
+		{"diff breaking", []string{"diff", user, stricter}, exitFailure, []string{"! /properties/name: minLength tightened from 2 to 5", "1 of 1 change(s) break backward compatibility"}},
+		{"diff forward", []string{"diff", "--mode", "forward", user, stricter}, exitOK, []string{"forward compatible"}},
+		{"convert openapi", []string{"convert", "--schema", user, "--to", "openapi", "--name", "User"}, exitOK, []string{`"openapi": "3.1.0"`, `"User": {`}},
+		{"convert jsonschema", []string{"convert", "--schema", user, "--to", "jsonschema"}, exitOK, []string{`"$schema": "https://json-schema.org/draft/2020-12/schema"`}},
+		{"convert schema", []string{"convert", "--schema", user, "--to", "schema"}, exitOK, []string{`"kind": "object"`}},
+		{"convert unknown format", []string{"convert", "--schema", user, "--to", "xml"}, exitUsage, []string{"usage: schema convert"}},
+		{"unknown command", []string{"lint"}, exitUsage, []string{`unknown command "lint"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			status := run(tt.args, &out, &out)
+			if status != tt.status {
+				t.Errorf("status = %d, want %d\n%s", status, tt.status, out.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateExample(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user.json")
+	if err := os.WriteFile(path, []byte(userSchema), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var out, again bytes.Buffer
+	if status := run([]string{"generate-example", "--schema", path, "--seed", "7", "--all"}, &out, &out); status != exitOK {
+		t.Fatalf("status = %d\n%s", status, out.String())
+	}
+	var example map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &example); err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := example["name"].(string); len(name) < 2 {
+		t.Errorf("name = %v", example["name"])
+	}
+	if _, ok := example["age"]; !ok {
+		t.Error("age is missing with --all")
+	}
+
+	run([]string{"generate-example", "--schema", path, "--seed", "7", "--all"}, &again, &again)
+	if again.String() != out.String() {
+		t.Errorf("the same seed generated different examples:\n%s\n%s", out.String(), again.String())
+	}
+}
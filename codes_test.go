@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"testing"
+)
+
+func TestCountryCodeSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *CountryCodeSchema
+		value    interface{}
+		expected interface{} // parsed value, nil when invalid
+		code     ErrorCode
+	}{
+		{"alpha-2", CountryCode(), "DE", "DE", ""},
+		{"alpha-2 unknown", CountryCode(), "XX", nil, "invalid_code"},
+		{"alpha-2 rejects alpha-3", CountryCode(), "DEU", nil, "invalid_code"},
+		{"alpha-2 case", CountryCode(), "de", nil, "invalid_code"},
+		{"alpha-3", CountryCode().Alpha3(), "DEU", "DEU", ""},
+		{"alpha-3 rejects alpha-2", CountryCode().Alpha3(), "DE", nil, "invalid_code"},
+		{"any", CountryCode().Format(CountryCodeAny), "USA", "USA", ""},
+		{"case insensitive", CountryCode().CaseInsensitive(), "gb", "GB", ""},
+		{"not a string", CountryCode(), 276, nil, "invalid_type"},
+		{"required nil", CountryCode(), nil, nil, "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != (tt.expected != nil) {
+				t.Fatalf("Parse(%v) = %v (%v)", tt.value, result.Valid, result.Errors)
+			}
+			if result.Valid && result.Value != tt.expected {
+				t.Errorf("Value = %v, want %v", result.Value, tt.expected)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	doc := CountryCode().JSON()
+	if codes, ok := doc["enum"].([]string); !ok || len(codes) != 249 || codes[0] != "AD" {
+		t.Errorf("enum = %v", doc["enum"])
+	}
+	if doc := CountryCode().Alpha3().CaseInsensitive().JSON(); doc["pattern"] != "^[A-Za-z]{3}$" || doc["enum"] != nil {
+		t.Errorf("JSON() = %v", doc)
+	}
+}
+
+func TestCurrencyCodeSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	for _, valid := range []string{"EUR", "USD", "JPY", "CHF", "XAU"} {
+		if result := CurrencyCode().Parse(valid, ctx); !result.Valid {
+			t.Errorf("expected %q to be valid, got %v", valid, result.Errors)
+		}
+	}
+	for _, invalid := range []string{"eur", "EURO", "ABC", ""} {
+		if result := CurrencyCode().Parse(invalid, ctx); result.Valid || result.Errors[0].Code != "invalid_code" {
+			t.Errorf("expected %q to be rejected, got %v", invalid, result.Errors)
+		}
+	}
+	if result := CurrencyCode().CaseInsensitive().Parse("eur", ctx); result.Value != "EUR" {
+		t.Errorf("Value = %v, want EUR", result.Value)
+	}
+	if result := CurrencyCode("unsupported currency").Parse("ABC", ctx); result.Errors[0].Message != "unsupported currency" {
+		t.Errorf("message = %q", result.Errors[0].Message)
+	}
+}
+
+func TestLanguageTagSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	valid := map[string]string{
+		"en":                     "en",
+		"EN-us":                  "en-US",
+		"pt-BR":                  "pt-BR",
+		"zh-hant-tw":             "zh-Hant-TW",
+		"sr-Latn":                "sr-Latn",
+		"es-419":                 "es-419",
+		"yue-HK":                 "yue-HK",
+		"zh-yue-HK":              "zh-yue-HK",
+		"de-CH-1996":             "de-CH-1996",
+		"sl-rozaj-biske":         "sl-rozaj-biske",
+		"en-US-u-ca-gregory":     "en-US-u-ca-gregory",
+		"de-DE-u-co-phonebk-x-a": "de-DE-u-co-phonebk-x-a",
+		"x-whatever":             "x-whatever",
+		"und":                    "und",
+	}
+	for input, want := range valid {
+		result := LanguageTag().Canonicalize().Parse(input, ctx)
+		if !result.Valid || result.Value != want {
+			t.Errorf("Parse(%q) = %v %v, want %q", input, result.Value, result.Errors, want)
+		}
+	}
+	if result := LanguageTag().Parse("EN-us", ctx); result.Value != "EN-us" {
+		t.Errorf("Value = %v, want input unchanged without Canonicalize", result.Value)
+	}
+
+	invalid := []string{
+		"", "e", "english", "qq", "en_US", "en-", "-en", "en--US",
+		"en-XX", "en-Abcd", "en-US-u", "en-u-ca-u-nu", "de-1996-1996",
+		"en-x", "i-klingon", "en-US-ab", "abcdefghi",
+	}
+	for _, input := range invalid {
+		if result := LanguageTag().Parse(input, ctx); result.Valid || result.Errors[0].Code != "format" {
+			t.Errorf("expected %q to be rejected, got %v", input, result.Errors)
+		}
+	}
+}
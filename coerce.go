@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Coercion converts loosely typed input (query strings, env vars, form fields) into the
+// type a primitive schema expects. It is enabled per schema with Coerce() or for a whole
+// parse with ValidationContext.Coerce. Values that cannot be converted are returned
+// unchanged so the schema reports its usual type error.
+
+// isBlankString reports whether v is a string containing only whitespace.
+// Blank strings coerce to nil so empty form fields behave like missing ones.
+func isBlankString(v interface{}) bool {
+	str, ok := v.(string)
+	return ok && strings.TrimSpace(str) == ""
+}
+
+// coerceString converts numbers, booleans and byte slices to their string form
+func coerceString(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string, nil:
+		return value
+	case bool:
+		return strconv.FormatBool(v)
+	case []byte:
+		return string(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	}
+	return value
+}
+
+// coerceInt converts numeric strings and unsigned integers to int64
+func coerceInt(value interface{}) interface{} {
+	if isBlankString(value) {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		str := strings.TrimSpace(v)
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return i
+		}
+		// Accept whole numbers written as floats ("42.0", "1e3")
+		if f, err := strconv.ParseFloat(str, 64); err == nil && f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return f
+		}
+		return value
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr && rv.Uint() <= math.MaxInt64 {
+		return int64(rv.Uint())
+	}
+	return value
+}
+
+// coerceUint converts numeric strings to uint64; other numbers are converted by the
+// unsigned schemas themselves
+func coerceUint(value interface{}) interface{} {
+	if isBlankString(value) {
+		return nil
+	}
+
+	if v, ok := value.(string); ok {
+		str := strings.TrimSpace(v)
+		if n, err := strconv.ParseUint(str, 10, 64); err == nil {
+			return n
+		}
+		// Accept whole numbers written as floats ("42.0", "1e3")
+		if f, err := strconv.ParseFloat(str, 64); err == nil && f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return f
+		}
+	}
+	return value
+}
+
+// coerceFloat converts numeric strings and unsigned integers to float64
+func coerceFloat(value interface{}) interface{} {
+	if isBlankString(value) {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return value
+		}
+		return f
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr {
+		return float64(rv.Uint())
+	}
+	return value
+}
+
+// coerceBool converts common textual and numeric spellings of booleans
+func coerceBool(value interface{}) interface{} {
+	if isBlankString(value) {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "t", "1", "yes", "y", "on":
+			return true
+		case "false", "f", "0", "no", "n", "off":
+			return false
+		}
+		return value
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+		if n := rv.Int(); n == 0 || n == 1 {
+			return n == 1
+		}
+	case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr:
+		if n := rv.Uint(); n == 0 || n == 1 {
+			return n == 1
+		}
+	case rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64:
+		if f := rv.Float(); f == 0 || f == 1 {
+			return f == 1
+		}
+	}
+	return value
+}
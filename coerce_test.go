@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoerce_Primitives(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   Parseable
+		value    interface{}
+		expected bool
+		output   interface{}
+	}{
+		{"int from string", Int().Coerce(), "42", true, 42},
+		{"int from padded string", Int().Coerce(), " 7 ", true, 7},
+		{"int from whole float string", Int().Coerce(), "3.0", true, 3},
+		{"int from fractional string", Int().Coerce(), "3.5", false, nil},
+		{"int from uint", Int().Coerce(), uint(9), true, 9},
+		{"int constraints still apply", Int().Min(10).Coerce(), "5", false, nil},
+		{"int8 out of range", Int8().Coerce(), "300", false, nil},
+		{"int64 from string", Int64().Coerce(), "9000000000", true, int64(9000000000)},
+		{"number from string", Number().Coerce(), "1.5", true, 1.5},
+		{"number rejects NaN", Number().Coerce(), "NaN", false, nil},
+		{"bool from string", Bool().Coerce(), "true", true, true},
+		{"bool from yes/no", Bool().Coerce(), "No", true, false},
+		{"bool from int", Bool().Coerce(), 1, true, true},
+		{"bool from garbage", Bool().Coerce(), "maybe", false, nil},
+		{"string from int", String().Coerce(), 1, true, "1"},
+		{"string from float", String().Coerce(), 2.5, true, "2.5"},
+		{"string from bool", String().Coerce(), false, true, "false"},
+		{"date from time", Date().Coerce(), time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), true, "2024-03-01"},
+		{"blank string is missing", Int().Coerce().Optional(), "", true, nil},
+		{"blank string required", Int().Coerce(), "", false, nil},
+		{"no coercion by default", Int(), "42", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v. Errors: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.expected && result.Value != tt.output {
+				t.Errorf("Parse(%v) value = %#v, want %#v", tt.value, result.Value, tt.output)
+			}
+		})
+	}
+}
+
+func TestCoerce_Context(t *testing.T) {
+	s := Object().
+		Property("page", Int().Min(1)).
+		Property("active", Bool()).
+		Property("q", String())
+
+	query := map[string]interface{}{"page": "2", "active": "on", "q": "shoes"}
+
+	if result := s.Parse(query, DefaultValidationContext()); result.Valid {
+		t.Error("expected type errors without coercion")
+	}
+
+	result := s.Parse(query, DefaultValidationContext().WithCoercion())
+	if !result.Valid {
+		t.Fatalf("expected valid with coercion, got %v", result.Errors)
+	}
+	values := result.Value.(map[string]interface{})
+	if values["page"] != 2 || values["active"] != true {
+		t.Errorf("unexpected coerced values: %v", values)
+	}
+}
@@ -0,0 +1,476 @@
+package schema
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// ColorNotation identifies how a CSS color is written
+type ColorNotation string
+
+const (
+	ColorHex ColorNotation = "hex" // #RGB, #RGBA, #RRGGBB or #RRGGBBAA
+	ColorRGB ColorNotation = "rgb" // rgb(255, 0, 0), rgba(255, 0, 0, 0.5), rgb(255 0 0 / 50%)
+	ColorHSL ColorNotation = "hsl" // hsl(120, 100%, 50%), hsla(...), hsl(120deg 100% 50% / 0.5)
+)
+
+// Default error messages for color validation
+var (
+	colorRequiredError = i18n.S("value is required")
+	colorTypeError     = i18n.S("value must be a string")
+	colorFormatError   = i18n.S("value must be a valid color")
+	colorAlphaError    = i18n.S("color must not have an alpha channel")
+)
+
+// Default error message functions that take parameters
+func colorNotationError(notations []ColorNotation) i18n.TranslatedFunc {
+	names := make([]string, len(notations))
+	for i, notation := range notations {
+		names[i] = string(notation)
+	}
+	return i18n.F("color must use one of the notations: %s", strings.Join(names, ", "))
+}
+
+// ColorSchema validates CSS colors in hex, rgb() or hsl() notation and returns the
+// string unchanged. Named colors ("red") and other color functions are rejected.
+type ColorSchema struct {
+	Schema
+	notations []ColorNotation // Allowed notations (empty allows all)
+	noAlpha   bool
+	nullable  bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	notationError     ErrorMessage
+	alphaError        ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// Color creates a new color schema accepting every supported notation
+func Color(errorMessage ...interface{}) *ColorSchema {
+	schema := &ColorSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *ColorSchema) Clone() *ColorSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.notations = slices.Clone(s.notations)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *ColorSchema) Freeze() *ColorSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *ColorSchema) Title(title string) *ColorSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *ColorSchema) Description(description string) *ColorSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *ColorSchema) ReadOnly() *ColorSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *ColorSchema) WriteOnly() *ColorSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *ColorSchema) Sensitive() *ColorSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *ColorSchema) Deprecated(reason string) *ColorSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *ColorSchema) Meta(key string, value interface{}) *ColorSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *ColorSchema) AsWarning(codes ...ErrorCode) *ColorSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *ColorSchema) AsInfo(codes ...ErrorCode) *ColorSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *ColorSchema) Default(value interface{}) *ColorSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *ColorSchema) DefaultFunc(fn func() interface{}) *ColorSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *ColorSchema) Example(example string) *ColorSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Notations restricts the accepted notations, e.g. Notations(ColorHex)
+func (s *ColorSchema) Notations(notations ...ColorNotation) *ColorSchema {
+	s.checkMutable()
+	s.notations = notations
+	return s
+}
+
+// NotationError sets a custom error message for colors in a notation not allowed by Notations
+func (s *ColorSchema) NotationError(message interface{}) *ColorSchema {
+	s.checkMutable()
+	s.notationError = toErrorMessage(message)
+	return s
+}
+
+// NoAlpha rejects colors with an alpha channel, such as "#ff000080" or "rgb(255 0 0 / 50%)",
+// even when the alpha is fully opaque
+func (s *ColorSchema) NoAlpha(errorMessage ...interface{}) *ColorSchema {
+	s.checkMutable()
+	s.noAlpha = true
+	if len(errorMessage) > 0 {
+		s.alphaError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *ColorSchema) Optional() *ColorSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *ColorSchema) Required(errorMessage ...interface{}) *ColorSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *ColorSchema) Nullable() *ColorSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *ColorSchema) TypeError(message string) *ColorSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for malformed colors
+func (s *ColorSchema) FormatError(message string) *ColorSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *ColorSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *ColorSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *ColorSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetNotations returns the allowed notations (empty allows all)
+func (s *ColorSchema) GetNotations() []ColorNotation {
+	return s.notations
+}
+
+// IsAlphaAllowed returns whether colors may have an alpha channel
+func (s *ColorSchema) IsAlphaAllowed() bool {
+	return !s.noAlpha
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *ColorSchema) Transform(fn TransformFunc) *ColorSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *ColorSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *ColorSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *ColorSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *ColorSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a color and returns it unchanged
+func (s *ColorSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the color; Parse runs the refine/transform pipeline on top
+func (s *ColorSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := colorRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	str, ok := value.(string)
+	if !ok {
+		message := colorTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	notation, alpha, ok := parseColor(str)
+	if !ok {
+		message := colorFormatError(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+
+	var errors []ValidationError
+
+	if len(s.notations) > 0 && !containsColorNotation(s.notations, notation) {
+		message := colorNotationError(s.notations)(ctx.Locale)
+		if !isEmptyErrorMessage(s.notationError) {
+			message = resolveErrorMessage(s.notationError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeNotation))
+	}
+
+	if s.noAlpha && alpha {
+		message := colorAlphaError(ctx.Locale)
+		if !isEmptyErrorMessage(s.alphaError) {
+			message = resolveErrorMessage(s.alphaError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeAlpha))
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errors}
+	}
+	return ParseResult{Valid: true, Value: str, Errors: nil}
+}
+
+func containsColorNotation(notations []ColorNotation, notation ColorNotation) bool {
+	for _, n := range notations {
+		if n == notation {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	hexColorRegex  = regexp.MustCompile(`^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	cssNumberRegex = regexp.MustCompile(`^[+-]?(\d+|\d*\.\d+)$`)
+)
+
+// parseColor reports the notation of a color and whether it has an alpha channel
+func parseColor(str string) (ColorNotation, bool, bool) {
+	if strings.HasPrefix(str, "#") {
+		return ColorHex, len(str) == 5 || len(str) == 9, hexColorRegex.MatchString(str)
+	}
+
+	open := strings.IndexByte(str, '(')
+	if open < 0 || !strings.HasSuffix(str, ")") {
+		return "", false, false
+	}
+	var notation ColorNotation
+	switch strings.ToLower(str[:open]) {
+	case "rgb", "rgba":
+		notation = ColorRGB
+	case "hsl", "hsla":
+		notation = ColorHSL
+	default:
+		return "", false, false
+	}
+
+	// Legacy syntax separates all components with commas; modern syntax separates
+	// channels with spaces and the alpha with a slash
+	args := str[open+1 : len(str)-1]
+	var channels []string
+	alpha := ""
+	if strings.Contains(args, ",") {
+		channels = strings.Split(args, ",")
+		for i := range channels {
+			channels[i] = strings.TrimSpace(channels[i])
+		}
+		if len(channels) == 4 {
+			channels, alpha = channels[:3], channels[3]
+			if alpha == "" {
+				return "", false, false
+			}
+		}
+	} else {
+		parts := strings.Split(args, "/")
+		if len(parts) > 2 {
+			return "", false, false
+		}
+		channels = strings.Fields(parts[0])
+		if len(parts) == 2 {
+			if alpha = strings.TrimSpace(parts[1]); alpha == "" || strings.ContainsAny(alpha, " \t\n") {
+				return "", false, false
+			}
+		}
+	}
+	if len(channels) != 3 {
+		return "", false, false
+	}
+
+	for i, channel := range channels {
+		var ok bool
+		switch {
+		case notation == ColorRGB:
+			ok = cssNumberInRange(channel, 255) || cssPercentage(channel)
+		case i == 0:
+			hue := strings.TrimSuffix(strings.ToLower(channel), "deg")
+			ok = cssNumberRegex.MatchString(hue)
+		default:
+			ok = cssPercentage(channel)
+		}
+		if !ok {
+			return "", false, false
+		}
+	}
+	if alpha != "" && !cssNumberInRange(alpha, 1) && !cssPercentage(alpha) {
+		return "", false, false
+	}
+	return notation, alpha != "", true
+}
+
+// cssNumberInRange reports whether str is a plain CSS number between 0 and max
+func cssNumberInRange(str string, max float64) bool {
+	if !cssNumberRegex.MatchString(str) {
+		return false
+	}
+	n, err := strconv.ParseFloat(str, 64)
+	return err == nil && !math.Signbit(n) && n <= max
+}
+
+// cssPercentage reports whether str is a CSS percentage between 0% and 100%
+func cssPercentage(str string) bool {
+	return strings.HasSuffix(str, "%") && cssNumberInRange(strings.TrimSuffix(str, "%"), 100)
+}
+
+// JSON generates JSON Schema representation. Hex-only schemas get an exact pattern;
+// otherwise the non-standard "color" format is used.
+func (s *ColorSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	if len(s.notations) == 1 && s.notations[0] == ColorHex {
+		if s.noAlpha {
+			schema["pattern"] = "^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$"
+		} else {
+			schema["pattern"] = hexColorRegex.String()
+		}
+	} else {
+		schema["format"] = "color"
+	}
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
@@ -0,0 +1,121 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColorSchema_Formats(t *testing.T) {
+	ctx := DefaultValidationContext()
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"#fff", true},
+		{"#FFF8", true},
+		{"#1e90ff", true},
+		{"#1E90FF80", true},
+		{"#1e90f", false},
+		{"#1e90ffz0", false},
+		{"1e90ff", false},
+		{"rgb(30, 144, 255)", true},
+		{"rgba(30, 144, 255, 0.5)", true},
+		{"RGB(30,144,255)", true},
+		{"rgb(30 144 255)", true},
+		{"rgb(30 144 255 / 50%)", true},
+		{"rgb(12% 56% 100%)", true},
+		{"rgb(256, 0, 0)", false},
+		{"rgb(-1, 0, 0)", false},
+		{"rgb(30, 144)", false},
+		{"rgb(30, 144, 255, )", false},
+		{"rgb(30 144 255 / 1.5)", false},
+		{"rgb(30 144 255 / 0.5 / 1)", false},
+		{"rgb(30 144 255", false},
+		{"hsl(210, 100%, 56%)", true},
+		{"hsla(210, 100%, 56%, .3)", true},
+		{"hsl(210deg 100% 56% / 30%)", true},
+		{"hsl(-30 100% 56%)", true},
+		{"hsl(210, 100, 56)", false},
+		{"hsl(210, 101%, 56%)", false},
+		{"red", false},
+		{"lab(29% 39 20)", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result := Color().Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%q) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if result.Valid && result.Value != tt.value {
+				t.Errorf("Value = %v, want %q", result.Value, tt.value)
+			}
+			if !result.Valid && result.Errors[0].Code != "format" {
+				t.Errorf("code = %q, want format", result.Errors[0].Code)
+			}
+		})
+	}
+}
+
+func TestColorSchema_Constraints(t *testing.T) {
+	ctx := DefaultValidationContext()
+	tests := []struct {
+		name   string
+		schema *ColorSchema
+		value  interface{}
+		errors []string
+	}{
+		{"hex allowed", Color().Notations(ColorHex), "#336699", nil},
+		{"rgb rejected", Color().Notations(ColorHex), "rgb(51, 102, 153)", []string{" notation"}},
+		{"rgb or hsl", Color().Notations(ColorRGB, ColorHSL), "hsl(210, 50%, 40%)", nil},
+		{"opaque", Color().NoAlpha(), "#336699", nil},
+		{"hex alpha", Color().NoAlpha(), "#33669980", []string{" alpha"}},
+		{"short hex alpha", Color().NoAlpha(), "#3698", []string{" alpha"}},
+		{"rgba", Color().NoAlpha(), "rgba(51, 102, 153, 1)", []string{" alpha"}},
+		{"both", Color().Notations(ColorHex).NoAlpha(), "rgb(51 102 153 / 1)", []string{" alpha", " notation"}},
+		{"wrong type", Color(), 0x336699, []string{" invalid_type"}},
+		{"nil", Color(), nil, []string{" required"}},
+		{"nil optional", Color().Optional(), nil, nil},
+		{"default", Color().Default("#000"), nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if codes := errorKeys(result.Errors); len(codes) > 0 || len(tt.errors) > 0 {
+				if !reflect.DeepEqual(codes, tt.errors) {
+					t.Errorf("errors = %v, want %v", codes, tt.errors)
+				}
+			}
+			if result.Valid != (len(tt.errors) == 0) {
+				t.Errorf("Valid = %v", result.Valid)
+			}
+		})
+	}
+
+	result := Color().Notations(ColorHex).NotationError("use a hex color").Parse("hsl(0, 0%, 0%)", ctx)
+	if result.Errors[0].Message != "use a hex color" {
+		t.Errorf("message = %q", result.Errors[0].Message)
+	}
+}
+
+func TestColorSchema_JSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   *ColorSchema
+		expected map[string]interface{}
+	}{
+		{"any", Color(), map[string]interface{}{"type": "string", "format": "color"}},
+		{"hex", Color().Notations(ColorHex), map[string]interface{}{"type": "string", "pattern": "^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$"}},
+		{"opaque hex", Color().Notations(ColorHex).NoAlpha(), map[string]interface{}{"type": "string", "pattern": "^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$"}},
+		{"nullable", Color().Nullable(), map[string]interface{}{"type": []string{"string", "null"}, "format": "color"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schema.JSON(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("JSON() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,765 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// jsonSchemaCompiler turns a decoded JSON Schema document into a schema tree.
+// Local references are compiled once, on first use, into a shared registry.
+type jsonSchemaCompiler struct {
+	root     interface{}
+	registry *SchemaRegistry
+	compiled map[string]bool
+}
+
+// CompileJSONSchema parses a JSON Schema document (draft-07 or 2020-12) and builds the
+// equivalent schema tree, so externally maintained schemas can be used for validation.
+//
+// Supported keywords: type (including type lists and "null"), enum, const, title,
+// description, default, examples; minLength, maxLength, pattern, format; minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, multipleOf; items, prefixItems,
+// additionalItems, minItems, maxItems, uniqueItems; properties, required,
+// additionalProperties, minProperties, maxProperties; allOf, anyOf, oneOf, not,
+// if/then/else; and local $ref pointers ("#", "#/$defs/...", "#/definitions/...").
+//
+// Unknown keywords are ignored. When "type" is omitted it is inferred from the
+// type-specific keywords present (e.g. "properties" implies an object). Remote
+// references are not supported and produce an error.
+func CompileJSONSchema(data []byte) (Parseable, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("schema: invalid JSON Schema document: %w", err)
+	}
+
+	c := &jsonSchemaCompiler{
+		root:     doc,
+		registry: NewSchemaRegistry(),
+		compiled: make(map[string]bool),
+	}
+	return c.compile(doc, "#")
+}
+
+// compile builds the schema for a single JSON Schema node located at path
+func (c *jsonSchemaCompiler) compile(node interface{}, path string) (Parseable, error) {
+	switch n := node.(type) {
+	case bool:
+		if n {
+			return Any(), nil
+		}
+		return Not(Any()), nil
+	case map[string]interface{}:
+		return c.compileObject(n, path)
+	default:
+		return nil, fmt.Errorf("schema: invalid schema at %s: expected an object or boolean", path)
+	}
+}
+
+// compileObject builds the schema for an object-form JSON Schema node
+func (c *jsonSchemaCompiler) compileObject(m map[string]interface{}, path string) (Parseable, error) {
+	var parts []Parseable
+
+	if ref, ok := m["$ref"]; ok {
+		refStr, ok := ref.(string)
+		if !ok {
+			return nil, fmt.Errorf("schema: invalid \"$ref\" at %s: must be a string", path)
+		}
+		refSchema, err := c.ref(refStr, path)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, refSchema)
+	}
+
+	base, err := c.compileTypes(m, path)
+	if err != nil {
+		return nil, err
+	}
+	if base != nil {
+		parts = append(parts, base)
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		raw, ok := m[keyword]
+		if !ok {
+			continue
+		}
+		schemas, err := c.compileList(raw, path+"/"+keyword)
+		if err != nil {
+			return nil, err
+		}
+		switch keyword {
+		case "allOf":
+			parts = append(parts, AllOf(schemas...))
+		case "anyOf":
+			parts = append(parts, AnyOf(schemas...))
+		case "oneOf":
+			parts = append(parts, OneOf(schemas...))
+		}
+	}
+
+	if raw, ok := m["not"]; ok {
+		notSchema, err := c.compile(raw, path+"/not")
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, Not(notSchema))
+	}
+
+	if raw, ok := m["if"]; ok {
+		ifSchema, err := c.compile(raw, path+"/if")
+		if err != nil {
+			return nil, err
+		}
+		cond := Conditional(ifSchema)
+		if raw, ok := m["then"]; ok {
+			thenSchema, err := c.compile(raw, path+"/then")
+			if err != nil {
+				return nil, err
+			}
+			cond.Then(thenSchema)
+		}
+		if raw, ok := m["else"]; ok {
+			elseSchema, err := c.compile(raw, path+"/else")
+			if err != nil {
+				return nil, err
+			}
+			cond.Else(elseSchema)
+		}
+		parts = append(parts, cond)
+	}
+
+	// enum/const that could not be expressed on a typed schema are checked by value
+	if base == nil || !typedEnumApplied(base, m) {
+		if values, ok := m["enum"].([]interface{}); ok {
+			parts = append(parts, Any().Refine(func(v interface{}) bool {
+				for _, allowed := range values {
+					if jsonValuesEqual(v, allowed) {
+						return true
+					}
+				}
+				return false
+			}, anyEnumError))
+		}
+		if constVal, ok := m["const"]; ok {
+			parts = append(parts, Any().Refine(func(v interface{}) bool {
+				return jsonValuesEqual(v, constVal)
+			}, anyConstError))
+		}
+	}
+
+	var result Parseable
+	switch len(parts) {
+	case 0:
+		result = Any()
+	case 1:
+		result = parts[0]
+	default:
+		result = AllOf(parts...)
+	}
+
+	applyJSONSchemaMetadata(result, m)
+	return result, nil
+}
+
+// compileList compiles an array of subschemas (allOf/anyOf/oneOf/prefixItems)
+func (c *jsonSchemaCompiler) compileList(raw interface{}, path string) ([]Parseable, error) {
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema: invalid schema list at %s: expected an array", path)
+	}
+	schemas := make([]Parseable, 0, len(list))
+	for i, item := range list {
+		s, err := c.compile(item, fmt.Sprintf("%s/%d", path, i))
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, s)
+	}
+	return schemas, nil
+}
+
+// ref compiles the target of a local reference (once) and returns a RefSchema to it
+func (c *jsonSchemaCompiler) ref(ref string, path string) (Parseable, error) {
+	if ref == "#" {
+		ref = "#/"
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("schema: unsupported $ref %q at %s: only local references are supported", ref, path)
+	}
+
+	name := ref[2:]
+	if !c.compiled[name] {
+		c.compiled[name] = true
+		target, err := c.resolvePointer(name)
+		if err != nil {
+			return nil, fmt.Errorf("schema: unresolvable $ref %q at %s: %w", ref, path, err)
+		}
+		s, err := c.compile(target, "#/"+name)
+		if err != nil {
+			return nil, err
+		}
+		c.registry.Define(name, s)
+	}
+	return Ref(ref, c.registry), nil
+}
+
+// resolvePointer walks a JSON pointer (without the leading "#/") from the document root
+func (c *jsonSchemaCompiler) resolvePointer(pointer string) (interface{}, error) {
+	node := c.root
+	if pointer == "" {
+		return node, nil
+	}
+	for _, token := range strings.Split(pointer, "/") {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch n := node.(type) {
+		case map[string]interface{}:
+			next, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%q not found", token)
+			}
+			node = next
+		case []interface{}:
+			var index int
+			if _, err := fmt.Sscanf(token, "%d", &index); err != nil || index < 0 || index >= len(n) {
+				return nil, fmt.Errorf("invalid array index %q", token)
+			}
+			node = n[index]
+		default:
+			return nil, fmt.Errorf("cannot descend into %q", token)
+		}
+	}
+	return node, nil
+}
+
+// compileTypes builds the typed part of a node from "type" (or the keywords that imply one)
+func (c *jsonSchemaCompiler) compileTypes(m map[string]interface{}, path string) (Parseable, error) {
+	var types []string
+	switch t := m["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("schema: invalid \"type\" at %s: entries must be strings", path)
+			}
+			types = append(types, name)
+		}
+	case nil:
+		if inferred := inferJSONSchemaType(m); inferred != "" {
+			types = []string{inferred}
+		}
+	default:
+		return nil, fmt.Errorf("schema: invalid \"type\" at %s: must be a string or array", path)
+	}
+
+	if len(types) == 0 {
+		return nil, nil
+	}
+
+	nullable := false
+	var schemas []Parseable
+	for _, name := range types {
+		if name == "null" {
+			nullable = true
+			continue
+		}
+		s, err := c.compileType(name, m, path)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, s)
+	}
+
+	switch {
+	case len(schemas) == 0:
+		return Null(), nil
+	case len(schemas) == 1:
+		if nullable {
+			if setter, ok := schemas[0].(SetNullable); ok {
+				setter.SetNullable()
+			} else {
+				return AnyOf(schemas[0]).Nullable(), nil
+			}
+		}
+		return schemas[0], nil
+	default:
+		anyOf := AnyOf(schemas...)
+		if nullable {
+			anyOf.Nullable()
+		}
+		return anyOf, nil
+	}
+}
+
+// compileType builds the schema for one JSON Schema primitive type
+func (c *jsonSchemaCompiler) compileType(name string, m map[string]interface{}, path string) (Parseable, error) {
+	switch name {
+	case "string":
+		return compileStringKeywords(m, path)
+	case "integer":
+		return compileIntegerKeywords(m, path)
+	case "number":
+		return compileNumberKeywords(m, path)
+	case "boolean":
+		b := Bool()
+		if values, ok := m["enum"].([]interface{}); ok {
+			if bools, ok := jsonEnumOf[bool](values); ok {
+				b.Enum(bools)
+			}
+		}
+		if v, ok := m["const"].(bool); ok {
+			b.Const(v)
+		}
+		return b, nil
+	case "array":
+		return c.compileArray(m, path)
+	case "object":
+		return c.compileObjectType(m, path)
+	default:
+		return nil, fmt.Errorf("schema: unsupported type %q at %s", name, path)
+	}
+}
+
+// compileStringKeywords builds a string schema. Empty strings are valid JSON Schema
+// strings, validated against the keywords like any other, so only a missing or null
+// value is absent.
+func compileStringKeywords(m map[string]interface{}, path string) (Parseable, error) {
+	s := String()
+	s.emptyIsValue = true
+	if n, ok, err := jsonSchemaInt(m, "minLength", path); err != nil {
+		return nil, err
+	} else if ok {
+		s.MinLength(n)
+	}
+	if n, ok, err := jsonSchemaInt(m, "maxLength", path); err != nil {
+		return nil, err
+	} else if ok {
+		s.MaxLength(n)
+	}
+	if pattern, ok := m["pattern"].(string); ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("schema: invalid \"pattern\" at %s: %w", path, err)
+		}
+		s.Pattern(pattern)
+	}
+	if format, ok := m["format"].(string); ok {
+		s.Format(StringFormat(format))
+	}
+	if values, ok := m["enum"].([]interface{}); ok {
+		if strs, ok := jsonEnumOf[string](values); ok {
+			s.Enum(strs)
+		}
+	}
+	if v, ok := m["const"].(string); ok {
+		s.Const(v)
+	}
+	return s, nil
+}
+
+// compileIntegerKeywords builds an integer schema; fractional bounds are rounded inwards
+func compileIntegerKeywords(m map[string]interface{}, path string) (Parseable, error) {
+	s := Int()
+	lower, lowerExclusive, upper, upperExclusive, err := jsonSchemaBounds(m, path)
+	if err != nil {
+		return nil, err
+	}
+	if lower != nil {
+		min := math.Ceil(*lower)
+		if lowerExclusive && min == *lower {
+			min++
+		}
+		s.Min(int(min))
+	}
+	if upper != nil {
+		max := math.Floor(*upper)
+		if upperExclusive && max == *upper {
+			max--
+		}
+		s.Max(int(max))
+	}
+	if multiple, ok := m["multipleOf"].(float64); ok && multiple == math.Trunc(multiple) && multiple > 0 {
+		s.MultipleOf(int(multiple))
+	}
+	if values, ok := m["enum"].([]interface{}); ok {
+		if ints, ok := jsonIntEnum(values); ok {
+			s.Enum(ints)
+		}
+	}
+	if v, ok := m["const"].(float64); ok && v == math.Trunc(v) {
+		s.Const(int(v))
+	}
+	return s, nil
+}
+
+// compileNumberKeywords builds a number schema
+func compileNumberKeywords(m map[string]interface{}, path string) (Parseable, error) {
+	s := Number()
+	lower, lowerExclusive, upper, upperExclusive, err := jsonSchemaBounds(m, path)
+	if err != nil {
+		return nil, err
+	}
+	if lower != nil {
+		if lowerExclusive {
+			s.ExclusiveMin(*lower)
+		} else {
+			s.Min(*lower)
+		}
+	}
+	if upper != nil {
+		if upperExclusive {
+			s.ExclusiveMax(*upper)
+		} else {
+			s.Max(*upper)
+		}
+	}
+	if multiple, ok := m["multipleOf"].(float64); ok && multiple > 0 {
+		s.MultipleOf(multiple)
+	}
+	if values, ok := m["enum"].([]interface{}); ok {
+		if floats, ok := jsonEnumOf[float64](values); ok {
+			s.Enum(floats)
+		}
+	}
+	if v, ok := m["const"].(float64); ok {
+		s.Const(v)
+	}
+	return s, nil
+}
+
+// compileArray builds an array schema, or a tuple when positional items are given
+func (c *jsonSchemaCompiler) compileArray(m map[string]interface{}, path string) (Parseable, error) {
+	// Positional items: "prefixItems" (2020-12) or an "items" array (draft-07)
+	prefixKey, rest := "prefixItems", "items"
+	if _, ok := m["items"].([]interface{}); ok {
+		prefixKey, rest = "items", "additionalItems"
+	}
+	if raw, ok := m[prefixKey]; ok {
+		items, err := c.compileList(raw, path+"/"+prefixKey)
+		if err != nil {
+			return nil, err
+		}
+		tuple := Tuple(items...)
+		if restRaw, ok := m[rest].(map[string]interface{}); ok {
+			restSchema, err := c.compile(restRaw, path+"/"+rest)
+			if err != nil {
+				return nil, err
+			}
+			tuple.Rest(restSchema)
+		} else if allowed, ok := m[rest].(bool); !ok || allowed {
+			tuple.AllowAdditionalItems()
+		}
+		if unique, ok := m["uniqueItems"].(bool); ok && unique {
+			tuple.UniqueItems()
+		}
+		return tuple, nil
+	}
+
+	var itemSchema Parseable = Any()
+	if raw, ok := m["items"]; ok {
+		compiled, err := c.compile(raw, path+"/items")
+		if err != nil {
+			return nil, err
+		}
+		itemSchema = compiled
+	}
+
+	arr := Array(itemSchema)
+	if n, ok, err := jsonSchemaInt(m, "minItems", path); err != nil {
+		return nil, err
+	} else if ok {
+		arr.MinItems(n)
+	}
+	if n, ok, err := jsonSchemaInt(m, "maxItems", path); err != nil {
+		return nil, err
+	} else if ok {
+		arr.MaxItems(n)
+	}
+	if unique, ok := m["uniqueItems"].(bool); ok && unique {
+		arr.UniqueItems()
+	}
+	return arr, nil
+}
+
+// compileObjectType builds an object schema, or a record when only additionalProperties
+// describes the values
+func (c *jsonSchemaCompiler) compileObjectType(m map[string]interface{}, path string) (Parseable, error) {
+	minProps, hasMin, err := jsonSchemaInt(m, "minProperties", path)
+	if err != nil {
+		return nil, err
+	}
+	maxProps, hasMax, err := jsonSchemaInt(m, "maxProperties", path)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, _ := m["properties"].(map[string]interface{})
+	additional, hasAdditional := m["additionalProperties"]
+	_, additionalIsSchema := additional.(map[string]interface{})
+
+	if len(properties) == 0 && hasAdditional && additionalIsSchema {
+		valueSchema, err := c.compile(additional, path+"/additionalProperties")
+		if err != nil {
+			return nil, err
+		}
+		record := Record(String(), valueSchema)
+		if hasMin {
+			record.MinProperties(minProps)
+		}
+		if hasMax {
+			record.MaxProperties(maxProps)
+		}
+		return record, nil
+	}
+
+	required := map[string]bool{}
+	if list, ok := m["required"].([]interface{}); ok {
+		for _, item := range list {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("schema: invalid \"required\" at %s: entries must be strings", path)
+			}
+			required[name] = true
+		}
+	}
+
+	obj := Object()
+	// Sorted so that compiled schemas (and their JSON output) are deterministic
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propSchema, err := c.compile(properties[name], path+"/properties/"+name)
+		if err != nil {
+			return nil, err
+		}
+		if required[name] {
+			obj.RequiredProperty(name, propSchema)
+		} else {
+			obj.OptionalProperty(name, propSchema)
+		}
+	}
+
+	// Required names without a property schema only need to be present
+	requiredOnly := make([]string, 0, len(required))
+	for name := range required {
+		if _, ok := properties[name]; !ok {
+			requiredOnly = append(requiredOnly, name)
+		}
+	}
+	sort.Strings(requiredOnly)
+	for _, name := range requiredOnly {
+		obj.RequiredProperty(name, Any())
+	}
+
+	// JSON Schema allows additional properties unless told otherwise
+	if allowed, ok := additional.(bool); ok && !allowed {
+		obj.Strict()
+	} else {
+		obj.Passthrough()
+	}
+	if hasMin {
+		obj.MinProperties(minProps)
+	}
+	if hasMax {
+		obj.MaxProperties(maxProps)
+	}
+	return obj, nil
+}
+
+// inferJSONSchemaType guesses the type of an untyped node from its type-specific keywords
+func inferJSONSchemaType(m map[string]interface{}) string {
+	has := func(keys ...string) bool {
+		for _, key := range keys {
+			if _, ok := m[key]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("properties", "required", "additionalProperties", "minProperties", "maxProperties"):
+		return "object"
+	case has("items", "prefixItems", "additionalItems", "minItems", "maxItems", "uniqueItems"):
+		return "array"
+	case has("minLength", "maxLength", "pattern", "format"):
+		return "string"
+	case has("minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"):
+		return "number"
+	}
+	return ""
+}
+
+// applyJSONSchemaMetadata copies annotation keywords onto schemas that support them
+func applyJSONSchemaMetadata(s Parseable, m map[string]interface{}) {
+	if title, ok := m["title"].(string); ok {
+		if setter, ok := s.(SetTitle); ok {
+			setter.SetTitle(title)
+		}
+	}
+	if description, ok := m["description"].(string); ok {
+		if setter, ok := s.(SetDescription); ok {
+			setter.SetDescription(description)
+		}
+	}
+	if defaultVal, ok := m["default"]; ok {
+		if setter, ok := s.(SetDefault); ok {
+			setter.SetDefault(defaultVal)
+		}
+	}
+	if examples, ok := m["examples"].([]interface{}); ok {
+		if setter, ok := s.(SetExample); ok {
+			for _, example := range examples {
+				setter.SetExample(example)
+			}
+		}
+	}
+}
+
+// typedEnumApplied reports whether enum/const (if any) were fully expressed by the typed schema
+func typedEnumApplied(s Parseable, m map[string]interface{}) bool {
+	values, hasEnum := m["enum"].([]interface{})
+	constVal, hasConst := m["const"]
+	if !hasEnum && !hasConst {
+		return true
+	}
+
+	var ok bool
+	switch s.(type) {
+	case *StringSchema:
+		ok = (!hasEnum || isJSONEnumOf[string](values)) && (!hasConst || isJSONEnumOf[string]([]interface{}{constVal}))
+	case *BoolSchema:
+		ok = (!hasEnum || isJSONEnumOf[bool](values)) && (!hasConst || isJSONEnumOf[bool]([]interface{}{constVal}))
+	case *NumberSchema:
+		ok = (!hasEnum || isJSONEnumOf[float64](values)) && (!hasConst || isJSONEnumOf[float64]([]interface{}{constVal}))
+	case *IntSchema:
+		_, enumOK := jsonIntEnum(values)
+		_, constOK := jsonIntEnum([]interface{}{constVal})
+		ok = (!hasEnum || enumOK) && (!hasConst || constOK)
+	}
+	return ok
+}
+
+// jsonSchemaBounds reads minimum/maximum and both exclusive forms: numeric (draft-06+)
+// and boolean modifiers of minimum/maximum (draft-04)
+func jsonSchemaBounds(m map[string]interface{}, path string) (lower *float64, lowerExclusive bool, upper *float64, upperExclusive bool, err error) {
+	read := func(key string) (*float64, error) {
+		raw, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		f, ok := raw.(float64)
+		if !ok {
+			return nil, fmt.Errorf("schema: invalid %q at %s: must be a number", key, path)
+		}
+		return &f, nil
+	}
+
+	if lower, err = read("minimum"); err != nil {
+		return
+	}
+	if upper, err = read("maximum"); err != nil {
+		return
+	}
+	switch v := m["exclusiveMinimum"].(type) {
+	case bool:
+		lowerExclusive = v && lower != nil
+	case float64:
+		if lower == nil || v >= *lower {
+			lower, lowerExclusive = &v, true
+		}
+	}
+	switch v := m["exclusiveMaximum"].(type) {
+	case bool:
+		upperExclusive = v && upper != nil
+	case float64:
+		if upper == nil || v <= *upper {
+			upper, upperExclusive = &v, true
+		}
+	}
+	return
+}
+
+// jsonSchemaInt reads a non-negative integer keyword
+func jsonSchemaInt(m map[string]interface{}, key string, path string) (int, bool, error) {
+	raw, ok := m[key]
+	if !ok {
+		return 0, false, nil
+	}
+	f, ok := raw.(float64)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		return 0, false, fmt.Errorf("schema: invalid %q at %s: must be a non-negative integer", key, path)
+	}
+	return int(f), true, nil
+}
+
+// jsonEnumOf converts decoded enum values to a typed slice if they all share type T
+func jsonEnumOf[T any](values []interface{}) ([]T, bool) {
+	result := make([]T, 0, len(values))
+	for _, v := range values {
+		typed, ok := v.(T)
+		if !ok {
+			return nil, false
+		}
+		result = append(result, typed)
+	}
+	return result, true
+}
+
+// isJSONEnumOf reports whether all decoded values have type T
+func isJSONEnumOf[T any](values []interface{}) bool {
+	_, ok := jsonEnumOf[T](values)
+	return ok
+}
+
+// jsonIntEnum converts decoded enum values to ints if they are all whole numbers
+func jsonIntEnum(values []interface{}) ([]int, bool) {
+	floats, ok := jsonEnumOf[float64](values)
+	if !ok {
+		return nil, false
+	}
+	ints := make([]int, 0, len(floats))
+	for _, f := range floats {
+		if f != math.Trunc(f) {
+			return nil, false
+		}
+		ints = append(ints, int(f))
+	}
+	return ints, true
+}
+
+// jsonValuesEqual compares two values with JSON semantics (all numbers compare as float64)
+func jsonValuesEqual(a, b interface{}) bool {
+	if fa, ok := toFloat64(a); ok {
+		fb, ok := toFloat64(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// toFloat64 converts any Go numeric value to float64
+func toFloat64(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+		return float64(rv.Int()), true
+	case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,186 @@
+package schema
+
+import (
+	"testing"
+)
+
+const compileTestDocument = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["id", "name", "contact"],
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"name": {"type": "string", "minLength": 2, "maxLength": 20},
+		"nickname": {"type": ["string", "null"]},
+		"score": {"type": "number", "exclusiveMinimum": 0, "maximum": 100},
+		"role": {"enum": ["admin", "member"]},
+		"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2, "uniqueItems": true},
+		"point": {"type": "array", "prefixItems": [{"type": "number"}, {"type": "number"}], "items": false},
+		"contact": {"$ref": "#/$defs/contact"},
+		"labels": {"type": "object", "additionalProperties": {"type": "integer"}}
+	},
+	"additionalProperties": false,
+	"$defs": {
+		"contact": {
+			"oneOf": [
+				{"properties": {"email": {"type": "string", "format": "email"}}, "required": ["email"], "additionalProperties": false},
+				{"properties": {"phone": {"type": "string", "pattern": "^[0-9]+$"}}, "required": ["phone"], "additionalProperties": false}
+			]
+		}
+	}
+}`
+
+func TestCompileJSONSchema_Parse(t *testing.T) {
+	s, err := CompileJSONSchema([]byte(compileTestDocument))
+	if err != nil {
+		t.Fatalf("CompileJSONSchema() error = %v", err)
+	}
+	ctx := DefaultValidationContext()
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"id":      1.0,
+			"name":    "Ada",
+			"contact": map[string]interface{}{"email": "ada@example.com"},
+		}
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		m := valid()
+		m[key] = value
+		return m
+	}
+	without := func(key string) map[string]interface{} {
+		m := valid()
+		delete(m, key)
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{"minimal valid", valid(), true},
+		{"missing required", without("name"), false},
+		{"integer below minimum", with("id", 0.0), false},
+		{"fractional integer", with("id", 1.5), false},
+		{"string too short", with("name", "A"), false},
+		{"empty string too short", with("name", ""), false},
+		{"string null", with("name", nil), false},
+		{"nullable string empty", with("nickname", ""), true},
+		{"nullable string null", with("nickname", nil), true},
+		{"nullable string wrong type", with("nickname", 5.0), false},
+		{"exclusive minimum boundary", with("score", 0.0), false},
+		{"exclusive minimum above", with("score", 0.5), true},
+		{"enum without type", with("role", "admin"), true},
+		{"enum mismatch", with("role", "root"), false},
+		{"array items", with("tags", []interface{}{"a", "b"}), true},
+		{"array too many items", with("tags", []interface{}{"a", "b", "c"}), false},
+		{"array duplicate items", with("tags", []interface{}{"a", "a"}), false},
+		{"tuple", with("point", []interface{}{1.0, 2.0}), true},
+		{"tuple extra item", with("point", []interface{}{1.0, 2.0, 3.0}), false},
+		{"ref oneOf phone", with("contact", map[string]interface{}{"phone": "123"}), true},
+		{"ref oneOf no match", with("contact", map[string]interface{}{"phone": "abc"}), false},
+		{"record values", with("labels", map[string]interface{}{"a": 1.0}), true},
+		{"record invalid value", with("labels", map[string]interface{}{"a": "x"}), false},
+		{"additional property", with("extra", true), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Errorf("Parse(%v) = %v, want %v. Errors: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+		})
+	}
+}
+
+func TestCompileJSONSchema_String(t *testing.T) {
+	s, err := CompileJSONSchema([]byte(`{"type": "string"}`))
+	if err != nil {
+		t.Fatalf("CompileJSONSchema() error = %v", err)
+	}
+	if result := s.Parse(nil, DefaultValidationContext()); result.Valid {
+		t.Error("null accepted as a string")
+	}
+	if result := s.Parse("", DefaultValidationContext()); !result.Valid || result.Value != "" {
+		t.Errorf("Parse(\"\") = %+v, want a valid empty string", result)
+	}
+
+	// The compiled schema survives a serialization round trip
+	data, err := MarshalSchema(s)
+	if err != nil {
+		t.Fatalf("MarshalSchema() error = %v", err)
+	}
+	restored, err := UnmarshalSchema(data)
+	if err != nil {
+		t.Fatalf("UnmarshalSchema() error = %v", err)
+	}
+	if !restored.Parse("", DefaultValidationContext()).Valid || restored.Parse(nil, DefaultValidationContext()).Valid {
+		t.Error("deserialized schema lost the empty string handling")
+	}
+}
+
+func TestCompileJSONSchema_Draft07(t *testing.T) {
+	doc := `{
+		"definitions": {"positive": {"type": "integer", "minimum": 0, "exclusiveMinimum": true}},
+		"type": "array",
+		"items": [{"$ref": "#/definitions/positive"}, {"type": "boolean"}],
+		"additionalItems": false
+	}`
+	s, err := CompileJSONSchema([]byte(doc))
+	if err != nil {
+		t.Fatalf("CompileJSONSchema() error = %v", err)
+	}
+	ctx := DefaultValidationContext()
+
+	if result := s.Parse([]interface{}{1.0, true}, ctx); !result.Valid {
+		t.Errorf("expected valid tuple, got %v", result.Errors)
+	}
+	if result := s.Parse([]interface{}{0.0, true}, ctx); result.Valid {
+		t.Error("expected draft-04/07 boolean exclusiveMinimum to reject 0")
+	}
+}
+
+func TestCompileJSONSchema_TupleRest(t *testing.T) {
+	for _, doc := range []string{
+		`{"type": "array", "prefixItems": [{"type": "string"}], "items": {"type": "integer"}}`,
+		`{"type": "array", "items": [{"type": "string"}], "additionalItems": {"type": "integer"}}`,
+	} {
+		s, err := CompileJSONSchema([]byte(doc))
+		if err != nil {
+			t.Fatalf("CompileJSONSchema(%s) error = %v", doc, err)
+		}
+		ctx := DefaultValidationContext()
+		if result := s.Parse([]interface{}{"a", 1.0, 2.0}, ctx); !result.Valid {
+			t.Errorf("%s: expected valid rest items, got %v", doc, result.Errors)
+		}
+		if result := s.Parse([]interface{}{"a", "b"}, ctx); result.Valid {
+			t.Errorf("%s: expected the rest schema to reject a string", doc)
+		}
+	}
+}
+
+func TestCompileJSONSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"invalid json", `{"type":`},
+		{"not a schema", `42`},
+		{"unknown type", `{"type": "decimal"}`},
+		{"remote ref", `{"$ref": "https://example.com/schema.json"}`},
+		{"missing ref target", `{"$ref": "#/$defs/missing"}`},
+		{"bad keyword value", `{"type": "string", "minLength": -1}`},
+		{"invalid pattern", `{"type": "string", "pattern": "(?=x)"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CompileJSONSchema([]byte(tt.doc)); err == nil {
+				t.Errorf("CompileJSONSchema(%s) expected error", tt.doc)
+			}
+		})
+	}
+}
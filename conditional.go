@@ -1,6 +1,9 @@
 package schema
 
 import (
+	"encoding/json"
+	"slices"
+
 	"github.com/nyxstack/i18n"
 )
 
@@ -19,146 +22,499 @@ var ConditionalErrors = struct {
 	ElseFailed: conditionalElseFailedError,
 }
 
-// ConditionalSchema represents an if-then-else validation schema
+// ConditionalSchema represents an if-then-else validation schema, with optional
+// further cases tried in order when the 'if' condition does not match
 type ConditionalSchema struct {
+	Schema
 	ifSchema   Parseable
 	thenSchema Parseable
+	cases      []conditionalCase // Cases after the if/then pair
 	elseSchema Parseable
+	nullable   bool // Allow null values
 	thenError  ErrorMessage
 	elseError  ErrorMessage
 }
 
-// Conditional creates a new Conditional schema with if condition
-func Conditional(ifSchema Parseable) *ConditionalSchema {
-	return &ConditionalSchema{
-		ifSchema: ifSchema,
+// conditionalCase is a condition and the schema applied when it matches
+type conditionalCase struct {
+	condition Parseable
+	schema    Parseable
+}
+
+// Conditional creates a new Conditional schema with if condition. Without a
+// condition, the cases are added with Case:
+//
+//	payment := Conditional().
+//		Case(FieldEquals("method", "card"), cardSchema).
+//		Case(FieldEquals("method", "iban"), ibanSchema).
+//		Else(Never()) // Applied when no condition matches
+func Conditional(ifSchema ...Parseable) *ConditionalSchema {
+	s := &ConditionalSchema{
+		Schema: Schema{
+			schemaType: "if",
+			required:   true, // Default to required
+		},
+	}
+	if len(ifSchema) > 0 {
+		s.ifSchema = ifSchema[0]
+	}
+	return s
+}
+
+// FieldEquals returns a condition matching objects whose field is present and equal
+// to value, for the cases of a Conditional schema. It is written as
+// {"properties": {field: {"const": value}}, "required": [field]} in JSON Schema.
+func FieldEquals(field string, value interface{}) *ObjectSchema {
+	return Object().Passthrough().Property(field, Literal(value))
+}
+
+// Predicate returns a condition matching the values for which fn returns true, for
+// the cases of a Conditional schema. JSON Schema cannot express Go functions, so the
+// condition is written as an empty schema, which matches every value.
+func Predicate(fn RefineFunc) *AnySchema {
+	return Any().Refine(fn)
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *ConditionalSchema) Clone() *ConditionalSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.cases = slices.Clone(s.cases)
+	return &c
+}
+
+// Freeze makes the schema and its if, then, case and else schemas immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *ConditionalSchema) Freeze() *ConditionalSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and its if, then, case and else schemas
+func (s *ConditionalSchema) freeze() {
+	if s.frozen {
+		return
 	}
+	s.Schema.freeze()
+	freezeSchemas(s.branchSchemas()...)
+}
+
+// Children returns the if, then, case and else schemas, which apply to the value itself
+func (s *ConditionalSchema) Children() []SchemaChild {
+	return sameValueChildren(s.branchSchemas()...)
+}
+
+// branchSchemas returns the if, then, case and else schemas in order, with nil for
+// the missing ones
+func (s *ConditionalSchema) branchSchemas() []Parseable {
+	schemas := []Parseable{s.ifSchema, s.thenSchema}
+	for _, c := range s.cases {
+		schemas = append(schemas, c.condition, c.schema)
+	}
+	return append(schemas, s.elseSchema)
+}
+
+// allCases returns the if/then pair followed by the other cases
+func (s *ConditionalSchema) allCases() []conditionalCase {
+	if s.ifSchema == nil {
+		return s.cases
+	}
+	return append([]conditionalCase{{s.ifSchema, s.thenSchema}}, s.cases...)
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *ConditionalSchema) Title(title string) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *ConditionalSchema) Description(description string) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *ConditionalSchema) ReadOnly() *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *ConditionalSchema) WriteOnly() *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *ConditionalSchema) Sensitive() *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *ConditionalSchema) Deprecated(reason string) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *ConditionalSchema) Meta(key string, value interface{}) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *ConditionalSchema) AsWarning(codes ...ErrorCode) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *ConditionalSchema) AsInfo(codes ...ErrorCode) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value, used in place of nil
+func (s *ConditionalSchema) Default(value interface{}) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
 }
 
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *ConditionalSchema) DefaultFunc(fn func() interface{}) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *ConditionalSchema) Example(example interface{}) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Required/Optional/Nullable control
+
+// Optional marks the schema as optional: nil is accepted, and the property may be
+// missing when the schema is used in an object
+func (s *ConditionalSchema) Optional() *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior). A required Conditional
+// schema checks nil against its if, then and else schemas like any other value.
+func (s *ConditionalSchema) Required() *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *ConditionalSchema) Nullable() *ConditionalSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// Conditional schemas
+
 // Then sets the schema that must be valid if the 'if' condition matches
 func (s *ConditionalSchema) Then(thenSchema Parseable) *ConditionalSchema {
+	s.checkMutable()
 	s.thenSchema = thenSchema
 	return s
 }
 
-// Else sets the schema that must be valid if the 'if' condition does not match
+// Else sets the schema that must be valid if the 'if' condition does not match, nor
+// the condition of any case
 func (s *ConditionalSchema) Else(elseSchema Parseable) *ConditionalSchema {
+	s.checkMutable()
 	s.elseSchema = elseSchema
 	return s
 }
 
-// ThenError sets a custom error message for when the 'then' validation fails
+// Case adds a case: when no earlier condition matched and condition does, the value
+// must be valid for then. Conditions are schemas, such as FieldEquals and Predicate
+// conditions; the first case of a schema created without condition is its if/then pair.
+func (s *ConditionalSchema) Case(condition, then Parseable) *ConditionalSchema {
+	s.checkMutable()
+	if s.ifSchema == nil {
+		s.ifSchema, s.thenSchema = condition, then
+		return s
+	}
+	s.cases = append(s.cases, conditionalCase{condition: condition, schema: then})
+	return s
+}
+
+// ThenError sets a custom error message for when the 'then' validation or the schema of a case fails
 func (s *ConditionalSchema) ThenError(err ErrorMessage) *ConditionalSchema {
+	s.checkMutable()
 	s.thenError = err
 	return s
 }
 
 // ElseError sets a custom error message for when the 'else' validation fails
 func (s *ConditionalSchema) ElseError(err ErrorMessage) *ConditionalSchema {
+	s.checkMutable()
 	s.elseError = err
 	return s
 }
 
+// Getters for accessing private fields
+
+// IsOptional returns whether the schema is marked as optional
+func (s *ConditionalSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *ConditionalSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetIf returns the condition schema
+func (s *ConditionalSchema) GetIf() Parseable {
+	return s.ifSchema
+}
+
+// GetThen returns the schema applied when the condition matches, or nil
+func (s *ConditionalSchema) GetThen() Parseable {
+	return s.thenSchema
+}
+
+// GetCases returns the conditions and schemas of the cases after the if/then pair
+func (s *ConditionalSchema) GetCases() (conditions, schemas []Parseable) {
+	for _, c := range s.cases {
+		conditions = append(conditions, c.condition)
+		schemas = append(schemas, c.schema)
+	}
+	return conditions, schemas
+}
+
+// GetElse returns the schema applied when the condition does not match, or nil
+func (s *ConditionalSchema) GetElse() Parseable {
+	return s.elseSchema
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *ConditionalSchema) Transform(fn TransformFunc) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *ConditionalSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *ConditionalSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *ConditionalSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Parse validates using if-then-else logic
 func (s *ConditionalSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
-	// First, test the 'if' condition
-	ifResult := s.ifSchema.Parse(value, ctx)
-
-	if ifResult.Valid {
-		// If condition matched, apply 'then' schema
-		if s.thenSchema != nil {
-			thenResult := s.thenSchema.Parse(value, ctx)
-			if !thenResult.Valid {
-				// 'Then' schema failed
-				message := ConditionalErrors.ThenFailed(ctx.Locale)
-				if !isEmptyErrorMessage(s.thenError) {
-					message = resolveErrorMessage(s.thenError, ctx)
-				}
-
-				// Combine the original errors with our conditional error
-				errors := []ValidationError{NewPrimitiveError(value, message, "then_failed")}
-				errors = append(errors, thenResult.Errors...)
-
-				return ParseResult{
-					Valid:  false,
-					Value:  value,
-					Errors: errors,
-				}
-			}
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
 
-			// 'Then' schema passed, use its transformed value
-			return thenResult
+// parse applies the conditional constraints; Parse runs the refine/transform pipeline on top
+func (s *ConditionalSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values; a required schema without a default checks nil like any value
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
 		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if !s.Schema.required {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+	}
 
-		// No 'then' schema specified, just return the value
-		return ParseResult{
-			Valid:  true,
-			Value:  value,
-			Errors: nil,
+	// Apply the schema of the first case whose condition matches
+	for _, c := range s.allCases() {
+		if !c.condition.Parse(value, ctx).Valid {
+			continue
 		}
-	} else {
-		// If condition did not match, apply 'else' schema if present
-		if s.elseSchema != nil {
-			elseResult := s.elseSchema.Parse(value, ctx)
-			if !elseResult.Valid {
-				// 'Else' schema failed
-				message := ConditionalErrors.ElseFailed(ctx.Locale)
-				if !isEmptyErrorMessage(s.elseError) {
-					message = resolveErrorMessage(s.elseError, ctx)
-				}
-
-				// Combine the original errors with our conditional error
-				errors := []ValidationError{NewPrimitiveError(value, message, "else_failed")}
-				errors = append(errors, elseResult.Errors...)
-
-				return ParseResult{
-					Valid:  false,
-					Value:  value,
-					Errors: errors,
-				}
+		if c.schema == nil {
+			// No schema for the case, just return the value
+			return ParseResult{Valid: true, Value: value, Errors: nil}
+		}
+		thenResult := c.schema.Parse(value, ctx)
+		if !thenResult.Valid {
+			// The case's schema failed
+			message := ConditionalErrors.ThenFailed(ctx.Locale)
+			if !isEmptyErrorMessage(s.thenError) {
+				message = resolveErrorMessage(s.thenError, ctx)
 			}
 
-			// 'Else' schema passed, use its transformed value
-			return elseResult
+			// Combine the original errors with our conditional error
+			errors := []ValidationError{NewPrimitiveError(value, message, CodeThenFailed)}
+			errors = append(errors, thenResult.Errors...)
+
+			return ParseResult{
+				Valid:    false,
+				Value:    value,
+				Errors:   errors,
+				Warnings: thenResult.Warnings,
+			}
 		}
 
-		// No 'else' schema specified, just return the value
-		return ParseResult{
-			Valid:  true,
-			Value:  value,
-			Errors: nil,
+		// The case's schema passed, use its transformed value
+		return thenResult
+	}
+
+	// No condition matched, apply 'else' schema if present
+	if s.elseSchema != nil {
+		elseResult := s.elseSchema.Parse(value, ctx)
+		if !elseResult.Valid {
+			// 'Else' schema failed
+			message := ConditionalErrors.ElseFailed(ctx.Locale)
+			if !isEmptyErrorMessage(s.elseError) {
+				message = resolveErrorMessage(s.elseError, ctx)
+			}
+
+			// Combine the original errors with our conditional error
+			errors := []ValidationError{NewPrimitiveError(value, message, CodeElseFailed)}
+			errors = append(errors, elseResult.Errors...)
+
+			return ParseResult{
+				Valid:    false,
+				Value:    value,
+				Errors:   errors,
+				Warnings: elseResult.Warnings,
+			}
 		}
+
+		// 'Else' schema passed, use its transformed value
+		return elseResult
+	}
+
+	// No 'else' schema specified, just return the value
+	return ParseResult{
+		Valid:  true,
+		Value:  value,
+		Errors: nil,
 	}
 }
 
 // JSON generates JSON Schema for Conditional validation
 func (s *ConditionalSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *ConditionalSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	schema := map[string]interface{}{}
 
-	// Add 'if' schema
-	if ifSchema, ok := s.ifSchema.(interface{ JSON() map[string]interface{} }); ok {
-		schema["if"] = ifSchema.JSON()
-	} else {
-		schema["if"] = map[string]interface{}{"type": "unknown"}
+	// The cases form a chain: each case after the first is the 'else' of the one before
+	cases := s.allCases()
+	var elseJSON map[string]interface{}
+	if s.elseSchema != nil {
+		elseJSON = conditionalBranchJSON(s.elseSchema, g)
 	}
-
-	// Add 'then' schema if present
-	if s.thenSchema != nil {
-		if thenSchema, ok := s.thenSchema.(interface{ JSON() map[string]interface{} }); ok {
-			schema["then"] = thenSchema.JSON()
-		} else {
-			schema["then"] = map[string]interface{}{"type": "unknown"}
+	for i := len(cases) - 1; i >= 0; i-- {
+		branch := map[string]interface{}{"if": conditionalBranchJSON(cases[i].condition, g)}
+		if cases[i].schema != nil {
+			branch["then"] = conditionalBranchJSON(cases[i].schema, g)
+		}
+		if elseJSON != nil {
+			branch["else"] = elseJSON
 		}
+		elseJSON = branch
+	}
+	if len(cases) > 0 {
+		schema = elseJSON
+	} else if elseJSON != nil {
+		// Without conditions, the 'else' schema always applies
+		schema["allOf"] = []interface{}{elseJSON}
 	}
 
-	// Add 'else' schema if present
-	if s.elseSchema != nil {
-		if elseSchema, ok := s.elseSchema.(interface{ JSON() map[string]interface{} }); ok {
-			schema["else"] = elseSchema.JSON()
-		} else {
-			schema["else"] = map[string]interface{}{"type": "unknown"}
+	// Add base schema fields
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+
+	// Add nullable if true
+	if s.nullable {
+		// The if/then/else schemas may reject null, so null is added with anyOf
+		schema = map[string]interface{}{
+			"anyOf": []interface{}{
+				schema,
+				map[string]interface{}{"type": "null"},
+			},
 		}
 	}
 
 	return schema
 }
+
+// conditionalBranchJSON returns the JSON Schema of a condition or branch schema
+func conditionalBranchJSON(s Parseable, g *jsonGeneration) map[string]interface{} {
+	if schema, ok := childJSON(s, g); ok {
+		return schema
+	}
+	return map[string]interface{}{"type": "unknown"}
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize ConditionalSchema for JSON schema generation
+func (s *ConditionalSchema) MarshalJSON() ([]byte, error) {
+	type jsonConditionalCase struct {
+		If   Parseable `json:"if"`
+		Then Parseable `json:"then,omitempty"`
+	}
+	type jsonConditionalSchema struct {
+		Schema
+		If       Parseable             `json:"if"`
+		Then     Parseable             `json:"then,omitempty"`
+		Cases    []jsonConditionalCase `json:"cases,omitempty"`
+		Else     Parseable             `json:"else,omitempty"`
+		Nullable bool                  `json:"nullable,omitempty"`
+	}
+
+	var cases []jsonConditionalCase
+	for _, c := range s.cases {
+		cases = append(cases, jsonConditionalCase{If: c.condition, Then: c.schema})
+	}
+	return json.Marshal(jsonConditionalSchema{
+		Schema:   s.Schema,
+		If:       s.ifSchema,
+		Then:     s.thenSchema,
+		Cases:    cases,
+		Else:     s.elseSchema,
+		Nullable: s.nullable,
+	})
+}
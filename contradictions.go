@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+)
+
+// contradiction is an issue reported by the Validate methods of schemas
+func contradiction(format string, args ...interface{}) error {
+	return fmt.Errorf("schema: "+format, args...)
+}
+
+// constEnumContradictions reports the const and enum values that the schema rejects
+// without its const and enum (the schema without), unless they override the other
+// constraints, and a const that is not in the enum
+func constEnumContradictions(constVal interface{}, enum []interface{}, overrides bool, without Parseable) []error {
+	var issues []error
+	if constVal != nil && len(enum) > 0 && !slices.Contains(enum, constVal) {
+		issues = append(issues, contradiction("const %v is not in the enum", constVal))
+	}
+	if overrides {
+		return issues
+	}
+	ctx := DefaultValidationContext()
+	if constVal != nil {
+		if result := without.Parse(constVal, ctx); !result.Valid {
+			issues = append(issues, contradiction("const %v fails the other constraints: %s", constVal, result.Errors[0].Message))
+		}
+	}
+	for _, value := range enum {
+		if result := without.Parse(value, ctx); !result.Valid {
+			issues = append(issues, contradiction("enum value %v fails the other constraints: %s", value, result.Errors[0].Message))
+		}
+	}
+	return issues
+}
@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConstOverrides(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		value  interface{}
+		errors []string
+	}{
+		{"const without overrides", String().MinLength(10).Const("Hi"), "Hi", []string{" min_length"}},
+		{"const overrides", String().MinLength(10).Const("Hi").ConstOverrides(true), "Hi", []string{}},
+		{"other value reports the const only", String().MinLength(10).Const("Hi").ConstOverrides(true), "Hello", []string{" const"}},
+		{"enum overrides", String().Pattern(`^[a-z]+$`).Enum([]string{"none", "N/A"}).ConstOverrides(true), "N/A", []string{}},
+		{"no const or enum", String().MinLength(10).ConstOverrides(true), "Hi", []string{" min_length"}},
+		{"int const", Int().Min(10).Const(0).ConstOverrides(true), 0, []string{}},
+		{"int other value", Int().Min(10).Const(0).ConstOverrides(true), 5, []string{" const"}},
+		{"number enum", Number().Min(1).Enum([]float64{-1, 2}).ConstOverrides(true), -1.0, []string{}},
+		{"number other value", Number().Min(1).Enum([]float64{-1, 2}).ConstOverrides(true), 0.5, []string{" enum"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorKeys(tt.schema.Parse(tt.value, ctx).Errors); !reflect.DeepEqual(got, tt.errors) {
+				t.Errorf("errors = %v, want %v", got, tt.errors)
+			}
+		})
+	}
+}
+
+func TestValidateContradictions(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema interface{ Validate() error }
+		issues []string // Substrings of the reported issues, in order
+	}{
+		{"consistent string", String().MinLength(2).MaxLength(5).Enum([]string{"ab", "abc"}), nil},
+		{"min length above max", String().MinLength(10).MaxLength(5), []string{"minLength 10 is greater than maxLength 5"}},
+		{"const fails min length", String().MinLength(10).Const("Hi"), []string{`const Hi fails the other constraints: value must be at least 10 characters long`}},
+		{"const overrides", String().MinLength(10).Const("Hi").ConstOverrides(true), nil},
+		{"enum value fails pattern", String().Pattern(`^[a-z]+$`).Enum([]string{"ok", "NO"}), []string{"enum value NO fails the other constraints"}},
+		{"const outside enum", String().Enum([]string{"a"}).Const("b").ConstOverrides(true), []string{"const b is not in the enum"}},
+		{"int bounds", Int().Min(5).Max(1), []string{"minimum 5 is greater than maximum 1"}},
+		{"int multipleOf", Int().MultipleOf(0), []string{"multipleOf 0 is not positive"}},
+		{"int const", Int().Max(1).Const(2), []string{"const 2 fails the other constraints"}},
+		{"number bounds", Number().Min(1).Max(0.5), []string{"no number is within the bounds 1 and 0.5"}},
+		{"number exclusive bounds", Number().ExclusiveMin(1).Max(1), []string{"no number is within the bounds 1 and 1"}},
+		{"number equal bounds", Number().Min(1).Max(1), nil},
+		{"number enum", Number().Max(1).Enum([]float64{0.5, 3}), []string{"enum value 3 fails"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.Validate()
+			if tt.issues == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want %v", tt.issues)
+			}
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tt.issues) {
+				t.Fatalf("Validate() = %v, want %d issues", err, len(tt.issues))
+			}
+			for i, want := range tt.issues {
+				if !strings.Contains(lines[i], want) {
+					t.Errorf("issue %d = %q, want %q", i, lines[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// CountryCodeFormat selects which ISO 3166-1 codes a CountryCode schema accepts
+type CountryCodeFormat string
+
+const (
+	CountryCodeAlpha2 CountryCodeFormat = "alpha-2" // Two-letter codes such as "DE" (default)
+	CountryCodeAlpha3 CountryCodeFormat = "alpha-3" // Three-letter codes such as "DEU"
+	CountryCodeAny    CountryCodeFormat = "any"     // Either form
+)
+
+// Default error messages for country code validation
+var (
+	countryRequiredError = i18n.S("value is required")
+	countryTypeError     = i18n.S("value must be a string")
+	countryCodeError     = i18n.S("value must be a valid ISO 3166-1 country code")
+)
+
+// CountryCodeSchema validates ISO 3166-1 country codes against an embedded code table
+type CountryCodeSchema struct {
+	Schema
+	format          CountryCodeFormat
+	caseInsensitive bool
+	nullable        bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	codeError         ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// CountryCode creates a new schema accepting ISO 3166-1 alpha-2 codes
+func CountryCode(errorMessage ...interface{}) *CountryCodeSchema {
+	schema := &CountryCodeSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+		format: CountryCodeAlpha2,
+	}
+	if len(errorMessage) > 0 {
+		schema.codeError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *CountryCodeSchema) Clone() *CountryCodeSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *CountryCodeSchema) Freeze() *CountryCodeSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *CountryCodeSchema) Title(title string) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *CountryCodeSchema) Description(description string) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *CountryCodeSchema) ReadOnly() *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *CountryCodeSchema) WriteOnly() *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *CountryCodeSchema) Sensitive() *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *CountryCodeSchema) Deprecated(reason string) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *CountryCodeSchema) Meta(key string, value interface{}) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *CountryCodeSchema) AsWarning(codes ...ErrorCode) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *CountryCodeSchema) AsInfo(codes ...ErrorCode) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *CountryCodeSchema) Default(value interface{}) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *CountryCodeSchema) DefaultFunc(fn func() interface{}) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *CountryCodeSchema) Example(example string) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Format selects the accepted codes
+func (s *CountryCodeSchema) Format(format CountryCodeFormat) *CountryCodeSchema {
+	s.checkMutable()
+	s.format = format
+	return s
+}
+
+// Alpha2 accepts two-letter codes only (default)
+func (s *CountryCodeSchema) Alpha2() *CountryCodeSchema {
+	return s.Format(CountryCodeAlpha2)
+}
+
+// Alpha3 accepts three-letter codes only
+func (s *CountryCodeSchema) Alpha3() *CountryCodeSchema {
+	return s.Format(CountryCodeAlpha3)
+}
+
+// CaseInsensitive accepts codes in any case ("de", "De") and returns them in uppercase
+func (s *CountryCodeSchema) CaseInsensitive() *CountryCodeSchema {
+	s.checkMutable()
+	s.caseInsensitive = true
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *CountryCodeSchema) Optional() *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *CountryCodeSchema) Required(errorMessage ...interface{}) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *CountryCodeSchema) Nullable() *CountryCodeSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *CountryCodeSchema) TypeError(message string) *CountryCodeSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// CodeError sets a custom error message for unknown codes
+func (s *CountryCodeSchema) CodeError(message string) *CountryCodeSchema {
+	s.checkMutable()
+	s.codeError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *CountryCodeSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *CountryCodeSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *CountryCodeSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetFormat returns the accepted codes
+func (s *CountryCodeSchema) GetFormat() CountryCodeFormat {
+	return s.format
+}
+
+// IsCaseInsensitive returns whether codes are accepted in any case
+func (s *CountryCodeSchema) IsCaseInsensitive() bool {
+	return s.caseInsensitive
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *CountryCodeSchema) Transform(fn TransformFunc) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *CountryCodeSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *CountryCodeSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *CountryCodeSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a country code and returns it (in uppercase with CaseInsensitive)
+func (s *CountryCodeSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the code table; Parse runs the refine/transform pipeline on top
+func (s *CountryCodeSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := countryRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	code, ok := value.(string)
+	if !ok {
+		message := countryTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	if s.caseInsensitive {
+		code = strings.ToUpper(code)
+	}
+	known := false
+	switch s.format {
+	case CountryCodeAlpha3:
+		known = countryAlpha3Codes.has(code)
+	case CountryCodeAny:
+		known = countryAlpha2Codes.has(code) || countryAlpha3Codes.has(code)
+	default:
+		known = countryAlpha2Codes.has(code)
+	}
+	if !known {
+		message := countryCodeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.codeError) {
+			message = resolveErrorMessage(s.codeError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidCode)}}
+	}
+	return ParseResult{Valid: true, Value: code, Errors: nil}
+}
+
+// JSON generates JSON Schema representation. Case-sensitive schemas list the codes
+// as an enum; case-insensitive ones only describe their shape.
+func (s *CountryCodeSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+
+	var codes []string
+	pattern := "^[A-Za-z]{2}$"
+	switch s.format {
+	case CountryCodeAlpha3:
+		codes, pattern = countryAlpha3Codes.sorted(), "^[A-Za-z]{3}$"
+	case CountryCodeAny:
+		codes, pattern = append(countryAlpha2Codes.sorted(), countryAlpha3Codes.sorted()...), "^[A-Za-z]{2,3}$"
+	default:
+		codes = countryAlpha2Codes.sorted()
+	}
+	if s.caseInsensitive {
+		schema["pattern"] = pattern
+	} else {
+		schema["enum"] = codes
+	}
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
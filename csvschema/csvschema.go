@@ -0,0 +1,329 @@
+// Package csvschema validates CSV and TSV documents row by row against an object
+// schema. The header row names the columns, each following row is parsed as an object
+// keyed by property name, and every failure is reported with its row, column and
+// position in the document.
+//
+//	report, err := csvschema.Validate(file, partnerRow, csvschema.Options{Source: "partner.csv"})
+//	if err != nil {
+//	    return err // Reading the document failed
+//	}
+//	for _, e := range report.Errors {
+//	    fmt.Println(e) // partner.csv:3:12: row 2, email: invalid email format
+//	}
+//
+// Rows are streamed: only the current row is held in memory, and Options.OnRow
+// receives the parsed value of each valid row.
+package csvschema
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/nyxstack/schema"
+)
+
+// Options configures Validate
+type Options struct {
+	Comma   rune   // Field delimiter (',' when zero, '\t' for TSV)
+	Comment rune   // Lines starting with this character are skipped (none when zero)
+	Source  string // Document name, prefixing the error locations (e.g. "partner.csv")
+	Locale  string // Locale of the error messages ("en" when empty)
+
+	// Columns maps header names to property names, for headers that differ from the
+	// property they hold (e.g. "E-mail Address" to "email")
+	Columns map[string]string
+
+	// Converters convert the cells of a column, by property name, for values coercion
+	// does not handle such as "1,234.50" or "03/04/2025". Cells of the other columns
+	// are coerced to the type of their property ("42" to 42 for Int properties).
+	Converters map[string]func(string) (interface{}, error)
+
+	// IgnoreUnknownColumns skips columns without a property instead of reporting them
+	IgnoreUnknownColumns bool
+
+	// MaxErrors stops validation once this many errors are reported (0 means no limit)
+	MaxErrors int
+
+	// OnRow is called with the parsed value of each valid row, in document order.
+	// Returning an error stops validation and makes Validate return it.
+	OnRow func(row int, value map[string]interface{}) error
+}
+
+// Error is a validation failure at a position of the document
+type Error struct {
+	Row    int    // Data row, starting at 1 after the header (0 for header errors)
+	Column string // Header of the column ("" for errors about the whole row)
+	schema.ValidationError
+}
+
+// Error formats the error as "source:line:column: row 2, email: message"
+func (e Error) Error() string {
+	var b strings.Builder
+	if e.Location != nil {
+		b.WriteString(e.Location.String())
+		b.WriteString(": ")
+	}
+	if e.Row == 0 {
+		b.WriteString("header")
+	} else {
+		fmt.Fprintf(&b, "row %d", e.Row)
+	}
+	if e.Column != "" {
+		b.WriteString(", ")
+		b.WriteString(e.Column)
+	}
+	b.WriteString(": ")
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+// Report summarizes the validation of a document
+type Report struct {
+	Rows      int     // Data rows read
+	ValidRows int     // Data rows without errors
+	Errors    []Error // Errors in document order
+	Truncated bool    // Validation stopped at Options.MaxErrors
+}
+
+// Valid reports whether the document has no errors
+func (r *Report) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Validate reads a CSV document from r and validates each row against rowSchema.
+// Empty cells are treated as missing values, so they only fail required properties.
+// A header that lacks a column for a required property, or that has columns without a
+// property (unless IgnoreUnknownColumns is set), fails with header errors before any
+// row is read. Malformed rows are reported with code "invalid_csv"; a malformed quote
+// ends the validation, since the rest of the document cannot be split reliably.
+//
+// The error is non-nil only when reading r or OnRow fails.
+func Validate(r io.Reader, rowSchema *schema.ObjectSchema, opts Options) (*Report, error) {
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	reader.Comment = opts.Comment
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	v := &validator{schema: rowSchema, opts: opts, reader: reader, report: &Report{}}
+	ctx := schema.NewValidationContext(opts.Locale)
+	if opts.Locale == "" {
+		ctx = schema.DefaultValidationContext()
+	}
+	v.ctx = ctx.WithCoercion()
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			v.add(Error{ValidationError: v.documentError("document has no header row", 1, 1)})
+			return v.report, nil
+		}
+		return v.report, v.readError(err)
+	}
+	if !v.readHeader(header) {
+		return v.report, nil
+	}
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if v.full() {
+			v.report.Truncated = true
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			v.report.Rows++
+			v.add(Error{Row: row, ValidationError: v.documentError(parseErr.Err.Error(), parseErr.Line, parseErr.Column)})
+			break
+		}
+		if err != nil {
+			return v.report, v.readError(err)
+		}
+		v.report.Rows++
+		if err := v.validateRow(row, record); err != nil {
+			return v.report, err
+		}
+	}
+	return v.report, nil
+}
+
+// validator holds the state of one Validate call
+type validator struct {
+	schema *schema.ObjectSchema
+	opts   Options
+	ctx    *schema.ValidationContext
+	reader *csv.Reader
+	report *Report
+
+	headers    []string       // Header of each column
+	properties []string       // Property of each column ("" for skipped columns)
+	columns    map[string]int // Column index by property name
+}
+
+// readHeader maps the header columns to properties and reports the mismatches with
+// the schema. It returns false when rows cannot be validated.
+func (v *validator) readHeader(header []string) bool {
+	known := v.schema.GetProperties()
+	v.headers = append([]string(nil), header...)
+	v.properties = make([]string, len(header))
+	v.columns = make(map[string]int, len(header))
+	if len(v.headers) > 0 {
+		v.headers[0] = strings.TrimPrefix(v.headers[0], "\ufeff") // Byte order mark
+	}
+
+	valid := true
+	for i, name := range v.headers {
+		property := strings.TrimSpace(name)
+		if mapped, ok := v.opts.Columns[property]; ok {
+			property = mapped
+		}
+		if _, ok := known[property]; !ok {
+			if !v.opts.IgnoreUnknownColumns {
+				v.add(v.headerError(i, fmt.Sprintf("column %q has no matching property", name), schema.CodeAdditionalProperty))
+				valid = false
+			}
+			continue
+		}
+		if _, duplicate := v.columns[property]; duplicate {
+			v.add(v.headerError(i, fmt.Sprintf("column %q is repeated", name), schema.CodeDuplicateKey))
+			valid = false
+			continue
+		}
+		v.properties[i] = property
+		v.columns[property] = i
+	}
+
+	for _, property := range v.schema.GetRequiredProperties() {
+		if _, ok := v.columns[property]; !ok {
+			err := v.documentError(fmt.Sprintf("missing column for required property %q", property), 1, 1)
+			err.Code = schema.CodeRequired
+			err.Path = schema.Path{schema.FieldSegment(property)}
+			v.add(Error{Column: property, ValidationError: err})
+			valid = false
+		}
+	}
+	return valid
+}
+
+// validateRow parses a record and reports its errors
+func (v *validator) validateRow(row int, record []string) error {
+	value := make(map[string]interface{}, len(record))
+	var rowErrors []Error
+	unconverted := map[string]bool{}
+	for i, cell := range record {
+		if i >= len(v.properties) {
+			err := v.documentError(fmt.Sprintf("row has %d fields, the header has %d", len(record), len(v.headers)), 0, 0)
+			err.Location = v.location(i)
+			rowErrors = append(rowErrors, Error{Row: row, ValidationError: err})
+			break
+		}
+		property := v.properties[i]
+		if property == "" || cell == "" {
+			continue
+		}
+		if convert, ok := v.opts.Converters[property]; ok {
+			converted, err := convert(cell)
+			if err != nil {
+				e := schema.NewFieldError(schema.Path{schema.FieldSegment(property)}, cell, err.Error(), schema.CodeFormat)
+				e.Location = v.location(i)
+				rowErrors = append(rowErrors, Error{Row: row, Column: v.headers[i], ValidationError: e})
+				unconverted[property] = true
+				continue
+			}
+			value[property] = converted
+			continue
+		}
+		value[property] = cell
+	}
+
+	result := v.schema.Parse(value, v.ctx)
+	for _, err := range result.Errors {
+		if err.Code == schema.CodePropertyInvalid {
+			continue // Summary of the errors of the property, which follow it
+		}
+		if len(err.Path) > 0 && unconverted[err.Path[0].Field] {
+			continue // Missing because its converter failed
+		}
+		e := Error{Row: row, ValidationError: err}
+		if len(err.Path) > 0 {
+			if i, ok := v.columns[err.Path[0].Field]; ok {
+				e.Column = v.headers[i]
+				e.Location = v.location(i)
+			}
+		}
+		if e.Location == nil {
+			e.Location = v.location(0)
+		}
+		rowErrors = append(rowErrors, e)
+	}
+
+	if len(rowErrors) > 0 {
+		sort.SliceStable(rowErrors, func(i, j int) bool {
+			a, b := rowErrors[i].Location, rowErrors[j].Location
+			return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+		})
+		for _, e := range rowErrors {
+			v.add(e)
+		}
+		return nil
+	}
+	v.report.ValidRows++
+	if v.opts.OnRow != nil {
+		parsed, _ := result.Value.(map[string]interface{})
+		return v.opts.OnRow(row, parsed)
+	}
+	return nil
+}
+
+// add appends an error to the report, unless MaxErrors is reached
+func (v *validator) add(err Error) {
+	if v.full() {
+		v.report.Truncated = true
+		return
+	}
+	v.report.Errors = append(v.report.Errors, err)
+}
+
+// full reports whether the report holds MaxErrors errors
+func (v *validator) full() bool {
+	return v.opts.MaxErrors > 0 && len(v.report.Errors) >= v.opts.MaxErrors
+}
+
+// location returns the position of a field of the last record read
+func (v *validator) location(field int) *schema.Location {
+	line, column := v.reader.FieldPos(field)
+	return &schema.Location{Source: v.opts.Source, Line: line, Column: column}
+}
+
+// headerError builds an error about a header column
+func (v *validator) headerError(field int, message string, code schema.ErrorCode) Error {
+	err := schema.NewPrimitiveError(v.headers[field], message, code)
+	err.Location = v.location(field)
+	return Error{Column: v.headers[field], ValidationError: err}
+}
+
+// documentError builds an error about the structure of the document
+func (v *validator) documentError(message string, line, column int) schema.ValidationError {
+	err := schema.NewPrimitiveError(nil, message, schema.CodeInvalidCSV)
+	if line > 0 {
+		err.Location = &schema.Location{Source: v.opts.Source, Line: line, Column: column}
+	}
+	return err
+}
+
+// readError annotates an error reading the document with its source
+func (v *validator) readError(err error) error {
+	if v.opts.Source == "" {
+		return err
+	}
+	return fmt.Errorf("%s: %w", v.opts.Source, err)
+}
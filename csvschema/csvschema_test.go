@@ -0,0 +1,127 @@
+package csvschema
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+var partnerRow = schema.Object().
+	Property("sku", schema.String().MinLength(3)).
+	Property("email", schema.String().Email()).
+	Property("quantity", schema.Int().Min(1)).
+	Property("price", schema.Number().Optional())
+
+func TestValidate(t *testing.T) {
+	document := "sku,email,quantity,price\n" +
+		"ABC-1,ann@example.com,2,9.5\n" +
+		"X,bob@example.com,0,\n" +
+		"DEF-2,,3,1\n" +
+		"GHI-3,eve@example.com,4,1,extra\n"
+
+	var parsed []map[string]interface{}
+	report, err := Validate(strings.NewReader(document), partnerRow, Options{
+		Source: "partner.csv",
+		OnRow: func(row int, value map[string]interface{}) error {
+			parsed = append(parsed, value)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if report.Rows != 4 || report.ValidRows != 1 || report.Valid() {
+		t.Errorf("report = %+v", report)
+	}
+
+	got := make([]string, len(report.Errors))
+	for i, e := range report.Errors {
+		got[i] = fmt.Sprintf("%s %s", e.Location, e.Code)
+	}
+	want := []string{
+		"partner.csv:3:1 min_length",
+		"partner.csv:3:19 minimum",
+		"partner.csv:4:7 required",
+		"partner.csv:5:27 invalid_csv",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+	if msg := report.Errors[0].Error(); !strings.HasPrefix(msg, "partner.csv:3:1: row 2, sku: ") {
+		t.Errorf("Error() = %q", msg)
+	}
+
+	wantParsed := []map[string]interface{}{{"sku": "ABC-1", "email": "ann@example.com", "quantity": 2, "price": 9.5}}
+	if !reflect.DeepEqual(parsed, wantParsed) {
+		t.Errorf("OnRow values = %#v, want %#v", parsed, wantParsed)
+	}
+}
+
+func TestValidate_Header(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		opts     Options
+		want     []string
+	}{
+		{"unknown column", "sku,email,quantity,notes\n", Options{}, []string{"header, notes: column \"notes\" has no matching property"}},
+		{"missing required column", "sku,quantity\n", Options{}, []string{"header, email: missing column for required property \"email\""}},
+		{"repeated column", "sku,email,quantity,sku\n", Options{}, []string{"header, sku: column \"sku\" is repeated"}},
+		{"empty document", "", Options{}, []string{"header: document has no header row"}},
+		{"ignored and renamed columns", "\ufeffsku,E-mail,quantity,notes\n", Options{IgnoreUnknownColumns: true, Columns: map[string]string{"E-mail": "email"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Validate(strings.NewReader(tt.document), partnerRow, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range report.Errors {
+				got = append(got, strings.SplitN(e.Error(), ": ", 2)[1])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_Options(t *testing.T) {
+	document := "sku\tquantity\temail\n" +
+		"# comment\n" +
+		"ABC\t1,200\tann@example.com\n" +
+		"AB\tx\tann\n" +
+		"A\t0\tbob\n"
+	converters := map[string]func(string) (interface{}, error){
+		"quantity": func(cell string) (interface{}, error) {
+			return strconv.Atoi(strings.ReplaceAll(cell, ",", ""))
+		},
+	}
+
+	report, err := Validate(strings.NewReader(document), partnerRow, Options{Comma: '\t', Comment: '#', Converters: converters, MaxErrors: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.ValidRows != 1 || len(report.Errors) != 2 || !report.Truncated {
+		t.Errorf("report = %+v", report)
+	}
+	if e := report.Errors[1]; e.Column != "quantity" || e.Code != schema.CodeFormat || e.Location.Line != 4 {
+		t.Errorf("converter error = %+v", e)
+	}
+
+	stop := errors.New("stop")
+	_, err = Validate(strings.NewReader(document), partnerRow, Options{
+		Comma: '\t', Comment: '#', Converters: converters,
+		OnRow: func(int, map[string]interface{}) error { return stop },
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Validate() error = %v, want the OnRow error", err)
+	}
+}
@@ -0,0 +1,300 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for currency code validation
+var (
+	currencyRequiredError = i18n.S("value is required")
+	currencyTypeError     = i18n.S("value must be a string")
+	currencyCodeError     = i18n.S("value must be a valid ISO 4217 currency code")
+)
+
+// CurrencyCodeSchema validates ISO 4217 alphabetic currency codes against an embedded code table
+type CurrencyCodeSchema struct {
+	Schema
+	caseInsensitive bool
+	nullable        bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	codeError         ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// CurrencyCode creates a new schema accepting ISO 4217 codes such as "EUR"
+func CurrencyCode(errorMessage ...interface{}) *CurrencyCodeSchema {
+	schema := &CurrencyCodeSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.codeError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *CurrencyCodeSchema) Clone() *CurrencyCodeSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *CurrencyCodeSchema) Freeze() *CurrencyCodeSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *CurrencyCodeSchema) Title(title string) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *CurrencyCodeSchema) Description(description string) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *CurrencyCodeSchema) ReadOnly() *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *CurrencyCodeSchema) WriteOnly() *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *CurrencyCodeSchema) Sensitive() *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *CurrencyCodeSchema) Deprecated(reason string) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *CurrencyCodeSchema) Meta(key string, value interface{}) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *CurrencyCodeSchema) AsWarning(codes ...ErrorCode) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *CurrencyCodeSchema) AsInfo(codes ...ErrorCode) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *CurrencyCodeSchema) Default(value interface{}) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *CurrencyCodeSchema) DefaultFunc(fn func() interface{}) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *CurrencyCodeSchema) Example(example string) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// CaseInsensitive accepts codes in any case ("eur", "Eur") and returns them in uppercase
+func (s *CurrencyCodeSchema) CaseInsensitive() *CurrencyCodeSchema {
+	s.checkMutable()
+	s.caseInsensitive = true
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *CurrencyCodeSchema) Optional() *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *CurrencyCodeSchema) Required(errorMessage ...interface{}) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *CurrencyCodeSchema) Nullable() *CurrencyCodeSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *CurrencyCodeSchema) TypeError(message string) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// CodeError sets a custom error message for unknown codes
+func (s *CurrencyCodeSchema) CodeError(message string) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.codeError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *CurrencyCodeSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *CurrencyCodeSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *CurrencyCodeSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// IsCaseInsensitive returns whether codes are accepted in any case
+func (s *CurrencyCodeSchema) IsCaseInsensitive() bool {
+	return s.caseInsensitive
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *CurrencyCodeSchema) Transform(fn TransformFunc) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *CurrencyCodeSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *CurrencyCodeSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *CurrencyCodeSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a currency code and returns it (in uppercase with CaseInsensitive)
+func (s *CurrencyCodeSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the code table; Parse runs the refine/transform pipeline on top
+func (s *CurrencyCodeSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := currencyRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	code, ok := value.(string)
+	if !ok {
+		message := currencyTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	if s.caseInsensitive {
+		code = strings.ToUpper(code)
+	}
+	if !currencyCodes.has(code) {
+		message := currencyCodeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.codeError) {
+			message = resolveErrorMessage(s.codeError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidCode)}}
+	}
+	return ParseResult{Valid: true, Value: code, Errors: nil}
+}
+
+// JSON generates JSON Schema representation. Case-sensitive schemas list the codes
+// as an enum; case-insensitive ones only describe their shape.
+func (s *CurrencyCodeSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	if s.caseInsensitive {
+		schema["pattern"] = "^[A-Za-z]{3}$"
+	} else {
+		schema["enum"] = currencyCodes.sorted()
+	}
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
@@ -3,6 +3,8 @@ package schema
 import (
 	"encoding/json"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nyxstack/i18n"
@@ -14,6 +16,8 @@ var (
 	dateTypeError     = i18n.S("value must be a date string")
 	dateFormatError   = i18n.S("value must be a valid date format")
 	dateEnumError     = i18n.S("value must be one of the allowed dates")
+	datePastError     = i18n.S("value must be in the past")
+	dateFutureError   = i18n.S("value must be in the future")
 )
 
 func dateConstError(value string) i18n.TranslatedFunc {
@@ -24,6 +28,22 @@ func dateRangeError(min, max string) i18n.TranslatedFunc {
 	return i18n.F("value must be between %s and %s", min, max)
 }
 
+func dateNotBeforeError(min string) i18n.TranslatedFunc {
+	return i18n.F("value must not be before %s", min)
+}
+
+func dateNotAfterError(max string) i18n.TranslatedFunc {
+	return i18n.F("value must not be after %s", max)
+}
+
+func dateWithinLastError(d time.Duration) i18n.TranslatedFunc {
+	return i18n.F("value must be within the last %s", formatDuration(d))
+}
+
+func dateWithinNextError(d time.Duration) i18n.TranslatedFunc {
+	return i18n.F("value must be within the next %s", formatDuration(d))
+}
+
 // DateFormat represents supported date/time formats
 type DateFormat string
 
@@ -44,12 +64,21 @@ const (
 // DateSchema represents a JSON Schema for date/time values
 type DateSchema struct {
 	Schema
+	coerce bool // Convert compatible input types before validating
 	// Date-specific validation
 	format   DateFormat // Date format to validate against
 	minDate  *time.Time // Minimum date/time
 	maxDate  *time.Time // Maximum date/time
 	nullable bool       // Allow null values
 
+	// Constraints relative to the time of each parse (ValidationContext.Now)
+	past       bool
+	future     bool
+	notBefore  func() time.Time
+	notAfter   func() time.Time
+	withinLast *time.Duration
+	withinNext *time.Duration
+
 	// Error messages for validation failures (support i18n)
 	requiredError     ErrorMessage
 	enumError         ErrorMessage
@@ -57,6 +86,12 @@ type DateSchema struct {
 	formatError       ErrorMessage
 	rangeError        ErrorMessage
 	typeMismatchError ErrorMessage
+	pastError         ErrorMessage
+	futureError       ErrorMessage
+	notBeforeError    ErrorMessage
+	notAfterError     ErrorMessage
+	withinLastError   ErrorMessage
+	withinNextError   ErrorMessage
 }
 
 // Date creates a new date schema with default date format (YYYY-MM-DD)
@@ -74,6 +109,20 @@ func Date(errorMessage ...interface{}) *DateSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *DateSchema) Clone() *DateSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *DateSchema) Freeze() *DateSchema {
+	s.freeze()
+	return s
+}
+
 // DateTime creates a new datetime schema with RFC3339 format
 func DateTime(errorMessage ...interface{}) *DateSchema {
 	schema := &DateSchema{
@@ -108,30 +157,98 @@ func Time(errorMessage ...interface{}) *DateSchema {
 
 // Title sets the title of the schema
 func (s *DateSchema) Title(title string) *DateSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *DateSchema) Description(description string) *DateSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *DateSchema) ReadOnly() *DateSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *DateSchema) WriteOnly() *DateSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *DateSchema) Sensitive() *DateSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *DateSchema) Deprecated(reason string) *DateSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *DateSchema) Meta(key string, value interface{}) *DateSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *DateSchema) AsWarning(codes ...ErrorCode) *DateSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *DateSchema) AsInfo(codes ...ErrorCode) *DateSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *DateSchema) Default(value interface{}) *DateSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *DateSchema) DefaultFunc(fn func() interface{}) *DateSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *DateSchema) Example(example string) *DateSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 // Enum sets the allowed enum values with optional custom error message
 func (s *DateSchema) Enum(values []string, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -144,6 +261,7 @@ func (s *DateSchema) Enum(values []string, errorMessage ...interface{}) *DateSch
 
 // Const sets a constant value with optional custom error message
 func (s *DateSchema) Const(value string, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -155,12 +273,14 @@ func (s *DateSchema) Const(value string, errorMessage ...interface{}) *DateSchem
 
 // Format sets the date format to validate against
 func (s *DateSchema) Format(format DateFormat) *DateSchema {
+	s.checkMutable()
 	s.format = format
 	return s
 }
 
 // MinDate sets the minimum date/time constraint
 func (s *DateSchema) MinDate(min time.Time, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
 	s.minDate = &min
 	if len(errorMessage) > 0 {
 		s.rangeError = toErrorMessage(errorMessage[0])
@@ -170,6 +290,7 @@ func (s *DateSchema) MinDate(min time.Time, errorMessage ...interface{}) *DateSc
 
 // MaxDate sets the maximum date/time constraint
 func (s *DateSchema) MaxDate(max time.Time, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
 	s.maxDate = &max
 	if len(errorMessage) > 0 {
 		s.rangeError = toErrorMessage(errorMessage[0])
@@ -179,21 +300,92 @@ func (s *DateSchema) MaxDate(max time.Time, errorMessage ...interface{}) *DateSc
 
 // DateRange sets both min and max date constraints
 func (s *DateSchema) DateRange(min, max time.Time) *DateSchema {
+	s.checkMutable()
 	s.minDate = &min
 	s.maxDate = &max
 	return s
 }
 
+// Relative constraints are evaluated against the time of each parse
+// (ValidationContext.Now), so schemas built once at startup stay correct. They
+// apply to the date and date-time formats.
+
+// Past requires the value to be before now, with optional custom error message
+func (s *DateSchema) Past(errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
+	s.past = true
+	if len(errorMessage) > 0 {
+		s.pastError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Future requires the value to be after now, with optional custom error message
+func (s *DateSchema) Future(errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
+	s.future = true
+	if len(errorMessage) > 0 {
+		s.futureError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// NotBefore requires the value to be at or after the time returned by fn, which is
+// called on every parse, with optional custom error message
+func (s *DateSchema) NotBefore(fn func() time.Time, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
+	s.notBefore = fn
+	if len(errorMessage) > 0 {
+		s.notBeforeError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// NotAfter requires the value to be at or before the time returned by fn, which is
+// called on every parse, with optional custom error message
+func (s *DateSchema) NotAfter(fn func() time.Time, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
+	s.notAfter = fn
+	if len(errorMessage) > 0 {
+		s.notAfterError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// WithinLast requires the value to lie between now-d and now (e.g. a login within
+// the last 30 days), with optional custom error message
+func (s *DateSchema) WithinLast(d time.Duration, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
+	s.withinLast = &d
+	if len(errorMessage) > 0 {
+		s.withinLastError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// WithinNext requires the value to lie between now and now+d (e.g. a token expiry
+// within 24 hours), with optional custom error message
+func (s *DateSchema) WithinNext(d time.Duration, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
+	s.withinNext = &d
+	if len(errorMessage) > 0 {
+		s.withinNextError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
 // Required/Optional/Nullable control
 
 // Optional marks the schema as optional
 func (s *DateSchema) Optional() *DateSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *DateSchema) Required(errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -203,20 +395,36 @@ func (s *DateSchema) Required(errorMessage ...interface{}) *DateSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *DateSchema) Nullable() *DateSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
+// Coerce enables conversion of compatible input types (e.g. strings from query
+// parameters or environment variables) before validation
+func (s *DateSchema) Coerce() *DateSchema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *DateSchema) coerces() bool {
+	return s.coerce
+}
+
 // Error customization
 
 // TypeError sets a custom error message for type mismatch validation
 func (s *DateSchema) TypeError(message string) *DateSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
 
 // FormatError sets a custom error message for format validation
 func (s *DateSchema) FormatError(message string) *DateSchema {
+	s.checkMutable()
 	s.formatError = toErrorMessage(message)
 	return s
 }
@@ -255,6 +463,14 @@ func (s *DateSchema) GetMaxDate() *time.Time {
 
 // Validation helpers
 
+// Compiled shape checks run before time.Parse, shared by all date schemas
+var (
+	dateOnlyPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	dateTimePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}`)
+	timeOnlyPattern = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}`)
+	unixPattern     = regexp.MustCompile(`^\d+$`)
+)
+
 // validateDateFormat validates a date string against the specified format
 func (s *DateSchema) validateDateFormat(dateStr string) (*time.Time, error) {
 	var layout string
@@ -263,20 +479,20 @@ func (s *DateSchema) validateDateFormat(dateStr string) (*time.Time, error) {
 	switch s.format {
 	case FormatDate, FormatDateOnly:
 		layout = "2006-01-02"
-		pattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+		pattern = dateOnlyPattern
 
 	case FormatDateTime, FormatRFC3339, FormatISO8601:
 		layout = time.RFC3339
 		// More flexible pattern for RFC3339
-		pattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}`)
+		pattern = dateTimePattern
 
 	case FormatTime, FormatTimeOnly:
 		layout = "15:04:05"
-		pattern = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}`)
+		pattern = timeOnlyPattern
 
 	case FormatUnix:
 		// Unix timestamp validation (numbers only)
-		pattern = regexp.MustCompile(`^\d+$`)
+		pattern = unixPattern
 		// For unix timestamp, we don't parse as time.Time here
 		if pattern.MatchString(dateStr) {
 			return nil, nil // Valid unix timestamp format
@@ -286,7 +502,7 @@ func (s *DateSchema) validateDateFormat(dateStr string) (*time.Time, error) {
 	default:
 		// Default to RFC3339
 		layout = time.RFC3339
-		pattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}`)
+		pattern = dateTimePattern
 	}
 
 	// First check pattern
@@ -306,10 +522,77 @@ func (s *DateSchema) validateDateFormat(dateStr string) (*time.Time, error) {
 	return nil, nil
 }
 
+// coerceValue formats time.Time values (and numbers, for unix timestamps) as strings in the schema's format
+func (s *DateSchema) coerceValue(value interface{}) interface{} {
+	if isBlankString(value) {
+		return nil
+	}
+
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		t = *v
+	default:
+		if s.format == FormatUnix {
+			if str, ok := coerceString(coerceInt(value)).(string); ok {
+				return str
+			}
+		}
+		return value
+	}
+
+	switch s.format {
+	case FormatDate, FormatDateOnly:
+		return t.Format("2006-01-02")
+	case FormatTime, FormatTimeOnly:
+		return t.Format("15:04:05")
+	case FormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(time.RFC3339)
+	}
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *DateSchema) Transform(fn TransformFunc) *DateSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *DateSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *DateSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *DateSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses a date value, returning the final parsed value
 func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the date constraints; Parse runs the refine/transform pipeline on top
+func (s *DateSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = s.coerceValue(value)
+	}
+
 	var errors []ValidationError
 
 	// Handle nil values
@@ -322,7 +605,7 @@ func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := dateRequiredError(ctx.Locale)
@@ -332,12 +615,12 @@ func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -353,7 +636,7 @@ func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
@@ -364,7 +647,7 @@ func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 		if !isEmptyErrorMessage(s.formatError) {
 			message = resolveErrorMessage(s.formatError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(dateString, message, "format"))
+		errors = append(errors, NewPrimitiveError(dateString, message, CodeFormat))
 	}
 
 	// Check enum
@@ -381,7 +664,7 @@ func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(dateString, message, "enum"))
+			errors = append(errors, NewPrimitiveError(dateString, message, CodeEnum))
 		}
 	}
 
@@ -392,7 +675,7 @@ func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.constError) {
 				message = resolveErrorMessage(s.constError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(dateString, message, "const"))
+			errors = append(errors, NewPrimitiveError(dateString, message, CodeConst))
 		}
 	}
 
@@ -410,7 +693,7 @@ func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.rangeError) {
 				message = resolveErrorMessage(s.rangeError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(dateString, message, "min_date"))
+			errors = append(errors, NewPrimitiveError(dateString, message, CodeMinDate))
 		}
 
 		if s.maxDate != nil && parsedTime.After(*s.maxDate) {
@@ -425,10 +708,15 @@ func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.rangeError) {
 				message = resolveErrorMessage(s.rangeError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(dateString, message, "max_date"))
+			errors = append(errors, NewPrimitiveError(dateString, message, CodeMaxDate))
 		}
 	}
 
+	// Check constraints relative to the current time
+	if parsedTime != nil && s.format != FormatTime && s.format != FormatTimeOnly {
+		errors = append(errors, s.checkRelative(*parsedTime, dateString, ctx)...)
+	}
+
 	return ParseResult{
 		Valid:  len(errors) == 0,
 		Value:  dateString, // Return the original string value
@@ -436,6 +724,66 @@ func (s *DateSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 	}
 }
 
+// checkRelative applies Past, Future, NotBefore, NotAfter, WithinLast and WithinNext
+func (s *DateSchema) checkRelative(t time.Time, dateString string, ctx *ValidationContext) []ValidationError {
+	if !s.past && !s.future && s.notBefore == nil && s.notAfter == nil && s.withinLast == nil && s.withinNext == nil {
+		return nil
+	}
+
+	var errors []ValidationError
+	fail := func(custom ErrorMessage, message string, code ErrorCode) {
+		if !isEmptyErrorMessage(custom) {
+			message = resolveErrorMessage(custom, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(dateString, message, code))
+	}
+
+	now := ctx.now()
+	if s.past && !t.Before(now) {
+		fail(s.pastError, datePastError(ctx.Locale), CodePast)
+	}
+	if s.future && !t.After(now) {
+		fail(s.futureError, dateFutureError(ctx.Locale), CodeFuture)
+	}
+	if s.notBefore != nil {
+		if min := s.notBefore(); t.Before(min) {
+			fail(s.notBeforeError, dateNotBeforeError(s.formatBound(min))(ctx.Locale), CodeMinDate)
+		}
+	}
+	if s.notAfter != nil {
+		if max := s.notAfter(); t.After(max) {
+			fail(s.notAfterError, dateNotAfterError(s.formatBound(max))(ctx.Locale), CodeMaxDate)
+		}
+	}
+	if s.withinLast != nil && (t.Before(now.Add(-*s.withinLast)) || t.After(now)) {
+		fail(s.withinLastError, dateWithinLastError(*s.withinLast)(ctx.Locale), CodeWithinLast)
+	}
+	if s.withinNext != nil && (t.Before(now) || t.After(now.Add(*s.withinNext))) {
+		fail(s.withinNextError, dateWithinNextError(*s.withinNext)(ctx.Locale), CodeWithinNext)
+	}
+	return errors
+}
+
+// formatBound formats a bound for error messages in the schema's format
+func (s *DateSchema) formatBound(t time.Time) string {
+	if s.format == FormatDate || s.format == FormatDateOnly {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatDuration formats a duration without trailing zero units ("24h" rather than "24h0m0s")
+func formatDuration(d time.Duration) string {
+	str := d.String()
+	if strings.HasSuffix(str, "m0s") {
+		str = str[:len(str)-2]
+	}
+	if strings.HasSuffix(str, "h0m") {
+		str = str[:len(str)-2]
+	}
+	return str
+}
+
 // JSON generates JSON Schema representation
 func (s *DateSchema) JSON() map[string]interface{} {
 	schema := baseJSONSchema("string")
@@ -443,7 +791,8 @@ func (s *DateSchema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateSchema_Relative(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ctx := DefaultValidationContext().WithClock(func() time.Time { return now })
+
+	tests := []struct {
+		name     string
+		schema   *DateSchema
+		value    string
+		expected bool
+		code     ErrorCode
+	}{
+		{"past", Date().Past(), "1990-02-14", true, ""},
+		{"past today", Date().Past(), "2024-05-01", true, ""},
+		{"past tomorrow", Date().Past(), "2024-05-02", false, "past"},
+		{"future", DateTime().Future(), "2024-05-01T12:00:01Z", true, ""},
+		{"future now", DateTime().Future(), "2024-05-01T12:00:00Z", false, "future"},
+		{"not before", Date().NotBefore(func() time.Time { return now.AddDate(-1, 0, 0) }), "2023-06-01", true, ""},
+		{"not before earlier", Date().NotBefore(func() time.Time { return now.AddDate(-1, 0, 0) }), "2023-04-30", false, "min_date"},
+		{"not after later", DateTime().NotAfter(func() time.Time { return now }), "2024-05-01T13:00:00+00:00", false, "max_date"},
+		{"within last", DateTime().WithinLast(24 * time.Hour), "2024-04-30T13:00:00Z", true, ""},
+		{"within last too old", DateTime().WithinLast(24 * time.Hour), "2024-04-30T11:00:00Z", false, "within_last"},
+		{"within last future", DateTime().WithinLast(24 * time.Hour), "2024-05-01T12:30:00Z", false, "within_last"},
+		{"within next", DateTime().WithinNext(24 * time.Hour), "2024-05-02T11:59:59Z", true, ""},
+		{"within next too late", DateTime().WithinNext(24 * time.Hour), "2024-05-02T12:00:01Z", false, "within_next"},
+		{"time format ignores relative", Time().Past(), "23:59:59", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%q) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	// The clock is read on every parse, not when the schema is built
+	expiry := DateTime().Future()
+	later := DefaultValidationContext().WithClock(func() time.Time { return now.Add(48 * time.Hour) })
+	if !expiry.Parse("2024-05-02T00:00:00Z", ctx).Valid || expiry.Parse("2024-05-02T00:00:00Z", later).Valid {
+		t.Error("expected Future to use the clock of each parse")
+	}
+
+	result := DateTime().WithinLast(24*time.Hour).Parse("2024-01-01T00:00:00Z", ctx)
+	if result.Valid || result.Errors[0].Message != "value must be within the last 24h" {
+		t.Errorf("unexpected errors %v", result.Errors)
+	}
+}
@@ -0,0 +1,286 @@
+package schema
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// interfaceType is the type of the values of the maps and slices built by ApplyDefaults
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// ApplyDefaults returns value with the defaults of s filled in, without validating it,
+// so that a configuration can be hydrated before, or without, being parsed:
+//
+//   - a nil value gets the default of its schema, unless the schema is nullable and
+//     the value is an explicit null in an object or array
+//   - the missing properties of objects get the default of their schema, if any,
+//     unless the object opted out with FillDefaults(false)
+//   - the items of arrays and tuples, and the values of records and maps, get the
+//     defaults of their schemas
+//
+// Defaults are applied again to the defaults themselves, so a default object gets the
+// defaults of its properties. The members of an allOf, and the schemas wrapped by
+// Lazy, Ref, Cached and the input of Transform, all apply. Union, AnyOf, Not and
+// Conditional schemas only apply their own default, as the branch a value belongs to
+// is only known by validating it. Values that are not maps or slices, such as structs,
+// are returned as they are.
+//
+// The value is not modified: maps and slices are copied, to map[K]interface{} and
+// []interface{}, when a default is added to them or to a value nested in them.
+// Properties are looked up by name and alias, but keys are not renamed.
+func ApplyDefaults(s Parseable, value interface{}) interface{} {
+	result, _ := applyDefaults(s, value, false)
+	return result
+}
+
+// applyDefaults returns value with the defaults of s filled in, and whether any was.
+// nested is set for the values of an object or array, whose null is kept when the
+// schema is nullable.
+func applyDefaults(s Parseable, value interface{}, nested bool) (interface{}, bool) {
+	if s == nil {
+		return value, false
+	}
+	changed := false
+	if value == nil && !(nested && isNullableSchema(s)) {
+		if defaulted, ok := s.(interface{ GetDefault() interface{} }); ok {
+			value = defaulted.GetDefault()
+			changed = value != nil
+		}
+	}
+	if value == nil {
+		return nil, false
+	}
+
+	var applied bool
+	switch v := s.(type) {
+	case *UnionSchema, *AnyOfSchema, *NotSchema, *ConditionalSchema:
+		return value, changed
+	case *TransformSchema:
+		value, applied = applyDefaults(v.inputSchema, value, nested)
+	case *ObjectSchema:
+		value, applied = v.applyDefaults(value)
+	case Composite:
+		value, applied = applyChildDefaults(v.Children(), value, nested)
+	}
+	return value, changed || applied
+}
+
+// applyDefaults fills in the missing properties of a map with their defaults, and
+// the defaults nested in the properties it has
+func (s *ObjectSchema) applyDefaults(value interface{}) (interface{}, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return value, false
+	}
+
+	present := make(map[string]bool, v.Len())
+	var result reflect.Value
+	set := func(key reflect.Value, item interface{}) {
+		if !result.IsValid() {
+			result = copyMap(v)
+		}
+		result.SetMapIndex(key, reflect.ValueOf(&item).Elem())
+	}
+	for _, key := range v.MapKeys() {
+		name := s.propertyName(key.String())
+		present[name] = true
+		var schema Parseable
+		if prop, defined := s.properties[name]; defined {
+			schema = prop.Schema
+		} else if patterns := s.matchingPatternSchemas(name); len(patterns) > 0 {
+			schema = patterns[0]
+		}
+		if item, ok := applyDefaults(schema, v.MapIndex(key).Interface(), true); ok {
+			set(key, item)
+		}
+	}
+	for _, name := range sortedPropertyNames(s.properties) {
+		if present[name] || s.skipDefaults {
+			continue
+		}
+		if item, ok := applyDefaults(s.properties[name].Schema, nil, false); ok {
+			set(reflect.ValueOf(name).Convert(v.Type().Key()), item)
+		}
+	}
+	if !result.IsValid() {
+		return value, false
+	}
+	return result.Interface(), true
+}
+
+// applyChildDefaults applies the defaults of the children of a schema: the schemas
+// applying to the value itself in turn, then those of the items or map values
+func applyChildDefaults(children []SchemaChild, value interface{}, nested bool) (interface{}, bool) {
+	changed := false
+	var positions []Parseable // Tuple positions
+	var items, values Parseable
+	for _, child := range children {
+		switch segment := child.Segment; {
+		case segment == "":
+			var applied bool
+			value, applied = applyDefaults(child.Schema, value, nested)
+			changed = changed || applied
+		case segment == ItemsSegment:
+			items = child.Schema
+		case segment == ValuesSegment:
+			values = child.Schema
+		case strings.HasPrefix(segment, "[") && strings.HasSuffix(segment, "]"):
+			if i, err := strconv.Atoi(segment[1 : len(segment)-1]); err == nil {
+				for len(positions) <= i {
+					positions = append(positions, nil)
+				}
+				positions[i] = child.Schema
+			}
+		}
+	}
+
+	v := reflect.ValueOf(value)
+	var applied bool
+	switch {
+	case (items != nil || len(positions) > 0) && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8:
+		value, applied = applyItemDefaults(v, positions, items)
+	case values != nil && v.Kind() == reflect.Map:
+		value, applied = applyValueDefaults(v, values)
+	}
+	return value, changed || applied
+}
+
+// applyItemDefaults applies the schema of their position, or items, to the items of a
+// slice
+func applyItemDefaults(v reflect.Value, positions []Parseable, items Parseable) (interface{}, bool) {
+	var result []interface{}
+	for i := 0; i < v.Len(); i++ {
+		schema := items
+		if i < len(positions) {
+			schema = positions[i]
+		}
+		item, ok := applyDefaults(schema, v.Index(i).Interface(), true)
+		if !ok {
+			continue
+		}
+		if result == nil {
+			result = make([]interface{}, v.Len())
+			for j := range result {
+				result[j] = v.Index(j).Interface()
+			}
+		}
+		result[i] = item
+	}
+	if result == nil {
+		return v.Interface(), false
+	}
+	return result, true
+}
+
+// applyValueDefaults applies the values schema to the values of a map
+func applyValueDefaults(v reflect.Value, values Parseable) (interface{}, bool) {
+	var result reflect.Value
+	for _, key := range v.MapKeys() {
+		item, ok := applyDefaults(values, v.MapIndex(key).Interface(), true)
+		if !ok {
+			continue
+		}
+		if !result.IsValid() {
+			result = copyMap(v)
+		}
+		result.SetMapIndex(key, reflect.ValueOf(&item).Elem())
+	}
+	if !result.IsValid() {
+		return v.Interface(), false
+	}
+	return result.Interface(), true
+}
+
+// copyMap returns a copy of a map as a map of interface{} values with the same keys
+func copyMap(v reflect.Value) reflect.Value {
+	result := reflect.MakeMapWithSize(reflect.MapOf(v.Type().Key(), interfaceType), v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		result.SetMapIndex(iter.Key(), iter.Value())
+	}
+	return result
+}
+
+// withoutDefaults returns a copy of the schema that does not fill missing properties
+// with their default, and neither do the objects nested in its properties
+func (s *ObjectSchema) withoutDefaults() *ObjectSchema {
+	result := s.clone()
+	result.skipDefaults = true
+	for name, prop := range result.properties {
+		if nested, ok := prop.Schema.(*ObjectSchema); ok {
+			prop.Schema = nested.withoutDefaults()
+			result.properties[name] = prop
+		}
+	}
+	return result
+}
+
+// fillsDefault reports whether the property name is added with its default when it is
+// missing
+func (s *ObjectSchema) fillsDefault(name string) bool {
+	if s.skipDefaults {
+		return false
+	}
+	base, ok := s.properties[name].Schema.(embedsSchema)
+	return ok && (base.base().defaultValue != nil || base.base().defaultFunc != nil)
+}
+
+// parseDefaults parses the default of each property missing from objectMap that has
+// one, and adds it to finalValue unless it is nil. Invalid defaults are reported as
+// invalid properties, and required properties whose DefaultFunc returns nil as
+// missing.
+func (s *ObjectSchema) parseDefaults(objectMap, finalValue map[string]interface{}, errors, warnings []ValidationError, child, ctx *ValidationContext) ([]ValidationError, []ValidationError) {
+	missing := func(name string) bool {
+		_, exists := objectMap[name]
+		return !exists && s.fillsDefault(name)
+	}
+	// Only sort the property names when there is a default to add, as most objects
+	// have all their properties
+	anyMissing := false
+	for name := range s.properties {
+		if missing(name) {
+			anyMissing = true
+			break
+		}
+	}
+	if !anyMissing {
+		return errors, warnings
+	}
+	for _, name := range sortedPropertyNames(s.properties) {
+		if !missing(name) {
+			continue
+		}
+		if ctx.stopCollecting(len(errors)) {
+			break
+		}
+		prop := s.properties[name]
+		value := prop.Schema.(embedsSchema).base().GetDefault()
+		if value == nil {
+			if slices.Contains(s.requiredProps, name) {
+				message := objectRequiredPropError(name)(ctx.Locale)
+				errors = append(errors, NewFieldError(Path{FieldSegment(name)}, "<missing>", message, CodeRequired))
+			}
+			continue
+		}
+
+		result := prop.Schema.Parse(value, child.at(FieldSegment(name)))
+		warnings = nestedWarnings(warnings, FieldSegment(name), result)
+		if !result.Valid {
+			message := objectPropertyError(name)(ctx.Locale)
+			if !isEmptyErrorMessage(s.propertyError) {
+				message = resolveErrorMessage(s.propertyError, ctx)
+			}
+			errors = append(errors, NewFieldError(Path{FieldSegment(name)}, value, message, CodePropertyInvalid))
+			for _, propErr := range result.Errors {
+				errors = append(errors, nestedError(append(Path{FieldSegment(name)}, propErr.Path...), propErr))
+			}
+			continue
+		}
+		if finalValue != nil {
+			finalValue[name] = result.Value
+		}
+	}
+	return errors, warnings
+}
@@ -0,0 +1,144 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	server := Object().
+		Property("host", String().Default("localhost")).
+		Property("port", Int().Default(8080)).
+		Property("tls", Bool().Optional())
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		value  interface{}
+		want   interface{}
+	}{
+		{
+			name:   "missing properties",
+			schema: server,
+			value:  map[string]interface{}{"port": 9090},
+			want:   map[string]interface{}{"host": "localhost", "port": 9090},
+		},
+		{
+			name:   "nil value gets the default",
+			schema: String().Default("info"),
+			value:  nil,
+			want:   "info",
+		},
+		{
+			name:   "nested objects",
+			schema: Object().Property("server", server).Property("name", String()),
+			value:  map[string]interface{}{"server": map[string]interface{}{}},
+			want:   map[string]interface{}{"server": map[string]interface{}{"host": "localhost", "port": 8080}},
+		},
+		{
+			name:   "missing object without default",
+			schema: Object().Property("server", server.Clone().Optional()),
+			value:  map[string]interface{}{},
+			want:   map[string]interface{}{},
+		},
+		{
+			name:   "default object gets its defaults",
+			schema: Object().Property("server", server.Clone().Default(map[string]interface{}{"port": 443})),
+			value:  map[string]interface{}{},
+			want:   map[string]interface{}{"server": map[string]interface{}{"host": "localhost", "port": 443}},
+		},
+		{
+			name:   "array items",
+			schema: Array(Object().Property("weight", Int().Default(1)).Property("url", String())),
+			value:  []interface{}{map[string]interface{}{"url": "a"}, map[string]interface{}{"url": "b", "weight": 3}},
+			want: []interface{}{
+				map[string]interface{}{"url": "a", "weight": 1},
+				map[string]interface{}{"url": "b", "weight": 3},
+			},
+		},
+		{
+			name:   "nil items",
+			schema: Array(Int().Default(0)),
+			value:  []interface{}{1, nil},
+			want:   []interface{}{1, 0},
+		},
+		{
+			name:   "nullable item keeps null",
+			schema: Array(Int().Nullable().Default(0)),
+			value:  []interface{}{nil},
+			want:   []interface{}{nil},
+		},
+		{
+			name:   "tuple positions",
+			schema: Tuple(String().Default("GET"), Int().Default(200)),
+			value:  []interface{}{nil, nil},
+			want:   []interface{}{"GET", 200},
+		},
+		{
+			name:   "record values",
+			schema: Record(String(), Object().Property("enabled", Bool().Default(true))),
+			value:  map[string]interface{}{"search": map[string]interface{}{}},
+			want:   map[string]interface{}{"search": map[string]interface{}{"enabled": true}},
+		},
+		{
+			name:   "alias counts as present",
+			schema: Object().Property("timeout", Int().Default(30)).Alias("timeout_s", "timeout"),
+			value:  map[string]interface{}{"timeout_s": 5},
+			want:   map[string]interface{}{"timeout_s": 5},
+		},
+		{
+			name:   "allOf members",
+			schema: AllOf(Object().Passthrough().Property("a", Int().Default(1)), Object().Passthrough().Property("b", Int().Default(2))),
+			value:  map[string]interface{}{},
+			want:   map[string]interface{}{"a": 1, "b": 2},
+		},
+		{
+			name:   "objects opting out",
+			schema: Object().Property("server", server.Clone().FillDefaults(false)).Property("debug", Bool().Default(false)),
+			value:  map[string]interface{}{"server": map[string]interface{}{"port": 9090}},
+			want:   map[string]interface{}{"server": map[string]interface{}{"port": 9090}, "debug": false},
+		},
+		{
+			name:   "union branches are not applied",
+			schema: Union(server, String()),
+			value:  map[string]interface{}{},
+			want:   map[string]interface{}{},
+		},
+		{
+			name:   "invalid values are kept",
+			schema: server,
+			value:  map[string]interface{}{"port": "not a number"},
+			want:   map[string]interface{}{"host": "localhost", "port": "not a number"},
+		},
+		{
+			name:   "typed maps",
+			schema: server,
+			value:  map[string]int{"port": 9090},
+			want:   map[string]interface{}{"host": "localhost", "port": 9090},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyDefaults(tt.schema, tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyDefaults() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyDefaults_DoesNotModifyValue(t *testing.T) {
+	schema := Object().Property("nested", Object().Property("level", Int().Default(1)))
+	value := map[string]interface{}{"nested": map[string]interface{}{}}
+
+	ApplyDefaults(schema, value)
+	if want := (map[string]interface{}{"nested": map[string]interface{}{}}); !reflect.DeepEqual(value, want) {
+		t.Errorf("value = %v, want it unchanged", value)
+	}
+
+	// Values without missing defaults are returned as they are
+	config := map[string]interface{}{"nested": map[string]interface{}{"level": 3}}
+	if got := ApplyDefaults(schema, config); reflect.ValueOf(got).Pointer() != reflect.ValueOf(config).Pointer() {
+		t.Error("ApplyDefaults() copied a value without missing defaults")
+	}
+}
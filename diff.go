@@ -0,0 +1,484 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind classifies a difference between two schemas
+type ChangeKind string
+
+const (
+	ChangeTypeChanged         ChangeKind = "type_changed"         // Accepted JSON types differ
+	ChangePropertyAdded       ChangeKind = "property_added"       // A property is defined only in the new schema
+	ChangePropertyRemoved     ChangeKind = "property_removed"     // A property is defined only in the old schema
+	ChangeRequiredAdded       ChangeKind = "required_added"       // A property became required
+	ChangeRequiredRemoved     ChangeKind = "required_removed"     // A property is no longer required
+	ChangeEnumValueAdded      ChangeKind = "enum_value_added"     // An allowed value was added
+	ChangeEnumValueRemoved    ChangeKind = "enum_value_removed"   // An allowed value was removed
+	ChangeConstraintTightened ChangeKind = "constraint_tightened" // A constraint now rejects more values
+	ChangeConstraintLoosened  ChangeKind = "constraint_loosened"  // A constraint now accepts more values
+	ChangeConstraintChanged   ChangeKind = "constraint_changed"   // A constraint changed in both directions
+	ChangeAnnotationChanged   ChangeKind = "annotation_changed"   // Title, description and other annotations
+)
+
+// CompatibilityMode selects which consumers a schema change must not break
+type CompatibilityMode string
+
+const (
+	// CompatibilityBackward: the new schema accepts every value the old one accepted, so data
+	// written by old producers is still valid
+	CompatibilityBackward CompatibilityMode = "backward"
+	// CompatibilityForward: the old schema accepts every value the new one accepts, so consumers
+	// still on the old schema can read data from new producers
+	CompatibilityForward CompatibilityMode = "forward"
+	// CompatibilityFull: both backward and forward compatible
+	CompatibilityFull CompatibilityMode = "full"
+)
+
+// Change is one difference between two schemas
+type Change struct {
+	Path    string      // JSON Pointer of the changed location in the schema ("" for the root)
+	Kind    ChangeKind  // What changed
+	Keyword string      // The JSON Schema keyword, or the property name for property and required changes
+	Old     interface{} // The old value, nil when added
+	New     interface{} // The new value, nil when removed
+	Narrows bool        // The new schema rejects some values the old one accepted
+	Widens  bool        // The new schema accepts some values the old one rejected
+}
+
+// Breaks reports whether the change breaks compatibility in the given mode
+func (c Change) Breaks(mode CompatibilityMode) bool {
+	switch mode {
+	case CompatibilityBackward:
+		return c.Narrows
+	case CompatibilityForward:
+		return c.Widens
+	}
+	return c.Narrows || c.Widens
+}
+
+// String describes the change, e.g. `/properties/age: minimum tightened from 0 to 18`
+func (c Change) String() string {
+	var description string
+	switch c.Kind {
+	case ChangeTypeChanged:
+		description = fmt.Sprintf("type changed from %v to %v", describeValue(c.Old), describeValue(c.New))
+	case ChangePropertyAdded:
+		description = fmt.Sprintf("property %q added", c.Keyword)
+	case ChangePropertyRemoved:
+		description = fmt.Sprintf("property %q removed", c.Keyword)
+	case ChangeRequiredAdded:
+		description = fmt.Sprintf("property %q became required", c.Keyword)
+	case ChangeRequiredRemoved:
+		description = fmt.Sprintf("property %q is no longer required", c.Keyword)
+	case ChangeEnumValueAdded:
+		description = fmt.Sprintf("enum value %v added", describeValue(c.New))
+	case ChangeEnumValueRemoved:
+		description = fmt.Sprintf("enum value %v removed", describeValue(c.Old))
+	default:
+		verb := map[ChangeKind]string{
+			ChangeConstraintTightened: "tightened",
+			ChangeConstraintLoosened:  "loosened",
+		}[c.Kind]
+		if verb == "" {
+			verb = "changed"
+		}
+		switch {
+		case c.Old == nil:
+			description = fmt.Sprintf("%s added (%v)", c.Keyword, describeValue(c.New))
+		case c.New == nil:
+			description = fmt.Sprintf("%s removed (was %v)", c.Keyword, describeValue(c.Old))
+		default:
+			description = fmt.Sprintf("%s %s from %v to %v", c.Keyword, verb, describeValue(c.Old), describeValue(c.New))
+		}
+	}
+	path := c.Path
+	if path == "" {
+		path = "/"
+	}
+	return path + ": " + description
+}
+
+// CompatibilityError lists the changes that break compatibility in a mode
+type CompatibilityError struct {
+	Mode    CompatibilityMode
+	Changes []Change
+}
+
+// Error implements the error interface
+func (e *CompatibilityError) Error() string {
+	lines := make([]string, len(e.Changes))
+	for i, change := range e.Changes {
+		lines[i] = change.String()
+	}
+	return fmt.Sprintf("schema change is not %s compatible: %s", e.Mode, strings.Join(lines, "; "))
+}
+
+// Diff compares the JSON Schema of two schemas and returns their differences, in a
+// deterministic order
+func Diff(old, new Parseable) []Change {
+	var changes []Change
+	diffSchemaNode("", diffJSON(old), diffJSON(new), &changes)
+	return changes
+}
+
+// CheckCompatibility returns a *CompatibilityError listing the changes from old to new
+// that break compatibility in mode, or nil
+func CheckCompatibility(old, new Parseable, mode CompatibilityMode) error {
+	var breaking []Change
+	for _, change := range Diff(old, new) {
+		if change.Breaks(mode) {
+			breaking = append(breaking, change)
+		}
+	}
+	if len(breaking) == 0 {
+		return nil
+	}
+	return &CompatibilityError{Mode: mode, Changes: breaking}
+}
+
+// diffJSON returns the normalized JSON Schema of a schema (empty if it has none)
+func diffJSON(s Parseable) map[string]interface{} {
+	if generator, ok := s.(interface{ JSON() map[string]interface{} }); ok {
+		return normalizeJSONSchema(generator.JSON())
+	}
+	return map[string]interface{}{}
+}
+
+// Keywords whose value bounds the accepted values from below or above
+var (
+	lowerBoundKeywords = map[string]bool{
+		"minimum": true, "exclusiveMinimum": true, "minLength": true,
+		"minItems": true, "minProperties": true, "minContains": true,
+	}
+	upperBoundKeywords = map[string]bool{
+		"maximum": true, "exclusiveMaximum": true, "maxLength": true,
+		"maxItems": true, "maxProperties": true, "maxContains": true,
+	}
+)
+
+// isAnnotationKeyword reports whether a keyword does not affect validation
+func isAnnotationKeyword(keyword string) bool {
+	switch keyword {
+	case "title", "description", "default", "examples", "deprecated", "readOnly", "writeOnly",
+		"$id", "$schema", "$comment", "$defs", "definitions":
+		return true
+	}
+	return strings.HasPrefix(keyword, "x-")
+}
+
+// diffSchemaNode compares two schema nodes at path
+func diffSchemaNode(path string, old, new map[string]interface{}, changes *[]Change) {
+	keywords := make(map[string]bool, len(old)+len(new))
+	for keyword := range old {
+		keywords[keyword] = true
+	}
+	for keyword := range new {
+		keywords[keyword] = true
+	}
+	sorted := make([]string, 0, len(keywords))
+	for keyword := range keywords {
+		sorted = append(sorted, keyword)
+	}
+	sort.Strings(sorted)
+
+	for _, keyword := range sorted {
+		oldValue, newValue := old[keyword], new[keyword]
+		switch {
+		case keyword == "type":
+			diffTypes(path, oldValue, newValue, changes)
+		case keyword == "enum":
+			diffEnum(path, oldValue, newValue, changes)
+		case keyword == "required":
+			diffRequired(path, oldValue, newValue, changes)
+		case keyword == "properties":
+			diffProperties(path, old, new, changes)
+		case keyword == "patternProperties":
+			diffSchemaMap(path+"/patternProperties", oldValue, newValue, changes)
+		case keyword == "additionalProperties" || keyword == "items":
+			diffSubschema(path+"/"+keyword, keyword, oldValue, newValue, changes)
+		case keyword == "prefixItems":
+			diffPrefixItems(path, oldValue, newValue, changes)
+		case reflect.DeepEqual(oldValue, newValue):
+		case isAnnotationKeyword(keyword):
+			*changes = append(*changes, Change{Path: path, Kind: ChangeAnnotationChanged, Keyword: keyword, Old: oldValue, New: newValue})
+		case lowerBoundKeywords[keyword] || upperBoundKeywords[keyword]:
+			diffBound(path, keyword, oldValue, newValue, changes)
+		case keyword == "uniqueItems":
+			diffFlag(path, keyword, oldValue == true, newValue == true, changes)
+		default:
+			// Any other constraint: adding it narrows, removing it widens, changing it
+			// may do both
+			diffConstraint(path, keyword, oldValue, newValue, changes)
+		}
+	}
+}
+
+// diffConstraint reports an added, removed or changed constraint
+func diffConstraint(path, keyword string, oldValue, newValue interface{}, changes *[]Change) {
+	change := Change{Path: path, Keyword: keyword, Old: oldValue, New: newValue}
+	switch {
+	case oldValue == nil:
+		change.Kind, change.Narrows = ChangeConstraintTightened, true
+	case newValue == nil:
+		change.Kind, change.Widens = ChangeConstraintLoosened, true
+	default:
+		change.Kind, change.Narrows, change.Widens = ChangeConstraintChanged, true, true
+	}
+	*changes = append(*changes, change)
+}
+
+// diffBound compares a lower or upper bound
+func diffBound(path, keyword string, oldValue, newValue interface{}, changes *[]Change) {
+	oldNumber, oldOK := oldValue.(float64)
+	newNumber, newOK := newValue.(float64)
+	if !oldOK || !newOK {
+		diffConstraint(path, keyword, oldValue, newValue, changes)
+		return
+	}
+	tightened := newNumber > oldNumber
+	if upperBoundKeywords[keyword] {
+		tightened = newNumber < oldNumber
+	}
+	change := Change{Path: path, Keyword: keyword, Old: oldValue, New: newValue}
+	if tightened {
+		change.Kind, change.Narrows = ChangeConstraintTightened, true
+	} else {
+		change.Kind, change.Widens = ChangeConstraintLoosened, true
+	}
+	*changes = append(*changes, change)
+}
+
+// diffFlag compares a boolean constraint that narrows when set
+func diffFlag(path, keyword string, oldValue, newValue bool, changes *[]Change) {
+	if oldValue == newValue {
+		return
+	}
+	change := Change{Path: path, Keyword: keyword, Old: oldValue, New: newValue}
+	if newValue {
+		change.Kind, change.Narrows = ChangeConstraintTightened, true
+	} else {
+		change.Kind, change.Widens = ChangeConstraintLoosened, true
+	}
+	*changes = append(*changes, change)
+}
+
+// diffTypes compares the accepted JSON types; a missing type accepts every type and
+// "number" includes "integer"
+func diffTypes(path string, oldValue, newValue interface{}, changes *[]Change) {
+	oldTypes, newTypes := schemaTypes(oldValue), schemaTypes(newValue)
+	narrows := !typesCovered(oldTypes, newTypes)
+	widens := !typesCovered(newTypes, oldTypes)
+	if narrows || widens {
+		*changes = append(*changes, Change{Path: path, Kind: ChangeTypeChanged, Keyword: "type", Old: oldValue, New: newValue, Narrows: narrows, Widens: widens})
+	}
+}
+
+// schemaTypes returns the types of a "type" value, or nil for any type
+func schemaTypes(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		types := make([]string, 0, len(v))
+		for _, t := range v {
+			if name, ok := t.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// typesCovered reports whether every value of the types in a is accepted by b
+func typesCovered(a, b []string) bool {
+	if b == nil {
+		return true
+	}
+	if a == nil {
+		return false
+	}
+	for _, t := range a {
+		if !containsString(b, t) && !(t == "integer" && containsString(b, "number")) {
+			return false
+		}
+	}
+	return true
+}
+
+// diffEnum reports the added and removed enum values
+func diffEnum(path string, oldValue, newValue interface{}, changes *[]Change) {
+	oldValues, oldOK := oldValue.([]interface{})
+	newValues, newOK := newValue.([]interface{})
+	if !oldOK || !newOK {
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diffConstraint(path, "enum", oldValue, newValue, changes)
+		}
+		return
+	}
+	for _, value := range oldValues {
+		if !containsValue(newValues, value) {
+			*changes = append(*changes, Change{Path: path, Kind: ChangeEnumValueRemoved, Keyword: "enum", Old: value, Narrows: true})
+		}
+	}
+	for _, value := range newValues {
+		if !containsValue(oldValues, value) {
+			*changes = append(*changes, Change{Path: path, Kind: ChangeEnumValueAdded, Keyword: "enum", New: value, Widens: true})
+		}
+	}
+}
+
+// diffRequired reports the properties that became required or optional
+func diffRequired(path string, oldValue, newValue interface{}, changes *[]Change) {
+	oldNames, newNames := stringList(oldValue), stringList(newValue)
+	for _, name := range oldNames {
+		if !containsString(newNames, name) {
+			*changes = append(*changes, Change{Path: path, Kind: ChangeRequiredRemoved, Keyword: name, Widens: true})
+		}
+	}
+	for _, name := range newNames {
+		if !containsString(oldNames, name) {
+			*changes = append(*changes, Change{Path: path, Kind: ChangeRequiredAdded, Keyword: name, Narrows: true})
+		}
+	}
+}
+
+// diffProperties compares the properties of two object schemas. Adding a property
+// narrows an object that allowed additional properties (the key is now constrained)
+// and widens one that did not; removing a property is the reverse.
+func diffProperties(path string, old, new map[string]interface{}, changes *[]Change) {
+	oldProps, _ := old["properties"].(map[string]interface{})
+	newProps, _ := new["properties"].(map[string]interface{})
+	oldOpen := old["additionalProperties"] != false
+	newOpen := new["additionalProperties"] != false
+
+	for _, name := range sortedKeys(oldProps) {
+		newProp, exists := newProps[name]
+		if !exists {
+			*changes = append(*changes, Change{Path: path, Kind: ChangePropertyRemoved, Keyword: name, Old: oldProps[name], Narrows: !newOpen, Widens: newOpen})
+			continue
+		}
+		diffSubschema(path+"/properties/"+jsonPointerEscaper.Replace(name), name, oldProps[name], newProp, changes)
+	}
+	for _, name := range sortedKeys(newProps) {
+		if _, exists := oldProps[name]; !exists {
+			*changes = append(*changes, Change{Path: path, Kind: ChangePropertyAdded, Keyword: name, New: newProps[name], Narrows: oldOpen, Widens: !oldOpen})
+		}
+	}
+}
+
+// diffSchemaMap compares maps of subschemas such as patternProperties
+func diffSchemaMap(path string, oldValue, newValue interface{}, changes *[]Change) {
+	oldMap, _ := oldValue.(map[string]interface{})
+	newMap, _ := newValue.(map[string]interface{})
+	for _, key := range sortedKeys(oldMap) {
+		if _, exists := newMap[key]; !exists {
+			*changes = append(*changes, Change{Path: path, Kind: ChangeConstraintLoosened, Keyword: key, Old: oldMap[key], Widens: true})
+			continue
+		}
+		diffSubschema(path+"/"+jsonPointerEscaper.Replace(key), key, oldMap[key], newMap[key], changes)
+	}
+	for _, key := range sortedKeys(newMap) {
+		if _, exists := oldMap[key]; !exists {
+			*changes = append(*changes, Change{Path: path, Kind: ChangeConstraintTightened, Keyword: key, New: newMap[key], Narrows: true})
+		}
+	}
+}
+
+// diffSubschema compares subschemas, which may also be booleans (true accepts
+// everything, false nothing) or absent (accepts everything)
+func diffSubschema(path, keyword string, oldValue, newValue interface{}, changes *[]Change) {
+	oldSchema, oldIsSchema := oldValue.(map[string]interface{})
+	newSchema, newIsSchema := newValue.(map[string]interface{})
+	if oldIsSchema && newIsSchema {
+		diffSchemaNode(path, oldSchema, newSchema, changes)
+		return
+	}
+	if reflect.DeepEqual(oldValue, newValue) || isAcceptAll(oldValue) && isAcceptAll(newValue) {
+		return
+	}
+	change := Change{Path: path, Keyword: keyword, Old: oldValue, New: newValue}
+	switch {
+	case isAcceptAll(oldValue) || newValue == false:
+		change.Kind, change.Narrows = ChangeConstraintTightened, true
+	case isAcceptAll(newValue) || oldValue == false:
+		change.Kind, change.Widens = ChangeConstraintLoosened, true
+	default:
+		change.Kind, change.Narrows, change.Widens = ChangeConstraintChanged, true, true
+	}
+	*changes = append(*changes, change)
+}
+
+// diffPrefixItems compares tuple positions
+func diffPrefixItems(path string, oldValue, newValue interface{}, changes *[]Change) {
+	oldItems, _ := oldValue.([]interface{})
+	newItems, _ := newValue.([]interface{})
+	for i := 0; i < len(oldItems) && i < len(newItems); i++ {
+		diffSubschema(fmt.Sprintf("%s/prefixItems/%d", path, i), "prefixItems", oldItems[i], newItems[i], changes)
+	}
+	if len(oldItems) != len(newItems) {
+		*changes = append(*changes, Change{Path: path, Kind: ChangeConstraintChanged, Keyword: "prefixItems", Old: len(oldItems), New: len(newItems), Narrows: true, Widens: true})
+	}
+}
+
+// isAcceptAll reports whether a subschema value accepts every value
+func isAcceptAll(value interface{}) bool {
+	if value == nil || value == true {
+		return true
+	}
+	schema, ok := value.(map[string]interface{})
+	return ok && len(schema) == 0
+}
+
+// containsValue reports whether values contains value (deep equality)
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringList returns the strings of a normalized JSON array
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// describeValue formats a value for a change description
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case map[string]interface{}:
+		return "schema"
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = describeValue(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return fmt.Sprintf("%v", value)
+}
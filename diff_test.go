@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	user := func() *ObjectSchema {
+		return Object().
+			Property("name", String().MinLength(1).MaxLength(50)).
+			Property("age", Int().Min(0).Optional()).
+			Property("role", Enum("admin", "user")).
+			Property("tags", Array(String()).Optional())
+	}
+
+	tests := []struct {
+		name    string
+		new     Parseable
+		kind    ChangeKind
+		path    string
+		narrows bool
+		widens  bool
+	}{
+		{"required added", user().RequiredProperty("email", String()), ChangeRequiredAdded, "", true, false},
+		{"optional property added", user().OptionalProperty("email", String()), ChangePropertyAdded, "", false, true},
+		{"property removed", user().Omit("tags"), ChangePropertyRemoved, "", true, false},
+		{"min tightened", user().Property("age", Int().Min(18).Optional()), ChangeConstraintTightened, "/properties/age", true, false},
+		{"max loosened", user().Property("name", String().MinLength(1).MaxLength(100)), ChangeConstraintLoosened, "/properties/name", false, true},
+		{"enum value removed", user().Property("role", Enum("admin")), ChangeEnumValueRemoved, "/properties/role", true, false},
+		{"enum value added", user().Property("role", Enum("admin", "user", "guest")), ChangeEnumValueAdded, "/properties/role", false, true},
+		{"pattern added", user().Property("name", String().MinLength(1).MaxLength(50).Pattern("^[a-z]+$")), ChangeConstraintTightened, "/properties/name", true, false},
+		{"items changed", user().Property("tags", Array(Int()).Optional()), ChangeTypeChanged, "/properties/tags/items", true, true},
+		{"passthrough", user().Passthrough(), ChangeConstraintLoosened, "/additionalProperties", false, true},
+		{"annotation", user().Description("A user"), ChangeAnnotationChanged, "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(user(), tt.new)
+			for _, change := range changes {
+				if change.Kind == tt.kind && change.Path == tt.path {
+					if change.Narrows != tt.narrows || change.Widens != tt.widens {
+						t.Errorf("%v: narrows = %v, widens = %v", change, change.Narrows, change.Widens)
+					}
+					return
+				}
+			}
+			t.Errorf("no %s change at %q in %v", tt.kind, tt.path, changes)
+		})
+	}
+
+	if changes := Diff(user(), user()); len(changes) != 0 {
+		t.Errorf("identical schemas: %v", changes)
+	}
+}
+
+func TestDiff_Types(t *testing.T) {
+	tests := []struct {
+		name            string
+		old, new        Parseable
+		narrows, widens bool
+	}{
+		{"integer to number", Int(), Number(), false, true},
+		{"number to integer", Number(), Int(), true, false},
+		{"nullable added", String(), String().Nullable(), false, true},
+		{"string to boolean", String(), Bool(), true, true},
+		{"any to string", Any(), String(), true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(tt.old, tt.new)
+			for _, change := range changes {
+				if change.Kind == ChangeTypeChanged {
+					if change.Narrows != tt.narrows || change.Widens != tt.widens {
+						t.Errorf("%v: narrows = %v, widens = %v", change, change.Narrows, change.Widens)
+					}
+					return
+				}
+			}
+			t.Errorf("no type change in %v", changes)
+		})
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	v1 := Object().
+		Property("id", Int()).
+		Property("status", Enum("active", "disabled"))
+	v2 := Object().
+		Property("id", Int()).
+		Property("status", Enum("active", "disabled", "pending")).
+		OptionalProperty("note", String())
+
+	if err := CheckCompatibility(v1, v2, CompatibilityBackward); err != nil {
+		t.Errorf("CompatibilityBackward: %v", err)
+	}
+	err := CheckCompatibility(v1, v2, CompatibilityForward)
+	var compatErr *CompatibilityError
+	if !errors.As(err, &compatErr) || len(compatErr.Changes) != 2 {
+		t.Fatalf("CompatibilityForward: %v", err)
+	}
+	if !strings.Contains(err.Error(), `/properties/status: enum value "pending" added`) {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if err := CheckCompatibility(v2, v1, CompatibilityFull); err == nil {
+		t.Error("CompatibilityFull: expected an error")
+	}
+	if err := CheckCompatibility(v1, v1, CompatibilityFull); err != nil {
+		t.Errorf("identical: %v", err)
+	}
+
+	change := Change{Path: "/properties/age", Kind: ChangeConstraintTightened, Keyword: "minimum", Old: 0.0, New: 18.0}
+	if got := change.String(); got != "/properties/age: minimum tightened from 0 to 18" {
+		t.Errorf("String() = %q", got)
+	}
+}
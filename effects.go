@@ -0,0 +1,226 @@
+package schema
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for refinements
+var (
+	refineFailedError = i18n.S("value is invalid")
+)
+
+// RefineFunc is a custom predicate that a parsed value must satisfy
+type RefineFunc func(value interface{}) bool
+
+// RefineCtxFunc is a custom validator that receives the Go context of the parse
+// (ValidationContext.Ctx), so it can perform I/O such as database lookups and honour
+// cancellation and deadlines. Returning a non-nil error fails validation; a returned
+// ValidationError or ValidationErrors is reported as is.
+type RefineCtxFunc func(ctx context.Context, value interface{}) error
+
+// effect is a single step of a schema's post-processing pipeline.
+// Exactly one of transform, refine, refineCtx or check is set.
+type effect struct {
+	transform TransformFunc
+	refine    RefineFunc
+	refineCtx RefineCtxFunc
+	check     func(value interface{}, ctx *ValidationContext) []ValidationError
+	message   ErrorMessage
+}
+
+// effects is the ordered refine/transform pipeline attached to a schema.
+// It runs after the schema's own constraints succeed, so every step sees a
+// value that already passed validation (and the output of the previous step).
+type effects []effect
+
+// withTransform returns the pipeline with a transform step appended
+func (e effects) withTransform(fn TransformFunc) effects {
+	return append(e, effect{transform: fn})
+}
+
+// withRefine returns the pipeline with a refinement step appended
+func (e effects) withRefine(fn RefineFunc, errorMessage ...interface{}) effects {
+	step := effect{refine: fn}
+	if len(errorMessage) > 0 {
+		step.message = toErrorMessage(errorMessage[0])
+	}
+	return append(e, step)
+}
+
+// withRefineCtx returns the pipeline with a context-aware validation step appended
+func (e effects) withRefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) effects {
+	step := effect{refineCtx: fn}
+	if len(errorMessage) > 0 {
+		step.message = toErrorMessage(errorMessage[0])
+	}
+	return append(e, step)
+}
+
+// withCheck returns the pipeline with a step appended that reports its own errors
+func (e effects) withCheck(fn func(value interface{}, ctx *ValidationContext) []ValidationError) effects {
+	return append(e, effect{check: fn})
+}
+
+// valueContext returns the context for parsing the value the pipeline runs on: a
+// non-empty pipeline needs the parsed value even when ctx only asks for validity
+func (e effects) valueContext(ctx *ValidationContext) *ValidationContext {
+	if len(e) == 0 {
+		return ctx
+	}
+	return ctx.withValues()
+}
+
+// apply runs the pipeline against the result of parsing value with schema, after
+// setting the params of the errors and moving those the schema grades as warnings
+// aside, masks their sensitive values, renders the custom message templates of the
+// errors and warnings and reports the parse to the hooks of ctx
+func (e effects) apply(start parseStart, result ParseResult, ctx *ValidationContext, schema Parseable, value interface{}) ParseResult {
+	if !result.Valid {
+		fillParams(result.Errors, schema)
+		result = splitWarnings(result, schema, value)
+	}
+	warnings := result.Warnings
+	result = e.run(result, ctx)
+	result.Warnings = warnings
+	input := value
+	if !result.Valid || len(result.Warnings) > 0 {
+		input = redactErrors(result, ctx, schema, value)
+	}
+	if !result.Valid {
+		renderMessages(result.Errors, ctx, schema, input)
+	}
+	renderMessages(result.Warnings, ctx, schema, input)
+	ctx.endParse(start, schema, value, result)
+	return result
+}
+
+// run runs the pipeline against a parse result. Invalid results are passed through
+// with their errors limited by ctx, nil values are passed through untouched, as are
+// all values when only constraints are checked (see Lint); the first failing step
+// stops the pipeline.
+func (e effects) run(result ParseResult, ctx *ValidationContext) ParseResult {
+	if !result.Valid {
+		result.Errors = ctx.limitErrors(result.Errors)
+		return result
+	}
+	if len(e) == 0 || result.Value == nil || ctx.structural {
+		return result
+	}
+
+	value := result.Value
+	for _, step := range e {
+		if step.refine != nil {
+			if !step.refine(value) {
+				message := refineFailedError(ctx.Locale)
+				if !isEmptyErrorMessage(step.message) {
+					message = resolveErrorMessage(step.message, ctx)
+				}
+				return ParseResult{
+					Valid:  false,
+					Value:  nil,
+					Errors: []ValidationError{NewPrimitiveError(value, message, CodeCustom)},
+				}
+			}
+			continue
+		}
+
+		if step.refineCtx != nil {
+			if errs := runRefineCtx(step, value, ctx); len(errs) > 0 {
+				return ParseResult{Valid: false, Value: nil, Errors: ctx.limitErrors(errs)}
+			}
+			continue
+		}
+
+		if step.check != nil {
+			if errs := step.check(value, ctx); len(errs) > 0 {
+				return ParseResult{Valid: false, Value: nil, Errors: ctx.limitErrors(errs)}
+			}
+			continue
+		}
+
+		transformed, err := step.transform(value)
+		if err != nil {
+			message := transformFailedError(err)(ctx.Locale)
+			return ParseResult{
+				Valid:  false,
+				Value:  nil,
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeTransform)},
+			}
+		}
+		value = transformed
+	}
+
+	return ParseResult{Valid: true, Value: value, Errors: nil}
+}
+
+// validationContextKey is the key of the validation context in the Go context given
+// to RefineCtx validators
+type validationContextKey struct{}
+
+// ValidationContextFrom returns the validation context of the parse running a RefineCtx
+// validator, whose Root and Path locate the validated value in the document:
+//
+//	discount := schema.Number().RefineCtx(func(ctx context.Context, v interface{}) error {
+//	    vc, _ := schema.ValidationContextFrom(ctx)
+//	    order := vc.Root().(map[string]interface{})
+//	    ...
+//	})
+//
+// The validation context may be reused once the validator returns, so it must not be
+// kept.
+func ValidationContextFrom(ctx context.Context) (*ValidationContext, bool) {
+	vc, ok := ctx.Value(validationContextKey{}).(*ValidationContext)
+	return vc, ok
+}
+
+// runRefineCtx runs a context-aware validation step and converts its error into
+// validation errors. Cancellation and deadline errors of the parse context are
+// reported with the codes "canceled" and "deadline_exceeded".
+func runRefineCtx(step effect, value interface{}, ctx *ValidationContext) []ValidationError {
+	goCtx := ctx.Ctx
+	if goCtx == nil {
+		goCtx = context.Background()
+	}
+	if err := goCtx.Err(); err != nil {
+		return []ValidationError{contextError(value, err)}
+	}
+
+	vc := ctx
+	if !vc.hasRoot {
+		rooted := *ctx
+		rooted.root, rooted.hasRoot = value, true
+		vc = &rooted
+	}
+	err := step.refineCtx(context.WithValue(goCtx, validationContextKey{}, vc), value)
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return []ValidationError{contextError(value, err)}
+	case !isEmptyErrorMessage(step.message):
+		return []ValidationError{NewPrimitiveError(value, resolveErrorMessage(step.message, ctx), CodeCustom)}
+	}
+
+	var validationErrs ValidationErrors
+	if errors.As(err, &validationErrs) && len(validationErrs) > 0 {
+		return validationErrs
+	}
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return []ValidationError{validationErr}
+	}
+	return []ValidationError{NewPrimitiveError(value, err.Error(), CodeCustom)}
+}
+
+// contextError reports a parse aborted by its Go context
+func contextError(value interface{}, err error) ValidationError {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return NewPrimitiveError(value, err.Error(), CodeDeadlineExceeded)
+	}
+	return NewPrimitiveError(value, err.Error(), CodeCanceled)
+}
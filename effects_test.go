@@ -0,0 +1,258 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEffects_TransformAndRefine(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	trim := func(v interface{}) (interface{}, error) { return strings.TrimSpace(v.(string)), nil }
+	lower := func(v interface{}) (interface{}, error) { return strings.ToLower(v.(string)), nil }
+	notAdmin := func(v interface{}) bool { return v.(string) != "admin" }
+
+	s := String().Transform(trim).Transform(lower).Refine(notAdmin, "username is reserved")
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+		output   interface{}
+		code     ErrorCode
+	}{
+		{"transforms compose in order", "  Alice ", true, "alice", ""},
+		{"refine sees transformed value", " ADMIN ", false, nil, "custom"},
+		{"base constraints run first", 42, false, nil, "invalid_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v. Errors: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.expected && result.Value != tt.output {
+				t.Errorf("Parse(%v) value = %v, want %v", tt.value, result.Value, tt.output)
+			}
+			if !tt.expected && tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("Parse(%v) code = %s, want %s", tt.value, result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	result := s.Parse("admin", ctx)
+	if result.Valid || result.Errors[0].Message != "username is reserved" {
+		t.Errorf("expected custom refine message, got %v", result.Errors)
+	}
+}
+
+func TestEffects_TransformError(t *testing.T) {
+	ctx := DefaultValidationContext()
+	s := Int().Transform(func(v interface{}) (interface{}, error) { return nil, errors.New("boom") })
+
+	result := s.Parse(5, ctx)
+	if result.Valid || result.Errors[0].Code != "transform" {
+		t.Errorf("expected transform error, got %v", result.Errors)
+	}
+}
+
+func TestEffects_Nested(t *testing.T) {
+	ctx := DefaultValidationContext()
+	upper := func(v interface{}) (interface{}, error) { return strings.ToUpper(v.(string)), nil }
+
+	s := Object().
+		Property("code", String().Transform(upper)).
+		Refine(func(v interface{}) bool { return v.(map[string]interface{})["code"] != "" })
+
+	result := s.Parse(map[string]interface{}{"code": "abc"}, ctx)
+	if !result.Valid {
+		t.Fatalf("expected valid, got %v", result.Errors)
+	}
+	if got := result.Value.(map[string]interface{})["code"]; got != "ABC" {
+		t.Errorf("expected nested transform to apply, got %v", got)
+	}
+
+	arr := Array(String().Transform(upper)).Refine(func(v interface{}) bool { return len(v.([]interface{})) > 1 }, "need two")
+	if result := arr.Parse([]interface{}{"a"}, ctx); result.Valid {
+		t.Error("expected array refine to fail")
+	}
+	result = arr.Parse([]interface{}{"a", "b"}, ctx)
+	if !result.Valid || result.Value.([]interface{})[1] != "B" {
+		t.Errorf("unexpected array result: %v %v", result.Value, result.Errors)
+	}
+
+	optional := String().Optional().Refine(func(v interface{}) bool { return false })
+	if result := optional.Parse(nil, ctx); !result.Valid {
+		t.Error("nil optional value should skip the pipeline")
+	}
+}
+
+func TestEffects_RefineCtx(t *testing.T) {
+	taken := map[string]bool{"ada": true}
+	unique := func(ctx context.Context, v interface{}) error {
+		if taken[v.(string)] {
+			return errors.New("username is taken")
+		}
+		return nil
+	}
+	slow := func(ctx context.Context, v interface{}) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	}
+	typed := func(ctx context.Context, v interface{}) error {
+		return NewPrimitiveError(v, "blocked", "blocked")
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	timeout, cancelTimeout := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelTimeout()
+
+	tests := []struct {
+		name    string
+		schema  Parseable
+		goCtx   context.Context
+		value   interface{}
+		message string
+		code    ErrorCode
+	}{
+		{"passes", String().RefineCtx(unique), context.Background(), "grace", "", ""},
+		{"error message", String().RefineCtx(unique), context.Background(), "ada", "username is taken", "custom"},
+		{"custom message", String().RefineCtx(unique, "pick another name"), context.Background(), "ada", "pick another name", "custom"},
+		{"validation error", String().RefineCtx(typed), context.Background(), "x", "blocked", "blocked"},
+		{"canceled before run", String().RefineCtx(unique), canceled, "grace", "", "canceled"},
+		{"deadline", String().RefineCtx(slow), timeout, "grace", "", "deadline_exceeded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, DefaultValidationContext().WithContext(tt.goCtx))
+			if tt.code == "" {
+				if !result.Valid {
+					t.Errorf("expected valid, got %v", result.Errors)
+				}
+				return
+			}
+			if result.Valid || len(result.Errors) != 1 {
+				t.Fatalf("expected one error, got %v", result.Errors)
+			}
+			if result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+			if tt.message != "" && result.Errors[0].Message != tt.message {
+				t.Errorf("message = %q, want %q", result.Errors[0].Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestEffects_ContextValues(t *testing.T) {
+	type tenantKey struct{}
+	type tenant struct{ maxProjects int }
+	withinLimit := func(ctx context.Context, v interface{}) error {
+		limits, ok := ContextValue[tenant](ctx, tenantKey{})
+		if !ok {
+			return errors.New("no tenant")
+		}
+		if len(v.([]interface{})) > limits.maxProjects {
+			return fmt.Errorf("at most %d projects", limits.maxProjects)
+		}
+		return nil
+	}
+	projects := Object().Property("projects", Array(String()).RefineCtx(withinLimit))
+	value := map[string]interface{}{"projects": []interface{}{"a", "b", "c"}}
+
+	tests := []struct {
+		name    string
+		ctx     *ValidationContext
+		message string
+	}{
+		{"within limit", DefaultValidationContext().WithValue(tenantKey{}, tenant{maxProjects: 5}), ""},
+		{"over limit", DefaultValidationContext().WithValue(tenantKey{}, tenant{maxProjects: 2}), "at most 2 projects"},
+		{"kept by WithContext first", DefaultValidationContext().WithContext(context.Background()).WithValue(tenantKey{}, tenant{maxProjects: 2}), "at most 2 projects"},
+		{"missing", DefaultValidationContext(), "no tenant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := projects.Parse(value, tt.ctx)
+			if tt.message == "" {
+				if !result.Valid {
+					t.Errorf("expected valid, got %v", result.Errors)
+				}
+				return
+			}
+			if result.Valid || result.Errors[len(result.Errors)-1].Message != tt.message {
+				t.Errorf("errors = %v, want %q", result.Errors, tt.message)
+			}
+		})
+	}
+
+	ctx := DefaultValidationContext().WithValue("user", 42)
+	if got := ctx.Value("user"); got != 42 {
+		t.Errorf("Value(user) = %v, want 42", got)
+	}
+	if _, ok := ContextValue[string](ctx.Ctx, "user"); ok {
+		t.Error("ContextValue should not convert values of another type")
+	}
+}
+
+func TestEffects_RootAndPath(t *testing.T) {
+	var paths []string
+	withinTotal := func(ctx context.Context, v interface{}) error {
+		vc, ok := ValidationContextFrom(ctx)
+		if !ok {
+			return errors.New("no validation context")
+		}
+		paths = append(paths, vc.Path().String())
+		order := vc.Root().(map[string]interface{})
+		if v.(float64) > order["total"].(float64) {
+			return errors.New("discount cannot exceed the order total")
+		}
+		return nil
+	}
+	order := Object().
+		Property("total", Number()).
+		Property("lines", Array(Object().Property("discount", Number().RefineCtx(withinTotal))))
+
+	tests := []struct {
+		name   string
+		value  map[string]interface{}
+		errors []string
+	}{
+		{"within total", map[string]interface{}{"total": 50.0, "lines": []interface{}{map[string]interface{}{"discount": 5.0}}}, []string{}},
+		{"over total", map[string]interface{}{"total": 50.0, "lines": []interface{}{map[string]interface{}{"discount": 5.0}, map[string]interface{}{"discount": 80.0}}}, []string{"lines property_invalid", "lines[1] item_invalid", "lines[1].discount custom", "lines[1].discount property_invalid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorKeys(order.Parse(tt.value, DefaultValidationContext()).Errors); !reflect.DeepEqual(got, tt.errors) {
+				t.Errorf("errors = %v, want %v", got, tt.errors)
+			}
+		})
+	}
+	if want := []string{"lines[0].discount", "lines[0].discount", "lines[1].discount"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	// At the root, the validated value is the root document
+	root := Object().Property("total", Number()).RefineCtx(func(ctx context.Context, v interface{}) error {
+		if vc, _ := ValidationContextFrom(ctx); vc.Root() == nil || len(vc.Path()) != 0 {
+			return errors.New("root not set")
+		}
+		return nil
+	})
+	if result := root.Parse(map[string]interface{}{"total": 1.0}, DefaultValidationContext()); !result.Valid {
+		t.Errorf("root refinement errors = %v", result.Errors)
+	}
+}
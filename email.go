@@ -0,0 +1,470 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for email validation
+var (
+	emailRequiredError    = i18n.S("value is required")
+	emailTypeError        = i18n.S("value must be a string")
+	emailFormatError      = i18n.S("value must be a valid email address")
+	emailDisplayNameError = i18n.S("email address must not include a display name")
+	emailDomainError      = i18n.S("email domain is not allowed")
+	emailMXError          = i18n.S("email domain does not accept mail")
+)
+
+// MXResolver looks up the mail exchangers of a domain. *net.Resolver implements it;
+// tests and offline environments can supply their own.
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// EmailSchema validates email addresses with net/mail.ParseAddress (RFC 5322) and
+// returns the bare address, without any display name
+type EmailSchema struct {
+	Schema
+	noDisplayName  bool
+	domains        []string // Allowed domains, lowercase; "*.example.com" matches subdomains
+	blockedDomains []string // Rejected domains, lowercase; "*.example.com" matches subdomains
+	mxResolver     MXResolver
+	normalize      bool // Lowercase the domain of the returned address
+	nullable       bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	displayNameError  ErrorMessage
+	domainError       ErrorMessage
+	mxError           ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// Email creates a new email address schema
+func Email(errorMessage ...interface{}) *EmailSchema {
+	schema := &EmailSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *EmailSchema) Clone() *EmailSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.domains = slices.Clone(s.domains)
+	c.blockedDomains = slices.Clone(s.blockedDomains)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *EmailSchema) Freeze() *EmailSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *EmailSchema) Title(title string) *EmailSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *EmailSchema) Description(description string) *EmailSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *EmailSchema) ReadOnly() *EmailSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *EmailSchema) WriteOnly() *EmailSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *EmailSchema) Sensitive() *EmailSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *EmailSchema) Deprecated(reason string) *EmailSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *EmailSchema) Meta(key string, value interface{}) *EmailSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *EmailSchema) AsWarning(codes ...ErrorCode) *EmailSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *EmailSchema) AsInfo(codes ...ErrorCode) *EmailSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *EmailSchema) Default(value interface{}) *EmailSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *EmailSchema) DefaultFunc(fn func() interface{}) *EmailSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *EmailSchema) Example(example string) *EmailSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// NoDisplayName rejects addresses with a display name, such as "Ada <ada@example.com>"
+func (s *EmailSchema) NoDisplayName(errorMessage ...interface{}) *EmailSchema {
+	s.checkMutable()
+	s.noDisplayName = true
+	if len(errorMessage) > 0 {
+		s.displayNameError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Domains restricts the domain to the given list (compared case-insensitively).
+// A leading "*." matches any subdomain.
+func (s *EmailSchema) Domains(domains ...string) *EmailSchema {
+	s.checkMutable()
+	s.domains = lowerAll(domains)
+	return s
+}
+
+// BlockedDomains rejects addresses at the given domains (compared case-insensitively),
+// e.g. disposable mail providers. A leading "*." matches any subdomain.
+func (s *EmailSchema) BlockedDomains(domains ...string) *EmailSchema {
+	s.checkMutable()
+	s.blockedDomains = lowerAll(domains)
+	return s
+}
+
+// DomainError sets a custom error message for domains rejected by Domains or BlockedDomains
+func (s *EmailSchema) DomainError(message interface{}) *EmailSchema {
+	s.checkMutable()
+	s.domainError = toErrorMessage(message)
+	return s
+}
+
+// CheckMX requires the domain to publish at least one MX record, looked up with
+// resolver (net.DefaultResolver for real DNS) using the parse's Go context. Failed
+// lookups are reported as validation errors.
+func (s *EmailSchema) CheckMX(resolver MXResolver, errorMessage ...interface{}) *EmailSchema {
+	s.checkMutable()
+	s.mxResolver = resolver
+	if len(errorMessage) > 0 {
+		s.mxError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Normalize lowercases the domain of the returned address; the local part is
+// case-sensitive and kept as is
+func (s *EmailSchema) Normalize() *EmailSchema {
+	s.checkMutable()
+	s.normalize = true
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *EmailSchema) Optional() *EmailSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *EmailSchema) Required(errorMessage ...interface{}) *EmailSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *EmailSchema) Nullable() *EmailSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *EmailSchema) TypeError(message string) *EmailSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for invalid addresses
+func (s *EmailSchema) FormatError(message string) *EmailSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *EmailSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *EmailSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *EmailSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetDomains returns the allowed domains
+func (s *EmailSchema) GetDomains() []string {
+	return s.domains
+}
+
+// GetBlockedDomains returns the rejected domains
+func (s *EmailSchema) GetBlockedDomains() []string {
+	return s.blockedDomains
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *EmailSchema) Transform(fn TransformFunc) *EmailSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *EmailSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *EmailSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *EmailSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *EmailSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates an email address and returns the bare address as a string
+func (s *EmailSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the email constraints; Parse runs the refine/transform pipeline on top
+func (s *EmailSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := emailRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	str, ok := value.(string)
+	if !ok {
+		message := emailTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	addr, err := mail.ParseAddress(str)
+	local, domain, valid := "", "", err == nil
+	if valid {
+		at := strings.LastIndex(addr.Address, "@")
+		local, domain = addr.Address[:at], addr.Address[at+1:]
+		valid = validEmailParts(local, domain)
+	}
+	if !valid {
+		message := emailFormatError(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+
+	var errs []ValidationError
+
+	// ParseAddress also accepts "<ada@example.com>", which has no name but is not bare either
+	if s.noDisplayName && (addr.Name != "" || strings.HasPrefix(strings.TrimSpace(str), "<")) {
+		message := emailDisplayNameError(ctx.Locale)
+		if !isEmptyErrorMessage(s.displayNameError) {
+			message = resolveErrorMessage(s.displayNameError, ctx)
+		}
+		errs = append(errs, NewPrimitiveError(value, message, CodeDisplayName))
+	}
+
+	lowerDomain := strings.ToLower(domain)
+	if (len(s.domains) > 0 && !matchHost(s.domains, lowerDomain)) || matchHost(s.blockedDomains, lowerDomain) {
+		message := emailDomainError(ctx.Locale)
+		if !isEmptyErrorMessage(s.domainError) {
+			message = resolveErrorMessage(s.domainError, ctx)
+		}
+		errs = append(errs, NewPrimitiveError(value, message, CodeDomain))
+	}
+
+	// The lookup is skipped once the address is known to be invalid
+	if s.mxResolver != nil && len(errs) == 0 {
+		if err := s.lookupMX(lowerDomain, ctx); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{contextError(value, err)}}
+			}
+			message := emailMXError(ctx.Locale)
+			if !isEmptyErrorMessage(s.mxError) {
+				message = resolveErrorMessage(s.mxError, ctx)
+			}
+			errs = append(errs, NewPrimitiveError(value, message, CodeMX))
+		}
+	}
+
+	if len(errs) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errs}
+	}
+
+	if s.normalize {
+		domain = lowerDomain
+	}
+	return ParseResult{Valid: true, Value: local + "@" + domain, Errors: nil}
+}
+
+// errNoMX reports a domain without usable mail exchangers
+var errNoMX = errors.New("no MX records")
+
+// lookupMX checks that domain has at least one MX record, ignoring the null MX
+// record ("."), which declares that the domain accepts no mail (RFC 7505)
+func (s *EmailSchema) lookupMX(domain string, ctx *ValidationContext) error {
+	goCtx := ctx.Ctx
+	if goCtx == nil {
+		goCtx = context.Background()
+	}
+	if err := goCtx.Err(); err != nil {
+		return err
+	}
+	records, err := s.mxResolver.LookupMX(goCtx, domain)
+	if err != nil {
+		if ctxErr := goCtx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	for _, record := range records {
+		if record.Host != "." && record.Host != "" {
+			return nil
+		}
+	}
+	return errNoMX
+}
+
+// validEmailParts applies the RFC 5321 length limits and requires a dotted domain
+// name; ParseAddress alone accepts "ada@localhost" and domain literals
+func validEmailParts(local, domain string) bool {
+	if len(local) > 64 || len(local)+1+len(domain) > 254 {
+		return false
+	}
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, "[") {
+		return false
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+	}
+	return true
+}
+
+// lowerAll returns a lowercase copy of values
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, value := range values {
+		lowered[i] = strings.ToLower(value)
+	}
+	return lowered
+}
+
+// JSON generates JSON Schema representation
+func (s *EmailSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["format"] = "email"
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
@@ -0,0 +1,119 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeMXResolver serves MX records from a map; unknown domains fail like NXDOMAIN
+type fakeMXResolver map[string][]*net.MX
+
+func (r fakeMXResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if records, ok := r[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestEmailSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+	resolver := fakeMXResolver{
+		"example.com": {{Host: "mx1.example.com.", Pref: 10}},
+		"nomail.com":  {{Host: ".", Pref: 0}},
+	}
+
+	tests := []struct {
+		name     string
+		schema   *EmailSchema
+		value    interface{}
+		expected bool
+		code     ErrorCode
+	}{
+		{"simple", Email(), "ada@example.com", true, ""},
+		{"plus tag", Email(), "ada+newsletter@example.com", true, ""},
+		{"subdomain", Email(), "ada@mail.example.co.uk", true, ""},
+		{"special characters", Email(), "o'brien!#$%&*=?^_`{|}~@example.com", true, ""},
+		{"display name", Email(), "Ada Lovelace <ada@example.com>", true, ""},
+		{"missing at", Email(), "ada.example.com", false, "format"},
+		{"double dot", Email(), "ada..lovelace@example.com", false, "format"},
+		{"trailing dot", Email(), "ada.@example.com", false, "format"},
+		{"no tld", Email(), "ada@localhost", false, "format"},
+		{"empty label", Email(), "ada@example..com", false, "format"},
+		{"hyphen label", Email(), "ada@-example.com", false, "format"},
+		{"domain literal", Email(), "ada@[192.168.0.1]", false, "format"},
+		{"local part too long", Email(), strings.Repeat("a", 65) + "@example.com", false, "format"},
+		{"not a string", Email(), 42, false, "invalid_type"},
+		{"no display name", Email().NoDisplayName(), "ada@example.com", true, ""},
+		{"display name rejected", Email().NoDisplayName(), "Ada <ada@example.com>", false, "display_name"},
+		{"angle brackets rejected", Email().NoDisplayName(), "<ada@example.com>", false, "display_name"},
+		{"allowed domain", Email().Domains("example.com"), "ada@Example.COM", true, ""},
+		{"domain not allowed", Email().Domains("example.com"), "ada@other.com", false, "domain"},
+		{"wildcard domain", Email().Domains("*.example.com"), "ada@eu.example.com", true, ""},
+		{"blocked domain", Email().BlockedDomains("mailinator.com"), "ada@mailinator.com", false, "domain"},
+		{"blocked subdomain", Email().BlockedDomains("*.mailinator.com"), "ada@x.mailinator.com", false, "domain"},
+		{"mx", Email().CheckMX(resolver), "ada@example.com", true, ""},
+		{"mx missing", Email().CheckMX(resolver), "ada@unknown.com", false, "mx"},
+		{"null mx", Email().CheckMX(resolver), "ada@nomail.com", false, "mx"},
+		{"required nil", Email(), nil, false, "required"},
+		{"optional nil", Email().Optional(), nil, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestEmailSchema_Value(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	if got := Email().Parse("Ada <Ada@Example.COM>", ctx).Value; got != "Ada@Example.COM" {
+		t.Errorf("Value = %v, want bare address", got)
+	}
+	if got := Email().Normalize().Parse("Ada@Example.COM", ctx).Value; got != "Ada@example.com" {
+		t.Errorf("Value = %v, want lowercase domain", got)
+	}
+
+	// Lookups are skipped for addresses that already failed
+	calls := 0
+	counting := mxResolverFunc(func(ctx context.Context, name string) ([]*net.MX, error) {
+		calls++
+		return nil, nil
+	})
+	Email().Domains("example.com").CheckMX(counting).Parse("ada@other.com", ctx)
+	if calls != 0 {
+		t.Errorf("LookupMX called %d times", calls)
+	}
+
+	// A canceled parse reports the context error instead of an MX failure
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := Email().CheckMX(counting).Parse("ada@example.com", DefaultValidationContext().WithContext(canceled))
+	if result.Valid || result.Errors[0].Code != "canceled" {
+		t.Errorf("errors = %v, want canceled", result.Errors)
+	}
+
+	failing := mxResolverFunc(func(ctx context.Context, name string) ([]*net.MX, error) {
+		return nil, errors.New("timeout")
+	})
+	result = Email().CheckMX(failing, "could not verify domain").Parse("ada@example.com", ctx)
+	if result.Valid || result.Errors[0].Message != "could not verify domain" {
+		t.Errorf("errors = %v", result.Errors)
+	}
+}
+
+type mxResolverFunc func(ctx context.Context, name string) ([]*net.MX, error)
+
+func (f mxResolverFunc) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return f(ctx, name)
+}
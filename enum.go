@@ -0,0 +1,515 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for enum validation
+var (
+	enumRequiredError = i18n.S("value is required")
+)
+
+func enumTypeError(typeName string) i18n.TranslatedFunc {
+	return i18n.F("value must be a %s", typeName)
+}
+
+func enumValueError(values string) i18n.TranslatedFunc {
+	return i18n.F("value must be one of: %s", values)
+}
+
+// EnumSchema validates that a value is one of a fixed set of values of a Go enum type,
+// such as a named string or integer type with declared constants:
+//
+//	type Status string
+//	const (
+//	    StatusActive   Status = "active"
+//	    StatusDisabled Status = "disabled"
+//	)
+//
+//	schema.Enum(StatusActive, StatusDisabled)
+//
+// Input of the underlying type ("active", or 2.0 from JSON for integer enums) is
+// converted to T, so the parsed value is always a T.
+type EnumSchema[T comparable] struct {
+	Schema
+	values          []T
+	labels          map[T]string // Variable names, emitted as x-enum-varnames
+	descriptions    map[T]string // Per-value descriptions, emitted as x-enum-descriptions
+	caseInsensitive bool
+	nullable        bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	enumError         ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// Enum creates a schema that accepts exactly the given values
+func Enum[T comparable](values ...T) *EnumSchema[T] {
+	return &EnumSchema[T]{
+		Schema: Schema{
+			schemaType: enumJSONType(reflect.TypeOf((*T)(nil)).Elem()),
+			required:   true, // Default to required
+		},
+		values:       values,
+		labels:       make(map[T]string),
+		descriptions: make(map[T]string),
+	}
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *EnumSchema[T]) Clone() *EnumSchema[T] {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.values = slices.Clone(s.values)
+	c.labels = maps.Clone(s.labels)
+	c.descriptions = maps.Clone(s.descriptions)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *EnumSchema[T]) Freeze() *EnumSchema[T] {
+	s.freeze()
+	return s
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *EnumSchema[T]) Title(title string) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *EnumSchema[T]) Description(description string) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *EnumSchema[T]) ReadOnly() *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *EnumSchema[T]) WriteOnly() *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *EnumSchema[T]) Sensitive() *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *EnumSchema[T]) Deprecated(reason string) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *EnumSchema[T]) Meta(key string, value interface{}) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *EnumSchema[T]) AsWarning(codes ...ErrorCode) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *EnumSchema[T]) AsInfo(codes ...ErrorCode) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *EnumSchema[T]) Default(value T) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *EnumSchema[T]) DefaultFunc(fn func() interface{}) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *EnumSchema[T]) Example(example T) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Enum-specific methods
+
+// Values appends allowed values
+func (s *EnumSchema[T]) Values(values ...T) *EnumSchema[T] {
+	s.checkMutable()
+	s.values = append(s.values, values...)
+	return s
+}
+
+// Label sets the variable name of a value (e.g. "StatusActive"), used by code generators
+func (s *EnumSchema[T]) Label(value T, label string) *EnumSchema[T] {
+	s.checkMutable()
+	s.labels[value] = label
+	return s
+}
+
+// DescribeValue sets a human-readable description of a single value
+func (s *EnumSchema[T]) DescribeValue(value T, description string) *EnumSchema[T] {
+	s.checkMutable()
+	s.descriptions[value] = description
+	return s
+}
+
+// CaseInsensitive accepts string input regardless of case; the parsed value is the
+// declared value (so "ACTIVE" parses to StatusActive)
+func (s *EnumSchema[T]) CaseInsensitive() *EnumSchema[T] {
+	s.checkMutable()
+	s.caseInsensitive = true
+	return s
+}
+
+// Required/Optional/Nullable control
+
+// Optional marks the schema as optional
+func (s *EnumSchema[T]) Optional() *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *EnumSchema[T]) Required(errorMessage ...interface{}) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *EnumSchema[T]) Nullable() *EnumSchema[T] {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// Error customization
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *EnumSchema[T]) TypeError(message string) *EnumSchema[T] {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// EnumError sets a custom error message for values outside the enum
+func (s *EnumSchema[T]) EnumError(message string) *EnumSchema[T] {
+	s.checkMutable()
+	s.enumError = toErrorMessage(message)
+	return s
+}
+
+// Getters for accessing private fields
+
+// IsRequired returns whether the schema is marked as required
+func (s *EnumSchema[T]) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *EnumSchema[T]) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *EnumSchema[T]) IsNullable() bool {
+	return s.nullable
+}
+
+// IsCaseInsensitive returns whether string input is matched regardless of case
+func (s *EnumSchema[T]) IsCaseInsensitive() bool {
+	return s.caseInsensitive
+}
+
+// GetValues returns the allowed values
+func (s *EnumSchema[T]) GetValues() []T {
+	return s.values
+}
+
+// coerces reports that input is converted to T, so an enum of a type that implements
+// encoding.TextUnmarshaler accepts strings whatever its underlying type
+func (s *EnumSchema[T]) coerces() bool {
+	return true
+}
+
+// GetLabel returns the variable name of a value: the label set with Label, the value's
+// String() method if T implements fmt.Stringer, or "" otherwise
+func (s *EnumSchema[T]) GetLabel(value T) string {
+	if label, ok := s.labels[value]; ok {
+		return label
+	}
+	if stringer, ok := any(value).(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return ""
+}
+
+// GetValueDescription returns the description of a value set with DescribeValue
+func (s *EnumSchema[T]) GetValueDescription(value T) string {
+	return s.descriptions[value]
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *EnumSchema[T]) Transform(fn TransformFunc) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *EnumSchema[T]) Refine(fn RefineFunc, errorMessage ...interface{}) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *EnumSchema[T]) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *EnumSchema[T] {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Validation
+
+// Parse validates and parses an enum value, returning the matching T
+func (s *EnumSchema[T]) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the enum constraints; Parse runs the refine/transform pipeline on top
+func (s *EnumSchema[T]) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := enumRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{
+				Valid:  false,
+				Value:  nil,
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
+			}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Convert input of the underlying type (e.g. string or float64 from JSON) to T
+	enumType := reflect.TypeOf((*T)(nil)).Elem()
+	typed, ok := value.(T)
+	if !ok {
+		converted := reflect.New(enumType).Elem()
+		if err := bindValue(converted, value, nil); err != nil {
+			message := enumTypeError(enumType.String())(ctx.Locale)
+			if !isEmptyErrorMessage(s.typeMismatchError) {
+				message = resolveErrorMessage(s.typeMismatchError, ctx)
+			}
+			return ParseResult{
+				Valid:  false,
+				Value:  nil,
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
+			}
+		}
+		typed = converted.Interface().(T)
+	}
+
+	if match, ok := s.match(typed); ok {
+		return ParseResult{Valid: true, Value: match, Errors: nil}
+	}
+
+	message := enumValueError(s.valueList())(ctx.Locale)
+	if !isEmptyErrorMessage(s.enumError) {
+		message = resolveErrorMessage(s.enumError, ctx)
+	}
+	return ParseResult{
+		Valid:  false,
+		Value:  nil,
+		Errors: []ValidationError{NewPrimitiveError(value, message, CodeEnum)},
+	}
+}
+
+// match returns the declared value equal to v, comparing strings case-insensitively
+// when enabled
+func (s *EnumSchema[T]) match(v T) (T, bool) {
+	for _, allowed := range s.values {
+		// Numbers match regardless of Go type, so 1 matches 1.0 in untyped enums
+		if allowed == v || jsonValuesEqual(enumPrimitive(allowed), enumPrimitive(v)) {
+			return allowed, true
+		}
+	}
+	if s.caseInsensitive {
+		input := reflect.ValueOf(v)
+		if input.Kind() == reflect.String {
+			for _, allowed := range s.values {
+				if strings.EqualFold(reflect.ValueOf(allowed).String(), input.String()) {
+					return allowed, true
+				}
+			}
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// valueList formats the allowed values for error messages
+func (s *EnumSchema[T]) valueList() string {
+	parts := make([]string, len(s.values))
+	for i, v := range s.values {
+		parts[i] = fmt.Sprintf("%v", enumPrimitive(v))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// JSON generates JSON Schema representation
+func (s *EnumSchema[T]) JSON() map[string]interface{} {
+	schema := map[string]interface{}{}
+	if s.Schema.schemaType != "" {
+		schema["type"] = s.Schema.schemaType
+	}
+
+	// Add base schema fields
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	if defaultVal := s.staticDefault(); defaultVal != nil {
+		schema["default"] = enumPrimitive(defaultVal)
+	}
+	if examples := s.GetExamples(); len(examples) > 0 {
+		primitives := make([]interface{}, len(examples))
+		for i, example := range examples {
+			primitives[i] = enumPrimitive(example)
+		}
+		schema["examples"] = primitives
+	}
+
+	values := make([]interface{}, len(s.values))
+	varnames := make([]string, len(s.values))
+	descriptions := make([]string, len(s.values))
+	hasVarnames, hasDescriptions := false, false
+	for i, v := range s.values {
+		values[i] = enumPrimitive(v)
+		if varnames[i] = s.GetLabel(v); varnames[i] != "" {
+			hasVarnames = true
+		}
+		if descriptions[i] = s.descriptions[v]; descriptions[i] != "" {
+			hasDescriptions = true
+		}
+	}
+	schema["enum"] = values
+	if hasVarnames {
+		// Fall back to the value itself for values without a label
+		for i, name := range varnames {
+			if name == "" {
+				varnames[i] = fmt.Sprintf("%v", values[i])
+			}
+		}
+		schema["x-enum-varnames"] = varnames
+	}
+	if hasDescriptions {
+		schema["x-enum-descriptions"] = descriptions
+	}
+
+	// Add nullable if true
+	if s.nullable {
+		if s.Schema.schemaType != "" {
+			schema["type"] = []string{s.Schema.schemaType, "null"}
+		}
+		schema["enum"] = append(values, nil)
+	}
+
+	return schema
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize EnumSchema for JSON schema generation
+func (s *EnumSchema[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.JSON())
+}
+
+// enumJSONType returns the JSON Schema type for the underlying kind of an enum type
+func enumJSONType(t reflect.Type) string {
+	switch kind := t.Kind(); {
+	case kind == reflect.String:
+		return "string"
+	case kind >= reflect.Int && kind <= reflect.Uint64:
+		return "integer"
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return "number"
+	case kind == reflect.Bool:
+		return "boolean"
+	}
+	return ""
+}
+
+// enumPrimitive converts a value of a named type to its underlying primitive, so JSON
+// output is not affected by methods such as MarshalJSON or MarshalText on the enum type
+func enumPrimitive(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	switch kind := rv.Kind(); {
+	case kind == reflect.String:
+		return rv.String()
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		return rv.Int()
+	case kind >= reflect.Uint && kind <= reflect.Uintptr:
+		return rv.Uint()
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return rv.Float()
+	case kind == reflect.Bool:
+		return rv.Bool()
+	}
+	return v
+}
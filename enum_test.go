@@ -0,0 +1,170 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testStatus string
+
+const (
+	testStatusActive   testStatus = "active"
+	testStatusDisabled testStatus = "disabled"
+)
+
+type testPriority int
+
+const (
+	testPriorityLow testPriority = iota + 1
+	testPriorityHigh
+)
+
+func (p testPriority) String() string {
+	switch p {
+	case testPriorityLow:
+		return "PriorityLow"
+	case testPriorityHigh:
+		return "PriorityHigh"
+	}
+	return "PriorityUnknown"
+}
+
+func TestEnumSchema_Parse(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   Parseable
+		value    interface{}
+		expected bool
+		want     interface{}
+		code     ErrorCode
+	}{
+		{"typed string value", Enum(testStatusActive, testStatusDisabled), testStatusActive, true, testStatusActive, ""},
+		{"underlying string", Enum(testStatusActive, testStatusDisabled), "disabled", true, testStatusDisabled, ""},
+		{"unknown string", Enum(testStatusActive, testStatusDisabled), "deleted", false, nil, "enum"},
+		{"case sensitive by default", Enum(testStatusActive), "ACTIVE", false, nil, "enum"},
+		{"case insensitive", Enum(testStatusActive).CaseInsensitive(), "ACTIVE", true, testStatusActive, ""},
+		{"wrong type", Enum(testStatusActive), 1, false, nil, "invalid_type"},
+		{"int from json number", Enum(testPriorityLow, testPriorityHigh), 2.0, true, testPriorityHigh, ""},
+		{"int out of range", Enum(testPriorityLow, testPriorityHigh), 3, false, nil, "enum"},
+		{"fractional int", Enum(testPriorityLow), 1.5, false, nil, "invalid_type"},
+		{"required nil", Enum(testStatusActive), nil, false, nil, "required"},
+		{"default", Enum(testStatusActive, testStatusDisabled).Default(testStatusDisabled), nil, true, testStatusDisabled, ""},
+		{"nullable nil", Enum(testStatusActive).Nullable(), nil, true, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v. Errors: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if result.Valid && result.Value != tt.want {
+				t.Errorf("Parse(%v) value = %#v, want %#v", tt.value, result.Value, tt.want)
+			}
+			if !result.Valid && result.Errors[0].Code != tt.code {
+				t.Errorf("Parse(%v) code = %q, want %q", tt.value, result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestEnumSchema_JSON(t *testing.T) {
+	status := Enum(testStatusActive, testStatusDisabled).
+		Label(testStatusActive, "StatusActive").
+		DescribeValue(testStatusDisabled, "Account was disabled by an admin").
+		JSON()
+
+	if status["type"] != "string" {
+		t.Errorf("type = %v, want string", status["type"])
+	}
+	if !reflect.DeepEqual(status["enum"], []interface{}{"active", "disabled"}) {
+		t.Errorf("enum = %v", status["enum"])
+	}
+	if !reflect.DeepEqual(status["x-enum-varnames"], []string{"StatusActive", "disabled"}) {
+		t.Errorf("x-enum-varnames = %v", status["x-enum-varnames"])
+	}
+	if !reflect.DeepEqual(status["x-enum-descriptions"], []string{"", "Account was disabled by an admin"}) {
+		t.Errorf("x-enum-descriptions = %v", status["x-enum-descriptions"])
+	}
+
+	priority := Enum(testPriorityLow, testPriorityHigh).JSON()
+	if priority["type"] != "integer" || !reflect.DeepEqual(priority["enum"], []interface{}{int64(1), int64(2)}) {
+		t.Errorf("priority schema = %v", priority)
+	}
+	if !reflect.DeepEqual(priority["x-enum-varnames"], []string{"PriorityLow", "PriorityHigh"}) {
+		t.Errorf("Stringer labels = %v", priority["x-enum-varnames"])
+	}
+}
+
+func TestLiteralSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *LiteralSchema
+		value    interface{}
+		expected bool
+	}{
+		{"string match", Literal("circle"), "circle", true},
+		{"string mismatch", Literal("circle"), "square", false},
+		{"number across types", Literal(1), 1.0, true},
+		{"number mismatch", Literal(1), 2, false},
+		{"bool", Literal(true), true, true},
+		{"bool mismatch", Literal(true), "true", false},
+		{"named string type", Literal("active"), testStatusActive, true},
+		{"required nil", Literal("circle"), nil, false},
+		{"optional nil", Literal("circle").Optional(), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v. Errors: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if result.Valid && tt.value != nil && result.Value != tt.schema.GetValue() {
+				t.Errorf("Parse(%v) value = %#v, want the literal %#v", tt.value, result.Value, tt.schema.GetValue())
+			}
+		})
+	}
+
+	json := Literal("circle").JSON()
+	if json["const"] != "circle" || json["type"] != "string" {
+		t.Errorf("Literal JSON = %v", json)
+	}
+
+	shape := Object().Property("type", Literal("circle")).Property("radius", Number())
+	if result := shape.Parse(map[string]interface{}{"type": "square", "radius": 1.0}, ctx); result.Valid {
+		t.Error("expected discriminator mismatch to fail")
+	}
+}
+
+func TestNativeEnum(t *testing.T) {
+	ctx := DefaultValidationContext()
+	colors := NativeEnum(map[string]interface{}{"Red": 1, "Green": 2, "Blue": 3})
+
+	if result := colors.Parse(2.0, ctx); !result.Valid || result.Value != 2 {
+		t.Errorf("Parse(2.0) = %v (%#v), want the declared value 2", result.Errors, result.Value)
+	}
+	if result := colors.Parse(4, ctx); result.Valid {
+		t.Error("expected value outside the enum to fail")
+	}
+
+	json := colors.JSON()
+	if json["type"] != "integer" {
+		t.Errorf("type = %v, want integer", json["type"])
+	}
+	if !reflect.DeepEqual(json["enum"], []interface{}{int64(3), int64(2), int64(1)}) {
+		t.Errorf("enum = %v, want values ordered by name", json["enum"])
+	}
+	if !reflect.DeepEqual(json["x-enum-varnames"], []string{"Blue", "Green", "Red"}) {
+		t.Errorf("x-enum-varnames = %v", json["x-enum-varnames"])
+	}
+
+	mixed := NativeEnum(map[string]interface{}{"A": "a", "One": 1}).JSON()
+	if _, ok := mixed["type"]; ok {
+		t.Errorf("mixed enum should not declare a type, got %v", mixed["type"])
+	}
+}
@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"os"
+	"strings"
+	"unicode"
+)
+
+// LoadEnv reads configuration for s from environment variables and validates it.
+// Each property maps to a variable named after its path, upper-cased and joined with
+// underscores: with prefix "APP", the property port of the nested object db is read
+// from APP_DB_PORT (camelCase names become DB_MAX_CONNS). Arrays are read as
+// comma-separated lists.
+//
+// Values are coerced to the property types. A property whose variable is unset or
+// empty behaves like a missing value elsewhere in the package: its default is used
+// if it has one, otherwise it is reported as required unless it is optional.
+func LoadEnv(s *ObjectSchema, prefix string) (map[string]interface{}, error) {
+	result := s.Parse(envToMap(s, prefix, os.LookupEnv), DefaultValidationContext().WithCoercion())
+	if !result.Valid {
+		return nil, ValidationErrors(result.Errors)
+	}
+	config, _ := result.Value.(map[string]interface{})
+	return config, nil
+}
+
+// LoadEnvInto reads and validates configuration like LoadEnv and binds the result
+// into dest, a non-nil pointer to a struct
+func LoadEnvInto(s *ObjectSchema, prefix string, dest interface{}) error {
+	return s.ParseInto(envToMap(s, prefix, os.LookupEnv), dest, DefaultValidationContext().WithCoercion())
+}
+
+// EnvName returns the environment variable that LoadEnv reads for the property at
+// path, e.g. EnvName("APP", "db", "maxConns") is "APP_DB_MAX_CONNS"
+func EnvName(prefix string, path ...string) string {
+	parts := make([]string, 0, len(path)+1)
+	if prefix = strings.TrimSuffix(prefix, "_"); prefix != "" {
+		parts = append(parts, prefix)
+	}
+	for _, name := range path {
+		parts = append(parts, envSegment(name))
+	}
+	return strings.Join(parts, "_")
+}
+
+// envToMap collects the variables for the properties of s. Nested objects are
+// included when any of their variables are set or when they are required, so that
+// missing variables are reported at their own path.
+func envToMap(s *ObjectSchema, prefix string, lookup func(string) (string, bool)) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	for name, prop := range s.properties {
+		key := EnvName(prefix, name)
+
+		if nested, ok := prop.Schema.(*ObjectSchema); ok {
+			nestedValues := envToMap(nested, key, lookup)
+			if len(nestedValues) > 0 || (prop.Required && nested.GetDefault() == nil) {
+				values[name] = nestedValues
+			}
+			continue
+		}
+
+		raw, ok := lookup(key)
+		if !ok || strings.TrimSpace(raw) == "" {
+			// Present as nil so the property's default (or required error) applies
+			if d, hasDefault := prop.Schema.(interface{ GetDefault() interface{} }); hasDefault && d.GetDefault() != nil {
+				values[name] = nil
+			}
+			continue
+		}
+
+		if _, isArray := prop.Schema.(*ArraySchema); isArray {
+			items := []interface{}{}
+			for _, item := range strings.Split(raw, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			values[name] = items
+			continue
+		}
+		values[name] = raw
+	}
+	return values
+}
+
+// envSegment converts a property name to its environment variable form:
+// "maxConns" and "max-conns" become "MAX_CONNS", "apiURL" becomes "API_URL"
+func envSegment(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == '.' || r == ' ':
+			b.WriteByte('_')
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
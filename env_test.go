@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLoadEnv(t *testing.T) {
+	config := Object().
+		Property("port", Int().Min(1).Default(8080)).
+		Property("debug", Bool().Optional()).
+		Property("allowedHosts", Array(String()).Optional()).
+		Property("db", Object().
+			Property("host", String()).
+			Property("maxConns", Int().Default(10)))
+
+	t.Setenv("APP_DB_HOST", "localhost")
+	t.Setenv("APP_ALLOWED_HOSTS", "a.example.com, b.example.com")
+	t.Setenv("APP_DEBUG", "true")
+	t.Setenv("APP_PORT", "")
+
+	values, err := LoadEnv(config, "APP")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]interface{}{
+		"port":         8080,
+		"debug":        true,
+		"allowedHosts": []interface{}{"a.example.com", "b.example.com"},
+		"db":           map[string]interface{}{"host": "localhost", "maxConns": 10},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("LoadEnv = %#v, want %#v", values, want)
+	}
+
+	t.Setenv("APP_DB_MAX_CONNS", "many")
+	_, err = LoadEnv(config, "APP_")
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	if path := validationErrs[len(validationErrs)-1].Path.DotPath(); path != "db.maxConns" {
+		t.Errorf("error path = %s, want db.maxConns", path)
+	}
+}
+
+func TestLoadEnv_Required(t *testing.T) {
+	config := Object().Property("db", Object().Property("host", String()))
+
+	_, err := LoadEnv(config, "MISSING_PREFIX")
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	if path := validationErrs[len(validationErrs)-1].Path.DotPath(); path != "db.host" {
+		t.Errorf("missing variable reported at %s, want db.host", path)
+	}
+}
+
+func TestLoadEnvInto(t *testing.T) {
+	type Config struct {
+		Port int    `json:"port"`
+		Mode string `json:"mode"`
+	}
+	config := Object().
+		Property("port", Int()).
+		Property("mode", String().Default("production"))
+
+	t.Setenv("SVC_PORT", "9000")
+	var cfg Config
+	if err := LoadEnvInto(config, "SVC", &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Port != 9000 || cfg.Mode != "production" {
+		t.Errorf("config = %+v", cfg)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		prefix string
+		path   []string
+		want   string
+	}{
+		{"APP", []string{"port"}, "APP_PORT"},
+		{"APP_", []string{"db", "maxConns"}, "APP_DB_MAX_CONNS"},
+		{"", []string{"apiURL"}, "API_URL"},
+		{"X", []string{"oauth2Token"}, "X_OAUTH2_TOKEN"},
+		{"X", []string{"log-level"}, "X_LOG_LEVEL"},
+		{"X", []string{"HTTPServer"}, "X_HTTP_SERVER"},
+	}
+	for _, tt := range tests {
+		if got := EnvName(tt.prefix, tt.path...); got != tt.want {
+			t.Errorf("EnvName(%q, %v) = %q, want %q", tt.prefix, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package schema
+
+import (
+	"net/http"
+	"sort"
+)
+
+// ErrorCode is the machine-readable code of a validation error. The codes reported by
+// the schemas of this package are the Code constants; validators such as Refine and
+// SuperRefine can report codes of their own.
+type ErrorCode string
+
+// Codes common to all schemas
+const (
+	CodeRequired    ErrorCode = "required"     // A required value or property is missing
+	CodeInvalidType ErrorCode = "invalid_type" // The value has the wrong type
+	CodeEnum        ErrorCode = "enum"         // The value is not one of the allowed values
+	CodeConst       ErrorCode = "const"        // The value is not the constant value
+	CodeFormat      ErrorCode = "format"       // The value does not match the format
+	CodeCustom      ErrorCode = "custom"       // A Refine or SuperRefine check failed
+	CodeTransform   ErrorCode = "transform"    // A transform failed
+	CodeNever       ErrorCode = "never"        // The value is forbidden
+	CodeDeprecated  ErrorCode = "deprecated"   // A deprecated property is used (a warning)
+)
+
+// Codes of strings and numbers
+const (
+	CodeMinLength        ErrorCode = "min_length"
+	CodeMaxLength        ErrorCode = "max_length"
+	CodePattern          ErrorCode = "pattern"
+	CodeControlChars     ErrorCode = "control_chars"
+	CodeMinimum          ErrorCode = "minimum"
+	CodeMaximum          ErrorCode = "maximum"
+	CodeExclusiveMinimum ErrorCode = "exclusive_minimum"
+	CodeExclusiveMaximum ErrorCode = "exclusive_maximum"
+	CodeMultipleOf       ErrorCode = "multiple_of"
+	CodePrecision        ErrorCode = "precision"
+	CodeNotFinite        ErrorCode = "not_finite"
+)
+
+// Codes of objects, arrays, tuples, records and maps
+const (
+	CodeMinItems           ErrorCode = "min_items"
+	CodeMaxItems           ErrorCode = "max_items"
+	CodeUniqueItems        ErrorCode = "unique_items"
+	CodeItemInvalid        ErrorCode = "item_invalid"
+	CodeTupleLength        ErrorCode = "tuple_length"
+	CodeMinProperties      ErrorCode = "min_properties"
+	CodeMaxProperties      ErrorCode = "max_properties"
+	CodePropertyInvalid    ErrorCode = "property_invalid"
+	CodeAdditionalProperty ErrorCode = "additional_property"
+	CodeDuplicateKey       ErrorCode = "duplicate_key"
+	CodeKeyInvalid         ErrorCode = "key_invalid"
+	CodeValueInvalid       ErrorCode = "value_invalid"
+)
+
+// Codes of combinators and references
+const (
+	CodeAllOfNotAllMatch  ErrorCode = "allof_not_all_match"
+	CodeAllOfSchemaFailed ErrorCode = "allof_schema_failed"
+	CodeAnyOfNoMatch      ErrorCode = "anyof_no_match"
+	CodeNoMatch           ErrorCode = "no_match"
+	CodeMultipleMatch     ErrorCode = "multiple_match"
+	CodeNotMatch          ErrorCode = "not_match"
+	CodeThenFailed        ErrorCode = "then_failed"
+	CodeElseFailed        ErrorCode = "else_failed"
+	CodeInvalidRefFormat  ErrorCode = "invalid_ref_format"
+	CodeRefNotFound       ErrorCode = "ref_not_found"
+	CodeCircularRef       ErrorCode = "circular_ref"
+)
+
+// Codes of input limits, cancellation and documents
+const (
+	CodeMaxDepth         ErrorCode = "max_depth"
+	CodeTooLong          ErrorCode = "too_long"
+	CodeTooLarge         ErrorCode = "too_large"
+	CodeCanceled         ErrorCode = "canceled"
+	CodeDeadlineExceeded ErrorCode = "deadline_exceeded"
+	CodeInvalidJSON      ErrorCode = "invalid_json"
+	CodeInvalidYAML      ErrorCode = "invalid_yaml"
+	CodeInvalidTOML      ErrorCode = "invalid_toml"
+	CodeInvalidINI       ErrorCode = "invalid_ini"
+	CodeInvalidCSV       ErrorCode = "invalid_csv"
+	CodeInvalidMultipart ErrorCode = "invalid_multipart"
+)
+
+// Codes of specialized schemas
+const (
+	CodePast             ErrorCode = "past"
+	CodeFuture           ErrorCode = "future"
+	CodeMinDate          ErrorCode = "min_date"
+	CodeMaxDate          ErrorCode = "max_date"
+	CodeWithinLast       ErrorCode = "within_last"
+	CodeWithinNext       ErrorCode = "within_next"
+	CodeMinSize          ErrorCode = "min_size"
+	CodeMaxSize          ErrorCode = "max_size"
+	CodeInvalidCode      ErrorCode = "invalid_code"
+	CodeCharacterClass   ErrorCode = "character_class"
+	CodeCharacterClasses ErrorCode = "character_classes"
+	CodeRepeated         ErrorCode = "repeated"
+	CodeEntropy          ErrorCode = "entropy"
+	CodeCommon           ErrorCode = "common"
+	CodeDeniedTerm       ErrorCode = "denied_term"
+	CodeDomain           ErrorCode = "domain"
+	CodeDisplayName      ErrorCode = "display_name"
+	CodeMX               ErrorCode = "mx"
+	CodeScheme           ErrorCode = "scheme"
+	CodeHost             ErrorCode = "host"
+	CodeHostRequired     ErrorCode = "host_required"
+	CodeUserInfo         ErrorCode = "user_info"
+	CodeHostBits         ErrorCode = "host_bits"
+	CodeAbsolute         ErrorCode = "absolute"
+	CodeTraversal        ErrorCode = "traversal"
+	CodePortable         ErrorCode = "portable"
+	CodeExtension        ErrorCode = "extension"
+	CodeMimeType         ErrorCode = "mime_type"
+	CodeParameters       ErrorCode = "parameters"
+	CodeDimensions       ErrorCode = "dimensions"
+	CodeAlpha            ErrorCode = "alpha"
+	CodeNotation         ErrorCode = "notation"
+	CodeRegion           ErrorCode = "region"
+	CodePhoneType        ErrorCode = "phone_type"
+	CodeVersion          ErrorCode = "version"
+	CodeCase             ErrorCode = "case"
+	CodePrerelease       ErrorCode = "prerelease"
+	CodeMinVersion       ErrorCode = "min_version"
+	CodeMaxVersion       ErrorCode = "max_version"
+	CodeVersionRange     ErrorCode = "version_range"
+	CodeAlgorithm        ErrorCode = "algorithm"
+	CodeSignature        ErrorCode = "signature"
+	CodeInvalidClaim     ErrorCode = "invalid_claim"
+	CodeExpired          ErrorCode = "expired"
+	CodeExpiryRequired   ErrorCode = "expiry_required"
+	CodeNotYetValid      ErrorCode = "not_yet_valid"
+	CodeIssuer           ErrorCode = "issuer"
+	CodeAudience         ErrorCode = "audience"
+	CodePatchPath        ErrorCode = "patch_path"
+)
+
+// ErrorCodeInfo describes an error code of the catalog
+type ErrorCodeInfo struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`          // Default English message, without the constraint values
+	Status  int       `json:"status"`           // HTTP status a service should answer with
+	Params  []string  `json:"params,omitempty"` // Keys of ValidationError.Params for the code
+}
+
+// catalogEntry is the catalog description of a code. params maps the keys of
+// ValidationError.Params to the JSON Schema keywords their values are read from.
+type catalogEntry struct {
+	message string
+	status  int
+	params  map[string]string
+}
+
+const (
+	statusInvalid = http.StatusUnprocessableEntity
+	statusLimit   = http.StatusRequestEntityTooLarge
+)
+
+var (
+	minLengthParams = map[string]string{"min": "minLength"}
+	maxLengthParams = map[string]string{"max": "maxLength"}
+)
+
+// errorCatalog describes the codes reported by the schemas of this package
+var errorCatalog = map[ErrorCode]catalogEntry{
+	CodeRequired:    {"value is required", statusInvalid, nil},
+	CodeInvalidType: {"value has the wrong type", statusInvalid, map[string]string{"expected": "type"}},
+	CodeEnum:        {"value is not one of the allowed values", statusInvalid, map[string]string{"allowed": "enum"}},
+	CodeConst:       {"value is not the expected value", statusInvalid, map[string]string{"expected": "const"}},
+	CodeFormat:      {"value has an invalid format", statusInvalid, map[string]string{"format": "format"}},
+	CodeCustom:      {"value is invalid", statusInvalid, nil},
+	CodeTransform:   {"value could not be transformed", statusInvalid, nil},
+	CodeNever:       {"value is not allowed", statusInvalid, nil},
+	CodeDeprecated:  {"property is deprecated", statusInvalid, nil},
+
+	CodeMinLength:        {"value is too short", statusInvalid, minLengthParams},
+	CodeMaxLength:        {"value is too long", statusInvalid, maxLengthParams},
+	CodePattern:          {"value does not match the pattern", statusInvalid, map[string]string{"pattern": "pattern"}},
+	CodeControlChars:     {"value contains control characters", statusInvalid, nil},
+	CodeMinimum:          {"value is too small", statusInvalid, map[string]string{"min": "minimum"}},
+	CodeMaximum:          {"value is too large", statusInvalid, map[string]string{"max": "maximum"}},
+	CodeExclusiveMinimum: {"value is too small", statusInvalid, map[string]string{"min": "exclusiveMinimum"}},
+	CodeExclusiveMaximum: {"value is too large", statusInvalid, map[string]string{"max": "exclusiveMaximum"}},
+	CodeMultipleOf:       {"value is not a multiple of the step", statusInvalid, map[string]string{"multipleOf": "multipleOf"}},
+	CodePrecision:        {"value has too many decimal places", statusInvalid, nil},
+	CodeNotFinite:        {"value is not a finite number", statusInvalid, nil},
+
+	CodeMinItems:           {"too few items", statusInvalid, map[string]string{"min": "minItems"}},
+	CodeMaxItems:           {"too many items", statusInvalid, map[string]string{"max": "maxItems"}},
+	CodeUniqueItems:        {"items are not unique", statusInvalid, nil},
+	CodeItemInvalid:        {"item is invalid", statusInvalid, nil},
+	CodeTupleLength:        {"tuple has the wrong length", statusInvalid, map[string]string{"min": "minItems", "max": "maxItems"}},
+	CodeMinProperties:      {"too few properties", statusInvalid, map[string]string{"min": "minProperties"}},
+	CodeMaxProperties:      {"too many properties", statusInvalid, map[string]string{"max": "maxProperties"}},
+	CodePropertyInvalid:    {"property is invalid", statusInvalid, nil},
+	CodeAdditionalProperty: {"property is not allowed", statusInvalid, nil},
+	CodeDuplicateKey:       {"key is duplicated", statusInvalid, nil},
+	CodeKeyInvalid:         {"key is invalid", statusInvalid, nil},
+	CodeValueInvalid:       {"value is invalid", statusInvalid, nil},
+
+	CodeAllOfNotAllMatch:  {"value does not match all schemas", statusInvalid, nil},
+	CodeAllOfSchemaFailed: {"value does not match a schema", statusInvalid, nil},
+	CodeAnyOfNoMatch:      {"value does not match any schema", statusInvalid, nil},
+	CodeNoMatch:           {"value does not match any schema", statusInvalid, nil},
+	CodeMultipleMatch:     {"value matches more than one schema", statusInvalid, nil},
+	CodeNotMatch:          {"value matches a forbidden schema", statusInvalid, nil},
+	CodeThenFailed:        {"value does not match the then schema", statusInvalid, nil},
+	CodeElseFailed:        {"value does not match the else schema", statusInvalid, nil},
+	CodeInvalidRefFormat:  {"reference is malformed", http.StatusInternalServerError, nil},
+	CodeRefNotFound:       {"reference is not defined", http.StatusInternalServerError, nil},
+	CodeCircularRef:       {"reference is circular", http.StatusInternalServerError, nil},
+
+	CodeMaxDepth:         {"value is nested too deeply", statusLimit, nil},
+	CodeTooLong:          {"string is too long", statusLimit, nil},
+	CodeTooLarge:         {"collection is too large", statusLimit, nil},
+	CodeCanceled:         {"validation was canceled", http.StatusServiceUnavailable, nil},
+	CodeDeadlineExceeded: {"validation timed out", http.StatusServiceUnavailable, nil},
+	CodeInvalidJSON:      {"document is not valid JSON", http.StatusBadRequest, nil},
+	CodeInvalidYAML:      {"document is not valid YAML", http.StatusBadRequest, nil},
+	CodeInvalidTOML:      {"document is not valid TOML", http.StatusBadRequest, nil},
+	CodeInvalidINI:       {"document is not valid INI", http.StatusBadRequest, nil},
+	CodeInvalidCSV:       {"document is not valid CSV", http.StatusBadRequest, nil},
+	CodeInvalidMultipart: {"request is not a valid multipart form", http.StatusBadRequest, nil},
+
+	CodePast:             {"date must be in the past", statusInvalid, nil},
+	CodeFuture:           {"date must be in the future", statusInvalid, nil},
+	CodeMinDate:          {"date is too early", statusInvalid, nil},
+	CodeMaxDate:          {"date is too late", statusInvalid, nil},
+	CodeWithinLast:       {"date is too far in the past", statusInvalid, nil},
+	CodeWithinNext:       {"date is too far in the future", statusInvalid, nil},
+	CodeMinSize:          {"data is too small", statusInvalid, minLengthParams},
+	CodeMaxSize:          {"data is too large", statusInvalid, maxLengthParams},
+	CodeInvalidCode:      {"code is unknown", statusInvalid, nil},
+	CodeCharacterClass:   {"password lacks a required character class", statusInvalid, nil},
+	CodeCharacterClasses: {"password uses too few character classes", statusInvalid, nil},
+	CodeRepeated:         {"password repeats characters", statusInvalid, nil},
+	CodeEntropy:          {"password is too weak", statusInvalid, nil},
+	CodeCommon:           {"password is too common", statusInvalid, nil},
+	CodeDeniedTerm:       {"password contains a denied term", statusInvalid, nil},
+	CodeDomain:           {"email domain is not allowed", statusInvalid, nil},
+	CodeDisplayName:      {"email display name is not allowed", statusInvalid, nil},
+	CodeMX:               {"email domain does not accept mail", statusInvalid, nil},
+	CodeScheme:           {"URL scheme is not allowed", statusInvalid, nil},
+	CodeHost:             {"URL host is not allowed", statusInvalid, nil},
+	CodeHostRequired:     {"URL has no host", statusInvalid, nil},
+	CodeUserInfo:         {"URL contains credentials", statusInvalid, nil},
+	CodeHostBits:         {"CIDR has host bits set", statusInvalid, nil},
+	CodeAbsolute:         {"path is absolute", statusInvalid, nil},
+	CodeTraversal:        {"path escapes its directory", statusInvalid, nil},
+	CodePortable:         {"filename is not portable", statusInvalid, nil},
+	CodeExtension:        {"file extension is not allowed", statusInvalid, nil},
+	CodeMimeType:         {"MIME type is not allowed", statusInvalid, nil},
+	CodeParameters:       {"MIME type parameters are not allowed", statusInvalid, nil},
+	CodeDimensions:       {"image dimensions are out of range", statusInvalid, nil},
+	CodeAlpha:            {"color transparency is not allowed", statusInvalid, nil},
+	CodeNotation:         {"color notation is not allowed", statusInvalid, nil},
+	CodeRegion:           {"phone number region is not allowed", statusInvalid, nil},
+	CodePhoneType:        {"phone number type is not allowed", statusInvalid, nil},
+	CodeVersion:          {"UUID version is not allowed", statusInvalid, nil},
+	CodeCase:             {"UUID case is not allowed", statusInvalid, nil},
+	CodePrerelease:       {"prerelease versions are not allowed", statusInvalid, nil},
+	CodeMinVersion:       {"version is too old", statusInvalid, nil},
+	CodeMaxVersion:       {"version is too new", statusInvalid, nil},
+	CodeVersionRange:     {"version is out of range", statusInvalid, nil},
+	CodeAlgorithm:        {"token algorithm is not allowed", http.StatusUnauthorized, nil},
+	CodeSignature:        {"token signature is invalid", http.StatusUnauthorized, nil},
+	CodeInvalidClaim:     {"token claims are invalid", http.StatusUnauthorized, nil},
+	CodeExpired:          {"token has expired", http.StatusUnauthorized, nil},
+	CodeExpiryRequired:   {"token has no expiry", http.StatusUnauthorized, nil},
+	CodeNotYetValid:      {"token is not valid yet", http.StatusUnauthorized, nil},
+	CodeIssuer:           {"token issuer is not allowed", http.StatusUnauthorized, nil},
+	CodeAudience:         {"token audience is not allowed", http.StatusUnauthorized, nil},
+	CodePatchPath:        {"patch path is not a location of the document", statusInvalid, nil},
+}
+
+// Info returns the catalog description of the code. Codes outside the catalog, such
+// as those of custom validators, get an empty message and status 422.
+func (c ErrorCode) Info() ErrorCodeInfo {
+	entry, ok := errorCatalog[c]
+	if !ok {
+		return ErrorCodeInfo{Code: c, Status: statusInvalid}
+	}
+	info := ErrorCodeInfo{Code: c, Message: entry.message, Status: entry.status}
+	for key := range entry.params {
+		info.Params = append(info.Params, key)
+	}
+	sort.Strings(info.Params)
+	return info
+}
+
+// Status returns the HTTP status a service should answer with for the code
+func (c ErrorCode) Status() int {
+	return c.Info().Status
+}
+
+// ErrorCatalog returns the descriptions of all codes of the catalog, sorted by code
+func ErrorCatalog() []ErrorCodeInfo {
+	catalog := make([]ErrorCodeInfo, 0, len(errorCatalog))
+	for code := range errorCatalog {
+		catalog = append(catalog, code.Info())
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Code < catalog[j].Code })
+	return catalog
+}
+
+// fillParams sets the Params of the errors schema reported about the input itself
+// from the keywords of its JSON Schema, computed only when an error needs them
+func fillParams(errors []ValidationError, schema Parseable) {
+	var keywords map[string]interface{}
+	prepared := false
+	for i := range errors {
+		if len(errors[i].Path) > 0 || errors[i].Params != nil {
+			continue
+		}
+		params := errorCatalog[errors[i].Code].params
+		if len(params) == 0 {
+			continue
+		}
+		if !prepared {
+			if generator, ok := schema.(interface{ JSON() map[string]interface{} }); ok {
+				keywords = generator.JSON()
+			}
+			prepared = true
+		}
+		for key, keyword := range params {
+			value, ok := keywords[keyword]
+			if !ok {
+				continue
+			}
+			if errors[i].Params == nil {
+				errors[i].Params = make(map[string]interface{}, len(params))
+			}
+			errors[i].Params[key] = value
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestErrorParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema Parseable
+		value  interface{}
+		code   ErrorCode
+		params map[string]interface{}
+	}{
+		{"min length", String().MinLength(3), "ab", CodeMinLength, map[string]interface{}{"min": 3}},
+		{"max length", String().MaxLength(2), "abc", CodeMaxLength, map[string]interface{}{"max": 2}},
+		{"pattern", String().Pattern(`^\d+$`), "x", CodePattern, map[string]interface{}{"pattern": `^\d+$`}},
+		{"minimum", Int().Min(10), 5, CodeMinimum, map[string]interface{}{"min": 10}},
+		{"expected type", Bool(), "yes", CodeInvalidType, map[string]interface{}{"expected": "boolean"}},
+		{"allowed values", String().Enum([]string{"a", "b"}), "c", CodeEnum, map[string]interface{}{"allowed": []interface{}{"a", "b"}}},
+		{"nested", Object().Property("tags", Array(String()).MaxItems(1)), map[string]interface{}{"tags": []interface{}{"a", "b"}}, CodeMaxItems, map[string]interface{}{"max": 1}},
+		{"no params", Int().Refine(func(v interface{}) bool { return false }, "no"), 1, CodeCustom, nil},
+		{"limit", Array(String()), []interface{}{"abcdefghijklmnopqrstuvwxyz"}, CodeTooLong, map[string]interface{}{"max": 16}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, DefaultValidationContext().WithMaxStringLength(16))
+			for _, err := range result.Errors {
+				if err.Code != tt.code {
+					continue
+				}
+				if !reflect.DeepEqual(err.Params, tt.params) {
+					t.Errorf("params = %#v, want %#v", err.Params, tt.params)
+				}
+				return
+			}
+			t.Errorf("no %s error in %v", tt.code, result.Errors)
+		})
+	}
+}
+
+func TestErrorCatalog(t *testing.T) {
+	catalog := ErrorCatalog()
+	if !sort.SliceIsSorted(catalog, func(i, j int) bool { return catalog[i].Code < catalog[j].Code }) {
+		t.Error("catalog is not sorted")
+	}
+	for _, info := range catalog {
+		if info.Message == "" || info.Status == 0 {
+			t.Errorf("incomplete entry %+v", info)
+		}
+	}
+
+	tests := []struct {
+		code   ErrorCode
+		status int
+		params []string
+	}{
+		{CodeMinLength, http.StatusUnprocessableEntity, []string{"min"}},
+		{CodeTupleLength, http.StatusUnprocessableEntity, []string{"max", "min"}},
+		{CodeInvalidJSON, http.StatusBadRequest, nil},
+		{CodeTooLarge, http.StatusRequestEntityTooLarge, nil},
+		{"app_specific", http.StatusUnprocessableEntity, nil},
+	}
+	for _, tt := range tests {
+		info := tt.code.Info()
+		if info.Code != tt.code || tt.code.Status() != tt.status || !reflect.DeepEqual(info.Params, tt.params) {
+			t.Errorf("%s: info = %+v", tt.code, info)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ErrorFormat selects how FormatErrors renders validation errors
+type ErrorFormat int
+
+const (
+	// ErrorFormatTree renders the errors grouped by path, as ValidationErrors.Error does
+	ErrorFormatTree ErrorFormat = iota
+	// ErrorFormatList renders one "path: message" line per error
+	ErrorFormatList
+	// ErrorFormatJSON renders the errors as a JSON array of ValidationError
+	ErrorFormatJSON
+)
+
+// Format renders the errors in the given format. Without errors, ErrorFormatJSON renders
+// an empty array and the other formats an empty string.
+func (e ValidationErrors) Format(format ErrorFormat) string {
+	switch format {
+	case ErrorFormatJSON:
+		if len(e) == 0 {
+			return "[]"
+		}
+		data, err := json.Marshal([]ValidationError(e))
+		if err != nil {
+			return "[]"
+		}
+		return string(data)
+	case ErrorFormatList:
+		var b strings.Builder
+		for i, err := range e {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			b.WriteString(err.Error())
+		}
+		return b.String()
+	default:
+		if len(e) == 0 {
+			return ""
+		}
+		return e.Error()
+	}
+}
+
+// Flatten groups the messages of the errors by the JSON Pointer of their path ("" for
+// the root value, "/items/0/name" for a nested value), in the order they were reported
+func (e ValidationErrors) Flatten() map[string][]string {
+	flat := make(map[string][]string)
+	for _, err := range e {
+		pointer := err.Path.JSONPointer()
+		flat[pointer] = append(flat[pointer], err.Message)
+	}
+	return flat
+}
+
+// FirstFor returns the first error reported at path, given as a JSON Pointer
+// ("/items/0/name") or a dotted path ("items[0].name"); "" is the root value
+func (e ValidationErrors) FirstFor(path string) (ValidationError, bool) {
+	for _, err := range e {
+		if err.Path.JSONPointer() == path || err.Path.DotPath() == path {
+			return err, true
+		}
+	}
+	return ValidationError{}, false
+}
+
+// FlattenErrors groups the messages of the errors by JSON Pointer (see ValidationErrors.Flatten)
+func (r ParseResult) FlattenErrors() map[string][]string {
+	return ValidationErrors(r.Errors).Flatten()
+}
+
+// FormatErrors renders the errors in the given format (see ValidationErrors.Format)
+func (r ParseResult) FormatErrors(format ErrorFormat) string {
+	return ValidationErrors(r.Errors).Format(format)
+}
+
+// FirstErrorFor returns the first error reported at path (see ValidationErrors.FirstFor)
+func (r ParseResult) FirstErrorFor(path string) (ValidationError, bool) {
+	return ValidationErrors(r.Errors).FirstFor(path)
+}
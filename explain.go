@@ -0,0 +1,386 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Outcome is the result of a constraint in an ExplainReport
+type Outcome string
+
+const (
+	OutcomePassed  Outcome = "passed"
+	OutcomeFailed  Outcome = "failed"
+	OutcomeSkipped Outcome = "skipped" // Not evaluated, because the value had the wrong type or was absent
+)
+
+// ExplainReport describes how a value was validated: the result of the parse and a
+// tree of the schemas that parsed it
+type ExplainReport struct {
+	Valid  bool
+	Value  interface{}
+	Errors []ValidationError
+	Root   *ExplainStep
+}
+
+// ExplainStep is the parse of a value by one schema
+type ExplainStep struct {
+	Schema      string            // Schema type without its "Schema" suffix, as in Hooks
+	Path        Path              // Position of the value
+	Valid       bool              // Whether the schema accepted the value
+	Constraints []ConstraintCheck // Constraints of the schema and their outcomes
+	Decision    string            // Branch taken by unions, conditionals and other composite schemas
+	Steps       []*ExplainStep    // Parses of nested values and of the branches of composite schemas
+
+	schema Parseable
+	errors []ValidationError // Errors of the parse reported at the position of the value
+}
+
+// ConstraintCheck is the outcome of one constraint of a schema
+type ConstraintCheck struct {
+	Keyword  string            // JSON Schema keyword, or the error code of constraints without one
+	Expected interface{}       // Value of the keyword in the schema's JSON Schema
+	Outcome  Outcome           // Whether the value satisfied the constraint
+	Errors   []ValidationError // Errors reported by the constraint when it failed
+}
+
+// Explain parses value with s and records every schema that took part, the outcome of
+// each of their constraints and the branches chosen by unions and conditionals, to
+// answer "why did this payload fail (or pass)?" while debugging:
+//
+//	report := schema.Explain(orderSchema, payload)
+//	fmt.Println(report)
+//
+// Constraints are read from the JSON Schema of each schema; a constraint failed when
+// the schema reported an error for it, and passed otherwise. Errors without a keyword,
+// such as those of Refine, are listed under their error code. Explain records every
+// parse in memory and is meant for debugging, not for production validation.
+func Explain(s Parseable, value interface{}) ExplainReport {
+	recorder := &explainRecorder{}
+	ctx := DefaultValidationContext()
+	ctx.explain = recorder
+
+	recorder.push(s, nil)
+	result := s.Parse(value, ctx)
+	root := recorder.pop(s, value, result)
+	if len(root.Steps) == 1 && sameSchema(root.Steps[0].schema, s) {
+		root = root.Steps[0]
+	}
+	return ExplainReport{Valid: result.Valid, Value: result.Value, Errors: result.Errors, Root: root}
+}
+
+// String renders the report as an indented tree of steps and constraints
+func (r ExplainReport) String() string {
+	var b strings.Builder
+	if r.Valid {
+		b.WriteString("valid\n")
+	} else {
+		b.WriteString("invalid\n")
+	}
+	if r.Root != nil {
+		r.Root.write(&b, 1)
+	}
+	return b.String()
+}
+
+// write renders the step and its nested steps at the indentation level
+func (s *ExplainStep) write(b *strings.Builder, level int) {
+	indent := strings.Repeat("  ", level)
+	path := s.Path.DotPath()
+	if path == "" {
+		path = "(root)"
+	}
+	verdict := "valid"
+	if !s.Valid {
+		verdict = "invalid"
+	}
+	fmt.Fprintf(b, "%s%s %s: %s", indent, s.Schema, path, verdict)
+	if s.Decision != "" {
+		fmt.Fprintf(b, " (%s)", s.Decision)
+	}
+	b.WriteByte('\n')
+	for _, check := range s.Constraints {
+		fmt.Fprintf(b, "%s  - %s", indent, check.Keyword)
+		if check.Expected != nil {
+			fmt.Fprintf(b, " %v", check.Expected)
+		}
+		fmt.Fprintf(b, ": %s", check.Outcome)
+		for i, err := range check.Errors {
+			if i == 0 {
+				b.WriteString(" (")
+			} else {
+				b.WriteString("; ")
+			}
+			b.WriteString(err.Message)
+			if i == len(check.Errors)-1 {
+				b.WriteByte(')')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	for _, step := range s.Steps {
+		step.write(b, level+1)
+	}
+}
+
+// explainRecorder builds the steps of an ExplainReport from the parses started and
+// ended by the schemas
+type explainRecorder struct {
+	stack []*ExplainStep
+}
+
+// push records the start of a parse of s at path
+func (r *explainRecorder) push(s Parseable, path Path) {
+	step := &ExplainStep{Schema: schemaTypeName(s), Path: path, schema: s}
+	if len(r.stack) > 0 {
+		parent := r.stack[len(r.stack)-1]
+		parent.Steps = append(parent.Steps, step)
+	}
+	r.stack = append(r.stack, step)
+}
+
+// pop records the end of the parse started by the last push and returns its step
+func (r *explainRecorder) pop(s Parseable, value interface{}, result ParseResult) *ExplainStep {
+	step := r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+	step.Valid = result.Valid
+	step.collectErrors(result.Errors)
+	step.checkConstraints(value, result)
+	step.Decision = decide(s, step)
+	return step
+}
+
+// collectErrors keeps the errors the schema reported itself: those at the position of
+// the value, and those one level below that no nested step parsed (such as missing and
+// unknown object properties), unless a branch parsing the same value reported them
+func (s *ExplainStep) collectErrors(errors []ValidationError) {
+	for _, err := range errors {
+		if len(err.Path) > 1 || len(err.Path) == 1 && s.hasStepAt(err.Path[0]) || s.branchReported(err) {
+			continue
+		}
+		s.errors = append(s.errors, err)
+	}
+}
+
+// branchReported returns whether a step parsing the same value reported err
+func (s *ExplainStep) branchReported(err ValidationError) bool {
+	for _, step := range s.Steps {
+		if len(step.Path) != len(s.Path) {
+			continue
+		}
+		for _, branchErr := range step.errors {
+			if branchErr.Code == err.Code && branchErr.Message == err.Message && branchErr.Path.String() == err.Path.String() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasStepAt returns whether a nested step parsed the element of the value at segment
+func (s *ExplainStep) hasStepAt(segment PathSegment) bool {
+	for _, step := range s.Steps {
+		if len(step.Path) == len(s.Path)+1 && step.Path[len(s.Path)] == segment {
+			return true
+		}
+	}
+	return false
+}
+
+// explainKeywords are the JSON Schema keywords reported as constraints, in the order
+// they are listed
+var explainKeywords = []string{
+	"type", "const", "enum", "format", "pattern", "minLength", "maxLength",
+	"minimum", "exclusiveMinimum", "maximum", "exclusiveMaximum", "multipleOf",
+	"minItems", "maxItems", "uniqueItems", "required", "minProperties", "maxProperties",
+	"additionalProperties",
+}
+
+// codeKeywords maps the codes of the catalog to the keywords whose constraints report them
+var codeKeywords = func() map[ErrorCode]string {
+	keywords := map[ErrorCode]string{
+		CodeInvalidType:        "type",
+		CodeUniqueItems:        "uniqueItems",
+		CodeAdditionalProperty: "additionalProperties",
+	}
+	for code, entry := range errorCatalog {
+		if len(entry.params) != 1 {
+			continue
+		}
+		for _, keyword := range entry.params {
+			keywords[code] = keyword
+		}
+	}
+	return keywords
+}()
+
+// checkConstraints lists the constraints of the step's schema with their outcomes
+func (s *ExplainStep) checkConstraints(value interface{}, result ParseResult) {
+	var document map[string]interface{}
+	if generator, ok := s.schema.(interface{ JSON() map[string]interface{} }); ok {
+		document = generator.JSON()
+	}
+
+	// A missing value fails its presence, reported first, and skips the other constraints
+	if value == nil {
+		required, _ := s.schema.(interface{ IsRequired() bool })
+		check := ConstraintCheck{Keyword: "required", Expected: required != nil && required.IsRequired(), Outcome: OutcomePassed}
+		if !result.Valid {
+			check.Outcome = OutcomeFailed
+		}
+		s.Constraints = append(s.Constraints, check)
+	}
+
+	failures := make(map[string][]ValidationError)
+	var order []string
+	skipped := value == nil // Constraints are not evaluated for absent values and values of the wrong type
+	for _, err := range s.errors {
+		if err.Code == CodeRequired && len(err.Path) == 0 && value == nil {
+			s.Constraints[0].Errors = append(s.Constraints[0].Errors, err)
+			continue
+		}
+		keyword, ok := codeKeywords[err.Code]
+		if !ok {
+			keyword = string(err.Code)
+		}
+		if err.Code == CodeInvalidType {
+			skipped = true
+		}
+		if _, seen := failures[keyword]; !seen {
+			order = append(order, keyword)
+		}
+		failures[keyword] = append(failures[keyword], err)
+	}
+
+	declared := make(map[string]bool)
+	for _, keyword := range explainKeywords {
+		expected, ok := document[keyword]
+		// uniqueItems and additionalProperties only constrain values when they forbid something
+		if !ok || keyword == "uniqueItems" && expected != true || keyword == "additionalProperties" && expected != false {
+			continue
+		}
+		declared[keyword] = true
+		check := ConstraintCheck{Keyword: keyword, Expected: expected, Outcome: OutcomePassed}
+		switch {
+		case failures[keyword] != nil:
+			check.Outcome = OutcomeFailed
+			check.Errors = failures[keyword]
+		case skipped && (keyword != "type" || value == nil):
+			check.Outcome = OutcomeSkipped
+		}
+		s.Constraints = append(s.Constraints, check)
+	}
+	for _, keyword := range order {
+		if !declared[keyword] {
+			s.Constraints = append(s.Constraints, ConstraintCheck{Keyword: keyword, Outcome: OutcomeFailed, Errors: failures[keyword]})
+		}
+	}
+}
+
+// decide describes the branch taken by a composite schema
+func decide(s Parseable, step *ExplainStep) string {
+	switch schema := s.(type) {
+	case *UnionSchema:
+		return branchDecision(schema.schemas, step)
+	case *AnyOfSchema:
+		return branchDecision(schema.schemas, step)
+	case *AllOfSchema:
+		return branchDecision(schema.schemas, step)
+	case *NotSchema:
+		inner := step.branch(schema.schema)
+		switch {
+		case inner == nil:
+			return ""
+		case inner.Valid:
+			return "inner schema matched, so the value is rejected"
+		default:
+			return "inner schema did not match, so the value is accepted"
+		}
+	case *ConditionalSchema:
+		return conditionalDecision(schema, step)
+	}
+	return ""
+}
+
+// conditionalDecision describes the case applied by a conditional schema
+func conditionalDecision(s *ConditionalSchema, step *ExplainStep) string {
+	cases := s.allCases()
+	for i, c := range cases {
+		condition := step.branch(c.condition)
+		if condition == nil {
+			return ""
+		}
+		if !condition.Valid {
+			continue
+		}
+		switch {
+		case len(cases) == 1 && c.schema == nil:
+			return "if matched, no then schema"
+		case len(cases) == 1:
+			return "if matched, applied then"
+		case c.schema == nil:
+			return fmt.Sprintf("case %d matched, no schema", i)
+		default:
+			return fmt.Sprintf("case %d matched, applied its schema", i)
+		}
+	}
+
+	prefix := "if did not match"
+	if len(cases) != 1 {
+		prefix = "no case matched"
+	}
+	if s.elseSchema == nil {
+		return prefix + ", no else schema"
+	}
+	return prefix + ", applied else"
+}
+
+// branchDecision describes which of the schemas applying to the same value matched
+func branchDecision(schemas []Parseable, step *ExplainStep) string {
+	var matched, failed, untried []string
+	for i, schema := range schemas {
+		name := fmt.Sprintf("%d (%s)", i, schemaTypeName(schema))
+		switch branch := step.branch(schema); {
+		case branch == nil:
+			untried = append(untried, name)
+		case branch.Valid:
+			matched = append(matched, name)
+		default:
+			failed = append(failed, name)
+		}
+	}
+
+	var parts []string
+	if len(matched) == 0 {
+		parts = append(parts, "no branch matched")
+	} else {
+		parts = append(parts, "branch "+strings.Join(matched, ", ")+" matched")
+	}
+	if len(failed) > 0 {
+		parts = append(parts, "failed: "+strings.Join(failed, ", "))
+	}
+	if len(untried) > 0 {
+		parts = append(parts, "not tried: "+strings.Join(untried, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// branch returns the step of the schema parsing the same value as this step
+func (s *ExplainStep) branch(schema Parseable) *ExplainStep {
+	for _, step := range s.Steps {
+		if len(step.Path) == len(s.Path) && sameSchema(step.schema, schema) {
+			return step
+		}
+	}
+	return nil
+}
+
+// sameSchema returns whether a and b are the same schema
+func sameSchema(a, b Parseable) bool {
+	t := reflect.TypeOf(a)
+	if t == nil || t != reflect.TypeOf(b) || !t.Comparable() {
+		return false
+	}
+	return a == b
+}
@@ -0,0 +1,242 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+// findStep returns the first step of the tree parsing the value at path with the schema type
+func findStep(step *ExplainStep, schemaType, path string) *ExplainStep {
+	if step.Schema == schemaType && step.Path.DotPath() == path {
+		return step
+	}
+	for _, nested := range step.Steps {
+		if found := findStep(nested, schemaType, path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// outcomes returns the outcome of each constraint of the step by keyword
+func outcomes(step *ExplainStep) map[string]Outcome {
+	result := make(map[string]Outcome)
+	for _, check := range step.Constraints {
+		result[check.Keyword] = check.Outcome
+	}
+	return result
+}
+
+func TestExplainConstraints(t *testing.T) {
+	user := Object().
+		Property("name", String().MinLength(3).MaxLength(20)).
+		Property("age", Int().Min(18).Optional())
+
+	tests := []struct {
+		name       string
+		value      interface{}
+		valid      bool
+		schemaType string
+		path       string
+		want       map[string]Outcome
+	}{
+		{
+			name:       "passed constraints",
+			value:      map[string]interface{}{"name": "Ada", "age": 36},
+			valid:      true,
+			schemaType: "String",
+			path:       "name",
+			want:       map[string]Outcome{"type": OutcomePassed, "minLength": OutcomePassed, "maxLength": OutcomePassed},
+		},
+		{
+			name:       "failed constraint",
+			value:      map[string]interface{}{"name": "Al"},
+			valid:      false,
+			schemaType: "String",
+			path:       "name",
+			want:       map[string]Outcome{"type": OutcomePassed, "minLength": OutcomeFailed, "maxLength": OutcomePassed},
+		},
+		{
+			name:       "wrong type skips the other constraints",
+			value:      map[string]interface{}{"name": "Ada", "age": "old"},
+			valid:      false,
+			schemaType: "Int",
+			path:       "age",
+			want:       map[string]Outcome{"type": OutcomeFailed, "minimum": OutcomeSkipped},
+		},
+		{
+			name:       "missing property",
+			value:      map[string]interface{}{},
+			valid:      false,
+			schemaType: "Object",
+			path:       "",
+			want:       map[string]Outcome{"type": OutcomePassed, "required": OutcomeFailed, "additionalProperties": OutcomePassed},
+		},
+		{
+			name:       "unknown property",
+			value:      map[string]interface{}{"name": "Ada", "admin": true},
+			valid:      false,
+			schemaType: "Object",
+			path:       "",
+			want:       map[string]Outcome{"type": OutcomePassed, "required": OutcomePassed, "additionalProperties": OutcomeFailed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Explain(user, tt.value)
+			if report.Valid != tt.valid {
+				t.Fatalf("Valid = %v, want %v", report.Valid, tt.valid)
+			}
+			step := findStep(report.Root, tt.schemaType, tt.path)
+			if step == nil {
+				t.Fatalf("no %s step at %q in\n%s", tt.schemaType, tt.path, report)
+			}
+			got := outcomes(step)
+			for keyword, want := range tt.want {
+				if got[keyword] != want {
+					t.Errorf("%s = %q, want %q in\n%s", keyword, got[keyword], want, report)
+				}
+			}
+		})
+	}
+}
+
+func TestExplainFailedConstraintErrors(t *testing.T) {
+	report := Explain(String().MinLength(5).Refine(func(v interface{}) bool { return false }, "nope"), "abc")
+	if report.Root == nil || report.Root.Schema != "String" {
+		t.Fatalf("Root = %+v, want the String step", report.Root)
+	}
+	var minLength *ConstraintCheck
+	for i, check := range report.Root.Constraints {
+		if check.Keyword == "minLength" {
+			minLength = &report.Root.Constraints[i]
+		}
+	}
+	if minLength == nil || minLength.Expected != 5 || len(minLength.Errors) != 1 || minLength.Errors[0].Code != CodeMinLength {
+		t.Errorf("minLength check = %+v, want a failure with the min_length error", minLength)
+	}
+
+	// A refinement runs only on values that passed the constraints
+	report = Explain(String().MinLength(1).Refine(func(v interface{}) bool { return false }, "nope"), "abc")
+	if got := outcomes(report.Root)[string(CodeCustom)]; got != OutcomeFailed {
+		t.Errorf("refinement outcome = %q, want failed in\n%s", got, report)
+	}
+}
+
+func TestExplainDecisions(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     Parseable
+		value      interface{}
+		schemaType string
+		decision   string
+	}{
+		{
+			name:       "union branch",
+			schema:     Union(String().Email(), Int().Min(1)),
+			value:      42,
+			schemaType: "Union",
+			decision:   "branch 1 (Int) matched; not tried: 0 (String)",
+		},
+		{
+			name:       "union without match",
+			schema:     Union(String().Email(), String().UUID()),
+			value:      "nope",
+			schemaType: "Union",
+			decision:   "no branch matched; failed: 0 (String), 1 (String)",
+		},
+		{
+			name:       "anyOf branches",
+			schema:     AnyOf(String().MinLength(1), String().MaxLength(3)),
+			value:      "abcd",
+			schemaType: "AnyOf",
+			decision:   "branch 0 (String) matched; failed: 1 (String)",
+		},
+		{
+			name: "conditional then",
+			schema: Conditional(Object().Passthrough().Property("country", Enum("US"))).
+				Then(Object().Passthrough().Property("zip", String().Required())),
+			value:      map[string]interface{}{"country": "US"},
+			schemaType: "Conditional",
+			decision:   "if matched, applied then",
+		},
+		{
+			name: "conditional else",
+			schema: Conditional(Object().Passthrough().Property("country", Enum("US"))).
+				Then(Object().Passthrough().Property("zip", String().Required())).
+				Else(Object().Passthrough()),
+			value:      map[string]interface{}{"country": "FR"},
+			schemaType: "Conditional",
+			decision:   "if did not match, applied else",
+		},
+		{
+			name: "conditional case",
+			schema: Conditional().
+				Case(FieldEquals("method", "card"), Object().Passthrough()).
+				Case(FieldEquals("method", "iban"), Object().Passthrough()),
+			value:      map[string]interface{}{"method": "iban"},
+			schemaType: "Conditional",
+			decision:   "case 1 matched, applied its schema",
+		},
+		{
+			name:       "not",
+			schema:     Not(String().MinLength(1)),
+			value:      "abc",
+			schemaType: "Not",
+			decision:   "inner schema matched, so the value is rejected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Explain(tt.schema, tt.value)
+			step := findStep(report.Root, tt.schemaType, "")
+			if step == nil {
+				t.Fatalf("no %s step in\n%s", tt.schemaType, report)
+			}
+			if step.Decision != tt.decision {
+				t.Errorf("Decision = %q, want %q", step.Decision, tt.decision)
+			}
+		})
+	}
+}
+
+func TestExplainConditionalErrors(t *testing.T) {
+	address := Conditional(Object().Passthrough().Property("country", Enum("US"))).
+		Then(Object().Passthrough().Property("zip", String().Required()))
+
+	report := Explain(address, map[string]interface{}{"country": "US"})
+	if report.Valid {
+		t.Fatal("the then schema should reject an address without zip")
+	}
+	// The missing zip is explained by the then branch, not by the conditional
+	got := outcomes(report.Root)
+	if got[string(CodeThenFailed)] != OutcomeFailed || got["required"] != "" {
+		t.Errorf("conditional constraints = %v, want then_failed only", got)
+	}
+	if len(report.Root.Steps) != 2 {
+		t.Fatalf("conditional steps = %d, want the if and then branches", len(report.Root.Steps))
+	}
+	if then := report.Root.Steps[1]; outcomes(then)["required"] != OutcomeFailed {
+		t.Errorf("then constraints = %v, want required failed", outcomes(then))
+	}
+}
+
+func TestExplainString(t *testing.T) {
+	report := Explain(Object().Property("name", String().MinLength(3)), map[string]interface{}{"name": "Al"})
+	want := strings.Join([]string{
+		"invalid",
+		"  Object (root): invalid",
+		"    - type object: passed",
+		"    - required [name]: passed",
+		"    - additionalProperties false: passed",
+		"    String name: invalid",
+		"      - type string: passed",
+		"      - minLength 3: failed (value must be at least 3 characters long)",
+		"",
+	}, "\n")
+	if got := report.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,416 @@
+package schema
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif" // Register the image formats checked by MinDimensions and MaxDimensions
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for file validation
+var (
+	fileRequiredError = i18n.S("file is required")
+	fileTypeError     = i18n.S("value must be an uploaded file")
+	fileReadError     = i18n.S("file could not be read")
+	fileImageError    = i18n.S("file must be a GIF, JPEG or PNG image")
+)
+
+// Default error message functions that take parameters
+func fileTooSmallError(size, min int64) i18n.TranslatedFunc {
+	return i18n.F("file size %d bytes is less than minimum %d bytes", size, min)
+}
+
+func fileTooLargeError(size, max int64) i18n.TranslatedFunc {
+	return i18n.F("file size %d bytes exceeds maximum %d bytes", size, max)
+}
+
+func fileTypeAllowedError(detected string, types []string) i18n.TranslatedFunc {
+	return i18n.F("file type %s is not allowed, expected one of: %s", detected, strings.Join(types, ", "))
+}
+
+func fileNamePatternError(pattern string) i18n.TranslatedFunc {
+	return i18n.F("file name must match pattern %s", pattern)
+}
+
+func fileMinDimensionsError(width, height int) i18n.TranslatedFunc {
+	return i18n.F("image must be at least %dx%d pixels", width, height)
+}
+
+func fileMaxDimensionsError(width, height int) i18n.TranslatedFunc {
+	return i18n.F("image must be at most %dx%d pixels", width, height)
+}
+
+// sniffLength is the number of bytes http.DetectContentType considers
+const sniffLength = 512
+
+// FileSchema validates uploaded files, given as *multipart.FileHeader values such as
+// those of ParseMultipart. The type of a file is sniffed from its content with
+// http.DetectContentType, so a renamed executable is not accepted as an image because
+// of its declared Content-Type or extension. The parsed value is the file header.
+type FileSchema struct {
+	Schema
+	minSize         *int64
+	maxSize         *int64
+	allowedTypes    []string // Allowed sniffed types, lowercase; "image/*" matches any image type
+	filenamePattern *regexp.Regexp
+	minWidth        int
+	minHeight       int
+	maxWidth        int // 0 when there is no maximum
+	maxHeight       int
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	sizeError         ErrorMessage
+	allowedTypeError  ErrorMessage
+	filenameError     ErrorMessage
+	dimensionsError   ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// File creates a new file schema accepting any uploaded file
+func File(errorMessage ...interface{}) *FileSchema {
+	schema := &FileSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.typeMismatchError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *FileSchema) Clone() *FileSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.allowedTypes = slices.Clone(s.allowedTypes)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *FileSchema) Freeze() *FileSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *FileSchema) Title(title string) *FileSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *FileSchema) Description(description string) *FileSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *FileSchema) Deprecated(reason string) *FileSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *FileSchema) Meta(key string, value interface{}) *FileSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_size"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *FileSchema) AsWarning(codes ...ErrorCode) *FileSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *FileSchema) AsInfo(codes ...ErrorCode) *FileSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// MinSize sets the minimum file size in bytes
+func (s *FileSchema) MinSize(bytes int64) *FileSchema {
+	s.checkMutable()
+	s.minSize = &bytes
+	return s
+}
+
+// MaxSize sets the maximum file size in bytes
+func (s *FileSchema) MaxSize(bytes int64) *FileSchema {
+	s.checkMutable()
+	s.maxSize = &bytes
+	return s
+}
+
+// SizeError sets a custom error message for files outside MinSize and MaxSize
+func (s *FileSchema) SizeError(message interface{}) *FileSchema {
+	s.checkMutable()
+	s.sizeError = toErrorMessage(message)
+	return s
+}
+
+// AllowedTypes restricts the type sniffed from the content of the file (compared
+// case-insensitively, parameters ignored). "image/*" matches any image type and
+// "*/*" matches everything.
+func (s *FileSchema) AllowedTypes(types ...string) *FileSchema {
+	s.checkMutable()
+	s.allowedTypes = lowerAll(types)
+	return s
+}
+
+// AllowedTypeError sets a custom error message for types not in AllowedTypes
+func (s *FileSchema) AllowedTypeError(message interface{}) *FileSchema {
+	s.checkMutable()
+	s.allowedTypeError = toErrorMessage(message)
+	return s
+}
+
+// FilenamePattern requires the name of the file, as sent by the client, to match
+// a regular expression. It panics if the pattern does not compile.
+func (s *FileSchema) FilenamePattern(pattern string, errorMessage ...interface{}) *FileSchema {
+	s.checkMutable()
+	s.filenamePattern = regexp.MustCompile(pattern)
+	if len(errorMessage) > 0 {
+		s.filenameError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// MinDimensions requires the file to be a GIF, JPEG or PNG image of at least
+// width x height pixels
+func (s *FileSchema) MinDimensions(width, height int) *FileSchema {
+	s.checkMutable()
+	s.minWidth, s.minHeight = width, height
+	return s
+}
+
+// MaxDimensions requires the file to be a GIF, JPEG or PNG image of at most
+// width x height pixels
+func (s *FileSchema) MaxDimensions(width, height int) *FileSchema {
+	s.checkMutable()
+	s.maxWidth, s.maxHeight = width, height
+	return s
+}
+
+// DimensionsError sets a custom error message for images outside MinDimensions and
+// MaxDimensions
+func (s *FileSchema) DimensionsError(message interface{}) *FileSchema {
+	s.checkMutable()
+	s.dimensionsError = toErrorMessage(message)
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *FileSchema) Optional() *FileSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *FileSchema) Required(errorMessage ...interface{}) *FileSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// TypeError sets a custom error message for values that are not uploaded files
+func (s *FileSchema) TypeError(message string) *FileSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *FileSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *FileSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// GetMinSize returns the minimum file size in bytes
+func (s *FileSchema) GetMinSize() *int64 {
+	return s.minSize
+}
+
+// GetMaxSize returns the maximum file size in bytes
+func (s *FileSchema) GetMaxSize() *int64 {
+	return s.maxSize
+}
+
+// GetAllowedTypes returns the allowed media types
+func (s *FileSchema) GetAllowedTypes() []string {
+	return s.allowedTypes
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *FileSchema) Transform(fn TransformFunc) *FileSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *FileSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *FileSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *FileSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *FileSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates an uploaded file and returns its header
+func (s *FileSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the file; Parse runs the refine/transform pipeline on top
+func (s *FileSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	if value == nil {
+		if s.Schema.required {
+			message := fileRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	header, ok := value.(*multipart.FileHeader)
+	if !ok || header == nil {
+		message := fileTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	var errors []ValidationError
+	fail := func(message string, custom ErrorMessage, code ErrorCode, params map[string]interface{}) {
+		if !isEmptyErrorMessage(custom) {
+			message = resolveErrorMessage(custom, ctx)
+		}
+		err := NewPrimitiveError(header.Filename, message, code)
+		err.Params = params
+		errors = append(errors, err)
+	}
+
+	if s.minSize != nil && header.Size < *s.minSize {
+		fail(fileTooSmallError(header.Size, *s.minSize)(ctx.Locale), s.sizeError, CodeMinSize, map[string]interface{}{"min": *s.minSize})
+	}
+	if s.maxSize != nil && header.Size > *s.maxSize {
+		fail(fileTooLargeError(header.Size, *s.maxSize)(ctx.Locale), s.sizeError, CodeMaxSize, map[string]interface{}{"max": *s.maxSize})
+	}
+	if s.filenamePattern != nil && !s.filenamePattern.MatchString(header.Filename) {
+		pattern := s.filenamePattern.String()
+		fail(fileNamePatternError(pattern)(ctx.Locale), s.filenameError, CodePattern, map[string]interface{}{"pattern": pattern})
+	}
+
+	if len(s.allowedTypes) > 0 || s.checksDimensions() {
+		if err := s.checkContent(header, ctx, fail); err != nil {
+			fail(fileReadError(ctx.Locale), nil, CodeFormat, nil)
+		}
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errors}
+	}
+	return ParseResult{Valid: true, Value: header, Errors: nil}
+}
+
+// checksDimensions reports whether the schema constrains image dimensions
+func (s *FileSchema) checksDimensions() bool {
+	return s.minWidth > 0 || s.minHeight > 0 || s.maxWidth > 0 || s.maxHeight > 0
+}
+
+// checkContent sniffs the type of a file and decodes its image header, reporting the
+// failed constraints
+func (s *FileSchema) checkContent(header *multipart.FileHeader, ctx *ValidationContext, fail func(string, ErrorMessage, ErrorCode, map[string]interface{})) error {
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	head = head[:n]
+
+	if len(s.allowedTypes) > 0 {
+		detected, _, _ := strings.Cut(strings.ToLower(http.DetectContentType(head)), ";")
+		if !matchMIMEType(s.allowedTypes, detected) {
+			fail(fileTypeAllowedError(detected, s.allowedTypes)(ctx.Locale), s.allowedTypeError, CodeMimeType, map[string]interface{}{"allowed": s.allowedTypes, "detected": detected})
+		}
+	}
+	if !s.checksDimensions() {
+		return nil
+	}
+
+	// The image header can follow large metadata segments, so it is decoded from the
+	// whole file rather than from the sniffed bytes
+	config, _, err := image.DecodeConfig(io.MultiReader(bytes.NewReader(head), file))
+	if err != nil {
+		fail(fileImageError(ctx.Locale), s.dimensionsError, CodeFormat, map[string]interface{}{"format": "image"})
+		return nil
+	}
+	params := map[string]interface{}{"width": config.Width, "height": config.Height}
+	if config.Width < s.minWidth || config.Height < s.minHeight {
+		fail(fileMinDimensionsError(s.minWidth, s.minHeight)(ctx.Locale), s.dimensionsError, CodeDimensions, params)
+	}
+	if s.maxWidth > 0 && config.Width > s.maxWidth || s.maxHeight > 0 && config.Height > s.maxHeight {
+		fail(fileMaxDimensionsError(s.maxWidth, s.maxHeight)(ctx.Locale), s.dimensionsError, CodeDimensions, params)
+	}
+	return nil
+}
+
+// JSON generates JSON Schema representation, the OpenAPI form of a file upload. The
+// content constraints are only enforced by Parse.
+func (s *FileSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	schema["format"] = "binary"
+	if len(s.allowedTypes) == 1 && !strings.Contains(s.allowedTypes[0], "*") {
+		schema["contentMediaType"] = s.allowedTypes[0]
+	}
+	return schema
+}
@@ -0,0 +1,160 @@
+package schema
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// pngImage encodes a blank PNG image of the given size
+func pngImage(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	if err := png.Encode(&b, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// uploadPart is a field or file part of a multipart request
+type uploadPart struct {
+	name     string
+	filename string // Empty for fields
+	content  []byte
+}
+
+// multipartRequest builds a multipart/form-data request with the given parts
+func multipartRequest(t *testing.T, parts ...uploadPart) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range parts {
+		if part.filename == "" {
+			writer.WriteField(part.name, string(part.content))
+			continue
+		}
+		w, err := writer.CreateFormFile(part.name, part.filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(part.content)
+	}
+	writer.Close()
+	r := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+// uploadedFile returns the header of a file uploaded in a multipart request
+func uploadedFile(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+	r := multipartRequest(t, uploadPart{"file", filename, content})
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatal(err)
+	}
+	return r.MultipartForm.File["file"][0]
+}
+
+func TestFileSchema(t *testing.T) {
+	avatar := uploadedFile(t, "avatar.png", pngImage(t, 64, 48))
+	text := uploadedFile(t, "notes.txt", []byte("hello"))
+
+	tests := []struct {
+		name   string
+		schema *FileSchema
+		value  interface{}
+		codes  []ErrorCode
+	}{
+		{"any file", File(), text, nil},
+		{"missing", File(), nil, []ErrorCode{CodeRequired}},
+		{"optional", File().Optional(), nil, nil},
+		{"not a file", File(), "avatar.png", []ErrorCode{CodeInvalidType}},
+		{"size within bounds", File().MinSize(5).MaxSize(5), text, nil},
+		{"too large", File().MaxSize(4), text, []ErrorCode{CodeMaxSize}},
+		{"too small", File().MinSize(6), text, []ErrorCode{CodeMinSize}},
+		{"sniffed type allowed", File().AllowedTypes("image/*"), avatar, nil},
+		{"sniffed type rejected", File().AllowedTypes("image/png"), text, []ErrorCode{CodeMimeType}},
+		{"filename pattern", File().FilenamePattern(`\.png$`), text, []ErrorCode{CodePattern}},
+		{"dimensions within bounds", File().MinDimensions(64, 48).MaxDimensions(64, 48), avatar, nil},
+		{"image too small", File().MinDimensions(100, 10), avatar, []ErrorCode{CodeDimensions}},
+		{"image too large", File().MaxDimensions(32, 0), avatar, []ErrorCode{CodeDimensions}},
+		{"not an image", File().MaxDimensions(100, 100), text, []ErrorCode{CodeFormat}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, DefaultValidationContext())
+			var codes []ErrorCode
+			for _, err := range result.Errors {
+				codes = append(codes, err.Code)
+			}
+			if result.Valid != (len(tt.codes) == 0) || len(codes) != len(tt.codes) {
+				t.Fatalf("Parse() = %v, want codes %v", result.Errors, tt.codes)
+			}
+			for i := range codes {
+				if codes[i] != tt.codes[i] {
+					t.Errorf("codes = %v, want %v", codes, tt.codes)
+				}
+			}
+			if result.Valid && tt.value != nil && result.Value != tt.value {
+				t.Errorf("Parse() value = %v, want the file header", result.Value)
+			}
+		})
+	}
+}
+
+func TestParseMultipart(t *testing.T) {
+	upload := Object().
+		Property("title", String().MaxLength(10)).
+		Property("count", Int().Min(1)).
+		Property("avatar", File().AllowedTypes("image/png")).
+		Property("attachments", Array(File().MaxSize(5)).MaxItems(2).Optional())
+
+	valid := multipartRequest(t,
+		uploadPart{"title", "", []byte("Holiday")},
+		uploadPart{"count", "", []byte("2")},
+		uploadPart{"avatar", "me.png", pngImage(t, 2, 2)},
+		uploadPart{"attachments[]", "a.txt", []byte("a")},
+	)
+	result := ParseMultipart(upload, valid)
+	if !result.Valid {
+		t.Fatalf("ParseMultipart() errors = %v", result.Errors)
+	}
+	value := result.Value.(map[string]interface{})
+	if value["count"] != 2 || value["avatar"].(*multipart.FileHeader).Filename != "me.png" {
+		t.Errorf("ParseMultipart() value = %v", value)
+	}
+	if attachments := value["attachments"].([]interface{}); len(attachments) != 1 {
+		t.Errorf("attachments = %v", attachments)
+	}
+
+	invalid := multipartRequest(t,
+		uploadPart{"title", "", []byte("A very long title")},
+		uploadPart{"count", "", []byte("1")},
+		uploadPart{"avatar", "me.png", []byte("not an image")},
+		uploadPart{"attachments", "a.txt", []byte("too large")},
+	)
+	result = ParseMultipart(upload, invalid)
+	paths := map[string]ErrorCode{}
+	for _, err := range result.Errors {
+		if err.Code != CodePropertyInvalid && err.Code != CodeItemInvalid {
+			paths[err.Path.DotPath()] = err.Code
+		}
+	}
+	want := map[string]ErrorCode{"title": CodeMaxLength, "avatar": CodeMimeType, "attachments[0]": CodeMaxSize}
+	for path, code := range want {
+		if paths[path] != code {
+			t.Errorf("errors = %v, want %s at %s", paths, code, path)
+		}
+	}
+
+	notMultipart := httptest.NewRequest(http.MethodPost, "/upload", bytes.NewBufferString("title=x"))
+	notMultipart.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if result := ParseMultipart(upload, notMultipart); result.Valid || result.Errors[0].Code != CodeInvalidMultipart {
+		t.Errorf("ParseMultipart() = %v, want invalid_multipart", result.Errors)
+	}
+}
@@ -0,0 +1,761 @@
+package schema
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for file name and path validation
+var (
+	filenameRequiredError  = i18n.S("value is required")
+	filenameTypeError      = i18n.S("value must be a string")
+	filenameFormatError    = i18n.S("value must be a valid file name")
+	filenamePortableError  = i18n.S("file name is not portable across operating systems")
+	filePathFormatError    = i18n.S("value must be a valid file path")
+	filePathTraversalError = i18n.S("file path must not contain '..' segments")
+	filePathAbsoluteError  = i18n.S("file path must be relative")
+)
+
+// Default error message functions that take parameters
+func fileExtensionError(extensions []string) i18n.TranslatedFunc {
+	dotted := make([]string, len(extensions))
+	for i, extension := range extensions {
+		dotted[i] = "." + extension
+	}
+	return i18n.F("file extension must be one of: %s", strings.Join(dotted, ", "))
+}
+
+// maxFilenameLength is the limit of common file systems (ext4, NTFS, APFS), in bytes
+const maxFilenameLength = 255
+
+// FilenameSchema validates a single file name, such as the name of an uploaded file, and
+// returns it unchanged. Names containing a path separator ("/" or "\"), NUL or control
+// characters, names longer than 255 bytes, and "." and ".." are rejected.
+type FilenameSchema struct {
+	Schema
+	extensions []string // Allowed extensions, lowercase without the leading dot
+	portable   bool
+	nullable   bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	extensionError    ErrorMessage
+	portableError     ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// Filename creates a new file name schema
+func Filename(errorMessage ...interface{}) *FilenameSchema {
+	schema := &FilenameSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *FilenameSchema) Clone() *FilenameSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.extensions = slices.Clone(s.extensions)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *FilenameSchema) Freeze() *FilenameSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *FilenameSchema) Title(title string) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *FilenameSchema) Description(description string) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *FilenameSchema) ReadOnly() *FilenameSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *FilenameSchema) WriteOnly() *FilenameSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *FilenameSchema) Sensitive() *FilenameSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *FilenameSchema) Deprecated(reason string) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *FilenameSchema) Meta(key string, value interface{}) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *FilenameSchema) AsWarning(codes ...ErrorCode) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *FilenameSchema) AsInfo(codes ...ErrorCode) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *FilenameSchema) Default(value interface{}) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *FilenameSchema) DefaultFunc(fn func() interface{}) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *FilenameSchema) Example(example string) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Extensions restricts the file extension (compared case-insensitively), e.g.
+// Extensions("png", ".jpg", "tar.gz")
+func (s *FilenameSchema) Extensions(extensions ...string) *FilenameSchema {
+	s.checkMutable()
+	s.extensions = normalizeExtensions(extensions)
+	return s
+}
+
+// ExtensionError sets a custom error message for extensions not in Extensions
+func (s *FilenameSchema) ExtensionError(message interface{}) *FilenameSchema {
+	s.checkMutable()
+	s.extensionError = toErrorMessage(message)
+	return s
+}
+
+// Portable rejects names that Windows cannot store: the characters < > : " | ? *,
+// reserved device names such as "CON" or "lpt1.txt", and a trailing dot or space
+func (s *FilenameSchema) Portable(errorMessage ...interface{}) *FilenameSchema {
+	s.checkMutable()
+	s.portable = true
+	if len(errorMessage) > 0 {
+		s.portableError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *FilenameSchema) Optional() *FilenameSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *FilenameSchema) Required(errorMessage ...interface{}) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *FilenameSchema) Nullable() *FilenameSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *FilenameSchema) TypeError(message string) *FilenameSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for invalid file names
+func (s *FilenameSchema) FormatError(message string) *FilenameSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *FilenameSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *FilenameSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *FilenameSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// IsPortable returns whether names must be valid on Windows
+func (s *FilenameSchema) IsPortable() bool {
+	return s.portable
+}
+
+// GetExtensions returns the allowed extensions, lowercase without the leading dot
+func (s *FilenameSchema) GetExtensions() []string {
+	return s.extensions
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *FilenameSchema) Transform(fn TransformFunc) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *FilenameSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *FilenameSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *FilenameSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a file name and returns it unchanged
+func (s *FilenameSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the file name; Parse runs the refine/transform pipeline on top
+func (s *FilenameSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := filenameRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	name, ok := value.(string)
+	if !ok {
+		message := filenameTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	if !validFilename(name) {
+		message := filenameFormatError(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+
+	var errors []ValidationError
+
+	if len(s.extensions) > 0 && !hasExtension(s.extensions, name) {
+		message := fileExtensionError(s.extensions)(ctx.Locale)
+		if !isEmptyErrorMessage(s.extensionError) {
+			message = resolveErrorMessage(s.extensionError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeExtension))
+	}
+
+	if s.portable && !portableFilename(name) {
+		message := filenamePortableError(ctx.Locale)
+		if !isEmptyErrorMessage(s.portableError) {
+			message = resolveErrorMessage(s.portableError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodePortable))
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errors}
+	}
+	return ParseResult{Valid: true, Value: name, Errors: nil}
+}
+
+// JSON generates JSON Schema representation. The pattern cannot exclude "." and "..",
+// which are only rejected by Parse.
+func (s *FilenameSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["minLength"] = 1
+	schema["maxLength"] = maxFilenameLength
+	schema["pattern"] = `^[^/\\\x00-\x1F\x7F-\x9F]+$`
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
+
+// FilePathSchema validates a slash- or backslash-separated file path, such as a path
+// inside an archive or a storage bucket, and returns it unchanged. Paths are relative by
+// default, and ".." segments are always rejected so the path cannot escape the
+// directory it is joined to.
+type FilePathSchema struct {
+	Schema
+	allowAbsolute bool
+	extensions    []string // Allowed extensions, lowercase without the leading dot
+	nullable      bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	traversalError    ErrorMessage
+	absoluteError     ErrorMessage
+	extensionError    ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// FilePath creates a new file path schema accepting relative paths
+func FilePath(errorMessage ...interface{}) *FilePathSchema {
+	schema := &FilePathSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *FilePathSchema) Clone() *FilePathSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.extensions = slices.Clone(s.extensions)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *FilePathSchema) Freeze() *FilePathSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *FilePathSchema) Title(title string) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *FilePathSchema) Description(description string) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *FilePathSchema) ReadOnly() *FilePathSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *FilePathSchema) WriteOnly() *FilePathSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *FilePathSchema) Sensitive() *FilePathSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *FilePathSchema) Deprecated(reason string) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *FilePathSchema) Meta(key string, value interface{}) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// Default sets the default value
+func (s *FilePathSchema) Default(value interface{}) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *FilePathSchema) DefaultFunc(fn func() interface{}) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *FilePathSchema) Example(example string) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// AllowAbsolute accepts absolute paths ("/etc/app.conf", "\\server\share", "C:\data")
+func (s *FilePathSchema) AllowAbsolute() *FilePathSchema {
+	s.checkMutable()
+	s.allowAbsolute = true
+	return s
+}
+
+// AbsoluteError sets a custom error message for absolute paths
+func (s *FilePathSchema) AbsoluteError(message interface{}) *FilePathSchema {
+	s.checkMutable()
+	s.absoluteError = toErrorMessage(message)
+	return s
+}
+
+// TraversalError sets a custom error message for paths with ".." segments
+func (s *FilePathSchema) TraversalError(message interface{}) *FilePathSchema {
+	s.checkMutable()
+	s.traversalError = toErrorMessage(message)
+	return s
+}
+
+// Extensions restricts the extension of the last path segment (compared
+// case-insensitively), e.g. Extensions("yaml", "yml")
+func (s *FilePathSchema) Extensions(extensions ...string) *FilePathSchema {
+	s.checkMutable()
+	s.extensions = normalizeExtensions(extensions)
+	return s
+}
+
+// ExtensionError sets a custom error message for extensions not in Extensions
+func (s *FilePathSchema) ExtensionError(message interface{}) *FilePathSchema {
+	s.checkMutable()
+	s.extensionError = toErrorMessage(message)
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *FilePathSchema) Optional() *FilePathSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *FilePathSchema) Required(errorMessage ...interface{}) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *FilePathSchema) Nullable() *FilePathSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *FilePathSchema) TypeError(message string) *FilePathSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for invalid paths
+func (s *FilePathSchema) FormatError(message string) *FilePathSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *FilePathSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *FilePathSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *FilePathSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// IsAbsoluteAllowed returns whether absolute paths are accepted
+func (s *FilePathSchema) IsAbsoluteAllowed() bool {
+	return s.allowAbsolute
+}
+
+// GetExtensions returns the allowed extensions, lowercase without the leading dot
+func (s *FilePathSchema) GetExtensions() []string {
+	return s.extensions
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *FilePathSchema) Transform(fn TransformFunc) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *FilePathSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *FilePathSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *FilePathSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a file path and returns it unchanged
+func (s *FilePathSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the file path; Parse runs the refine/transform pipeline on top
+func (s *FilePathSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := filenameRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	path, ok := value.(string)
+	if !ok {
+		message := filenameTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	if path == "" || containsControlChar(path) {
+		message := filePathFormatError(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+
+	var errors []ValidationError
+
+	// Both separators are checked whatever the host OS, since the path may be used on another one
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' })
+	for _, segment := range segments {
+		if segment == ".." {
+			message := filePathTraversalError(ctx.Locale)
+			if !isEmptyErrorMessage(s.traversalError) {
+				message = resolveErrorMessage(s.traversalError, ctx)
+			}
+			errors = append(errors, NewPrimitiveError(value, message, CodeTraversal))
+			break
+		}
+	}
+
+	if !s.allowAbsolute && isAbsolutePath(path) {
+		message := filePathAbsoluteError(ctx.Locale)
+		if !isEmptyErrorMessage(s.absoluteError) {
+			message = resolveErrorMessage(s.absoluteError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeAbsolute))
+	}
+
+	if len(s.extensions) > 0 && (len(segments) == 0 || !hasExtension(s.extensions, segments[len(segments)-1])) {
+		message := fileExtensionError(s.extensions)(ctx.Locale)
+		if !isEmptyErrorMessage(s.extensionError) {
+			message = resolveErrorMessage(s.extensionError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeExtension))
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errors}
+	}
+	return ParseResult{Valid: true, Value: path, Errors: nil}
+}
+
+// JSON generates JSON Schema representation. Relative paths get a pattern rejecting
+// leading separators and drive letters; ".." segments are only rejected by Parse.
+func (s *FilePathSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["minLength"] = 1
+	if !s.allowAbsolute {
+		schema["pattern"] = `^([^/\\A-Za-z]|[A-Za-z]([^:]|$))`
+	}
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
+
+// validFilename reports whether name is a single, non-special path segment
+func validFilename(name string) bool {
+	return name != "" && name != "." && name != ".." && len(name) <= maxFilenameLength &&
+		!strings.ContainsAny(name, `/\`) && !containsControlChar(name)
+}
+
+// windowsReservedNames are device names Windows reserves with or without an extension
+var windowsReservedNames = map[string]bool{
+	"con": true, "prn": true, "aux": true, "nul": true,
+	"com1": true, "com2": true, "com3": true, "com4": true, "com5": true, "com6": true, "com7": true, "com8": true, "com9": true,
+	"lpt1": true, "lpt2": true, "lpt3": true, "lpt4": true, "lpt5": true, "lpt6": true, "lpt7": true, "lpt8": true, "lpt9": true,
+}
+
+// portableFilename reports whether name can also be stored on Windows
+func portableFilename(name string) bool {
+	if strings.ContainsAny(name, `<>:"|?*`) || strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+		return false
+	}
+	base := strings.ToLower(name)
+	if dot := strings.IndexByte(base, '.'); dot >= 0 {
+		base = base[:dot]
+	}
+	return !windowsReservedNames[strings.TrimRight(base, " ")]
+}
+
+// isAbsolutePath reports whether path is absolute on Unix or Windows
+func isAbsolutePath(path string) bool {
+	if path[0] == '/' || path[0] == '\\' {
+		return true
+	}
+	return len(path) >= 2 && path[1] == ':' && isAlpha(path[:1])
+}
+
+// normalizeExtensions lowercases extensions and strips their leading dot
+func normalizeExtensions(extensions []string) []string {
+	normalized := make([]string, len(extensions))
+	for i, extension := range extensions {
+		normalized[i] = strings.TrimPrefix(strings.ToLower(extension), ".")
+	}
+	return normalized
+}
+
+// hasExtension reports whether name ends with one of the extensions; a name that is
+// only an extension (".png") does not count
+func hasExtension(extensions []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, extension := range extensions {
+		if len(name) > len(extension)+1 && strings.HasSuffix(name, "."+extension) {
+			return true
+		}
+	}
+	return false
+}
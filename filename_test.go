@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFilenameSchema_Parse(t *testing.T) {
+	ctx := DefaultValidationContext()
+	tests := []struct {
+		name   string
+		schema *FilenameSchema
+		value  interface{}
+		errors []string
+	}{
+		{"simple", Filename(), "report.pdf", nil},
+		{"unicode", Filename(), "résumé 2024.docx", nil},
+		{"dotfile", Filename(), ".gitignore", nil},
+		{"slash", Filename(), "../etc/passwd", []string{" format"}},
+		{"backslash", Filename(), `..\boot.ini`, []string{" format"}},
+		{"dot", Filename(), ".", []string{" format"}},
+		{"dot dot", Filename(), "..", []string{" format"}},
+		{"empty", Filename(), "", []string{" format"}},
+		{"nul", Filename(), "a\x00.txt", []string{" format"}},
+		{"newline", Filename(), "a\n.txt", []string{" format"}},
+		{"too long", Filename(), strings.Repeat("a", 252) + ".txt", []string{" format"}},
+		{"extension", Filename().Extensions("png", ".JPG"), "photo.jpg", nil},
+		{"extension case", Filename().Extensions("png"), "photo.PNG", nil},
+		{"multi-part extension", Filename().Extensions("tar.gz"), "backup.tar.gz", nil},
+		{"extension mismatch", Filename().Extensions("png"), "photo.png.exe", []string{" extension"}},
+		{"only extension", Filename().Extensions("png"), ".png", []string{" extension"}},
+		{"portable", Filename().Portable(), "notes.txt", nil},
+		{"reserved name", Filename().Portable(), "CON", []string{" portable"}},
+		{"reserved with extension", Filename().Portable(), "lpt1.txt", []string{" portable"}},
+		{"reserved prefix is fine", Filename().Portable(), "console.log", nil},
+		{"windows characters", Filename().Portable(), "a:b.txt", []string{" portable"}},
+		{"trailing dot", Filename().Portable(), "notes.", []string{" portable"}},
+		{"both", Filename().Extensions("txt").Portable(), "aux.log", []string{" extension", " portable"}},
+		{"wrong type", Filename(), 42, []string{" invalid_type"}},
+		{"nil", Filename(), nil, []string{" required"}},
+		{"nil optional", Filename().Optional(), nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if codes := errorKeys(result.Errors); len(codes) > 0 || len(tt.errors) > 0 {
+				if !reflect.DeepEqual(codes, tt.errors) {
+					t.Errorf("errors = %v, want %v", codes, tt.errors)
+				}
+			}
+			if result.Valid != (len(tt.errors) == 0) {
+				t.Errorf("Valid = %v", result.Valid)
+			}
+			if result.Valid && result.Value != tt.value {
+				t.Errorf("Value = %v, want %v", result.Value, tt.value)
+			}
+		})
+	}
+}
+
+func TestFilePathSchema_Parse(t *testing.T) {
+	ctx := DefaultValidationContext()
+	tests := []struct {
+		name   string
+		schema *FilePathSchema
+		value  interface{}
+		errors []string
+	}{
+		{"relative", FilePath(), "assets/img/logo.png", nil},
+		{"dot segments", FilePath(), "./assets/../logo.png", []string{" traversal"}},
+		{"dot segment", FilePath(), "./logo.png", nil},
+		{"dot dot prefix in name", FilePath(), "assets/..hidden", nil},
+		{"traversal", FilePath(), "../../etc/passwd", []string{" traversal"}},
+		{"windows traversal", FilePath(), `assets\..\..\secret`, []string{" traversal"}},
+		{"absolute", FilePath(), "/etc/passwd", []string{" absolute"}},
+		{"windows absolute", FilePath(), `C:\Windows\win.ini`, []string{" absolute"}},
+		{"unc", FilePath(), `\\server\share`, []string{" absolute"}},
+		{"absolute traversal", FilePath(), "/var/../etc", []string{" absolute", " traversal"}},
+		{"absolute allowed", FilePath().AllowAbsolute(), "/etc/app.conf", nil},
+		{"absolute allowed still no traversal", FilePath().AllowAbsolute(), "/srv/../etc", []string{" traversal"}},
+		{"nul", FilePath(), "a\x00/b", []string{" format"}},
+		{"empty", FilePath(), "", []string{" format"}},
+		{"extension", FilePath().Extensions("yaml", "yml"), "config/app.YML", nil},
+		{"extension of directory", FilePath().Extensions("yaml"), "config.yaml/app", []string{" extension"}},
+		{"extension of root", FilePath().AllowAbsolute().Extensions("yaml"), "/", []string{" extension"}},
+		{"wrong type", FilePath(), []byte("a"), []string{" invalid_type"}},
+		{"nullable", FilePath().Nullable(), nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if codes := errorKeys(result.Errors); len(codes) > 0 || len(tt.errors) > 0 {
+				if !reflect.DeepEqual(codes, tt.errors) {
+					t.Errorf("errors = %v, want %v", codes, tt.errors)
+				}
+			}
+			if result.Valid != (len(tt.errors) == 0) {
+				t.Errorf("Valid = %v", result.Valid)
+			}
+		})
+	}
+
+	result := FilePath().TraversalError("stay inside the upload folder").Parse("../x", ctx)
+	if result.Errors[0].Message != "stay inside the upload folder" {
+		t.Errorf("message = %q", result.Errors[0].Message)
+	}
+}
+
+func TestFilePathSchema_JSONPatterns(t *testing.T) {
+	// The JSON patterns must compile with String().Pattern so schemas round-trip
+	filenamePattern := Filename().JSON()["pattern"].(string)
+	pathPattern := FilePath().JSON()["pattern"].(string)
+
+	name := String().Pattern(filenamePattern)
+	path := String().Pattern(pathPattern)
+	ctx := DefaultValidationContext()
+	for value, expected := range map[string]bool{"report.pdf": true, "a/b": false, "a\x01": false} {
+		if result := name.Parse(value, ctx); result.Valid != expected {
+			t.Errorf("filename pattern on %q = %v, want %v", value, result.Valid, expected)
+		}
+	}
+	for value, expected := range map[string]bool{"a/b": true, "c": true, "/a": false, `\a`: false, "C:/a": false, "Ca:": true} {
+		if result := path.Parse(value, ctx); result.Valid != expected {
+			t.Errorf("path pattern on %q = %v, want %v", value, result.Valid, expected)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// Fingerprint returns a stable SHA-256 (hex encoded) of the canonical JSON Schema of s.
+// Schemas that generate the same JSON Schema have the same fingerprint, regardless of
+// the order in which properties, required properties, types and enum values were
+// declared. Annotations such as title, description and metadata are included; error
+// messages and Refine/Transform functions are not, as they have no JSON form.
+func Fingerprint(s Parseable) string {
+	doc := map[string]interface{}{}
+	if generator, ok := s.(JSONSchemaGenerator); ok {
+		doc = JSONSchemaWithOptions(generator, JSONSchemaOptions{SortKeys: true})
+	}
+	// encoding/json writes map keys in sorted order, so the encoding is canonical
+	data, err := json.Marshal(canonicalizeSchema(doc))
+	if err != nil {
+		data = []byte("{}")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalizeSchema sorts the enum values of every schema in a normalized JSON Schema
+func canonicalizeSchema(node interface{}) interface{} {
+	switch n := node.(type) {
+	case []interface{}:
+		for i, item := range n {
+			n[i] = canonicalizeSchema(item)
+		}
+	case map[string]interface{}:
+		for key, value := range n {
+			switch key {
+			case "const", "default", "examples":
+				// Instance data, not schemas
+			case "enum":
+				if values, ok := value.([]interface{}); ok {
+					sortByEncoding(values)
+				}
+			case "properties", "patternProperties", "$defs", "definitions", "dependentSchemas":
+				// Maps from names to schemas, whose keys must not be mistaken for keywords
+				if named, ok := value.(map[string]interface{}); ok {
+					for name, child := range named {
+						named[name] = canonicalizeSchema(child)
+					}
+				}
+			default:
+				n[key] = canonicalizeSchema(value)
+			}
+		}
+	}
+	return node
+}
+
+// sortByEncoding sorts values by their JSON encoding
+func sortByEncoding(values []interface{}) {
+	keys := make([]string, len(values))
+	for i, value := range values {
+		data, _ := json.Marshal(value)
+		keys[i] = string(data)
+	}
+	sort.Sort(byEncoding{keys: keys, values: values})
+}
+
+// byEncoding sorts values together with their encodings
+type byEncoding struct {
+	keys   []string
+	values []interface{}
+}
+
+func (b byEncoding) Len() int           { return len(b.keys) }
+func (b byEncoding) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byEncoding) Swap(i, j int) {
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+}
@@ -0,0 +1,43 @@
+package schema
+
+import "testing"
+
+func TestFingerprint(t *testing.T) {
+	a := Object().
+		Property("name", String().MinLength(1)).
+		Property("role", Enum("admin", "user")).
+		Property("age", Int().Optional())
+	b := Object().
+		Property("age", Int().Optional()).
+		Property("role", Enum("user", "admin")).
+		Property("name", String().MinLength(1))
+
+	fingerprint := Fingerprint(a)
+	if len(fingerprint) != 64 {
+		t.Fatalf("Fingerprint() = %q, want 64 hex characters", fingerprint)
+	}
+	if Fingerprint(a) != fingerprint {
+		t.Error("Fingerprint is not stable")
+	}
+	if Fingerprint(b) != fingerprint {
+		t.Error("declaration order should not change the fingerprint")
+	}
+
+	changed := []Parseable{
+		a.Pick("name", "role"),
+		Object().Property("name", String().MinLength(2)).Property("role", Enum("admin", "user")).Property("age", Int().Optional()),
+		Object().Property("name", String().MinLength(1)).Property("role", Enum("admin", "user")).Property("age", Int()),
+		a.Pick("name", "role", "age").Description("User"),
+		a.Pick("name", "role", "age").Passthrough(),
+	}
+	for i, s := range changed {
+		if Fingerprint(s) == fingerprint {
+			t.Errorf("schema %d should have a different fingerprint", i)
+		}
+	}
+
+	// Instance data keeps its order
+	if Fingerprint(String().Example("b").Example("a")) == Fingerprint(String().Example("a").Example("b")) {
+		t.Error("examples should be hashed in order")
+	}
+}
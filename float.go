@@ -11,6 +11,7 @@ var (
 	floatRequiredError = i18n.S("value is required")
 	floatTypeError     = i18n.S("value must be a 32-bit float")
 	floatEnumError     = i18n.S("value must be one of the allowed values")
+	floatFiniteError   = i18n.S("value must be a finite number")
 )
 
 func floatMinimumError(min float32) i18n.TranslatedFunc {
@@ -21,6 +22,18 @@ func floatMaximumError(max float32) i18n.TranslatedFunc {
 	return i18n.F("value must be at most %g", max)
 }
 
+func floatExclusiveMinimumError(min float32) i18n.TranslatedFunc {
+	return i18n.F("value must be greater than %g", min)
+}
+
+func floatExclusiveMaximumError(max float32) i18n.TranslatedFunc {
+	return i18n.F("value must be less than %g", max)
+}
+
+func floatPrecisionError(places int) i18n.TranslatedFunc {
+	return i18n.F("value must have at most %d decimal places", places)
+}
+
 func floatMultipleOfError(multiple float32) i18n.TranslatedFunc {
 	return i18n.F("value must be a multiple of %g", multiple)
 }
@@ -31,18 +44,27 @@ func floatConstError(value float32) i18n.TranslatedFunc {
 
 type FloatSchema struct {
 	Schema
-	minimum    *float32
-	maximum    *float32
-	multipleOf *float32
-	nullable   bool
-
-	requiredError     ErrorMessage
-	minimumError      ErrorMessage
-	maximumError      ErrorMessage
-	multipleOfError   ErrorMessage
-	enumError         ErrorMessage
-	constError        ErrorMessage
-	typeMismatchError ErrorMessage
+	coerce           bool // Convert compatible input types before validating
+	minimum          *float32
+	maximum          *float32
+	exclusiveMinimum *float32
+	exclusiveMaximum *float32
+	multipleOf       *float32
+	precision        *int // Maximum number of decimal places
+	finite           bool // Reject NaN and ±Inf
+	nullable         bool
+
+	requiredError         ErrorMessage
+	minimumError          ErrorMessage
+	maximumError          ErrorMessage
+	exclusiveMinimumError ErrorMessage
+	exclusiveMaximumError ErrorMessage
+	multipleOfError       ErrorMessage
+	precisionError        ErrorMessage
+	finiteError           ErrorMessage
+	enumError             ErrorMessage
+	constError            ErrorMessage
+	typeMismatchError     ErrorMessage
 }
 
 func Float(errorMessage ...interface{}) *FloatSchema {
@@ -58,23 +80,124 @@ func Float(errorMessage ...interface{}) *FloatSchema {
 	return schema
 }
 
-func (s *FloatSchema) Title(title string) *FloatSchema { s.Schema.title = title; return s }
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *FloatSchema) Clone() *FloatSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *FloatSchema) Freeze() *FloatSchema {
+	s.freeze()
+	return s
+}
+
+func (s *FloatSchema) Title(title string) *FloatSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
 func (s *FloatSchema) Description(description string) *FloatSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *FloatSchema) ReadOnly() *FloatSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *FloatSchema) WriteOnly() *FloatSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *FloatSchema) Sensitive() *FloatSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *FloatSchema) Deprecated(reason string) *FloatSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *FloatSchema) Meta(key string, value interface{}) *FloatSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *FloatSchema) AsWarning(codes ...ErrorCode) *FloatSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *FloatSchema) AsInfo(codes ...ErrorCode) *FloatSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
 func (s *FloatSchema) Default(value interface{}) *FloatSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+func (s *FloatSchema) DefaultFunc(fn func() interface{}) *FloatSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 func (s *FloatSchema) Example(example float32) *FloatSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
-func (s *FloatSchema) Optional() *FloatSchema { s.Schema.required = false; return s }
-func (s *FloatSchema) Nullable() *FloatSchema { s.nullable = true; return s }
+func (s *FloatSchema) Optional() *FloatSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+func (s *FloatSchema) Nullable() *FloatSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+func (s *FloatSchema) Coerce() *FloatSchema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *FloatSchema) coerces() bool {
+	return s.coerce
+}
 
 func (s *FloatSchema) Enum(values []float32, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -86,6 +209,7 @@ func (s *FloatSchema) Enum(values []float32, errorMessage ...interface{}) *Float
 }
 
 func (s *FloatSchema) Const(value float32, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -94,6 +218,7 @@ func (s *FloatSchema) Const(value float32, errorMessage ...interface{}) *FloatSc
 }
 
 func (s *FloatSchema) Min(min float32, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
 	s.minimum = &min
 	if len(errorMessage) > 0 {
 		s.minimumError = toErrorMessage(errorMessage[0])
@@ -102,6 +227,7 @@ func (s *FloatSchema) Min(min float32, errorMessage ...interface{}) *FloatSchema
 }
 
 func (s *FloatSchema) Max(max float32, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
 	s.maximum = &max
 	if len(errorMessage) > 0 {
 		s.maximumError = toErrorMessage(errorMessage[0])
@@ -110,6 +236,7 @@ func (s *FloatSchema) Max(max float32, errorMessage ...interface{}) *FloatSchema
 }
 
 func (s *FloatSchema) Range(min, max float32, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
 	s.minimum = &min
 	s.maximum = &max
 	if len(errorMessage) > 0 {
@@ -120,6 +247,7 @@ func (s *FloatSchema) Range(min, max float32, errorMessage ...interface{}) *Floa
 }
 
 func (s *FloatSchema) MultipleOf(multiple float32, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
 	s.multipleOf = &multiple
 	if len(errorMessage) > 0 {
 		s.multipleOfError = toErrorMessage(errorMessage[0])
@@ -127,14 +255,105 @@ func (s *FloatSchema) MultipleOf(multiple float32, errorMessage ...interface{})
 	return s
 }
 
-func (s *FloatSchema) IsRequired() bool        { return s.Schema.required }
-func (s *FloatSchema) IsOptional() bool        { return !s.Schema.required }
-func (s *FloatSchema) IsNullable() bool        { return s.nullable }
-func (s *FloatSchema) GetMinimum() *float32    { return s.minimum }
-func (s *FloatSchema) GetMaximum() *float32    { return s.maximum }
-func (s *FloatSchema) GetMultipleOf() *float32 { return s.multipleOf }
+// ExclusiveMin requires the value to be greater than min, with optional custom error message
+func (s *FloatSchema) ExclusiveMin(min float32, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
+	s.exclusiveMinimum = &min
+	if len(errorMessage) > 0 {
+		s.exclusiveMinimumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// ExclusiveMax requires the value to be less than max, with optional custom error message
+func (s *FloatSchema) ExclusiveMax(max float32, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
+	s.exclusiveMaximum = &max
+	if len(errorMessage) > 0 {
+		s.exclusiveMaximumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Positive requires the value to be greater than zero, with optional custom error message
+func (s *FloatSchema) Positive(errorMessage ...interface{}) *FloatSchema {
+	return s.ExclusiveMin(0, errorMessage...)
+}
+
+// Negative requires the value to be less than zero, with optional custom error message
+func (s *FloatSchema) Negative(errorMessage ...interface{}) *FloatSchema {
+	return s.ExclusiveMax(0, errorMessage...)
+}
+
+// NonNegative requires the value to be zero or greater, with optional custom error message
+func (s *FloatSchema) NonNegative(errorMessage ...interface{}) *FloatSchema {
+	return s.Min(0, errorMessage...)
+}
+
+// Precision limits the number of decimal places in the shortest float32
+// representation of the value, with optional custom error message
+func (s *FloatSchema) Precision(decimalPlaces int, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
+	s.precision = &decimalPlaces
+	if len(errorMessage) > 0 {
+		s.precisionError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Finite rejects NaN and ±Inf, with optional custom error message
+func (s *FloatSchema) Finite(errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
+	s.finite = true
+	if len(errorMessage) > 0 {
+		s.finiteError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+func (s *FloatSchema) IsRequired() bool              { return s.Schema.required }
+func (s *FloatSchema) IsOptional() bool              { return !s.Schema.required }
+func (s *FloatSchema) IsNullable() bool              { return s.nullable }
+func (s *FloatSchema) IsFinite() bool                { return s.finite }
+func (s *FloatSchema) GetMinimum() *float32          { return s.minimum }
+func (s *FloatSchema) GetMaximum() *float32          { return s.maximum }
+func (s *FloatSchema) GetExclusiveMinimum() *float32 { return s.exclusiveMinimum }
+func (s *FloatSchema) GetExclusiveMaximum() *float32 { return s.exclusiveMaximum }
+func (s *FloatSchema) GetMultipleOf() *float32       { return s.multipleOf }
+func (s *FloatSchema) GetPrecision() *int            { return s.precision }
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *FloatSchema) Transform(fn TransformFunc) *FloatSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *FloatSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *FloatSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *FloatSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
 
 func (s *FloatSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the float constraints; Parse runs the refine/transform pipeline on top
+func (s *FloatSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceFloat(value)
+	}
+
 	var errors []ValidationError
 
 	if value == nil {
@@ -143,16 +362,16 @@ func (s *FloatSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		}
 		if s.Schema.required {
 			if defaultVal := s.GetDefault(); defaultVal != nil {
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			message := floatRequiredError(ctx.Locale)
 			if !isEmptyErrorMessage(s.requiredError) {
 				message = resolveErrorMessage(s.requiredError, ctx)
 			}
-			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, "required")}}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
 		}
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
 	}
@@ -191,17 +410,25 @@ func (s *FloatSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.typeMismatchError) {
 			message = resolveErrorMessage(s.typeMismatchError, ctx)
 		}
-		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")}}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
 	}
 
 	finalValue := floatValue
 
+	if s.finite && (math.IsNaN(float64(floatValue)) || math.IsInf(float64(floatValue), 0)) {
+		message := floatFiniteError(ctx.Locale)
+		if !isEmptyErrorMessage(s.finiteError) {
+			message = resolveErrorMessage(s.finiteError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(floatValue, message, CodeNotFinite))
+	}
+
 	if s.minimum != nil && floatValue < *s.minimum {
 		message := floatMinimumError(*s.minimum)(ctx.Locale)
 		if !isEmptyErrorMessage(s.minimumError) {
 			message = resolveErrorMessage(s.minimumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(floatValue, message, "minimum"))
+		errors = append(errors, NewPrimitiveError(floatValue, message, CodeMinimum))
 	}
 
 	if s.maximum != nil && floatValue > *s.maximum {
@@ -209,7 +436,31 @@ func (s *FloatSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.maximumError) {
 			message = resolveErrorMessage(s.maximumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(floatValue, message, "maximum"))
+		errors = append(errors, NewPrimitiveError(floatValue, message, CodeMaximum))
+	}
+
+	if s.exclusiveMinimum != nil && floatValue <= *s.exclusiveMinimum {
+		message := floatExclusiveMinimumError(*s.exclusiveMinimum)(ctx.Locale)
+		if !isEmptyErrorMessage(s.exclusiveMinimumError) {
+			message = resolveErrorMessage(s.exclusiveMinimumError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(floatValue, message, CodeExclusiveMinimum))
+	}
+
+	if s.exclusiveMaximum != nil && floatValue >= *s.exclusiveMaximum {
+		message := floatExclusiveMaximumError(*s.exclusiveMaximum)(ctx.Locale)
+		if !isEmptyErrorMessage(s.exclusiveMaximumError) {
+			message = resolveErrorMessage(s.exclusiveMaximumError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(floatValue, message, CodeExclusiveMaximum))
+	}
+
+	if s.precision != nil && decimalPlaces(float64(floatValue), 32) > *s.precision {
+		message := floatPrecisionError(*s.precision)(ctx.Locale)
+		if !isEmptyErrorMessage(s.precisionError) {
+			message = resolveErrorMessage(s.precisionError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(floatValue, message, CodePrecision))
 	}
 
 	if s.multipleOf != nil {
@@ -219,7 +470,7 @@ func (s *FloatSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			if !isEmptyErrorMessage(s.multipleOfError) {
 				message = resolveErrorMessage(s.multipleOfError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(floatValue, message, "multiple_of"))
+			errors = append(errors, NewPrimitiveError(floatValue, message, CodeMultipleOf))
 		}
 	}
 
@@ -236,7 +487,7 @@ func (s *FloatSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(floatValue, message, "enum"))
+			errors = append(errors, NewPrimitiveError(floatValue, message, CodeEnum))
 		}
 	}
 
@@ -246,7 +497,7 @@ func (s *FloatSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			if !isEmptyErrorMessage(s.constError) {
 				message = resolveErrorMessage(s.constError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(floatValue, message, "const"))
+			errors = append(errors, NewPrimitiveError(floatValue, message, CodeConst))
 		}
 	}
 
@@ -257,7 +508,8 @@ func (s *FloatSchema) JSON() map[string]interface{} {
 	schema := baseJSONSchema("number")
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -268,6 +520,12 @@ func (s *FloatSchema) JSON() map[string]interface{} {
 	if s.maximum != nil {
 		schema["maximum"] = *s.maximum
 	}
+	if s.exclusiveMinimum != nil {
+		schema["exclusiveMinimum"] = *s.exclusiveMinimum
+	}
+	if s.exclusiveMaximum != nil {
+		schema["exclusiveMaximum"] = *s.exclusiveMaximum
+	}
 	if s.multipleOf != nil {
 		schema["multipleOf"] = *s.multipleOf
 	}
@@ -284,19 +542,23 @@ func (s *FloatSchema) JSON() map[string]interface{} {
 func (s *FloatSchema) MarshalJSON() ([]byte, error) {
 	type jsonFloatSchema struct {
 		Schema
-		Minimum    *float32 `json:"minimum,omitempty"`
-		Maximum    *float32 `json:"maximum,omitempty"`
-		MultipleOf *float32 `json:"multipleOf,omitempty"`
-		Format     string   `json:"format"`
-		Nullable   bool     `json:"nullable,omitempty"`
+		Minimum          *float32 `json:"minimum,omitempty"`
+		Maximum          *float32 `json:"maximum,omitempty"`
+		ExclusiveMinimum *float32 `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum *float32 `json:"exclusiveMaximum,omitempty"`
+		MultipleOf       *float32 `json:"multipleOf,omitempty"`
+		Format           string   `json:"format"`
+		Nullable         bool     `json:"nullable,omitempty"`
 	}
 
 	return json.Marshal(jsonFloatSchema{
-		Schema:     s.Schema,
-		Minimum:    s.minimum,
-		Maximum:    s.maximum,
-		MultipleOf: s.multipleOf,
-		Format:     "float",
-		Nullable:   s.nullable,
+		Schema:           s.Schema,
+		Minimum:          s.minimum,
+		Maximum:          s.maximum,
+		ExclusiveMinimum: s.exclusiveMinimum,
+		ExclusiveMaximum: s.exclusiveMaximum,
+		MultipleOf:       s.multipleOf,
+		Format:           "float",
+		Nullable:         s.nullable,
 	})
 }
@@ -0,0 +1,60 @@
+package schema
+
+// Schemas are built by fluent methods that modify the receiver, which is convenient
+// while a schema is being defined but unsafe once it is shared: deriving a variant
+// from a base schema changes the base, and a change racing with Parse in another
+// goroutine is a data race.
+//
+// Freeze ends the building phase. A frozen schema, and every schema nested in it,
+// panics on any further modification, so a shared schema can never change under its
+// users. Clone returns an unfrozen copy from which variants are derived. Parse and the
+// getters never modify a schema, so a frozen schema may be parsed against from any
+// number of goroutines at once, and so may its JSON Schema be generated.
+
+// frozenSchemaError is the panic message for modifying a frozen schema
+const frozenSchemaError = "schema: cannot modify a frozen schema; modify a Clone instead"
+
+// frozenState records whether a schema is frozen. It is part of the base Schema and
+// of the schema types that do not embed Schema.
+type frozenState struct {
+	frozen      bool
+	sensitivity uint32 // Whether nested schemas are Sensitive, kept once frozen (see subtreeSensitive)
+}
+
+// state returns the frozen state of the schema
+func (f *frozenState) state() *frozenState {
+	return f
+}
+
+// IsFrozen returns whether the schema has been frozen with Freeze
+func (f *frozenState) IsFrozen() bool {
+	return f.frozen
+}
+
+// checkMutable panics if the schema is frozen. Every fluent method that modifies a
+// schema calls it first.
+func (f *frozenState) checkMutable() {
+	if f.frozen {
+		panic(frozenSchemaError)
+	}
+}
+
+// freeze marks the schema itself as frozen. Schemas that contain other schemas
+// override it to freeze those too.
+func (f *frozenState) freeze() {
+	f.frozen = true
+}
+
+// freezable is implemented by every schema type
+type freezable interface {
+	freeze()
+}
+
+// freezeSchemas freezes the given schemas and the schemas nested in them
+func freezeSchemas(schemas ...Parseable) {
+	for _, schema := range schemas {
+		if f, ok := schema.(freezable); ok {
+			f.freeze()
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	mustPanic := func(t *testing.T, name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: modifying a frozen schema did not panic", name)
+			}
+		}()
+		fn()
+	}
+
+	address := Object().Property("city", String())
+	tags := String().MinLength(1)
+	base := Object().
+		Property("name", String().MinLength(1)).
+		Property("address", address).
+		Property("tags", Array(tags)).
+		Property("kind", Union(Literal("a"), Int())).
+		When("name", Literal("x")).ThenRequire("tags").
+		Freeze()
+
+	mustPanic(t, "property", func() { base.Property("age", Int()) })
+	mustPanic(t, "strict", func() { base.Strict() })
+	mustPanic(t, "refine", func() { base.Refine(func(interface{}) bool { return true }) })
+	mustPanic(t, "condition", func() { base.When("name", Literal("y")).ThenRequire("age") })
+	mustPanic(t, "nested object", func() { address.Property("zip", String()) })
+	mustPanic(t, "array items", func() { tags.MaxLength(3) })
+	mustPanic(t, "scalar", func() { String().Freeze().Trim() })
+	mustPanic(t, "uuid", func() { UUID().Freeze().Version(UUIDVersion4) })
+	mustPanic(t, "lazy", func() { Lazy(func() Parseable { return base }).Freeze().Nullable() })
+
+	if !base.IsFrozen() || !address.IsFrozen() || !tags.IsFrozen() {
+		t.Error("Freeze should freeze nested schemas")
+	}
+
+	// Derived schemas are unfrozen and leave the frozen base unchanged
+	admin := base.Clone().Property("role", String())
+	partial := base.Partial().Strict()
+	if admin.IsFrozen() || partial.IsFrozen() {
+		t.Error("Clone and derivations should be unfrozen")
+	}
+	if _, ok := base.GetProperties()["role"]; ok {
+		t.Error("modifying a clone changed the frozen original")
+	}
+	email := Email().Freeze()
+	if strict := email.Clone().Domains("example.com"); strict.IsFrozen() || len(email.domains) != 0 {
+		t.Error("clone should be independent of the frozen original")
+	}
+
+	// A frozen schema is parsed concurrently without races
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				result := base.Parse(map[string]interface{}{
+					"name":    "Ada",
+					"address": map[string]interface{}{"city": "London"},
+					"tags":    []interface{}{"math"},
+					"kind":    "a",
+				}, DefaultValidationContext())
+				if !result.Valid {
+					t.Errorf("unexpected errors: %v", result.Errors)
+					return
+				}
+				_ = base.JSON()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFreeze_LazyJSON(t *testing.T) {
+	var category *ObjectSchema
+	category = Object().Title("Category").
+		Property("name", String()).
+		Property("children", Array(Lazy(func() Parseable { return category })).Optional())
+	category.Freeze()
+	want := normalizeJSONSchema(category.JSON())
+
+	// Each generation tracks its own recursion, so concurrent ones agree
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if got := normalizeJSONSchema(category.JSON()); !reflect.DeepEqual(got, want) {
+					t.Errorf("JSON() = %v, want %v", got, want)
+					return
+				}
+				if result := category.Parse(map[string]interface{}{"name": "root"}, DefaultValidationContext()); !result.Valid {
+					t.Errorf("unexpected errors: %v", result.Errors)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClone(t *testing.T) {
+	original := String().MinLength(2).Enum([]string{"a", "b"}).Trim().Meta("owner", "team")
+	copied := original.Clone().MaxLength(5).Lowercase().Meta("owner", "other")
+
+	if original.GetMaxLength() != nil {
+		t.Error("MaxLength on the clone changed the original")
+	}
+	if len(original.normalizers) != 1 || len(copied.normalizers) != 2 {
+		t.Errorf("normalizers = %d and %d, want 1 and 2", len(original.normalizers), len(copied.normalizers))
+	}
+	if original.GetMeta()["owner"] != "team" {
+		t.Error("Meta on the clone changed the original")
+	}
+	if result := copied.Parse("  AB ", DefaultValidationContext()); result.Valid {
+		t.Error("clone lost the enum of the original")
+	}
+}
@@ -0,0 +1,577 @@
+package schema
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// GenOptions controls Generate
+type GenOptions struct {
+	// Seed makes the output reproducible: the same schema and seed always generate the
+	// same data. Zero uses a random seed.
+	Seed int64
+	// IncludeOptional generates every optional property; otherwise each one is
+	// included with probability 1/2
+	IncludeOptional bool
+	// MaxItems bounds arrays and records that set no maximum (3 when zero)
+	MaxItems int
+	// MaxStringLength bounds strings that set no maximum length (12 when zero)
+	MaxStringLength int
+	// Attempts is the number of candidates tried before giving up on producing data
+	// that parses (10 when zero)
+	Attempts int
+}
+
+// generatorMaxDepth stops runaway recursion in recursive schemas
+const generatorMaxDepth = 8
+
+// Generate returns example data that satisfies s, for tests, sandbox responses and
+// seeding. It follows the JSON Schema of s (types, bounds, patterns, formats, enums,
+// object shapes, arrays and tuples) and checks every candidate with s.Parse, trying
+// again with new random choices when a candidate fails. Constraints without a JSON
+// Schema form (signatures, refinements, $ref) may not be satisfied, in which case the
+// last candidate is returned.
+func Generate(s Parseable, opts GenOptions) interface{} {
+	if opts.MaxItems <= 0 {
+		opts.MaxItems = 3
+	}
+	if opts.MaxStringLength <= 0 {
+		opts.MaxStringLength = 12
+	}
+	if opts.Attempts <= 0 {
+		opts.Attempts = 10
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	doc := map[string]interface{}{}
+	if generator, ok := s.(JSONSchemaGenerator); ok {
+		doc = JSONSchemaWithOptions(generator, JSONSchemaOptions{})
+	}
+	g := &dataGenerator{rand: rand.New(rand.NewSource(seed)), opts: opts}
+	ctx := DefaultValidationContext()
+
+	var value interface{}
+	for attempt := 0; attempt < opts.Attempts; attempt++ {
+		value = g.value(doc, 0)
+		if s.Parse(value, ctx).Valid {
+			break
+		}
+	}
+	return value
+}
+
+// dataGenerator produces values for normalized JSON Schema nodes
+type dataGenerator struct {
+	rand *rand.Rand
+	opts GenOptions
+}
+
+// value generates a value for a schema node
+func (g *dataGenerator) value(node interface{}, depth int) interface{} {
+	schema, ok := node.(map[string]interface{})
+	if !ok || depth > generatorMaxDepth {
+		if node == false {
+			return nil
+		}
+		return g.word(1, 8)
+	}
+
+	if value, ok := schema["const"]; ok {
+		return value
+	}
+	if values, ok := schema["enum"].([]interface{}); ok && len(values) > 0 {
+		return values[g.rand.Intn(len(values))]
+	}
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		if branches, ok := schema[keyword].([]interface{}); ok && len(branches) > 0 {
+			branch := mergeSchemaNodes(schema, branches[g.rand.Intn(len(branches))], keyword)
+			return g.value(branch, depth+1)
+		}
+	}
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		merged := schema
+		for _, part := range all {
+			merged = mergeSchemaNodes(merged, part, "allOf")
+		}
+		return g.value(merged, depth+1)
+	}
+	if not, ok := schema["not"]; ok {
+		return g.notValue(not)
+	}
+
+	switch g.schemaType(schema) {
+	case "null":
+		return nil
+	case "boolean":
+		return g.rand.Intn(2) == 0
+	case "integer":
+		return g.integer(schema)
+	case "number":
+		return g.number(schema)
+	case "array":
+		return g.array(schema, depth)
+	case "object":
+		return g.object(schema, depth)
+	}
+	return g.str(schema)
+}
+
+// schemaType picks the type to generate for a schema node
+func (g *dataGenerator) schemaType(schema map[string]interface{}) string {
+	types := schemaTypes(schema["type"])
+	if len(types) > 1 {
+		// Prefer a non-null type; nullable schemas are null one time in four
+		var nonNull []string
+		for _, t := range types {
+			if t != "null" {
+				nonNull = append(nonNull, t)
+			}
+		}
+		if len(nonNull) == 0 || len(nonNull) < len(types) && g.rand.Intn(4) == 0 {
+			return "null"
+		}
+		return nonNull[g.rand.Intn(len(nonNull))]
+	}
+	if len(types) == 1 {
+		return types[0]
+	}
+	switch {
+	case schema["properties"] != nil || schema["additionalProperties"] != nil || schema["required"] != nil:
+		return "object"
+	case schema["items"] != nil || schema["prefixItems"] != nil:
+		return "array"
+	case schema["pattern"] != nil || schema["format"] != nil || schema["minLength"] != nil:
+		return "string"
+	case schema["minimum"] != nil || schema["maximum"] != nil:
+		return "number"
+	}
+	return []string{"string", "integer", "boolean"}[g.rand.Intn(3)]
+}
+
+// notValue returns a value of the first simple kind that the schema rejects
+func (g *dataGenerator) notValue(not interface{}) interface{} {
+	candidates := []interface{}{g.word(1, 8), g.rand.Intn(100), true, nil, []interface{}{}, map[string]interface{}{}}
+	data, err := json.Marshal(not)
+	if err != nil {
+		return candidates[0]
+	}
+	excluded, err := CompileJSONSchema(data)
+	if err != nil {
+		return candidates[0]
+	}
+	ctx := DefaultValidationContext()
+	for _, candidate := range candidates {
+		if !excluded.Parse(candidate, ctx).Valid {
+			return candidate
+		}
+	}
+	return candidates[0]
+}
+
+// integerFormatRanges are the ranges of the sized integer formats
+var integerFormatRanges = map[string][2]float64{
+	"int8":   {math.MinInt8, math.MaxInt8},
+	"int16":  {math.MinInt16, math.MaxInt16},
+	"int32":  {math.MinInt32, math.MaxInt32},
+	"uint8":  {0, math.MaxUint8},
+	"uint16": {0, math.MaxUint16},
+	"uint32": {0, math.MaxUint32},
+	"uint64": {0, math.MaxInt64},
+}
+
+// numberBounds returns the inclusive range of a numeric schema, defaulting to a
+// span of 100 around the bounds that are set
+func numberBounds(schema map[string]interface{}, step float64) (float64, float64) {
+	low, hasLow := schema["minimum"].(float64)
+	if exclusive, ok := schema["exclusiveMinimum"].(float64); ok && (!hasLow || exclusive >= low) {
+		low, hasLow = exclusive+step, true
+	}
+	high, hasHigh := schema["maximum"].(float64)
+	if exclusive, ok := schema["exclusiveMaximum"].(float64); ok && (!hasHigh || exclusive <= high) {
+		high, hasHigh = exclusive-step, true
+	}
+	if format, ok := schema["format"].(string); ok {
+		if limits, ok := integerFormatRanges[format]; ok {
+			if !hasLow || low < limits[0] {
+				low, hasLow = limits[0], hasLow || limits[0] == 0
+			}
+			if !hasHigh || high > limits[1] {
+				high = limits[1]
+			}
+		}
+	}
+	switch {
+	case hasLow && !hasHigh:
+		high = low + 100
+	case !hasLow && hasHigh:
+		low = math.Max(high-100, low)
+	case !hasLow && !hasHigh:
+		low, high = 0, 100
+	}
+	// Keep wide ranges small so that the values read naturally
+	if high-low > 1000 {
+		if hasLow {
+			high = low + 1000
+		} else {
+			low = high - 1000
+		}
+	}
+	return low, high
+}
+
+func (g *dataGenerator) integer(schema map[string]interface{}) interface{} {
+	low, high := numberBounds(schema, 1)
+	low, high = math.Ceil(low), math.Floor(high)
+	if multiple, ok := schema["multipleOf"].(float64); ok && multiple >= 1 {
+		first, last := math.Ceil(low/multiple), math.Floor(high/multiple)
+		if last < first {
+			return int(low)
+		}
+		return int((first + float64(g.rand.Int63n(int64(last-first)+1))) * multiple)
+	}
+	if high < low {
+		return int(low)
+	}
+	return int(low) + int(g.rand.Int63n(int64(high-low)+1))
+}
+
+func (g *dataGenerator) number(schema map[string]interface{}) interface{} {
+	low, high := numberBounds(schema, 0.01)
+	if multiple, ok := schema["multipleOf"].(float64); ok && multiple > 0 {
+		first, last := math.Ceil(low/multiple), math.Floor(high/multiple)
+		if last < first {
+			return low
+		}
+		return (first + float64(g.rand.Int63n(int64(last-first)+1))) * multiple
+	}
+	if high < low {
+		return low
+	}
+	// Two decimal places
+	value := low + g.rand.Float64()*(high-low)
+	return math.Max(low, math.Min(high, math.Round(value*100)/100))
+}
+
+// lengthBounds returns the length range for strings, arrays and objects
+func (g *dataGenerator) lengthBounds(schema map[string]interface{}, minKey, maxKey string, defaultSpan int) (int, int) {
+	low, high := 0, -1
+	if v, ok := schema[minKey].(float64); ok {
+		low = int(v)
+	}
+	if v, ok := schema[maxKey].(float64); ok {
+		high = int(v)
+	}
+	if high < 0 {
+		high = low + defaultSpan
+	}
+	if high < low {
+		high = low
+	}
+	return low, high
+}
+
+func (g *dataGenerator) str(schema map[string]interface{}) interface{} {
+	low, high := g.lengthBounds(schema, "minLength", "maxLength", g.opts.MaxStringLength)
+	if low == 0 && high > 0 {
+		low = 1 // Prefer non-empty strings
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		// Regular expressions may generate strings of the wrong length; try a few times
+		var value string
+		for i := 0; i < 20; i++ {
+			value = g.fromPattern(pattern, high)
+			if length := len([]rune(value)); length >= low && length <= high {
+				break
+			}
+		}
+		return value
+	}
+
+	format, _ := schema["format"].(string)
+	if schema["contentEncoding"] == "base64" || format == "byte" || format == "binary" {
+		format = "base64"
+	}
+	switch format {
+	case "email":
+		return g.word(3, 8) + "@example.com"
+	case "uri", "url":
+		return "https://example.com/" + g.word(3, 8)
+	case "hostname":
+		return g.word(3, 8) + ".example.com"
+	case "uuid":
+		b := make([]byte, 16)
+		g.rand.Read(b)
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	case "date", "date-time", "time":
+		moment := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(g.rand.Int63n(int64(30 * 365 * 24 * time.Hour))))
+		moment = moment.Truncate(time.Second)
+		return moment.Format(map[string]string{"date": "2006-01-02", "date-time": time.RFC3339, "time": "15:04:05"}[format])
+	case "ipv4":
+		return fmt.Sprintf("%d.%d.%d.%d", 1+g.rand.Intn(223), g.rand.Intn(256), g.rand.Intn(256), 1+g.rand.Intn(254))
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x:%x", g.rand.Intn(0x10000), 1+g.rand.Intn(0xfffe))
+	case "cidr":
+		return fmt.Sprintf("10.%d.0.0/16", g.rand.Intn(256))
+	case "mac":
+		b := make([]byte, 6)
+		g.rand.Read(b)
+		b[0] = b[0]&0xfe | 0x02 // Locally administered unicast
+		return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", b[0], b[1], b[2], b[3], b[4], b[5])
+	case "color":
+		return fmt.Sprintf("#%06x", g.rand.Intn(0x1000000))
+	case "phone":
+		return fmt.Sprintf("+1415555%04d", g.rand.Intn(10000))
+	case "password":
+		return g.password(low, high)
+	case "hex":
+		b := make([]byte, max(1, low/2+g.rand.Intn(4)))
+		g.rand.Read(b)
+		return hex.EncodeToString(b)
+	case "base64":
+		b := make([]byte, 3+g.rand.Intn(12))
+		g.rand.Read(b)
+		return base64.StdEncoding.EncodeToString(b)
+	}
+	return g.word(low, high)
+}
+
+// password returns a password with every character class, of a length within bounds
+func (g *dataGenerator) password(low, high int) string {
+	classes := []string{"abcdefghijkmnpqrstuvwxyz", "ABCDEFGHJKLMNPQRSTUVWXYZ", "23456789", "!#$%&*+-=?@^_"}
+	length := max(low, min(16, high))
+	b := make([]byte, length)
+	for i := range b {
+		class := classes[i%len(classes)]
+		b[i] = class[g.rand.Intn(len(class))]
+	}
+	g.rand.Shuffle(len(b), func(i, j int) { b[i], b[j] = b[j], b[i] })
+	return string(b)
+}
+
+// word returns random lowercase letters, with a length between low and high
+func (g *dataGenerator) word(low, high int) string {
+	length := low
+	if high > low {
+		length += g.rand.Intn(high - low + 1)
+	}
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = byte('a' + g.rand.Intn(26))
+	}
+	return string(b)
+}
+
+// fromPattern returns a string matching the regular expression pattern. Unbounded
+// repetitions repeat at most a few times.
+func (g *dataGenerator) fromPattern(pattern string, maxLength int) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	g.regexp(re.Simplify(), &b, max(1, min(maxLength, 4)))
+	return b.String()
+}
+
+func (g *dataGenerator) regexp(re *syntax.Regexp, b *strings.Builder, maxRepeat int) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && g.rand.Intn(2) == 0 {
+				r = unicode.SimpleFold(r)
+			}
+			b.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		b.WriteRune(g.classRune(re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		b.WriteByte(byte('a' + g.rand.Intn(26)))
+	case syntax.OpCapture:
+		g.regexp(re.Sub[0], b, maxRepeat)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.regexp(sub, b, maxRepeat)
+		}
+	case syntax.OpAlternate:
+		g.regexp(re.Sub[g.rand.Intn(len(re.Sub))], b, maxRepeat)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		low, high := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			low, high = 0, maxRepeat
+		case syntax.OpPlus:
+			low, high = 1, maxRepeat
+		case syntax.OpQuest:
+			low, high = 0, 1
+		}
+		if high < 0 {
+			high = low + maxRepeat
+		}
+		count := low
+		if high > low {
+			count += g.rand.Intn(high - low + 1)
+		}
+		for i := 0; i < count; i++ {
+			g.regexp(re.Sub[0], b, maxRepeat)
+		}
+	}
+	// Anchors, word boundaries and empty matches produce no characters
+}
+
+// classRune picks a rune from a character class given as inclusive ranges, preferring
+// ASCII letters and digits so that negated classes ("[^/]") stay readable
+func (g *dataGenerator) classRune(ranges []rune) rune {
+	if len(ranges) == 0 {
+		return 'a'
+	}
+	var readable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for _, r := range "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_" {
+			if r >= ranges[i] && r <= ranges[i+1] {
+				readable = append(readable, r)
+			}
+		}
+	}
+	if len(readable) > 0 {
+		return readable[g.rand.Intn(len(readable))]
+	}
+	i := 2 * g.rand.Intn(len(ranges)/2)
+	return ranges[i] + rune(g.rand.Intn(int(ranges[i+1]-ranges[i])+1))
+}
+
+func (g *dataGenerator) array(schema map[string]interface{}, depth int) interface{} {
+	low, high := g.lengthBounds(schema, "minItems", "maxItems", g.opts.MaxItems)
+	prefix, _ := schema["prefixItems"].([]interface{})
+	length := low
+	if high > low {
+		length += g.rand.Intn(high - low + 1)
+	}
+	if length < len(prefix) && (low >= len(prefix) || schema["items"] == false) {
+		length = len(prefix)
+	}
+	if schema["items"] == false && length > len(prefix) {
+		length = len(prefix)
+	}
+
+	unique := schema["uniqueItems"] == true
+	items := make([]interface{}, 0, length)
+	for len(items) < length {
+		var item interface{}
+		if len(items) < len(prefix) {
+			item = g.value(prefix[len(items)], depth+1)
+		} else {
+			item = g.value(schema["items"], depth+1)
+		}
+		if unique && len(items) >= len(prefix) {
+			// Give up on uniqueness after a few collisions; Parse reports the failure
+			for tries := 0; containsValue(items, item) && tries < 10; tries++ {
+				item = g.value(schema["items"], depth+1)
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (g *dataGenerator) object(schema map[string]interface{}, depth int) interface{} {
+	properties, _ := schema["properties"].(map[string]interface{})
+	required := stringList(schema["required"])
+	dependencies, _ := schema["dependentRequired"].(map[string]interface{})
+
+	result := make(map[string]interface{}, len(properties))
+	include := make(map[string]bool, len(properties))
+	for _, name := range required {
+		include[name] = true
+	}
+	for _, name := range sortedKeys(properties) {
+		if g.opts.IncludeOptional || g.rand.Intn(2) == 0 {
+			include[name] = true
+		}
+	}
+	for _, name := range sortedKeys(dependencies) {
+		if include[name] {
+			for _, dependency := range stringList(dependencies[name]) {
+				include[dependency] = true
+			}
+		}
+	}
+	for _, name := range sortedKeys(properties) {
+		if include[name] {
+			result[name] = g.value(properties[name], depth+1)
+		}
+	}
+
+	// Records and maps: generate entries for additional properties that have a schema
+	additional, isSchema := schema["additionalProperties"].(map[string]interface{})
+	if isSchema && len(properties) == 0 {
+		low, high := g.lengthBounds(schema, "minProperties", "maxProperties", g.opts.MaxItems)
+		count := low
+		if high > low {
+			count += g.rand.Intn(high - low + 1)
+		}
+		names, _ := schema["propertyNames"].(map[string]interface{})
+		for tries := 0; len(result) < count && tries < 10*count; tries++ {
+			key := g.word(3, 8)
+			if names != nil {
+				if name, ok := g.value(names, depth+1).(string); ok {
+					key = name
+				}
+			}
+			result[key] = g.value(additional, depth+1)
+		}
+	}
+	return result
+}
+
+// mergeSchemaNodes combines a schema with one of its branches or allOf parts: the
+// part's keywords are added to the schema without the combinator keyword
+func mergeSchemaNodes(schema map[string]interface{}, part interface{}, combinator string) map[string]interface{} {
+	merged := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		if key != combinator {
+			merged[key] = value
+		}
+	}
+	partSchema, ok := part.(map[string]interface{})
+	if !ok {
+		return merged
+	}
+	for key, value := range partSchema {
+		switch existing := merged[key].(type) {
+		case map[string]interface{}:
+			// Combine nested property maps
+			if next, ok := value.(map[string]interface{}); ok && key == "properties" {
+				combined := make(map[string]interface{}, len(existing)+len(next))
+				for name, prop := range existing {
+					combined[name] = prop
+				}
+				for name, prop := range next {
+					combined[name] = prop
+				}
+				merged[key] = combined
+				continue
+			}
+		case []interface{}:
+			if next, ok := value.([]interface{}); ok && key == "required" {
+				merged[key] = append(append([]interface{}{}, existing...), next...)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
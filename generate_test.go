@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema Parseable
+	}{
+		{"string bounds", String().MinLength(5).MaxLength(8)},
+		{"string pattern", String().Pattern(`^[A-Z]{3}-\d{2,4}$`)},
+		{"string enum", String().Enum([]string{"red", "green", "blue"})},
+		{"slug", String().Slug()},
+		{"email", Email()},
+		{"url", URL()},
+		{"uuid", UUID()},
+		{"date", Date()},
+		{"date time", DateTime()},
+		{"time", Time()},
+		{"ip", IP()},
+		{"cidr", CIDR()},
+		{"mac", MACAddress()},
+		{"color", Color()},
+		{"phone", Phone()},
+		{"hex", Hex()},
+		{"base64", Base64()},
+		{"password", Password()},
+		{"semver", Semver()},
+		{"int range", Int().Min(10).Max(20)},
+		{"int multiple", Int().Min(1).MultipleOf(5)},
+		{"int8", Int8()},
+		{"uint16", Uint16()},
+		{"number exclusive", Number().ExclusiveMin(0).ExclusiveMax(1)},
+		{"bool", Bool()},
+		{"literal", Literal("fixed")},
+		{"nullable", String().Nullable()},
+		{"array", Array(Int().Min(0)).MinItems(2).MaxItems(5)},
+		{"unique array", Array(Int().Min(0).Max(50)).MinItems(3).UniqueItems()},
+		{"tuple", Tuple(String(), Int(), Bool())},
+		{"record", Record(String().Pattern(`^[a-z]+$`), Int()).MinProperties(1)},
+		{"union", Union(String().Email(), Int().Min(0))},
+		{"object", Object().
+			Property("id", UUID()).
+			Property("name", String().MinLength(1).MaxLength(40)).
+			Property("age", Int().Min(0).Max(130).Optional()).
+			Property("tags", Array(String().Slug()).Optional()).
+			Property("address", Object().
+				Property("city", String()).
+				Property("zip", String().Pattern(`^\d{5}$`)))},
+		{"dependent required", Object().
+			Property("card", String().Optional()).
+			Property("cvv", String().Pattern(`^\d{3}$`).Optional()).
+			DependentRequired(map[string][]string{"card": {"cvv"}})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(1); seed <= 20; seed++ {
+				value := Generate(tt.schema, GenOptions{Seed: seed})
+				if result := tt.schema.Parse(value, DefaultValidationContext()); !result.Valid {
+					t.Fatalf("seed %d: generated %#v is invalid: %v", seed, value, result.Errors)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_Options(t *testing.T) {
+	user := Object().
+		Property("name", String()).
+		Property("nickname", String().Optional()).
+		Property("tags", Array(String()).Optional())
+
+	first := Generate(user, GenOptions{Seed: 42})
+	second := Generate(user, GenOptions{Seed: 42})
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("same seed generated %#v and %#v", first, second)
+	}
+
+	for seed := int64(1); seed <= 10; seed++ {
+		value := Generate(user, GenOptions{Seed: seed, IncludeOptional: true, MaxItems: 1}).(map[string]interface{})
+		if _, ok := value["nickname"]; !ok {
+			t.Fatalf("seed %d: optional property missing from %#v", seed, value)
+		}
+		if tags := value["tags"].([]interface{}); len(tags) > 1 {
+			t.Fatalf("seed %d: %d tags, want at most 1", seed, len(tags))
+		}
+	}
+}
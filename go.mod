@@ -2,4 +2,14 @@ module github.com/nyxstack/schema
 
 go 1.24.2
 
-require github.com/nyxstack/i18n v1.0.0
+require (
+	github.com/nyxstack/i18n v1.0.0
+	github.com/pelletier/go-toml/v2 v2.2.4
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/kr/pretty v0.3.1 // indirect
+	github.com/rogpeppe/go-internal v1.13.1 // indirect
+	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
+)
@@ -0,0 +1,288 @@
+// Package grpcschema validates protobuf messages with schemas in gRPC servers.
+// FromDescriptor builds a schema from a message descriptor, and the interceptors
+// validate every request with the schema registered for its message type, rejecting
+// invalid ones with codes.InvalidArgument and a google.rpc.BadRequest detail.
+//
+//	validator := grpcschema.NewValidator().
+//	    Register(&pb.CreateUserRequest{}, schema.Object().
+//	        Property("email", schema.String().Email()).
+//	        Property("age", schema.Int().Min(18).Optional()))
+//
+//	server := grpc.NewServer(
+//	    grpc.UnaryInterceptor(grpcschema.UnaryServerInterceptor(validator)),
+//	    grpc.StreamInterceptor(grpcschema.StreamServerInterceptor(validator)),
+//	)
+//
+// Messages are validated as the maps MessageValue returns, keyed by proto field name.
+package grpcschema
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nyxstack/schema"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FromDescriptor builds an object schema checking the field types of a message:
+// integers and floats become Int, Uint64 and Number schemas, enums an Enum of their
+// value names, bytes a Binary schema of their base64 encoding, repeated fields arrays,
+// map fields records and message fields nested objects (recursive messages reuse the
+// schema being built). Every field is optional, since proto3 cannot tell a field set
+// to its zero value from an unset one. Add constraints by replacing properties, e.g.
+// FromDescriptor(d).Property("email", schema.String().Email()).
+func FromDescriptor(desc protoreflect.MessageDescriptor) *schema.ObjectSchema {
+	return messageSchema(desc, map[protoreflect.FullName]*schema.ObjectSchema{})
+}
+
+// messageSchema builds the schema of a message, reusing the schemas in built
+func messageSchema(desc protoreflect.MessageDescriptor, built map[protoreflect.FullName]*schema.ObjectSchema) *schema.ObjectSchema {
+	if s, ok := built[desc.FullName()]; ok {
+		return s
+	}
+	// Optional because the schema of a message type is shared by the fields holding it,
+	// which are optional
+	s := schema.Object().Title(string(desc.Name())).Optional()
+	built[desc.FullName()] = s
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		var fieldSchema schema.Parseable
+		switch {
+		case field.IsMap():
+			fieldSchema = schema.Record(schema.String(), valueSchema(field.MapValue(), built)).Optional()
+		case field.IsList():
+			fieldSchema = schema.Array(valueSchema(field, built)).Optional()
+		default:
+			fieldSchema = optional(valueSchema(field, built))
+		}
+		s.Property(string(field.Name()), fieldSchema)
+	}
+	return s
+}
+
+// valueSchema builds the schema of a single value of a field
+func valueSchema(field protoreflect.FieldDescriptor, built map[protoreflect.FullName]*schema.ObjectSchema) schema.Parseable {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return schema.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return schema.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return schema.Int().Min(0)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return schema.Uint64()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return schema.Number()
+	case protoreflect.StringKind:
+		return schema.String()
+	case protoreflect.BytesKind:
+		return schema.Binary()
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		return schema.Enum(names...)
+	default: // MessageKind, GroupKind
+		return messageSchema(field.Message(), built)
+	}
+}
+
+// optional makes a field schema optional
+func optional(s schema.Parseable) schema.Parseable {
+	switch v := s.(type) {
+	case *schema.BoolSchema:
+		return v.Optional()
+	case *schema.IntSchema:
+		return v.Optional()
+	case *schema.Uint64Schema:
+		return v.Optional()
+	case *schema.NumberSchema:
+		return v.Optional()
+	case *schema.StringSchema:
+		return v.Optional()
+	case *schema.EnumSchema[string]:
+		return v.Optional()
+	}
+	return s // Binary schemas are optional, message schemas are built optional
+}
+
+// MessageValue returns the value schemas validate a message as: a map of its
+// populated fields keyed by proto field name, holding int for signed and 32-bit
+// integers, uint64 for unsigned 64-bit integers, float64 for floats, the value name
+// for enums, base64 for bytes, slices for repeated fields and maps keyed by the
+// string form of the key for map fields
+func MessageValue(msg protoreflect.Message) map[string]interface{} {
+	value := make(map[string]interface{})
+	msg.Range(func(field protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		value[string(field.Name())] = fieldValue(field, v)
+		return true
+	})
+	return value
+}
+
+// fieldValue converts the value of a populated field
+func fieldValue(field protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch {
+	case field.IsMap():
+		entries := make(map[string]interface{}, v.Map().Len())
+		v.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			entries[key.String()] = singularValue(field.MapValue(), value)
+			return true
+		})
+		return entries
+	case field.IsList():
+		list := v.List()
+		items := make([]interface{}, list.Len())
+		for i := range items {
+			items[i] = singularValue(field, list.Get(i))
+		}
+		return items
+	}
+	return singularValue(field, v)
+}
+
+// singularValue converts a single value of a field
+func singularValue(field protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return int(v.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return int(v.Uint())
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.StringKind:
+		return v.String()
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	case protoreflect.EnumKind:
+		if value := field.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return int(v.Enum())
+	default: // MessageKind, GroupKind
+		return MessageValue(v.Message())
+	}
+}
+
+// Validator validates messages with the schemas registered for their types, and with
+// schemas built by FromDescriptor for the other types
+type Validator struct {
+	mu      sync.RWMutex
+	schemas map[protoreflect.FullName]schema.Parseable
+}
+
+// NewValidator creates a validator without registered schemas
+func NewValidator() *Validator {
+	return &Validator{schemas: make(map[protoreflect.FullName]schema.Parseable)}
+}
+
+// Register sets the schema validating the messages of the type of msg
+func (v *Validator) Register(msg proto.Message, s schema.Parseable) *Validator {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.schemas[msg.ProtoReflect().Descriptor().FullName()] = s
+	return v
+}
+
+// Schema returns the schema validating the messages of a type, building it from the
+// descriptor the first time when none is registered
+func (v *Validator) Schema(desc protoreflect.MessageDescriptor) schema.Parseable {
+	v.mu.RLock()
+	s, ok := v.schemas[desc.FullName()]
+	v.mu.RUnlock()
+	if ok {
+		return s
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if s, ok := v.schemas[desc.FullName()]; ok {
+		return s
+	}
+	built := FromDescriptor(desc).Freeze()
+	v.schemas[desc.FullName()] = built
+	return built
+}
+
+// Validate returns nil when msg is valid, and otherwise a status error with code
+// InvalidArgument and a google.rpc.BadRequest detail listing a field violation per
+// validation error
+func (v *Validator) Validate(ctx context.Context, msg proto.Message) error {
+	reflected := msg.ProtoReflect()
+	result := v.Schema(reflected.Descriptor()).Parse(MessageValue(reflected), schema.DefaultValidationContext())
+	if result.Valid {
+		return nil
+	}
+	return invalidArgument(reflected.Descriptor(), result.Errors)
+}
+
+// invalidArgument builds the status error reporting the validation errors of a message
+func invalidArgument(desc protoreflect.MessageDescriptor, errors []schema.ValidationError) error {
+	badRequest := &errdetails.BadRequest{}
+	for _, err := range errors {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       err.Path.DotPath(),
+			Description: err.Message,
+			Reason:      strings.ToUpper(string(err.Code)),
+		})
+	}
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("invalid %s: %s", desc.FullName(), schema.ValidationErrors(errors).Format(schema.ErrorFormatList)))
+	if detailed, err := st.WithDetails(badRequest); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// UnaryServerInterceptor validates the request messages of unary calls
+func UnaryServerInterceptor(v *Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := v.Validate(ctx, msg); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates every message received on streaming calls
+func StreamServerInterceptor(v *Validator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: stream, validator: v})
+	}
+}
+
+// validatingStream validates the messages received on a server stream
+type validatingStream struct {
+	grpc.ServerStream
+	validator *Validator
+}
+
+// RecvMsg receives a message and validates it
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		return s.validator.Validate(s.Context(), msg)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package grpcschema
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nyxstack/schema"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// userDescriptor describes
+//
+//	message User {
+//	  string name = 1;
+//	  int32 age = 2;
+//	  repeated string tags = 3;
+//	  Role role = 4;
+//	  User manager = 5;
+//	  map<string, int64> scores = 6;
+//	}
+//	enum Role { ROLE_UNSPECIFIED = 0; ROLE_ADMIN = 1; }
+func userDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	field := func(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number), Type: kind.Enum(), Label: label.Enum()}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	optional, repeated := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("user.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Role"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("ROLE_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("ROLE_ADMIN"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+				field("age", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, optional, ""),
+				field("tags", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, repeated, ""),
+				field("role", 4, descriptorpb.FieldDescriptorProto_TYPE_ENUM, optional, ".test.Role"),
+				field("manager", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional, ".test.User"),
+				field("scores", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated, ".test.User.ScoresEntry"),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("ScoresEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+					field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional, ""),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+	}
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd.Messages().ByName("User")
+}
+
+// newUser returns a User message with the given fields set
+func newUser(desc protoreflect.MessageDescriptor, set func(m *dynamicpb.Message, field func(string) protoreflect.FieldDescriptor)) *dynamicpb.Message {
+	m := dynamicpb.NewMessage(desc)
+	set(m, func(name string) protoreflect.FieldDescriptor { return desc.Fields().ByName(protoreflect.Name(name)) })
+	return m
+}
+
+func TestMessageValue(t *testing.T) {
+	desc := userDescriptor(t)
+	user := newUser(desc, func(m *dynamicpb.Message, field func(string) protoreflect.FieldDescriptor) {
+		m.Set(field("name"), protoreflect.ValueOfString("Ann"))
+		m.Set(field("age"), protoreflect.ValueOfInt32(30))
+		m.Set(field("role"), protoreflect.ValueOfEnum(1))
+		tags := m.Mutable(field("tags")).List()
+		tags.Append(protoreflect.ValueOfString("a"))
+		scores := m.Mutable(field("scores")).Map()
+		scores.Set(protoreflect.ValueOfString("math").MapKey(), protoreflect.ValueOfInt64(9))
+		manager := m.Mutable(field("manager")).Message()
+		manager.Set(field("name"), protoreflect.ValueOfString("Bob"))
+	})
+
+	value := MessageValue(user)
+	want := map[string]interface{}{
+		"name":    "Ann",
+		"age":     30,
+		"role":    "ROLE_ADMIN",
+		"tags":    []interface{}{"a"},
+		"scores":  map[string]interface{}{"math": 9},
+		"manager": map[string]interface{}{"name": "Bob"},
+	}
+	if !reflect.DeepEqual(value, want) {
+		t.Errorf("MessageValue() = %#v, want %#v", value, want)
+	}
+
+	if result := FromDescriptor(desc).Parse(value, schema.DefaultValidationContext()); !result.Valid {
+		t.Errorf("FromDescriptor() rejected the message: %v", result.Errors)
+	}
+	if result := FromDescriptor(desc).Parse(map[string]interface{}{"role": "ROLE_OWNER"}, schema.DefaultValidationContext()); result.Valid {
+		t.Error("FromDescriptor() accepted an unknown enum value")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	desc := userDescriptor(t)
+	validator := NewValidator().Register(dynamicpb.NewMessage(desc), schema.Object().
+		Property("name", schema.String().MinLength(2)).
+		Property("age", schema.Int().Min(18).Optional()).
+		AdditionalProperties(true))
+	interceptor := UnaryServerInterceptor(validator)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	valid := newUser(desc, func(m *dynamicpb.Message, field func(string) protoreflect.FieldDescriptor) {
+		m.Set(field("name"), protoreflect.ValueOfString("Ann"))
+	})
+	if resp, err := interceptor(context.Background(), valid, &grpc.UnaryServerInfo{}, handler); err != nil || resp != "ok" {
+		t.Fatalf("valid request: %v, %v", resp, err)
+	}
+
+	invalid := newUser(desc, func(m *dynamicpb.Message, field func(string) protoreflect.FieldDescriptor) {
+		m.Set(field("name"), protoreflect.ValueOfString("A"))
+		m.Set(field("age"), protoreflect.ValueOfInt32(12))
+	})
+	_, err := interceptor(context.Background(), invalid, &grpc.UnaryServerInfo{}, handler)
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %v, want InvalidArgument", st.Code())
+	}
+	violations := map[string]bool{}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				violations[violation.Field+" "+violation.Reason] = true
+			}
+		}
+	}
+	for _, want := range []string{"name MIN_LENGTH", "age MINIMUM"} {
+		if !violations[want] {
+			t.Errorf("field violations = %v, want %q", violations, want)
+		}
+	}
+}
+
+// recvStream is a server stream receiving a single message
+type recvStream struct {
+	grpc.ServerStream
+	msg proto.Message
+}
+
+func (s *recvStream) Context() context.Context { return context.Background() }
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.msg)
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	desc := userDescriptor(t)
+	validator := NewValidator().Register(dynamicpb.NewMessage(desc), schema.Object().
+		Property("name", schema.String()).
+		AdditionalProperties(true))
+	interceptor := StreamServerInterceptor(validator)
+
+	stream := &recvStream{msg: dynamicpb.NewMessage(desc)}
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		return stream.RecvMsg(dynamicpb.NewMessage(desc))
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RecvMsg() error = %v, want InvalidArgument", err)
+	}
+}
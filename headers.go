@@ -0,0 +1,227 @@
+package schema
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for header validation
+var (
+	headersTypeError      = i18n.S("value must be HTTP headers")
+	cookiesTypeError      = i18n.S("value must be HTTP cookies")
+	bearerTokenError      = i18n.S("value must be a Bearer token")
+	entityTagError        = i18n.S("value must be an entity tag")
+	acceptMediaRangeError = i18n.S("value must be a media range")
+)
+
+var (
+	// bearerTokenPattern matches the credentials of the Bearer scheme (RFC 6750)
+	bearerTokenPattern = `^[Bb]earer [A-Za-z0-9\-._~+/]+=*$`
+	// entityTagPattern matches strong and weak entity tags (RFC 9110)
+	entityTagPattern = `^(W/)?"[\x21\x23-\x7E]*"$`
+	// mediaRangePattern matches an element of an Accept list: a media type, "type/*"
+	// or "*/*", with parameters such as q=0.8
+	mediaRangePattern = `^(\*/\*|[A-Za-z0-9!#$&^_.+-]+/(\*|[A-Za-z0-9!#$&^_.+-]+))(\s*;\s*[A-Za-z0-9!#$&^_.+-]+=([A-Za-z0-9!#$&^_.+-]+|"[^"]*"))*$`
+)
+
+// BearerToken creates a string schema for Authorization headers with a Bearer token
+// ("Bearer mF_9.B5f-4.1JqM")
+func BearerToken() *StringSchema {
+	return String().Pattern(bearerTokenPattern, bearerTokenError)
+}
+
+// ETag creates a string schema for entity tags such as `"xyzzy"` or `W/"xyzzy"`, the
+// values of ETag headers
+func ETag() *StringSchema {
+	return String().Pattern(entityTagPattern, entityTagError)
+}
+
+// AcceptList creates an array schema for list headers of media ranges such as Accept
+// ("text/html, application/*;q=0.9, */*;q=0.8"), with one item per media range
+func AcceptList() *ArraySchema {
+	return Array(String().Pattern(mediaRangePattern, acceptMediaRangeError))
+}
+
+// HeadersSchema validates HTTP headers against the schemas of a shape. Header names
+// are matched case-insensitively, and headers that are not in the shape are ignored.
+type HeadersSchema struct {
+	object *ObjectSchema
+}
+
+// Headers creates a schema validating http.Header values (or *http.Request values, for
+// their headers). The parsed value is a map keyed by the names used in shape:
+//
+//	schema.Headers(schema.Shape{
+//	    "Authorization": schema.BearerToken(),
+//	    "If-None-Match": schema.Array(schema.ETag()).Optional(),
+//	    "X-Request-Id":  schema.String().MaxLength(64).Optional(),
+//	})
+//
+// Headers whose property is an array are split into their comma-separated elements,
+// over all of their lines. Other headers sent on several lines are joined with ", ",
+// as HTTP defines. Values are coerced to the property types.
+func Headers(shape Shape) *HeadersSchema {
+	return &HeadersSchema{object: shape.AsObject()}
+}
+
+// Object returns the object schema the headers are validated with, keyed by the names
+// of the shape
+func (s *HeadersSchema) Object() *ObjectSchema {
+	return s.object
+}
+
+// Children returns the object schema the headers are validated with
+func (s *HeadersSchema) Children() []SchemaChild {
+	return sameValueChildren(s.object)
+}
+
+// Parse validates http.Header, map[string][]string or *http.Request values
+func (s *HeadersSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	var header http.Header
+	switch v := value.(type) {
+	case http.Header:
+		header = v
+	case map[string][]string:
+		header = v
+	case *http.Request:
+		header = v.Header
+	default:
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, headersTypeError(ctx.Locale), CodeInvalidType)}}
+	}
+
+	lines := make(map[string][]string, len(header))
+	for name, values := range header {
+		key := strings.ToLower(name)
+		lines[key] = append(lines[key], values...)
+	}
+	return parseHTTPValues(s.object, ctx, func(name string) []string { return lines[strings.ToLower(name)] })
+}
+
+// JSON generates the JSON Schema of the object the headers are validated with
+func (s *HeadersSchema) JSON() map[string]interface{} {
+	return s.object.JSON()
+}
+
+// CookiesSchema validates HTTP cookies against the schemas of a shape. Cookie names
+// are matched case-insensitively, preferring exact matches, and cookies that are not
+// in the shape are ignored.
+type CookiesSchema struct {
+	object *ObjectSchema
+}
+
+// Cookies creates a schema validating []*http.Cookie values (or *http.Request values,
+// for their cookies). The parsed value is a map keyed by the names used in shape:
+//
+//	schema.Cookies(schema.Shape{
+//	    "session": schema.String().MinLength(32),
+//	    "theme":   schema.Enum("light", "dark").Optional(),
+//	})
+//
+// Cookies sent several times, for different paths, fill array properties; other
+// properties take the first one, which has the most specific path. Values are
+// coerced to the property types.
+func Cookies(shape Shape) *CookiesSchema {
+	return &CookiesSchema{object: shape.AsObject()}
+}
+
+// Object returns the object schema the cookies are validated with, keyed by the names
+// of the shape
+func (s *CookiesSchema) Object() *ObjectSchema {
+	return s.object
+}
+
+// Children returns the object schema the cookies are validated with
+func (s *CookiesSchema) Children() []SchemaChild {
+	return sameValueChildren(s.object)
+}
+
+// Parse validates []*http.Cookie or *http.Request values
+func (s *CookiesSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	var cookies []*http.Cookie
+	switch v := value.(type) {
+	case []*http.Cookie:
+		cookies = v
+	case *http.Request:
+		cookies = v.Cookies()
+	default:
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, cookiesTypeError(ctx.Locale), CodeInvalidType)}}
+	}
+
+	return parseHTTPValues(s.object, ctx, func(name string) []string {
+		var exact, folded []string
+		for _, cookie := range cookies {
+			switch {
+			case cookie.Name == name:
+				exact = append(exact, cookie.Value)
+			case strings.EqualFold(cookie.Name, name):
+				folded = append(folded, cookie.Value)
+			}
+		}
+		if len(exact) > 0 {
+			return exact
+		}
+		return folded
+	})
+}
+
+// JSON generates the JSON Schema of the object the cookies are validated with
+func (s *CookiesSchema) JSON() map[string]interface{} {
+	return s.object.JSON()
+}
+
+// parseHTTPValues validates the values that lookup returns for each property of
+// object, with coercion enabled
+func parseHTTPValues(object *ObjectSchema, ctx *ValidationContext, lookup func(name string) []string) ParseResult {
+	value := make(map[string]interface{}, len(object.properties))
+	for name, property := range object.properties {
+		values := lookup(name)
+		if len(values) == 0 {
+			continue
+		}
+		if _, isArray := property.Schema.(*ArraySchema); isArray {
+			items := make([]interface{}, 0, len(values))
+			for _, line := range values {
+				for _, item := range splitHeaderList(line) {
+					items = append(items, item)
+				}
+			}
+			value[name] = items
+			continue
+		}
+		value[name] = strings.Join(values, ", ")
+	}
+
+	coercing := *ctx
+	coercing.Coerce = true
+	return object.Parse(value, &coercing)
+}
+
+// splitHeaderList splits a comma-separated header value into its trimmed, non-empty
+// elements, ignoring commas inside quoted strings
+func splitHeaderList(line string) []string {
+	var items []string
+	start, quoted := 0, false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				if item := strings.TrimSpace(line[start:i]); item != "" {
+					items = append(items, item)
+				}
+				start = i + 1
+			}
+		}
+	}
+	if item := strings.TrimSpace(line[start:]); item != "" {
+		items = append(items, item)
+	}
+	return items
+}
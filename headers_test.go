@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestHeaders(t *testing.T) {
+	headers := Headers(Shape{
+		"Authorization":  BearerToken(),
+		"If-None-Match":  Array(ETag()).Optional(),
+		"Accept":         AcceptList().Optional(),
+		"x-request-id":   String().MaxLength(64).Optional(),
+		"Content-Length": Int().Min(0).Optional(),
+		"X-Tags":         String().Optional(),
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer mF_9.B5f-4.1JqM")
+	r.Header.Add("If-None-Match", `"xyzzy", W/"r2d2,xxxx"`)
+	r.Header.Add("If-None-Match", `"c3piozzzz"`)
+	r.Header.Set("Accept", "text/html, application/*;q=0.9, */*;q=0.8")
+	r.Header.Set("X-Request-ID", "123e4567-e89b-12d3-a456-426614174000")
+	r.Header.Set("Content-Length", "42")
+	r.Header.Add("X-Tags", "a")
+	r.Header.Add("X-Tags", "b")
+	r.Header.Set("X-Unknown", "ignored")
+
+	result := headers.Parse(r, DefaultValidationContext())
+	if !result.Valid {
+		t.Fatalf("Parse() errors = %v", result.Errors)
+	}
+	want := map[string]interface{}{
+		"Authorization":  "Bearer mF_9.B5f-4.1JqM",
+		"If-None-Match":  []interface{}{`"xyzzy"`, `W/"r2d2,xxxx"`, `"c3piozzzz"`},
+		"Accept":         []interface{}{"text/html", "application/*;q=0.9", "*/*;q=0.8"},
+		"x-request-id":   "123e4567-e89b-12d3-a456-426614174000",
+		"Content-Length": 42,
+		"X-Tags":         "a, b",
+	}
+	if !reflect.DeepEqual(result.Value, want) {
+		t.Errorf("Parse() = %#v, want %#v", result.Value, want)
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		path   string
+	}{
+		{"missing authorization", http.Header{}, "Authorization"},
+		{"basic credentials", http.Header{"Authorization": {"Basic dXNlcjpwYXNz"}}, "Authorization"},
+		{"unquoted etag", http.Header{"Authorization": {"Bearer x"}, "If-None-Match": {"xyzzy"}}, "If-None-Match[0]"},
+		{"invalid media range", http.Header{"Authorization": {"Bearer x"}, "Accept": {"html"}}, "Accept[0]"},
+		{"non-canonical keys", map[string][]string{"authorization": {"Bearer x"}, "content-length": {"-1"}}, "Content-Length"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := headers.Parse(tt.header, DefaultValidationContext())
+			if _, ok := ValidationErrors(result.Errors).FirstFor(tt.path); result.Valid || !ok {
+				t.Errorf("Parse() errors = %v, want an error at %s", result.Errors, tt.path)
+			}
+		})
+	}
+
+	if result := headers.Parse("Bearer x", DefaultValidationContext()); result.Valid || result.Errors[0].Code != CodeInvalidType {
+		t.Errorf("Parse() of a string = %v", result.Errors)
+	}
+}
+
+func TestCookies(t *testing.T) {
+	cookies := Cookies(Shape{
+		"session": String().MinLength(8),
+		"theme":   Enum("light", "dark").Optional(),
+		"visits":  Int().Optional(),
+		"prefs":   Array(String()).Optional(),
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Cookie", "Session=folded; session=abcdefgh; THEME=dark; visits=3; prefs=a; prefs=b; other=x")
+	result := cookies.Parse(r, DefaultValidationContext())
+	if !result.Valid {
+		t.Fatalf("Parse() errors = %v", result.Errors)
+	}
+	want := map[string]interface{}{"session": "abcdefgh", "theme": "dark", "visits": 3, "prefs": []interface{}{"a", "b"}}
+	if !reflect.DeepEqual(result.Value, want) {
+		t.Errorf("Parse() = %#v, want %#v", result.Value, want)
+	}
+
+	result = cookies.Parse([]*http.Cookie{{Name: "session", Value: "short"}, {Name: "theme", Value: "blue"}}, DefaultValidationContext())
+	for _, path := range []string{"session", "theme"} {
+		if _, ok := ValidationErrors(result.Errors).FirstFor(path); !ok {
+			t.Errorf("Parse() errors = %v, want an error at %s", result.Errors, path)
+		}
+	}
+}
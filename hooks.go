@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Hooks receives telemetry about parses, to measure which schemas are slow and how
+// often each field fails. Every schema calls the hooks when it parses a value,
+// including the schemas of nested properties and items, so they must be cheap and
+// safe for concurrent use. Either hook may be nil.
+//
+// schemaType is the name of the schema type without its "Schema" suffix ("String",
+// "Object", "Email", "Enum", ...) and path the position of the value, as in errors.
+type Hooks struct {
+	OnParseStart func(ctx context.Context, schemaType string, path Path)
+	OnParseEnd   func(ctx context.Context, schemaType string, path Path, duration time.Duration, errorCount int)
+}
+
+// WithHooks sets the telemetry hooks called by the schemas parsed with this context
+func (vc *ValidationContext) WithHooks(hooks *Hooks) *ValidationContext {
+	vc.Hooks = hooks
+	return vc
+}
+
+// parseStart records the start of a parse for the hooks
+type parseStart struct {
+	time   time.Time
+	hooked bool
+}
+
+// startParse calls OnParseStart for a parse of s and records it for Explain. Schemas
+// call it before parsing, so parses without hooks only pay for the nil checks.
+func (vc *ValidationContext) startParse(s Parseable) parseStart {
+	if vc == nil || vc.Hooks == nil && vc.explain == nil {
+		return parseStart{}
+	}
+	if vc.explain != nil {
+		vc.explain.push(s, vc.path())
+	}
+	if vc.Hooks != nil && vc.Hooks.OnParseStart != nil {
+		vc.Hooks.OnParseStart(vc.goContext(), schemaTypeName(s), vc.path())
+	}
+	return parseStart{time: time.Now(), hooked: true}
+}
+
+// endParse calls OnParseEnd for a parse of value by s started with startParse
+func (vc *ValidationContext) endParse(start parseStart, s Parseable, value interface{}, result ParseResult) {
+	if !start.hooked {
+		return
+	}
+	if vc.explain != nil {
+		vc.explain.pop(s, value, result)
+	}
+	if vc.Hooks != nil && vc.Hooks.OnParseEnd != nil {
+		vc.Hooks.OnParseEnd(vc.goContext(), schemaTypeName(s), vc.path(), time.Since(start.time), len(result.Errors))
+	}
+}
+
+// goContext returns the Go context of the validation (context.Background when unset)
+func (vc *ValidationContext) goContext() context.Context {
+	if vc.Ctx == nil {
+		return context.Background()
+	}
+	return vc.Ctx
+}
+
+// schemaTypeName returns the name of the type of s without its "Schema" suffix and
+// type parameters ("Enum" for *EnumSchema[string])
+func schemaTypeName(s Parseable) string {
+	t := reflect.TypeOf(s)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	return strings.TrimSuffix(name, "Schema")
+}
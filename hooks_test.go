@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHooks(t *testing.T) {
+	type event struct {
+		kind       string
+		schemaType string
+		path       string
+		errorCount int
+	}
+	var mu sync.Mutex
+	var events []event
+	var durations []time.Duration
+	type key struct{}
+	hooks := &Hooks{
+		OnParseStart: func(ctx context.Context, schemaType string, path Path) {
+			if ctx.Value(key{}) != "request" {
+				t.Error("hooks should receive the Go context of the validation")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event{"start", schemaType, path.DotPath(), 0})
+		},
+		OnParseEnd: func(ctx context.Context, schemaType string, path Path, duration time.Duration, errorCount int) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event{"end", schemaType, path.DotPath(), errorCount})
+			durations = append(durations, duration)
+		},
+	}
+
+	// A single property keeps the order of the events deterministic
+	order := Object().Property("items", Array(Enum("a", "b")))
+	ctx := DefaultValidationContext().
+		WithContext(context.WithValue(context.Background(), key{}, "request")).
+		WithHooks(hooks)
+
+	order.Parse(map[string]interface{}{"items": []interface{}{"a", "c"}}, ctx)
+
+	want := []event{
+		{"start", "Object", "", 0},
+		{"start", "Array", "items", 0},
+		{"start", "Enum", "items[0]", 0},
+		{"end", "Enum", "items[0]", 0},
+		{"start", "Enum", "items[1]", 0},
+		{"end", "Enum", "items[1]", 1},
+		{"end", "Array", "items", 2},
+		{"end", "Object", "", 3},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v\nwant     %v", events, want)
+	}
+	for _, d := range durations {
+		if d < 0 {
+			t.Errorf("negative duration %v", d)
+		}
+	}
+
+	// Either hook may be nil
+	ctx = DefaultValidationContext().WithHooks(&Hooks{})
+	if !String().Parse("x", ctx).Valid {
+		t.Error("parse with empty hooks failed")
+	}
+}
+
+func TestSchemaTypeName(t *testing.T) {
+	tests := []struct {
+		schema Parseable
+		want   string
+	}{
+		{String(), "String"},
+		{Int64(), "Int64"},
+		{Enum("a"), "Enum"},
+		{MapOf[string, int](String(), Int()), "Map"},
+		{Lazy(func() Parseable { return String() }), "Lazy"},
+	}
+	for _, tt := range tests {
+		if got := schemaTypeName(tt.schema); got != tt.want {
+			t.Errorf("schemaTypeName(%T) = %q, want %q", tt.schema, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package httpbind decodes HTTP request data, validates it against a schema and
+// reports failures as structured errors that can be written straight back as a
+// JSON response.
+//
+//	func createUser(w http.ResponseWriter, r *http.Request) {
+//	    body, err := httpbind.BindJSON(createUserSchema, r)
+//	    if err != nil {
+//	        httpbind.WriteError(w, err)
+//	        return
+//	    }
+//	    ...
+//	}
+package httpbind
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/nyxstack/schema"
+)
+
+// MaxBodyBytes limits the size of request bodies read by BindJSON and BindForm
+const MaxBodyBytes = 10 << 20
+
+// FieldError describes one validation failure of a request
+type FieldError struct {
+	Field   string           `json:"field"` // Dot path of the failing value ("" for the value itself)
+	Message string           `json:"message"`
+	Code    schema.ErrorCode `json:"code"`
+}
+
+// Error is returned when a request cannot be decoded or fails validation. Status is
+// 422 for validation failures, 400 for malformed input, 413 for a body over
+// MaxBodyBytes and 415 for an unsupported content type. It marshals to {"status": ..., "message": ..., "errors": [...]}.
+type Error struct {
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if len(e.Errors) == 0 {
+		return e.Message
+	}
+	parts := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		if fieldErr.Field == "" {
+			parts[i] = fieldErr.Message
+		} else {
+			parts[i] = fieldErr.Field + ": " + fieldErr.Message
+		}
+	}
+	return e.Message + ": " + strings.Join(parts, "; ")
+}
+
+// WriteError writes err as a JSON response. Errors returned by the Bind functions use
+// their own status; any other error is written as a 500 without exposing its message.
+func WriteError(w http.ResponseWriter, err error) {
+	var bindErr *Error
+	if !errors.As(err, &bindErr) {
+		bindErr = &Error{Status: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError)}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(bindErr.Status)
+	json.NewEncoder(w).Encode(bindErr)
+}
+
+// BindJSON decodes the JSON request body and validates it against s. The body must be
+// a single JSON value; an empty body is treated as nil.
+func BindJSON(s schema.Parseable, r *http.Request) (interface{}, error) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return nil, &Error{
+				Status:  http.StatusUnsupportedMediaType,
+				Message: fmt.Sprintf("unsupported content type %q, expected application/json", contentType),
+			}
+		}
+	}
+
+	var value interface{}
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(nil, r.Body, MaxBodyBytes)
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&value); err != nil && err != io.EOF {
+			return nil, bodyError("malformed JSON body", err)
+		}
+		if decoder.More() {
+			return nil, &Error{Status: http.StatusBadRequest, Message: "malformed JSON body: unexpected data after the top-level value"}
+		}
+	}
+
+	return validate(s, value, newContext(r))
+}
+
+// BindQuery validates the URL query parameters against s. Keys are mapped onto the
+// schema as described by schema.ParseURLValues (tags[]=a, user.name=bob, ...) and
+// scalar strings are coerced to the property types.
+func BindQuery(s *schema.ObjectSchema, r *http.Request) (map[string]interface{}, error) {
+	return validateValues(s, r.URL.Query(), newContext(r))
+}
+
+// BindForm validates an application/x-www-form-urlencoded or multipart/form-data body
+// against s, with the same matching and coercion rules as BindQuery. Query
+// parameters are not included.
+func BindForm(s *schema.ObjectSchema, r *http.Request) (map[string]interface{}, error) {
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(nil, r.Body, MaxBodyBytes)
+	}
+
+	var err error
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		err = r.ParseMultipartForm(MaxBodyBytes)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		return nil, bodyError("malformed form body", err)
+	}
+
+	return validateValues(s, r.PostForm, newContext(r))
+}
+
+// BindPath validates path parameters, as extracted by a router, against s. Values are
+// coerced to the property types.
+func BindPath(s *schema.ObjectSchema, params map[string]string) (map[string]interface{}, error) {
+	value := make(map[string]interface{}, len(params))
+	for name, param := range params {
+		value[name] = param
+	}
+	return validateObject(s, value, schema.DefaultValidationContext().WithCoercion())
+}
+
+// bodyError converts an error reading a request body into an *Error: 413 when the
+// body is over MaxBodyBytes, 400 otherwise
+func bodyError(message string, err error) *Error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return &Error{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: fmt.Sprintf("request body is larger than the limit of %d bytes", tooLarge.Limit),
+		}
+	}
+	return &Error{Status: http.StatusBadRequest, Message: message + ": " + err.Error()}
+}
+
+// newContext creates the validation context for a request, carrying its Go context
+func newContext(r *http.Request) *schema.ValidationContext {
+	return schema.DefaultValidationContext().WithContext(r.Context())
+}
+
+// validateObject validates value against an object schema and returns the parsed map
+func validateObject(s *schema.ObjectSchema, value map[string]interface{}, ctx *schema.ValidationContext) (map[string]interface{}, error) {
+	parsed, err := validate(s, value, ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, _ := parsed.(map[string]interface{})
+	return result, nil
+}
+
+// validateValues validates query or form values against s
+func validateValues(s *schema.ObjectSchema, values url.Values, ctx *schema.ValidationContext) (map[string]interface{}, error) {
+	result := schema.ParseURLValues(s, values, ctx)
+	if !result.Valid {
+		return nil, validationError(result)
+	}
+	parsed, _ := result.Value.(map[string]interface{})
+	return parsed, nil
+}
+
+// validate parses value, converting validation failures into an *Error
+func validate(s schema.Parseable, value interface{}, ctx *schema.ValidationContext) (interface{}, error) {
+	result := s.Parse(value, ctx)
+	if result.Valid {
+		return result.Value, nil
+	}
+	return nil, validationError(result)
+}
+
+// validationError converts the errors of an invalid parse result into an *Error
+func validationError(result schema.ParseResult) *Error {
+	fieldErrors := make([]FieldError, len(result.Errors))
+	for i, err := range result.Errors {
+		fieldErrors[i] = FieldError{Field: err.Path.DotPath(), Message: err.Message, Code: err.Code}
+	}
+	return &Error{
+		Status:  http.StatusUnprocessableEntity,
+		Message: "validation failed",
+		Errors:  fieldErrors,
+	}
+}
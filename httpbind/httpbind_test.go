@@ -0,0 +1,158 @@
+package httpbind
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+func TestBindJSON(t *testing.T) {
+	user := schema.Object().
+		Property("name", schema.String().MinLength(2)).
+		Property("age", schema.Int().Min(0))
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		status      int
+		field       string
+	}{
+		{"valid", `{"name":"Ada","age":36}`, "application/json", 0, ""},
+		{"charset parameter", `{"name":"Ada","age":36}`, "application/json; charset=utf-8", 0, ""},
+		{"invalid field", `{"name":"A","age":36}`, "application/json", http.StatusUnprocessableEntity, "name"},
+		{"malformed", `{"name":`, "application/json", http.StatusBadRequest, ""},
+		{"trailing data", `{"name":"Ada","age":1} {}`, "application/json", http.StatusBadRequest, ""},
+		{"wrong content type", `name=Ada`, "text/plain", http.StatusUnsupportedMediaType, ""},
+		{"empty body", ``, "", http.StatusUnprocessableEntity, ""},
+		{"body too large", `{"name":"` + strings.Repeat("a", MaxBodyBytes) + `"}`, "application/json", http.StatusRequestEntityTooLarge, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			value, err := BindJSON(user, r)
+			if tt.status == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if value.(map[string]interface{})["name"] != "Ada" {
+					t.Errorf("value = %v", value)
+				}
+				return
+			}
+
+			var bindErr *Error
+			if !errors.As(err, &bindErr) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if bindErr.Status != tt.status {
+				t.Errorf("status = %d, want %d (%v)", bindErr.Status, tt.status, err)
+			}
+			if tt.field != "" && (len(bindErr.Errors) == 0 || bindErr.Errors[0].Field != tt.field) {
+				t.Errorf("field errors = %v, want first field %q", bindErr.Errors, tt.field)
+			}
+		})
+	}
+}
+
+func TestBindQuery(t *testing.T) {
+	search := schema.Object().
+		Property("q", schema.String()).
+		Property("page", schema.Int().Min(1).Optional()).
+		Property("tags", schema.Array(schema.String()).Optional()).
+		Property("ids", schema.Array(schema.Int()).Optional())
+
+	r := httptest.NewRequest(http.MethodGet, "/search?q=go&page=2&tags[]=a&tags[]=b&ids=7", nil)
+	value, err := BindQuery(search, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]interface{}{
+		"q":    "go",
+		"page": 2,
+		"tags": []interface{}{"a", "b"},
+		"ids":  []interface{}{7},
+	}
+	if !reflect.DeepEqual(value, want) {
+		t.Errorf("value = %#v, want %#v", value, want)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/search?q=go&page=zero", nil)
+	if _, err := BindQuery(search, r); err == nil || err.(*Error).Errors[0].Field != "page" {
+		t.Errorf("expected a page error, got %v", err)
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	login := schema.Object().
+		Property("email", schema.String().Email()).
+		Property("remember", schema.Bool().Optional())
+
+	form := url.Values{"email": {"ada@example.com"}, "remember": {"true"}}
+	r := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	value, err := BindForm(login, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value["remember"] != true {
+		t.Errorf("remember = %#v, want true", value["remember"])
+	}
+
+	large := url.Values{"email": {strings.Repeat("a", MaxBodyBytes)}}
+	r = httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(large.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var bindErr *Error
+	if _, err := BindForm(login, r); !errors.As(err, &bindErr) || bindErr.Status != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected a 413 error for a body over MaxBodyBytes, got %v", err)
+	}
+}
+
+func TestBindPath(t *testing.T) {
+	params := schema.Object().Property("id", schema.Int().Min(1))
+
+	value, err := BindPath(params, map[string]string{"id": "42"})
+	if err != nil || value["id"] != 42 {
+		t.Errorf("BindPath = %v, %v", value, err)
+	}
+	if _, err := BindPath(params, map[string]string{"id": "0"}); err == nil {
+		t.Error("expected id below minimum to fail")
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":1}`))
+	_, err := BindJSON(schema.Object().Property("name", schema.String()), r)
+
+	w := httptest.NewRecorder()
+	WriteError(w, err)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want 422", w.Code)
+	}
+	var body Error
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if len(body.Errors) == 0 || body.Errors[0].Field != "name" {
+		t.Errorf("response errors = %v", body.Errors)
+	}
+
+	w = httptest.NewRecorder()
+	WriteError(w, errors.New("database is down"))
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "database") {
+		t.Errorf("internal errors should be hidden, got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -59,10 +59,11 @@ func isEmptyErrorMessage(em ErrorMessage) bool {
 	return em == nil
 }
 
-// Helper function to resolve ErrorMessage to string
+// Helper function to resolve ErrorMessage to string; the message is marked as custom for
+// the schema reporting the error to render (see MessageData)
 func resolveErrorMessage(em ErrorMessage, ctx *ValidationContext) string {
 	if em == nil {
 		return ""
 	}
-	return em.Resolve(ctx)
+	return markCustom(em.Resolve(ctx))
 }
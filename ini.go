@@ -0,0 +1,136 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+func iniDecodeError(err error) i18n.TranslatedFunc {
+	return i18n.F("invalid INI: %v", err)
+}
+
+// ParseINI decodes an INI document and validates it against s. Keys before the first
+// [section] belong to the top-level object, each section becomes a nested object
+// (dotted section names such as [server.tls] nest further), repeated keys and keys
+// ending in "[]" produce arrays, and quoted values are unquoted. Lines starting
+// with ';' or '#' are comments.
+//
+// All INI values are strings, so they are coerced to the schema types as if ctx had
+// coercion enabled. Errors carry the Location of the offending key, as with ParseTOML.
+// A document that cannot be decoded fails with code "invalid_ini".
+func ParseINI(s Parseable, data []byte, ctx *ValidationContext) ParseResult {
+	doc, locs, err := decodeINI(data)
+	if err != nil {
+		var loc *Location
+		if lineErr, ok := err.(*iniLineError); ok {
+			loc = &lineErr.location
+		}
+		return documentError(iniDecodeError(err)(ctx.Locale), CodeInvalidINI, loc, ctx.Source)
+	}
+
+	coercing := *ctx
+	coercing.Coerce = true
+	return locs.attach(s.Parse(doc, &coercing), ctx.Source)
+}
+
+// ParseINI decodes an INI document and validates it against the object schema
+func (s *ObjectSchema) ParseINI(data []byte, ctx *ValidationContext) ParseResult {
+	return ParseINI(s, data, ctx)
+}
+
+// iniLineError reports a malformed line
+type iniLineError struct {
+	location Location
+	message  string
+}
+
+func (e *iniLineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.location.Line, e.message)
+}
+
+// decodeINI parses data into nested maps of strings and records the location of
+// every section and key
+func decodeINI(data []byte) (map[string]interface{}, sourceLocations, error) {
+	root := map[string]interface{}{}
+	locs := sourceLocations{}
+	section, sectionPath := root, Path{}
+
+	offset := 0
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := i + 1
+		lineOffset := offset
+		offset += len(raw) + 1
+
+		text := strings.TrimSpace(raw)
+		if text == "" || text[0] == ';' || text[0] == '#' {
+			continue
+		}
+		indent := strings.Index(raw, text)
+		loc := Location{Offset: lineOffset + indent, Line: line, Column: indent + 1}
+
+		if text[0] == '[' {
+			if !strings.HasSuffix(text, "]") {
+				return nil, nil, &iniLineError{loc, "section header is missing ']'"}
+			}
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if name == "" {
+				return nil, nil, &iniLineError{loc, "empty section name"}
+			}
+			section, sectionPath = root, Path{}
+			for _, part := range strings.Split(name, ".") {
+				part = strings.TrimSpace(part)
+				sectionPath = sectionPath.Field(part)
+				child, ok := section[part].(map[string]interface{})
+				if !ok {
+					child = map[string]interface{}{}
+					section[part] = child
+				}
+				section = child
+				locs.add(sectionPath, loc)
+			}
+			continue
+		}
+
+		sep := strings.IndexAny(text, "=:")
+		if sep <= 0 {
+			return nil, nil, &iniLineError{loc, fmt.Sprintf("expected key = value, got %q", text)}
+		}
+		key := strings.TrimSpace(text[:sep])
+		value := iniUnquote(strings.TrimSpace(text[sep+1:]))
+
+		appendValue := strings.HasSuffix(key, "[]")
+		key = strings.TrimSuffix(key, "[]")
+		path := sectionPath.Field(key)
+
+		switch existing := section[key].(type) {
+		case []interface{}:
+			section[key] = append(existing, value)
+			locs.add(path.Index(len(existing)), loc)
+		case string:
+			section[key] = []interface{}{existing, value}
+			locs.add(path.Index(0), locs[path.JSONPointer()])
+			locs.add(path.Index(1), loc)
+		default:
+			if appendValue {
+				section[key] = []interface{}{value}
+				locs.add(path.Index(0), loc)
+			} else {
+				section[key] = value
+			}
+		}
+		locs.add(path, loc)
+	}
+	return root, locs, nil
+}
+
+// iniUnquote removes matching single or double quotes around a value
+func iniUnquote(value string) string {
+	if len(value) >= 2 {
+		if first, last := value[0], value[len(value)-1]; first == last && (first == '"' || first == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
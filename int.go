@@ -2,6 +2,7 @@ package schema
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/nyxstack/i18n"
 )
@@ -33,11 +34,13 @@ func intConstError(value int) i18n.TranslatedFunc {
 // IntSchema represents a JSON Schema for integer values
 type IntSchema struct {
 	Schema
+	coerce bool // Convert compatible input types before validating
 	// Int-specific validation (private fields)
-	minimum    *int
-	maximum    *int
-	multipleOf *int
-	nullable   bool
+	minimum        *int
+	maximum        *int
+	multipleOf     *int
+	nullable       bool
+	constOverrides bool // Const and enum values skip the other constraints
 
 	// Error messages for validation failures (support i18n)
 	requiredError     ErrorMessage
@@ -63,34 +66,116 @@ func Int(errorMessage ...interface{}) *IntSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *IntSchema) Clone() *IntSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *IntSchema) Freeze() *IntSchema {
+	s.freeze()
+	return s
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *IntSchema) Title(title string) *IntSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *IntSchema) Description(description string) *IntSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *IntSchema) ReadOnly() *IntSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *IntSchema) WriteOnly() *IntSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *IntSchema) Sensitive() *IntSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *IntSchema) Deprecated(reason string) *IntSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *IntSchema) Meta(key string, value interface{}) *IntSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *IntSchema) AsWarning(codes ...ErrorCode) *IntSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *IntSchema) AsInfo(codes ...ErrorCode) *IntSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *IntSchema) Default(value interface{}) *IntSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *IntSchema) DefaultFunc(fn func() interface{}) *IntSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *IntSchema) Example(example int) *IntSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 // Enum sets the allowed enum values with optional custom error message
 func (s *IntSchema) Enum(values []int, errorMessage ...interface{}) *IntSchema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -103,6 +188,7 @@ func (s *IntSchema) Enum(values []int, errorMessage ...interface{}) *IntSchema {
 
 // Const sets a constant value with optional custom error message
 func (s *IntSchema) Const(value int, errorMessage ...interface{}) *IntSchema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -110,16 +196,27 @@ func (s *IntSchema) Const(value int, errorMessage ...interface{}) *IntSchema {
 	return s
 }
 
+// ConstOverrides gives the const and enum precedence over the other constraints: a
+// const or enum value is valid even if it fails them, and another value only reports
+// the const or enum error
+func (s *IntSchema) ConstOverrides(enabled bool) *IntSchema {
+	s.checkMutable()
+	s.constOverrides = enabled
+	return s
+}
+
 // Required/Optional/Nullable control
 
 // Optional marks the schema as optional
 func (s *IntSchema) Optional() *IntSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *IntSchema) Required(errorMessage ...interface{}) *IntSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -129,12 +226,27 @@ func (s *IntSchema) Required(errorMessage ...interface{}) *IntSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *IntSchema) Nullable() *IntSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
+// Coerce enables conversion of compatible input types (e.g. strings from query
+// parameters or environment variables) before validation
+func (s *IntSchema) Coerce() *IntSchema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *IntSchema) coerces() bool {
+	return s.coerce
+}
+
 // TypeError sets a custom error message for type mismatch validation
 func (s *IntSchema) TypeError(message string) *IntSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -143,6 +255,7 @@ func (s *IntSchema) TypeError(message string) *IntSchema {
 
 // Min sets the minimum value constraint with optional custom error message
 func (s *IntSchema) Min(min int, errorMessage ...interface{}) *IntSchema {
+	s.checkMutable()
 	s.minimum = &min
 	if len(errorMessage) > 0 {
 		s.minimumError = toErrorMessage(errorMessage[0])
@@ -152,6 +265,7 @@ func (s *IntSchema) Min(min int, errorMessage ...interface{}) *IntSchema {
 
 // Max sets the maximum value constraint with optional custom error message
 func (s *IntSchema) Max(max int, errorMessage ...interface{}) *IntSchema {
+	s.checkMutable()
 	s.maximum = &max
 	if len(errorMessage) > 0 {
 		s.maximumError = toErrorMessage(errorMessage[0])
@@ -161,6 +275,7 @@ func (s *IntSchema) Max(max int, errorMessage ...interface{}) *IntSchema {
 
 // Range sets both minimum and maximum values with optional custom error message
 func (s *IntSchema) Range(min, max int, errorMessage ...interface{}) *IntSchema {
+	s.checkMutable()
 	s.minimum = &min
 	s.maximum = &max
 	if len(errorMessage) > 0 {
@@ -172,6 +287,7 @@ func (s *IntSchema) Range(min, max int, errorMessage ...interface{}) *IntSchema
 
 // MultipleOf sets the multiple constraint with optional custom error message
 func (s *IntSchema) MultipleOf(multiple int, errorMessage ...interface{}) *IntSchema {
+	s.checkMutable()
 	s.multipleOf = &multiple
 	if len(errorMessage) > 0 {
 		s.multipleOfError = toErrorMessage(errorMessage[0])
@@ -179,6 +295,21 @@ func (s *IntSchema) MultipleOf(multiple int, errorMessage ...interface{}) *IntSc
 	return s
 }
 
+// Positive requires the value to be at least 1, with optional custom error message
+func (s *IntSchema) Positive(errorMessage ...interface{}) *IntSchema {
+	return s.Min(1, errorMessage...)
+}
+
+// NonNegative requires the value to be zero or greater, with optional custom error message
+func (s *IntSchema) NonNegative(errorMessage ...interface{}) *IntSchema {
+	return s.Min(0, errorMessage...)
+}
+
+// Port requires a valid TCP/UDP port number (1-65535), with optional custom error message
+func (s *IntSchema) Port(errorMessage ...interface{}) *IntSchema {
+	return s.Range(1, 65535, errorMessage...)
+}
+
 // Getters for accessing private fields
 
 // IsRequired returns whether the schema is marked as required
@@ -196,6 +327,11 @@ func (s *IntSchema) IsNullable() bool {
 	return s.nullable
 }
 
+// IsConstOverrides returns whether the const and enum take precedence over the other constraints
+func (s *IntSchema) IsConstOverrides() bool {
+	return s.constOverrides
+}
+
 // GetMinimum returns the minimum value constraint
 func (s *IntSchema) GetMinimum() *int {
 	return s.minimum
@@ -213,18 +349,47 @@ func (s *IntSchema) GetMultipleOf() *int {
 
 // GetDefault returns the default value as an int
 func (s *IntSchema) GetDefaultInt() *int {
-	if s.GetDefault() != nil {
-		if i, ok := s.GetDefault().(int); ok {
-			return &i
-		}
+	if i, ok := s.GetDefault().(int); ok {
+		return &i
 	}
 	return nil
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *IntSchema) Transform(fn TransformFunc) *IntSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *IntSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *IntSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *IntSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *IntSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses an integer value, returning the final parsed value
 func (s *IntSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the int constraints; Parse runs the refine/transform pipeline on top
+func (s *IntSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceInt(value)
+	}
+
 	var errors []ValidationError
 
 	// Handle nil values
@@ -237,7 +402,7 @@ func (s *IntSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := intRequiredError(ctx.Locale)
@@ -247,12 +412,12 @@ func (s *IntSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -312,10 +477,16 @@ func (s *IntSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
+	// With ConstOverrides, the const and enum alone decide
+	if s.constOverrides && (s.Schema.constVal != nil || len(s.Schema.enum) > 0) {
+		errors = append(errors, s.constEnumErrors(intValue, ctx)...)
+		return ParseResult{Valid: len(errors) == 0, Value: intValue, Errors: errors}
+	}
+
 	// Now validate the int value against all constraints
 	finalValue := intValue // This is our parsed value
 
@@ -325,7 +496,7 @@ func (s *IntSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 		if !isEmptyErrorMessage(s.minimumError) {
 			message = resolveErrorMessage(s.minimumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(intValue, message, "minimum"))
+		errors = append(errors, NewPrimitiveError(intValue, message, CodeMinimum))
 	}
 
 	// Check maximum
@@ -334,7 +505,7 @@ func (s *IntSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 		if !isEmptyErrorMessage(s.maximumError) {
 			message = resolveErrorMessage(s.maximumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(intValue, message, "maximum"))
+		errors = append(errors, NewPrimitiveError(intValue, message, CodeMaximum))
 	}
 
 	// Check multipleOf
@@ -343,8 +514,21 @@ func (s *IntSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 		if !isEmptyErrorMessage(s.multipleOfError) {
 			message = resolveErrorMessage(s.multipleOfError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(intValue, message, "multiple_of"))
+		errors = append(errors, NewPrimitiveError(intValue, message, CodeMultipleOf))
+	}
+
+	errors = append(errors, s.constEnumErrors(intValue, ctx)...)
+
+	return ParseResult{
+		Valid:  len(errors) == 0,
+		Value:  finalValue,
+		Errors: errors,
 	}
+}
+
+// constEnumErrors checks the value against the enum and const
+func (s *IntSchema) constEnumErrors(intValue int, ctx *ValidationContext) []ValidationError {
+	var errors []ValidationError
 
 	// Check enum
 	if len(s.Schema.enum) > 0 {
@@ -360,7 +544,7 @@ func (s *IntSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(intValue, message, "enum"))
+			errors = append(errors, NewPrimitiveError(intValue, message, CodeEnum))
 		}
 	}
 
@@ -371,15 +555,29 @@ func (s *IntSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 			if !isEmptyErrorMessage(s.constError) {
 				message = resolveErrorMessage(s.constError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(intValue, message, "const"))
+			errors = append(errors, NewPrimitiveError(intValue, message, CodeConst))
 		}
 	}
+	return errors
+}
 
-	return ParseResult{
-		Valid:  len(errors) == 0,
-		Value:  finalValue,
-		Errors: errors,
+// Validate reports the constraints that contradict each other, so that values are
+// rejected whatever they are: a minimum above the maximum, a multipleOf that is not positive, or a const or enum value that
+// fails the other constraints (unless ConstOverrides is set) or a const outside the
+// enum. It returns nil for a consistent schema.
+func (s *IntSchema) Validate() error {
+	var issues []error
+	if s.minimum != nil && s.maximum != nil && *s.minimum > *s.maximum {
+		issues = append(issues, contradiction("minimum %d is greater than maximum %d", *s.minimum, *s.maximum))
 	}
+	if s.multipleOf != nil && *s.multipleOf <= 0 {
+		issues = append(issues, contradiction("multipleOf %d is not positive", *s.multipleOf))
+	}
+
+	without := s.Clone()
+	without.Schema.constVal, without.Schema.enum, without.Schema.effects = nil, nil, nil
+	issues = append(issues, constEnumContradictions(s.Schema.constVal, s.Schema.enum, s.constOverrides, without)...)
+	return errors.Join(issues...)
 }
 
 // JSON generates JSON Schema representation
@@ -389,7 +587,8 @@ func (s *IntSchema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
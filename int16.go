@@ -35,6 +35,7 @@ func int16ConstError(value int16) i18n.TranslatedFunc {
 // Int16Schema represents a JSON Schema for int16 values
 type Int16Schema struct {
 	Schema
+	coerce bool // Convert compatible input types before validating
 	// Int16-specific validation (private fields)
 	minimum    *int16
 	maximum    *int16
@@ -66,34 +67,116 @@ func Int16(errorMessage ...interface{}) *Int16Schema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *Int16Schema) Clone() *Int16Schema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *Int16Schema) Freeze() *Int16Schema {
+	s.freeze()
+	return s
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *Int16Schema) Title(title string) *Int16Schema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *Int16Schema) Description(description string) *Int16Schema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *Int16Schema) ReadOnly() *Int16Schema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *Int16Schema) WriteOnly() *Int16Schema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *Int16Schema) Sensitive() *Int16Schema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *Int16Schema) Deprecated(reason string) *Int16Schema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *Int16Schema) Meta(key string, value interface{}) *Int16Schema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *Int16Schema) AsWarning(codes ...ErrorCode) *Int16Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *Int16Schema) AsInfo(codes ...ErrorCode) *Int16Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *Int16Schema) Default(value interface{}) *Int16Schema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *Int16Schema) DefaultFunc(fn func() interface{}) *Int16Schema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *Int16Schema) Example(example int16) *Int16Schema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 // Enum sets the allowed enum values with optional custom error message
 func (s *Int16Schema) Enum(values []int16, errorMessage ...interface{}) *Int16Schema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -106,6 +189,7 @@ func (s *Int16Schema) Enum(values []int16, errorMessage ...interface{}) *Int16Sc
 
 // Const sets a constant value with optional custom error message
 func (s *Int16Schema) Const(value int16, errorMessage ...interface{}) *Int16Schema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -117,12 +201,14 @@ func (s *Int16Schema) Const(value int16, errorMessage ...interface{}) *Int16Sche
 
 // Optional marks the schema as optional
 func (s *Int16Schema) Optional() *Int16Schema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *Int16Schema) Required(errorMessage ...interface{}) *Int16Schema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -132,12 +218,27 @@ func (s *Int16Schema) Required(errorMessage ...interface{}) *Int16Schema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *Int16Schema) Nullable() *Int16Schema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
+// Coerce enables conversion of compatible input types (e.g. strings from query
+// parameters or environment variables) before validation
+func (s *Int16Schema) Coerce() *Int16Schema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *Int16Schema) coerces() bool {
+	return s.coerce
+}
+
 // TypeError sets a custom error message for type mismatch validation
 func (s *Int16Schema) TypeError(message string) *Int16Schema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -146,6 +247,7 @@ func (s *Int16Schema) TypeError(message string) *Int16Schema {
 
 // Min sets the minimum value constraint with optional custom error message
 func (s *Int16Schema) Min(min int16, errorMessage ...interface{}) *Int16Schema {
+	s.checkMutable()
 	s.minimum = &min
 	if len(errorMessage) > 0 {
 		s.minimumError = toErrorMessage(errorMessage[0])
@@ -155,6 +257,7 @@ func (s *Int16Schema) Min(min int16, errorMessage ...interface{}) *Int16Schema {
 
 // Max sets the maximum value constraint with optional custom error message
 func (s *Int16Schema) Max(max int16, errorMessage ...interface{}) *Int16Schema {
+	s.checkMutable()
 	s.maximum = &max
 	if len(errorMessage) > 0 {
 		s.maximumError = toErrorMessage(errorMessage[0])
@@ -164,6 +267,7 @@ func (s *Int16Schema) Max(max int16, errorMessage ...interface{}) *Int16Schema {
 
 // Range sets both minimum and maximum values with optional custom error message
 func (s *Int16Schema) Range(min, max int16, errorMessage ...interface{}) *Int16Schema {
+	s.checkMutable()
 	s.minimum = &min
 	s.maximum = &max
 	if len(errorMessage) > 0 {
@@ -175,6 +279,7 @@ func (s *Int16Schema) Range(min, max int16, errorMessage ...interface{}) *Int16S
 
 // MultipleOf sets the multiple constraint with optional custom error message
 func (s *Int16Schema) MultipleOf(multiple int16, errorMessage ...interface{}) *Int16Schema {
+	s.checkMutable()
 	s.multipleOf = &multiple
 	if len(errorMessage) > 0 {
 		s.multipleOfError = toErrorMessage(errorMessage[0])
@@ -182,6 +287,16 @@ func (s *Int16Schema) MultipleOf(multiple int16, errorMessage ...interface{}) *I
 	return s
 }
 
+// Positive requires the value to be at least 1, with optional custom error message
+func (s *Int16Schema) Positive(errorMessage ...interface{}) *Int16Schema {
+	return s.Min(1, errorMessage...)
+}
+
+// NonNegative requires the value to be zero or greater, with optional custom error message
+func (s *Int16Schema) NonNegative(errorMessage ...interface{}) *Int16Schema {
+	return s.Min(0, errorMessage...)
+}
+
 // Getters for accessing private fields
 
 // IsRequired returns whether the schema is marked as required
@@ -216,18 +331,47 @@ func (s *Int16Schema) GetMultipleOf() *int16 {
 
 // GetDefault returns the default value as an int16
 func (s *Int16Schema) GetDefaultInt16() *int16 {
-	if s.GetDefault() != nil {
-		if i, ok := s.GetDefault().(int16); ok {
-			return &i
-		}
+	if i, ok := s.GetDefault().(int16); ok {
+		return &i
 	}
 	return nil
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *Int16Schema) Transform(fn TransformFunc) *Int16Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *Int16Schema) Refine(fn RefineFunc, errorMessage ...interface{}) *Int16Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *Int16Schema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *Int16Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses an int16 value, returning the final parsed value
 func (s *Int16Schema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the int16 constraints; Parse runs the refine/transform pipeline on top
+func (s *Int16Schema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceInt(value)
+	}
+
 	var errors []ValidationError
 
 	// Handle nil values
@@ -237,7 +381,7 @@ func (s *Int16Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		}
 		if s.Schema.required {
 			if defaultVal := s.GetDefault(); defaultVal != nil {
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			message := int16RequiredError(ctx.Locale)
 			if !isEmptyErrorMessage(s.requiredError) {
@@ -246,11 +390,11 @@ func (s *Int16Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
 	}
@@ -301,7 +445,7 @@ func (s *Int16Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
@@ -313,7 +457,7 @@ func (s *Int16Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.minimumError) {
 			message = resolveErrorMessage(s.minimumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int16Value, message, "minimum"))
+		errors = append(errors, NewPrimitiveError(int16Value, message, CodeMinimum))
 	}
 
 	if s.maximum != nil && int16Value > *s.maximum {
@@ -321,7 +465,7 @@ func (s *Int16Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.maximumError) {
 			message = resolveErrorMessage(s.maximumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int16Value, message, "maximum"))
+		errors = append(errors, NewPrimitiveError(int16Value, message, CodeMaximum))
 	}
 
 	if s.multipleOf != nil && int16Value%*s.multipleOf != 0 {
@@ -329,7 +473,7 @@ func (s *Int16Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.multipleOfError) {
 			message = resolveErrorMessage(s.multipleOfError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int16Value, message, "multiple_of"))
+		errors = append(errors, NewPrimitiveError(int16Value, message, CodeMultipleOf))
 	}
 
 	if len(s.Schema.enum) > 0 {
@@ -345,7 +489,7 @@ func (s *Int16Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(int16Value, message, "enum"))
+			errors = append(errors, NewPrimitiveError(int16Value, message, CodeEnum))
 		}
 	}
 
@@ -355,7 +499,7 @@ func (s *Int16Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			if !isEmptyErrorMessage(s.constError) {
 				message = resolveErrorMessage(s.constError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(int16Value, message, "const"))
+			errors = append(errors, NewPrimitiveError(int16Value, message, CodeConst))
 		}
 	}
 
@@ -372,7 +516,8 @@ func (s *Int16Schema) JSON() map[string]interface{} {
 
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -416,3 +561,56 @@ func (s *Int16Schema) MarshalJSON() ([]byte, error) {
 		Nullable:   s.nullable,
 	})
 }
+
+// Interface implementations for Int16Schema
+
+// SetTitle implements SetTitle interface
+func (s *Int16Schema) SetTitle(title string) {
+	s.Title(title)
+}
+
+// SetDescription implements SetDescription interface
+func (s *Int16Schema) SetDescription(description string) {
+	s.Description(description)
+}
+
+// SetRequired implements SetRequired interface
+func (s *Int16Schema) SetRequired() {
+	s.Required()
+}
+
+// SetOptional implements SetOptional interface
+func (s *Int16Schema) SetOptional() {
+	s.Optional()
+}
+
+// SetMinimum implements SetMinimum interface; out-of-range bounds are ignored
+func (s *Int16Schema) SetMinimum(min int) {
+	if min >= math.MinInt16 && min <= math.MaxInt16 {
+		s.Min(int16(min))
+	}
+}
+
+// SetMaximum implements SetMaximum interface; out-of-range bounds are ignored
+func (s *Int16Schema) SetMaximum(max int) {
+	if max >= math.MinInt16 && max <= math.MaxInt16 {
+		s.Max(int16(max))
+	}
+}
+
+// SetNullable implements SetNullable interface
+func (s *Int16Schema) SetNullable() {
+	s.Nullable()
+}
+
+// SetDefault implements SetDefault interface
+func (s *Int16Schema) SetDefault(value interface{}) {
+	s.Default(value)
+}
+
+// SetExample implements SetExample interface
+func (s *Int16Schema) SetExample(example interface{}) {
+	if val, ok := example.(int16); ok {
+		s.Example(val)
+	}
+}
@@ -30,6 +30,7 @@ func int32ConstError(value int32) i18n.TranslatedFunc {
 
 type Int32Schema struct {
 	Schema
+	coerce     bool // Convert compatible input types before validating
 	minimum    *int32
 	maximum    *int32
 	multipleOf *int32
@@ -57,27 +58,107 @@ func Int32(errorMessage ...interface{}) *Int32Schema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *Int32Schema) Clone() *Int32Schema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *Int32Schema) Freeze() *Int32Schema {
+	s.freeze()
+	return s
+}
+
 func (s *Int32Schema) Title(title string) *Int32Schema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 func (s *Int32Schema) Description(description string) *Int32Schema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *Int32Schema) ReadOnly() *Int32Schema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *Int32Schema) WriteOnly() *Int32Schema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *Int32Schema) Sensitive() *Int32Schema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *Int32Schema) Deprecated(reason string) *Int32Schema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *Int32Schema) Meta(key string, value interface{}) *Int32Schema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *Int32Schema) AsWarning(codes ...ErrorCode) *Int32Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *Int32Schema) AsInfo(codes ...ErrorCode) *Int32Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 func (s *Int32Schema) Default(value interface{}) *Int32Schema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+func (s *Int32Schema) DefaultFunc(fn func() interface{}) *Int32Schema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 func (s *Int32Schema) Example(example int32) *Int32Schema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 func (s *Int32Schema) Enum(values []int32, errorMessage ...interface{}) *Int32Schema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -89,6 +170,7 @@ func (s *Int32Schema) Enum(values []int32, errorMessage ...interface{}) *Int32Sc
 }
 
 func (s *Int32Schema) Const(value int32, errorMessage ...interface{}) *Int32Schema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -97,11 +179,13 @@ func (s *Int32Schema) Const(value int32, errorMessage ...interface{}) *Int32Sche
 }
 
 func (s *Int32Schema) Optional() *Int32Schema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 func (s *Int32Schema) Required(errorMessage ...interface{}) *Int32Schema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -110,11 +194,24 @@ func (s *Int32Schema) Required(errorMessage ...interface{}) *Int32Schema {
 }
 
 func (s *Int32Schema) Nullable() *Int32Schema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
+func (s *Int32Schema) Coerce() *Int32Schema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *Int32Schema) coerces() bool {
+	return s.coerce
+}
+
 func (s *Int32Schema) Min(min int32, errorMessage ...interface{}) *Int32Schema {
+	s.checkMutable()
 	s.minimum = &min
 	if len(errorMessage) > 0 {
 		s.minimumError = toErrorMessage(errorMessage[0])
@@ -123,6 +220,7 @@ func (s *Int32Schema) Min(min int32, errorMessage ...interface{}) *Int32Schema {
 }
 
 func (s *Int32Schema) Max(max int32, errorMessage ...interface{}) *Int32Schema {
+	s.checkMutable()
 	s.maximum = &max
 	if len(errorMessage) > 0 {
 		s.maximumError = toErrorMessage(errorMessage[0])
@@ -131,6 +229,7 @@ func (s *Int32Schema) Max(max int32, errorMessage ...interface{}) *Int32Schema {
 }
 
 func (s *Int32Schema) Range(min, max int32, errorMessage ...interface{}) *Int32Schema {
+	s.checkMutable()
 	s.minimum = &min
 	s.maximum = &max
 	if len(errorMessage) > 0 {
@@ -141,6 +240,7 @@ func (s *Int32Schema) Range(min, max int32, errorMessage ...interface{}) *Int32S
 }
 
 func (s *Int32Schema) MultipleOf(multiple int32, errorMessage ...interface{}) *Int32Schema {
+	s.checkMutable()
 	s.multipleOf = &multiple
 	if len(errorMessage) > 0 {
 		s.multipleOfError = toErrorMessage(errorMessage[0])
@@ -148,6 +248,18 @@ func (s *Int32Schema) MultipleOf(multiple int32, errorMessage ...interface{}) *I
 	return s
 }
 
+func (s *Int32Schema) Positive(errorMessage ...interface{}) *Int32Schema {
+	return s.Min(1, errorMessage...)
+}
+
+func (s *Int32Schema) NonNegative(errorMessage ...interface{}) *Int32Schema {
+	return s.Min(0, errorMessage...)
+}
+
+func (s *Int32Schema) Port(errorMessage ...interface{}) *Int32Schema {
+	return s.Range(1, 65535, errorMessage...)
+}
+
 func (s *Int32Schema) IsRequired() bool      { return s.Schema.required }
 func (s *Int32Schema) IsOptional() bool      { return !s.Schema.required }
 func (s *Int32Schema) IsNullable() bool      { return s.nullable }
@@ -155,7 +267,38 @@ func (s *Int32Schema) GetMinimum() *int32    { return s.minimum }
 func (s *Int32Schema) GetMaximum() *int32    { return s.maximum }
 func (s *Int32Schema) GetMultipleOf() *int32 { return s.multipleOf }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *Int32Schema) Transform(fn TransformFunc) *Int32Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *Int32Schema) Refine(fn RefineFunc, errorMessage ...interface{}) *Int32Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *Int32Schema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *Int32Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 func (s *Int32Schema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the int32 constraints; Parse runs the refine/transform pipeline on top
+func (s *Int32Schema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceInt(value)
+	}
+
 	var errors []ValidationError
 
 	if value == nil {
@@ -164,16 +307,16 @@ func (s *Int32Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		}
 		if s.Schema.required {
 			if defaultVal := s.GetDefault(); defaultVal != nil {
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			message := int32RequiredError(ctx.Locale)
 			if !isEmptyErrorMessage(s.requiredError) {
 				message = resolveErrorMessage(s.requiredError, ctx)
 			}
-			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, "required")}}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
 		}
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
 	}
@@ -218,7 +361,7 @@ func (s *Int32Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.typeMismatchError) {
 			message = resolveErrorMessage(s.typeMismatchError, ctx)
 		}
-		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")}}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
 	}
 
 	finalValue := int32Value
@@ -228,7 +371,7 @@ func (s *Int32Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.minimumError) {
 			message = resolveErrorMessage(s.minimumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int32Value, message, "minimum"))
+		errors = append(errors, NewPrimitiveError(int32Value, message, CodeMinimum))
 	}
 
 	if s.maximum != nil && int32Value > *s.maximum {
@@ -236,7 +379,7 @@ func (s *Int32Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.maximumError) {
 			message = resolveErrorMessage(s.maximumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int32Value, message, "maximum"))
+		errors = append(errors, NewPrimitiveError(int32Value, message, CodeMaximum))
 	}
 
 	if s.multipleOf != nil && int32Value%*s.multipleOf != 0 {
@@ -244,7 +387,7 @@ func (s *Int32Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.multipleOfError) {
 			message = resolveErrorMessage(s.multipleOfError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int32Value, message, "multiple_of"))
+		errors = append(errors, NewPrimitiveError(int32Value, message, CodeMultipleOf))
 	}
 
 	if len(s.Schema.enum) > 0 {
@@ -260,7 +403,7 @@ func (s *Int32Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(int32Value, message, "enum"))
+			errors = append(errors, NewPrimitiveError(int32Value, message, CodeEnum))
 		}
 	}
 
@@ -270,7 +413,7 @@ func (s *Int32Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			if !isEmptyErrorMessage(s.constError) {
 				message = resolveErrorMessage(s.constError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(int32Value, message, "const"))
+			errors = append(errors, NewPrimitiveError(int32Value, message, CodeConst))
 		}
 	}
 
@@ -282,7 +425,8 @@ func (s *Int32Schema) JSON() map[string]interface{} {
 
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -305,3 +449,56 @@ func (s *Int32Schema) JSON() map[string]interface{} {
 
 	return schema
 }
+
+// Interface implementations for Int32Schema
+
+// SetTitle implements SetTitle interface
+func (s *Int32Schema) SetTitle(title string) {
+	s.Title(title)
+}
+
+// SetDescription implements SetDescription interface
+func (s *Int32Schema) SetDescription(description string) {
+	s.Description(description)
+}
+
+// SetRequired implements SetRequired interface
+func (s *Int32Schema) SetRequired() {
+	s.Required()
+}
+
+// SetOptional implements SetOptional interface
+func (s *Int32Schema) SetOptional() {
+	s.Optional()
+}
+
+// SetMinimum implements SetMinimum interface; out-of-range bounds are ignored
+func (s *Int32Schema) SetMinimum(min int) {
+	if min >= math.MinInt32 && min <= math.MaxInt32 {
+		s.Min(int32(min))
+	}
+}
+
+// SetMaximum implements SetMaximum interface; out-of-range bounds are ignored
+func (s *Int32Schema) SetMaximum(max int) {
+	if max >= math.MinInt32 && max <= math.MaxInt32 {
+		s.Max(int32(max))
+	}
+}
+
+// SetNullable implements SetNullable interface
+func (s *Int32Schema) SetNullable() {
+	s.Nullable()
+}
+
+// SetDefault implements SetDefault interface
+func (s *Int32Schema) SetDefault(value interface{}) {
+	s.Default(value)
+}
+
+// SetExample implements SetExample interface
+func (s *Int32Schema) SetExample(example interface{}) {
+	if val, ok := example.(int32); ok {
+		s.Example(val)
+	}
+}
@@ -28,6 +28,7 @@ func int64ConstError(value int64) i18n.TranslatedFunc {
 
 type Int64Schema struct {
 	Schema
+	coerce     bool // Convert compatible input types before validating
 	minimum    *int64
 	maximum    *int64
 	multipleOf *int64
@@ -55,23 +56,124 @@ func Int64(errorMessage ...interface{}) *Int64Schema {
 	return schema
 }
 
-func (s *Int64Schema) Title(title string) *Int64Schema { s.Schema.title = title; return s }
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *Int64Schema) Clone() *Int64Schema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *Int64Schema) Freeze() *Int64Schema {
+	s.freeze()
+	return s
+}
+
+func (s *Int64Schema) Title(title string) *Int64Schema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
 func (s *Int64Schema) Description(description string) *Int64Schema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *Int64Schema) ReadOnly() *Int64Schema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *Int64Schema) WriteOnly() *Int64Schema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *Int64Schema) Sensitive() *Int64Schema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *Int64Schema) Deprecated(reason string) *Int64Schema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *Int64Schema) Meta(key string, value interface{}) *Int64Schema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *Int64Schema) AsWarning(codes ...ErrorCode) *Int64Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *Int64Schema) AsInfo(codes ...ErrorCode) *Int64Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
 func (s *Int64Schema) Default(value interface{}) *Int64Schema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+func (s *Int64Schema) DefaultFunc(fn func() interface{}) *Int64Schema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 func (s *Int64Schema) Example(example int64) *Int64Schema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
-func (s *Int64Schema) Optional() *Int64Schema { s.Schema.required = false; return s }
-func (s *Int64Schema) Nullable() *Int64Schema { s.nullable = true; return s }
+func (s *Int64Schema) Optional() *Int64Schema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+func (s *Int64Schema) Nullable() *Int64Schema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+func (s *Int64Schema) Coerce() *Int64Schema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *Int64Schema) coerces() bool {
+	return s.coerce
+}
 
 func (s *Int64Schema) Enum(values []int64, errorMessage ...interface{}) *Int64Schema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -83,6 +185,7 @@ func (s *Int64Schema) Enum(values []int64, errorMessage ...interface{}) *Int64Sc
 }
 
 func (s *Int64Schema) Const(value int64, errorMessage ...interface{}) *Int64Schema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -91,6 +194,7 @@ func (s *Int64Schema) Const(value int64, errorMessage ...interface{}) *Int64Sche
 }
 
 func (s *Int64Schema) Min(min int64, errorMessage ...interface{}) *Int64Schema {
+	s.checkMutable()
 	s.minimum = &min
 	if len(errorMessage) > 0 {
 		s.minimumError = toErrorMessage(errorMessage[0])
@@ -99,6 +203,7 @@ func (s *Int64Schema) Min(min int64, errorMessage ...interface{}) *Int64Schema {
 }
 
 func (s *Int64Schema) Max(max int64, errorMessage ...interface{}) *Int64Schema {
+	s.checkMutable()
 	s.maximum = &max
 	if len(errorMessage) > 0 {
 		s.maximumError = toErrorMessage(errorMessage[0])
@@ -107,6 +212,7 @@ func (s *Int64Schema) Max(max int64, errorMessage ...interface{}) *Int64Schema {
 }
 
 func (s *Int64Schema) Range(min, max int64, errorMessage ...interface{}) *Int64Schema {
+	s.checkMutable()
 	s.minimum = &min
 	s.maximum = &max
 	if len(errorMessage) > 0 {
@@ -117,6 +223,7 @@ func (s *Int64Schema) Range(min, max int64, errorMessage ...interface{}) *Int64S
 }
 
 func (s *Int64Schema) MultipleOf(multiple int64, errorMessage ...interface{}) *Int64Schema {
+	s.checkMutable()
 	s.multipleOf = &multiple
 	if len(errorMessage) > 0 {
 		s.multipleOfError = toErrorMessage(errorMessage[0])
@@ -124,6 +231,18 @@ func (s *Int64Schema) MultipleOf(multiple int64, errorMessage ...interface{}) *I
 	return s
 }
 
+func (s *Int64Schema) Positive(errorMessage ...interface{}) *Int64Schema {
+	return s.Min(1, errorMessage...)
+}
+
+func (s *Int64Schema) NonNegative(errorMessage ...interface{}) *Int64Schema {
+	return s.Min(0, errorMessage...)
+}
+
+func (s *Int64Schema) Port(errorMessage ...interface{}) *Int64Schema {
+	return s.Range(1, 65535, errorMessage...)
+}
+
 func (s *Int64Schema) IsRequired() bool      { return s.Schema.required }
 func (s *Int64Schema) IsOptional() bool      { return !s.Schema.required }
 func (s *Int64Schema) IsNullable() bool      { return s.nullable }
@@ -131,7 +250,38 @@ func (s *Int64Schema) GetMinimum() *int64    { return s.minimum }
 func (s *Int64Schema) GetMaximum() *int64    { return s.maximum }
 func (s *Int64Schema) GetMultipleOf() *int64 { return s.multipleOf }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *Int64Schema) Transform(fn TransformFunc) *Int64Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *Int64Schema) Refine(fn RefineFunc, errorMessage ...interface{}) *Int64Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *Int64Schema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *Int64Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 func (s *Int64Schema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the int64 constraints; Parse runs the refine/transform pipeline on top
+func (s *Int64Schema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceInt(value)
+	}
+
 	var errors []ValidationError
 
 	if value == nil {
@@ -140,16 +290,16 @@ func (s *Int64Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		}
 		if s.Schema.required {
 			if defaultVal := s.GetDefault(); defaultVal != nil {
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			message := int64RequiredError(ctx.Locale)
 			if !isEmptyErrorMessage(s.requiredError) {
 				message = resolveErrorMessage(s.requiredError, ctx)
 			}
-			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, "required")}}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
 		}
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
 	}
@@ -190,7 +340,7 @@ func (s *Int64Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.typeMismatchError) {
 			message = resolveErrorMessage(s.typeMismatchError, ctx)
 		}
-		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")}}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
 	}
 
 	finalValue := int64Value
@@ -200,7 +350,7 @@ func (s *Int64Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.minimumError) {
 			message = resolveErrorMessage(s.minimumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int64Value, message, "minimum"))
+		errors = append(errors, NewPrimitiveError(int64Value, message, CodeMinimum))
 	}
 
 	if s.maximum != nil && int64Value > *s.maximum {
@@ -208,7 +358,7 @@ func (s *Int64Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.maximumError) {
 			message = resolveErrorMessage(s.maximumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int64Value, message, "maximum"))
+		errors = append(errors, NewPrimitiveError(int64Value, message, CodeMaximum))
 	}
 
 	if s.multipleOf != nil && int64Value%*s.multipleOf != 0 {
@@ -216,7 +366,7 @@ func (s *Int64Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.multipleOfError) {
 			message = resolveErrorMessage(s.multipleOfError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int64Value, message, "multiple_of"))
+		errors = append(errors, NewPrimitiveError(int64Value, message, CodeMultipleOf))
 	}
 
 	if len(s.Schema.enum) > 0 {
@@ -232,7 +382,7 @@ func (s *Int64Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(int64Value, message, "enum"))
+			errors = append(errors, NewPrimitiveError(int64Value, message, CodeEnum))
 		}
 	}
 
@@ -242,7 +392,7 @@ func (s *Int64Schema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			if !isEmptyErrorMessage(s.constError) {
 				message = resolveErrorMessage(s.constError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(int64Value, message, "const"))
+			errors = append(errors, NewPrimitiveError(int64Value, message, CodeConst))
 		}
 	}
 
@@ -253,7 +403,8 @@ func (s *Int64Schema) JSON() map[string]interface{} {
 	schema := baseJSONSchema("integer")
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -276,3 +427,53 @@ func (s *Int64Schema) JSON() map[string]interface{} {
 
 	return schema
 }
+
+// Interface implementations for Int64Schema
+
+// SetTitle implements SetTitle interface
+func (s *Int64Schema) SetTitle(title string) {
+	s.Title(title)
+}
+
+// SetDescription implements SetDescription interface
+func (s *Int64Schema) SetDescription(description string) {
+	s.Description(description)
+}
+
+// SetRequired implements SetRequired interface
+func (s *Int64Schema) SetRequired() {
+	s.checkMutable()
+	s.Schema.required = true
+}
+
+// SetOptional implements SetOptional interface
+func (s *Int64Schema) SetOptional() {
+	s.Optional()
+}
+
+// SetMinimum implements SetMinimum interface
+func (s *Int64Schema) SetMinimum(min int) {
+	s.Min(int64(min))
+}
+
+// SetMaximum implements SetMaximum interface
+func (s *Int64Schema) SetMaximum(max int) {
+	s.Max(int64(max))
+}
+
+// SetNullable implements SetNullable interface
+func (s *Int64Schema) SetNullable() {
+	s.Nullable()
+}
+
+// SetDefault implements SetDefault interface
+func (s *Int64Schema) SetDefault(value interface{}) {
+	s.Default(value)
+}
+
+// SetExample implements SetExample interface
+func (s *Int64Schema) SetExample(example interface{}) {
+	if val, ok := example.(int64); ok {
+		s.Example(val)
+	}
+}
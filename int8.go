@@ -35,6 +35,7 @@ func int8ConstError(value int8) i18n.TranslatedFunc {
 // Int8Schema represents a JSON Schema for int8 values
 type Int8Schema struct {
 	Schema
+	coerce bool // Convert compatible input types before validating
 	// Int8-specific validation (private fields)
 	minimum    *int8
 	maximum    *int8
@@ -66,34 +67,116 @@ func Int8(errorMessage ...interface{}) *Int8Schema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *Int8Schema) Clone() *Int8Schema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *Int8Schema) Freeze() *Int8Schema {
+	s.freeze()
+	return s
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *Int8Schema) Title(title string) *Int8Schema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *Int8Schema) Description(description string) *Int8Schema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *Int8Schema) ReadOnly() *Int8Schema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *Int8Schema) WriteOnly() *Int8Schema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *Int8Schema) Sensitive() *Int8Schema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *Int8Schema) Deprecated(reason string) *Int8Schema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *Int8Schema) Meta(key string, value interface{}) *Int8Schema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *Int8Schema) AsWarning(codes ...ErrorCode) *Int8Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *Int8Schema) AsInfo(codes ...ErrorCode) *Int8Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *Int8Schema) Default(value interface{}) *Int8Schema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *Int8Schema) DefaultFunc(fn func() interface{}) *Int8Schema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *Int8Schema) Example(example int8) *Int8Schema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 // Enum sets the allowed enum values with optional custom error message
 func (s *Int8Schema) Enum(values []int8, errorMessage ...interface{}) *Int8Schema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -106,6 +189,7 @@ func (s *Int8Schema) Enum(values []int8, errorMessage ...interface{}) *Int8Schem
 
 // Const sets a constant value with optional custom error message
 func (s *Int8Schema) Const(value int8, errorMessage ...interface{}) *Int8Schema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -117,12 +201,14 @@ func (s *Int8Schema) Const(value int8, errorMessage ...interface{}) *Int8Schema
 
 // Optional marks the schema as optional
 func (s *Int8Schema) Optional() *Int8Schema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *Int8Schema) Required(errorMessage ...interface{}) *Int8Schema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -132,12 +218,27 @@ func (s *Int8Schema) Required(errorMessage ...interface{}) *Int8Schema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *Int8Schema) Nullable() *Int8Schema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
+// Coerce enables conversion of compatible input types (e.g. strings from query
+// parameters or environment variables) before validation
+func (s *Int8Schema) Coerce() *Int8Schema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *Int8Schema) coerces() bool {
+	return s.coerce
+}
+
 // TypeError sets a custom error message for type mismatch validation
 func (s *Int8Schema) TypeError(message string) *Int8Schema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -146,6 +247,7 @@ func (s *Int8Schema) TypeError(message string) *Int8Schema {
 
 // Min sets the minimum value constraint with optional custom error message
 func (s *Int8Schema) Min(min int8, errorMessage ...interface{}) *Int8Schema {
+	s.checkMutable()
 	s.minimum = &min
 	if len(errorMessage) > 0 {
 		s.minimumError = toErrorMessage(errorMessage[0])
@@ -155,6 +257,7 @@ func (s *Int8Schema) Min(min int8, errorMessage ...interface{}) *Int8Schema {
 
 // Max sets the maximum value constraint with optional custom error message
 func (s *Int8Schema) Max(max int8, errorMessage ...interface{}) *Int8Schema {
+	s.checkMutable()
 	s.maximum = &max
 	if len(errorMessage) > 0 {
 		s.maximumError = toErrorMessage(errorMessage[0])
@@ -164,6 +267,7 @@ func (s *Int8Schema) Max(max int8, errorMessage ...interface{}) *Int8Schema {
 
 // Range sets both minimum and maximum values with optional custom error message
 func (s *Int8Schema) Range(min, max int8, errorMessage ...interface{}) *Int8Schema {
+	s.checkMutable()
 	s.minimum = &min
 	s.maximum = &max
 	if len(errorMessage) > 0 {
@@ -175,6 +279,7 @@ func (s *Int8Schema) Range(min, max int8, errorMessage ...interface{}) *Int8Sche
 
 // MultipleOf sets the multiple constraint with optional custom error message
 func (s *Int8Schema) MultipleOf(multiple int8, errorMessage ...interface{}) *Int8Schema {
+	s.checkMutable()
 	s.multipleOf = &multiple
 	if len(errorMessage) > 0 {
 		s.multipleOfError = toErrorMessage(errorMessage[0])
@@ -182,6 +287,16 @@ func (s *Int8Schema) MultipleOf(multiple int8, errorMessage ...interface{}) *Int
 	return s
 }
 
+// Positive requires the value to be at least 1, with optional custom error message
+func (s *Int8Schema) Positive(errorMessage ...interface{}) *Int8Schema {
+	return s.Min(1, errorMessage...)
+}
+
+// NonNegative requires the value to be zero or greater, with optional custom error message
+func (s *Int8Schema) NonNegative(errorMessage ...interface{}) *Int8Schema {
+	return s.Min(0, errorMessage...)
+}
+
 // Getters for accessing private fields
 
 // IsRequired returns whether the schema is marked as required
@@ -216,18 +331,47 @@ func (s *Int8Schema) GetMultipleOf() *int8 {
 
 // GetDefault returns the default value as an int8
 func (s *Int8Schema) GetDefaultInt8() *int8 {
-	if s.GetDefault() != nil {
-		if i, ok := s.GetDefault().(int8); ok {
-			return &i
-		}
+	if i, ok := s.GetDefault().(int8); ok {
+		return &i
 	}
 	return nil
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *Int8Schema) Transform(fn TransformFunc) *Int8Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *Int8Schema) Refine(fn RefineFunc, errorMessage ...interface{}) *Int8Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *Int8Schema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *Int8Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses an int8 value, returning the final parsed value
 func (s *Int8Schema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the int8 constraints; Parse runs the refine/transform pipeline on top
+func (s *Int8Schema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceInt(value)
+	}
+
 	var errors []ValidationError
 
 	// Handle nil values
@@ -240,7 +384,7 @@ func (s *Int8Schema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := int8RequiredError(ctx.Locale)
@@ -250,12 +394,12 @@ func (s *Int8Schema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -327,7 +471,7 @@ func (s *Int8Schema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
@@ -340,7 +484,7 @@ func (s *Int8Schema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 		if !isEmptyErrorMessage(s.minimumError) {
 			message = resolveErrorMessage(s.minimumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int8Value, message, "minimum"))
+		errors = append(errors, NewPrimitiveError(int8Value, message, CodeMinimum))
 	}
 
 	// Check maximum
@@ -349,7 +493,7 @@ func (s *Int8Schema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 		if !isEmptyErrorMessage(s.maximumError) {
 			message = resolveErrorMessage(s.maximumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int8Value, message, "maximum"))
+		errors = append(errors, NewPrimitiveError(int8Value, message, CodeMaximum))
 	}
 
 	// Check multipleOf
@@ -358,7 +502,7 @@ func (s *Int8Schema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 		if !isEmptyErrorMessage(s.multipleOfError) {
 			message = resolveErrorMessage(s.multipleOfError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(int8Value, message, "multiple_of"))
+		errors = append(errors, NewPrimitiveError(int8Value, message, CodeMultipleOf))
 	}
 
 	// Check enum
@@ -375,7 +519,7 @@ func (s *Int8Schema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(int8Value, message, "enum"))
+			errors = append(errors, NewPrimitiveError(int8Value, message, CodeEnum))
 		}
 	}
 
@@ -386,7 +530,7 @@ func (s *Int8Schema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.constError) {
 				message = resolveErrorMessage(s.constError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(int8Value, message, "const"))
+			errors = append(errors, NewPrimitiveError(int8Value, message, CodeConst))
 		}
 	}
 
@@ -404,7 +548,8 @@ func (s *Int8Schema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -451,3 +596,56 @@ func (s *Int8Schema) MarshalJSON() ([]byte, error) {
 		Nullable:   s.nullable,
 	})
 }
+
+// Interface implementations for Int8Schema
+
+// SetTitle implements SetTitle interface
+func (s *Int8Schema) SetTitle(title string) {
+	s.Title(title)
+}
+
+// SetDescription implements SetDescription interface
+func (s *Int8Schema) SetDescription(description string) {
+	s.Description(description)
+}
+
+// SetRequired implements SetRequired interface
+func (s *Int8Schema) SetRequired() {
+	s.Required()
+}
+
+// SetOptional implements SetOptional interface
+func (s *Int8Schema) SetOptional() {
+	s.Optional()
+}
+
+// SetMinimum implements SetMinimum interface; out-of-range bounds are ignored
+func (s *Int8Schema) SetMinimum(min int) {
+	if min >= math.MinInt8 && min <= math.MaxInt8 {
+		s.Min(int8(min))
+	}
+}
+
+// SetMaximum implements SetMaximum interface; out-of-range bounds are ignored
+func (s *Int8Schema) SetMaximum(max int) {
+	if max >= math.MinInt8 && max <= math.MaxInt8 {
+		s.Max(int8(max))
+	}
+}
+
+// SetNullable implements SetNullable interface
+func (s *Int8Schema) SetNullable() {
+	s.Nullable()
+}
+
+// SetDefault implements SetDefault interface
+func (s *Int8Schema) SetDefault(value interface{}) {
+	s.Default(value)
+}
+
+// SetExample implements SetExample interface
+func (s *Int8Schema) SetExample(example interface{}) {
+	if val, ok := example.(int8); ok {
+		s.Example(val)
+	}
+}
@@ -374,3 +374,89 @@ func TestIntegerSchemas_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegerSchemas_Helpers(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   Parseable
+		value    interface{}
+		expected bool
+	}{
+		{"int positive", Int().Positive(), 1, true},
+		{"int positive zero", Int().Positive(), 0, false},
+		{"int non-negative zero", Int().NonNegative(), 0, true},
+		{"int non-negative below", Int().NonNegative(), -1, false},
+		{"int8 positive", Int8().Positive(), -3, false},
+		{"int16 non-negative", Int16().NonNegative(), int16(5), true},
+		{"int32 port", Int32().Port(), 8080, true},
+		{"int64 port zero", Int64().Port(), 0, false},
+		{"int port too large", Int().Port(), 65536, false},
+		{"uint16 port", Uint16().Port(), 443, true},
+		{"uint positive zero", Uint().Positive(), uint(0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Errorf("Parse(%v) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+		})
+	}
+}
+
+func TestUintSchemas(t *testing.T) {
+	ctx := DefaultValidationContext()
+	type port uint16
+
+	tests := []struct {
+		name     string
+		schema   Parseable
+		value    interface{}
+		expected bool
+		want     interface{}
+	}{
+		{"uint from uint", Uint(), uint(7), true, uint(7)},
+		{"uint from int", Uint(), 7, true, uint(7)},
+		{"uint from whole float", Uint(), 7.0, true, uint(7)},
+		{"uint negative", Uint(), -1, false, nil},
+		{"uint fraction", Uint(), 1.5, false, nil},
+		{"uint string", Uint(), "7", false, nil},
+		{"uint8 max", Uint8(), 255, true, uint8(255)},
+		{"uint8 overflow", Uint8(), 256, false, nil},
+		{"uint8 from uint64", Uint8(), uint64(200), true, uint8(200)},
+		{"uint16 overflow", Uint16(), uint32(70000), false, nil},
+		{"uint16 named type", Uint16(), port(8080), true, uint16(8080)},
+		{"uint32 max", Uint32(), uint64(math.MaxUint32), true, uint32(math.MaxUint32)},
+		{"uint32 overflow", Uint32(), int64(math.MaxUint32) + 1, false, nil},
+		{"uint64 max", Uint64(), uint64(math.MaxUint64), true, uint64(math.MaxUint64)},
+		{"uint64 float overflow", Uint64(), 1.8446744073709552e19, false, nil},
+		{"uint64 coerce", Uint64().Coerce(), "18446744073709551615", true, uint64(math.MaxUint64)},
+		{"uint8 min", Uint8().Min(10), 5, false, nil},
+		{"uint32 max constraint", Uint32().Max(10), 11, false, nil},
+		{"uint multiple of", Uint().MultipleOf(5), 12, false, nil},
+		{"uint16 enum", Uint16().Enum([]uint16{80, 443}), 443, true, uint16(443)},
+		{"uint64 const", Uint64().Const(3), 4, false, nil},
+		{"uint optional nil", Uint().Optional(), nil, true, nil},
+		{"uint default", Uint8().Optional().Default(uint8(9)), nil, true, uint8(9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.expected && result.Value != tt.want {
+				t.Errorf("value = %#v, want %#v", result.Value, tt.want)
+			}
+		})
+	}
+
+	doc := Uint16().Max(100).JSON()
+	if doc["minimum"] != 0 || doc["maximum"] != 100 || doc["format"] != "uint16" {
+		t.Errorf("JSON() = %v", doc)
+	}
+}
@@ -0,0 +1,910 @@
+package schema
+
+import (
+	"net"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// IPVersion selects the IP address family accepted by IP and CIDR schemas
+type IPVersion int
+
+const (
+	IPVersionAny IPVersion = 0 // Accept IPv4 and IPv6
+	IPVersion4   IPVersion = 4 // Accept dotted-decimal IPv4 only
+	IPVersion6   IPVersion = 6 // Accept IPv6 only (including compressed and IPv4-mapped forms)
+)
+
+// Default error messages for network address validation
+var (
+	ipRequiredError   = i18n.S("value is required")
+	ipTypeError       = i18n.S("value must be a string")
+	ipFormatError     = i18n.S("value must be a valid IP address")
+	ipv4FormatError   = i18n.S("value must be a valid IPv4 address")
+	ipv6FormatError   = i18n.S("value must be a valid IPv6 address")
+	cidrFormatError   = i18n.S("value must be a valid CIDR block")
+	cidrv4FormatError = i18n.S("value must be a valid IPv4 CIDR block")
+	cidrv6FormatError = i18n.S("value must be a valid IPv6 CIDR block")
+	cidrHostBitsError = i18n.S("value must be a network address without host bits set")
+	macFormatError    = i18n.S("value must be a valid MAC address")
+)
+
+// ipFormatMessage returns the format error for an IP version
+func ipFormatMessage(version IPVersion) i18n.TranslatedFunc {
+	switch version {
+	case IPVersion4:
+		return ipv4FormatError
+	case IPVersion6:
+		return ipv6FormatError
+	}
+	return ipFormatError
+}
+
+// cidrFormatMessage returns the format error for a CIDR version
+func cidrFormatMessage(version IPVersion) i18n.TranslatedFunc {
+	switch version {
+	case IPVersion4:
+		return cidrv4FormatError
+	case IPVersion6:
+		return cidrv6FormatError
+	}
+	return cidrFormatError
+}
+
+// parseIPVersion parses an IP address with net.ParseIP and checks its family. The
+// family is decided by the notation: "::ffff:10.0.0.1" is an IPv6 address.
+func parseIPVersion(str string, version IPVersion) (net.IP, bool) {
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return nil, false
+	}
+	isV6 := strings.Contains(str, ":")
+	switch version {
+	case IPVersion4:
+		return ip.To4(), !isV6
+	case IPVersion6:
+		return ip, isV6
+	}
+	if !isV6 {
+		return ip.To4(), true
+	}
+	return ip, true
+}
+
+// parseNetworkNil handles a nil value for the network address schemas: nullable
+// schemas accept it, otherwise the default is parsed in its place, and a required
+// schema without a default fails
+func parseNetworkNil(s *Schema, nullable bool, requiredError ErrorMessage, parse func(interface{}, *ValidationContext) ParseResult, ctx *ValidationContext) ParseResult {
+	if nullable {
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+	if defaultVal := s.GetDefault(); defaultVal != nil {
+		return parse(defaultVal, ctx)
+	}
+	if s.required {
+		message := ipRequiredError(ctx.Locale)
+		if !isEmptyErrorMessage(requiredError) {
+			message = resolveErrorMessage(requiredError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(nil, message, CodeRequired)}}
+	}
+	return ParseResult{Valid: true, Value: nil, Errors: nil}
+}
+
+// ipVersionJSON adds the format keywords for an IP version to a JSON Schema
+func ipVersionJSON(schema map[string]interface{}, version IPVersion) {
+	switch version {
+	case IPVersion4:
+		schema["format"] = "ipv4"
+	case IPVersion6:
+		schema["format"] = "ipv6"
+	default:
+		schema["anyOf"] = []interface{}{
+			map[string]interface{}{"format": "ipv4"},
+			map[string]interface{}{"format": "ipv6"},
+		}
+	}
+}
+
+// IPSchema validates IP addresses with net.ParseIP and returns the parsed net.IP
+// (4 bytes for IPv4 addresses)
+type IPSchema struct {
+	Schema
+	version  IPVersion
+	nullable bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// IP creates a new schema accepting IPv4 and IPv6 addresses
+func IP(errorMessage ...interface{}) *IPSchema {
+	schema := &IPSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *IPSchema) Clone() *IPSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *IPSchema) Freeze() *IPSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *IPSchema) Title(title string) *IPSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *IPSchema) Description(description string) *IPSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *IPSchema) ReadOnly() *IPSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *IPSchema) WriteOnly() *IPSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *IPSchema) Sensitive() *IPSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *IPSchema) Deprecated(reason string) *IPSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *IPSchema) Meta(key string, value interface{}) *IPSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *IPSchema) AsWarning(codes ...ErrorCode) *IPSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *IPSchema) AsInfo(codes ...ErrorCode) *IPSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *IPSchema) Default(value interface{}) *IPSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *IPSchema) DefaultFunc(fn func() interface{}) *IPSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *IPSchema) Example(example string) *IPSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Version restricts the accepted address family
+func (s *IPSchema) Version(version IPVersion) *IPSchema {
+	s.checkMutable()
+	s.version = version
+	return s
+}
+
+// V4 accepts IPv4 addresses only
+func (s *IPSchema) V4() *IPSchema {
+	return s.Version(IPVersion4)
+}
+
+// V6 accepts IPv6 addresses only
+func (s *IPSchema) V6() *IPSchema {
+	return s.Version(IPVersion6)
+}
+
+// Optional marks the schema as optional
+func (s *IPSchema) Optional() *IPSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *IPSchema) Required(errorMessage ...interface{}) *IPSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *IPSchema) Nullable() *IPSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *IPSchema) TypeError(message string) *IPSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for invalid addresses
+func (s *IPSchema) FormatError(message string) *IPSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// IsRequired returns whether the schema is marked as required
+func (s *IPSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *IPSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *IPSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetVersion returns the accepted address family
+func (s *IPSchema) GetVersion() IPVersion {
+	return s.version
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *IPSchema) Transform(fn TransformFunc) *IPSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *IPSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *IPSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *IPSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *IPSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates an IP address string (or net.IP) and returns the parsed net.IP
+func (s *IPSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the IP constraints; Parse runs the refine/transform pipeline on top
+func (s *IPSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	if value == nil {
+		return parseNetworkNil(&s.Schema, s.nullable, s.requiredError, s.parse, ctx)
+	}
+
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case net.IP:
+		str = v.String()
+	default:
+		message := ipTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	ip, ok := parseIPVersion(str, s.version)
+	if !ok {
+		message := ipFormatMessage(s.version)(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+	return ParseResult{Valid: true, Value: ip, Errors: nil}
+}
+
+// JSON generates JSON Schema representation
+func (s *IPSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	ipVersionJSON(schema, s.version)
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
+
+// CIDRSchema validates CIDR blocks such as "10.0.0.0/8" with net.ParseCIDR and
+// returns the parsed *net.IPNet
+type CIDRSchema struct {
+	Schema
+	version  IPVersion
+	strict   bool // Reject addresses with host bits set ("10.0.0.1/8")
+	nullable bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	hostBitsError     ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// CIDR creates a new schema accepting IPv4 and IPv6 CIDR blocks
+func CIDR(errorMessage ...interface{}) *CIDRSchema {
+	schema := &CIDRSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *CIDRSchema) Clone() *CIDRSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *CIDRSchema) Freeze() *CIDRSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *CIDRSchema) Title(title string) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *CIDRSchema) Description(description string) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *CIDRSchema) ReadOnly() *CIDRSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *CIDRSchema) WriteOnly() *CIDRSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *CIDRSchema) Sensitive() *CIDRSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *CIDRSchema) Deprecated(reason string) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *CIDRSchema) Meta(key string, value interface{}) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// Default sets the default value
+func (s *CIDRSchema) Default(value interface{}) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *CIDRSchema) DefaultFunc(fn func() interface{}) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *CIDRSchema) Example(example string) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Version restricts the accepted address family
+func (s *CIDRSchema) Version(version IPVersion) *CIDRSchema {
+	s.checkMutable()
+	s.version = version
+	return s
+}
+
+// V4 accepts IPv4 blocks only
+func (s *CIDRSchema) V4() *CIDRSchema {
+	return s.Version(IPVersion4)
+}
+
+// V6 accepts IPv6 blocks only
+func (s *CIDRSchema) V6() *CIDRSchema {
+	return s.Version(IPVersion6)
+}
+
+// Strict rejects blocks whose address has host bits set, such as "10.0.0.1/8",
+// with optional custom error message
+func (s *CIDRSchema) Strict(errorMessage ...interface{}) *CIDRSchema {
+	s.checkMutable()
+	s.strict = true
+	if len(errorMessage) > 0 {
+		s.hostBitsError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *CIDRSchema) Optional() *CIDRSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *CIDRSchema) Required(errorMessage ...interface{}) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *CIDRSchema) Nullable() *CIDRSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *CIDRSchema) TypeError(message string) *CIDRSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for invalid blocks
+func (s *CIDRSchema) FormatError(message string) *CIDRSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// IsRequired returns whether the schema is marked as required
+func (s *CIDRSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *CIDRSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *CIDRSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetVersion returns the accepted address family
+func (s *CIDRSchema) GetVersion() IPVersion {
+	return s.version
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *CIDRSchema) Transform(fn TransformFunc) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *CIDRSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *CIDRSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *CIDRSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a CIDR string (or *net.IPNet) and returns the parsed *net.IPNet
+func (s *CIDRSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the CIDR constraints; Parse runs the refine/transform pipeline on top
+func (s *CIDRSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	if value == nil {
+		return parseNetworkNil(&s.Schema, s.nullable, s.requiredError, s.parse, ctx)
+	}
+
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case *net.IPNet:
+		str = v.String()
+	default:
+		message := ipTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	ip, network, err := net.ParseCIDR(str)
+	isV6 := strings.Contains(str, ":")
+	if err != nil || (s.version == IPVersion4 && isV6) || (s.version == IPVersion6 && !isV6) {
+		message := cidrFormatMessage(s.version)(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+
+	if s.strict && !ip.Equal(network.IP) {
+		message := cidrHostBitsError(ctx.Locale)
+		if !isEmptyErrorMessage(s.hostBitsError) {
+			message = resolveErrorMessage(s.hostBitsError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeHostBits)}}
+	}
+	return ParseResult{Valid: true, Value: network, Errors: nil}
+}
+
+// JSON generates JSON Schema representation; "cidr" is a custom format
+func (s *CIDRSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["format"] = "cidr"
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
+
+// MACAddressSchema validates hardware addresses with net.ParseMAC (EUI-48, EUI-64
+// and 20-octet InfiniBand addresses, separated by colons, hyphens or dots) and
+// returns the parsed net.HardwareAddr
+type MACAddressSchema struct {
+	Schema
+	nullable bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// MACAddress creates a new MAC address schema
+func MACAddress(errorMessage ...interface{}) *MACAddressSchema {
+	schema := &MACAddressSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *MACAddressSchema) Clone() *MACAddressSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *MACAddressSchema) Freeze() *MACAddressSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *MACAddressSchema) Title(title string) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *MACAddressSchema) Description(description string) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *MACAddressSchema) ReadOnly() *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *MACAddressSchema) WriteOnly() *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *MACAddressSchema) Sensitive() *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *MACAddressSchema) Deprecated(reason string) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *MACAddressSchema) Meta(key string, value interface{}) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// Default sets the default value
+func (s *MACAddressSchema) Default(value interface{}) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *MACAddressSchema) DefaultFunc(fn func() interface{}) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *MACAddressSchema) Example(example string) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *MACAddressSchema) Optional() *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *MACAddressSchema) Required(errorMessage ...interface{}) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *MACAddressSchema) Nullable() *MACAddressSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *MACAddressSchema) TypeError(message string) *MACAddressSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for invalid addresses
+func (s *MACAddressSchema) FormatError(message string) *MACAddressSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// IsRequired returns whether the schema is marked as required
+func (s *MACAddressSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *MACAddressSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *MACAddressSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *MACAddressSchema) Transform(fn TransformFunc) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *MACAddressSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *MACAddressSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *MACAddressSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a MAC address string (or net.HardwareAddr) and returns the parsed net.HardwareAddr
+func (s *MACAddressSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the MAC address constraints; Parse runs the refine/transform pipeline on top
+func (s *MACAddressSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	if value == nil {
+		return parseNetworkNil(&s.Schema, s.nullable, s.requiredError, s.parse, ctx)
+	}
+
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case net.HardwareAddr:
+		str = v.String()
+	default:
+		message := ipTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	mac, err := net.ParseMAC(str)
+	if err != nil {
+		message := macFormatError(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+	return ParseResult{Valid: true, Value: mac, Errors: nil}
+}
+
+// JSON generates JSON Schema representation; "mac" is a custom format
+func (s *MACAddressSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["format"] = "mac"
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
@@ -0,0 +1,157 @@
+package schema
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestIPSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *IPSchema
+		value    interface{}
+		expected bool
+		code     ErrorCode
+	}{
+		{"ipv4", IP(), "192.168.1.1", true, ""},
+		{"ipv6 compressed", IP(), "2001:db8::8a2e:370:7334", true, ""},
+		{"ipv6 loopback", IP(), "::1", true, ""},
+		{"ipv4-mapped ipv6", IP(), "::ffff:10.0.0.1", true, ""},
+		{"net.IP input", IP(), net.ParseIP("10.0.0.1"), true, ""},
+		{"out of range octet", IP(), "256.1.1.1", false, "format"},
+		{"leading zero octet", IP(), "010.0.0.1", false, "format"},
+		{"double compression", IP(), "2001:db8::1::1", false, "format"},
+		{"zone not accepted", IP(), "fe80::1%eth0", false, "format"},
+		{"not a string", IP(), 42, false, "invalid_type"},
+		{"v4 accepts ipv4", IP().V4(), "8.8.8.8", true, ""},
+		{"v4 rejects ipv6", IP().V4(), "2001:db8::1", false, "format"},
+		{"v4 rejects mapped", IP().V4(), "::ffff:8.8.8.8", false, "format"},
+		{"v6 accepts compressed", IP().V6(), "fe80::1", true, ""},
+		{"v6 rejects ipv4", IP().V6(), "8.8.8.8", false, "format"},
+		{"required nil", IP(), nil, false, "required"},
+		{"optional nil", IP().Optional(), nil, true, ""},
+		{"nullable nil", IP().Nullable(), nil, true, ""},
+		{"default", IP().Optional().Default("127.0.0.1"), nil, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	result := IP().Parse("192.168.1.1", ctx)
+	if ip, ok := result.Value.(net.IP); !ok || len(ip) != net.IPv4len || !ip.Equal(net.IPv4(192, 168, 1, 1)) {
+		t.Errorf("Value = %#v, want 4-byte net.IP", result.Value)
+	}
+	if ip := IP().Parse("2001:db8::1", ctx).Value.(net.IP); len(ip) != net.IPv6len {
+		t.Errorf("Value = %#v, want 16-byte net.IP", ip)
+	}
+
+	if result := IP().V6().Parse("1.2.3.4", ctx); result.Errors[0].Message != "value must be a valid IPv6 address" {
+		t.Errorf("message = %q", result.Errors[0].Message)
+	}
+	if result := IP("bad address").Parse("x", ctx); result.Errors[0].Message != "bad address" {
+		t.Errorf("message = %q", result.Errors[0].Message)
+	}
+}
+
+func TestCIDRSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *CIDRSchema
+		value    interface{}
+		expected bool
+		code     ErrorCode
+	}{
+		{"ipv4 block", CIDR(), "10.0.0.0/8", true, ""},
+		{"ipv6 block", CIDR(), "2001:db8::/32", true, ""},
+		{"host bits allowed", CIDR(), "10.0.0.1/8", true, ""},
+		{"missing prefix", CIDR(), "10.0.0.0", false, "format"},
+		{"prefix too long", CIDR(), "10.0.0.0/33", false, "format"},
+		{"not a string", CIDR(), true, false, "invalid_type"},
+		{"v4 rejects ipv6", CIDR().V4(), "2001:db8::/32", false, "format"},
+		{"v6 rejects ipv4", CIDR().V6(), "10.0.0.0/8", false, "format"},
+		{"strict network", CIDR().Strict(), "192.168.0.0/16", true, ""},
+		{"strict host bits", CIDR().Strict(), "192.168.1.1/16", false, "host_bits"},
+		{"optional nil", CIDR().Optional(), nil, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	network, ok := CIDR().Parse("10.1.2.3/8", ctx).Value.(*net.IPNet)
+	if !ok || network.String() != "10.0.0.0/8" {
+		t.Errorf("Value = %v, want 10.0.0.0/8", network)
+	}
+	if !CIDR().Parse(network, ctx).Valid {
+		t.Error("expected *net.IPNet input to be valid")
+	}
+}
+
+func TestMACAddressSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	for _, valid := range []string{"00:1a:2b:3c:4d:5e", "00-1A-2B-3C-4D-5E", "001a.2b3c.4d5e", "00:1a:2b:ff:fe:3c:4d:5e"} {
+		result := MACAddress().Parse(valid, ctx)
+		if !result.Valid {
+			t.Errorf("expected %q to be valid, got %v", valid, result.Errors)
+			continue
+		}
+		if _, ok := result.Value.(net.HardwareAddr); !ok {
+			t.Errorf("Value = %#v, want net.HardwareAddr", result.Value)
+		}
+	}
+	for _, invalid := range []string{"00:1a:2b:3c:4d", "00:1a:2b:3c:4d:zz", "00:1a-2b:3c:4d:5e"} {
+		if result := MACAddress().Parse(invalid, ctx); result.Valid || result.Errors[0].Code != "format" {
+			t.Errorf("expected %q to fail with format, got %v", invalid, result.Errors)
+		}
+	}
+	if result := MACAddress().Parse(nil, ctx); result.Valid || result.Errors[0].Code != "required" {
+		t.Errorf("expected required error, got %v", result.Errors)
+	}
+}
+
+func TestNetworkSchemas_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  map[string]interface{}
+		want map[string]interface{}
+	}{
+		{"ip v4", IP().V4().JSON(), map[string]interface{}{"type": "string", "format": "ipv4"}},
+		{"ip v6", IP().V6().JSON(), map[string]interface{}{"type": "string", "format": "ipv6"}},
+		{"ip any", IP().JSON(), map[string]interface{}{"type": "string", "anyOf": []interface{}{
+			map[string]interface{}{"format": "ipv4"},
+			map[string]interface{}{"format": "ipv6"},
+		}}},
+		{"cidr", CIDR().JSON(), map[string]interface{}{"type": "string", "format": "cidr"}},
+		{"mac", MACAddress().Nullable().JSON(), map[string]interface{}{"type": []string{"string", "null"}, "format": "mac"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.doc, tt.want) {
+				t.Errorf("JSON() = %v, want %v", tt.doc, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package schema
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 )
 
 // JSONSchemaGenerator interface for types that can generate JSON Schema
@@ -20,6 +22,246 @@ func JSON(s JSONSchemaGenerator) ([]byte, error) {
 	return json.MarshalIndent(schema, "", "  ")
 }
 
+// jsonGeneration is the state of one JSON Schema generation, passed down to the
+// nested schemas so that a recursive Lazy schema is emitted once under $defs and
+// referenced with $ref, without the schemas themselves keeping any state
+type jsonGeneration struct {
+	root       *LazySchema            // The Lazy schema generated as the document, referenced with "#"
+	generating map[*LazySchema]bool   // The Lazy schemas whose JSON Schema is being generated
+	names      map[*LazySchema]string // The $defs names of the recursive Lazy schemas
+	defs       map[string]interface{} // The definitions of the recursive Lazy schemas
+}
+
+// nestedJSONGenerator is implemented by the schemas generating the JSON Schema of
+// nested schemas
+type nestedJSONGenerator interface {
+	generateJSON(g *jsonGeneration) map[string]interface{}
+}
+
+// childJSON returns the JSON Schema of a schema nested in the one being generated by
+// g, and whether it generates one
+func childJSON(s interface{}, g *jsonGeneration) (map[string]interface{}, bool) {
+	switch generator := s.(type) {
+	case nestedJSONGenerator:
+		return generator.generateJSON(g), true
+	case JSONSchemaGenerator:
+		return generator.JSON(), true
+	}
+	return nil, false
+}
+
+// rootJSON returns the JSON Schema document generated by generate, with the
+// definitions of the recursive Lazy schemas it references under $defs
+func rootJSON(generate func(g *jsonGeneration) map[string]interface{}) map[string]interface{} {
+	g := &jsonGeneration{}
+	schema := generate(g)
+	if len(g.defs) == 0 {
+		return schema
+	}
+	defs := map[string]interface{}{}
+	if existing, ok := schema["$defs"].(map[string]interface{}); ok {
+		for name, def := range existing {
+			defs[name] = def
+		}
+	}
+	for name, def := range g.defs {
+		defs[name] = def
+	}
+	schema["$defs"] = defs
+	return schema
+}
+
+// JSONSchemaDraft selects the JSON Schema dialect of generated documents
+type JSONSchemaDraft string
+
+// Supported JSON Schema drafts
+const (
+	Draft2020 JSONSchemaDraft = "2020-12"
+	Draft07   JSONSchemaDraft = "draft-07"
+)
+
+// schemaURIs are the $schema values of the supported drafts
+var schemaURIs = map[JSONSchemaDraft]string{
+	Draft2020: "https://json-schema.org/draft/2020-12/schema",
+	Draft07:   "http://json-schema.org/draft-07/schema#",
+}
+
+// JSONSchemaOptions controls JSONWithOptions
+type JSONSchemaOptions struct {
+	Draft            JSONSchemaDraft // Keyword dialect, Draft2020 when empty
+	IncludeSchemaURI bool            // Add "$schema" with the draft's URI
+	// SortKeys makes the output independent of the order in which properties and
+	// types were declared by also sorting "required" and "type" lists (object keys
+	// are always sorted), which keeps golden files stable
+	SortKeys bool
+	Indent   string // Indentation per level, "  " when empty; use "\t" or any other string
+}
+
+// JSONWithOptions converts a schema to JSON Schema bytes for the selected draft:
+// tuples use "prefixItems" and "items" in 2020-12 and an "items" array with
+// "additionalItems" in draft-07, definitions live under "$defs" or "definitions",
+// and dependentRequired/dependentSchemas become "dependencies" in draft-07.
+func JSONWithOptions(s JSONSchemaGenerator, opts JSONSchemaOptions) ([]byte, error) {
+	return json.MarshalIndent(JSONSchemaWithOptions(s, opts), "", jsonIndent(opts.Indent))
+}
+
+// JSONSchemaWithOptions returns the JSON Schema document JSONWithOptions encodes
+func JSONSchemaWithOptions(s JSONSchemaGenerator, opts JSONSchemaOptions) map[string]interface{} {
+	draft := opts.Draft
+	if draft == "" {
+		draft = Draft2020
+	}
+	doc := convertDraft(normalizeJSONSchema(s.JSON()), draft, opts.SortKeys).(map[string]interface{})
+	if opts.IncludeSchemaURI {
+		doc["$schema"] = schemaURIs[draft]
+	}
+	return doc
+}
+
+// jsonIndent returns the indentation used by JSONWithOptions
+func jsonIndent(indent string) string {
+	if indent == "" {
+		return "  "
+	}
+	return indent
+}
+
+// convertDraft rewrites the draft-specific keywords of a normalized JSON Schema
+func convertDraft(node interface{}, draft JSONSchemaDraft, sortLists bool) interface{} {
+	switch n := node.(type) {
+	case []interface{}:
+		result := make([]interface{}, len(n))
+		for i, item := range n {
+			result[i] = convertDraft(item, draft, sortLists)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(n))
+		for key, value := range n {
+			switch key {
+			case "enum", "const", "default", "examples":
+				result[key] = value // Instance data, not schemas
+			case "$ref":
+				ref, _ := value.(string)
+				result[key] = convertPointer(ref, draft)
+			case "properties", "patternProperties", "$defs", "definitions", "dependentSchemas", "dependencies":
+				// Maps from names to schemas, whose keys must not be mistaken for keywords
+				if named, ok := value.(map[string]interface{}); ok {
+					converted := make(map[string]interface{}, len(named))
+					for name, child := range named {
+						converted[name] = convertDraft(child, draft, sortLists)
+					}
+					result[key] = converted
+					continue
+				}
+				result[key] = convertDraft(value, draft, sortLists)
+			default:
+				result[key] = convertDraft(value, draft, sortLists)
+			}
+		}
+		if draft == Draft07 {
+			convertTo07(result)
+		} else {
+			convertTo2020(result)
+		}
+		if sortLists {
+			for _, key := range []string{"required", "type"} {
+				if list, ok := result[key].([]interface{}); ok {
+					sortStrings(list)
+				}
+			}
+		}
+		return result
+	}
+	return node
+}
+
+// convertTo2020 rewrites draft-07 keywords of a single schema to 2020-12
+func convertTo2020(schema map[string]interface{}) {
+	if items, ok := schema["items"].([]interface{}); ok {
+		schema["prefixItems"] = items
+		delete(schema, "items")
+		if additional, ok := schema["additionalItems"]; ok {
+			if additional != true {
+				schema["items"] = additional
+			}
+			delete(schema, "additionalItems")
+		}
+	}
+	if defs, ok := schema["definitions"].(map[string]interface{}); ok {
+		if _, exists := schema["$defs"]; !exists {
+			schema["$defs"] = defs
+			delete(schema, "definitions")
+		}
+	}
+	if deps, ok := schema["dependencies"].(map[string]interface{}); ok {
+		required, schemas := map[string]interface{}{}, map[string]interface{}{}
+		for name, dep := range deps {
+			if _, ok := dep.([]interface{}); ok {
+				required[name] = dep
+			} else {
+				schemas[name] = dep
+			}
+		}
+		if len(required) > 0 {
+			schema["dependentRequired"] = required
+		}
+		if len(schemas) > 0 {
+			schema["dependentSchemas"] = schemas
+		}
+		delete(schema, "dependencies")
+	}
+}
+
+// convertTo07 rewrites 2020-12 keywords of a single schema to draft-07
+func convertTo07(schema map[string]interface{}) {
+	if prefix, ok := schema["prefixItems"].([]interface{}); ok {
+		if items, ok := schema["items"]; ok {
+			schema["additionalItems"] = items
+		}
+		schema["items"] = prefix
+		delete(schema, "prefixItems")
+	}
+	if defs, ok := schema["$defs"].(map[string]interface{}); ok {
+		if _, exists := schema["definitions"]; !exists {
+			schema["definitions"] = defs
+			delete(schema, "$defs")
+		}
+	}
+	deps := map[string]interface{}{}
+	for _, key := range []string{"dependentRequired", "dependentSchemas"} {
+		if values, ok := schema[key].(map[string]interface{}); ok {
+			for name, dep := range values {
+				deps[name] = dep
+			}
+			delete(schema, key)
+		}
+	}
+	if len(deps) > 0 {
+		schema["dependencies"] = deps
+	}
+}
+
+// convertPointer rewrites references into the definitions section of another draft
+func convertPointer(value string, draft JSONSchemaDraft) string {
+	if draft == Draft07 && strings.HasPrefix(value, "#/$defs/") {
+		return "#/definitions/" + value[len("#/$defs/"):]
+	}
+	if draft != Draft07 && strings.HasPrefix(value, "#/definitions/") {
+		return "#/$defs/" + value[len("#/definitions/"):]
+	}
+	return value
+}
+
+// sortStrings sorts a decoded JSON list of strings in place
+func sortStrings(list []interface{}) {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, _ := list[i].(string)
+		b, _ := list[j].(string)
+		return a < b
+	})
+}
+
 // Helper functions for common JSON Schema patterns
 
 // baseJSONSchema creates a basic JSON Schema with type
@@ -87,3 +329,134 @@ func addDescription(schema map[string]interface{}, description string) {
 		schema["description"] = description
 	}
 }
+
+// addAnnotations adds the readOnly, writeOnly and deprecated annotations that are set,
+// and the metadata as x- keywords
+func addAnnotations(schema map[string]interface{}, s *Schema) {
+	if s.readOnly {
+		schema["readOnly"] = true
+	}
+	if s.writeOnly {
+		schema["writeOnly"] = true
+	}
+	if s.deprecated {
+		schema["deprecated"] = true
+		if s.deprecationReason != "" {
+			schema["x-deprecation-reason"] = s.deprecationReason
+		}
+	}
+	for key, value := range s.meta {
+		schema[metaKeyword(key)] = value
+	}
+}
+
+// jsonWithRefs rewrites the JSON Schema doc so that every subtree identical to the
+// JSON Schema of a definition in registry becomes a "$ref" to "#/$defs/<name>", and
+// references to the registry ("#/<name>") point into $defs. The referenced
+// definitions are emitted once under $defs, themselves using references.
+// Definitions that are a bare type ({"type": "string"}) are never substituted, as
+// they would match every unconstrained value of that type.
+func jsonWithRefs(doc map[string]interface{}, registry *SchemaRegistry) map[string]interface{} {
+	doc = normalizeJSONSchema(doc)
+	if registry == nil {
+		return doc
+	}
+
+	// Index the definitions by their canonical encoding; the first name in sorted
+	// order wins when several definitions are identical
+	byEncoding := map[string]string{}
+	definitions := map[string]map[string]interface{}{}
+	for _, name := range registry.Names() {
+		s, _ := registry.Get(name)
+		generator, ok := s.(JSONSchemaGenerator)
+		if !ok {
+			continue
+		}
+		def := normalizeJSONSchema(generator.JSON())
+		definitions[name] = def
+		if len(def) <= 1 {
+			continue
+		}
+		if encoded, err := json.Marshal(def); err == nil {
+			if _, exists := byEncoding[string(encoded)]; !exists {
+				byEncoding[string(encoded)] = name
+			}
+		}
+	}
+
+	used := map[string]bool{}
+	var rewrite func(node interface{}, root bool) interface{}
+	rewrite = func(node interface{}, root bool) interface{} {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			if !root {
+				if encoded, err := json.Marshal(n); err == nil {
+					if name, ok := byEncoding[string(encoded)]; ok {
+						used[name] = true
+						return map[string]interface{}{"$ref": defsPointer(name)}
+					}
+				}
+			}
+			result := make(map[string]interface{}, len(n))
+			for key, value := range n {
+				result[key] = rewrite(value, false)
+			}
+			if ref, ok := n["$ref"].(string); ok && strings.HasPrefix(ref, "#/") && !strings.HasPrefix(ref, "#/$defs/") {
+				if _, exists := definitions[ref[2:]]; exists {
+					used[ref[2:]] = true
+					result["$ref"] = defsPointer(ref[2:])
+				}
+			}
+			return result
+		case []interface{}:
+			result := make([]interface{}, len(n))
+			for i, value := range n {
+				result[i] = rewrite(value, false)
+			}
+			return result
+		}
+		return node
+	}
+
+	result := rewrite(doc, true).(map[string]interface{})
+
+	// Emitting a definition may reference further definitions
+	defs := map[string]interface{}{}
+	for len(defs) < len(used) {
+		for _, name := range sortedMapKeys(used) {
+			if _, done := defs[name]; !done {
+				defs[name] = rewrite(definitions[name], true)
+			}
+		}
+	}
+	if len(defs) > 0 {
+		if existing, ok := result["$defs"].(map[string]interface{}); ok {
+			for name, def := range existing {
+				if _, clash := defs[name]; !clash {
+					defs[name] = def
+				}
+			}
+		}
+		result["$defs"] = defs
+	}
+	return result
+}
+
+// normalizeJSONSchema converts a JSON Schema to the value types produced by
+// encoding/json, so documents can be compared by their encoding
+func normalizeJSONSchema(doc map[string]interface{}) map[string]interface{} {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return doc
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return doc
+	}
+	return normalized
+}
+
+// defsPointer returns the JSON pointer to a definition under $defs
+func defsPointer(name string) string {
+	return "#/$defs/" + strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchemaWithOptions_Drafts(t *testing.T) {
+	point := Tuple(Float(), Float())
+
+	doc := JSONSchemaWithOptions(point, JSONSchemaOptions{Draft: Draft2020, IncludeSchemaURI: true})
+	if doc["$schema"] != "https://json-schema.org/draft/2020-12/schema" {
+		t.Errorf("$schema = %v", doc["$schema"])
+	}
+	if items, ok := doc["prefixItems"].([]interface{}); !ok || len(items) != 2 {
+		t.Errorf("prefixItems = %v, want two schemas", doc["prefixItems"])
+	}
+	if doc["items"] != false || doc["additionalItems"] != nil {
+		t.Errorf("items = %v, additionalItems = %v, want items false only", doc["items"], doc["additionalItems"])
+	}
+
+	doc = JSONSchemaWithOptions(point, JSONSchemaOptions{Draft: Draft07, IncludeSchemaURI: true})
+	if doc["$schema"] != "http://json-schema.org/draft-07/schema#" {
+		t.Errorf("$schema = %v", doc["$schema"])
+	}
+	if items, ok := doc["items"].([]interface{}); !ok || len(items) != 2 || doc["additionalItems"] != false {
+		t.Errorf("items = %v, additionalItems = %v, want draft-07 tuple form", doc["items"], doc["additionalItems"])
+	}
+	if _, ok := doc["prefixItems"]; ok {
+		t.Error("draft-07 output should not contain prefixItems")
+	}
+}
+
+func TestJSONSchemaWithOptions_Keywords(t *testing.T) {
+	doc := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"definitions": map[string]interface{}{"$ref": "#/$defs/Item"},
+		},
+		"$defs":             map[string]interface{}{"Item": map[string]interface{}{"type": "string"}},
+		"dependentRequired": map[string]interface{}{"card": []interface{}{"cvv"}},
+	}
+
+	draft07 := convertDraft(doc, Draft07, false).(map[string]interface{})
+	want := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"definitions": map[string]interface{}{"$ref": "#/definitions/Item"},
+		},
+		"definitions":  map[string]interface{}{"Item": map[string]interface{}{"type": "string"}},
+		"dependencies": map[string]interface{}{"card": []interface{}{"cvv"}},
+	}
+	if !reflect.DeepEqual(draft07, want) {
+		t.Errorf("draft-07 = %v, want %v", draft07, want)
+	}
+
+	if back := convertDraft(draft07, Draft2020, false); !reflect.DeepEqual(back, doc) {
+		t.Errorf("2020-12 = %v, want %v", back, doc)
+	}
+}
+
+func TestJSONWithOptions_Stable(t *testing.T) {
+	a := Object().Property("name", String()).Property("age", Int())
+	b := Object().Property("age", Int()).Property("name", String())
+
+	opts := JSONSchemaOptions{SortKeys: true}
+	first, err := JSONWithOptions(a, opts)
+	if err != nil {
+		t.Fatalf("JSONWithOptions() error = %v", err)
+	}
+	second, _ := JSONWithOptions(b, opts)
+	if string(first) != string(second) {
+		t.Errorf("output depends on declaration order:\n%s\n%s", first, second)
+	}
+	if !strings.Contains(string(first), "\"required\": [\n    \"age\",\n    \"name\"\n  ]") {
+		t.Errorf("required should be sorted:\n%s", first)
+	}
+}
+
+func TestJSONSchema_Annotations(t *testing.T) {
+	legacy := String().
+		Deprecated("use displayName").
+		Meta("owner", "identity-team").
+		Meta("x-internal", true)
+
+	doc := legacy.JSON()
+	want := map[string]interface{}{
+		"deprecated":           true,
+		"x-deprecation-reason": "use displayName",
+		"x-owner":              "identity-team",
+		"x-internal":           true,
+	}
+	for key, value := range want {
+		if doc[key] != value {
+			t.Errorf("%s = %v, want %v", key, doc[key], value)
+		}
+	}
+	if !legacy.IsDeprecated() || legacy.GetDeprecationReason() != "use displayName" {
+		t.Error("deprecation getters")
+	}
+	if meta := legacy.GetMeta(); len(meta) != 2 || meta["owner"] != "identity-team" {
+		t.Errorf("GetMeta() = %v", meta)
+	}
+
+	// Copies of an object schema do not share metadata
+	base := Object().Property("name", String()).Meta("team", "a")
+	derived := base.Pick("name").Meta("team", "b")
+	if base.GetMeta()["team"] != "a" || derived.GetMeta()["team"] != "b" {
+		t.Errorf("meta = %v / %v", base.GetMeta(), derived.GetMeta())
+	}
+	if doc := Int().JSON(); doc["deprecated"] != nil {
+		t.Errorf("deprecated emitted by default: %v", doc)
+	}
+
+	// draft-07 has no dependentRequired
+	payment := Object().
+		Property("card", String().Optional()).
+		Property("cvv", String().Optional()).
+		DependentRequired(map[string][]string{"card": {"cvv"}})
+	doc = JSONSchemaWithOptions(payment, JSONSchemaOptions{Draft: Draft07})
+	if deps, ok := doc["dependencies"].(map[string]interface{}); !ok || !reflect.DeepEqual(deps["card"], []interface{}{"cvv"}) {
+		t.Errorf("dependencies = %#v", doc["dependencies"])
+	}
+}
@@ -0,0 +1,287 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ParseBytes decodes a JSON document and validates it against s. The document is
+// decoded by a purpose-built scanner straight into the maps, slices and scalars the
+// schemas consume, without the reflection encoding/json uses for interface{}
+// targets, so it is faster than json.Unmarshal followed by Parse. Integers decode
+// as int (as with ParseYAML) and other numbers as float64.
+//
+// A document that cannot be decoded, or that nests arrays and objects deeper than
+// ctx.MaxDepth, fails with code "invalid_json" and the Location of the syntax error.
+func ParseBytes(s Parseable, data []byte, ctx *ValidationContext) ParseResult {
+	value, err := decodeJSONBytes(data, ctx.maxDepth())
+	if err != nil {
+		var loc *Location
+		if syntaxErr, ok := err.(*jsonBytesError); ok {
+			l := offsetLocation(data, syntaxErr.offset)
+			loc = &l
+		}
+		return documentError(jsonDecodeError(err)(ctx.Locale), CodeInvalidJSON, loc, ctx.Source)
+	}
+	return s.Parse(value, ctx)
+}
+
+// ParseBytes decodes a JSON document and validates it against the object schema
+func (s *ObjectSchema) ParseBytes(data []byte, ctx *ValidationContext) ParseResult {
+	return ParseBytes(s, data, ctx)
+}
+
+// jsonBytesError reports malformed JSON at a byte offset
+type jsonBytesError struct {
+	offset int
+	msg    string
+}
+
+func (e *jsonBytesError) Error() string {
+	return fmt.Sprintf("%s at offset %d", e.msg, e.offset)
+}
+
+// jsonBytesDecoder scans a JSON document without reflection
+type jsonBytesDecoder struct {
+	data     []byte
+	pos      int
+	maxDepth int // Deepest nesting of arrays and objects accepted
+}
+
+// decodeJSONBytes decodes a single JSON value that makes up the whole of data,
+// rejecting arrays and objects nested deeper than maxDepth
+func decodeJSONBytes(data []byte, maxDepth int) (interface{}, error) {
+	d := &jsonBytesDecoder{data: data, maxDepth: maxDepth}
+	value, err := d.value(0)
+	if err != nil {
+		return nil, err
+	}
+	d.skipSpace()
+	if d.pos < len(d.data) {
+		return nil, d.errorf("invalid character %q after top-level value", d.data[d.pos])
+	}
+	return value, nil
+}
+
+// errorf returns a syntax error at the current position
+func (d *jsonBytesDecoder) errorf(format string, args ...interface{}) error {
+	return &jsonBytesError{offset: d.pos, msg: fmt.Sprintf(format, args...)}
+}
+
+// skipSpace advances past insignificant whitespace
+func (d *jsonBytesDecoder) skipSpace() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\n', '\r':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+// value decodes the value at the current position
+func (d *jsonBytesDecoder) value(depth int) (interface{}, error) {
+	if depth > d.maxDepth {
+		return nil, d.errorf("document is nested too deeply")
+	}
+	d.skipSpace()
+	if d.pos >= len(d.data) {
+		return nil, d.errorf("unexpected end of JSON input")
+	}
+
+	switch c := d.data[d.pos]; {
+	case c == '{':
+		return d.object(depth)
+	case c == '[':
+		return d.array(depth)
+	case c == '"':
+		return d.string()
+	case c == '-' || (c >= '0' && c <= '9'):
+		return d.number()
+	case c == 't':
+		return true, d.literal("true")
+	case c == 'f':
+		return false, d.literal("false")
+	case c == 'n':
+		return nil, d.literal("null")
+	default:
+		return nil, d.errorf("invalid character %q looking for beginning of value", c)
+	}
+}
+
+// object decodes an object; the current byte is '{'
+func (d *jsonBytesDecoder) object(depth int) (interface{}, error) {
+	d.pos++
+	object := map[string]interface{}{}
+	d.skipSpace()
+	if d.pos < len(d.data) && d.data[d.pos] == '}' {
+		d.pos++
+		return object, nil
+	}
+	for {
+		d.skipSpace()
+		if d.pos >= len(d.data) || d.data[d.pos] != '"' {
+			return nil, d.errorf("expected string for object key")
+		}
+		key, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		d.skipSpace()
+		if d.pos >= len(d.data) || d.data[d.pos] != ':' {
+			return nil, d.errorf("expected ':' after object key")
+		}
+		d.pos++
+		value, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = value
+
+		d.skipSpace()
+		if d.pos >= len(d.data) {
+			return nil, d.errorf("unexpected end of JSON input")
+		}
+		switch d.data[d.pos] {
+		case ',':
+			d.pos++
+		case '}':
+			d.pos++
+			return object, nil
+		default:
+			return nil, d.errorf("invalid character %q after object key:value pair", d.data[d.pos])
+		}
+	}
+}
+
+// array decodes an array; the current byte is '['
+func (d *jsonBytesDecoder) array(depth int) (interface{}, error) {
+	d.pos++
+	array := []interface{}{}
+	d.skipSpace()
+	if d.pos < len(d.data) && d.data[d.pos] == ']' {
+		d.pos++
+		return array, nil
+	}
+	for {
+		value, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+
+		d.skipSpace()
+		if d.pos >= len(d.data) {
+			return nil, d.errorf("unexpected end of JSON input")
+		}
+		switch d.data[d.pos] {
+		case ',':
+			d.pos++
+		case ']':
+			d.pos++
+			return array, nil
+		default:
+			return nil, d.errorf("invalid character %q after array element", d.data[d.pos])
+		}
+	}
+}
+
+// string decodes a string; the current byte is '"'. Strings without escapes or
+// non-ASCII bytes are sliced directly; others are decoded by encoding/json.
+func (d *jsonBytesDecoder) string() (string, error) {
+	start := d.pos
+	simple := true
+	for i := start + 1; i < len(d.data); i++ {
+		switch c := d.data[i]; {
+		case c == '"':
+			d.pos = i + 1
+			if simple {
+				return string(d.data[start+1 : i]), nil
+			}
+			var s string
+			if err := json.Unmarshal(d.data[start:i+1], &s); err != nil {
+				d.pos = start
+				return "", d.errorf("invalid string literal")
+			}
+			return s, nil
+		case c == '\\':
+			simple = false
+			i++ // The escaped character cannot end the string
+		case c < 0x20:
+			d.pos = i
+			return "", d.errorf("invalid control character in string literal")
+		case c >= utf8.RuneSelf:
+			simple = false
+		}
+	}
+	d.pos = len(d.data)
+	return "", d.errorf("unexpected end of JSON input")
+}
+
+// number decodes a number following the JSON grammar
+func (d *jsonBytesDecoder) number() (interface{}, error) {
+	start := d.pos
+	integer := true
+	if d.data[d.pos] == '-' {
+		d.pos++
+	}
+	switch {
+	case d.pos < len(d.data) && d.data[d.pos] == '0':
+		d.pos++
+	case d.pos < len(d.data) && d.data[d.pos] >= '1' && d.data[d.pos] <= '9':
+		d.digits()
+	default:
+		return nil, d.errorf("invalid number")
+	}
+	if d.pos < len(d.data) && d.data[d.pos] == '.' {
+		integer = false
+		d.pos++
+		if d.digits() == 0 {
+			return nil, d.errorf("invalid number: expected digit after decimal point")
+		}
+	}
+	if d.pos < len(d.data) && (d.data[d.pos] == 'e' || d.data[d.pos] == 'E') {
+		integer = false
+		d.pos++
+		if d.pos < len(d.data) && (d.data[d.pos] == '+' || d.data[d.pos] == '-') {
+			d.pos++
+		}
+		if d.digits() == 0 {
+			return nil, d.errorf("invalid number: expected digit in exponent")
+		}
+	}
+
+	text := string(d.data[start:d.pos])
+	if integer {
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil && n >= math.MinInt && n <= math.MaxInt {
+			return int(n), nil
+		}
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, &jsonBytesError{offset: start, msg: "number " + text + " is out of range"}
+	}
+	return f, nil
+}
+
+// digits advances past a run of decimal digits and returns its length
+func (d *jsonBytesDecoder) digits() int {
+	start := d.pos
+	for d.pos < len(d.data) && d.data[d.pos] >= '0' && d.data[d.pos] <= '9' {
+		d.pos++
+	}
+	return d.pos - start
+}
+
+// literal consumes true, false or null
+func (d *jsonBytesDecoder) literal(word string) error {
+	if len(d.data)-d.pos < len(word) || string(d.data[d.pos:d.pos+len(word)]) != word {
+		return d.errorf("invalid literal, expected %s", word)
+	}
+	d.pos += len(word)
+	return nil
+}
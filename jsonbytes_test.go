@@ -0,0 +1,201 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseBytes(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	order := Object().
+		Property("id", String().MinLength(3)).
+		Property("quantity", Int().Min(1)).
+		Property("price", Number().Min(0)).
+		Property("tags", Array(String()).Optional()).
+		Property("note", String().Nullable().Optional()).
+		Property("gift", Bool().Optional())
+
+	valid := []byte(`{"id": "ord-1", "quantity": 2, "price": 9.5e0, "tags": ["a", "café", "naïve"], "note": null, "gift": false}`)
+	result := order.ParseBytes(valid, ctx)
+	if !result.Valid {
+		t.Fatalf("expected valid, got %v", result.Errors)
+	}
+	value := result.Value.(map[string]interface{})
+	if value["quantity"] != 2 || value["price"] != 9.5 {
+		t.Errorf("numbers = %#v, %#v", value["quantity"], value["price"])
+	}
+	if !reflect.DeepEqual(value["tags"], []interface{}{"a", "café", "naïve"}) {
+		t.Errorf("tags = %#v", value["tags"])
+	}
+
+	// Validation errors match json.Unmarshal followed by Parse
+	invalid := []byte(`{"id": "x", "quantity": 0, "price": -1, "tags": [1]}`)
+	var decoded interface{}
+	if err := json.Unmarshal(invalid, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	want := order.Parse(decoded, ctx)
+	got := order.ParseBytes(invalid, ctx)
+	if got.Valid || !reflect.DeepEqual(errorKeys(got.Errors), errorKeys(want.Errors)) {
+		t.Errorf("got %v, want %v", got.Errors, want.Errors)
+	}
+
+	// Any schema can be used
+	if result := ParseBytes(Array(Int()), []byte(` [1, 2, 3] `), ctx); !result.Valid {
+		t.Errorf("expected array to be valid, got %v", result.Errors)
+	}
+}
+
+func TestDecodeJSONBytes(t *testing.T) {
+	tests := []string{
+		`null`, `true`, `false`, `0`, `-0`, `12`, `-7`, `1.5`, `-2.5e-3`, `1E10`, `9223372036854775808`,
+		`""`, `"plain"`, `"esc\"aped\\ \n \t \/"`, `"😀"`, `"日本"`,
+		`[]`, `{}`, `[1, [2, [3]], {"a": {}}]`, `{"a": 1, "b": [true, null], "c": {"d": "e"}}`,
+	}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			got, err := decodeJSONBytes([]byte(input), DefaultMaxDepth)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var want interface{}
+			if err := json.Unmarshal([]byte(input), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(normalizeJSONNumbers(got), want) {
+				t.Errorf("got %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseBytes_SyntaxErrors(t *testing.T) {
+	ctx := DefaultValidationContext().WithSource("order.json")
+
+	tests := []struct {
+		name   string
+		input  string
+		line   int
+		column int
+	}{
+		{"empty", ``, 1, 1},
+		{"trailing comma", "{\n  \"a\": 1,\n}", 3, 1},
+		{"missing colon", `{"a" 1}`, 1, 6},
+		{"bad literal", `[tru]`, 1, 2},
+		{"leading zero", `[01]`, 1, 3},
+		{"bare fraction", `[1.]`, 1, 4},
+		{"unterminated string", `["abc`, 1, 6},
+		{"control character", "[\"a\tb\"]", 1, 4},
+		{"trailing data", `{} {}`, 1, 4},
+		{"single quotes", `{'a': 1}`, 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseBytes(Any(), []byte(tt.input), ctx)
+			if result.Valid || len(result.Errors) != 1 {
+				t.Fatalf("expected one error, got %v", result.Errors)
+			}
+			err := result.Errors[0]
+			if err.Code != "invalid_json" {
+				t.Errorf("code = %q, want invalid_json", err.Code)
+			}
+			if err.Location == nil || err.Location.Line != tt.line || err.Location.Column != tt.column {
+				t.Errorf("location = %+v, want %d:%d", err.Location, tt.line, tt.column)
+			}
+			var v interface{}
+			if json.Unmarshal([]byte(tt.input), &v) == nil {
+				t.Errorf("encoding/json accepts %q", tt.input)
+			}
+		})
+	}
+
+	deep := strings.Repeat("[", DefaultMaxDepth+2) + strings.Repeat("]", DefaultMaxDepth+2)
+	if result := ParseBytes(Any(), []byte(deep), ctx); result.Valid {
+		t.Error("expected deeply nested document to be rejected")
+	}
+
+	// A lower limit of the context applies to the document too
+	shallow := []byte("[[[[[]]]]]")
+	if result := ParseBytes(Any(), shallow, ctx); !result.Valid {
+		t.Errorf("unexpected errors within the default limit: %v", result.Errors)
+	}
+	result := ParseBytes(Any(), shallow, DefaultValidationContext().WithMaxDepth(3))
+	if result.Valid || result.Errors[0].Code != CodeInvalidJSON {
+		t.Errorf("errors = %v, want the document rejected with MaxDepth 3", result.Errors)
+	}
+}
+
+// errorKeys returns the path and code of each error, sorted
+func errorKeys(errs []ValidationError) []string {
+	keys := make([]string, len(errs))
+	for i, err := range errs {
+		keys[i] = err.Path.String() + " " + string(err.Code)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeJSONNumbers converts the int values ParseBytes produces to the float64
+// values encoding/json produces
+func normalizeJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = normalizeJSONNumbers(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = normalizeJSONNumbers(child)
+		}
+	}
+	return value
+}
+
+var benchmarkOrder = []byte(`{"id": "ord-1042", "customer": {"name": "Ada Lovelace", "email": "ada@example.com"},
+ "items": [{"sku": "A-1", "quantity": 2, "price": 9.99}, {"sku": "B-22", "quantity": 1, "price": 24.5},
+ {"sku": "C-333", "quantity": 5, "price": 1.25}], "tags": ["priority", "gift"], "paid": true}`)
+
+func benchmarkOrderSchema() *ObjectSchema {
+	item := Object().
+		Property("sku", String().MinLength(1)).
+		Property("quantity", Int().Min(1)).
+		Property("price", Number().Min(0))
+	return Object().
+		Property("id", String()).
+		Property("customer", Object().Property("name", String()).Property("email", String().Email())).
+		Property("items", Array(item).MinItems(1)).
+		Property("tags", Array(String())).
+		Property("paid", Bool())
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	s := benchmarkOrderSchema()
+	ctx := DefaultValidationContext()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if result := s.ParseBytes(benchmarkOrder, ctx); !result.Valid {
+			b.Fatal(result.Errors)
+		}
+	}
+}
+
+func BenchmarkUnmarshalParse(b *testing.B) {
+	s := benchmarkOrderSchema()
+	ctx := DefaultValidationContext()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var value interface{}
+		if err := json.Unmarshal(benchmarkOrder, &value); err != nil {
+			b.Fatal(err)
+		}
+		if result := s.Parse(value, ctx); !result.Valid {
+			b.Fatal(result.Errors)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nyxstack/i18n"
+)
+
+func jsonDecodeError(err error) i18n.TranslatedFunc {
+	return i18n.F("invalid JSON: %v", err)
+}
+
+// ParseJSONWithLocations decodes a JSON document, validates it against s and attaches
+// the Location (byte offset, line and column) of the offending value to each error.
+// Errors about missing properties point at the enclosing object. Locations are
+// prefixed with ctx.Source, so editors and CLI validators can jump straight to the
+// problem. A document that cannot be decoded fails with code "invalid_json".
+func ParseJSONWithLocations(s Parseable, data []byte, ctx *ValidationContext) ParseResult {
+	value, locs, err := decodeJSONWithLocations(data)
+	if err != nil {
+		var loc *Location
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			l := offsetLocation(data, int(syntaxErr.Offset))
+			loc = &l
+		}
+		return documentError(jsonDecodeError(err)(ctx.Locale), CodeInvalidJSON, loc, ctx.Source)
+	}
+	return locs.attach(s.Parse(value, ctx), ctx.Source)
+}
+
+// jsonLocationDecoder walks the tokens of a JSON document, building the same values
+// as json.Unmarshal into interface{} while recording where each value starts
+type jsonLocationDecoder struct {
+	data []byte
+	dec  *json.Decoder
+	locs sourceLocations
+}
+
+// decodeJSONWithLocations decodes a single JSON value and the locations of its nodes
+func decodeJSONWithLocations(data []byte) (interface{}, sourceLocations, error) {
+	d := &jsonLocationDecoder{
+		data: data,
+		dec:  json.NewDecoder(bytes.NewReader(data)),
+		locs: sourceLocations{},
+	}
+	value, err := d.value(Path{}, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := d.dec.Token(); err != io.EOF {
+		return nil, nil, &json.SyntaxError{Offset: d.dec.InputOffset()}
+	}
+	return value, d.locs, nil
+}
+
+// value decodes the value at path
+func (d *jsonLocationDecoder) value(path Path, depth int) (interface{}, error) {
+	if depth > DefaultMaxDepth {
+		return nil, fmt.Errorf("document is nested too deeply at %s", path)
+	}
+
+	d.locs.add(path, offsetLocation(d.data, d.nextOffset()))
+	tok, err := d.dec.Token()
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		object := map[string]interface{}{}
+		for d.dec.More() {
+			keyTok, err := d.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyTok.(string)
+			if object[key], err = d.value(path.Field(key), depth+1); err != nil {
+				return nil, err
+			}
+		}
+		_, err = d.dec.Token() // Closing brace
+		return object, err
+
+	case json.Delim('['):
+		array := []interface{}{}
+		for i := 0; d.dec.More(); i++ {
+			item, err := d.value(path.Index(i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, item)
+		}
+		_, err = d.dec.Token() // Closing bracket
+		return array, err
+	}
+	return tok, nil
+}
+
+// nextOffset returns the offset of the next token, skipping whitespace and the
+// separators that the decoder consumes implicitly
+func (d *jsonLocationDecoder) nextOffset() int {
+	offset := int(d.dec.InputOffset())
+	for offset < len(d.data) {
+		switch d.data[offset] {
+		case ' ', '\t', '\n', '\r', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
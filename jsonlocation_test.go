@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseJSONWithLocations(t *testing.T) {
+	order := Object().
+		Property("id", String()).
+		Property("items", Array(Object().
+			Property("sku", String().MinLength(3)).
+			Property("qty", Int().Min(1))))
+
+	ctx := DefaultValidationContext().WithSource("order.json")
+
+	result := ParseJSONWithLocations(order, []byte(`{"id": "A1", "items": [{"sku": "abc", "qty": 2}]}`), ctx)
+	if !result.Valid {
+		t.Fatalf("expected valid, got %v", result.Errors)
+	}
+
+	data := []byte(`{
+  "id": "A1",
+  "items": [
+    {"sku": "abc", "qty": 2},
+    {"sku": "xy",
+     "qty": 0}
+  ]
+}`)
+
+	tests := []struct {
+		path     string
+		location string
+		offset   int
+	}{
+		{"items[1].sku", "order.json:5:13", bytes.Index(data, []byte(`"xy"`))},
+		{"items[1].qty", "order.json:6:13", bytes.Index(data, []byte(`0}`))},
+	}
+
+	result = ParseJSONWithLocations(order, data, ctx)
+	if result.Valid {
+		t.Fatal("expected invalid result")
+	}
+	for _, tt := range tests {
+		found := false
+		for _, err := range result.Errors {
+			if err.Path.DotPath() != tt.path {
+				continue
+			}
+			found = true
+			if err.Location == nil || err.Location.String() != tt.location || err.Location.Offset != tt.offset {
+				t.Errorf("%s location = %+v, want %s (offset %d)", tt.path, err.Location, tt.location, tt.offset)
+			}
+		}
+		if !found {
+			t.Errorf("no error reported for %s: %v", tt.path, result.Errors)
+		}
+	}
+
+	missing := ParseJSONWithLocations(order, []byte("{\n  \"items\": []\n}"), ctx)
+	if missing.Valid || missing.Errors[0].Location.String() != "order.json:1:1" {
+		t.Errorf("missing property should point at its object, got %v", missing.Errors)
+	}
+
+	for _, bad := range []string{`{"id": }`, `{"id": "x"} []`, `[1, 2`} {
+		result := ParseJSONWithLocations(order, []byte(bad), ctx)
+		if result.Valid || result.Errors[0].Code != "invalid_json" {
+			t.Errorf("ParseJSONWithLocations(%s) errors = %v, want invalid_json", bad, result.Errors)
+		}
+	}
+}
@@ -0,0 +1,628 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for JSON Patch validation
+var (
+	jsonPatchRequiredError = i18n.S("value is required")
+	jsonPatchTypeError     = i18n.S("value must be an array of patch operations")
+	jsonPatchOpTypeError   = i18n.S("patch operation must be an object")
+	jsonPatchOpError       = i18n.S("op must be one of add, remove, replace, move, copy, test")
+	jsonPatchMoveError     = i18n.S("a value cannot be moved into itself")
+)
+
+func jsonPatchItemError(index int) i18n.TranslatedFunc {
+	return i18n.F("patch operation %d is invalid", index)
+}
+
+func jsonPatchMemberError(member string) i18n.TranslatedFunc {
+	return i18n.F("%s is required", member)
+}
+
+func jsonPatchPointerTypeError(member string) i18n.TranslatedFunc {
+	return i18n.F("%s must be a JSON Pointer string", member)
+}
+
+func jsonPatchPointerError(pointer string) i18n.TranslatedFunc {
+	return i18n.F("%q is not a valid JSON Pointer", pointer)
+}
+
+func jsonPatchPathError(pointer string) i18n.TranslatedFunc {
+	return i18n.F("%q is not a location of the target schema", pointer)
+}
+
+func jsonPatchRemoveError(property string) i18n.TranslatedFunc {
+	return i18n.F("property %s is required and cannot be removed", property)
+}
+
+func jsonPatchMaxOpsError(max int) i18n.TranslatedFunc {
+	return i18n.F("patch must contain at most %d operations", max)
+}
+
+// jsonPatchMembers lists the members each operation requires besides op and path
+var jsonPatchMembers = map[string][]string{
+	"add":     {"value"},
+	"remove":  nil,
+	"replace": {"value"},
+	"move":    {"from"},
+	"copy":    {"from"},
+	"test":    {"value"},
+}
+
+// jsonPointerPattern matches the JSON Pointers of RFC 6901
+var jsonPointerPattern = regexp.MustCompile(`^(/([^~/]|~[01])*)*$`)
+
+// JSONPatchSchema validates RFC 6902 JSON Patch documents: arrays of operations such as
+// {"op": "replace", "path": "/name", "value": "Ada"}. Each operation must have a known
+// op, a JSON Pointer path, a value for add, replace and test and a from pointer for
+// move and copy. With a target schema, the operations are also checked against the
+// documents they will be applied to, so invalid patches are rejected before being
+// applied:
+//
+//   - path and from must point to a location the target schema describes (a
+//     property, an array item, "-" to append to an array, a record value, ...)
+//   - the value of add and replace must be valid for the schema at path
+//   - remove and move cannot take away a required property
+//
+// Locations inside values the target does not describe (Any schemas, the additional
+// properties of passthrough objects) are accepted without checking their values.
+type JSONPatchSchema struct {
+	Schema
+	target   Parseable
+	maxOps   *int
+	nullable bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	typeMismatchError ErrorMessage
+	maxOpsError       ErrorMessage
+}
+
+// JSONPatch creates a schema for JSON Patch documents applied to values of target.
+// A nil target validates the structure of the operations only.
+func JSONPatch(target Parseable) *JSONPatchSchema {
+	return &JSONPatchSchema{
+		Schema: Schema{
+			schemaType: "array",
+			required:   true, // Default to required
+		},
+		target: target,
+	}
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// The target schema is shared.
+func (s *JSONPatchSchema) Clone() *JSONPatchSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema and the target schema immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *JSONPatchSchema) Freeze() *JSONPatchSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and the target schema
+func (s *JSONPatchSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.target)
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *JSONPatchSchema) Title(title string) *JSONPatchSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *JSONPatchSchema) Description(description string) *JSONPatchSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *JSONPatchSchema) Deprecated(reason string) *JSONPatchSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *JSONPatchSchema) Meta(key string, value interface{}) *JSONPatchSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// MaxOperations sets the maximum number of operations of a patch
+func (s *JSONPatchSchema) MaxOperations(max int, errorMessage ...interface{}) *JSONPatchSchema {
+	s.checkMutable()
+	s.maxOps = &max
+	if len(errorMessage) > 0 {
+		s.maxOpsError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Required/Optional/Nullable control
+
+// Optional marks the schema as optional
+func (s *JSONPatchSchema) Optional() *JSONPatchSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with an optional custom error message
+func (s *JSONPatchSchema) Required(errorMessage ...interface{}) *JSONPatchSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *JSONPatchSchema) Nullable() *JSONPatchSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for values that are not arrays
+func (s *JSONPatchSchema) TypeError(message string) *JSONPatchSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// Getters for accessing private fields
+
+// IsOptional returns whether the schema is marked as optional
+func (s *JSONPatchSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *JSONPatchSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetTarget returns the schema of the documents the patches apply to (nil if unchecked)
+func (s *JSONPatchSchema) GetTarget() Parseable {
+	return s.target
+}
+
+// GetMaxOperations returns the maximum number of operations
+func (s *JSONPatchSchema) GetMaxOperations() *int {
+	return s.maxOps
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *JSONPatchSchema) Transform(fn TransformFunc) *JSONPatchSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *JSONPatchSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *JSONPatchSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *JSONPatchSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *JSONPatchSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Validation
+
+// Parse validates a JSON Patch document, returning its operations as a []interface{}
+// of map[string]interface{}
+func (s *JSONPatchSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the patch constraints; Parse runs the refine/transform pipeline on top
+func (s *JSONPatchSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if !s.Schema.required {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		message := jsonPatchRequiredError(ctx.Locale)
+		if !isEmptyErrorMessage(s.requiredError) {
+			message = resolveErrorMessage(s.requiredError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		message := jsonPatchTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+	if err, exceeded := ctx.checkCollectionSize(value, v.Len()); exceeded {
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{err}}
+	}
+
+	var errors []ValidationError
+	if s.maxOps != nil && v.Len() > *s.maxOps {
+		message := jsonPatchMaxOpsError(*s.maxOps)(ctx.Locale)
+		if !isEmptyErrorMessage(s.maxOpsError) {
+			message = resolveErrorMessage(s.maxOpsError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeMaxItems))
+	}
+
+	child, ok := ctx.descend(value)
+	if !ok {
+		return ctx.depthExceeded(value)
+	}
+	defer child.release()
+
+	operations := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if ctx.stopCollecting(len(errors)) {
+			break
+		}
+		item := v.Index(i).Interface()
+		operation, opErrors := s.parseOperation(item, child.at(IndexSegment(i)))
+		operations = append(operations, operation)
+		if len(opErrors) == 0 {
+			continue
+		}
+		errors = append(errors, NewFieldError(Path{IndexSegment(i)}, item, jsonPatchItemError(i)(ctx.Locale), CodeItemInvalid))
+		for _, err := range opErrors {
+			errors = append(errors, nestedError(append(Path{IndexSegment(i)}, err.Path...), err))
+		}
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errors}
+	}
+	return ParseResult{Valid: true, Value: operations, Errors: nil}
+}
+
+// parseOperation validates a single operation, with errors relative to it
+func (s *JSONPatchSchema) parseOperation(item interface{}, ctx *ValidationContext) (map[string]interface{}, []ValidationError) {
+	operation, ok := convertToMap(item, ctx.TagNames...)
+	if !ok || item == nil {
+		return nil, []ValidationError{NewPrimitiveError(item, jsonPatchOpTypeError(ctx.Locale), CodeInvalidType)}
+	}
+
+	op, _ := operation["op"].(string)
+	members, known := jsonPatchMembers[op]
+	switch {
+	case operation["op"] == nil:
+		return operation, []ValidationError{jsonPatchMissing("op", ctx)}
+	case !known:
+		return operation, []ValidationError{NewFieldError(Path{FieldSegment("op")}, operation["op"], jsonPatchOpError(ctx.Locale), CodeEnum)}
+	}
+
+	var errors []ValidationError
+	pointers := make(map[string][]string, 2)
+	for _, member := range append([]string{"path"}, members...) {
+		raw, present := operation[member]
+		if !present {
+			errors = append(errors, jsonPatchMissing(member, ctx))
+			continue
+		}
+		if member == "value" {
+			continue
+		}
+		tokens, err, ok := parseJSONPointer(member, raw, ctx)
+		if !ok {
+			errors = append(errors, err)
+			continue
+		}
+		pointers[member] = tokens
+	}
+	if len(errors) > 0 {
+		return operation, errors
+	}
+
+	// A value cannot be moved into one of its own children
+	if op == "move" && len(pointers["path"]) > len(pointers["from"]) && isPointerPrefix(pointers["from"], pointers["path"]) {
+		errors = append(errors, NewFieldError(Path{FieldSegment("path")}, operation["path"], jsonPatchMoveError(ctx.Locale), CodePatchPath))
+	}
+	if s.target != nil {
+		errors = append(errors, s.checkTarget(op, operation, pointers, ctx)...)
+	}
+	return operation, errors
+}
+
+// checkTarget checks the locations and value of an operation against the target schema
+func (s *JSONPatchSchema) checkTarget(op string, operation map[string]interface{}, pointers map[string][]string, ctx *ValidationContext) []ValidationError {
+	var errors []ValidationError
+	for _, member := range []string{"from", "path"} {
+		tokens, ok := pointers[member]
+		if !ok {
+			continue
+		}
+		location := resolvePatchLocation(s.target, tokens)
+		if !location.found {
+			message := jsonPatchPathError(operation[member].(string))(ctx.Locale)
+			errors = append(errors, NewFieldError(Path{FieldSegment(member)}, operation[member], message, CodePatchPath))
+			continue
+		}
+		if location.required && (op == "remove" && member == "path" || op == "move" && member == "from") {
+			message := jsonPatchRemoveError(tokens[len(tokens)-1])(ctx.Locale)
+			errors = append(errors, NewFieldError(Path{FieldSegment(member)}, operation[member], message, CodeRequired))
+		}
+		if member == "path" && (op == "add" || op == "replace") && !location.open {
+			errors = append(errors, checkPatchValue(location.schemas, operation["value"], ctx)...)
+		}
+	}
+	return errors
+}
+
+// checkPatchValue validates the value of an operation with the schemas of its location,
+// reporting the errors of the first schema when none accepts it
+func checkPatchValue(schemas []Parseable, value interface{}, ctx *ValidationContext) []ValidationError {
+	child, ok := ctx.descend(value)
+	if !ok {
+		return ctx.depthExceeded(value).Errors
+	}
+	defer child.release()
+
+	var first []ValidationError
+	for i, schema := range schemas {
+		result := schema.Parse(value, child.at(FieldSegment("value")))
+		if result.Valid {
+			return nil
+		}
+		if i == 0 {
+			first = result.Errors
+		}
+	}
+	errors := make([]ValidationError, len(first))
+	for i, err := range first {
+		errors[i] = nestedError(append(Path{FieldSegment("value")}, err.Path...), err)
+	}
+	return errors
+}
+
+// jsonPatchMissing reports a missing member of an operation
+func jsonPatchMissing(member string, ctx *ValidationContext) ValidationError {
+	return NewFieldError(Path{FieldSegment(member)}, "<missing>", jsonPatchMemberError(member)(ctx.Locale), CodeRequired)
+}
+
+// parseJSONPointer parses the JSON Pointer of a member into its unescaped tokens
+func parseJSONPointer(member string, raw interface{}, ctx *ValidationContext) ([]string, ValidationError, bool) {
+	pointer, ok := raw.(string)
+	if !ok {
+		return nil, NewFieldError(Path{FieldSegment(member)}, raw, jsonPatchPointerTypeError(member)(ctx.Locale), CodeInvalidType), false
+	}
+	if !jsonPointerPattern.MatchString(pointer) {
+		return nil, NewFieldError(Path{FieldSegment(member)}, raw, jsonPatchPointerError(pointer)(ctx.Locale), CodeFormat), false
+	}
+	if pointer == "" {
+		return []string{}, ValidationError{}, true
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, ValidationError{}, true
+}
+
+// isPointerPrefix reports whether the tokens of prefix start those of pointer
+func isPointerPrefix(prefix, pointer []string) bool {
+	for i, token := range prefix {
+		if pointer[i] != token {
+			return false
+		}
+	}
+	return true
+}
+
+// patchLocation is where a JSON Pointer leads in a target schema: the schemas of the
+// value there, or open when it leads into a value the schema does not describe
+type patchLocation struct {
+	schemas  []Parseable
+	found    bool
+	open     bool
+	required bool // The pointer names a required property
+}
+
+// resolvePatchLocation follows the tokens of a pointer from the target schema
+func resolvePatchLocation(target Parseable, tokens []string) patchLocation {
+	location := patchLocation{schemas: []Parseable{target}, found: true}
+	for _, token := range tokens {
+		next := patchLocation{}
+		for _, schema := range expandSameValue(location.schemas) {
+			next.merge(patchChild(schema, token))
+		}
+		if !next.found {
+			return next
+		}
+		if next.open {
+			return patchLocation{found: true, open: true}
+		}
+		location = next
+	}
+	return location
+}
+
+// merge adds the schemas found under a token in one of the schemas of a location
+func (l *patchLocation) merge(other patchLocation) {
+	l.schemas = append(l.schemas, other.schemas...)
+	l.found = l.found || other.found
+	l.open = l.open || other.open
+	l.required = l.required || other.required
+}
+
+// patchChild returns the location a token leads to in a schema
+func patchChild(schema Parseable, token string) patchLocation {
+	var location patchLocation
+	switch v := schema.(type) {
+	case *AnySchema, *UnknownSchema:
+		return patchLocation{found: true, open: true}
+	case *ObjectSchema:
+		if prop, ok := v.properties[token]; ok {
+			location.required = prop.Required
+		} else if len(v.matchingPatternSchemas(token)) == 0 && (v.additionalProps || v.stripUnknown) {
+			return patchLocation{found: true, open: true}
+		}
+	case *RecordSchema:
+		if v.valueSchema == nil {
+			return patchLocation{found: true, open: true}
+		}
+	}
+
+	composite, ok := schema.(Composite)
+	if !ok {
+		return location
+	}
+	index := token == "-" || isArrayIndex(token)
+	for _, child := range composite.Children() {
+		if child.Schema == nil {
+			continue
+		}
+		var matches bool
+		switch {
+		case child.Segment == "" || child.Segment == KeySegment:
+		case child.Segment == ItemsSegment:
+			matches = index
+		case child.Segment == ValuesSegment:
+			matches = true
+		case len(child.Segment) > 2 && strings.HasPrefix(child.Segment, "/") && strings.HasSuffix(child.Segment, "/"):
+			pattern, err := regexp.Compile(child.Segment[1 : len(child.Segment)-1])
+			matches = err == nil && pattern.MatchString(token)
+		case strings.HasPrefix(child.Segment, "[") && strings.HasSuffix(child.Segment, "]"):
+			matches = child.Segment == "["+token+"]"
+		default:
+			matches = child.Segment == token
+		}
+		if matches {
+			location.schemas = append(location.schemas, child.Schema)
+			location.found = true
+		}
+	}
+	return location
+}
+
+// expandSameValue returns the schemas and, recursively, the schemas applying to the
+// same value as them (union members, resolved references, ...)
+func expandSameValue(schemas []Parseable) []Parseable {
+	var expanded []Parseable
+	seen := map[Parseable]bool{}
+	var add func(schema Parseable)
+	add = func(schema Parseable) {
+		if schema == nil {
+			return
+		}
+		if reflect.TypeOf(schema).Comparable() {
+			if seen[schema] {
+				return
+			}
+			seen[schema] = true
+		}
+		expanded = append(expanded, schema)
+		if composite, ok := schema.(Composite); ok {
+			for _, child := range composite.Children() {
+				if child.Segment == "" {
+					add(child.Schema)
+				}
+			}
+		}
+	}
+	for _, schema := range schemas {
+		add(schema)
+	}
+	return expanded
+}
+
+// isArrayIndex reports whether a token is an array index as defined by RFC 6901
+func isArrayIndex(token string) bool {
+	if token == "0" {
+		return true
+	}
+	if token == "" || token[0] == '0' {
+		return false
+	}
+	_, err := strconv.Atoi(token)
+	return err == nil && token[0] != '-' && token[0] != '+'
+}
+
+// JSON generates JSON Schema for JSON Patch documents. The target schema cannot be
+// expressed in JSON Schema and is not part of the output.
+func (s *JSONPatchSchema) JSON() map[string]interface{} {
+	pointer := map[string]interface{}{"type": "string", "pattern": jsonPointerPattern.String()}
+	operation := func(ops []string, required ...string) map[string]interface{} {
+		properties := map[string]interface{}{
+			"op":   map[string]interface{}{"enum": ops},
+			"path": pointer,
+		}
+		for _, member := range required {
+			if member == "from" {
+				properties["from"] = pointer
+			} else {
+				properties[member] = map[string]interface{}{}
+			}
+		}
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+			"required":   append([]string{"op", "path"}, required...),
+		}
+	}
+
+	schema := baseJSONSchema("array")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["items"] = map[string]interface{}{
+		"oneOf": []interface{}{
+			operation([]string{"add", "replace", "test"}, "value"),
+			operation([]string{"remove"}),
+			operation([]string{"move", "copy"}, "from"),
+		},
+	}
+	if s.maxOps != nil {
+		schema["maxItems"] = *s.maxOps
+	}
+	if s.nullable {
+		schema["type"] = []string{"array", "null"}
+	}
+	return schema
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize JSONPatchSchema for JSON schema generation
+func (s *JSONPatchSchema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.JSON())
+}
@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONPatchSchema_Structure(t *testing.T) {
+	patch := JSONPatch(nil)
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		errors []string
+	}{
+		{"valid operations", []interface{}{
+			map[string]interface{}{"op": "add", "path": "/tags/-", "value": "new"},
+			map[string]interface{}{"op": "remove", "path": "/a~1b/0"},
+			map[string]interface{}{"op": "replace", "path": "", "value": nil},
+			map[string]interface{}{"op": "move", "from": "/a", "path": "/b"},
+			map[string]interface{}{"op": "copy", "from": "/a", "path": "/a2"},
+			map[string]interface{}{"op": "test", "path": "/n", "value": 1},
+		}, []string{}},
+		{"empty patch", []interface{}{}, []string{}},
+		{"not an array", map[string]interface{}{"op": "add"}, []string{" invalid_type"}},
+		{"not an object", []interface{}{"add"}, []string{"[0] invalid_type", "[0] item_invalid"}},
+		{"unknown op", []interface{}{map[string]interface{}{"op": "merge", "path": "/a"}}, []string{"[0] item_invalid", "[0].op enum"}},
+		{"missing members", []interface{}{
+			map[string]interface{}{"op": "add", "path": "/a"},
+			map[string]interface{}{"op": "copy"},
+		}, []string{"[0] item_invalid", "[0].value required", "[1] item_invalid", "[1].from required", "[1].path required"}},
+		{"invalid pointers", []interface{}{
+			map[string]interface{}{"op": "remove", "path": "a"},
+			map[string]interface{}{"op": "remove", "path": "/a~2"},
+			map[string]interface{}{"op": "remove", "path": 1},
+		}, []string{"[0] item_invalid", "[0].path format", "[1] item_invalid", "[1].path format", "[2] item_invalid", "[2].path invalid_type"}},
+		{"move into itself", []interface{}{map[string]interface{}{"op": "move", "from": "/a", "path": "/a/b"}}, []string{"[0] item_invalid", "[0].path patch_path"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := patch.Parse(tt.value, DefaultValidationContext())
+			if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.errors) {
+				t.Errorf("errors = %v, want %v", got, tt.errors)
+			}
+		})
+	}
+}
+
+func TestJSONPatchSchema_Target(t *testing.T) {
+	user := Object().
+		Property("name", String().MinLength(1)).
+		Property("bio", String().Optional()).
+		Property("tags", Array(String()).Optional()).
+		Property("settings", Record(String(), Bool()).Optional()).
+		Property("extra", Any().Optional()).
+		Property("contact", Union(
+			Object().Property("email", String().Email()),
+			Object().Property("phone", String()),
+		).Optional())
+	patch := JSONPatch(user)
+
+	tests := []struct {
+		name   string
+		op     map[string]interface{}
+		errors []string
+	}{
+		{"replace property", map[string]interface{}{"op": "replace", "path": "/name", "value": "Ada"}, []string{}},
+		{"invalid value", map[string]interface{}{"op": "replace", "path": "/name", "value": 42}, []string{"[0] item_invalid", "[0].value invalid_type"}},
+		{"append item", map[string]interface{}{"op": "add", "path": "/tags/-", "value": "go"}, []string{}},
+		{"invalid item", map[string]interface{}{"op": "add", "path": "/tags/0", "value": true}, []string{"[0] item_invalid", "[0].value invalid_type"}},
+		{"item index", map[string]interface{}{"op": "add", "path": "/tags/01", "value": "go"}, []string{"[0] item_invalid", "[0].path patch_path"}},
+		{"record value", map[string]interface{}{"op": "add", "path": "/settings/dark", "value": true}, []string{}},
+		{"unknown property", map[string]interface{}{"op": "add", "path": "/admin", "value": true}, []string{"[0] item_invalid", "[0].path patch_path"}},
+		{"inside a string", map[string]interface{}{"op": "remove", "path": "/name/first"}, []string{"[0] item_invalid", "[0].path patch_path"}},
+		{"inside any", map[string]interface{}{"op": "add", "path": "/extra/a/b", "value": 1}, []string{}},
+		{"union member property", map[string]interface{}{"op": "replace", "path": "/contact/phone", "value": "555"}, []string{}},
+		{"remove optional", map[string]interface{}{"op": "remove", "path": "/bio"}, []string{}},
+		{"remove required", map[string]interface{}{"op": "remove", "path": "/name"}, []string{"[0] item_invalid", "[0].path required"}},
+		{"move required", map[string]interface{}{"op": "move", "from": "/name", "path": "/bio"}, []string{"[0] item_invalid", "[0].from required"}},
+		{"copy required", map[string]interface{}{"op": "copy", "from": "/name", "path": "/bio"}, []string{}},
+		{"test unchecked value", map[string]interface{}{"op": "test", "path": "/name", "value": 1}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := patch.Parse([]interface{}{tt.op}, DefaultValidationContext())
+			if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.errors) {
+				t.Errorf("errors = %v, want %v", got, tt.errors)
+			}
+		})
+	}
+}
+
+func TestJSONPatchSchema_Options(t *testing.T) {
+	type Operation struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value,omitempty"`
+	}
+	patch := JSONPatch(nil).MaxOperations(1)
+
+	result := patch.Parse([]Operation{{Op: "replace", Path: "/a", Value: 1}}, DefaultValidationContext())
+	if !result.Valid {
+		t.Fatalf("struct operations rejected: %v", result.Errors)
+	}
+	want := []interface{}{map[string]interface{}{"op": "replace", "path": "/a", "value": 1}}
+	if !reflect.DeepEqual(result.Value, want) {
+		t.Errorf("Value = %v, want %v", result.Value, want)
+	}
+
+	result = patch.Parse([]Operation{{Op: "remove", Path: "/a"}, {Op: "remove", Path: "/b"}}, DefaultValidationContext())
+	if got := errorKeys(result.Errors); !reflect.DeepEqual(got, []string{" max_items"}) {
+		t.Errorf("errors = %v", got)
+	}
+
+	if result := JSONPatch(nil).Optional().Parse(nil, DefaultValidationContext()); !result.Valid {
+		t.Errorf("optional patch rejected nil: %v", result.Errors)
+	}
+
+	doc := patch.JSON()
+	if doc["type"] != "array" || doc["maxItems"] != 1 {
+		t.Errorf("JSON() = %v", doc)
+	}
+	if variants := doc["items"].(map[string]interface{})["oneOf"].([]interface{}); len(variants) != 3 {
+		t.Errorf("items = %v", doc["items"])
+	}
+}
@@ -0,0 +1,576 @@
+package schema
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // Register SHA-256 for crypto.Hash
+	_ "crypto/sha512" // Register SHA-384 and SHA-512 for crypto.Hash
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for JWT validation
+var (
+	jwtRequiredError     = i18n.S("value is required")
+	jwtTypeError         = i18n.S("value must be a string")
+	jwtFormatError       = i18n.S("value must be a valid JSON Web Token")
+	jwtAlgorithmError    = i18n.S("token algorithm is not allowed")
+	jwtSignatureError    = i18n.S("token signature is invalid")
+	jwtExpiredError      = i18n.S("token has expired")
+	jwtExpiryError       = i18n.S("token must have an expiration time")
+	jwtNotYetValidError  = i18n.S("token is not valid yet")
+	jwtIssuerError       = i18n.S("token issuer is not allowed")
+	jwtAudienceError     = i18n.S("token audience is not allowed")
+	jwtInvalidClaimError = i18n.S("token has an invalid registered claim")
+)
+
+// JWTKeyFunc returns the key that verifies a token, given its decoded header (for
+// example to select a key by "kid"). The key types are those accepted by JWT().Key.
+type JWTKeyFunc func(header map[string]interface{}) (interface{}, error)
+
+// JWTSchema validates JSON Web Tokens in compact serialization and returns the
+// decoded claims as map[string]interface{}. Signatures are only verified when a key
+// is configured; the exp and nbf claims are always checked when present.
+type JWTSchema struct {
+	Schema
+	key        interface{}
+	keyFunc    JWTKeyFunc
+	algorithms []string
+	issuers    []string
+	audiences  []string
+	requireExp bool
+	leeway     time.Duration
+	nullable   bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	signatureError    ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// JWT creates a new JSON Web Token schema
+func JWT(errorMessage ...interface{}) *JWTSchema {
+	schema := &JWTSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+			sensitive:  true,
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *JWTSchema) Clone() *JWTSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.algorithms = slices.Clone(s.algorithms)
+	c.issuers = slices.Clone(s.issuers)
+	c.audiences = slices.Clone(s.audiences)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *JWTSchema) Freeze() *JWTSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *JWTSchema) Title(title string) *JWTSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *JWTSchema) Description(description string) *JWTSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *JWTSchema) ReadOnly() *JWTSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *JWTSchema) WriteOnly() *JWTSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *JWTSchema) Sensitive() *JWTSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *JWTSchema) Deprecated(reason string) *JWTSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *JWTSchema) Meta(key string, value interface{}) *JWTSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *JWTSchema) AsWarning(codes ...ErrorCode) *JWTSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *JWTSchema) AsInfo(codes ...ErrorCode) *JWTSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *JWTSchema) Default(value interface{}) *JWTSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *JWTSchema) DefaultFunc(fn func() interface{}) *JWTSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *JWTSchema) Example(example string) *JWTSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Key verifies signatures with a fixed key: []byte for HS256/384/512, *rsa.PublicKey
+// for RS and PS algorithms, *ecdsa.PublicKey for ES256/384/512 and ed25519.PublicKey
+// for EdDSA. Tokens signed with an algorithm that doesn't match the key type are rejected.
+func (s *JWTSchema) Key(key interface{}) *JWTSchema {
+	s.checkMutable()
+	s.key = key
+	s.keyFunc = nil
+	return s
+}
+
+// KeyFunc verifies signatures with a key chosen per token, such as from a JWKS by "kid"
+func (s *JWTSchema) KeyFunc(fn JWTKeyFunc) *JWTSchema {
+	s.checkMutable()
+	s.keyFunc = fn
+	s.key = nil
+	return s
+}
+
+// Algorithms restricts the "alg" header, e.g. Algorithms("RS256"). "none" is never
+// accepted when a key is configured.
+func (s *JWTSchema) Algorithms(algorithms ...string) *JWTSchema {
+	s.checkMutable()
+	s.algorithms = algorithms
+	return s
+}
+
+// Issuer requires the "iss" claim to be one of the given issuers
+func (s *JWTSchema) Issuer(issuers ...string) *JWTSchema {
+	s.checkMutable()
+	s.issuers = issuers
+	return s
+}
+
+// Audience requires the "aud" claim (a string or an array) to contain one of the given audiences
+func (s *JWTSchema) Audience(audiences ...string) *JWTSchema {
+	s.checkMutable()
+	s.audiences = audiences
+	return s
+}
+
+// RequireExpiry rejects tokens without an "exp" claim
+func (s *JWTSchema) RequireExpiry() *JWTSchema {
+	s.checkMutable()
+	s.requireExp = true
+	return s
+}
+
+// Leeway allows for clock skew when checking the "exp" and "nbf" claims
+func (s *JWTSchema) Leeway(leeway time.Duration) *JWTSchema {
+	s.checkMutable()
+	s.leeway = leeway
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *JWTSchema) Optional() *JWTSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *JWTSchema) Required(errorMessage ...interface{}) *JWTSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *JWTSchema) Nullable() *JWTSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *JWTSchema) TypeError(message string) *JWTSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for malformed tokens
+func (s *JWTSchema) FormatError(message string) *JWTSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// SignatureError sets a custom error message for tokens that fail verification
+func (s *JWTSchema) SignatureError(message string) *JWTSchema {
+	s.checkMutable()
+	s.signatureError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *JWTSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *JWTSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *JWTSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// IsVerifying returns whether signatures are verified
+func (s *JWTSchema) IsVerifying() bool {
+	return s.key != nil || s.keyFunc != nil
+}
+
+// GetAlgorithms returns the allowed algorithms
+func (s *JWTSchema) GetAlgorithms() []string {
+	return s.algorithms
+}
+
+// GetIssuers returns the allowed issuers
+func (s *JWTSchema) GetIssuers() []string {
+	return s.issuers
+}
+
+// GetAudiences returns the accepted audiences
+func (s *JWTSchema) GetAudiences() []string {
+	return s.audiences
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *JWTSchema) Transform(fn TransformFunc) *JWTSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *JWTSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *JWTSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *JWTSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *JWTSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a token and returns its claims as map[string]interface{}
+func (s *JWTSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the token; Parse runs the refine/transform pipeline on top
+func (s *JWTSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := jwtRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	token, ok := value.(string)
+	if !ok {
+		message := jwtTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	header, claims, signingInput, signature, ok := decodeJWT(token)
+	if !ok {
+		message := jwtFormatError(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+
+	// The header is checked before any claim is trusted
+	alg, _ := header["alg"].(string)
+	if len(s.algorithms) > 0 && !containsString(s.algorithms, alg) || s.IsVerifying() && alg == "none" {
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, jwtAlgorithmError(ctx.Locale), CodeAlgorithm)}}
+	}
+	if s.IsVerifying() {
+		key := s.key
+		var err error
+		if s.keyFunc != nil {
+			key, err = s.keyFunc(header)
+		}
+		if err != nil || !verifyJWTSignature(alg, key, []byte(signingInput), signature) {
+			message := jwtSignatureError(ctx.Locale)
+			if !isEmptyErrorMessage(s.signatureError) {
+				message = resolveErrorMessage(s.signatureError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeSignature)}}
+		}
+	}
+
+	if errs := s.checkClaims(claims, value, ctx); len(errs) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errs}
+	}
+	return ParseResult{Valid: true, Value: claims, Errors: nil}
+}
+
+// checkClaims validates the registered claims against the schema settings and the
+// clock of the validation context
+func (s *JWTSchema) checkClaims(claims map[string]interface{}, value interface{}, ctx *ValidationContext) []ValidationError {
+	var errs []ValidationError
+	now := ctx.now()
+
+	exp, hasExp, expOK := jwtNumericDate(claims, "exp")
+	nbf, hasNbf, nbfOK := jwtNumericDate(claims, "nbf")
+	iss, issOK := claims["iss"].(string)
+	audiences, audOK := jwtAudiences(claims["aud"])
+	if !expOK || !nbfOK || claims["iss"] != nil && !issOK || !audOK {
+		return []ValidationError{NewPrimitiveError(value, jwtInvalidClaimError(ctx.Locale), CodeInvalidClaim)}
+	}
+
+	switch {
+	case hasExp && !now.Before(exp.Add(s.leeway)):
+		errs = append(errs, NewPrimitiveError(value, jwtExpiredError(ctx.Locale), CodeExpired))
+	case !hasExp && s.requireExp:
+		errs = append(errs, NewPrimitiveError(value, jwtExpiryError(ctx.Locale), CodeExpiryRequired))
+	}
+	if hasNbf && now.Add(s.leeway).Before(nbf) {
+		errs = append(errs, NewPrimitiveError(value, jwtNotYetValidError(ctx.Locale), CodeNotYetValid))
+	}
+	if len(s.issuers) > 0 && !containsString(s.issuers, iss) {
+		errs = append(errs, NewPrimitiveError(value, jwtIssuerError(ctx.Locale), CodeIssuer))
+	}
+	if len(s.audiences) > 0 {
+		matched := false
+		for _, audience := range audiences {
+			matched = matched || containsString(s.audiences, audience)
+		}
+		if !matched {
+			errs = append(errs, NewPrimitiveError(value, jwtAudienceError(ctx.Locale), CodeAudience))
+		}
+	}
+	return errs
+}
+
+// decodeJWT splits a compact JWS into its decoded header, claims, signing input and signature
+func decodeJWT(token string) (map[string]interface{}, map[string]interface{}, string, []byte, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, "", nil, false
+	}
+	var header, claims map[string]interface{}
+	for i, target := range []*map[string]interface{}{&header, &claims} {
+		data, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil || json.Unmarshal(data, target) != nil || *target == nil {
+			return nil, nil, "", nil, false
+		}
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, "", nil, false
+	}
+	if _, ok := header["alg"].(string); !ok {
+		return nil, nil, "", nil, false
+	}
+	return header, claims, parts[0] + "." + parts[1], signature, true
+}
+
+// jwtHashes maps the size suffix of an algorithm name to its hash
+var jwtHashes = map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}
+
+// verifyJWTSignature checks signature with key for alg; the key type must match the
+// algorithm family so that, for example, an RSA public key is never used as an HMAC secret
+func verifyJWTSignature(alg string, key interface{}, signingInput, signature []byte) bool {
+	if alg == "EdDSA" {
+		publicKey, ok := key.(ed25519.PublicKey)
+		return ok && ed25519.Verify(publicKey, signingInput, signature)
+	}
+	if len(alg) != 5 {
+		return false
+	}
+	hash, ok := jwtHashes[alg[2:]]
+	if !ok {
+		return false
+	}
+	digest := func() []byte {
+		h := hash.New()
+		h.Write(signingInput)
+		return h.Sum(nil)
+	}
+
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return false
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write(signingInput)
+		return hmac.Equal(signature, mac.Sum(nil))
+	case "RS":
+		publicKey, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPKCS1v15(publicKey, hash, digest(), signature) == nil
+	case "PS":
+		publicKey, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPSS(publicKey, hash, digest(), signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+	case "ES":
+		publicKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return false
+		}
+		// ES512 uses P-521, whose coordinates are 66 bytes
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		if publicKey.Curve.Params().BitSize != map[string]int{"256": 256, "384": 384, "512": 521}[alg[2:]] || len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		sig := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(publicKey, digest(), r, sig)
+	}
+	return false
+}
+
+// jwtNumericDate reads a NumericDate claim (seconds since the epoch). It reports
+// whether the claim is present and whether it is well-formed.
+func jwtNumericDate(claims map[string]interface{}, name string) (time.Time, bool, bool) {
+	raw, present := claims[name]
+	if !present {
+		return time.Time{}, false, true
+	}
+	seconds, ok := raw.(float64)
+	if !ok || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return time.Time{}, true, false
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*1e9)), true, true
+}
+
+// jwtAudiences reads the "aud" claim, which is either a string or an array of strings
+func jwtAudiences(raw interface{}) ([]string, bool) {
+	switch aud := raw.(type) {
+	case nil:
+		return nil, true
+	case string:
+		return []string{aud}, true
+	case []interface{}:
+		audiences := make([]string, len(aud))
+		for i, item := range aud {
+			str, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			audiences[i] = str
+		}
+		return audiences, true
+	}
+	return nil, false
+}
+
+// JSON generates JSON Schema representation
+func (s *JWTSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["pattern"] = `^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
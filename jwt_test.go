@@ -0,0 +1,225 @@
+package schema
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signJWT builds a compact token signed with key for alg
+func signJWT(t *testing.T, alg string, key interface{}, claims map[string]interface{}) string {
+	t.Helper()
+	segment := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	input := segment(map[string]interface{}{"alg": alg, "typ": "JWT", "kid": "k1"}) + "." + segment(claims)
+	if alg == "none" {
+		return input + "."
+	}
+
+	hash := jwtHashes[alg[len(alg)-3:]]
+	var signature []byte
+	var err error
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(input))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		h := hash.New()
+		h.Write([]byte(input))
+		if alg[:2] == "PS" {
+			signature, err = rsa.SignPSS(rand.Reader, k, hash, h.Sum(nil), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, k, hash, h.Sum(nil))
+		}
+	case *ecdsa.PrivateKey:
+		h := hash.New()
+		h.Write([]byte(input))
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, h.Sum(nil))
+		size := (k.Curve.Params().BitSize + 7) / 8
+		signature = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(k, []byte(input))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWTSchema_Signatures(t *testing.T) {
+	ctx := DefaultValidationContext()
+	claims := map[string]interface{}{"sub": "user-1"}
+
+	secret := []byte("top-secret")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		schema   *JWTSchema
+		token    string
+		expected bool
+		code     ErrorCode
+	}{
+		{"HS256", JWT().Key(secret), signJWT(t, "HS256", secret, claims), true, ""},
+		{"HS512", JWT().Key(secret), signJWT(t, "HS512", secret, claims), true, ""},
+		{"HS256 wrong secret", JWT().Key([]byte("other")), signJWT(t, "HS256", secret, claims), false, "signature"},
+		{"RS256", JWT().Key(&rsaKey.PublicKey), signJWT(t, "RS256", rsaKey, claims), true, ""},
+		{"PS384", JWT().Key(&rsaKey.PublicKey), signJWT(t, "PS384", rsaKey, claims), true, ""},
+		{"ES256", JWT().Key(&ecKey.PublicKey), signJWT(t, "ES256", ecKey, claims), true, ""},
+		{"ES512", JWT().Key(&ec521Key.PublicKey), signJWT(t, "ES512", ec521Key, claims), true, ""},
+		{"ES384 with P-256 key", JWT().Key(&ecKey.PublicKey), signJWT(t, "ES384", ecKey, claims), false, "signature"},
+		{"EdDSA", JWT().Key(edPublic), signJWT(t, "EdDSA", edPrivate, claims), true, ""},
+		{"alg confusion", JWT().Key(&rsaKey.PublicKey), signJWT(t, "HS256", secret, claims), false, "signature"},
+		{"none with key", JWT().Key(secret), signJWT(t, "none", nil, claims), false, "algorithm"},
+		{"none without key", JWT(), signJWT(t, "none", nil, claims), true, ""},
+		{"algorithm allowed", JWT().Key(secret).Algorithms("HS256"), signJWT(t, "HS256", secret, claims), true, ""},
+		{"algorithm rejected", JWT().Key(secret).Algorithms("HS256"), signJWT(t, "HS384", secret, claims), false, "algorithm"},
+		{"unverified", JWT(), signJWT(t, "HS256", []byte("anything"), claims), true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.token, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse = %v, want %v (%v)", result.Valid, tt.expected, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+			if result.Valid && !reflect.DeepEqual(result.Value, claims) {
+				t.Errorf("Value = %v, want %v", result.Value, claims)
+			}
+		})
+	}
+
+	keys := map[string]interface{}{"k1": secret}
+	byKid := JWT().KeyFunc(func(header map[string]interface{}) (interface{}, error) {
+		if key, ok := keys[header["kid"].(string)]; ok {
+			return key, nil
+		}
+		return nil, errors.New("unknown key")
+	})
+	if result := byKid.Parse(signJWT(t, "HS256", secret, claims), ctx); !result.Valid {
+		t.Errorf("expected KeyFunc token to be valid, got %v", result.Errors)
+	}
+	delete(keys, "k1")
+	if result := byKid.Parse(signJWT(t, "HS256", secret, claims), ctx); result.Valid || result.Errors[0].Code != "signature" {
+		t.Errorf("expected unknown key to fail, got %v", result.Errors)
+	}
+
+	// A modified payload invalidates the signature
+	token := signJWT(t, "HS256", secret, claims)
+	other := signJWT(t, "HS256", secret, map[string]interface{}{"sub": "admin"})
+	parts, otherParts := strings.Split(token, "."), strings.Split(other, ".")
+	tampered := parts[0] + "." + otherParts[1] + "." + parts[2]
+	if result := JWT().Key(secret).Parse(tampered, ctx); result.Valid {
+		t.Error("expected tampered token to be rejected")
+	}
+}
+
+func TestJWTSchema_Claims(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ctx := DefaultValidationContext().WithClock(func() time.Time { return now })
+	secret := []byte("secret")
+	token := func(claims map[string]interface{}) string { return signJWT(t, "HS256", secret, claims) }
+	unix := func(d time.Duration) float64 { return float64(now.Add(d).Unix()) }
+
+	tests := []struct {
+		name     string
+		schema   *JWTSchema
+		token    string
+		expected bool
+		code     ErrorCode
+	}{
+		{"not expired", JWT(), token(map[string]interface{}{"exp": unix(time.Minute)}), true, ""},
+		{"expired", JWT(), token(map[string]interface{}{"exp": unix(-time.Minute)}), false, "expired"},
+		{"expired at now", JWT(), token(map[string]interface{}{"exp": unix(0)}), false, "expired"},
+		{"expired within leeway", JWT().Leeway(2 * time.Minute), token(map[string]interface{}{"exp": unix(-time.Minute)}), true, ""},
+		{"expiry required", JWT().RequireExpiry(), token(map[string]interface{}{"sub": "x"}), false, "expiry_required"},
+		{"not yet valid", JWT(), token(map[string]interface{}{"nbf": unix(time.Minute)}), false, "not_yet_valid"},
+		{"nbf within leeway", JWT().Leeway(time.Minute), token(map[string]interface{}{"nbf": unix(30 * time.Second)}), true, ""},
+		{"issuer", JWT().Issuer("https://auth.example.com"), token(map[string]interface{}{"iss": "https://auth.example.com"}), true, ""},
+		{"issuer mismatch", JWT().Issuer("https://auth.example.com"), token(map[string]interface{}{"iss": "https://evil.example.com"}), false, "issuer"},
+		{"issuer missing", JWT().Issuer("https://auth.example.com"), token(map[string]interface{}{}), false, "issuer"},
+		{"audience string", JWT().Audience("api"), token(map[string]interface{}{"aud": "api"}), true, ""},
+		{"audience array", JWT().Audience("api"), token(map[string]interface{}{"aud": []string{"web", "api"}}), true, ""},
+		{"audience mismatch", JWT().Audience("api"), token(map[string]interface{}{"aud": []string{"web"}}), false, "audience"},
+		{"invalid exp", JWT(), token(map[string]interface{}{"exp": "tomorrow"}), false, "invalid_claim"},
+		{"invalid aud", JWT(), token(map[string]interface{}{"aud": 42}), false, "invalid_claim"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Key(secret).Parse(tt.token, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse = %v, want %v (%v)", result.Valid, tt.expected, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	// Claim failures are reported together
+	result := JWT().Issuer("a").Audience("b").Parse(token(map[string]interface{}{"exp": unix(-time.Hour), "iss": "x", "aud": "y"}), ctx)
+	if codes := errorKeys(result.Errors); !reflect.DeepEqual(codes, []string{" audience", " expired", " issuer"}) {
+		t.Errorf("errors = %v", codes)
+	}
+}
+
+func TestJWTSchema_Format(t *testing.T) {
+	ctx := DefaultValidationContext()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1"}`))
+
+	for _, token := range []string{
+		"",
+		"abc",
+		header + "." + payload,
+		header + "." + payload + ".sig.extra",
+		header + "." + payload + ".!!!",
+		"e30." + payload + ".", // header without alg
+		header + "." + base64.RawURLEncoding.EncodeToString([]byte(`[1]`)) + ".", // claims not an object
+		header + "=." + payload + ".",                                            // padded
+	} {
+		if result := JWT().Parse(token, ctx); result.Valid || result.Errors[0].Code != "format" {
+			t.Errorf("expected %q to fail with format, got %v", token, result.Errors)
+		}
+	}
+	if result := JWT().Parse(42, ctx); result.Valid || result.Errors[0].Code != "invalid_type" {
+		t.Errorf("errors = %v", result.Errors)
+	}
+}
@@ -0,0 +1,411 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for language tag validation
+var (
+	languageTagRequiredError = i18n.S("value is required")
+	languageTagTypeError     = i18n.S("value must be a string")
+	languageTagFormatError   = i18n.S("value must be a valid BCP 47 language tag")
+)
+
+// LanguageTagSchema validates BCP 47 language tags such as "en", "pt-BR", "zh-Hant-TW"
+// or "de-CH-1996". The language, script and region subtags are checked against
+// embedded ISO 639, ISO 15924 and ISO 3166-1 tables; the remaining subtags are checked
+// for syntax only. Grandfathered tags such as "i-klingon" are rejected.
+type LanguageTagSchema struct {
+	Schema
+	canonicalize bool
+	nullable     bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// LanguageTag creates a new BCP 47 language tag schema
+func LanguageTag(errorMessage ...interface{}) *LanguageTagSchema {
+	schema := &LanguageTagSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *LanguageTagSchema) Clone() *LanguageTagSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *LanguageTagSchema) Freeze() *LanguageTagSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *LanguageTagSchema) Title(title string) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *LanguageTagSchema) Description(description string) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *LanguageTagSchema) ReadOnly() *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *LanguageTagSchema) WriteOnly() *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *LanguageTagSchema) Sensitive() *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *LanguageTagSchema) Deprecated(reason string) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *LanguageTagSchema) Meta(key string, value interface{}) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *LanguageTagSchema) AsWarning(codes ...ErrorCode) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *LanguageTagSchema) AsInfo(codes ...ErrorCode) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *LanguageTagSchema) Default(value interface{}) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *LanguageTagSchema) DefaultFunc(fn func() interface{}) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *LanguageTagSchema) Example(example string) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Canonicalize returns tags in their canonical case: lowercase language, titlecase
+// script and uppercase region ("ZH-hant-tw" becomes "zh-Hant-TW"). Tags are accepted
+// in any case either way, as BCP 47 requires.
+func (s *LanguageTagSchema) Canonicalize() *LanguageTagSchema {
+	s.checkMutable()
+	s.canonicalize = true
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *LanguageTagSchema) Optional() *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *LanguageTagSchema) Required(errorMessage ...interface{}) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *LanguageTagSchema) Nullable() *LanguageTagSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *LanguageTagSchema) TypeError(message string) *LanguageTagSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for invalid tags
+func (s *LanguageTagSchema) FormatError(message string) *LanguageTagSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *LanguageTagSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *LanguageTagSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *LanguageTagSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// IsCanonicalized returns whether tags are returned in canonical case
+func (s *LanguageTagSchema) IsCanonicalized() bool {
+	return s.canonicalize
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *LanguageTagSchema) Transform(fn TransformFunc) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *LanguageTagSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *LanguageTagSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *LanguageTagSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a language tag and returns it (in canonical case with Canonicalize)
+func (s *LanguageTagSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the tag; Parse runs the refine/transform pipeline on top
+func (s *LanguageTagSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := languageTagRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	tag, ok := value.(string)
+	if !ok {
+		message := languageTagTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	canonical, ok := canonicalLanguageTag(tag)
+	if !ok {
+		message := languageTagFormatError(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+	if s.canonicalize {
+		tag = canonical
+	}
+	return ParseResult{Valid: true, Value: tag, Errors: nil}
+}
+
+// canonicalLanguageTag validates a tag against the RFC 5646 "langtag" and "privateuse"
+// productions and returns it in canonical case
+func canonicalLanguageTag(tag string) (string, bool) {
+	subtags := strings.Split(strings.ToLower(tag), "-")
+	for _, subtag := range subtags {
+		if subtag == "" || len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return "", false
+		}
+	}
+
+	i := 0
+	if subtags[0] != "x" {
+		// language, optionally followed by up to three extended language subtags
+		language := subtags[0]
+		if !isAlpha(language) || !(len(language) == 2 && languageAlpha2.has(language) || len(language) == 3 && languageAlpha3.has(language)) {
+			return "", false
+		}
+		i = 1
+		for extlangs := 0; len(language) <= 3 && extlangs < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); extlangs++ {
+			if !languageAlpha3.has(subtags[i]) {
+				return "", false
+			}
+			i++
+		}
+
+		// script
+		if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+			subtags[i] = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+			if !scriptCodes.has(subtags[i]) {
+				return "", false
+			}
+			i++
+		}
+
+		// region: ISO 3166-1 alpha-2 or UN M.49 area code
+		if i < len(subtags) && (len(subtags[i]) == 2 && isAlpha(subtags[i]) || len(subtags[i]) == 3 && isDigits(subtags[i])) {
+			subtags[i] = strings.ToUpper(subtags[i])
+			if isAlpha(subtags[i]) && !countryAlpha2Codes.has(subtags[i]) {
+				return "", false
+			}
+			i++
+		}
+
+		// variants: 5-8 characters, or 4 starting with a digit, without repeats
+		seen := map[string]bool{}
+		for i < len(subtags) && (len(subtags[i]) >= 5 || len(subtags[i]) == 4 && isDigits(subtags[i][:1])) {
+			if seen[subtags[i]] {
+				return "", false
+			}
+			seen[subtags[i]] = true
+			i++
+		}
+
+		// extensions: a singleton other than "x" followed by subtags of 2-8 characters
+		for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+			if seen[subtags[i]] {
+				return "", false
+			}
+			seen[subtags[i]] = true
+			i++
+			start := i
+			for i < len(subtags) && len(subtags[i]) >= 2 {
+				i++
+			}
+			if i == start {
+				return "", false
+			}
+		}
+	}
+
+	// private use: "x" followed by subtags of 1-8 characters
+	if i < len(subtags) {
+		if subtags[i] != "x" || i == len(subtags)-1 {
+			return "", false
+		}
+		i = len(subtags)
+	}
+	return strings.Join(subtags, "-"), true
+}
+
+// isAlpha reports whether s consists of ASCII letters only
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits reports whether s consists of ASCII digits only
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanumeric reports whether s consists of ASCII letters and digits only
+func isAlphanumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isAlpha(s[i:i+1]) && !isDigits(s[i:i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// JSON generates JSON Schema representation
+func (s *LanguageTagSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["pattern"] = "^[A-Za-z0-9]{1,8}(-[A-Za-z0-9]{1,8})*$"
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
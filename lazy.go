@@ -0,0 +1,211 @@
+package schema
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error message functions for recursive schemas
+func maxDepthError(max int) i18n.TranslatedFunc {
+	return i18n.F("maximum nesting depth of %d exceeded", max)
+}
+
+// LazySchema defers building its schema until it is first used, which allows
+// self-referential structures (trees, threaded comments, nested categories)
+type LazySchema struct {
+	getter     func() Parseable
+	once       sync.Once
+	schema     Parseable
+	nullable   bool // Allow null values without consulting the resolved schema
+	depthError ErrorMessage
+	effects    effects
+	frozenState
+}
+
+// Lazy creates a schema that is resolved from getter on first use.
+//
+//	var category *schema.ObjectSchema
+//	category = schema.Object().
+//	    Property("name", schema.String()).
+//	    Property("children", schema.Array(schema.Lazy(func() schema.Parseable { return category })).Optional())
+//
+// Recursion during parsing is bounded by ValidationContext.MaxDepth.
+func Lazy(getter func() Parseable) *LazySchema {
+	return &LazySchema{
+		getter: getter,
+	}
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// The copy resolves its schema from the same getter.
+func (s *LazySchema) Clone() *LazySchema {
+	return &LazySchema{
+		getter:     s.getter,
+		nullable:   s.nullable,
+		depthError: s.depthError,
+		effects:    slices.Clone(s.effects),
+	}
+}
+
+// Freeze makes the schema immutable: any later modification panics. The resolved
+// schema is not frozen, since resolving it early would defeat the laziness.
+func (s *LazySchema) Freeze() *LazySchema {
+	s.freeze()
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *LazySchema) Nullable() *LazySchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *LazySchema) IsNullable() bool {
+	return s.nullable
+}
+
+// SetNullable implements SetNullable interface
+func (s *LazySchema) SetNullable() {
+	s.Nullable()
+}
+
+// DepthError sets a custom error message for when the maximum nesting depth is exceeded
+func (s *LazySchema) DepthError(errorMessage ...interface{}) *LazySchema {
+	s.checkMutable()
+	if len(errorMessage) > 0 {
+		s.depthError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Schema returns the resolved schema, calling the getter on first use
+func (s *LazySchema) Schema() Parseable {
+	s.once.Do(func() {
+		s.schema = s.getter()
+	})
+	return s.schema
+}
+
+// Children returns the resolved schema, calling the getter on first use
+func (s *LazySchema) Children() []SchemaChild {
+	return sameValueChildren(s.Schema())
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *LazySchema) Transform(fn TransformFunc) *LazySchema {
+	s.checkMutable()
+	s.effects = s.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *LazySchema) Refine(fn RefineFunc, errorMessage ...interface{}) *LazySchema {
+	s.checkMutable()
+	s.effects = s.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *LazySchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *LazySchema {
+	s.checkMutable()
+	s.effects = s.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse resolves the schema and validates the value against it
+func (s *LazySchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.effects.apply(ctx.startParse(s), s.parse(value, s.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the resolved schema one recursion level deeper; Parse runs the refine/transform pipeline on top
+func (s *LazySchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	if value == nil && s.nullable {
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	child, ok := ctx.descend(value)
+	if !ok {
+		message := maxDepthError(ctx.maxDepth())(ctx.Locale)
+		if !isEmptyErrorMessage(s.depthError) {
+			message = resolveErrorMessage(s.depthError, ctx)
+		}
+		return ParseResult{
+			Valid:  false,
+			Value:  nil,
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeMaxDepth)},
+		}
+	}
+
+	defer child.release()
+	return s.Schema().Parse(value, child)
+}
+
+// JSON generates JSON Schema for the resolved schema. A recursive occurrence inside
+// its own output is a "$ref" to "#", and the recursive Lazy schemas nested in it are
+// emitted once under "$defs" and referenced with "$ref".
+func (s *LazySchema) JSON() map[string]interface{} {
+	return rootJSON(func(g *jsonGeneration) map[string]interface{} {
+		g.root = s
+		return s.generateJSON(g)
+	})
+}
+
+// generateJSON returns the JSON Schema of the resolved schema, or a "$ref" to its
+// definition when it is nested in itself
+func (s *LazySchema) generateJSON(g *jsonGeneration) map[string]interface{} {
+	if g.generating[s] {
+		if s == g.root {
+			return map[string]interface{}{"$ref": "#"}
+		}
+		return map[string]interface{}{"$ref": defsPointer(g.defName(s))}
+	}
+	if g.generating == nil {
+		g.generating = map[*LazySchema]bool{}
+	}
+	g.generating[s] = true
+	schema, ok := childJSON(s.Schema(), g)
+	delete(g.generating, s)
+	if !ok {
+		schema = map[string]interface{}{}
+	}
+
+	name, recursive := g.names[s]
+	if !recursive {
+		return schema
+	}
+	if g.defs == nil {
+		g.defs = map[string]interface{}{}
+	}
+	g.defs[name] = schema
+	return map[string]interface{}{"$ref": defsPointer(name)}
+}
+
+// defName returns the $defs name of a recursive Lazy schema: the title of its resolved
+// schema, or "Lazy", numbered when several schemas would have the same name
+func (g *jsonGeneration) defName(s *LazySchema) string {
+	if name, ok := g.names[s]; ok {
+		return name
+	}
+	base := "Lazy"
+	if described, ok := s.Schema().(describable); ok && described.GetTitle() != "" {
+		base = described.GetTitle()
+	}
+	taken := make(map[string]bool, len(g.names))
+	for _, name := range g.names {
+		taken[name] = true
+	}
+	name := base
+	for i := 2; taken[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	if g.names == nil {
+		g.names = map[*LazySchema]string{}
+	}
+	g.names[s] = name
+	return name
+}
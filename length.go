@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// LengthUnit selects how string length constraints measure a value
+type LengthUnit string
+
+// Supported length units
+const (
+	LengthUnitRunes     LengthUnit = "runes"     // Unicode code points (default, as in JSON Schema)
+	LengthUnitBytes     LengthUnit = "bytes"     // UTF-8 encoded bytes
+	LengthUnitGraphemes LengthUnit = "graphemes" // User-perceived characters
+)
+
+// Code points with special meaning for grapheme counting
+const (
+	zeroWidthJoiner        = '\u200D'
+	regionalIndicatorFirst = '\U0001F1E6'
+	regionalIndicatorLast  = '\U0001F1FF'
+	emojiModifierFirst     = '\U0001F3FB'
+	emojiModifierLast      = '\U0001F3FF'
+)
+
+// stringLength measures value in the given unit
+func stringLength(value string, unit LengthUnit) int {
+	switch unit {
+	case LengthUnitBytes:
+		return len(value)
+	case LengthUnitGraphemes:
+		return graphemeCount(value)
+	default:
+		return utf8.RuneCountInString(value)
+	}
+}
+
+// graphemeCount approximates the number of extended grapheme clusters in value.
+// Combining and spacing marks, variation selectors, emoji modifiers and
+// zero-width-joiner sequences are attached to the preceding character, conjoining
+// Hangul jamo form one syllable, CRLF counts once and pairs of regional indicators
+// (flags) count as one character.
+func graphemeCount(value string) int {
+	count := 0
+	prev := rune(-1)
+	joinNext := false
+	pendingFlag := false
+
+	for _, r := range value {
+		switch {
+		case prev == -1:
+			// First character always starts a cluster
+		case joinNext:
+			joinNext = false
+			prev = r
+			continue
+		case r == zeroWidthJoiner:
+			joinNext = true
+			continue
+		case prev == '\r' && r == '\n':
+			prev = r
+			continue
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Variation_Selector),
+			r >= emojiModifierFirst && r <= emojiModifierLast:
+			prev = r
+			continue
+		case hangulJoins(hangulTypeOf(prev), hangulTypeOf(r)):
+			prev = r
+			continue
+		case pendingFlag && r >= regionalIndicatorFirst && r <= regionalIndicatorLast:
+			pendingFlag = false
+			prev = r
+			continue
+		}
+
+		count++
+		pendingFlag = r >= regionalIndicatorFirst && r <= regionalIndicatorLast
+		prev = r
+	}
+	return count
+}
+
+// hangulType is the Hangul syllable type of a code point, as used by the grapheme
+// cluster rules
+type hangulType int
+
+const (
+	hangulNone hangulType = iota
+	hangulL               // Leading consonant jamo
+	hangulV               // Vowel jamo
+	hangulT               // Trailing consonant jamo
+	hangulLV              // Precomposed syllable without trailing consonant
+	hangulLVT             // Precomposed syllable with a trailing consonant
+)
+
+// hangulTypeOf returns the Hangul syllable type of r
+func hangulTypeOf(r rune) hangulType {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return hangulL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return hangulV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return hangulT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return hangulLV
+		}
+		return hangulLVT
+	}
+	return hangulNone
+}
+
+// hangulJoins reports whether a jamo of type next continues the syllable ending with
+// a code point of type prev
+func hangulJoins(prev, next hangulType) bool {
+	switch prev {
+	case hangulL:
+		return next == hangulL || next == hangulV || next == hangulLV || next == hangulLVT
+	case hangulLV, hangulV:
+		return next == hangulV || next == hangulT
+	case hangulLVT, hangulT:
+		return next == hangulT
+	}
+	return false
+}
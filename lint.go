@@ -0,0 +1,229 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// LintRule identifies the check that reported a LintIssue
+type LintRule string
+
+const (
+	LintContradiction      LintRule = "contradiction"       // Constraints that no value satisfies together
+	LintUnreachableBranch  LintRule = "unreachable_branch"  // A union or anyOf branch shadowed by an earlier one
+	LintEnumPattern        LintRule = "enum_pattern"        // A const or enum value not matching the pattern
+	LintInvalidDefault     LintRule = "invalid_default"     // A default rejected by its own schema
+	LintMissingTitle       LintRule = "missing_title"       // A root schema without title
+	LintMissingDescription LintRule = "missing_description" // A root schema or object property without description
+)
+
+// LintIssue is a problem found in a schema by Lint
+type LintIssue struct {
+	Path     []string // The path of the values the schema applies to, as passed by Walk (nil for the root)
+	Rule     LintRule
+	Severity Severity // Error for schemas rejecting values they should accept, warning or info otherwise
+	Message  string
+}
+
+// String describes the issue, e.g. `contact.name: error contradiction: minLength 10 is greater than maxLength 5`
+func (i LintIssue) String() string {
+	path := strings.Join(i.Path, ".")
+	if path == "" {
+		path = "(root)"
+	}
+	return fmt.Sprintf("%s: %s %s: %s", path, i.Severity, i.Rule, i.Message)
+}
+
+// Lint checks a schema and the schemas nested in it for mistakes made when writing
+// them, without parsing any value, so that a registry of schemas can be checked in CI:
+//
+//   - constraints contradicting each other, as reported by the Validate methods, and
+//     bounds no value satisfies (LintContradiction)
+//   - union and anyOf branches accepting only values an earlier branch accepts
+//     (LintUnreachableBranch)
+//   - string const and enum values not matching the pattern (LintEnumPattern)
+//   - defaults rejected by their schema (LintInvalidDefault)
+//   - a root schema without title or description, and object properties without
+//     description (LintMissingTitle and LintMissingDescription, as infos)
+//
+// Issues are returned in the order of Walk. Defaults are checked against the
+// constraints of their schema only: refinements, RefineCtx validators and transforms
+// are not run, so linting performs no I/O. Defaults computed by DefaultFunc are not
+// checked, as they may differ per parse.
+func Lint(s Parseable) []LintIssue {
+	var issues []LintIssue
+	report := func(path []string, rule LintRule, severity Severity, format string, args ...interface{}) {
+		issues = append(issues, LintIssue{Path: path, Rule: rule, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if described, ok := s.(describable); ok {
+		if described.GetTitle() == "" {
+			report(nil, LintMissingTitle, SeverityInfo, "the schema has no title")
+		}
+		if described.GetDescription() == "" {
+			report(nil, LintMissingDescription, SeverityInfo, "the schema has no description")
+		}
+	}
+
+	Walk(s, func(path []string, s Parseable) bool {
+		for _, message := range lintContradictions(s, path, report) {
+			report(path, LintContradiction, SeverityError, "%s", message)
+		}
+
+		switch v := s.(type) {
+		case *UnionSchema:
+			lintBranches(v.schemas, path, report)
+		case *AnyOfSchema:
+			lintBranches(v.schemas, path, report)
+		case *ObjectSchema:
+			for _, name := range sortedPropertyNames(v.properties) {
+				described, ok := v.properties[name].Schema.(describable)
+				if ok && described.GetDescription() == "" {
+					report(append(slices.Clip(path), name), LintMissingDescription, SeverityInfo, "property %s has no description", name)
+				}
+			}
+		}
+
+		if defaulted, ok := s.(lintDefaulted); ok && !defaulted.HasDefaultFunc() {
+			if value := defaulted.GetDefault(); value != nil {
+				ctx := DefaultValidationContext()
+				ctx.structural = true
+				if result := s.Parse(value, ctx); !result.Valid {
+					report(path, LintInvalidDefault, SeverityError, "default %v fails the schema: %s", value, result.Errors[0].Message)
+				}
+			}
+		}
+		return true
+	})
+	return issues
+}
+
+// describable is implemented by the schemas with a title and description
+type describable interface {
+	GetTitle() string
+	GetDescription() string
+}
+
+// lintDefaulted is implemented by the schemas with a default
+type lintDefaulted interface {
+	GetDefault() interface{}
+	HasDefaultFunc() bool
+}
+
+// validator is implemented by the schemas reporting their contradictions
+type validator interface {
+	Validate() error
+}
+
+// lintContradictions returns the contradictions of s. The const and enum values of
+// strings are checked against the pattern first, with their own rule, and against the
+// other constraints without the pattern, so that each mistake is reported once. The
+// bounds of the schemas without Validate method are checked in their JSON Schema.
+func lintContradictions(s Parseable, path []string, report func([]string, LintRule, Severity, string, ...interface{})) []string {
+	if str, ok := s.(*StringSchema); ok && str.regex != nil {
+		values := str.Schema.enum
+		if str.Schema.constVal != nil {
+			values = append([]interface{}{str.Schema.constVal}, values...)
+		}
+		matched := true
+		for _, value := range values {
+			if text, ok := value.(string); ok && !str.regex.MatchString(text) {
+				report(path, LintEnumPattern, SeverityError, "%q does not match the pattern %s", text, *str.pattern)
+				matched = false
+			}
+		}
+		if !matched {
+			without := str.Clone()
+			without.pattern, without.regex = nil, nil
+			s = without
+		}
+	}
+
+	v, ok := s.(validator)
+	if !ok {
+		return jsonContradictions(diffJSON(s))
+	}
+	err := v.Validate()
+	if err == nil {
+		return nil
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = strings.TrimPrefix(err.Error(), "schema: ")
+	}
+	return messages
+}
+
+// Pairs of JSON Schema keywords bounding the same quantity from below and above
+var boundKeywords = [][2]string{
+	{"minLength", "maxLength"},
+	{"minItems", "maxItems"},
+	{"minProperties", "maxProperties"},
+	{"minContains", "maxContains"},
+	{"minimum", "maximum"},
+}
+
+// jsonContradictions returns the bounds of a JSON Schema node that no value satisfies
+func jsonContradictions(node map[string]interface{}) []string {
+	var messages []string
+	for _, pair := range boundKeywords {
+		min, minOK := toFloat64(node[pair[0]])
+		max, maxOK := toFloat64(node[pair[1]])
+		if minOK && maxOK && min > max {
+			messages = append(messages, fmt.Sprintf("%s %v is greater than %s %v", pair[0], min, pair[1], max))
+		}
+	}
+	return messages
+}
+
+// lintBranches reports the branches accepting only values that an earlier branch
+// accepts: the earlier one matches first, or makes a union reject them as matching
+// several branches
+func lintBranches(branches []Parseable, path []string, report func([]string, LintRule, Severity, string, ...interface{})) {
+	for j, later := range branches {
+		for i, earlier := range branches[:j] {
+			if earlier != nil && later != nil && shadows(earlier, later) {
+				report(path, LintUnreachableBranch, SeverityWarning, "branch %d (%s) is unreachable: branch %d (%s) accepts all its values",
+					j, schemaTypeName(later), i, schemaTypeName(earlier))
+				break
+			}
+		}
+	}
+}
+
+// shadows reports whether a accepts every value b accepts, as far as their JSON Schema
+// tells: a has no refinement or transform, and either generates the same JSON Schema
+// as b or only constrains the type, to types covering those of b
+func shadows(a, b Parseable) bool {
+	if base, ok := a.(embedsSchema); !ok || len(base.base().effects) > 0 {
+		return false
+	}
+	aJSON, bJSON := validationKeywords(diffJSON(a)), validationKeywords(diffJSON(b))
+	if reflect.DeepEqual(aJSON, bJSON) {
+		return true
+	}
+	if len(aJSON) == 0 {
+		return true
+	}
+	if len(aJSON) > 1 || aJSON["type"] == nil {
+		return false
+	}
+	return typesCovered(schemaTypes(bJSON["type"]), schemaTypes(aJSON["type"]))
+}
+
+// validationKeywords returns the keywords of a JSON Schema node that affect validation
+func validationKeywords(node map[string]interface{}) map[string]interface{} {
+	keywords := make(map[string]interface{}, len(node))
+	for keyword, value := range node {
+		if !isAnnotationKeyword(keyword) {
+			keywords[keyword] = value
+		}
+	}
+	return keywords
+}
@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// lintKeys returns the path, rule and message of each issue, ignoring the missing
+// titles and descriptions
+func lintKeys(issues []LintIssue) []string {
+	keys := []string{}
+	for _, issue := range issues {
+		if issue.Rule != LintMissingTitle && issue.Rule != LintMissingDescription {
+			keys = append(keys, issue.String())
+		}
+	}
+	return keys
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema Parseable
+		want   []string
+	}{
+		{
+			name:   "consistent schema",
+			schema: Object().Property("name", String().MinLength(1).MaxLength(20).Default("anonymous")),
+			want:   []string{},
+		},
+		{
+			name:   "impossible length",
+			schema: Object().Property("name", String().MinLength(10).MaxLength(5)),
+			want:   []string{"name: error contradiction: minLength 10 is greater than maxLength 5"},
+		},
+		{
+			name:   "impossible array bounds",
+			schema: Array(String()).MinItems(3).MaxItems(1),
+			want:   []string{"(root): error contradiction: minItems 3 is greater than maxItems 1"},
+		},
+		{
+			name:   "enum outside the pattern",
+			schema: String().Pattern(`^[a-z]+$`).MinLength(3).Enum([]string{"abc", "ABC", "ab"}),
+			want: []string{
+				`(root): error enum_pattern: "ABC" does not match the pattern ^[a-z]+$`,
+				"(root): error contradiction: enum value ab fails the other constraints: value must be at least 3 characters long",
+			},
+		},
+		{
+			name:   "invalid default",
+			schema: Object().Property("age", Int().Min(18).Default(12)),
+			want:   []string{"age: error invalid_default: default 12 fails the schema: value must be at least 18"},
+		},
+		{
+			name:   "dynamic default is not checked",
+			schema: Int().Min(18).DefaultFunc(func() interface{} { return 12 }),
+			want:   []string{},
+		},
+		{
+			name:   "branch shadowed by a plain type",
+			schema: Union(String(), String().Email(), Int()),
+			want:   []string{"(root): warning unreachable_branch: branch 1 (String) is unreachable: branch 0 (String) accepts all its values"},
+		},
+		{
+			name:   "duplicate anyOf branch",
+			schema: AnyOf(Int().Min(1), Number(), Int().Min(1)),
+			want: []string{
+				"(root): warning unreachable_branch: branch 2 (Int) is unreachable: branch 0 (Int) accepts all its values",
+			},
+		},
+		{
+			name:   "number shadows int",
+			schema: Union(Number(), Int().Max(3)),
+			want:   []string{"(root): warning unreachable_branch: branch 1 (Int) is unreachable: branch 0 (Number) accepts all its values"},
+		},
+		{
+			name:   "refined branch shadows nothing",
+			schema: Union(String().Refine(func(v interface{}) bool { return v != "" }), String().Email()),
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lintKeys(Lint(tt.schema)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLint_DefaultsSkipEffects(t *testing.T) {
+	calls := 0
+	count := func(interface{}) bool { calls++; return false }
+	lookup := func(context.Context, interface{}) error { calls++; return errors.New("unknown") }
+	s := Object().
+		Property("plan", String().Default("free").RefineCtx(lookup)).
+		Property("seats", Int().Default(3).Refine(count)).
+		Property("slug", Transform(String(), String().MinLength(10), func(v interface{}) (interface{}, error) {
+			calls++
+			return v, nil
+		}).Default("abc")).
+		Property("team", Object().Property("name", String().Refine(count)).Default(map[string]interface{}{"name": "core"}))
+
+	if got := lintKeys(Lint(s)); len(got) != 0 {
+		t.Errorf("Lint() = %q, want no issue", got)
+	}
+	if calls != 0 {
+		t.Errorf("Lint() ran %d refinements or transforms, want 0", calls)
+	}
+
+	// The constraints of the input of a transform still apply
+	s = Object().Property("slug", Transform(String().MaxLength(2), String(), func(v interface{}) (interface{}, error) { return v, nil }).Default("abc"))
+	if got := lintKeys(Lint(s)); len(got) != 1 {
+		t.Errorf("Lint() = %q, want the invalid default", got)
+	}
+}
+
+func TestLintDocumentation(t *testing.T) {
+	user := Object().
+		Property("name", String().Description("The display name")).
+		Property("age", Int())
+
+	var got []string
+	for _, issue := range Lint(user) {
+		if issue.Severity != SeverityInfo {
+			t.Errorf("%s: severity = %s, want info", issue, issue.Severity)
+		}
+		got = append(got, issue.String())
+	}
+	want := []string{
+		"(root): info missing_title: the schema has no title",
+		"(root): info missing_description: the schema has no description",
+		"age: info missing_description: property age has no description",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() =\n%q\nwant\n%q", got, want)
+	}
+
+	documented := Object().Title("User").Description("A user").
+		Property("name", String().Description("The display name"))
+	if issues := Lint(documented); len(issues) != 0 {
+		t.Errorf("Lint() = %v, want no issue", issues)
+	}
+}
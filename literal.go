@@ -0,0 +1,320 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for literal validation
+var (
+	literalRequiredError = i18n.S("value is required")
+)
+
+func literalValueError(value interface{}) i18n.TranslatedFunc {
+	return i18n.F("value must be exactly: %v", value)
+}
+
+// LiteralSchema accepts exactly one primitive value (a string, number or boolean).
+// It is intended for discriminator fields and sentinel values:
+//
+//	schema.Object().
+//	    Property("type", schema.Literal("circle")).
+//	    Property("radius", schema.Number())
+type LiteralSchema struct {
+	Schema
+	value    interface{}
+	nullable bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError ErrorMessage
+	literalError  ErrorMessage
+}
+
+// Literal creates a schema that accepts only value. Numbers match regardless of their
+// Go type (1 matches 1.0), and the parsed value is always the literal itself.
+func Literal(value interface{}, errorMessage ...interface{}) *LiteralSchema {
+	schema := &LiteralSchema{
+		Schema: Schema{
+			schemaType: literalJSONType(value),
+			required:   true, // Default to required
+			constVal:   value,
+		},
+		value: value,
+	}
+	if len(errorMessage) > 0 {
+		schema.literalError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *LiteralSchema) Clone() *LiteralSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *LiteralSchema) Freeze() *LiteralSchema {
+	s.freeze()
+	return s
+}
+
+// NativeEnum creates an enum schema from a map of names to primitive values, as
+// produced by enums defined in other languages or configuration. The names are
+// emitted as x-enum-varnames and the values are ordered by name.
+//
+//	schema.NativeEnum(map[string]interface{}{"Red": 1, "Green": 2, "Blue": 3})
+func NativeEnum(values map[string]interface{}) *EnumSchema[interface{}] {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	enum := Enum[interface{}]()
+	jsonType := ""
+	for i, name := range names {
+		value := values[name]
+		enum.Values(value).Label(value, name)
+
+		// Declare a type only when all values share it
+		if t := literalJSONType(value); i == 0 || t == jsonType {
+			jsonType = t
+		} else {
+			jsonType = ""
+		}
+	}
+	enum.Schema.schemaType = jsonType
+	return enum
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *LiteralSchema) Title(title string) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *LiteralSchema) Description(description string) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *LiteralSchema) ReadOnly() *LiteralSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *LiteralSchema) WriteOnly() *LiteralSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *LiteralSchema) Sensitive() *LiteralSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *LiteralSchema) Deprecated(reason string) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *LiteralSchema) Meta(key string, value interface{}) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *LiteralSchema) AsWarning(codes ...ErrorCode) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *LiteralSchema) AsInfo(codes ...ErrorCode) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Required/Optional/Nullable control
+
+// Optional marks the schema as optional
+func (s *LiteralSchema) Optional() *LiteralSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *LiteralSchema) Required(errorMessage ...interface{}) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *LiteralSchema) Nullable() *LiteralSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// Error customization
+
+// LiteralError sets a custom error message for values other than the literal
+func (s *LiteralSchema) LiteralError(message string) *LiteralSchema {
+	s.checkMutable()
+	s.literalError = toErrorMessage(message)
+	return s
+}
+
+// Getters for accessing private fields
+
+// IsRequired returns whether the schema is marked as required
+func (s *LiteralSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *LiteralSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *LiteralSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetValue returns the literal value
+func (s *LiteralSchema) GetValue() interface{} {
+	return s.value
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *LiteralSchema) Transform(fn TransformFunc) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *LiteralSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *LiteralSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *LiteralSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Validation
+
+// Parse validates that the value equals the literal, returning the literal
+func (s *LiteralSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the literal constraint; Parse runs the refine/transform pipeline on top
+func (s *LiteralSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	if value == nil {
+		if s.nullable || !s.Schema.required {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		message := literalRequiredError(ctx.Locale)
+		if !isEmptyErrorMessage(s.requiredError) {
+			message = resolveErrorMessage(s.requiredError, ctx)
+		}
+		return ParseResult{
+			Valid:  false,
+			Value:  nil,
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
+		}
+	}
+
+	if !jsonValuesEqual(enumPrimitive(value), enumPrimitive(s.value)) {
+		message := literalValueError(s.value)(ctx.Locale)
+		if !isEmptyErrorMessage(s.literalError) {
+			message = resolveErrorMessage(s.literalError, ctx)
+		}
+		return ParseResult{
+			Valid:  false,
+			Value:  nil,
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeConst)},
+		}
+	}
+
+	return ParseResult{Valid: true, Value: s.value, Errors: nil}
+}
+
+// JSON generates JSON Schema representation
+func (s *LiteralSchema) JSON() map[string]interface{} {
+	schema := map[string]interface{}{
+		"const": enumPrimitive(s.value),
+	}
+	if s.Schema.schemaType != "" {
+		schema["type"] = s.Schema.schemaType
+	}
+
+	// Add base schema fields
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+
+	// A nullable literal is an enum of the value and null
+	if s.nullable {
+		delete(schema, "const")
+		schema["enum"] = []interface{}{enumPrimitive(s.value), nil}
+		if s.Schema.schemaType != "" {
+			schema["type"] = []string{s.Schema.schemaType, "null"}
+		}
+	}
+
+	return schema
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize LiteralSchema for JSON schema generation
+func (s *LiteralSchema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.JSON())
+}
+
+// literalJSONType returns the JSON Schema type of a primitive Go value
+func literalJSONType(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	return enumJSONType(reflect.TypeOf(value))
+}
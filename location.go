@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// Location is a position in a source document
+type Location struct {
+	Source string `json:"source,omitempty"` // Document name (ValidationContext.Source)
+	Offset int    `json:"offset"`           // Byte offset from the start of the document
+	Line   int    `json:"line"`             // 1-based line number
+	Column int    `json:"column"`           // 1-based column, in bytes
+}
+
+// String formats the location as "source:line:column", or "line:column" without a source
+func (l Location) String() string {
+	position := strconv.Itoa(l.Line) + ":" + strconv.Itoa(l.Column)
+	if l.Source == "" {
+		return position
+	}
+	return l.Source + ":" + position
+}
+
+// sourceLocations records where each value of a decoded document starts, keyed by
+// the JSON pointer of its path
+type sourceLocations map[string]Location
+
+// add records the location of the value at path, keeping the first location seen
+func (locs sourceLocations) add(path Path, loc Location) {
+	key := path.JSONPointer()
+	if _, exists := locs[key]; !exists {
+		locs[key] = loc
+	}
+}
+
+// attach sets the location of each error to that of its path, or of the closest
+// ancestor with a known location
+func (locs sourceLocations) attach(result ParseResult, source string) ParseResult {
+	if len(result.Errors) == 0 || len(locs) == 0 {
+		return result
+	}
+	errors := make([]ValidationError, len(result.Errors))
+	for i, err := range result.Errors {
+		for n := len(err.Path); n >= 0; n-- {
+			if loc, ok := locs[err.Path[:n].JSONPointer()]; ok {
+				loc.Source = source
+				err.Location = &loc
+				break
+			}
+		}
+		errors[i] = err
+	}
+	result.Errors = errors
+	return result
+}
+
+// offsetLocation converts a byte offset in data into a location
+func offsetLocation(data []byte, offset int) Location {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	lead := data[:offset]
+	return Location{
+		Offset: offset,
+		Line:   bytes.Count(lead, []byte{'\n'}) + 1,
+		Column: offset - bytes.LastIndexByte(lead, '\n'),
+	}
+}
+
+// documentError creates the error reported when a document cannot be decoded
+func documentError(message string, code ErrorCode, loc *Location, source string) ParseResult {
+	err := NewPrimitiveError(nil, message, code)
+	if loc != nil {
+		loc.Source = source
+		err.Location = loc
+	}
+	return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{err}}
+}
@@ -0,0 +1,585 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for map validation
+var (
+	mapRequiredError = i18n.S("value is required")
+	mapTypeError     = i18n.S("value must be a map")
+	mapKeyError      = i18n.S("map key is invalid")
+	mapValueError    = i18n.S("map value is invalid")
+)
+
+func mapMinPropsError(min int) i18n.TranslatedFunc {
+	return i18n.F("map must contain at least %d entries", min)
+}
+
+func mapMaxPropsError(max int) i18n.TranslatedFunc {
+	return i18n.F("map must contain at most %d entries", max)
+}
+
+func mapConversionError(typeName string) i18n.TranslatedFunc {
+	return i18n.F("value cannot be converted to %s", typeName)
+}
+
+// MapSchema validates a map and produces a typed Go map (map[K]V) as the parsed value.
+// Unlike RecordSchema, keys are not converted to strings: each key is validated by the
+// key schema and the parsed key and value are converted to K and V.
+//
+// Keys of decoded JSON objects are always strings; use a coercing key schema such as
+// Int().Coerce() to accept them for integer-keyed maps.
+type MapSchema[K comparable, V any] struct {
+	Schema
+	// Map-specific validation
+	keySchema   Parseable // Schema for validating keys
+	valueSchema Parseable // Schema for validating values
+	minProps    *int      // Minimum number of entries
+	maxProps    *int      // Maximum number of entries
+	nullable    bool      // Allow null values
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	minPropsError     ErrorMessage
+	maxPropsError     ErrorMessage
+	keyError          ErrorMessage
+	valueError        ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// MapOf creates a map schema whose parsed value is a map[K]V
+func MapOf[K comparable, V any](keySchema, valueSchema Parseable, errorMessage ...interface{}) *MapSchema[K, V] {
+	schema := &MapSchema[K, V]{
+		Schema: Schema{
+			schemaType: "object", // Maps are objects in JSON Schema
+			required:   true,     // Default to required
+		},
+		keySchema:   keySchema,
+		valueSchema: valueSchema,
+	}
+	if len(errorMessage) > 0 {
+		schema.typeMismatchError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *MapSchema[K, V]) Clone() *MapSchema[K, V] {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema and its key and value schemas immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *MapSchema[K, V]) Freeze() *MapSchema[K, V] {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and its key and value schemas
+func (s *MapSchema[K, V]) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.keySchema, s.valueSchema)
+}
+
+// Children returns the key schema and the value schema
+func (s *MapSchema[K, V]) Children() []SchemaChild {
+	var children []SchemaChild
+	if s.keySchema != nil {
+		children = append(children, SchemaChild{Segment: KeySegment, Schema: s.keySchema})
+	}
+	if s.valueSchema != nil {
+		children = append(children, SchemaChild{Segment: ValuesSegment, Schema: s.valueSchema})
+	}
+	return children
+}
+
+// Map creates a map schema whose parsed value is a map[interface{}]interface{} that keeps
+// the parsed keys as they are. Use MapOf for a concretely typed map.
+func Map(keySchema, valueSchema Parseable, errorMessage ...interface{}) *MapSchema[interface{}, interface{}] {
+	return MapOf[interface{}, interface{}](keySchema, valueSchema, errorMessage...)
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *MapSchema[K, V]) Title(title string) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *MapSchema[K, V]) Description(description string) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *MapSchema[K, V]) ReadOnly() *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *MapSchema[K, V]) WriteOnly() *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *MapSchema[K, V]) Sensitive() *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *MapSchema[K, V]) Deprecated(reason string) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *MapSchema[K, V]) Meta(key string, value interface{}) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *MapSchema[K, V]) AsWarning(codes ...ErrorCode) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *MapSchema[K, V]) AsInfo(codes ...ErrorCode) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *MapSchema[K, V]) Default(value map[K]V) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *MapSchema[K, V]) DefaultFunc(fn func() interface{}) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *MapSchema[K, V]) Example(example map[K]V) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Map-specific validation
+
+// Keys sets the schema for map keys
+func (s *MapSchema[K, V]) Keys(keySchema Parseable) *MapSchema[K, V] {
+	s.checkMutable()
+	s.keySchema = keySchema
+	return s
+}
+
+// Values sets the schema for map values
+func (s *MapSchema[K, V]) Values(valueSchema Parseable) *MapSchema[K, V] {
+	s.checkMutable()
+	s.valueSchema = valueSchema
+	return s
+}
+
+// MinProperties sets the minimum number of entries with optional custom error message
+func (s *MapSchema[K, V]) MinProperties(min int, errorMessage ...interface{}) *MapSchema[K, V] {
+	s.checkMutable()
+	s.minProps = &min
+	if len(errorMessage) > 0 {
+		s.minPropsError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// MaxProperties sets the maximum number of entries with optional custom error message
+func (s *MapSchema[K, V]) MaxProperties(max int, errorMessage ...interface{}) *MapSchema[K, V] {
+	s.checkMutable()
+	s.maxProps = &max
+	if len(errorMessage) > 0 {
+		s.maxPropsError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Size sets both min and max entries to the same value
+func (s *MapSchema[K, V]) Size(size int) *MapSchema[K, V] {
+	s.checkMutable()
+	s.minProps = &size
+	s.maxProps = &size
+	return s
+}
+
+// Required/Optional/Nullable control
+
+// Optional marks the schema as optional
+func (s *MapSchema[K, V]) Optional() *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *MapSchema[K, V]) Required(errorMessage ...interface{}) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *MapSchema[K, V]) Nullable() *MapSchema[K, V] {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// Error customization
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *MapSchema[K, V]) TypeError(message string) *MapSchema[K, V] {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// KeyError sets a custom error message for key validation failures
+func (s *MapSchema[K, V]) KeyError(message string) *MapSchema[K, V] {
+	s.checkMutable()
+	s.keyError = toErrorMessage(message)
+	return s
+}
+
+// ValueError sets a custom error message for value validation failures
+func (s *MapSchema[K, V]) ValueError(message string) *MapSchema[K, V] {
+	s.checkMutable()
+	s.valueError = toErrorMessage(message)
+	return s
+}
+
+// Getters for accessing private fields
+
+// IsRequired returns whether the schema is marked as required
+func (s *MapSchema[K, V]) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *MapSchema[K, V]) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *MapSchema[K, V]) IsNullable() bool {
+	return s.nullable
+}
+
+// GetKeySchema returns the schema for map keys
+func (s *MapSchema[K, V]) GetKeySchema() Parseable {
+	return s.keySchema
+}
+
+// GetValueSchema returns the schema for map values
+func (s *MapSchema[K, V]) GetValueSchema() Parseable {
+	return s.valueSchema
+}
+
+// GetMinProperties returns the minimum number of entries
+func (s *MapSchema[K, V]) GetMinProperties() *int {
+	return s.minProps
+}
+
+// GetMaxProperties returns the maximum number of entries
+func (s *MapSchema[K, V]) GetMaxProperties() *int {
+	return s.maxProps
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *MapSchema[K, V]) Transform(fn TransformFunc) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *MapSchema[K, V]) Refine(fn RefineFunc, errorMessage ...interface{}) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *MapSchema[K, V]) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *MapSchema[K, V] {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Validation
+
+// Parse validates and parses a map value, returning a map[K]V as the final parsed value
+func (s *MapSchema[K, V]) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the map constraints; Parse runs the refine/transform pipeline on top
+func (s *MapSchema[K, V]) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	var errors []ValidationError
+
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := mapRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{
+				Valid:  false,
+				Value:  nil,
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
+			}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map {
+		message := mapTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{
+			Valid:  false,
+			Value:  nil,
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
+		}
+	}
+
+	// Reject oversized and too deeply nested input before looking at its entries
+	size := v.Len()
+	if err, exceeded := ctx.checkCollectionSize(value, size); exceeded {
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{err}}
+	}
+	child, ok := ctx.descend(value)
+	if !ok {
+		return ctx.depthExceeded(value)
+	}
+	defer child.release()
+
+	// Validate size constraints
+	if s.minProps != nil && size < *s.minProps {
+		message := mapMinPropsError(*s.minProps)(ctx.Locale)
+		if !isEmptyErrorMessage(s.minPropsError) {
+			message = resolveErrorMessage(s.minPropsError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeMinProperties))
+	}
+
+	if s.maxProps != nil && size > *s.maxProps {
+		message := mapMaxPropsError(*s.maxProps)(ctx.Locale)
+		if !isEmptyErrorMessage(s.maxPropsError) {
+			message = resolveErrorMessage(s.maxPropsError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeMaxProperties))
+	}
+
+	keyType := reflect.TypeOf((*K)(nil)).Elem()
+	valueType := reflect.TypeOf((*V)(nil)).Elem()
+	finalValue := make(map[K]V, size)
+	var warnings []ValidationError // Warnings of the values
+
+	iter := v.MapRange()
+	for iter.Next() {
+		if ctx.stopCollecting(len(errors)) {
+			break
+		}
+		key := iter.Key().Interface()
+		val := iter.Value().Interface()
+		path := Path{FieldSegment(fmt.Sprintf("%v", key))}
+		if err, exceeded := ctx.checkStringLength(key, path...); exceeded {
+			errors = append(errors, err)
+			continue
+		}
+		if err, exceeded := ctx.checkStringLength(val, path...); exceeded {
+			errors = append(errors, err)
+			continue
+		}
+
+		// Validate key using key schema
+		parsedKey := key
+		if s.keySchema != nil {
+			keyResult := s.keySchema.Parse(key, ctx)
+			if !keyResult.Valid {
+				message := mapKeyError(ctx.Locale)
+				if !isEmptyErrorMessage(s.keyError) {
+					message = resolveErrorMessage(s.keyError, ctx)
+				}
+				errors = append(errors, NewFieldError(path, key, message, CodeKeyInvalid))
+				for _, keyErr := range keyResult.Errors {
+					errors = append(errors, nestedError(path, keyErr))
+				}
+				continue
+			}
+			parsedKey = keyResult.Value
+		}
+
+		// Validate value using value schema
+		parsedVal := val
+		if s.valueSchema != nil {
+			valueResult := s.valueSchema.Parse(val, child.at(path[0]))
+			warnings = nestedWarnings(warnings, path[0], valueResult)
+			if !valueResult.Valid {
+				message := mapValueError(ctx.Locale)
+				if !isEmptyErrorMessage(s.valueError) {
+					message = resolveErrorMessage(s.valueError, ctx)
+				}
+				errors = append(errors, NewFieldError(path, val, message, CodeValueInvalid))
+				for _, valErr := range valueResult.Errors {
+					errors = append(errors, nestedError(append(path, valErr.Path...), valErr))
+				}
+				continue
+			}
+			parsedVal = valueResult.Value
+		}
+
+		// Convert the parsed entry to the map's key and value types
+		typedKey := reflect.New(keyType).Elem()
+		if parsedKey == nil || bindValue(typedKey, parsedKey, nil) != nil {
+			message := mapConversionError(keyType.String())(ctx.Locale)
+			errors = append(errors, NewFieldError(path, key, message, CodeKeyInvalid))
+			continue
+		}
+		typedVal := reflect.New(valueType).Elem()
+		if err := bindValue(typedVal, parsedVal, nil); err != nil {
+			message := mapConversionError(valueType.String())(ctx.Locale)
+			errors = append(errors, NewFieldError(path, val, message, CodeInvalidType))
+			continue
+		}
+		finalValue[typedKey.Interface().(K)] = typedVal.Interface().(V)
+	}
+
+	return ParseResult{
+		Valid:    len(errors) == 0,
+		Value:    finalValue,
+		Errors:   errors,
+		Warnings: warnings,
+	}
+}
+
+// JSON generates JSON Schema representation
+func (s *MapSchema[K, V]) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *MapSchema[K, V]) generateJSON(g *jsonGeneration) map[string]interface{} {
+	schema := baseJSONSchema("object")
+
+	// Add base schema fields
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+
+	// Values map to additionalProperties, as with records
+	schema["additionalProperties"] = true
+	if valueJSON, ok := childJSON(s.valueSchema, g); ok {
+		schema["additionalProperties"] = valueJSON
+	}
+
+	// JSON object keys are strings: string key schemas become propertyNames, and
+	// integer keys are described by their decimal form
+	switch kind := reflect.TypeOf((*K)(nil)).Elem().Kind(); {
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		schema["propertyNames"] = map[string]interface{}{"type": "string", "pattern": "^-?[0-9]+$"}
+	case kind >= reflect.Uint && kind <= reflect.Uint64:
+		schema["propertyNames"] = map[string]interface{}{"type": "string", "pattern": "^[0-9]+$"}
+	default:
+		if keyJSON, ok := childJSON(s.keySchema, g); ok && keyJSON["type"] == "string" {
+			schema["propertyNames"] = keyJSON
+		}
+	}
+
+	// Add entry count constraints
+	if s.minProps != nil {
+		schema["minProperties"] = *s.minProps
+	}
+
+	if s.maxProps != nil {
+		schema["maxProperties"] = *s.maxProps
+	}
+
+	// Add nullable if true
+	if s.nullable {
+		schema["type"] = []string{"object", "null"}
+	}
+
+	return schema
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize MapSchema for JSON schema generation
+func (s *MapSchema[K, V]) MarshalJSON() ([]byte, error) {
+	type jsonMapSchema struct {
+		Schema
+		KeySchema   Parseable `json:"keySchema,omitempty"`
+		ValueSchema Parseable `json:"valueSchema,omitempty"`
+		MinProps    *int      `json:"minProps,omitempty"`
+		MaxProps    *int      `json:"maxProps,omitempty"`
+		Nullable    bool      `json:"nullable,omitempty"`
+	}
+
+	return json.Marshal(jsonMapSchema{
+		Schema:      s.Schema,
+		KeySchema:   s.keySchema,
+		ValueSchema: s.valueSchema,
+		MinProps:    s.minProps,
+		MaxProps:    s.maxProps,
+		Nullable:    s.nullable,
+	})
+}
@@ -0,0 +1,121 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMapSchema_Parse(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   Parseable
+		value    interface{}
+		expected bool
+		want     interface{}
+	}{
+		{
+			name:     "string keys to int values",
+			schema:   MapOf[string, int](String().MinLength(1), Int().Min(0)),
+			value:    map[string]interface{}{"a": 1, "b": 2.0},
+			expected: true,
+			want:     map[string]int{"a": 1, "b": 2},
+		},
+		{
+			name:     "int keyed input",
+			schema:   MapOf[int, string](Int().Min(1), String()),
+			value:    map[int]string{1: "one", 2: "two"},
+			expected: true,
+			want:     map[int]string{1: "one", 2: "two"},
+		},
+		{
+			name:     "json object keys coerced to int",
+			schema:   MapOf[int, bool](Int().Coerce(), Bool()),
+			value:    map[string]interface{}{"7": true},
+			expected: true,
+			want:     map[int]bool{7: true},
+		},
+		{
+			name:     "string keys rejected by int key schema",
+			schema:   MapOf[int, bool](Int(), Bool()),
+			value:    map[string]interface{}{"7": true},
+			expected: false,
+		},
+		{
+			name:     "invalid key",
+			schema:   MapOf[int, string](Int().Min(1), String()),
+			value:    map[int]string{0: "zero"},
+			expected: false,
+		},
+		{
+			name:     "invalid value",
+			schema:   MapOf[string, int](String(), Int()),
+			value:    map[string]interface{}{"a": "x"},
+			expected: false,
+		},
+		{
+			name:     "fractional value for int map",
+			schema:   MapOf[string, int](String(), Number()),
+			value:    map[string]interface{}{"a": 1.5},
+			expected: false,
+		},
+		{
+			name:     "untyped map keeps keys",
+			schema:   Map(Int(), String()),
+			value:    map[int]string{3: "c"},
+			expected: true,
+			want:     map[interface{}]interface{}{3: "c"},
+		},
+		{
+			name:     "too few entries",
+			schema:   MapOf[string, int](String(), Int()).MinProperties(2),
+			value:    map[string]int{"a": 1},
+			expected: false,
+		},
+		{
+			name:     "not a map",
+			schema:   MapOf[string, int](String(), Int()),
+			value:    []int{1},
+			expected: false,
+		},
+		{
+			name:     "nullable nil",
+			schema:   MapOf[string, int](String(), Int()).Nullable(),
+			value:    nil,
+			expected: true,
+		},
+		{
+			name:     "required nil",
+			schema:   MapOf[string, int](String(), Int()),
+			value:    nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v. Errors: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.want != nil && !reflect.DeepEqual(result.Value, tt.want) {
+				t.Errorf("Parse(%v) value = %#v, want %#v", tt.value, result.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapSchema_JSON(t *testing.T) {
+	json := MapOf[int, string](Int(), String()).JSON()
+	if json["type"] != "object" {
+		t.Errorf("type = %v, want object", json["type"])
+	}
+	names, ok := json["propertyNames"].(map[string]interface{})
+	if !ok || names["pattern"] != "^-?[0-9]+$" {
+		t.Errorf("propertyNames = %v, want integer pattern", json["propertyNames"])
+	}
+	if values, ok := json["additionalProperties"].(map[string]interface{}); !ok || values["type"] != "string" {
+		t.Errorf("additionalProperties = %v, want string schema", json["additionalProperties"])
+	}
+}
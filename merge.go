@@ -0,0 +1,355 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// clone returns an unfrozen copy of the object schema that can be modified without
+// affecting s. Property schemas themselves are shared.
+func (s *ObjectSchema) clone() *ObjectSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.properties = make(map[string]ObjectProperty, len(s.properties))
+	for name, prop := range s.properties {
+		c.properties[name] = prop
+	}
+	c.requiredProps = append([]string{}, s.requiredProps...)
+	c.dependentRequired = nil
+	for name, required := range s.dependentRequired {
+		c.addDependentRequired(name, required)
+	}
+	c.conditions = append([]objectCondition(nil), s.conditions...)
+	c.patternProps = append([]objectPatternProperty(nil), s.patternProps...)
+	c.aliases = nil
+	for alias, name := range s.aliases {
+		c.Alias(alias, name)
+	}
+	if s.deletable != nil {
+		c.deletable = make(map[string]bool, len(s.deletable))
+		for name := range s.deletable {
+			c.deletable[name] = true
+		}
+	}
+	return &c
+}
+
+// Intersection combines two object schemas into one whose values must satisfy both.
+// Conflicts are resolved deterministically:
+//   - properties are combined; a property defined in both must satisfy both definitions
+//     (nested objects are intersected, other schemas are combined with AllOf)
+//   - a property is required if either schema requires it
+//   - additional properties are allowed only if both schemas allow them, and stripped
+//     if both allow or strip them and at least one strips them
+//   - the stricter of each min/max property count is kept
+//   - the result is nullable only if both are, and optional only if both are
+//   - title, description and custom error messages come from a, falling back to b
+//   - refinements, transforms and cross-field rules of a run before those of b
+//   - aliases are combined (a's alias wins) and the key transform of a is kept if set
+//   - pattern properties are combined and property names must satisfy both schemas
+func Intersection(a, b *ObjectSchema) *ObjectSchema {
+	result := a.clone()
+
+	for name, prop := range b.properties {
+		existing, ok := result.properties[name]
+		if !ok {
+			result.properties[name] = prop
+			continue
+		}
+		existing.Required = existing.Required || prop.Required
+		existing.Schema = intersectProperty(existing.Schema, prop.Schema)
+		result.properties[name] = existing
+	}
+	result.requiredProps = mergeRequired(a.requiredProps, b.requiredProps)
+
+	result.additionalProps = a.additionalProps && b.additionalProps
+	result.stripUnknown = !result.additionalProps &&
+		(a.additionalProps || a.stripUnknown) && (b.additionalProps || b.stripUnknown)
+	if result.onUnknownKey == nil {
+		result.onUnknownKey = b.onUnknownKey
+	}
+	result.minProps = maxIntPtr(a.minProps, b.minProps)
+	result.maxProps = minIntPtr(a.maxProps, b.maxProps)
+	result.nullable = a.nullable && b.nullable
+	result.Schema.required = a.Schema.required || b.Schema.required
+
+	for name, required := range b.dependentRequired {
+		result.addDependentRequired(name, required)
+	}
+	result.conditions = append(result.conditions, b.conditions...)
+	result.patternProps = append(result.patternProps, b.patternProps...)
+	switch {
+	case result.propertyNames == nil:
+		result.propertyNames = b.propertyNames
+	case b.propertyNames != nil && b.propertyNames != result.propertyNames:
+		result.propertyNames = AllOf(result.propertyNames, b.propertyNames)
+	}
+	for alias, name := range b.aliases {
+		if _, exists := result.aliases[alias]; !exists {
+			result.Alias(alias, name)
+		}
+	}
+	if result.keyTransform == nil {
+		result.keyTransform = b.keyTransform
+	}
+
+	fillObjectMetadata(result, b)
+	result.Schema.effects = append(result.Schema.effects, b.Schema.effects...)
+	return result
+}
+
+// Merge combines object schemas left to right, with later schemas overriding earlier ones:
+//   - a property defined in several schemas takes the definition (and required flag)
+//     of the last schema that defines it
+//   - additional properties (and stripping), nullable, optional and min/max property counts follow
+//     the last schema (min/max only when the later schema sets them)
+//   - title, description and custom error messages are overridden when set
+//   - refinements, transforms and cross-field rules of all schemas run in order
+//   - aliases and pattern properties are combined, and a later key transform or
+//     property names schema replaces an earlier one
+//
+// Merge with no arguments returns an empty object schema.
+func Merge(objects ...*ObjectSchema) *ObjectSchema {
+	if len(objects) == 0 {
+		return Object()
+	}
+
+	result := objects[0].clone()
+	for _, next := range objects[1:] {
+		for name, prop := range next.properties {
+			result.properties[name] = prop
+		}
+		required := make([]string, 0, len(result.requiredProps)+len(next.requiredProps))
+		for _, name := range result.requiredProps {
+			if _, overridden := next.properties[name]; !overridden {
+				required = append(required, name)
+			}
+		}
+		result.requiredProps = mergeRequired(required, next.requiredProps)
+
+		result.additionalProps = next.additionalProps
+		result.stripUnknown = next.stripUnknown
+		if next.onUnknownKey != nil {
+			result.onUnknownKey = next.onUnknownKey
+		}
+		result.nullable = next.nullable
+		result.Schema.required = next.Schema.required
+		if next.minProps != nil {
+			result.minProps = next.minProps
+		}
+		if next.maxProps != nil {
+			result.maxProps = next.maxProps
+		}
+
+		for name, required := range next.dependentRequired {
+			result.addDependentRequired(name, required)
+		}
+		result.conditions = append(result.conditions, next.conditions...)
+		result.patternProps = append(result.patternProps, next.patternProps...)
+		if next.propertyNames != nil {
+			result.propertyNames = next.propertyNames
+		}
+		for alias, name := range next.aliases {
+			result.Alias(alias, name)
+		}
+		if next.keyTransform != nil {
+			result.keyTransform = next.keyTransform
+		}
+
+		overrideObjectMetadata(result, next)
+		result.Schema.effects = append(result.Schema.effects, next.Schema.effects...)
+	}
+	return result
+}
+
+// ExtendConflict selects how Extend resolves a setting defined differently by the base
+// and the extension
+type ExtendConflict int
+
+const (
+	// ExtendConflictError panics when the schemas disagree, so an extension cannot silently
+	// redefine a base property
+	ExtendConflictError ExtendConflict = iota
+	// ExtendConflictPreferBase keeps the definitions of the base
+	ExtendConflictPreferBase
+	// ExtendConflictPreferExtension takes the definitions of the extension
+	ExtendConflictPreferExtension
+)
+
+// ExtendOptions configures Extend
+type ExtendOptions struct {
+	Conflicts ExtendConflict // How conflicting definitions are resolved (ExtendConflictError by default)
+}
+
+// Extend returns a new object schema with the properties, required properties and
+// constraints of s and other, for hierarchies such as a base event and its specific
+// events. A conflict is a property both schemas define with a different schema or
+// required flag, an alias mapped to different properties, or min/max property counts
+// both schemas set to different values; opts.Conflicts decides which side wins, or
+// makes Extend panic listing the conflicts.
+//
+// Other settings are combined as by Merge, with the preferred side overriding:
+// the extension for ExtendConflictError and ExtendConflictPreferExtension, the base for
+// ExtendConflictPreferBase. Refinements, transforms and cross-field rules of s always run
+// before those of other. Neither input is modified.
+func (s *ObjectSchema) Extend(other *ObjectSchema, opts ExtendOptions) *ObjectSchema {
+	var result *ObjectSchema
+	switch opts.Conflicts {
+	case ExtendConflictPreferBase:
+		// Merge with the base last, then restore the order of the base first
+		result = Merge(other, s)
+		result.conditions = append(slices.Clone(s.conditions), other.conditions...)
+		result.patternProps = append(slices.Clone(s.patternProps), other.patternProps...)
+		result.Schema.examples = append(slices.Clone(s.Schema.examples), other.Schema.examples...)
+		result.Schema.effects = append(slices.Clone(s.Schema.effects), other.Schema.effects...)
+	case ExtendConflictPreferExtension:
+		result = Merge(s, other)
+	default:
+		if conflicts := extendConflicts(s, other); len(conflicts) > 0 {
+			panic(fmt.Sprintf("schema: Extend conflicts on %s", strings.Join(conflicts, ", ")))
+		}
+		result = Merge(s, other)
+	}
+
+	// List the required properties of the base first, in their order
+	required := make([]string, 0, len(result.requiredProps))
+	for _, name := range mergeRequired(s.requiredProps, other.requiredProps) {
+		if slices.Contains(result.requiredProps, name) {
+			required = append(required, name)
+		}
+	}
+	result.requiredProps = required
+	return result
+}
+
+// extendConflicts lists the settings base and extension define differently
+func extendConflicts(base, extension *ObjectSchema) []string {
+	var conflicts []string
+	for name, prop := range extension.properties {
+		if existing, ok := base.properties[name]; ok && (existing.Schema != prop.Schema || existing.Required != prop.Required) {
+			conflicts = append(conflicts, "property "+name)
+		}
+	}
+	for alias, name := range extension.aliases {
+		if existing, ok := base.aliases[alias]; ok && existing != name {
+			conflicts = append(conflicts, "alias "+alias)
+		}
+	}
+	sort.Strings(conflicts)
+	if base.minProps != nil && extension.minProps != nil && *base.minProps != *extension.minProps {
+		conflicts = append(conflicts, "minProperties")
+	}
+	if base.maxProps != nil && extension.maxProps != nil && *base.maxProps != *extension.maxProps {
+		conflicts = append(conflicts, "maxProperties")
+	}
+	return conflicts
+}
+
+// intersectProperty combines two definitions of the same property
+func intersectProperty(a, b Parseable) Parseable {
+	if a == b {
+		return a
+	}
+	objA, okA := a.(*ObjectSchema)
+	objB, okB := b.(*ObjectSchema)
+	if okA && okB {
+		return Intersection(objA, objB)
+	}
+	return AllOf(a, b)
+}
+
+// mergeRequired appends the names in b that are not already in a, keeping a's order
+func mergeRequired(a, b []string) []string {
+	result := append([]string{}, a...)
+	for _, name := range b {
+		found := false
+		for _, existing := range result {
+			if existing == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// fillObjectMetadata copies metadata and error messages from src where dst has none
+func fillObjectMetadata(dst, src *ObjectSchema) {
+	if dst.Schema.title == "" {
+		dst.Schema.title = src.Schema.title
+	}
+	if dst.Schema.description == "" {
+		dst.Schema.description = src.Schema.description
+	}
+	if dst.Schema.defaultValue == nil && dst.Schema.defaultFunc == nil {
+		dst.Schema.defaultValue = src.Schema.defaultValue
+		dst.Schema.defaultFunc = src.Schema.defaultFunc
+	}
+	dst.Schema.examples = append(dst.Schema.examples, src.Schema.examples...)
+	fillErrorMessage(&dst.requiredError, src.requiredError)
+	fillErrorMessage(&dst.minPropsError, src.minPropsError)
+	fillErrorMessage(&dst.maxPropsError, src.maxPropsError)
+	fillErrorMessage(&dst.additionalPropsError, src.additionalPropsError)
+	fillErrorMessage(&dst.propertyError, src.propertyError)
+	fillErrorMessage(&dst.typeMismatchError, src.typeMismatchError)
+	fillErrorMessage(&dst.dependentRequiredError, src.dependentRequiredError)
+	fillErrorMessage(&dst.propertyNamesError, src.propertyNamesError)
+}
+
+// overrideObjectMetadata copies the metadata and error messages that src sets onto dst
+func overrideObjectMetadata(dst, src *ObjectSchema) {
+	if src.Schema.title != "" {
+		dst.Schema.title = src.Schema.title
+	}
+	if src.Schema.description != "" {
+		dst.Schema.description = src.Schema.description
+	}
+	if src.Schema.defaultValue != nil || src.Schema.defaultFunc != nil {
+		dst.Schema.defaultValue = src.Schema.defaultValue
+		dst.Schema.defaultFunc = src.Schema.defaultFunc
+	}
+	dst.Schema.examples = append(dst.Schema.examples, src.Schema.examples...)
+	overrideErrorMessage(&dst.requiredError, src.requiredError)
+	overrideErrorMessage(&dst.minPropsError, src.minPropsError)
+	overrideErrorMessage(&dst.maxPropsError, src.maxPropsError)
+	overrideErrorMessage(&dst.additionalPropsError, src.additionalPropsError)
+	overrideErrorMessage(&dst.propertyError, src.propertyError)
+	overrideErrorMessage(&dst.typeMismatchError, src.typeMismatchError)
+	overrideErrorMessage(&dst.dependentRequiredError, src.dependentRequiredError)
+	overrideErrorMessage(&dst.propertyNamesError, src.propertyNamesError)
+}
+
+// fillErrorMessage sets *dst to src if no message is set yet
+func fillErrorMessage(dst *ErrorMessage, src ErrorMessage) {
+	if isEmptyErrorMessage(*dst) {
+		*dst = src
+	}
+}
+
+// overrideErrorMessage sets *dst to src if src is set
+func overrideErrorMessage(dst *ErrorMessage, src ErrorMessage) {
+	if !isEmptyErrorMessage(src) {
+		*dst = src
+	}
+}
+
+// maxIntPtr returns the larger of two optional limits
+func maxIntPtr(a, b *int) *int {
+	if a == nil || (b != nil && *b > *a) {
+		return b
+	}
+	return a
+}
+
+// minIntPtr returns the smaller of two optional limits
+func minIntPtr(a, b *int) *int {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,258 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntersection(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	named := Object().
+		Property("name", String()).
+		Property("id", Int().Min(1)).
+		MinProperties(1)
+	aged := Object().
+		OptionalProperty("age", Int().Min(0)).
+		Property("id", Int().Max(100)).
+		Passthrough().
+		MaxProperties(3)
+
+	s := Intersection(named, aged)
+
+	if got := s.GetRequiredProperties(); !reflect.DeepEqual(got, []string{"name", "id"}) {
+		t.Errorf("required = %v, want [name id]", got)
+	}
+	if s.AllowsAdditionalProperties() {
+		t.Error("expected additional properties to be disallowed when one side is strict")
+	}
+	if *s.GetMinProperties() != 1 || *s.GetMaxProperties() != 3 {
+		t.Errorf("min/max = %v/%v, want 1/3", *s.GetMinProperties(), *s.GetMaxProperties())
+	}
+
+	tests := []struct {
+		name     string
+		value    map[string]interface{}
+		expected bool
+	}{
+		{"valid", map[string]interface{}{"name": "Ada", "id": 5, "age": 36}, true},
+		{"id violates first", map[string]interface{}{"name": "Ada", "id": 0}, false},
+		{"id violates second", map[string]interface{}{"name": "Ada", "id": 101}, false},
+		{"missing name", map[string]interface{}{"id": 5}, false},
+		{"unknown property", map[string]interface{}{"name": "Ada", "id": 5, "x": 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Errorf("Parse(%v) = %v, want %v. Errors: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+		})
+	}
+
+	// Inputs are not modified
+	if _, ok := named.GetProperties()["age"]; ok {
+		t.Error("Intersection modified its first argument")
+	}
+}
+
+func TestIntersection_NestedObjects(t *testing.T) {
+	a := Object().Property("address", Object().Property("city", String()))
+	b := Object().Property("address", Object().Property("zip", String()))
+
+	address, ok := Intersection(a, b).GetProperties()["address"].Schema.(*ObjectSchema)
+	if !ok {
+		t.Fatal("expected nested objects to be intersected into an ObjectSchema")
+	}
+	if len(address.GetProperties()) != 2 {
+		t.Errorf("nested properties = %v, want city and zip", address.GetProperties())
+	}
+}
+
+func TestMerge(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	base := Object().
+		Property("id", Int()).
+		Property("name", String()).
+		Title("Base")
+	update := Object().
+		OptionalProperty("name", String().MinLength(3)).
+		Property("email", String()).
+		Passthrough()
+
+	s := Merge(base, update)
+
+	if got := s.GetRequiredProperties(); !reflect.DeepEqual(got, []string{"id", "email"}) {
+		t.Errorf("required = %v, want [id email]", got)
+	}
+	if !s.AllowsAdditionalProperties() {
+		t.Error("expected the last schema's additionalProperties setting")
+	}
+	if s.GetTitle() != "Base" {
+		t.Errorf("title = %q, want Base", s.GetTitle())
+	}
+
+	if result := s.Parse(map[string]interface{}{"id": 1, "email": "a@b.c"}, ctx); !result.Valid {
+		t.Errorf("expected valid without overridden optional name, got %v", result.Errors)
+	}
+	if result := s.Parse(map[string]interface{}{"id": 1, "email": "a@b.c", "name": "Al"}, ctx); result.Valid {
+		t.Error("expected the overriding name definition to apply")
+	}
+
+	if len(Merge().GetProperties()) != 0 {
+		t.Error("Merge() should return an empty object")
+	}
+}
+
+func TestObjectSchema_Derivation(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	user := Object().
+		Property("id", Int()).
+		Property("name", String()).
+		Property("email", String()).
+		OptionalProperty("bio", String())
+
+	t.Run("pick", func(t *testing.T) {
+		s := user.Pick("name", "bio", "missing")
+		if len(s.GetProperties()) != 2 || !reflect.DeepEqual(s.GetRequiredProperties(), []string{"name"}) {
+			t.Errorf("Pick() properties = %v, required = %v", s.GetProperties(), s.GetRequiredProperties())
+		}
+		if result := s.Parse(map[string]interface{}{"name": "Ada", "id": 1}, ctx); result.Valid {
+			t.Error("expected omitted property to be rejected as additional")
+		}
+	})
+
+	t.Run("omit", func(t *testing.T) {
+		s := user.Omit("id")
+		if !reflect.DeepEqual(s.GetRequiredProperties(), []string{"name", "email"}) {
+			t.Errorf("Omit() required = %v", s.GetRequiredProperties())
+		}
+		if result := s.Parse(map[string]interface{}{"name": "Ada", "email": "a@b.c"}, ctx); !result.Valid {
+			t.Errorf("expected valid, got %v", result.Errors)
+		}
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		s := user.Partial()
+		if len(s.GetRequiredProperties()) != 0 {
+			t.Errorf("Partial() required = %v", s.GetRequiredProperties())
+		}
+		if result := s.Parse(map[string]interface{}{"email": "a@b.c"}, ctx); !result.Valid {
+			t.Errorf("expected valid, got %v", result.Errors)
+		}
+	})
+
+	t.Run("partial skips defaults", func(t *testing.T) {
+		settings := Object().
+			Property("theme", String().Default("light")).
+			Property("layout", Object().Property("columns", Int().Default(2).Optional()))
+		result := settings.Partial().Parse(map[string]interface{}{"layout": map[string]interface{}{}}, ctx)
+		want := map[string]interface{}{"layout": map[string]interface{}{}}
+		if !result.Valid || !reflect.DeepEqual(result.Value, want) {
+			t.Errorf("Parse() = %v %v, want %v", result.Value, result.Errors, want)
+		}
+	})
+
+	t.Run("required all", func(t *testing.T) {
+		s := user.Partial().RequiredAll()
+		if !reflect.DeepEqual(s.GetRequiredProperties(), []string{"bio", "email", "id", "name"}) {
+			t.Errorf("RequiredAll() required = %v", s.GetRequiredProperties())
+		}
+		if !s.GetProperties()["bio"].Required {
+			t.Error("expected bio to be marked required")
+		}
+	})
+
+	// The original schema is unchanged
+	if !reflect.DeepEqual(user.GetRequiredProperties(), []string{"id", "name", "email"}) || len(user.GetProperties()) != 4 {
+		t.Errorf("derivation modified the original schema: %v", user.GetRequiredProperties())
+	}
+}
+
+func TestObjectSchema_Extend(t *testing.T) {
+	ctx := DefaultValidationContext()
+	id := String().UUID()
+	baseEvent := Object().
+		Property("id", id).
+		Property("type", String()).
+		Property("at", Int()).
+		MinProperties(3).
+		Title("Event")
+	clicked := Object().
+		Property("id", id).
+		Property("type", Literal("click")).
+		Property("x", Int()).
+		Property("y", Int()).
+		Title("Click")
+
+	tests := []struct {
+		name     string
+		policy   ExtendConflict
+		required []string
+		title    string
+		typeOK   bool // Whether a type other than "click" is accepted
+	}{
+		{"prefer base", ExtendConflictPreferBase, []string{"id", "type", "at", "x", "y"}, "Event", true},
+		{"prefer extension", ExtendConflictPreferExtension, []string{"id", "type", "at", "x", "y"}, "Click", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := baseEvent.Extend(clicked, ExtendOptions{Conflicts: tt.policy})
+			if got := s.GetRequiredProperties(); !reflect.DeepEqual(got, tt.required) {
+				t.Errorf("required = %v, want %v", got, tt.required)
+			}
+			if s.GetTitle() != tt.title {
+				t.Errorf("title = %q, want %q", s.GetTitle(), tt.title)
+			}
+			if got := s.GetMinProperties(); got == nil || *got != 3 {
+				t.Errorf("minProperties = %v, want 3", got)
+			}
+			event := map[string]interface{}{"id": "7c8e7f8a-3b7e-4f39-9c1e-8b8f1b7d0a11", "type": "scroll", "at": 1, "x": 2, "y": 3}
+			if result := s.Parse(event, ctx); result.Valid != tt.typeOK {
+				t.Errorf("Parse(type scroll) valid = %v, want %v: %v", result.Valid, tt.typeOK, result.Errors)
+			}
+		})
+	}
+
+	if len(baseEvent.GetProperties()) != 3 || len(clicked.GetProperties()) != 4 {
+		t.Error("Extend modified its inputs")
+	}
+}
+
+func TestObjectSchema_ExtendConflicts(t *testing.T) {
+	shared := Int()
+	base := Object().Property("id", shared).Property("name", String()).Alias("n", "name").MinProperties(1)
+
+	tests := []struct {
+		name      string
+		extension *ObjectSchema
+		conflicts string // Empty when Extend must not panic
+	}{
+		{"disjoint", Object().Property("email", String()), ""},
+		{"same property schema", Object().Property("id", shared), ""},
+		{"redefined property", Object().Property("name", String().MinLength(1)), "property name"},
+		{"property made optional", Object().OptionalProperty("id", shared), "property id"},
+		{"alias to another property", Object().Property("nick", String()).Alias("n", "nick"), "alias n"},
+		{"min properties", Object().MinProperties(2), "minProperties"},
+		{"several", Object().Property("name", Int()).Property("id", Int()).MinProperties(2), "property id, property name, minProperties"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				switch {
+				case tt.conflicts == "" && r != nil:
+					t.Errorf("Extend() panicked: %v", r)
+				case tt.conflicts != "" && r != "schema: Extend conflicts on "+tt.conflicts:
+					t.Errorf("Extend() panic = %v, want conflicts on %s", r, tt.conflicts)
+				}
+			}()
+			base.Extend(tt.extension, ExtendOptions{})
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Messages maps error codes to the messages replacing the built-in messages of the
+// errors with these codes. A key qualified with a schema type, such as
+// "invalid_type.string", only applies to the errors of schemas of that type and takes
+// precedence over the plain code. Messages are templates rendered with MessageData.
+type Messages map[string]string
+
+// MessageBundle holds Messages by locale, e.g.
+//
+//	fr:
+//	  required: "ce champ est obligatoire"
+//	  min_length: "doit contenir au moins {{.Min}} caractères"
+//	  invalid_type.string: "doit être une chaîne"
+type MessageBundle map[string]Messages
+
+var (
+	registeredMessages    = MessageBundle{}
+	registeredMessagesMu  sync.RWMutex
+	hasRegisteredMessages atomic.Bool
+)
+
+// ParseMessageBundle decodes a message bundle from JSON or YAML, checking that every
+// message is a valid template
+func ParseMessageBundle(data []byte) (MessageBundle, error) {
+	var bundle MessageBundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("schema: invalid message bundle: %w", err)
+	}
+	for locale, messages := range bundle {
+		for key, text := range messages {
+			if _, err := template.New("message").Parse(text); err != nil {
+				return nil, fmt.Errorf("schema: invalid message %q for locale %q: %w", key, locale, err)
+			}
+		}
+	}
+	return bundle, nil
+}
+
+// LoadMessageBundle reads a JSON or YAML message bundle file
+func LoadMessageBundle(path string) (MessageBundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	return ParseMessageBundle(data)
+}
+
+// RegisterMessages makes the messages of bundle replace the built-in messages for the
+// locales of bundle, in every validation. Messages registered later override earlier
+// ones with the same locale and key.
+func RegisterMessages(bundle MessageBundle) {
+	registeredMessagesMu.Lock()
+	defer registeredMessagesMu.Unlock()
+	for locale, messages := range bundle {
+		merged := maps.Clone(registeredMessages[locale])
+		if merged == nil {
+			merged = make(Messages, len(messages))
+		}
+		maps.Copy(merged, messages)
+		registeredMessages[locale] = merged
+	}
+	hasRegisteredMessages.Store(len(registeredMessages) > 0)
+}
+
+// ResetMessages removes the registered messages, restoring the built-in messages
+func ResetMessages() {
+	registeredMessagesMu.Lock()
+	defer registeredMessagesMu.Unlock()
+	registeredMessages = MessageBundle{}
+	hasRegisteredMessages.Store(false)
+}
+
+// WithMessages sets messages overriding, for this validation only, the built-in and
+// registered messages
+func (vc *ValidationContext) WithMessages(messages Messages) *ValidationContext {
+	vc.Messages = messages
+	return vc
+}
+
+// lookupMessage returns the message replacing the built-in message of an error with
+// code reported by schema: from the context's messages, then from the messages
+// registered for the context's locale or, failing that, its language ("pt" for "pt-BR")
+func lookupMessage(ctx *ValidationContext, code ErrorCode, schema Parseable) (string, bool) {
+	if len(ctx.Messages) == 0 && !hasRegisteredMessages.Load() {
+		return "", false
+	}
+	var schemaType string
+	if typed, ok := schema.(interface{ GetType() string }); ok {
+		schemaType = typed.GetType()
+	}
+	if text, ok := ctx.Messages.lookup(code, schemaType); ok {
+		return text, true
+	}
+
+	registeredMessagesMu.RLock()
+	defer registeredMessagesMu.RUnlock()
+	if text, ok := registeredMessages[ctx.Locale].lookup(code, schemaType); ok {
+		return text, true
+	}
+	if language, _, found := strings.Cut(strings.ReplaceAll(ctx.Locale, "_", "-"), "-"); found {
+		return registeredMessages[language].lookup(code, schemaType)
+	}
+	return "", false
+}
+
+// lookup returns the message for code, preferring the one qualified with schemaType
+func (m Messages) lookup(code ErrorCode, schemaType string) (string, bool) {
+	if len(m) == 0 {
+		return "", false
+	}
+	if schemaType != "" {
+		if text, ok := m[string(code)+"."+schemaType]; ok {
+			return text, true
+		}
+	}
+	text, ok := m[string(code)]
+	return text, ok
+}
@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMessageBundles(t *testing.T) {
+	bundle, err := ParseMessageBundle([]byte(`
+fr:
+  required: "ce champ est obligatoire"
+  min_length: "{{.Field}} doit contenir au moins {{.Min}} caractères"
+  invalid_type: "type invalide"
+  invalid_type.string: "doit être une chaîne"
+pt:
+  required: "campo obrigatório"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	RegisterMessages(bundle)
+	t.Cleanup(ResetMessages)
+
+	user := Object().
+		Property("name", String().MinLength(3)).
+		Property("age", Int().Min(0, "age must not be negative"))
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		value  interface{}
+		ctx    *ValidationContext
+		want   string
+	}{
+		{"code", String(), nil, &ValidationContext{Locale: "fr"}, "ce champ est obligatoire"},
+		{"template", user, map[string]interface{}{"name": "Al", "age": 1}, &ValidationContext{Locale: "fr"}, "name doit contenir au moins 3 caractères"},
+		{"qualified by type", String(), 1, &ValidationContext{Locale: "fr"}, "doit être une chaîne"},
+		{"plain code for other types", Bool(), "x", &ValidationContext{Locale: "fr"}, "type invalide"},
+		{"language fallback", String(), nil, &ValidationContext{Locale: "pt-BR"}, "campo obrigatório"},
+		{"other locales keep built-in messages", String(), nil, &ValidationContext{Locale: "en"}, "value is required"},
+		{"custom messages win", user, map[string]interface{}{"name": "Ann", "age": -1}, &ValidationContext{Locale: "fr"}, "age must not be negative"},
+		{"context overrides", String(), nil, (&ValidationContext{Locale: "fr"}).WithMessages(Messages{"required": "obligatoire !"}), "obligatoire !"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, tt.ctx)
+			for _, err := range result.Errors {
+				if err.Message == tt.want {
+					return
+				}
+			}
+			t.Errorf("no error with message %q in %v", tt.want, result.Errors)
+		})
+	}
+}
+
+func TestLoadMessageBundle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "messages.json")
+	if err := os.WriteFile(path, []byte(`{"de": {"max_length": "höchstens {{.Max}} Zeichen"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := LoadMessageBundle(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := bundle["de"]["max_length"]; got != "höchstens {{.Max}} Zeichen" {
+		t.Errorf("message = %q", got)
+	}
+
+	if _, err := ParseMessageBundle([]byte(`{"de": {"required": "{{.Missing"}}`)); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+	if _, err := LoadMessageBundle(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,156 @@
+package schema
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode/utf8"
+)
+
+// Custom error messages, and the messages of message bundles, can interpolate the
+// details of the error: a message whose text contains "{{" is a text/template template
+// rendered with MessageData, after translation when it is an i18n message, e.g.
+//
+//	String().MinLength(3, "{{.Field}} must be at least {{.Min}} characters, got {{.Length}}")
+//
+// A message that is not a valid template, or that fails to render, is used as it is.
+
+// MessageData is the data custom error message templates are rendered with
+type MessageData struct {
+	Field  string      // Last segment of Path: a property name or an index such as "[2]"
+	Path   string      // Dotted path to the value ("" for the root value)
+	Value  interface{} // The invalid value
+	Length int         // Characters of a string value, or elements of an array or object value
+	Code   ErrorCode   // Error code
+
+	// Bounds of the schema: minLength, minItems, minProperties, minimum or
+	// exclusiveMinimum for Min, and the corresponding keywords for Max
+	Min interface{}
+	Max interface{}
+
+	// Params holds all JSON Schema keywords of the schema, e.g. {{.Params.pattern}}
+	Params map[string]interface{}
+}
+
+// customMarker prefixes a resolved custom message. The schema reporting the error
+// renders it once the error's data is known, and leaves it out of the message bundles.
+const customMarker = "\x00custom\x00"
+
+var (
+	minKeywords = []string{"minLength", "minItems", "minProperties", "minimum", "exclusiveMinimum"}
+	maxKeywords = []string{"maxLength", "maxItems", "maxProperties", "maximum", "exclusiveMaximum"}
+
+	// messageTemplates caches parsed templates by text; nil marks text that is not a
+	// valid template
+	messageTemplates sync.Map
+)
+
+// markCustom marks a resolved custom message for rendering
+func markCustom(message string) string {
+	return customMarker + message
+}
+
+// renderMessages finishes the messages of the errors reported by schema for the input
+// value that no schema has finished yet: custom messages are rendered, and built-in
+// messages are replaced by the context's or registered messages for their code
+func renderMessages(errors []ValidationError, ctx *ValidationContext, schema Parseable, input interface{}) {
+	var params map[string]interface{}
+	var base Path
+	prepared := false
+	for i := range errors {
+		if errors[i].rendered {
+			continue
+		}
+		errors[i].rendered = true
+		text, custom := strings.CutPrefix(errors[i].Message, customMarker)
+		if !custom {
+			override, ok := lookupMessage(ctx, errors[i].Code, schema)
+			if !ok {
+				continue
+			}
+			text = override
+		}
+		if !strings.Contains(text, "{{") {
+			errors[i].Message = text
+			continue
+		}
+		if !prepared {
+			if generator, ok := schema.(interface{ JSON() map[string]interface{} }); ok {
+				params = generator.JSON()
+			}
+			base = ctx.path()
+			prepared = true
+		}
+		errors[i].Message = renderMessage(text, messageData(errors[i], base, params, input))
+	}
+}
+
+// messageData returns the template data of err. Errors about the input itself get
+// the input as Value; errors about a nested value only have its description.
+func messageData(err ValidationError, base Path, params map[string]interface{}, input interface{}) MessageData {
+	path := append(slices.Clone(base), err.Path...)
+	data := MessageData{
+		Path:   path.DotPath(),
+		Value:  err.Value,
+		Code:   err.Code,
+		Params: params,
+	}
+	if len(path) > 0 {
+		data.Field = path[len(path)-1].String()
+	}
+	if len(err.Path) == 0 {
+		data.Value = input
+		data.Length = valueLength(input)
+	}
+	for _, keyword := range minKeywords {
+		if v, ok := params[keyword]; ok {
+			data.Min = v
+			break
+		}
+	}
+	for _, keyword := range maxKeywords {
+		if v, ok := params[keyword]; ok {
+			data.Max = v
+			break
+		}
+	}
+	return data
+}
+
+// renderMessage executes the template text with data, or returns text unchanged
+// when it is not a valid template
+func renderMessage(text string, data MessageData) string {
+	cached, ok := messageTemplates.Load(text)
+	if !ok {
+		parsed, err := template.New("message").Parse(text)
+		if err != nil {
+			parsed = nil
+		}
+		cached, _ = messageTemplates.LoadOrStore(text, parsed)
+	}
+	tmpl := cached.(*template.Template)
+	if tmpl == nil {
+		return text
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return text
+	}
+	return b.String()
+}
+
+// valueLength returns the characters of a string, or the elements of an array, slice
+// or map; other values have length 0
+func valueLength(value interface{}) int {
+	if str, ok := value.(string); ok {
+		return utf8.RuneCountInString(str)
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return v.Len()
+	}
+	return 0
+}
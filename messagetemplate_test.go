@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/nyxstack/i18n"
+)
+
+func TestMessageTemplates(t *testing.T) {
+	user := Object().
+		Property("name", String().MinLength(3, "{{.Field}} must be at least {{.Min}} characters, got {{.Length}}")).
+		Property("age", Int().Max(130, "{{.Path}} is {{.Value}}, the limit is {{.Max}}")).
+		Property("tags", Array(String().Pattern(`^[a-z]+$`, "{{.Field}} does not match {{.Params.pattern}}")).
+			MaxItems(2, "at most {{.Max}} tags, got {{.Length}}"))
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		value  interface{}
+		want   string
+	}{
+		{"field, bound and length", user, map[string]interface{}{"name": "Al"}, "name must be at least 3 characters, got 2"},
+		{"path and value", user, map[string]interface{}{"name": "Ann", "age": 200}, "age is 200, the limit is 130"},
+		{"index and params", user, map[string]interface{}{"name": "Ann", "tags": []interface{}{"ok", "NO"}}, "[1] does not match ^[a-z]+$"},
+		{"collection length", user, map[string]interface{}{"name": "Ann", "tags": []interface{}{"a", "b", "c"}}, "at most 2 tags, got 3"},
+		{"nested path", Array(user), []interface{}{map[string]interface{}{"name": "Ann", "age": 131}}, "[0].age is 131, the limit is 130"},
+		{"root value", String().MinLength(5, "{{.Value}} is {{.Length}} long, field {{.Field}}"), "abc", "abc is 3 long, field "},
+		{"refine", Int().Refine(func(v interface{}) bool { return v.(int)%2 == 0 }, "{{.Value}} is odd"), 3, "3 is odd"},
+		{"code", String().Pattern(`^\d+$`, "{{.Code}}: {{.Value}}"), "nope", "pattern: nope"},
+		{"i18n template", String().MinLength(3, i18n.S("{{.Field}} is too short")), "ab", " is too short"},
+		{"not a template", String().MinLength(3, "needs {{ at least three"), "ab", "needs {{ at least three"},
+		{"unknown field", String().MinLength(3, "{{.Missing}} is short"), "ab", "{{.Missing}} is short"},
+		{"static message", String().MinLength(3, "too short"), "ab", "too short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, DefaultValidationContext())
+			for _, err := range result.Errors {
+				if err.Message == tt.want {
+					return
+				}
+			}
+			t.Errorf("no error with message %q in %v", tt.want, result.Errors)
+		})
+	}
+}
+
+func TestMessageTemplates_InjectedValues(t *testing.T) {
+	// A rendered message containing template syntax from the input is not rendered again
+	schema := Object().Property("name", String().MaxLength(3, "{{.Value}} is too long"))
+	result := schema.Parse(map[string]interface{}{"name": "{{.Code}}"}, DefaultValidationContext())
+	for _, err := range result.Errors {
+		if err.Code == "max_length" && err.Message != "{{.Code}} is too long" {
+			t.Errorf("message = %q", err.Message)
+		}
+	}
+}
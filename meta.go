@@ -1,4 +1,15 @@
 package schema
 
-type Meta struct {
+import "strings"
+
+// Meta is a bag of free-form schema annotations for generators and linters. Each key is
+// emitted in JSON Schema output as an "x-" extension keyword.
+type Meta map[string]interface{}
+
+// metaKeyword returns the JSON Schema keyword for a metadata key
+func metaKeyword(key string) string {
+	if strings.HasPrefix(key, "x-") {
+		return key
+	}
+	return "x-" + key
 }
@@ -0,0 +1,201 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for migrations
+var (
+	migrationTypeError = i18n.S("value must be an object")
+)
+
+func migrationVersionRequiredError(field string) i18n.TranslatedFunc {
+	return i18n.F("version field %s is required", field)
+}
+
+func migrationUnknownVersionError(version string) i18n.TranslatedFunc {
+	return i18n.F("unknown version %s", version)
+}
+
+func migrationLoopError(from, latest string) i18n.TranslatedFunc {
+	return i18n.F("the migrations from version %s do not lead to version %s", from, latest)
+}
+
+func migrationFailedError(from, to string) i18n.TranslatedFunc {
+	return i18n.F("migration from version %s to %s returned no document", from, to)
+}
+
+// Migration upgrades documents of version From to version To. Transform receives a
+// copy of the document, which it may modify and return; the version field of the
+// result is set to To.
+type Migration struct {
+	From      string
+	To        string
+	Transform func(map[string]interface{}) map[string]interface{}
+}
+
+// Migrations validates documents written by several generations of clients against
+// the schema of the latest version. The version of a document is read from a field;
+// older documents are upgraded by the chain of migrations leading to the latest version
+// before being parsed:
+//
+//	users := schema.NewMigrations("version", "v3", userV3).
+//	    Register(
+//	        schema.Migration{From: "v1", To: "v2", Transform: splitName},
+//	        schema.Migration{From: "v2", To: "v3", Transform: nestAddress},
+//	    )
+//	result := users.ParseAnyVersion(document, ctx)
+//
+// Migrations are safe for concurrent use once registered.
+type Migrations struct {
+	versionField   string
+	latest         string
+	schema         Parseable
+	defaultVersion string
+	migrations     map[string]Migration // By the version they upgrade from
+}
+
+// NewMigrations creates the migrations of the documents whose version is in
+// versionField, validated against schema once upgraded to the latest version
+func NewMigrations(versionField, latest string, schema Parseable) *Migrations {
+	return &Migrations{
+		versionField: versionField,
+		latest:       latest,
+		schema:       schema,
+		migrations:   make(map[string]Migration),
+	}
+}
+
+// Register adds migrations. It panics when two migrations upgrade from the same
+// version, when a migration has no transform, or when it upgrades from the latest
+// version.
+func (m *Migrations) Register(migrations ...Migration) *Migrations {
+	for _, migration := range migrations {
+		switch {
+		case migration.Transform == nil:
+			panic(fmt.Sprintf("schema: the migration from version %s to %s has no transform", migration.From, migration.To))
+		case migration.From == m.latest:
+			panic(fmt.Sprintf("schema: version %s is the latest version and cannot be migrated", migration.From))
+		}
+		if existing, ok := m.migrations[migration.From]; ok {
+			panic(fmt.Sprintf("schema: version %s already migrates to %s", migration.From, existing.To))
+		}
+		m.migrations[migration.From] = migration
+	}
+	return m
+}
+
+// DefaultVersion sets the version of the documents without a version field, usually
+// the first one, written before versions were introduced
+func (m *Migrations) DefaultVersion(version string) *Migrations {
+	m.defaultVersion = version
+	return m
+}
+
+// GetLatest returns the latest version
+func (m *Migrations) GetLatest() string {
+	return m.latest
+}
+
+// GetSchema returns the schema of the latest version
+func (m *Migrations) GetSchema() Parseable {
+	return m.schema
+}
+
+// Versions returns the known versions, from the oldest migrations to the latest version
+func (m *Migrations) Versions() []string {
+	versions := make([]string, 0, len(m.migrations)+1)
+	for version := range m.migrations {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return compareVersions(versions[i], versions[j]) < 0 })
+	return append(versions, m.latest)
+}
+
+// ParseAnyVersion detects the version of value, applies the migrations leading to the
+// latest version, and parses the upgraded document with the schema of the latest
+// version. Documents without a known version are rejected at the version field
+// (codes "required" and "enum"), and documents whose migration fails with code
+// "transform". Value may be a map or a struct, converted as by object schemas.
+func (m *Migrations) ParseAnyVersion(value interface{}, ctx *ValidationContext) ParseResult {
+	document, err := m.migrate(value, ctx)
+	if err != nil {
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{*err}}
+	}
+	return m.schema.Parse(document, ctx)
+}
+
+// Migrate upgrades value to the latest version without validating it, returning
+// ValidationErrors when its version is unknown or a migration fails
+func (m *Migrations) Migrate(value interface{}) (map[string]interface{}, error) {
+	document, err := m.migrate(value, DefaultValidationContext())
+	if err != nil {
+		return nil, ValidationErrors{*err}
+	}
+	return document, nil
+}
+
+// migrate upgrades value to the latest version
+func (m *Migrations) migrate(value interface{}, ctx *ValidationContext) (map[string]interface{}, *ValidationError) {
+	input, ok := convertToMap(value, ctx.TagNames...)
+	if !ok || value == nil {
+		err := NewPrimitiveError(value, migrationTypeError(ctx.Locale), CodeInvalidType)
+		return nil, &err
+	}
+	path := Path{FieldSegment(m.versionField)}
+
+	start, err := m.versionOf(input, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := m.migrations[start]; !ok && start != m.latest {
+		err := NewFieldError(path, input[m.versionField], migrationUnknownVersionError(start)(ctx.Locale), CodeEnum)
+		return nil, &err
+	}
+
+	// Migrate a copy, so the input is left untouched
+	document := make(map[string]interface{}, len(input)+1)
+	for key, value := range input {
+		document[key] = value
+	}
+	document[m.versionField] = start
+	visited := map[string]bool{}
+	for version := start; version != m.latest; {
+		migration, ok := m.migrations[version]
+		if !ok || visited[version] {
+			err := NewFieldError(path, start, migrationLoopError(start, m.latest)(ctx.Locale), CodeTransform)
+			return nil, &err
+		}
+		visited[version] = true
+
+		if document = migration.Transform(document); document == nil {
+			err := NewFieldError(path, version, migrationFailedError(migration.From, migration.To)(ctx.Locale), CodeTransform)
+			return nil, &err
+		}
+		document[m.versionField] = migration.To
+		version = migration.To
+	}
+	return document, nil
+}
+
+// versionOf returns the version of a document, or the default version when it has none
+func (m *Migrations) versionOf(document map[string]interface{}, ctx *ValidationContext) (string, *ValidationError) {
+	path := Path{FieldSegment(m.versionField)}
+	raw, ok := document[m.versionField]
+	if !ok || raw == nil {
+		if m.defaultVersion != "" {
+			return m.defaultVersion, nil
+		}
+		err := NewFieldError(path, "<missing>", migrationVersionRequiredError(m.versionField)(ctx.Locale), CodeRequired)
+		return "", &err
+	}
+	version, ok := raw.(string)
+	if !ok {
+		err := NewFieldError(path, raw, migrationUnknownVersionError(fmt.Sprint(raw))(ctx.Locale), CodeEnum)
+		return "", &err
+	}
+	return version, nil
+}
@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// userMigrations upgrades users from a single name (v1) to first and last names (v2)
+// and then nests the address (v3)
+func userMigrations() *Migrations {
+	userV3 := Object().
+		Property("version", Literal("v3")).
+		Property("firstName", String()).
+		Property("lastName", String()).
+		Property("address", Object().Property("city", String()).Optional())
+
+	return NewMigrations("version", "v3", userV3).
+		DefaultVersion("v1").
+		Register(
+			Migration{From: "v2", To: "v3", Transform: func(doc map[string]interface{}) map[string]interface{} {
+				if city, ok := doc["city"]; ok {
+					doc["address"] = map[string]interface{}{"city": city}
+					delete(doc, "city")
+				}
+				return doc
+			}},
+			Migration{From: "v1", To: "v2", Transform: func(doc map[string]interface{}) map[string]interface{} {
+				first, last, _ := strings.Cut(doc["name"].(string), " ")
+				doc["firstName"], doc["lastName"] = first, last
+				delete(doc, "name")
+				return doc
+			}},
+		)
+}
+
+func TestMigrations_ParseAnyVersion(t *testing.T) {
+	users := userMigrations()
+	want := map[string]interface{}{
+		"version": "v3", "firstName": "Ada", "lastName": "Lovelace",
+		"address": map[string]interface{}{"city": "London"},
+	}
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		errors []string
+	}{
+		{"latest", map[string]interface{}{"version": "v3", "firstName": "Ada", "lastName": "Lovelace", "address": map[string]interface{}{"city": "London"}}, nil},
+		{"one migration", map[string]interface{}{"version": "v2", "firstName": "Ada", "lastName": "Lovelace", "city": "London"}, nil},
+		{"two migrations", map[string]interface{}{"version": "v1", "name": "Ada Lovelace", "city": "London"}, nil},
+		{"default version", map[string]interface{}{"name": "Ada Lovelace", "city": "London"}, nil},
+		{"unknown version", map[string]interface{}{"version": "v9"}, []string{"version enum"}},
+		{"version not a string", map[string]interface{}{"version": 2}, []string{"version enum"}},
+		{"invalid after migration", map[string]interface{}{"version": "v2", "firstName": "Ada"}, []string{"lastName required"}},
+		{"not an object", "v1", []string{" invalid_type"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := users.ParseAnyVersion(tt.value, DefaultValidationContext())
+			if tt.errors != nil {
+				if got := errorKeys(result.Errors); result.Valid || !reflect.DeepEqual(got, tt.errors) {
+					t.Errorf("errors = %v, want %v", got, tt.errors)
+				}
+				return
+			}
+			if !result.Valid {
+				t.Fatalf("ParseAnyVersion() errors = %v", result.Errors)
+			}
+			if !reflect.DeepEqual(result.Value, want) {
+				t.Errorf("Value = %v, want %v", result.Value, want)
+			}
+		})
+	}
+}
+
+func TestMigrations_Migrate(t *testing.T) {
+	users := userMigrations()
+
+	input := map[string]interface{}{"version": "v1", "name": "Ada Lovelace"}
+	migrated, err := users.Migrate(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated["firstName"] != "Ada" || migrated["version"] != "v3" {
+		t.Errorf("Migrate() = %v", migrated)
+	}
+	if input["name"] != "Ada Lovelace" || input["version"] != "v1" {
+		t.Errorf("Migrate() modified its input: %v", input)
+	}
+
+	if got := users.Versions(); !reflect.DeepEqual(got, []string{"v1", "v2", "v3"}) {
+		t.Errorf("Versions() = %v", got)
+	}
+
+	// A migration returning no document fails the parse
+	failing := NewMigrations("v", "2", Any()).Register(Migration{From: "1", To: "2", Transform: func(map[string]interface{}) map[string]interface{} { return nil }})
+	if _, err := failing.Migrate(map[string]interface{}{"v": "1"}); err == nil || !strings.Contains(err.Error(), "returned no document") {
+		t.Errorf("Migrate() error = %v", err)
+	}
+
+	// A chain that does not reach the latest version is reported
+	broken := NewMigrations("v", "3", Any()).Register(Migration{From: "1", To: "2", Transform: func(doc map[string]interface{}) map[string]interface{} { return doc }})
+	if _, err := broken.Migrate(map[string]interface{}{"v": "1"}); err == nil || !strings.Contains(err.Error(), "do not lead to version 3") {
+		t.Errorf("Migrate() error = %v", err)
+	}
+}
+
+func TestMigrations_RegisterPanics(t *testing.T) {
+	identity := func(doc map[string]interface{}) map[string]interface{} { return doc }
+	tests := []struct {
+		name       string
+		migrations []Migration
+	}{
+		{"duplicate", []Migration{{From: "1", To: "2", Transform: identity}, {From: "1", To: "3", Transform: identity}}},
+		{"no transform", []Migration{{From: "1", To: "2"}}},
+		{"from latest", []Migration{{From: "3", To: "4", Transform: identity}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Register() did not panic")
+				}
+			}()
+			NewMigrations("v", "3", Any()).Register(tt.migrations...)
+		})
+	}
+}
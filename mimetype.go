@@ -0,0 +1,368 @@
+package schema
+
+import (
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for MIME type validation
+var (
+	mimeTypeRequiredError   = i18n.S("value is required")
+	mimeTypeTypeError       = i18n.S("value must be a string")
+	mimeTypeFormatError     = i18n.S("value must be a valid MIME type")
+	mimeTypeParametersError = i18n.S("MIME type must not have parameters")
+)
+
+// Default error message functions that take parameters
+func mimeTypeAllowedError(types []string) i18n.TranslatedFunc {
+	return i18n.F("MIME type must be one of: %s", strings.Join(types, ", "))
+}
+
+// MIMETypeSchema validates media types such as "image/png" or "text/html; charset=utf-8"
+// with mime.ParseMediaType and returns them in canonical form: type, subtype and
+// parameter names lowercased, parameters sorted. Wildcards ("image/*") are rejected as
+// values; they are only meaningful in AllowedTypes.
+type MIMETypeSchema struct {
+	Schema
+	allowedTypes []string // Allowed types, lowercase; "image/*" matches any image type
+	noParameters bool
+	nullable     bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	allowedTypeError  ErrorMessage
+	parametersError   ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// MIMEType creates a new MIME type schema accepting any media type
+func MIMEType(errorMessage ...interface{}) *MIMETypeSchema {
+	schema := &MIMETypeSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *MIMETypeSchema) Clone() *MIMETypeSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.allowedTypes = slices.Clone(s.allowedTypes)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *MIMETypeSchema) Freeze() *MIMETypeSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *MIMETypeSchema) Title(title string) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *MIMETypeSchema) Description(description string) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *MIMETypeSchema) ReadOnly() *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *MIMETypeSchema) WriteOnly() *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *MIMETypeSchema) Sensitive() *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *MIMETypeSchema) Deprecated(reason string) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *MIMETypeSchema) Meta(key string, value interface{}) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *MIMETypeSchema) AsWarning(codes ...ErrorCode) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *MIMETypeSchema) AsInfo(codes ...ErrorCode) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *MIMETypeSchema) Default(value interface{}) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *MIMETypeSchema) DefaultFunc(fn func() interface{}) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *MIMETypeSchema) Example(example string) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// AllowedTypes restricts the media type (compared case-insensitively, parameters ignored).
+// "image/*" matches any image type and "*/*" matches everything.
+func (s *MIMETypeSchema) AllowedTypes(types ...string) *MIMETypeSchema {
+	s.checkMutable()
+	s.allowedTypes = lowerAll(types)
+	return s
+}
+
+// AllowedTypeError sets a custom error message for types not in AllowedTypes
+func (s *MIMETypeSchema) AllowedTypeError(message interface{}) *MIMETypeSchema {
+	s.checkMutable()
+	s.allowedTypeError = toErrorMessage(message)
+	return s
+}
+
+// NoParameters rejects media types with parameters, such as "text/plain; charset=utf-8"
+func (s *MIMETypeSchema) NoParameters(errorMessage ...interface{}) *MIMETypeSchema {
+	s.checkMutable()
+	s.noParameters = true
+	if len(errorMessage) > 0 {
+		s.parametersError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *MIMETypeSchema) Optional() *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *MIMETypeSchema) Required(errorMessage ...interface{}) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *MIMETypeSchema) Nullable() *MIMETypeSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *MIMETypeSchema) TypeError(message string) *MIMETypeSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for malformed media types
+func (s *MIMETypeSchema) FormatError(message string) *MIMETypeSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *MIMETypeSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *MIMETypeSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *MIMETypeSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetAllowedTypes returns the allowed media types
+func (s *MIMETypeSchema) GetAllowedTypes() []string {
+	return s.allowedTypes
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *MIMETypeSchema) Transform(fn TransformFunc) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *MIMETypeSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *MIMETypeSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *MIMETypeSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a media type and returns it in canonical form
+func (s *MIMETypeSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the media type; Parse runs the refine/transform pipeline on top
+func (s *MIMETypeSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := mimeTypeRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	str, ok := value.(string)
+	if !ok {
+		message := mimeTypeTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	// mime.ParseMediaType also accepts Content-Disposition values, so a subtype is required
+	mediaType, params, err := mime.ParseMediaType(str)
+	slash := strings.IndexByte(mediaType, '/')
+	if err != nil || slash < 0 || strings.Contains(mediaType, "*") {
+		message := mimeTypeFormatError(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+
+	var errors []ValidationError
+
+	if len(s.allowedTypes) > 0 && !matchMIMEType(s.allowedTypes, mediaType) {
+		message := mimeTypeAllowedError(s.allowedTypes)(ctx.Locale)
+		if !isEmptyErrorMessage(s.allowedTypeError) {
+			message = resolveErrorMessage(s.allowedTypeError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeMimeType))
+	}
+
+	if s.noParameters && len(params) > 0 {
+		message := mimeTypeParametersError(ctx.Locale)
+		if !isEmptyErrorMessage(s.parametersError) {
+			message = resolveErrorMessage(s.parametersError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeParameters))
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errors}
+	}
+	return ParseResult{Valid: true, Value: mime.FormatMediaType(mediaType, params), Errors: nil}
+}
+
+// matchMIMEType reports whether mediaType (lowercase, without parameters) matches one of
+// the allowed types, where "type/*" and "*/*" are wildcards
+func matchMIMEType(allowed []string, mediaType string) bool {
+	topLevel := mediaType[:strings.IndexByte(mediaType, '/')]
+	for _, pattern := range allowed {
+		if pattern == mediaType || pattern == "*/*" || pattern == topLevel+"/*" {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON generates JSON Schema representation. The pattern only describes the syntax;
+// AllowedTypes is enforced by Parse.
+func (s *MIMETypeSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	token := "[A-Za-z0-9!#$&^_.+-]+"
+	if s.noParameters {
+		schema["pattern"] = "^" + token + "/" + token + "$"
+	} else {
+		schema["pattern"] = "^" + token + "/" + token + "(\\s*;.*)?$"
+	}
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMIMETypeSchema_Parse(t *testing.T) {
+	ctx := DefaultValidationContext()
+	tests := []struct {
+		value    string
+		expected interface{}
+	}{
+		{"image/png", "image/png"},
+		{"Image/PNG", "image/png"},
+		{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{"text/html; charset=utf-8", "text/html; charset=utf-8"},
+		{"text/html;Charset=UTF-8", "text/html; charset=UTF-8"},
+		{"multipart/form-data; boundary=\"a b\"", "multipart/form-data; boundary=\"a b\""},
+		{"image", nil},
+		{"image/", nil},
+		{"/png", nil},
+		{"image/png/extra", nil},
+		{"image/*", nil},
+		{"*/*", nil},
+		{"text/html; charset", nil},
+		{"text html", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result := MIMEType().Parse(tt.value, ctx)
+			if result.Valid != (tt.expected != nil) {
+				t.Fatalf("Parse(%q) valid = %v (%v)", tt.value, result.Valid, result.Errors)
+			}
+			if result.Valid && result.Value != tt.expected {
+				t.Errorf("Value = %q, want %q", result.Value, tt.expected)
+			}
+			if !result.Valid && result.Errors[0].Code != "format" {
+				t.Errorf("code = %q, want format", result.Errors[0].Code)
+			}
+		})
+	}
+}
+
+func TestMIMETypeSchema_Constraints(t *testing.T) {
+	ctx := DefaultValidationContext()
+	uploads := MIMEType().AllowedTypes("image/*", "Application/PDF")
+	tests := []struct {
+		name   string
+		schema *MIMETypeSchema
+		value  interface{}
+		errors []string
+	}{
+		{"wildcard", uploads, "image/webp", nil},
+		{"exact", uploads, "application/pdf", nil},
+		{"case", uploads, "APPLICATION/pdf", nil},
+		{"parameters ignored", uploads, "image/svg+xml; charset=utf-8", nil},
+		{"not allowed", uploads, "application/zip", []string{" mime_type"}},
+		{"prefix is not a wildcard", uploads, "imagex/png", []string{" mime_type"}},
+		{"any", MIMEType().AllowedTypes("*/*"), "font/woff2", nil},
+		{"no parameters", MIMEType().NoParameters(), "text/plain", nil},
+		{"parameters", MIMEType().NoParameters(), "text/plain; charset=utf-8", []string{" parameters"}},
+		{"both", MIMEType().AllowedTypes("image/*").NoParameters(), "text/plain; charset=utf-8", []string{" mime_type", " parameters"}},
+		{"wrong type", MIMEType(), 42, []string{" invalid_type"}},
+		{"nil", MIMEType(), nil, []string{" required"}},
+		{"nullable", MIMEType().Nullable(), nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if codes := errorKeys(result.Errors); len(codes) > 0 || len(tt.errors) > 0 {
+				if !reflect.DeepEqual(codes, tt.errors) {
+					t.Errorf("errors = %v, want %v", codes, tt.errors)
+				}
+			}
+			if result.Valid != (len(tt.errors) == 0) {
+				t.Errorf("Valid = %v", result.Valid)
+			}
+		})
+	}
+
+	result := MIMEType().AllowedTypes("image/png").Parse("image/gif", ctx)
+	if result.Errors[0].Message != "MIME type must be one of: image/png" {
+		t.Errorf("message = %q", result.Errors[0].Message)
+	}
+}
+
+func TestMIMETypeSchema_JSON(t *testing.T) {
+	expected := map[string]interface{}{"type": "string", "pattern": "^[A-Za-z0-9!#$&^_.+-]+/[A-Za-z0-9!#$&^_.+-]+$"}
+	if got := MIMEType().NoParameters().JSON(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("JSON() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,291 @@
+// Package msgrouter dispatches JSON messages, such as WebSocket frames or JSON-RPC
+// calls, to handlers registered by message type. Each message type has an object
+// schema validating its parameters; invalid messages are answered with an error
+// envelope listing the validation errors and never reach their handler.
+//
+//	router := msgrouter.New().
+//	    On("chat.send", chatSchema, func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+//	        return rooms.Send(ctx, params["room"].(string), params["text"].(string))
+//	    })
+//
+//	for {
+//	    _, frame, err := conn.ReadMessage()
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if reply := router.Dispatch(ctx, frame); reply != nil {
+//	        conn.WriteMessage(websocket.TextMessage, reply)
+//	    }
+//	}
+//
+// Two frame formats are accepted. JSON-RPC 2.0 requests ({"jsonrpc": "2.0", "method":
+// ..., "params": {...}, "id": ...}) are answered with JSON-RPC responses, and batches of
+// them with arrays of responses. Other frames name their type and parameters with
+// "type" and "payload" ({"type": "chat.send", "payload": {...}, "id": 7}) and are
+// answered with {"type": ..., "id": ..., "result": ...} or {"type": ..., "id": ...,
+// "error": {...}}.
+package msgrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/nyxstack/schema"
+)
+
+// Error codes of the error envelope, as defined by JSON-RPC 2.0
+const (
+	CodeParseError     = -32700 // The frame is not valid JSON
+	CodeInvalidRequest = -32600 // The frame is not a message
+	CodeMethodNotFound = -32601 // No handler is registered for the message type
+	CodeInvalidParams  = -32602 // The parameters fail the schema of the message type
+	CodeInternalError  = -32603 // The handler failed
+)
+
+// FieldError describes one validation failure of the parameters of a message
+type FieldError struct {
+	Field   string           `json:"field"` // Dot path of the failing value ("" for the parameters themselves)
+	Message string           `json:"message"`
+	Code    schema.ErrorCode `json:"code"`
+}
+
+// ErrorData holds the validation errors of an error envelope
+type ErrorData struct {
+	Errors []FieldError `json:"errors"`
+}
+
+// Error is the error envelope of replies. It marshals to {"code": ..., "message": ...,
+// "data": {"errors": [...]}}, with data present for validation failures only.
+// Handlers can return an *Error to answer with their own code and message.
+type Error struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    *ErrorData `json:"data,omitempty"`
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Data == nil || len(e.Data.Errors) == 0 {
+		return e.Message
+	}
+	parts := make([]string, len(e.Data.Errors))
+	for i, fieldErr := range e.Data.Errors {
+		if fieldErr.Field == "" {
+			parts[i] = fieldErr.Message
+		} else {
+			parts[i] = fieldErr.Field + ": " + fieldErr.Message
+		}
+	}
+	return e.Message + ": " + strings.Join(parts, "; ")
+}
+
+// InvalidParams builds the envelope reporting validation errors, with code
+// CodeInvalidParams. Handlers can return it for checks of their own.
+func InvalidParams(errors []schema.ValidationError) *Error {
+	fieldErrors := make([]FieldError, len(errors))
+	for i, err := range errors {
+		fieldErrors[i] = FieldError{Field: err.Path.DotPath(), Message: err.Message, Code: err.Code}
+	}
+	return &Error{Code: CodeInvalidParams, Message: "invalid params", Data: &ErrorData{Errors: fieldErrors}}
+}
+
+// Handler handles the messages of one type. params is the parsed value of the
+// parameters, with the defaults and transforms of the schema applied. The result is
+// marshaled to JSON as the result of the reply.
+type Handler func(ctx context.Context, params map[string]interface{}) (interface{}, error)
+
+// route is the schema and handler of a message type
+type route struct {
+	schema  *schema.ObjectSchema
+	handler Handler
+}
+
+// Router dispatches messages to the handlers of their type. It is safe for concurrent
+// use, so one router can serve every connection.
+type Router struct {
+	mu     sync.RWMutex
+	routes map[string]route
+	locale string
+}
+
+// New creates a router without handlers
+func New() *Router {
+	return &Router{routes: make(map[string]route)}
+}
+
+// On registers the handler of a message type, called with the parameters of messages
+// that pass s. Registering a type again replaces its handler.
+func (r *Router) On(messageType string, s *schema.ObjectSchema, handler Handler) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[messageType] = route{schema: s, handler: handler}
+	return r
+}
+
+// Locale sets the locale of validation error messages ("en" by default)
+func (r *Router) Locale(locale string) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.locale = locale
+	return r
+}
+
+// Dispatch handles a frame and returns the reply to send, or nil when there is none.
+// Messages without an id are notifications: JSON-RPC notifications are never
+// answered, and other messages without an id are answered only when they fail.
+// Errors returned by handlers that are not an *Error are answered with
+// CodeInternalError without exposing their message.
+func (r *Router) Dispatch(ctx context.Context, frame []byte) []byte {
+	frame = bytes.TrimSpace(frame)
+	if len(frame) > 0 && frame[0] == '[' {
+		return r.dispatchBatch(ctx, frame)
+	}
+	return marshalReply(r.dispatch(ctx, frame))
+}
+
+// dispatchBatch handles a JSON-RPC batch
+func (r *Router) dispatchBatch(ctx context.Context, frame []byte) []byte {
+	var messages []json.RawMessage
+	if err := json.Unmarshal(frame, &messages); err != nil {
+		return marshalReply(rpcError(nil, &Error{Code: CodeParseError, Message: "parse error: " + err.Error()}))
+	}
+	if len(messages) == 0 {
+		return marshalReply(rpcError(nil, &Error{Code: CodeInvalidRequest, Message: "empty batch"}))
+	}
+	var replies []*reply
+	for _, message := range messages {
+		if rep := r.dispatch(ctx, message); rep != nil {
+			replies = append(replies, rep)
+		}
+	}
+	if len(replies) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(replies)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// message is an incoming frame, in either format
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// reply is an outgoing frame, in the format of the message it answers
+type reply struct {
+	JSONRPC string          `json:"jsonrpc,omitempty"`
+	Type    string          `json:"type,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// dispatch handles a single message and returns its reply (nil when there is none)
+func (r *Router) dispatch(ctx context.Context, frame []byte) *reply {
+	var msg message
+	if err := json.Unmarshal(frame, &msg); err != nil {
+		return rpcError(nil, &Error{Code: CodeParseError, Message: "parse error: " + err.Error()})
+	}
+
+	rpc := msg.JSONRPC != ""
+	messageType, params := msg.Type, msg.Payload
+	if rpc {
+		messageType, params = msg.Method, msg.Params
+	}
+	// Without an id, JSON-RPC messages are notifications and other messages are only
+	// answered on failure
+	notification := len(msg.ID) == 0 || string(msg.ID) == "null"
+	answer := func(result json.RawMessage, err *Error) *reply {
+		if notification && (rpc || err == nil) {
+			return nil
+		}
+		if rpc {
+			if err != nil {
+				return rpcError(msg.ID, err)
+			}
+			return &reply{JSONRPC: "2.0", ID: msg.ID, Result: result}
+		}
+		if notification {
+			msg.ID = nil
+		}
+		return &reply{Type: messageType, ID: msg.ID, Result: result, Error: err}
+	}
+
+	if rpc && msg.JSONRPC != "2.0" {
+		notification = false // Invalid requests are always answered
+		return answer(nil, &Error{Code: CodeInvalidRequest, Message: "invalid request: unsupported jsonrpc version " + msg.JSONRPC})
+	}
+	if messageType == "" {
+		notification = false
+		return answer(nil, &Error{Code: CodeInvalidRequest, Message: "invalid request: missing message type"})
+	}
+
+	r.mu.RLock()
+	rt, ok := r.routes[messageType]
+	locale := r.locale
+	r.mu.RUnlock()
+	if !ok {
+		return answer(nil, &Error{Code: CodeMethodNotFound, Message: "unknown message type " + messageType})
+	}
+
+	var value interface{} = map[string]interface{}{}
+	if len(params) > 0 && string(params) != "null" {
+		if err := json.Unmarshal(params, &value); err != nil {
+			return answer(nil, &Error{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()})
+		}
+	}
+	vctx := schema.DefaultValidationContext()
+	if locale != "" {
+		vctx = schema.NewValidationContext(locale)
+	}
+	parsed := rt.schema.Parse(value, vctx.WithContext(ctx))
+	if !parsed.Valid {
+		return answer(nil, InvalidParams(parsed.Errors))
+	}
+
+	paramsValue, _ := parsed.Value.(map[string]interface{})
+	result, err := rt.handler(ctx, paramsValue)
+	if err != nil {
+		var envelope *Error
+		if !errors.As(err, &envelope) {
+			envelope = &Error{Code: CodeInternalError, Message: "internal error"}
+		}
+		return answer(nil, envelope)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return answer(nil, &Error{Code: CodeInternalError, Message: "internal error"})
+	}
+	return answer(data, nil)
+}
+
+// rpcError builds a JSON-RPC error reply; the id is null when unknown
+func rpcError(id json.RawMessage, err *Error) *reply {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &reply{JSONRPC: "2.0", ID: id, Error: err}
+}
+
+// marshalReply marshals a reply, returning nil for no reply
+func marshalReply(rep *reply) []byte {
+	if rep == nil {
+		return nil
+	}
+	data, err := json.Marshal(rep)
+	if err != nil {
+		return nil
+	}
+	return data
+}
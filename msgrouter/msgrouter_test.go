@@ -0,0 +1,168 @@
+package msgrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+func newChatRouter() *Router {
+	chat := schema.Object().
+		Property("room", schema.String().MinLength(1)).
+		Property("text", schema.String().MinLength(1).MaxLength(10)).
+		Property("priority", schema.Int().Min(0).Optional())
+
+	return New().
+		On("chat.send", chat, func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return map[string]interface{}{"delivered": params["room"], "priority": params["priority"]}, nil
+		}).
+		On("chat.fail", schema.Object(), func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("database is down")
+		}).
+		On("chat.forbidden", schema.Object(), func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, &Error{Code: 403, Message: "not a member of the room"}
+		})
+}
+
+func TestRouterDispatch(t *testing.T) {
+	router := newChatRouter()
+
+	tests := []struct {
+		name  string
+		frame string
+		reply string // "" for no reply
+	}{
+		{
+			"rpc result",
+			`{"jsonrpc":"2.0","method":"chat.send","params":{"room":"general","text":"hi"},"id":1}`,
+			`{"jsonrpc":"2.0","id":1,"result":{"delivered":"general","priority":null}}`,
+		},
+		{
+			"rpc invalid params",
+			`{"jsonrpc":"2.0","method":"chat.send","params":{"room":"","text":"hi"},"id":"a"}`,
+			`{"jsonrpc":"2.0","id":"a","error":{"code":-32602,"message":"invalid params","data":{"errors":[{"field":"room","message":"property room is invalid","code":"property_invalid"},{"field":"room","message":"value is required","code":"required"}]}}}`,
+		},
+		{
+			"rpc unknown method",
+			`{"jsonrpc":"2.0","method":"chat.edit","id":2}`,
+			`{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"unknown message type chat.edit"}}`,
+		},
+		{
+			"rpc notification",
+			`{"jsonrpc":"2.0","method":"chat.send","params":{"room":"general","text":"hi"}}`,
+			``,
+		},
+		{
+			"rpc invalid notification",
+			`{"jsonrpc":"2.0","method":"chat.send","params":{}}`,
+			``,
+		},
+		{
+			"rpc wrong version",
+			`{"jsonrpc":"1.0","method":"chat.send","id":3}`,
+			`{"jsonrpc":"2.0","id":3,"error":{"code":-32600,"message":"invalid request: unsupported jsonrpc version 1.0"}}`,
+		},
+		{
+			"typed result",
+			`{"type":"chat.send","payload":{"room":"general","text":"hi","priority":3},"id":7}`,
+			`{"type":"chat.send","id":7,"result":{"delivered":"general","priority":3}}`,
+		},
+		{
+			"typed notification",
+			`{"type":"chat.send","payload":{"room":"general","text":"hi"}}`,
+			``,
+		},
+		{
+			"typed invalid notification",
+			`{"type":"chat.send","payload":{"room":"general","text":"far too long"}}`,
+			`{"type":"chat.send","error":{"code":-32602,"message":"invalid params","data":{"errors":[{"field":"text","message":"property text is invalid","code":"property_invalid"},{"field":"text","message":"value must be at most 10 characters long","code":"max_length"}]}}}`,
+		},
+		{
+			"missing payload",
+			`{"type":"chat.send","id":8}`,
+			`{"type":"chat.send","id":8,"error":{"code":-32602,"message":"invalid params","data":{"errors":[{"field":"room","message":"property room is required","code":"required"},{"field":"text","message":"property text is required","code":"required"}]}}}`,
+		},
+		{
+			"handler error hidden",
+			`{"type":"chat.fail","id":9}`,
+			`{"type":"chat.fail","id":9,"error":{"code":-32603,"message":"internal error"}}`,
+		},
+		{
+			"handler envelope",
+			`{"type":"chat.forbidden","id":10}`,
+			`{"type":"chat.forbidden","id":10,"error":{"code":403,"message":"not a member of the room"}}`,
+		},
+		{
+			"parse error",
+			`{"type":`,
+			`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error: unexpected end of JSON input"}}`,
+		},
+		{
+			"missing type",
+			`{"payload":{}}`,
+			`{"error":{"code":-32600,"message":"invalid request: missing message type"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := router.Dispatch(context.Background(), []byte(tt.frame))
+			if tt.reply == "" {
+				if got != nil {
+					t.Fatalf("reply = %s, want none", got)
+				}
+				return
+			}
+			assertJSON(t, got, tt.reply)
+		})
+	}
+}
+
+func TestRouterDispatchBatch(t *testing.T) {
+	router := newChatRouter()
+
+	got := router.Dispatch(context.Background(), []byte(`[
+		{"jsonrpc":"2.0","method":"chat.send","params":{"room":"general","text":"hi"},"id":1},
+		{"jsonrpc":"2.0","method":"chat.send","params":{"room":"general","text":"hi"}},
+		{"jsonrpc":"2.0","method":"chat.send","params":{"text":"hi"},"id":2}
+	]`))
+	assertJSON(t, got, `[
+		{"jsonrpc":"2.0","id":1,"result":{"delivered":"general","priority":null}},
+		{"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":"invalid params","data":{"errors":[{"field":"room","message":"property room is required","code":"required"}]}}}
+	]`)
+
+	if got := router.Dispatch(context.Background(), []byte(`[{"jsonrpc":"2.0","method":"chat.send","params":{"room":"a","text":"b"}}]`)); got != nil {
+		t.Errorf("batch of notifications replied %s", got)
+	}
+	assertJSON(t, router.Dispatch(context.Background(), []byte(`[]`)),
+		`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"empty batch"}}`)
+}
+
+func TestInvalidParams(t *testing.T) {
+	result := schema.Object().Property("room", schema.String()).Parse(map[string]interface{}{}, schema.DefaultValidationContext())
+	err := InvalidParams(result.Errors)
+	if err.Code != CodeInvalidParams || len(err.Data.Errors) != 1 || err.Data.Errors[0].Field != "room" {
+		t.Fatalf("InvalidParams = %+v", err)
+	}
+	if got, want := err.Error(), "invalid params: room: property room is required"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("reply %q is not JSON: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("want %q is not JSON: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("reply = %s\nwant    %s", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+func multipartDecodeError(err error) i18n.TranslatedFunc {
+	return i18n.F("invalid multipart form: %v", err)
+}
+
+// multipartMaxMemory is the number of bytes of a multipart body ParseMultipart keeps
+// in memory; larger file parts are stored in temporary files
+const multipartMaxMemory = 32 << 20
+
+// ParseMultipart parses a multipart/form-data request and validates its fields and
+// files against s in one pass. Fields are mapped onto the schema as with
+// ParseURLValues and coerced to the property types. Files are set on the top-level
+// property of their part name (a trailing "[]" is ignored) as *multipart.FileHeader
+// values, or as a list of them for array properties and repeated parts, to be
+// validated by File schemas:
+//
+//	upload := schema.Object().
+//	    Property("title", schema.String().MaxLength(100)).
+//	    Property("avatar", schema.File().MaxSize(2<<20).AllowedTypes("image/png", "image/jpeg")).
+//	    Property("attachments", schema.Array(schema.File().MaxSize(10<<20)).MaxItems(5).Optional())
+//
+//	result := schema.ParseMultipart(upload, r)
+//
+// A body that is not a valid multipart form fails with code "invalid_multipart".
+// Bound the size of the body with http.MaxBytesReader before calling ParseMultipart;
+// the temporary files of large parts are removed by net/http once the handler returns.
+func ParseMultipart(s *ObjectSchema, r *http.Request) ParseResult {
+	ctx := DefaultValidationContext().WithContext(r.Context())
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		return documentError(multipartDecodeError(err)(ctx.Locale), CodeInvalidMultipart, nil, "")
+	}
+
+	value := urlValuesToMap(s, r.MultipartForm.Value)
+	for key, headers := range r.MultipartForm.File {
+		name := strings.TrimSuffix(key, "[]")
+		if _, isArray := s.properties[name].Schema.(*ArraySchema); isArray || len(headers) > 1 {
+			files := make([]interface{}, len(headers))
+			for i, header := range headers {
+				files[i] = header
+			}
+			value[name] = files
+		} else if len(headers) == 1 {
+			value[name] = headers[0]
+		}
+	}
+
+	coercing := *ctx
+	coercing.Coerce = true
+	return s.Parse(value, &coercing)
+}
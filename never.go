@@ -0,0 +1,152 @@
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for never validation
+var (
+	neverError = i18n.S("value is not allowed")
+)
+
+// NeverSchema represents a schema that no value satisfies. As an object property it
+// forbids the property: the property may be missing, but any value given for it is
+// rejected.
+type NeverSchema struct {
+	Schema
+	// Error messages for validation failures (support i18n)
+	neverError ErrorMessage
+}
+
+// Never creates a new schema that rejects every value, with optional custom error message
+func Never(errorMessage ...interface{}) *NeverSchema {
+	schema := &NeverSchema{
+		Schema: Schema{
+			schemaType: "never",
+			required:   false, // A forbidden property must be allowed to be missing
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.neverError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *NeverSchema) Clone() *NeverSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *NeverSchema) Freeze() *NeverSchema {
+	s.freeze()
+	return s
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *NeverSchema) Title(title string) *NeverSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *NeverSchema) Description(description string) *NeverSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *NeverSchema) Deprecated(reason string) *NeverSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *NeverSchema) Meta(key string, value interface{}) *NeverSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *NeverSchema) AsWarning(codes ...ErrorCode) *NeverSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *NeverSchema) AsInfo(codes ...ErrorCode) *NeverSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Error customization
+
+// NeverError sets a custom error message for rejected values
+func (s *NeverSchema) NeverError(message string) *NeverSchema {
+	s.checkMutable()
+	s.neverError = toErrorMessage(message)
+	return s
+}
+
+// Getters for accessing private fields
+
+// IsRequired returns false: a Never property may only be missing
+func (s *NeverSchema) IsRequired() bool {
+	return false
+}
+
+// IsOptional returns true: a Never property may only be missing
+func (s *NeverSchema) IsOptional() bool {
+	return true
+}
+
+// Validation
+
+// Parse rejects the value, whatever it is (nil included)
+func (s *NeverSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	message := neverError(ctx.Locale)
+	if !isEmptyErrorMessage(s.neverError) {
+		message = resolveErrorMessage(s.neverError, ctx)
+	}
+	result := ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeNever)}}
+	result = splitWarnings(result, s, value)
+	renderMessages(result.Errors, ctx, s, value)
+	renderMessages(result.Warnings, ctx, s, value)
+	return result
+}
+
+// JSON generates JSON Schema representation: "not" with the empty schema, which
+// matches every value
+func (s *NeverSchema) JSON() map[string]interface{} {
+	schema := map[string]interface{}{
+		"not": map[string]interface{}{},
+	}
+
+	// Add base schema fields
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+
+	return schema
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize NeverSchema for JSON schema generation
+func (s *NeverSchema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Schema)
+}
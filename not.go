@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"encoding/json"
+
 	"github.com/nyxstack/i18n"
 )
 
@@ -18,25 +20,237 @@ var NotErrors = struct {
 
 // NotSchema represents a "not" validation schema that rejects values matching the given schema
 type NotSchema struct {
+	Schema
 	schema   Parseable
+	nullable bool // Allow null values
 	notError ErrorMessage
 }
 
 // Not creates a new Not schema that rejects values matching the given schema
 func Not(schema Parseable) *NotSchema {
 	return &NotSchema{
+		Schema: Schema{
+			schemaType: "not",
+			required:   true, // Default to required
+		},
 		schema: schema,
 	}
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *NotSchema) Clone() *NotSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema and the negated schema immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *NotSchema) Freeze() *NotSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and the negated schema
+func (s *NotSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.schema)
+}
+
+// Children returns the negated schema, which applies to the value itself
+func (s *NotSchema) Children() []SchemaChild {
+	return sameValueChildren(s.schema)
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *NotSchema) Title(title string) *NotSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *NotSchema) Description(description string) *NotSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *NotSchema) ReadOnly() *NotSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *NotSchema) WriteOnly() *NotSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *NotSchema) Sensitive() *NotSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *NotSchema) Deprecated(reason string) *NotSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *NotSchema) Meta(key string, value interface{}) *NotSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *NotSchema) AsWarning(codes ...ErrorCode) *NotSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *NotSchema) AsInfo(codes ...ErrorCode) *NotSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value, used in place of nil
+func (s *NotSchema) Default(value interface{}) *NotSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *NotSchema) DefaultFunc(fn func() interface{}) *NotSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *NotSchema) Example(example interface{}) *NotSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Required/Optional/Nullable control
+
+// Optional marks the schema as optional: nil is accepted, and the property may be
+// missing when the schema is used in an object
+func (s *NotSchema) Optional() *NotSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior). A required Not schema
+// checks nil against the negated schema like any other value.
+func (s *NotSchema) Required() *NotSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *NotSchema) Nullable() *NotSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// Error customization
+
 // NotError sets a custom error message for when the value matches (and should not)
 func (s *NotSchema) NotError(err ErrorMessage) *NotSchema {
+	s.checkMutable()
 	s.notError = err
 	return s
 }
 
+// Getters for accessing private fields
+
+// IsOptional returns whether the schema is marked as optional
+func (s *NotSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *NotSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetSchema returns the negated schema
+func (s *NotSchema) GetSchema() Parseable {
+	return s.schema
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *NotSchema) Transform(fn TransformFunc) *NotSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *NotSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *NotSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *NotSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *NotSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Parse validates that a value does NOT match the specified schema
 func (s *NotSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the not constraints; Parse runs the refine/transform pipeline on top
+func (s *NotSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values; a required schema without a default negates nil like any value
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if !s.Schema.required {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+	}
+
 	// Try to parse with the inner schema
 	result := s.schema.Parse(value, ctx)
 
@@ -50,7 +264,7 @@ func (s *NotSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 		return ParseResult{
 			Valid:  false,
 			Value:  value,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "not_match")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeNotMatch)},
 		}
 	}
 
@@ -64,14 +278,51 @@ func (s *NotSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 
 // JSON generates JSON Schema for Not validation
 func (s *NotSchema) JSON() map[string]interface{} {
-	if jsonSchema, ok := s.schema.(interface{ JSON() map[string]interface{} }); ok {
-		return map[string]interface{}{
-			"not": jsonSchema.JSON(),
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *NotSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
+	schema := make(map[string]interface{})
+	if notJSON, ok := childJSON(s.schema, g); ok {
+		schema["not"] = notJSON
+	} else {
+		// Fallback if schema doesn't support JSON generation
+		schema["not"] = map[string]interface{}{"type": "unknown"}
+	}
+
+	// Add base schema fields
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+
+	// Add nullable if true
+	if s.nullable {
+		// The negated schema may reject null too, so null is added with anyOf
+		schema = map[string]interface{}{
+			"anyOf": []interface{}{
+				schema,
+				map[string]interface{}{"type": "null"},
+			},
 		}
 	}
 
-	// Fallback if schema doesn't support JSON generation
-	return map[string]interface{}{
-		"not": map[string]interface{}{"type": "unknown"},
+	return schema
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize NotSchema for JSON schema generation
+func (s *NotSchema) MarshalJSON() ([]byte, error) {
+	type jsonNotSchema struct {
+		Schema
+		Not      Parseable `json:"not"`
+		Nullable bool      `json:"nullable,omitempty"`
 	}
+
+	return json.Marshal(jsonNotSchema{
+		Schema:   s.Schema,
+		Not:      s.schema,
+		Nullable: s.nullable,
+	})
 }
@@ -8,8 +8,7 @@ import (
 
 // Default error messages for null validation
 var (
-	nullRequiredError = i18n.S("value is required")
-	nullTypeError     = i18n.S("value must be null")
+	nullTypeError = i18n.S("value must be null")
 )
 
 // NullSchema represents a JSON Schema for null values
@@ -34,22 +33,93 @@ func Null(errorMessage ...interface{}) *NullSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *NullSchema) Clone() *NullSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *NullSchema) Freeze() *NullSchema {
+	s.freeze()
+	return s
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *NullSchema) Title(title string) *NullSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *NullSchema) Description(description string) *NullSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *NullSchema) ReadOnly() *NullSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *NullSchema) WriteOnly() *NullSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *NullSchema) Sensitive() *NullSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *NullSchema) Deprecated(reason string) *NullSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *NullSchema) Meta(key string, value interface{}) *NullSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *NullSchema) AsWarning(codes ...ErrorCode) *NullSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *NullSchema) AsInfo(codes ...ErrorCode) *NullSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value (always nil for null schemas)
 func (s *NullSchema) Default(value interface{}) *NullSchema {
+	s.checkMutable()
 	if value == nil {
 		s.Schema.defaultValue = nil
 	}
@@ -59,6 +129,7 @@ func (s *NullSchema) Default(value interface{}) *NullSchema {
 
 // Example adds an example value (always nil for null schemas)
 func (s *NullSchema) Example(example interface{}) *NullSchema {
+	s.checkMutable()
 	if example == nil {
 		s.Schema.examples = append(s.Schema.examples, nil)
 	}
@@ -70,12 +141,14 @@ func (s *NullSchema) Example(example interface{}) *NullSchema {
 
 // Optional marks the schema as optional
 func (s *NullSchema) Optional() *NullSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *NullSchema) Required(errorMessage ...interface{}) *NullSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -85,6 +158,7 @@ func (s *NullSchema) Required(errorMessage ...interface{}) *NullSchema {
 
 // TypeError sets a custom error message for type mismatch validation
 func (s *NullSchema) TypeError(message string) *NullSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -101,35 +175,42 @@ func (s *NullSchema) IsOptional() bool {
 	return !s.Schema.required
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *NullSchema) Transform(fn TransformFunc) *NullSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *NullSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *NullSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *NullSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *NullSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses a null value, returning the final parsed value
 func (s *NullSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the null constraints; Parse runs the refine/transform pipeline on top
+func (s *NullSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	// Handle nil values
 	if value == nil {
 		// Null values are always valid for null schemas
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
 	}
 
-	// Handle missing required field
-	if s.Schema.required {
-		// Check if we have a default value (should be nil)
-		if defaultVal := s.GetDefault(); defaultVal == nil {
-			// Use default value and re-parse it
-			return s.Parse(defaultVal, ctx)
-		}
-		// Required null field but got non-nil value
-		message := nullRequiredError(ctx.Locale)
-		if !isEmptyErrorMessage(s.requiredError) {
-			message = resolveErrorMessage(s.requiredError, ctx)
-		}
-		return ParseResult{
-			Valid:  false,
-			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
-		}
-	}
-
 	// Non-nil value for null schema is type error
 	message := nullTypeError(ctx.Locale)
 	if !isEmptyErrorMessage(s.typeMismatchError) {
@@ -138,7 +219,7 @@ func (s *NullSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 	return ParseResult{
 		Valid:  false,
 		Value:  nil,
-		Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+		Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 	}
 }
 
@@ -149,6 +230,7 @@ func (s *NullSchema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
 	// Default and examples should always be null for null schemas
 	if s.GetDefault() == nil {
 		schema["default"] = nil
@@ -2,6 +2,10 @@ package schema
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/nyxstack/i18n"
 )
@@ -11,6 +15,7 @@ var (
 	numberRequiredError = i18n.S("value is required")
 	numberTypeError     = i18n.S("value must be a number")
 	numberEnumError     = i18n.S("value must be one of the allowed values")
+	numberFiniteError   = i18n.S("value must be a finite number")
 )
 
 // Default error message functions that take parameters
@@ -22,6 +27,18 @@ func numberMaximumError(max float64) i18n.TranslatedFunc {
 	return i18n.F("value must be at most %g", max)
 }
 
+func numberExclusiveMinimumError(min float64) i18n.TranslatedFunc {
+	return i18n.F("value must be greater than %g", min)
+}
+
+func numberExclusiveMaximumError(max float64) i18n.TranslatedFunc {
+	return i18n.F("value must be less than %g", max)
+}
+
+func numberPrecisionError(places int) i18n.TranslatedFunc {
+	return i18n.F("value must have at most %d decimal places", places)
+}
+
 func numberMultipleOfError(multiple float64) i18n.TranslatedFunc {
 	return i18n.F("value must be a multiple of %g", multiple)
 }
@@ -33,20 +50,30 @@ func numberConstError(value float64) i18n.TranslatedFunc {
 // NumberSchema represents a JSON Schema for float64 values
 type NumberSchema struct {
 	Schema
+	coerce bool // Convert compatible input types before validating
 	// Number-specific validation (private fields)
-	minimum    *float64
-	maximum    *float64
-	multipleOf *float64
-	nullable   bool
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+	precision        *int // Maximum number of decimal places
+	finite           bool // Reject NaN and ±Inf
+	nullable         bool
+	constOverrides   bool // Const and enum values skip the other constraints
 
 	// Error messages for validation failures (support i18n)
-	requiredError     ErrorMessage
-	minimumError      ErrorMessage
-	maximumError      ErrorMessage
-	multipleOfError   ErrorMessage
-	enumError         ErrorMessage
-	constError        ErrorMessage
-	typeMismatchError ErrorMessage
+	requiredError         ErrorMessage
+	minimumError          ErrorMessage
+	maximumError          ErrorMessage
+	exclusiveMinimumError ErrorMessage
+	exclusiveMaximumError ErrorMessage
+	multipleOfError       ErrorMessage
+	precisionError        ErrorMessage
+	finiteError           ErrorMessage
+	enumError             ErrorMessage
+	constError            ErrorMessage
+	typeMismatchError     ErrorMessage
 }
 
 // Number creates a new number schema with optional type error message
@@ -63,34 +90,116 @@ func Number(errorMessage ...interface{}) *NumberSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *NumberSchema) Clone() *NumberSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *NumberSchema) Freeze() *NumberSchema {
+	s.freeze()
+	return s
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *NumberSchema) Title(title string) *NumberSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *NumberSchema) Description(description string) *NumberSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *NumberSchema) ReadOnly() *NumberSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *NumberSchema) WriteOnly() *NumberSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *NumberSchema) Sensitive() *NumberSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *NumberSchema) Deprecated(reason string) *NumberSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *NumberSchema) Meta(key string, value interface{}) *NumberSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *NumberSchema) AsWarning(codes ...ErrorCode) *NumberSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *NumberSchema) AsInfo(codes ...ErrorCode) *NumberSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *NumberSchema) Default(value interface{}) *NumberSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *NumberSchema) DefaultFunc(fn func() interface{}) *NumberSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *NumberSchema) Example(example float64) *NumberSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 // Enum sets the allowed enum values with optional custom error message
 func (s *NumberSchema) Enum(values []float64, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -103,6 +212,7 @@ func (s *NumberSchema) Enum(values []float64, errorMessage ...interface{}) *Numb
 
 // Const sets a constant value with optional custom error message
 func (s *NumberSchema) Const(value float64, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -110,16 +220,27 @@ func (s *NumberSchema) Const(value float64, errorMessage ...interface{}) *Number
 	return s
 }
 
+// ConstOverrides gives the const and enum precedence over the other constraints: a
+// const or enum value is valid even if it fails them, and another value only reports
+// the const or enum error
+func (s *NumberSchema) ConstOverrides(enabled bool) *NumberSchema {
+	s.checkMutable()
+	s.constOverrides = enabled
+	return s
+}
+
 // Required/Optional/Nullable control
 
 // Optional marks the schema as optional
 func (s *NumberSchema) Optional() *NumberSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *NumberSchema) Required(errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -129,12 +250,27 @@ func (s *NumberSchema) Required(errorMessage ...interface{}) *NumberSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *NumberSchema) Nullable() *NumberSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
+// Coerce enables conversion of compatible input types (e.g. strings from query
+// parameters or environment variables) before validation
+func (s *NumberSchema) Coerce() *NumberSchema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *NumberSchema) coerces() bool {
+	return s.coerce
+}
+
 // TypeError sets a custom error message for type mismatch validation
 func (s *NumberSchema) TypeError(message string) *NumberSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -143,6 +279,7 @@ func (s *NumberSchema) TypeError(message string) *NumberSchema {
 
 // Min sets the minimum value constraint with optional custom error message
 func (s *NumberSchema) Min(min float64, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
 	s.minimum = &min
 	if len(errorMessage) > 0 {
 		s.minimumError = toErrorMessage(errorMessage[0])
@@ -152,6 +289,7 @@ func (s *NumberSchema) Min(min float64, errorMessage ...interface{}) *NumberSche
 
 // Max sets the maximum value constraint with optional custom error message
 func (s *NumberSchema) Max(max float64, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
 	s.maximum = &max
 	if len(errorMessage) > 0 {
 		s.maximumError = toErrorMessage(errorMessage[0])
@@ -161,6 +299,7 @@ func (s *NumberSchema) Max(max float64, errorMessage ...interface{}) *NumberSche
 
 // Range sets both minimum and maximum values with optional custom error message
 func (s *NumberSchema) Range(min, max float64, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
 	s.minimum = &min
 	s.maximum = &max
 	if len(errorMessage) > 0 {
@@ -172,6 +311,7 @@ func (s *NumberSchema) Range(min, max float64, errorMessage ...interface{}) *Num
 
 // MultipleOf sets the multiple constraint with optional custom error message
 func (s *NumberSchema) MultipleOf(multiple float64, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
 	s.multipleOf = &multiple
 	if len(errorMessage) > 0 {
 		s.multipleOfError = toErrorMessage(errorMessage[0])
@@ -179,6 +319,63 @@ func (s *NumberSchema) MultipleOf(multiple float64, errorMessage ...interface{})
 	return s
 }
 
+// ExclusiveMin requires the value to be greater than min, with optional custom error message
+func (s *NumberSchema) ExclusiveMin(min float64, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
+	s.exclusiveMinimum = &min
+	if len(errorMessage) > 0 {
+		s.exclusiveMinimumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// ExclusiveMax requires the value to be less than max, with optional custom error message
+func (s *NumberSchema) ExclusiveMax(max float64, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
+	s.exclusiveMaximum = &max
+	if len(errorMessage) > 0 {
+		s.exclusiveMaximumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Positive requires the value to be greater than zero, with optional custom error message
+func (s *NumberSchema) Positive(errorMessage ...interface{}) *NumberSchema {
+	return s.ExclusiveMin(0, errorMessage...)
+}
+
+// Negative requires the value to be less than zero, with optional custom error message
+func (s *NumberSchema) Negative(errorMessage ...interface{}) *NumberSchema {
+	return s.ExclusiveMax(0, errorMessage...)
+}
+
+// NonNegative requires the value to be zero or greater, with optional custom error message
+func (s *NumberSchema) NonNegative(errorMessage ...interface{}) *NumberSchema {
+	return s.Min(0, errorMessage...)
+}
+
+// Precision limits the number of decimal places (2 accepts 9.99 but not 9.999), with
+// optional custom error message. Unlike MultipleOf(0.01) it is not affected by
+// floating point rounding.
+func (s *NumberSchema) Precision(decimalPlaces int, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
+	s.precision = &decimalPlaces
+	if len(errorMessage) > 0 {
+		s.precisionError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Finite rejects NaN and ±Inf, with optional custom error message
+func (s *NumberSchema) Finite(errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
+	s.finite = true
+	if len(errorMessage) > 0 {
+		s.finiteError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
 // Getters for accessing private fields
 
 // IsRequired returns whether the schema is marked as required
@@ -196,6 +393,11 @@ func (s *NumberSchema) IsNullable() bool {
 	return s.nullable
 }
 
+// IsConstOverrides returns whether the const and enum take precedence over the other constraints
+func (s *NumberSchema) IsConstOverrides() bool {
+	return s.constOverrides
+}
+
 // GetMinimum returns the minimum value constraint
 func (s *NumberSchema) GetMinimum() *float64 {
 	return s.minimum
@@ -206,25 +408,74 @@ func (s *NumberSchema) GetMaximum() *float64 {
 	return s.maximum
 }
 
+// GetExclusiveMinimum returns the exclusive minimum constraint
+func (s *NumberSchema) GetExclusiveMinimum() *float64 {
+	return s.exclusiveMinimum
+}
+
+// GetExclusiveMaximum returns the exclusive maximum constraint
+func (s *NumberSchema) GetExclusiveMaximum() *float64 {
+	return s.exclusiveMaximum
+}
+
 // GetMultipleOf returns the multiple constraint
 func (s *NumberSchema) GetMultipleOf() *float64 {
 	return s.multipleOf
 }
 
+// GetPrecision returns the maximum number of decimal places
+func (s *NumberSchema) GetPrecision() *int {
+	return s.precision
+}
+
+// IsFinite returns whether NaN and ±Inf are rejected
+func (s *NumberSchema) IsFinite() bool {
+	return s.finite
+}
+
 // GetDefault returns the default value as a float64
 func (s *NumberSchema) GetDefaultNumber() *float64 {
-	if s.GetDefault() != nil {
-		if f, ok := s.GetDefault().(float64); ok {
-			return &f
-		}
+	if f, ok := s.GetDefault().(float64); ok {
+		return &f
 	}
 	return nil
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *NumberSchema) Transform(fn TransformFunc) *NumberSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *NumberSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *NumberSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *NumberSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses a number value, returning the final parsed value
 func (s *NumberSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the number constraints; Parse runs the refine/transform pipeline on top
+func (s *NumberSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceFloat(value)
+	}
+
 	var errors []ValidationError
 
 	// Handle nil values
@@ -237,7 +488,7 @@ func (s *NumberSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := numberRequiredError(ctx.Locale)
@@ -247,12 +498,12 @@ func (s *NumberSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -296,20 +547,35 @@ func (s *NumberSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
+	// With ConstOverrides, the const and enum alone decide
+	if s.constOverrides && (s.Schema.constVal != nil || len(s.Schema.enum) > 0) {
+		errors = append(errors, s.constEnumErrors(numValue, ctx)...)
+		return ParseResult{Valid: len(errors) == 0, Value: numValue, Errors: errors}
+	}
+
 	// Now validate the number value against all constraints
 	finalValue := numValue // This is our parsed value
 
+	// Check finite
+	if s.finite && (math.IsNaN(numValue) || math.IsInf(numValue, 0)) {
+		message := numberFiniteError(ctx.Locale)
+		if !isEmptyErrorMessage(s.finiteError) {
+			message = resolveErrorMessage(s.finiteError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(numValue, message, CodeNotFinite))
+	}
+
 	// Check minimum
 	if s.minimum != nil && numValue < *s.minimum {
 		message := numberMinimumError(*s.minimum)(ctx.Locale)
 		if !isEmptyErrorMessage(s.minimumError) {
 			message = resolveErrorMessage(s.minimumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(numValue, message, "minimum"))
+		errors = append(errors, NewPrimitiveError(numValue, message, CodeMinimum))
 	}
 
 	// Check maximum
@@ -318,7 +584,34 @@ func (s *NumberSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		if !isEmptyErrorMessage(s.maximumError) {
 			message = resolveErrorMessage(s.maximumError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(numValue, message, "maximum"))
+		errors = append(errors, NewPrimitiveError(numValue, message, CodeMaximum))
+	}
+
+	// Check exclusive minimum
+	if s.exclusiveMinimum != nil && numValue <= *s.exclusiveMinimum {
+		message := numberExclusiveMinimumError(*s.exclusiveMinimum)(ctx.Locale)
+		if !isEmptyErrorMessage(s.exclusiveMinimumError) {
+			message = resolveErrorMessage(s.exclusiveMinimumError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(numValue, message, CodeExclusiveMinimum))
+	}
+
+	// Check exclusive maximum
+	if s.exclusiveMaximum != nil && numValue >= *s.exclusiveMaximum {
+		message := numberExclusiveMaximumError(*s.exclusiveMaximum)(ctx.Locale)
+		if !isEmptyErrorMessage(s.exclusiveMaximumError) {
+			message = resolveErrorMessage(s.exclusiveMaximumError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(numValue, message, CodeExclusiveMaximum))
+	}
+
+	// Check precision
+	if s.precision != nil && decimalPlaces(numValue, 64) > *s.precision {
+		message := numberPrecisionError(*s.precision)(ctx.Locale)
+		if !isEmptyErrorMessage(s.precisionError) {
+			message = resolveErrorMessage(s.precisionError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(numValue, message, CodePrecision))
 	}
 
 	// Check multipleOf (for numbers, we need to handle floating point precision)
@@ -329,10 +622,28 @@ func (s *NumberSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			if !isEmptyErrorMessage(s.multipleOfError) {
 				message = resolveErrorMessage(s.multipleOfError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(numValue, message, "multiple_of"))
+			errors = append(errors, NewPrimitiveError(numValue, message, CodeMultipleOf))
 		}
 	}
 
+	errors = append(errors, s.constEnumErrors(numValue, ctx)...)
+
+	// Return the input itself when it is unchanged, which saves boxing the number again
+	parsed := value
+	if original, ok := value.(float64); !ok || original != finalValue {
+		parsed = finalValue
+	}
+	return ParseResult{
+		Valid:  len(errors) == 0,
+		Value:  parsed,
+		Errors: errors,
+	}
+}
+
+// constEnumErrors checks the value against the enum and const
+func (s *NumberSchema) constEnumErrors(numValue float64, ctx *ValidationContext) []ValidationError {
+	var errors []ValidationError
+
 	// Check enum
 	if len(s.Schema.enum) > 0 {
 		valid := false
@@ -347,7 +658,7 @@ func (s *NumberSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(numValue, message, "enum"))
+			errors = append(errors, NewPrimitiveError(numValue, message, CodeEnum))
 		}
 	}
 
@@ -358,15 +669,37 @@ func (s *NumberSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			if !isEmptyErrorMessage(s.constError) {
 				message = resolveErrorMessage(s.constError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(numValue, message, "const"))
+			errors = append(errors, NewPrimitiveError(numValue, message, CodeConst))
 		}
 	}
-
-	return ParseResult{
-		Valid:  len(errors) == 0,
-		Value:  finalValue,
-		Errors: errors,
+	return errors
+}
+
+// Validate reports the constraints that contradict each other, so that values are
+// rejected whatever they are: bounds no number is within, a multipleOf that is not positive, or a const or enum value that
+// fails the other constraints (unless ConstOverrides is set) or a const outside the
+// enum. It returns nil for a consistent schema.
+func (s *NumberSchema) Validate() error {
+	var issues []error
+	lower, lowerExclusive := s.minimum, false
+	if s.exclusiveMinimum != nil && (lower == nil || *s.exclusiveMinimum >= *lower) {
+		lower, lowerExclusive = s.exclusiveMinimum, true
+	}
+	upper, upperExclusive := s.maximum, false
+	if s.exclusiveMaximum != nil && (upper == nil || *s.exclusiveMaximum <= *upper) {
+		upper, upperExclusive = s.exclusiveMaximum, true
+	}
+	if lower != nil && upper != nil && (*lower > *upper || *lower == *upper && (lowerExclusive || upperExclusive)) {
+		issues = append(issues, contradiction("no number is within the bounds %v and %v", *lower, *upper))
+	}
+	if s.multipleOf != nil && *s.multipleOf <= 0 {
+		issues = append(issues, contradiction("multipleOf %v is not positive", *s.multipleOf))
 	}
+
+	without := s.Clone()
+	without.Schema.constVal, without.Schema.enum, without.Schema.effects = nil, nil, nil
+	issues = append(issues, constEnumContradictions(s.Schema.constVal, s.Schema.enum, s.constOverrides, without)...)
+	return errors.Join(issues...)
 }
 
 // JSON generates JSON Schema representation
@@ -376,7 +709,8 @@ func (s *NumberSchema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -384,6 +718,8 @@ func (s *NumberSchema) JSON() map[string]interface{} {
 	// Add number-specific fields
 	addOptionalField(schema, "minimum", s.minimum)
 	addOptionalField(schema, "maximum", s.maximum)
+	addOptionalField(schema, "exclusiveMinimum", s.exclusiveMinimum)
+	addOptionalField(schema, "exclusiveMaximum", s.exclusiveMaximum)
 	addOptionalField(schema, "multipleOf", s.multipleOf)
 
 	// Add nullable if true
@@ -398,21 +734,35 @@ func (s *NumberSchema) JSON() map[string]interface{} {
 func (s *NumberSchema) MarshalJSON() ([]byte, error) {
 	type jsonNumberSchema struct {
 		Schema
-		Minimum    *float64 `json:"minimum,omitempty"`
-		Maximum    *float64 `json:"maximum,omitempty"`
-		MultipleOf *float64 `json:"multipleOf,omitempty"`
-		Nullable   bool     `json:"nullable,omitempty"`
+		Minimum          *float64 `json:"minimum,omitempty"`
+		Maximum          *float64 `json:"maximum,omitempty"`
+		ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+		MultipleOf       *float64 `json:"multipleOf,omitempty"`
+		Nullable         bool     `json:"nullable,omitempty"`
 	}
 
 	return json.Marshal(jsonNumberSchema{
-		Schema:     s.Schema,
-		Minimum:    s.minimum,
-		Maximum:    s.maximum,
-		MultipleOf: s.multipleOf,
-		Nullable:   s.nullable,
+		Schema:           s.Schema,
+		Minimum:          s.minimum,
+		Maximum:          s.maximum,
+		ExclusiveMinimum: s.exclusiveMinimum,
+		ExclusiveMaximum: s.exclusiveMaximum,
+		MultipleOf:       s.multipleOf,
+		Nullable:         s.nullable,
 	})
 }
 
+// decimalPlaces counts the digits after the decimal point in the shortest
+// representation of v at the given bit size (32 or 64)
+func decimalPlaces(v float64, bitSize int) int {
+	text := strconv.FormatFloat(v, 'f', -1, bitSize)
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		return len(text) - i - 1
+	}
+	return 0
+}
+
 // Interface implementations for NumberSchema
 
 // SetTitle implements SetTitle interface
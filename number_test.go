@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNumberSchema_Constraints(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *NumberSchema
+		value    interface{}
+		expected bool
+		code     ErrorCode
+	}{
+		{"exclusive min above", Number().ExclusiveMin(0), 0.001, true, ""},
+		{"exclusive min boundary", Number().ExclusiveMin(0), 0.0, false, "exclusive_minimum"},
+		{"exclusive max below", Number().ExclusiveMax(1), 0.999, true, ""},
+		{"exclusive max boundary", Number().ExclusiveMax(1), 1, false, "exclusive_maximum"},
+		{"positive", Number().Positive(), 3.5, true, ""},
+		{"positive zero", Number().Positive(), 0, false, "exclusive_minimum"},
+		{"negative", Number().Negative(), -0.5, true, ""},
+		{"negative zero", Number().Negative(), 0.0, false, "exclusive_maximum"},
+		{"non-negative zero", Number().NonNegative(), 0.0, true, ""},
+		{"non-negative below", Number().NonNegative(), -0.1, false, "minimum"},
+		{"precision exact", Number().Precision(2), 9.99, true, ""},
+		{"precision fewer", Number().Precision(2), 10.5, true, ""},
+		{"precision integer", Number().Precision(0), 42, true, ""},
+		{"precision exceeded", Number().Precision(2), 9.999, false, "precision"},
+		{"precision rounding error", Number().Precision(2), 0.30000000000000004, false, "precision"},
+		{"finite", Number().Finite(), 1e308, true, ""},
+		{"finite NaN", Number().Finite(), math.NaN(), false, "not_finite"},
+		{"finite +Inf", Number().Finite(), math.Inf(1), false, "not_finite"},
+		{"finite -Inf", Number().Finite(), math.Inf(-1), false, "not_finite"},
+		{"infinite allowed by default", Number(), math.Inf(1), true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Number.Parse(%v) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestFloatSchema_Constraints(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *FloatSchema
+		value    interface{}
+		expected bool
+		code     ErrorCode
+	}{
+		{"exclusive min boundary", Float().ExclusiveMin(1.5), float32(1.5), false, "exclusive_minimum"},
+		{"exclusive max below", Float().ExclusiveMax(1.5), 1.25, true, ""},
+		{"positive", Float().Positive(), 1, true, ""},
+		{"negative zero", Float().Negative(), 0, false, "exclusive_maximum"},
+		{"non-negative below", Float().NonNegative(), -1, false, "minimum"},
+		{"precision float32", Float().Precision(2), float32(0.1), true, ""},
+		{"precision exceeded", Float().Precision(1), 0.25, false, "precision"},
+		{"finite NaN", Float().Finite(), float32(math.NaN()), false, "not_finite"},
+		{"finite +Inf", Float().Finite(), float32(math.Inf(1)), false, "not_finite"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Float.Parse(%v) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestNumberSchema_ExclusiveJSON(t *testing.T) {
+	doc := Number().ExclusiveMin(0).ExclusiveMax(100).JSON()
+	if doc["exclusiveMinimum"] != 0.0 || doc["exclusiveMaximum"] != 100.0 {
+		t.Errorf("JSON() = %v", doc)
+	}
+	if doc := Float().Positive().JSON(); doc["exclusiveMinimum"] != float32(0) {
+		t.Errorf("Float JSON() = %v", doc)
+	}
+
+	// Exclusive bounds survive a round trip through CompileJSONSchema
+	compiled, err := CompileJSONSchema([]byte(`{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := compiled.(*NumberSchema).JSON()
+	want := map[string]interface{}{"type": "number", "exclusiveMinimum": 0.0, "exclusiveMaximum": 1.0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("compiled JSON() = %v, want %v", got, want)
+	}
+}
@@ -1,9 +1,12 @@
 package schema
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/nyxstack/i18n"
 )
@@ -56,14 +59,32 @@ type ObjectSchema struct {
 	minProps        *int                      // Minimum number of properties
 	maxProps        *int                      // Maximum number of properties
 	nullable        bool                      // Allow null values
+	collectPartial  bool                      // Return the valid properties when others fail
+	stripUnknown    bool                      // Drop additional properties without errors
+	skipDefaults    bool                      // Leave missing properties out of the parsed value, even with a default
+	patternProps    []objectPatternProperty   // Schemas for the properties whose names match a pattern
+	propertyNames   Parseable                 // Schema every property name must satisfy
+	onUnknownKey    func(key string, value interface{})
+
+	// Input key mapping
+	aliases      map[string]string // Property name for each accepted alias
+	keyTransform KeyTransformer    // Renames input keys that are not property names
+
+	// Cross-field rules
+	dependentRequired map[string][]string // Properties required when a key is present
+	conditions        []objectCondition   // Requirements that depend on a property value
+
+	deletable map[string]bool // Properties a merge patch may set to null (nil unless PatchSchema)
 
 	// Error messages for validation failures (support i18n)
-	requiredError        ErrorMessage
-	minPropsError        ErrorMessage
-	maxPropsError        ErrorMessage
-	additionalPropsError ErrorMessage
-	propertyError        ErrorMessage
-	typeMismatchError    ErrorMessage
+	requiredError          ErrorMessage
+	minPropsError          ErrorMessage
+	maxPropsError          ErrorMessage
+	additionalPropsError   ErrorMessage
+	propertyError          ErrorMessage
+	typeMismatchError      ErrorMessage
+	dependentRequiredError ErrorMessage
+	propertyNamesError     ErrorMessage
 }
 
 // Object creates a new object schema with optional Shape and error message
@@ -95,28 +116,151 @@ func Object(shapeAndError ...interface{}) *ObjectSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Property schemas are shared.
+func (s *ObjectSchema) Clone() *ObjectSchema {
+	return s.clone()
+}
+
+// Freeze makes the schema and every property schema immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *ObjectSchema) Freeze() *ObjectSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and the schemas of its properties, pattern properties,
+// property names and conditions
+func (s *ObjectSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	for _, prop := range s.properties {
+		freezeSchemas(prop.Schema)
+	}
+	for _, prop := range s.patternProps {
+		freezeSchemas(prop.schema)
+	}
+	freezeSchemas(s.propertyNames)
+	for _, rule := range s.conditions {
+		freezeSchemas(rule.condition)
+	}
+}
+
+// Children returns the schemas of the properties, in name order, then those of the
+// pattern properties, property names and conditions
+func (s *ObjectSchema) Children() []SchemaChild {
+	names := make([]string, 0, len(s.properties))
+	for name := range s.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	children := make([]SchemaChild, 0, len(names)+len(s.patternProps)+len(s.conditions)+1)
+	for _, name := range names {
+		children = append(children, SchemaChild{Segment: name, Schema: s.properties[name].Schema})
+	}
+	for _, prop := range s.patternProps {
+		children = append(children, SchemaChild{Segment: "/" + prop.pattern + "/", Schema: prop.schema})
+	}
+	if s.propertyNames != nil {
+		children = append(children, SchemaChild{Segment: KeySegment, Schema: s.propertyNames})
+	}
+	for _, rule := range s.conditions {
+		children = append(children, SchemaChild{Segment: rule.property, Schema: rule.condition})
+	}
+	return children
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *ObjectSchema) Title(title string) *ObjectSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *ObjectSchema) Description(description string) *ObjectSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *ObjectSchema) ReadOnly() *ObjectSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *ObjectSchema) WriteOnly() *ObjectSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *ObjectSchema) Sensitive() *ObjectSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *ObjectSchema) Deprecated(reason string) *ObjectSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *ObjectSchema) Meta(key string, value interface{}) *ObjectSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *ObjectSchema) AsWarning(codes ...ErrorCode) *ObjectSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *ObjectSchema) AsInfo(codes ...ErrorCode) *ObjectSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *ObjectSchema) Default(value interface{}) *ObjectSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *ObjectSchema) DefaultFunc(fn func() interface{}) *ObjectSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *ObjectSchema) Example(example map[string]interface{}) *ObjectSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
@@ -125,6 +269,7 @@ func (s *ObjectSchema) Example(example map[string]interface{}) *ObjectSchema {
 
 // Property adds a property to the object schema (infers required/optional from schema)
 func (s *ObjectSchema) Property(name string, schema interface{}) *ObjectSchema {
+	s.checkMutable()
 	// Convert to Parseable interface
 	var parseable Parseable
 	if p, ok := schema.(Parseable); ok {
@@ -160,6 +305,7 @@ func (s *ObjectSchema) Property(name string, schema interface{}) *ObjectSchema {
 
 // OptionalProperty explicitly adds an optional property
 func (s *ObjectSchema) OptionalProperty(name string, schema interface{}) *ObjectSchema {
+	s.checkMutable()
 	var parseable Parseable
 	if p, ok := schema.(Parseable); ok {
 		parseable = p
@@ -177,6 +323,7 @@ func (s *ObjectSchema) OptionalProperty(name string, schema interface{}) *Object
 
 // RequiredProperty explicitly adds a required property
 func (s *ObjectSchema) RequiredProperty(name string, schema interface{}) *ObjectSchema {
+	s.checkMutable()
 	var parseable Parseable
 	if p, ok := schema.(Parseable); ok {
 		parseable = p
@@ -204,6 +351,7 @@ func (s *ObjectSchema) RequiredProperty(name string, schema interface{}) *Object
 
 // MinProperties sets the minimum number of properties with optional custom error message
 func (s *ObjectSchema) MinProperties(min int, errorMessage ...interface{}) *ObjectSchema {
+	s.checkMutable()
 	s.minProps = &min
 	if len(errorMessage) > 0 {
 		s.minPropsError = toErrorMessage(errorMessage[0])
@@ -213,6 +361,7 @@ func (s *ObjectSchema) MinProperties(min int, errorMessage ...interface{}) *Obje
 
 // MaxProperties sets the maximum number of properties with optional custom error message
 func (s *ObjectSchema) MaxProperties(max int, errorMessage ...interface{}) *ObjectSchema {
+	s.checkMutable()
 	s.maxProps = &max
 	if len(errorMessage) > 0 {
 		s.maxPropsError = toErrorMessage(errorMessage[0])
@@ -222,6 +371,7 @@ func (s *ObjectSchema) MaxProperties(max int, errorMessage ...interface{}) *Obje
 
 // PropertyRange sets both min and max property constraints
 func (s *ObjectSchema) PropertyRange(min, max int, errorMessage ...interface{}) *ObjectSchema {
+	s.checkMutable()
 	s.minProps = &min
 	s.maxProps = &max
 	if len(errorMessage) > 0 {
@@ -233,35 +383,236 @@ func (s *ObjectSchema) PropertyRange(min, max int, errorMessage ...interface{})
 
 // Strict disallows additional properties (default behavior)
 func (s *ObjectSchema) Strict() *ObjectSchema {
+	s.checkMutable()
 	s.additionalProps = false
+	s.stripUnknown = false
 	return s
 }
 
 // Passthrough allows additional properties
 func (s *ObjectSchema) Passthrough() *ObjectSchema {
+	s.checkMutable()
 	s.additionalProps = true
+	s.stripUnknown = false
+	return s
+}
+
+// Strip accepts additional properties but drops them from the parsed value
+func (s *ObjectSchema) Strip() *ObjectSchema {
+	s.checkMutable()
+	s.additionalProps = false
+	s.stripUnknown = true
+	return s
+}
+
+// OnUnknownKey sets a callback invoked for each additional property in the input,
+// whether it is rejected, kept or stripped (e.g. to log clients sending stale fields)
+func (s *ObjectSchema) OnUnknownKey(fn func(key string, value interface{})) *ObjectSchema {
+	s.checkMutable()
+	s.onUnknownKey = fn
 	return s
 }
 
 // AdditionalProperties sets whether additional properties are allowed with optional custom error message
 func (s *ObjectSchema) AdditionalProperties(allowed bool, errorMessage ...interface{}) *ObjectSchema {
+	s.checkMutable()
 	s.additionalProps = allowed
+	s.stripUnknown = false
 	if !allowed && len(errorMessage) > 0 {
 		s.additionalPropsError = toErrorMessage(errorMessage[0])
 	}
 	return s
 }
 
+// CollectPartial makes Parse return the successfully parsed properties in
+// ParseResult.Value even when other properties fail, with ParseResult.PartialOK set.
+// Nested objects that also collect partial results contribute their valid properties.
+func (s *ObjectSchema) CollectPartial() *ObjectSchema {
+	s.checkMutable()
+	s.collectPartial = true
+	return s
+}
+
+// FillDefaults sets whether the missing properties that have a default are added to
+// the parsed value with their default (the default behavior). A missing required
+// property with a default is then not reported as missing. With FillDefaults(false),
+// missing properties are left out of the parsed value, and missing required properties
+// are reported whatever their default.
+func (s *ObjectSchema) FillDefaults(enabled bool) *ObjectSchema {
+	s.checkMutable()
+	s.skipDefaults = !enabled
+	return s
+}
+
+// Derivation methods - these return a new schema and leave the receiver unchanged
+
+// Pick returns a copy of the schema containing only the named properties
+func (s *ObjectSchema) Pick(names ...string) *ObjectSchema {
+	keep := make(map[string]bool, len(names))
+	for _, name := range names {
+		keep[name] = true
+	}
+	return s.filterProperties(func(name string) bool { return keep[name] })
+}
+
+// Omit returns a copy of the schema without the named properties
+func (s *ObjectSchema) Omit(names ...string) *ObjectSchema {
+	drop := make(map[string]bool, len(names))
+	for _, name := range names {
+		drop[name] = true
+	}
+	return s.filterProperties(func(name string) bool { return !drop[name] })
+}
+
+// Partial returns a copy of the schema in which every property is optional. Missing
+// properties are not filled with their default, in nested objects either, since a
+// partial value only carries the properties it updates.
+func (s *ObjectSchema) Partial() *ObjectSchema {
+	result := s.withoutDefaults()
+	for name, prop := range result.properties {
+		prop.Required = false
+		result.properties[name] = prop
+	}
+	result.requiredProps = []string{}
+	return result
+}
+
+// RequiredAll returns a copy of the schema in which every property is required
+func (s *ObjectSchema) RequiredAll() *ObjectSchema {
+	result := s.clone()
+	for _, name := range sortedPropertyNames(result.properties) {
+		prop := result.properties[name]
+		if !prop.Required {
+			prop.Required = true
+			result.properties[name] = prop
+			result.requiredProps = append(result.requiredProps, name)
+		}
+	}
+	return result
+}
+
+// ForWrite returns a copy of the schema without its read-only properties, for
+// validating request bodies. Nested objects, also as array items, are derived too.
+func (s *ObjectSchema) ForWrite() *ObjectSchema {
+	return s.forView(func(schema Parseable) bool {
+		annotated, ok := schema.(interface{ IsReadOnly() bool })
+		return ok && annotated.IsReadOnly()
+	})
+}
+
+// ForRead returns a copy of the schema without its write-only properties, for
+// documenting and checking responses. Nested objects, also as array items, are derived too.
+func (s *ObjectSchema) ForRead() *ObjectSchema {
+	return s.forView(func(schema Parseable) bool {
+		annotated, ok := schema.(interface{ IsWriteOnly() bool })
+		return ok && annotated.IsWriteOnly()
+	})
+}
+
+// forView returns a copy of the schema without the properties for which drop returns true
+func (s *ObjectSchema) forView(drop func(schema Parseable) bool) *ObjectSchema {
+	result := s.filterProperties(func(name string) bool {
+		prop, defined := s.properties[name]
+		return !defined || !drop(prop.Schema)
+	})
+	for name, prop := range result.properties {
+		prop.Schema = viewOf(prop.Schema, drop)
+		result.properties[name] = prop
+	}
+	return result
+}
+
+// viewOf derives the view of nested object schemas, directly or as array items
+func viewOf(schema Parseable, drop func(schema Parseable) bool) Parseable {
+	switch v := schema.(type) {
+	case *ObjectSchema:
+		return v.forView(drop)
+	case *ArraySchema:
+		if items := viewOf(v.itemSchema, drop); items != v.itemSchema {
+			c := v.Clone()
+			c.itemSchema = items
+			return c
+		}
+	}
+	return schema
+}
+
+// filterProperties returns a copy of the schema with the properties for which keep returns true
+func (s *ObjectSchema) filterProperties(keep func(name string) bool) *ObjectSchema {
+	result := s.clone()
+	for name := range result.properties {
+		if !keep(name) {
+			delete(result.properties, name)
+		}
+	}
+	required := make([]string, 0, len(result.requiredProps))
+	for _, name := range result.requiredProps {
+		if keep(name) {
+			required = append(required, name)
+		}
+	}
+	result.requiredProps = required
+
+	// Drop the rules that refer to removed properties
+	dependencies := make(map[string][]string, len(result.dependentRequired))
+	for key, names := range result.dependentRequired {
+		if keep(key) {
+			dependencies[key] = filterNames(names, keep)
+		}
+	}
+	result.dependentRequired = dependencies
+	conditions := make([]objectCondition, 0, len(result.conditions))
+	for _, rule := range result.conditions {
+		if keep(rule.property) {
+			rule.thenRequired = filterNames(rule.thenRequired, keep)
+			rule.elseRequired = filterNames(rule.elseRequired, keep)
+			conditions = append(conditions, rule)
+		}
+	}
+	result.conditions = conditions
+	aliases := make(map[string]string, len(result.aliases))
+	for alias, name := range result.aliases {
+		if keep(name) {
+			aliases[alias] = name
+		}
+	}
+	result.aliases = aliases
+	return result
+}
+
+// filterNames returns the names for which keep returns true
+func filterNames(names []string, keep func(name string) bool) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if keep(name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// sortedPropertyNames returns the property names in a deterministic order
+func sortedPropertyNames(properties map[string]ObjectProperty) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Required/Optional/Nullable control
 
 // Optional marks the schema as optional
 func (s *ObjectSchema) Optional() *ObjectSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *ObjectSchema) Required(errorMessage ...interface{}) *ObjectSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -271,6 +622,7 @@ func (s *ObjectSchema) Required(errorMessage ...interface{}) *ObjectSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *ObjectSchema) Nullable() *ObjectSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
@@ -279,12 +631,14 @@ func (s *ObjectSchema) Nullable() *ObjectSchema {
 
 // TypeError sets a custom error message for type mismatch validation
 func (s *ObjectSchema) TypeError(message string) *ObjectSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
 
 // PropertyError sets a custom error prefix for property validation errors
 func (s *ObjectSchema) PropertyError(message string) *ObjectSchema {
+	s.checkMutable()
 	s.propertyError = toErrorMessage(message)
 	return s
 }
@@ -306,6 +660,11 @@ func (s *ObjectSchema) IsNullable() bool {
 	return s.nullable
 }
 
+// IsFillDefaults returns whether the missing properties are added with their default
+func (s *ObjectSchema) IsFillDefaults() bool {
+	return !s.skipDefaults
+}
+
 // GetProperties returns the object properties
 func (s *ObjectSchema) GetProperties() map[string]ObjectProperty {
 	return s.properties
@@ -321,6 +680,16 @@ func (s *ObjectSchema) AllowsAdditionalProperties() bool {
 	return s.additionalProps
 }
 
+// StripsUnknown returns whether additional properties are dropped from the parsed value
+func (s *ObjectSchema) StripsUnknown() bool {
+	return s.stripUnknown
+}
+
+// CollectsPartial returns whether Parse returns partial results for invalid objects
+func (s *ObjectSchema) CollectsPartial() bool {
+	return s.collectPartial
+}
+
 // GetMinProperties returns the minimum number of properties
 func (s *ObjectSchema) GetMinProperties() *int {
 	return s.minProps
@@ -333,10 +702,16 @@ func (s *ObjectSchema) GetMaxProperties() *int {
 
 // Helper methods for converting input to map[string]interface{}
 
-// convertToMap converts various input types to map[string]interface{}
-func convertToMap(value interface{}) (map[string]interface{}, bool) {
+// convertToMap converts various input types to map[string]interface{}. Struct fields
+// are named by the first of tagNames that names them (the json tag when none is given).
+func convertToMap(value interface{}, tagNames ...string) (map[string]interface{}, bool) {
+	// Decoded JSON needs no conversion; the map is only read
+	if m, ok := value.(map[string]interface{}); ok {
+		return m, true
+	}
+
 	v := reflect.ValueOf(value)
-	if v.Kind() == reflect.Ptr {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
 		v = v.Elem()
 	}
 
@@ -351,42 +726,255 @@ func convertToMap(value interface{}) (map[string]interface{}, bool) {
 		return result, true
 
 	case reflect.Struct:
-		// Convert struct to map[string]interface{}
+		// Structs with their own JSON encoding are validated as that encoding
+		if m, ok := marshaledObject(v); ok {
+			return m, true
+		}
+		if len(tagNames) == 0 {
+			tagNames = defaultTagNames
+		}
 		result := make(map[string]interface{})
-		structType := v.Type()
-		for i := 0; i < v.NumField(); i++ {
-			field := structType.Field(i)
-			if !field.IsExported() {
-				continue // Skip unexported fields
+		structToMap(v, tagNames, result)
+		return result, true
+
+	default:
+		return nil, false
+	}
+}
+
+// defaultTagNames names struct fields when the validation context sets no tag names
+var defaultTagNames = []string{"json"}
+
+// structToMap adds the exported fields of a struct to result as encoding/json would
+// encode them: fields tagged "-" are skipped, empty "omitempty" and zero "omitzero"
+// fields are left out, and the fields of embedded structs are promoted unless the
+// struct already has a field with the same name.
+func structToMap(v reflect.Value, tagNames []string, result map[string]interface{}) {
+	var embedded []reflect.Value
+	structType := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := structType.Field(i)
+		tag := structFieldTag(field, tagNames)
+		if tag.skip {
+			continue
+		}
+
+		fieldValue := v.Field(i)
+		if (field.Anonymous && tag.name == "") || tag.inline {
+			inner := fieldValue
+			for inner.Kind() == reflect.Ptr && !inner.IsNil() {
+				inner = inner.Elem()
+			}
+			if inner.Kind() == reflect.Struct {
+				embedded = append(embedded, inner)
+				continue
+			}
+			if inner.Kind() == reflect.Ptr && isStructPointer(inner.Type()) {
+				continue // Nil embedded struct pointer: its fields are absent
 			}
+		}
+		if !field.IsExported() {
+			continue
+		}
 
-			// Use json tag if available, otherwise use field name
-			fieldName := field.Name
-			if tag := field.Tag.Get("json"); tag != "" && tag != "-" {
-				// Handle "fieldname,omitempty" format
-				commaIdx := len(tag)
-				for idx := 0; idx < len(tag); idx++ {
-					if tag[idx] == ',' {
-						commaIdx = idx
-						break
-					}
-				}
-				fieldName = tag[:commaIdx]
+		name := tag.name
+		if name == "" {
+			name = field.Name
+		}
+		if (tag.omitEmpty && isEmptyValue(fieldValue)) || (tag.omitZero && isZeroValue(fieldValue)) {
+			continue
+		}
+		result[name] = structFieldValue(fieldValue)
+	}
+
+	// Promoted fields never replace the fields of the struct itself
+	for _, inner := range embedded {
+		promoted := make(map[string]interface{})
+		structToMap(inner, tagNames, promoted)
+		for name, value := range promoted {
+			if _, ok := result[name]; !ok {
+				result[name] = value
 			}
+		}
+	}
+}
+
+// structTag holds the naming and omission options of a struct field
+type structTag struct {
+	name      string
+	skip      bool
+	omitEmpty bool
+	omitZero  bool
+	inline    bool
+}
 
-			result[fieldName] = v.Field(i).Interface()
+// structFieldTag reads the options of a field from the first of tagNames present on
+// it. The schema tag names fields with its name option (`schema:"name=email"`), the
+// others with their first comma-separated element (`yaml:"email,omitempty"`).
+func structFieldTag(field reflect.StructField, tagNames []string) structTag {
+	for _, tagName := range tagNames {
+		raw, ok := field.Tag.Lookup(tagName)
+		if !ok {
+			continue
+		}
+		if raw == "-" {
+			return structTag{skip: true}
+		}
+		if tagName == structTagName {
+			opts := parseStructTag(raw)
+			if opts.skip || opts.name == "" {
+				continue
+			}
+			return structTag{name: opts.name}
 		}
-		return result, true
 
-	default:
+		name, options, _ := strings.Cut(raw, ",")
+		tag := structTag{name: name}
+		for _, option := range strings.Split(options, ",") {
+			switch option {
+			case "omitempty":
+				tag.omitEmpty = true
+			case "omitzero":
+				tag.omitZero = true
+			case "inline":
+				tag.inline = true
+			}
+		}
+		return tag
+	}
+	return structTag{}
+}
+
+// structFieldValue returns the value of a struct field as it is validated: nil
+// pointers and interfaces are absent, and values with their own JSON or text encoding
+// (time.Time, custom IDs, ...) are replaced by that encoding
+func structFieldValue(v reflect.Value) interface{} {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil
+	}
+	if encoded, ok := marshaledValue(v); ok {
+		return encoded
+	}
+	return v.Interface()
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// marshaledValue encodes a value implementing json.Marshaler or encoding.TextMarshaler
+// (with a value or addressable pointer receiver) and decodes the result, reporting
+// false for other values and encodings that fail
+func marshaledValue(v reflect.Value) (interface{}, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+	if !v.Type().Implements(jsonMarshalerType) && !v.Type().Implements(textMarshalerType) && v.CanAddr() {
+		v = v.Addr()
+	}
+
+	if v.Type().Implements(jsonMarshalerType) {
+		data, err := v.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return nil, false
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return nil, false
+		}
+		return decoded, true
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, false
+		}
+		return string(text), true
+	}
+	return nil, false
+}
+
+// marshaledObject returns the encoding of a value with its own JSON encoding when
+// that encoding is an object
+func marshaledObject(v reflect.Value) (map[string]interface{}, bool) {
+	encoded, ok := marshaledValue(v)
+	if !ok {
 		return nil, false
 	}
+	m, ok := encoded.(map[string]interface{})
+	return m, ok
+}
+
+// isStructPointer reports whether t is a (possibly nested) pointer to a struct
+func isStructPointer(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// isEmptyValue reports whether an "omitempty" field is left out, as with encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// isZeroValue reports whether an "omitzero" field is left out: its IsZero method
+// reports true, or it is the zero value of its type
+func isZeroValue(v reflect.Value) bool {
+	if zeroer, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			return true
+		}
+		return zeroer.IsZero()
+	}
+	return v.IsZero()
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *ObjectSchema) Transform(fn TransformFunc) *ObjectSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *ObjectSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *ObjectSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *ObjectSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *ObjectSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
 }
 
 // Validation
 
 // Parse validates and parses an object value, returning the final parsed value
 func (s *ObjectSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the object constraints; Parse runs the refine/transform pipeline on top
+func (s *ObjectSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
 	// Handle nil values
@@ -399,7 +987,7 @@ func (s *ObjectSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := objectRequiredError(ctx.Locale)
@@ -409,19 +997,19 @@ func (s *ObjectSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
 	}
 
 	// Type check and convert to map
-	objectMap, ok := convertToMap(value)
+	objectMap, ok := convertToMap(value, ctx.TagNames...)
 	if !ok {
 		message := objectTypeError(ctx.Locale)
 		if !isEmptyErrorMessage(s.typeMismatchError) {
@@ -430,12 +1018,30 @@ func (s *ObjectSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
-	// Now validate the object against all constraints
-	finalValue := make(map[string]interface{}, len(objectMap)) // This will be our parsed object
+	// Reject oversized and too deeply nested input before looking at its properties
+	if err, exceeded := ctx.checkCollectionSize(objectMap, len(objectMap)); exceeded {
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{err}}
+	}
+	child, ok := ctx.descend(objectMap)
+	if !ok {
+		return ctx.depthExceeded(objectMap)
+	}
+	defer child.release()
+
+	// Rename aliased and transformed keys to their property names
+	objectMap, errors = s.normalizeKeys(objectMap, ctx)
+
+	// Now validate the object against all constraints, building the parsed object
+	// unless only validity is needed
+	keepValue := ctx.keepValues()
+	var finalValue map[string]interface{}
+	if keepValue {
+		finalValue = make(map[string]interface{}, len(objectMap))
+	}
 
 	// Validate property count constraints
 	propCount := len(objectMap)
@@ -444,7 +1050,7 @@ func (s *ObjectSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		if !isEmptyErrorMessage(s.minPropsError) {
 			message = resolveErrorMessage(s.minPropsError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(objectMap, message, "min_properties"))
+		errors = append(errors, NewPrimitiveError(objectMap, message, CodeMinProperties))
 	}
 
 	if s.maxProps != nil && propCount > *s.maxProps {
@@ -452,71 +1058,191 @@ func (s *ObjectSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		if !isEmptyErrorMessage(s.maxPropsError) {
 			message = resolveErrorMessage(s.maxPropsError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(objectMap, message, "max_properties"))
+		errors = append(errors, NewPrimitiveError(objectMap, message, CodeMaxProperties))
 	}
 
 	// Check required properties
 	for _, requiredProp := range s.requiredProps {
-		if _, exists := objectMap[requiredProp]; !exists {
+		if _, exists := objectMap[requiredProp]; !exists && !s.fillsDefault(requiredProp) {
 			message := objectRequiredPropError(requiredProp)(ctx.Locale)
-			errors = append(errors, NewFieldError([]string{requiredProp}, "<missing>", message, "required"))
+			errors = append(errors, NewFieldError(Path{FieldSegment(requiredProp)}, "<missing>", message, CodeRequired))
 		}
 	}
 
-	// Validate each property
-	for propName, propValue := range objectMap {
-		// Check if property is defined in schema
-		propSchema, isDefined := s.properties[propName]
-		if !isDefined {
+	// Check the properties required by other properties
+	errors = s.validateDependencies(objectMap, errors, ctx)
+
+	// Report whether each property is absent, null or set
+	var present map[string]Presence
+	if ctx.TrackPresence {
+		present = make(map[string]Presence, len(s.properties))
+		for name := range s.properties {
+			present[name] = presenceOf(objectMap, name)
+		}
+	}
+
+	// Validate each property, collecting the warnings of the property values
+	var warnings []ValidationError
+	validateProperty := func(propName string, propValue interface{}) {
+		if err, exceeded := ctx.checkStringLength(propName, FieldSegment(propName)); exceeded {
+			errors = append(errors, err)
+			return
+		}
+		if err, exceeded := ctx.checkStringLength(propValue, FieldSegment(propName)); exceeded {
+			errors = append(errors, err)
+			return
+		}
+		// Check the property name against PropertyNames
+		if nameErrors := s.validatePropertyName(propName, ctx); len(nameErrors) > 0 {
+			errors = append(errors, nameErrors...)
+			return
+		}
+
+		// Collect the schemas of the property: its definition, then matching patterns
+		var schemaBuffer [2]Parseable
+		propSchemas := schemaBuffer[:0]
+		if propSchema, isDefined := s.properties[propName]; isDefined {
+			propSchemas = append(propSchemas, propSchema.Schema)
+		}
+		propSchemas = append(propSchemas, s.matchingPatternSchemas(propName)...)
+		if len(propSchemas) == 0 {
+			if s.onUnknownKey != nil && !ctx.structural {
+				s.onUnknownKey(propName, propValue)
+			}
+			if s.stripUnknown {
+				return
+			}
 			if !s.additionalProps {
 				message := objectAdditionalPropsError(ctx.Locale)
 				if !isEmptyErrorMessage(s.additionalPropsError) {
 					message = resolveErrorMessage(s.additionalPropsError, ctx)
 				}
-				errors = append(errors, NewFieldError([]string{propName}, propValue, message, "additional_property"))
-			} else {
+				errors = append(errors, NewFieldError(Path{FieldSegment(propName)}, propValue, message, CodeAdditionalProperty))
+			} else if keepValue {
 				// Additional property allowed, use as-is
 				finalValue[propName] = propValue
 			}
-			continue
+			return
+		}
+
+		// Report the use of a deprecated property
+		if deprecated, ok := propSchemas[0].(interface {
+			IsDeprecated() bool
+			GetDeprecationReason() string
+		}); ok && deprecated.IsDeprecated() {
+			message := objectDeprecatedPropError(propName)(ctx.Locale)
+			if reason := deprecated.GetDeprecationReason(); reason != "" {
+				message += ": " + reason
+			}
+			warning := NewFieldError(Path{FieldSegment(propName)}, propValue, message, CodeDeprecated)
+			warning.Severity = SeverityWarning
+			warnings = append(warnings, warning)
+		}
+
+		// In a merge patch, null deletes the property
+		if _, isDefined := s.properties[propName]; isDefined && propValue == nil && s.deletable != nil {
+			if nullErrors := s.patchNull(propName, ctx); len(nullErrors) > 0 {
+				errors = append(errors, nullErrors...)
+			} else if keepValue {
+				finalValue[propName] = nil
+			}
+			return
 		}
 
-		// Validate the property value using its schema
-		propResult := propSchema.Schema.Parse(propValue, ctx)
-		if !propResult.Valid {
+		// In presence mode, null is only accepted by nullable properties
+		if ctx.TrackPresence && propValue == nil && rejectsExplicitNull(propSchemas[0]) {
+			message := objectNullPropError(propName)(ctx.Locale)
+			errors = append(errors, NewFieldError(Path{FieldSegment(propName)}, nil, message, CodeInvalidType))
+			return
+		}
+
+		// Validate the property value using its schemas; the first one provides the value
+		var propResult ParseResult
+		propValid := true
+		for i, propSchema := range propSchemas {
+			result := propSchema.Parse(propValue, child.at(FieldSegment(propName)))
+			if i == 0 {
+				propResult = result
+				if present != nil {
+					addNestedPresence(present, propName, result.Present)
+				}
+			}
+			warnings = nestedWarnings(warnings, FieldSegment(propName), result)
+			if result.Valid {
+				continue
+			}
 			// Property validation failed
+			propValid = false
 			message := objectPropertyError(propName)(ctx.Locale)
 			if !isEmptyErrorMessage(s.propertyError) {
 				message = resolveErrorMessage(s.propertyError, ctx)
 			}
 			// Add the main property error
-			errors = append(errors, NewFieldError([]string{propName}, propValue, message, "property_invalid"))
+			errors = append(errors, NewFieldError(Path{FieldSegment(propName)}, propValue, message, CodePropertyInvalid))
 			// Also add the specific validation errors for this property
-			for _, propErr := range propResult.Errors {
+			for _, propErr := range result.Errors {
 				// Prefix the path with property name
-				errors = append(errors, NewFieldError(append([]string{propName}, propErr.Path...), propErr.Value, propErr.Message, propErr.Code))
+				errors = append(errors, nestedError(append(Path{FieldSegment(propName)}, propErr.Path...), propErr))
 			}
-		} else {
+		}
+		if !keepValue {
+			return
+		}
+		if propValid {
 			// Use the parsed value from property validation
 			finalValue[propName] = propResult.Value
+		} else if s.collectPartial && propResult.PartialOK {
+			// Keep the valid part of a nested object
+			finalValue[propName] = propResult.Value
+		}
+	}
+	if ctx.FailFast || ctx.MaxErrors > 0 {
+		// Validate the properties in sorted order, so that an error limit keeps the
+		// same errors on every parse of the same input
+		for _, propName := range sortedKeys(objectMap) {
+			if ctx.stopCollecting(len(errors)) {
+				break
+			}
+			validateProperty(propName, objectMap[propName])
 		}
+	} else {
+		for propName, propValue := range objectMap {
+			validateProperty(propName, propValue)
+		}
+	}
+
+	// Add the missing properties that have a default
+	if !s.skipDefaults {
+		errors, warnings = s.parseDefaults(objectMap, finalValue, errors, warnings, child, ctx)
+	}
+
+	if len(errors) > 0 && s.collectPartial {
+		return ParseResult{Valid: false, Value: finalValue, Errors: errors, PartialOK: true, Warnings: warnings, Present: present}
 	}
 
 	return ParseResult{
-		Valid:  len(errors) == 0,
-		Value:  finalValue,
-		Errors: errors,
+		Valid:    len(errors) == 0,
+		Value:    finalValue,
+		Errors:   errors,
+		Warnings: warnings,
+		Present:  present,
 	}
 }
 
 // JSON generates JSON Schema representation
 func (s *ObjectSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *ObjectSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	schema := baseJSONSchema("object")
 
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -524,9 +1250,10 @@ func (s *ObjectSchema) JSON() map[string]interface{} {
 	// Add object-specific fields
 	if len(s.properties) > 0 {
 		properties := make(map[string]interface{})
-		for name, prop := range s.properties {
-			if jsonSchema, ok := prop.Schema.(interface{ JSON() map[string]interface{} }); ok {
-				properties[name] = jsonSchema.JSON()
+		for _, name := range sortedPropertyNames(s.properties) {
+			prop := s.properties[name]
+			if propJSON, ok := childJSON(prop.Schema, g); ok {
+				properties[name] = s.patchPropertyJSON(name, prop.Schema, propJSON)
 			}
 		}
 		schema["properties"] = properties
@@ -536,7 +1263,8 @@ func (s *ObjectSchema) JSON() map[string]interface{} {
 		schema["required"] = s.requiredProps
 	}
 
-	schema["additionalProperties"] = s.additionalProps
+	// Stripped properties are accepted in the input
+	schema["additionalProperties"] = s.additionalProps || s.stripUnknown
 
 	if s.minProps != nil {
 		schema["minProperties"] = *s.minProps
@@ -546,6 +1274,9 @@ func (s *ObjectSchema) JSON() map[string]interface{} {
 		schema["maxProperties"] = *s.maxProps
 	}
 
+	s.addPatternPropertiesJSON(schema, g)
+	s.addDependenciesJSON(schema, g)
+
 	// Add nullable if true
 	if s.nullable {
 		schema["type"] = []string{"object", "null"}
@@ -554,6 +1285,12 @@ func (s *ObjectSchema) JSON() map[string]interface{} {
 	return schema
 }
 
+// JSONWithRefs generates JSON Schema in which sub-schemas registered in registry are
+// emitted once under $defs and referenced with $ref instead of being inlined
+func (s *ObjectSchema) JSONWithRefs(registry *SchemaRegistry) map[string]interface{} {
+	return jsonWithRefs(s.JSON(), registry)
+}
+
 // MarshalJSON implements json.Marshaler to properly serialize ObjectSchema for JSON schema generation
 func (s *ObjectSchema) MarshalJSON() ([]byte, error) {
 	type jsonObjectSchema struct {
@@ -564,6 +1301,7 @@ func (s *ObjectSchema) MarshalJSON() ([]byte, error) {
 		MinProps        *int                      `json:"minProperties,omitempty"`
 		MaxProps        *int                      `json:"maxProperties,omitempty"`
 		Nullable        bool                      `json:"nullable,omitempty"`
+		DependentReq    map[string][]string       `json:"dependentRequired,omitempty"`
 	}
 
 	return json.Marshal(jsonObjectSchema{
@@ -574,6 +1312,7 @@ func (s *ObjectSchema) MarshalJSON() ([]byte, error) {
 		MinProps:        s.minProps,
 		MaxProps:        s.maxProps,
 		Nullable:        s.nullable,
+		DependentReq:    s.dependentRequired,
 	})
 }
 
@@ -0,0 +1,712 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestObjectSchema_CollectPartial(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	address := Object().
+		Property("city", String()).
+		Property("zip", String().MinLength(5)).
+		CollectPartial()
+	form := Object().
+		Property("name", String()).
+		Property("age", Int().Min(18)).
+		Property("address", address).
+		CollectPartial()
+
+	result := form.Parse(map[string]interface{}{
+		"name":    "Ada",
+		"age":     12,
+		"address": map[string]interface{}{"city": "London", "zip": "N1"},
+	}, ctx)
+
+	if result.Valid {
+		t.Fatal("expected invalid result")
+	}
+	if !result.PartialOK {
+		t.Fatal("expected PartialOK to be set")
+	}
+	want := map[string]interface{}{
+		"name":    "Ada",
+		"address": map[string]interface{}{"city": "London"},
+	}
+	if !reflect.DeepEqual(result.Value, want) {
+		t.Errorf("partial value = %v, want %v", result.Value, want)
+	}
+
+	if result := form.Parse("not an object", ctx); result.PartialOK || result.Value != nil {
+		t.Errorf("type errors should not produce a partial result, got %v", result.Value)
+	}
+	if result := Object().Property("age", Int().Min(18)).Parse(map[string]interface{}{"age": 1}, ctx); result.PartialOK {
+		t.Error("PartialOK should only be set when CollectPartial is enabled")
+	}
+}
+
+func TestObjectSchema_JSONWithRefs(t *testing.T) {
+	registry := NewSchemaRegistry()
+	address := Object().
+		Property("street", String()).
+		Property("city", String())
+	registry.Define("Address", address)
+	registry.Define("Name", String()) // Bare types are never substituted
+	registry.Define("Node", Object().
+		Property("value", Int()).
+		Property("children", Array(Ref("#/Node", registry)).Optional()))
+
+	order := Object().
+		Property("customer", String()).
+		Property("billing", address).
+		Property("shipping", Array(address)).
+		Property("tree", Ref("#/Node", registry))
+
+	doc := order.JSONWithRefs(registry)
+	properties := doc["properties"].(map[string]interface{})
+
+	if got := properties["billing"]; !reflect.DeepEqual(got, map[string]interface{}{"$ref": "#/$defs/Address"}) {
+		t.Errorf("billing = %v, want a $ref to Address", got)
+	}
+	if got := properties["shipping"].(map[string]interface{})["items"]; !reflect.DeepEqual(got, map[string]interface{}{"$ref": "#/$defs/Address"}) {
+		t.Errorf("shipping items = %v, want a $ref to Address", got)
+	}
+	if got := properties["customer"]; !reflect.DeepEqual(got, map[string]interface{}{"type": "string"}) {
+		t.Errorf("customer = %v, want the inline string schema", got)
+	}
+	if got := properties["tree"]; !reflect.DeepEqual(got, map[string]interface{}{"$ref": "#/$defs/Node"}) {
+		t.Errorf("tree = %v, want a $ref to $defs/Node", got)
+	}
+
+	defs := doc["$defs"].(map[string]interface{})
+	if len(defs) != 2 || defs["Address"] == nil || defs["Node"] == nil {
+		t.Fatalf("$defs = %v, want Address and Node", defs)
+	}
+	children := defs["Node"].(map[string]interface{})["properties"].(map[string]interface{})["children"]
+	if got := children.(map[string]interface{})["items"]; !reflect.DeepEqual(got, map[string]interface{}{"$ref": "#/$defs/Node"}) {
+		t.Errorf("Node children items = %v, want a $ref to $defs/Node", got)
+	}
+}
+
+func TestObjectSchema_DependentRequired(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	payment := Object().
+		Property("name", String()).
+		Property("creditCard", String().Optional()).
+		Property("billingAddress", String().Optional()).
+		Property("cvv", String().Optional()).
+		DependentRequired(map[string][]string{"creditCard": {"billingAddress", "cvv"}})
+
+	tests := []struct {
+		name  string
+		value map[string]interface{}
+		want  []string
+	}{
+		{"key absent", map[string]interface{}{"name": "Ada"}, []string{}},
+		{"dependencies present", map[string]interface{}{"name": "Ada", "creditCard": "4111", "billingAddress": "London", "cvv": "123"}, []string{}},
+		{"dependencies missing", map[string]interface{}{"name": "Ada", "creditCard": "4111"}, []string{"billingAddress required", "cvv required"}},
+		{"reported once", map[string]interface{}{"creditCard": "4111", "cvv": "123"}, []string{"billingAddress required", "name required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := payment.Parse(tt.value, ctx)
+			if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	custom := Object().
+		Property("a", Int().Optional()).
+		Property("b", Int().Optional()).
+		DependentRequired(map[string][]string{"a": {"b"}}, "b goes with a")
+	if result := custom.Parse(map[string]interface{}{"a": 1}, ctx); result.Valid || result.Errors[0].Message != "b goes with a" {
+		t.Errorf("custom message: %v", result.Errors)
+	}
+
+	doc := payment.JSON()
+	if want := map[string][]string{"creditCard": {"billingAddress", "cvv"}}; !reflect.DeepEqual(doc["dependentRequired"], want) {
+		t.Errorf("dependentRequired = %v, want %v", doc["dependentRequired"], want)
+	}
+	if picked := payment.Pick("name", "creditCard", "cvv").GetDependentRequired(); !reflect.DeepEqual(picked, map[string][]string{"creditCard": {"cvv"}}) {
+		t.Errorf("Pick kept %v", picked)
+	}
+}
+
+func TestObjectSchema_When(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	payment := Object().
+		Property("type", Enum("card", "transfer")).
+		Property("cardNumber", String().Optional()).
+		Property("cvv", String().Optional()).
+		Property("iban", String().Optional()).
+		Property("reference", String().Optional())
+	payment.When("type", Literal("card")).ThenRequire("cardNumber", "cvv")
+	transfer := payment.When("type", Literal("transfer"))
+	transfer.ThenRequire("iban")
+	transfer.ElseRequire("reference")
+
+	tests := []struct {
+		name  string
+		value map[string]interface{}
+		want  []string
+	}{
+		{"card complete", map[string]interface{}{"type": "card", "cardNumber": "4111", "cvv": "123", "reference": "r"}, []string{}},
+		{"card missing fields", map[string]interface{}{"type": "card", "reference": "r"}, []string{"cardNumber required", "cvv required"}},
+		{"transfer", map[string]interface{}{"type": "transfer", "iban": "DE89"}, []string{}},
+		{"transfer missing iban", map[string]interface{}{"type": "transfer"}, []string{"iban required"}},
+		{"else branch", map[string]interface{}{"type": "card", "cardNumber": "4111", "cvv": "123"}, []string{"reference required"}},
+		{"property absent", map[string]interface{}{}, []string{"reference required", "type required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := payment.Parse(tt.value, ctx)
+			if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	custom := Object().Property("kind", String()).Property("extra", String().Optional())
+	custom.When("kind", Literal("x")).Error("extra is needed for x").ThenRequire("extra")
+	if result := custom.Parse(map[string]interface{}{"kind": "x"}, ctx); result.Valid || result.Errors[0].Message != "extra is needed for x" {
+		t.Errorf("custom message: %v", result.Errors)
+	}
+
+	// A single rule is inlined, several are combined with allOf
+	doc := custom.JSON()
+	want := map[string]interface{}{
+		"properties": map[string]interface{}{"kind": map[string]interface{}{"type": "string", "const": "x"}},
+		"required":   []string{"kind"},
+	}
+	if !reflect.DeepEqual(doc["if"], want) || !reflect.DeepEqual(doc["then"], map[string]interface{}{"required": []string{"extra"}}) {
+		t.Errorf("if/then = %v / %v", doc["if"], doc["then"])
+	}
+	allOf, ok := payment.JSON()["allOf"].([]interface{})
+	if !ok || len(allOf) != 2 {
+		t.Fatalf("allOf = %v", payment.JSON()["allOf"])
+	}
+	if rule := allOf[1].(map[string]interface{}); !reflect.DeepEqual(rule["else"], map[string]interface{}{"required": []string{"reference"}}) {
+		t.Errorf("else = %v", rule["else"])
+	}
+
+	// Derived schemas keep the rules without sharing them
+	derived := payment.Omit("iban")
+	derived.When("type", Literal("card")).ThenRequire("reference")
+	if len(payment.conditions) != 2 || len(derived.conditions) != 3 {
+		t.Errorf("conditions = %d and %d", len(payment.conditions), len(derived.conditions))
+	}
+	if result := derived.Parse(map[string]interface{}{"type": "card", "cardNumber": "4111", "cvv": "123"}, ctx); !reflect.DeepEqual(errorKeys(result.Errors), []string{"reference required"}) {
+		t.Errorf("Omit errors = %v", errorKeys(result.Errors))
+	}
+}
+
+func TestObjectSchema_SuperRefine(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	booking := Object().
+		Property("startDate", String()).
+		Property("endDate", String()).
+		Property("email", String().Optional()).
+		Property("phone", String().Optional()).
+		SuperRefine(func(obj map[string]interface{}, add func(path []string, msg, code string)) {
+			if obj["endDate"].(string) < obj["startDate"].(string) {
+				add([]string{"endDate"}, "endDate must be after startDate", "date_order")
+			}
+			if obj["email"] == nil && obj["phone"] == nil {
+				add(nil, "either email or phone must be set", "")
+			}
+		})
+
+	tests := []struct {
+		name  string
+		value map[string]interface{}
+		want  []string
+	}{
+		{"valid", map[string]interface{}{"startDate": "2024-01-01", "endDate": "2024-01-05", "email": "a@b.c"}, []string{}},
+		{"date order", map[string]interface{}{"startDate": "2024-01-05", "endDate": "2024-01-01", "phone": "1"}, []string{"endDate date_order"}},
+		{"several errors", map[string]interface{}{"startDate": "2024-01-05", "endDate": "2024-01-01"}, []string{" custom", "endDate date_order"}},
+		{"not run when properties fail", map[string]interface{}{"startDate": "2024-01-05"}, []string{"endDate required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := booking.Parse(tt.value, ctx)
+			if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	result := booking.Parse(map[string]interface{}{"startDate": "2024-01-05", "endDate": "2024-01-01", "phone": "1"}, ctx)
+	if err := result.Errors[0]; err.Value != "2024-01-01" || err.Message != "endDate must be after startDate" {
+		t.Errorf("error = %+v", err)
+	}
+
+	nested := Object().
+		Property("range", Object().Property("min", Int()).Property("max", Int())).
+		SuperRefine(func(obj map[string]interface{}, add func(path []string, msg, code string)) {
+			r := obj["range"].(map[string]interface{})
+			if r["min"].(int) > r["max"].(int) {
+				add([]string{"range", "max"}, "max must not be below min", "range")
+			}
+		})
+	result = nested.Parse(map[string]interface{}{"range": map[string]interface{}{"min": 5, "max": 1}}, ctx)
+	if got := errorKeys(result.Errors); !reflect.DeepEqual(got, []string{"range.max range"}) || result.Errors[0].Value != "1" {
+		t.Errorf("nested errors = %v (%v)", got, result.Errors)
+	}
+}
+
+func TestObjectSchema_KeyMapping(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	user := Object().
+		Property("username", String()).
+		Property("displayName", String().Optional()).
+		Alias("user_name", "username").
+		Alias("login", "username")
+
+	tests := []struct {
+		name   string
+		schema *ObjectSchema
+		value  map[string]interface{}
+		want   map[string]interface{}
+		errors []string
+	}{
+		{"canonical key", user, map[string]interface{}{"username": "ada"}, map[string]interface{}{"username": "ada"}, nil},
+		{"alias", user, map[string]interface{}{"user_name": "ada"}, map[string]interface{}{"username": "ada"}, nil},
+		{"duplicate", user, map[string]interface{}{"username": "ada", "login": "bob"}, nil, []string{"login duplicate_key"}},
+		{"two aliases", user, map[string]interface{}{"user_name": "ada", "login": "bob"}, nil, []string{"user_name duplicate_key"}},
+		{"unknown key", user, map[string]interface{}{"username": "ada", "display_name": "Ada"}, nil, []string{"display_name additional_property"}},
+		{"snake to camel", Object().
+			Property("displayName", String()).
+			Property("createdAt", String()).
+			KeyTransform(SnakeToCamel),
+			map[string]interface{}{"display_name": "Ada", "createdAt": "today"},
+			map[string]interface{}{"displayName": "Ada", "createdAt": "today"}, nil},
+		{"camel to snake passthrough", Object().
+			Property("user_id", Int()).
+			KeyTransform(CamelToSnake).
+			Passthrough(),
+			map[string]interface{}{"userID": 7, "HTTPServer": "x"},
+			map[string]interface{}{"user_id": 7, "http_server": "x"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if tt.errors != nil {
+				if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.errors) {
+					t.Errorf("errors = %v, want %v", got, tt.errors)
+				}
+				return
+			}
+			if !result.Valid || !reflect.DeepEqual(result.Value, tt.want) {
+				t.Errorf("Parse = %v (%v), want %v", result.Value, result.Errors, tt.want)
+			}
+		})
+	}
+
+	if aliases := user.Omit("username").GetAliases(); len(aliases) != 0 {
+		t.Errorf("Omit kept aliases %v", aliases)
+	}
+}
+
+func TestKeyTransformers(t *testing.T) {
+	camel := map[string]string{
+		"userName":   "user_name",
+		"UserID":     "user_id",
+		"HTTPServer": "http_server",
+		"already_ok": "already_ok",
+		"id":         "id",
+	}
+	for in, want := range camel {
+		if got := CamelToSnake(in); got != want {
+			t.Errorf("CamelToSnake(%q) = %q, want %q", in, got, want)
+		}
+	}
+	snake := map[string]string{
+		"user_name": "userName",
+		"user_id":   "userId",
+		"_private":  "_private",
+		"camelCase": "camelCase",
+	}
+	for in, want := range snake {
+		if got := SnakeToCamel(in); got != want {
+			t.Errorf("SnakeToCamel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestObjectSchema_Strip(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	var unknown []string
+	user := Object().
+		Property("name", String()).
+		Strip().
+		OnUnknownKey(func(key string, value interface{}) {
+			unknown = append(unknown, key)
+		})
+
+	result := user.Parse(map[string]interface{}{"name": "Ada", "legacyId": 7}, ctx)
+	if !result.Valid || !reflect.DeepEqual(result.Value, map[string]interface{}{"name": "Ada"}) {
+		t.Errorf("Parse = %v (%v)", result.Value, result.Errors)
+	}
+	if !reflect.DeepEqual(unknown, []string{"legacyId"}) {
+		t.Errorf("unknown keys = %v", unknown)
+	}
+	if doc := user.JSON(); doc["additionalProperties"] != true {
+		t.Errorf("additionalProperties = %v", doc["additionalProperties"])
+	}
+
+	// The callback also sees keys that are rejected
+	unknown = nil
+	user.Strict()
+	if result := user.Parse(map[string]interface{}{"name": "Ada", "extra": 1}, ctx); result.Valid || len(unknown) != 1 {
+		t.Errorf("Strict: valid = %v, unknown = %v", result.Valid, unknown)
+	}
+	if user.StripsUnknown() {
+		t.Error("Strict should turn stripping off")
+	}
+
+	stripped := Object().Property("a", Int()).Strip()
+	if merged := Intersection(stripped, Object().Property("b", Int()).Passthrough()); !merged.StripsUnknown() || merged.AllowsAdditionalProperties() {
+		t.Error("Intersection of strip and passthrough should strip")
+	}
+	if merged := Intersection(stripped, Object().Property("b", Int())); merged.StripsUnknown() {
+		t.Error("Intersection with a strict schema should be strict")
+	}
+}
+
+func TestObjectSchema_PatternProperties(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	spec := Object().
+		Property("title", String()).
+		Property("x-count", Int().Optional()).
+		PatternProperty("^x-", String().MinLength(2).Optional()).
+		PatternProperty("^x-count$", Int().Min(1).Optional())
+
+	tests := []struct {
+		name   string
+		value  map[string]interface{}
+		errors []string
+	}{
+		{"extensions", map[string]interface{}{"title": "API", "x-owner": "team"}, []string{}},
+		{"extension invalid", map[string]interface{}{"title": "API", "x-owner": "t"}, []string{"x-owner min_length", "x-owner property_invalid"}},
+		{"unmatched key", map[string]interface{}{"title": "API", "owner": "team"}, []string{"owner additional_property"}},
+		// x-count must satisfy its definition and both patterns
+		{"defined and pattern", map[string]interface{}{"title": "API", "x-count": 0}, []string{"x-count invalid_type", "x-count minimum", "x-count property_invalid", "x-count property_invalid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := spec.Parse(tt.value, ctx)
+			if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.errors) {
+				t.Errorf("errors = %v, want %v", got, tt.errors)
+			}
+		})
+	}
+
+	// A key that matches a pattern keeps its name under a key transform
+	transformed := Object().PatternProperty("^x_", String()).KeyTransform(SnakeToCamel)
+	if result := transformed.Parse(map[string]interface{}{"x_trace": "1"}, ctx); !result.Valid || !reflect.DeepEqual(result.Value, map[string]interface{}{"x_trace": "1"}) {
+		t.Errorf("Parse = %v (%v)", result.Value, result.Errors)
+	}
+
+	patterns, ok := spec.JSON()["patternProperties"].(map[string]interface{})
+	if !ok || len(patterns) != 2 || patterns["^x-"].(map[string]interface{})["minLength"] != 2 {
+		t.Errorf("patternProperties = %v", spec.JSON()["patternProperties"])
+	}
+	if compiled := Object().PatternProperty("^[a-z]+$", Int()); len(compiled.GetPatternProperties()) != 1 {
+		t.Errorf("GetPatternProperties = %v", compiled.GetPatternProperties())
+	}
+}
+
+func TestObjectSchema_PropertyNames(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	labels := Object().
+		Passthrough().
+		PropertyNames(String().Pattern("^[a-z][a-z0-9-]*$").MaxLength(16))
+
+	if result := labels.Parse(map[string]interface{}{"env": "prod", "team-a": "x"}, ctx); !result.Valid {
+		t.Errorf("valid names rejected: %v", result.Errors)
+	}
+	result := labels.Parse(map[string]interface{}{"env": "prod", "Team": "x"}, ctx)
+	if got := errorKeys(result.Errors); !reflect.DeepEqual(got, []string{"Team key_invalid", "Team pattern"}) {
+		t.Errorf("errors = %v", got)
+	}
+	if result.Errors[0].Message != "property name Team is invalid" {
+		t.Errorf("message = %q", result.Errors[0].Message)
+	}
+
+	doc := labels.JSON()
+	if names, ok := doc["propertyNames"].(map[string]interface{}); !ok || names["maxLength"] != 16 {
+		t.Errorf("propertyNames = %v", doc["propertyNames"])
+	}
+}
+
+func TestObjectSchema_ReadWriteViews(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	author := Object().
+		Property("id", Int().ReadOnly()).
+		Property("name", String())
+	user := Object().
+		Property("id", Int().ReadOnly()).
+		Property("createdAt", DateTime().ReadOnly()).
+		Property("email", String().Email()).
+		Property("password", String().MinLength(8).WriteOnly()).
+		Property("authors", Array(author).Optional()).
+		DependentRequired(map[string][]string{"email": {"password"}})
+
+	write := user.ForWrite()
+	if got := sortedPropertyNames(write.GetProperties()); !reflect.DeepEqual(got, []string{"authors", "email", "password"}) {
+		t.Errorf("ForWrite properties = %v", got)
+	}
+	if !reflect.DeepEqual(write.GetRequiredProperties(), []string{"email", "password"}) {
+		t.Errorf("ForWrite required = %v", write.GetRequiredProperties())
+	}
+	items := write.GetProperties()["authors"].Schema.(*ArraySchema).itemSchema.(*ObjectSchema)
+	if _, ok := items.GetProperties()["id"]; ok {
+		t.Error("ForWrite should derive array items")
+	}
+	if result := write.Parse(map[string]interface{}{"id": 1, "email": "a@b.c", "password": "secret123"}, ctx); result.Valid {
+		t.Error("ForWrite should reject read-only properties in strict mode")
+	}
+
+	read := user.ForRead()
+	if got := sortedPropertyNames(read.GetProperties()); !reflect.DeepEqual(got, []string{"authors", "createdAt", "email", "id"}) {
+		t.Errorf("ForRead properties = %v", got)
+	}
+	if deps := read.GetDependentRequired(); len(deps["email"]) != 0 {
+		t.Errorf("ForRead dependencies = %v", deps)
+	}
+	if _, ok := user.GetProperties()["password"]; !ok {
+		t.Error("views should not modify the receiver")
+	}
+
+	doc := user.JSON()["properties"].(map[string]interface{})
+	if doc["id"].(map[string]interface{})["readOnly"] != true || doc["password"].(map[string]interface{})["writeOnly"] != true {
+		t.Errorf("annotations = %v / %v", doc["id"], doc["password"])
+	}
+	if _, ok := doc["email"].(map[string]interface{})["readOnly"]; ok {
+		t.Error("unannotated properties should not emit readOnly")
+	}
+	if s := String().ReadOnly().WriteOnly(); s.IsReadOnly() || !s.IsWriteOnly() {
+		t.Error("WriteOnly should replace ReadOnly")
+	}
+}
+
+type structInputAudit struct {
+	CreatedBy string `json:"createdBy"`
+	ID        string `json:"id"`
+}
+
+type structInputID int
+
+func (id structInputID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("id-%d", int(id))), nil
+}
+
+type structInput struct {
+	structInputAudit
+	ID        int               `json:"id"`
+	Name      string            `json:"name,omitempty" yaml:"title"`
+	Note      *string           `json:"note"`
+	Tags      []string          `json:"tags,omitempty"`
+	Ref       structInputID     `json:"ref"`
+	Created   time.Time         `json:"created,omitzero"`
+	Internal  string            `json:"-"`
+	Meta      map[string]string `yaml:"-"`
+	unexposed string
+}
+
+func TestConvertToMap_Structs(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		tagNames []string
+		want     map[string]interface{}
+	}{
+		{
+			name:  "embedded fields are promoted",
+			value: structInput{structInputAudit: structInputAudit{CreatedBy: "ada", ID: "audit"}, ID: 7, Name: "box", Ref: 3, Created: created},
+			want: map[string]interface{}{
+				"createdBy": "ada", "id": 7, "name": "box", "note": nil, "ref": "id-3",
+				"created": "2024-05-01T12:00:00Z", "Meta": map[string]string(nil),
+			},
+		},
+		{
+			name:  "empty fields are omitted",
+			value: &structInput{},
+			want: map[string]interface{}{
+				"createdBy": "", "id": 0, "note": nil, "ref": "id-0", "Meta": map[string]string(nil),
+			},
+		},
+		{
+			name:     "configured tag names",
+			value:    structInput{Name: "box", Internal: "x"},
+			tagNames: []string{"yaml"},
+			want: map[string]interface{}{
+				"CreatedBy": "", "ID": 0, "title": "box", "Note": nil, "Tags": []string(nil),
+				"Ref": "id-0", "Created": "0001-01-01T00:00:00Z", "Internal": "x",
+			},
+		},
+		{
+			name: "nil embedded pointer",
+			value: struct {
+				*structInputAudit
+				Name string `schema:"name=title"`
+			}{Name: "box"},
+			tagNames: []string{"schema", "json"},
+			want:     map[string]interface{}{"title": "box"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := convertToMap(tt.value, tt.tagNames...)
+			if !ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertToMap = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectSchema_StructInput(t *testing.T) {
+	event := Object().
+		Property("title", String().MinLength(1)).
+		Property("at", DateTime()).
+		Property("note", String().Optional())
+
+	type Event struct {
+		Title string    `yaml:"title" json:"name"`
+		At    time.Time `yaml:"at"`
+		Note  *string   `yaml:"note,omitempty"`
+	}
+	value := Event{Title: "launch", At: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	if result := event.Parse(value, DefaultValidationContext().WithTagNames("yaml")); !result.Valid {
+		t.Errorf("struct named by yaml tags rejected: %v", result.Errors)
+	}
+	// With the json tag, the title is named "name" and "at" is missing
+	if result := event.Parse(value, DefaultValidationContext()); result.Valid {
+		t.Error("struct named by json tags should not match the schema")
+	}
+}
+
+func TestObjectSchema_FillDefaults(t *testing.T) {
+	ctx := DefaultValidationContext()
+	settings := Object().
+		Property("theme", String().Default("light")).
+		Property("pageSize", Int().Min(1).Default(20).Optional()).
+		Property("nickname", String().Optional()).
+		Property("created", String().DefaultFunc(func() interface{} { return "now" }))
+
+	tests := []struct {
+		name   string
+		schema *ObjectSchema
+		value  map[string]interface{}
+		valid  bool
+		want   map[string]interface{}
+	}{
+		{
+			name:   "missing properties get their default",
+			schema: settings,
+			value:  map[string]interface{}{},
+			valid:  true,
+			want:   map[string]interface{}{"theme": "light", "pageSize": 20, "created": "now"},
+		},
+		{
+			name:   "present properties are kept",
+			schema: settings,
+			value:  map[string]interface{}{"theme": "dark", "pageSize": 50, "created": "today"},
+			valid:  true,
+			want:   map[string]interface{}{"theme": "dark", "pageSize": 50, "created": "today"},
+		},
+		{
+			name:   "opt-out leaves them out",
+			schema: settings.Clone().FillDefaults(false),
+			value:  map[string]interface{}{"theme": "dark", "created": "today"},
+			valid:  true,
+			want:   map[string]interface{}{"theme": "dark", "created": "today"},
+		},
+		{
+			name:   "opt-out reports missing required properties",
+			schema: settings.Clone().FillDefaults(false),
+			value:  map[string]interface{}{},
+			valid:  false,
+		},
+		{
+			name:   "invalid default",
+			schema: Object().Property("pageSize", Int().Min(1).Default(0)),
+			value:  map[string]interface{}{},
+			valid:  false,
+		},
+		{
+			name:   "required property whose default func returns nil",
+			schema: Object().Property("id", String().DefaultFunc(func() interface{} { return nil })),
+			value:  map[string]interface{}{},
+			valid:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.valid {
+				t.Fatalf("Valid = %v, want %v: %v", result.Valid, tt.valid, result.Errors)
+			}
+			if tt.valid && !reflect.DeepEqual(result.Value, tt.want) {
+				t.Errorf("Value = %#v, want %#v", result.Value, tt.want)
+			}
+		})
+	}
+
+	result := Object().Property("pageSize", Int().Min(1).Default(0)).Parse(map[string]interface{}{}, ctx)
+	if got, want := errorKeys(result.Errors), []string{"pageSize minimum", "pageSize property_invalid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+}
+
+func TestObjectSchema_ErrorLimitsAreDeterministic(t *testing.T) {
+	s := Object()
+	value := map[string]interface{}{}
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("p%02d", i)
+		s.Property(name, Int())
+		value[name] = "not a number"
+	}
+
+	tests := []struct {
+		name string
+		ctx  func() *ValidationContext
+		want []string
+	}{
+		{"fail fast", func() *ValidationContext { return DefaultValidationContext().WithFailFast() },
+			[]string{"p00 invalid_type", "p00 property_invalid"}},
+		{"max errors", func() *ValidationContext { return DefaultValidationContext().WithMaxErrors(4) },
+			[]string{"p00 invalid_type", "p00 property_invalid", "p01 invalid_type", "p01 property_invalid"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for run := 0; run < 20; run++ {
+				if got := errorKeys(s.Parse(value, tt.ctx()).Errors); !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("run %d: errors = %v, want %v", run, got, tt.want)
+				}
+			}
+		})
+	}
+}
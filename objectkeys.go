@@ -0,0 +1,134 @@
+package schema
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nyxstack/i18n"
+)
+
+func objectDuplicateKeyError(prop string) i18n.TranslatedFunc {
+	return i18n.F("property %s is given more than once", prop)
+}
+
+// KeyTransformer maps an input key to the property name used by the schema
+type KeyTransformer func(key string) string
+
+var (
+	// CamelToSnake converts camelCase and PascalCase keys to snake_case
+	// ("userName" and "UserID" become "user_name" and "user_id")
+	CamelToSnake KeyTransformer = camelToSnake
+
+	// SnakeToCamel converts snake_case keys to camelCase ("user_name" becomes "userName")
+	SnakeToCamel KeyTransformer = snakeToCamel
+)
+
+// Alias accepts the input key alias as the property name; the parsed value uses name.
+// An object that contains both keys is invalid (code "duplicate_key").
+func (s *ObjectSchema) Alias(alias, name string) *ObjectSchema {
+	s.checkMutable()
+	if s.aliases == nil {
+		s.aliases = make(map[string]string)
+	}
+	s.aliases[alias] = name
+	return s
+}
+
+// KeyTransform renames input keys that are not property names with fn, so payloads
+// using another naming convention are accepted and normalized, e.g.
+// KeyTransform(SnakeToCamel) for snake_case input to a camelCase schema
+func (s *ObjectSchema) KeyTransform(fn KeyTransformer) *ObjectSchema {
+	s.checkMutable()
+	s.keyTransform = fn
+	return s
+}
+
+// GetAliases returns the property name for each alias
+func (s *ObjectSchema) GetAliases() map[string]string {
+	return s.aliases
+}
+
+// normalizeKeys renames aliased and transformed keys to their property names. Keys
+// that are property names or match a pattern property are kept as they are; two keys
+// that map to the same name are reported at the renamed key.
+func (s *ObjectSchema) normalizeKeys(objectMap map[string]interface{}, ctx *ValidationContext) (map[string]interface{}, []ValidationError) {
+	if len(s.aliases) == 0 && s.keyTransform == nil {
+		return objectMap, nil
+	}
+
+	keys := make([]string, 0, len(objectMap))
+	for key := range objectMap {
+		keys = append(keys, key)
+	}
+	// Process property names first so that they win over renamed keys
+	sort.SliceStable(keys, func(i, j int) bool {
+		_, iDefined := s.properties[keys[i]]
+		_, jDefined := s.properties[keys[j]]
+		if iDefined != jDefined {
+			return iDefined
+		}
+		return keys[i] < keys[j]
+	})
+
+	var errors []ValidationError
+	result := make(map[string]interface{}, len(objectMap))
+	for _, key := range keys {
+		name := s.propertyName(key)
+		if _, exists := result[name]; exists {
+			message := objectDuplicateKeyError(name)(ctx.Locale)
+			errors = append(errors, NewFieldError(Path{FieldSegment(key)}, objectMap[key], message, CodeDuplicateKey))
+			continue
+		}
+		result[name] = objectMap[key]
+	}
+	return result, errors
+}
+
+// propertyName returns the property name for an input key
+func (s *ObjectSchema) propertyName(key string) string {
+	if _, defined := s.properties[key]; defined || len(s.matchingPatternSchemas(key)) > 0 {
+		return key
+	}
+	if name, ok := s.aliases[key]; ok {
+		return name
+	}
+	if s.keyTransform != nil {
+		return s.keyTransform(key)
+	}
+	return key
+}
+
+func camelToSnake(key string) string {
+	runes := []rune(key)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a word at a lower-to-upper change, and before the last capital of an
+			// acronym that is followed by a lowercase letter ("HTTPServer")
+			if i > 0 && runes[i-1] != '_' && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func snakeToCamel(key string) string {
+	var b strings.Builder
+	upper := false
+	for i, r := range key {
+		if r == '_' && i > 0 {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
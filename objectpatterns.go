@@ -0,0 +1,111 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/nyxstack/i18n"
+)
+
+func objectPropertyNameError(prop string) i18n.TranslatedFunc {
+	return i18n.F("property name %s is invalid", prop)
+}
+
+// objectPatternProperty is the schema for the properties whose names match a pattern
+type objectPatternProperty struct {
+	pattern string
+	regex   *regexp.Regexp
+	schema  Parseable
+}
+
+// PatternProperty validates every property whose name matches the regular expression
+// pattern with schema (JSON Schema patternProperties). Such properties are not
+// additional properties; a defined property that matches must satisfy both schemas.
+// It panics if pattern is not a valid regular expression.
+func (s *ObjectSchema) PatternProperty(pattern string, schema Parseable) *ObjectSchema {
+	s.checkMutable()
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("schema: invalid pattern %q: %v", pattern, err))
+	}
+	s.patternProps = append(s.patternProps, objectPatternProperty{pattern: pattern, regex: regex, schema: schema})
+	return s
+}
+
+// PropertyNames requires every property name, defined or not, to satisfy schema
+// (usually a string schema) with optional custom error message
+func (s *ObjectSchema) PropertyNames(schema Parseable, errorMessage ...interface{}) *ObjectSchema {
+	s.checkMutable()
+	s.propertyNames = schema
+	if len(errorMessage) > 0 {
+		s.propertyNamesError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// GetPatternProperties returns the schema for each property name pattern
+func (s *ObjectSchema) GetPatternProperties() map[string]Parseable {
+	patterns := make(map[string]Parseable, len(s.patternProps))
+	for _, prop := range s.patternProps {
+		patterns[prop.pattern] = prop.schema
+	}
+	return patterns
+}
+
+// GetPropertyNames returns the schema for property names, or nil
+func (s *ObjectSchema) GetPropertyNames() Parseable {
+	return s.propertyNames
+}
+
+// matchingPatternSchemas returns the schemas of the patterns that match name, in the
+// order they were added
+func (s *ObjectSchema) matchingPatternSchemas(name string) []Parseable {
+	var schemas []Parseable
+	for _, prop := range s.patternProps {
+		if prop.regex.MatchString(name) {
+			schemas = append(schemas, prop.schema)
+		}
+	}
+	return schemas
+}
+
+// validatePropertyName checks name against PropertyNames, reporting the errors of the
+// name schema at the property path
+func (s *ObjectSchema) validatePropertyName(name string, ctx *ValidationContext) []ValidationError {
+	if s.propertyNames == nil {
+		return nil
+	}
+	result := s.propertyNames.Parse(name, ctx)
+	if result.Valid {
+		return nil
+	}
+	message := objectPropertyNameError(name)(ctx.Locale)
+	if !isEmptyErrorMessage(s.propertyNamesError) {
+		message = resolveErrorMessage(s.propertyNamesError, ctx)
+	}
+	errors := []ValidationError{NewFieldError(Path{FieldSegment(name)}, name, message, CodeKeyInvalid)}
+	for _, nameErr := range result.Errors {
+		errors = append(errors, nestedError(Path{FieldSegment(name)}, nameErr))
+	}
+	return errors
+}
+
+// addPatternPropertiesJSON adds patternProperties and propertyNames to schema
+func (s *ObjectSchema) addPatternPropertiesJSON(schema map[string]interface{}, g *jsonGeneration) {
+	if len(s.patternProps) > 0 {
+		patterns := make(map[string]interface{}, len(s.patternProps))
+		for _, prop := range s.patternProps {
+			if patternJSON, ok := childJSON(prop.schema, g); ok {
+				patterns[prop.pattern] = patternJSON
+			} else {
+				patterns[prop.pattern] = true
+			}
+		}
+		schema["patternProperties"] = patterns
+	}
+	if s.propertyNames != nil {
+		if namesJSON, ok := childJSON(s.propertyNames, g); ok {
+			schema["propertyNames"] = namesJSON
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package schema
+
+import (
+	"sort"
+
+	"github.com/nyxstack/i18n"
+)
+
+func objectDependentRequiredError(prop, dependency string) i18n.TranslatedFunc {
+	return i18n.F("property %s is required when %s is present", prop, dependency)
+}
+
+func objectConditionalRequiredError(prop, condition string) i18n.TranslatedFunc {
+	return i18n.F("property %s is required by the value of %s", prop, condition)
+}
+
+// SuperRefineFunc is a cross-field validator for an object. It calls add for each
+// problem it finds, with the path of the offending property (nil for the object
+// itself), a message and an error code ("custom" when empty).
+type SuperRefineFunc func(obj map[string]interface{}, add func(path []string, msg, code string))
+
+// objectCondition is an if/then/else rule on the value of one property
+type objectCondition struct {
+	property     string
+	condition    Parseable
+	thenRequired []string
+	elseRequired []string
+	errorMessage ErrorMessage
+}
+
+// ObjectCondition configures a rule added with ObjectSchema.When
+type ObjectCondition struct {
+	object *ObjectSchema
+	index  int
+}
+
+// DependentRequired requires, for each key present in the object, the properties listed
+// for it (JSON Schema dependentRequired), with optional custom error message.
+// Calling it again adds to the existing dependencies.
+func (s *ObjectSchema) DependentRequired(dependencies map[string][]string, errorMessage ...interface{}) *ObjectSchema {
+	s.checkMutable()
+	for name, required := range dependencies {
+		s.addDependentRequired(name, required)
+	}
+	if len(errorMessage) > 0 {
+		s.dependentRequiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// When adds a rule that applies when property is present and its value satisfies
+// condition, e.g. When("type", Literal("card")).ThenRequire("cardNumber", "cvv").
+// ThenRequire and ElseRequire may both be called on the returned condition.
+func (s *ObjectSchema) When(property string, condition Parseable) *ObjectCondition {
+	s.checkMutable()
+	s.conditions = append(s.conditions, objectCondition{property: property, condition: condition})
+	return &ObjectCondition{object: s, index: len(s.conditions) - 1}
+}
+
+// ThenRequire requires the named properties when the condition matches
+func (c *ObjectCondition) ThenRequire(names ...string) *ObjectSchema {
+	c.object.checkMutable()
+	rule := &c.object.conditions[c.index]
+	rule.thenRequired = mergeRequired(rule.thenRequired, names)
+	return c.object
+}
+
+// ElseRequire requires the named properties when the condition does not match
+func (c *ObjectCondition) ElseRequire(names ...string) *ObjectSchema {
+	c.object.checkMutable()
+	rule := &c.object.conditions[c.index]
+	rule.elseRequired = mergeRequired(rule.elseRequired, names)
+	return c.object
+}
+
+// Error sets a custom error message for the properties the condition requires
+func (c *ObjectCondition) Error(message interface{}) *ObjectCondition {
+	c.object.checkMutable()
+	c.object.conditions[c.index].errorMessage = toErrorMessage(message)
+	return c
+}
+
+// SuperRefine appends a cross-field validator (applied in order with Refine and
+// Transform), e.g. "endDate must be after startDate" reported at the endDate path.
+// Values that are no longer objects after a transform skip the validator.
+func (s *ObjectSchema) SuperRefine(fn SuperRefineFunc) *ObjectSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withCheck(func(value interface{}, ctx *ValidationContext) []ValidationError {
+		obj, ok := convertToMap(value)
+		if !ok {
+			return nil
+		}
+		var errors []ValidationError
+		fn(obj, func(path []string, msg, code string) {
+			if code == "" {
+				code = string(CodeCustom)
+			}
+			errorPath := make(Path, len(path))
+			for i, name := range path {
+				errorPath[i] = FieldSegment(name)
+			}
+			errors = append(errors, NewFieldError(errorPath, valueAtPath(obj, path), msg, ErrorCode(code)))
+		})
+		return errors
+	})
+	return s
+}
+
+// valueAtPath returns the value at path in nested objects, or "<missing>"
+func valueAtPath(obj map[string]interface{}, path []string) interface{} {
+	var value interface{} = obj
+	for _, name := range path {
+		current, ok := convertToMap(value)
+		if !ok {
+			return "<missing>"
+		}
+		if value, ok = current[name]; !ok {
+			return "<missing>"
+		}
+	}
+	return value
+}
+
+// addDependentRequired adds the properties required when name is present
+func (s *ObjectSchema) addDependentRequired(name string, required []string) {
+	if s.dependentRequired == nil {
+		s.dependentRequired = make(map[string][]string)
+	}
+	s.dependentRequired[name] = mergeRequired(s.dependentRequired[name], required)
+}
+
+// GetDependentRequired returns the dependent required properties by key
+func (s *ObjectSchema) GetDependentRequired() map[string][]string {
+	return s.dependentRequired
+}
+
+// validateDependencies checks the DependentRequired and When rules. Properties that
+// are already reported missing are not reported again.
+func (s *ObjectSchema) validateDependencies(objectMap map[string]interface{}, errors []ValidationError, ctx *ValidationContext) []ValidationError {
+	reported := make(map[string]bool)
+	for _, err := range errors {
+		if len(err.Path) == 1 && !err.Path[0].IsIndex && err.Code == "required" {
+			reported[err.Path[0].Field] = true
+		}
+	}
+	requireAll := func(names []string, message func(name string) string) {
+		for _, name := range names {
+			if _, exists := objectMap[name]; exists || reported[name] {
+				continue
+			}
+			reported[name] = true
+			errors = append(errors, NewFieldError(Path{FieldSegment(name)}, "<missing>", message(name), CodeRequired))
+		}
+	}
+
+	keys := make([]string, 0, len(s.dependentRequired))
+	for key := range s.dependentRequired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, exists := objectMap[key]; !exists {
+			continue
+		}
+		requireAll(s.dependentRequired[key], func(name string) string {
+			if !isEmptyErrorMessage(s.dependentRequiredError) {
+				return resolveErrorMessage(s.dependentRequiredError, ctx)
+			}
+			return objectDependentRequiredError(name, key)(ctx.Locale)
+		})
+	}
+
+	for _, rule := range s.conditions {
+		value, exists := objectMap[rule.property]
+		matched := exists && rule.condition.Parse(value, ctx).Valid
+		required := rule.elseRequired
+		if matched {
+			required = rule.thenRequired
+		}
+		requireAll(required, func(name string) string {
+			if !isEmptyErrorMessage(rule.errorMessage) {
+				return resolveErrorMessage(rule.errorMessage, ctx)
+			}
+			return objectConditionalRequiredError(name, rule.property)(ctx.Locale)
+		})
+	}
+	return errors
+}
+
+// addDependenciesJSON adds dependentRequired and the if/then/else form of the When
+// rules to schema. Several rules are combined with allOf.
+func (s *ObjectSchema) addDependenciesJSON(schema map[string]interface{}, g *jsonGeneration) {
+	if len(s.dependentRequired) > 0 {
+		schema["dependentRequired"] = s.dependentRequired
+	}
+
+	rules := make([]map[string]interface{}, 0, len(s.conditions))
+	for _, rule := range s.conditions {
+		condition := map[string]interface{}{"type": "unknown"}
+		if conditionJSON, ok := childJSON(rule.condition, g); ok {
+			condition = conditionJSON
+		}
+		entry := map[string]interface{}{
+			"if": map[string]interface{}{
+				"properties": map[string]interface{}{rule.property: condition},
+				"required":   []string{rule.property},
+			},
+		}
+		if len(rule.thenRequired) > 0 {
+			entry["then"] = map[string]interface{}{"required": rule.thenRequired}
+		}
+		if len(rule.elseRequired) > 0 {
+			entry["else"] = map[string]interface{}{"required": rule.elseRequired}
+		}
+		rules = append(rules, entry)
+	}
+
+	switch len(rules) {
+	case 0:
+	case 1:
+		for key, value := range rules[0] {
+			schema[key] = value
+		}
+	default:
+		allOf := make([]interface{}, len(rules))
+		for i, rule := range rules {
+			allOf[i] = rule
+		}
+		schema["allOf"] = allOf
+	}
+}
@@ -0,0 +1,389 @@
+// Package openapi generates OpenAPI 3.1 documents from the same schema definitions
+// that are used for validation.
+//
+//	doc := openapi.NewOpenAPIBuilder().
+//	    Title("Users API").
+//	    Version("1.0.0").
+//	    Component("User", userSchema).
+//	    Operation("POST", "/users", openapi.Operation{
+//	        OperationID: "createUser",
+//	        RequestBody: &openapi.RequestBody{Schema: createUserSchema, Required: true},
+//	        Responses: map[string]openapi.Response{
+//	            "201": {Description: "Created", Schema: userSchema},
+//	        },
+//	    })
+//
+//	spec, err := doc.JSON()
+package openapi
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/nyxstack/schema"
+)
+
+// Version is the OpenAPI specification version emitted by the builder
+const Version = "3.1.0"
+
+// DefaultContentType is used for request and response bodies without an explicit content type
+const DefaultContentType = "application/json"
+
+// Parameter describes a path, query, header or cookie parameter of an operation
+type Parameter struct {
+	Name        string
+	In          string // "path", "query", "header" or "cookie"
+	Description string
+	Required    bool // Path parameters are always emitted as required
+	Schema      schema.JSONSchemaGenerator
+}
+
+// RequestBody describes the body of an operation. Set Ref to point at a body
+// registered with Builder.RequestBody instead of describing it inline.
+type RequestBody struct {
+	Ref         string
+	Description string
+	Required    bool
+	ContentType string // Defaults to DefaultContentType
+	Schema      schema.JSONSchemaGenerator
+}
+
+// Response describes one response of an operation. Set Ref to point at a response
+// registered with Builder.Response instead of describing it inline.
+type Response struct {
+	Ref         string
+	Description string
+	ContentType string // Defaults to DefaultContentType
+	Schema      schema.JSONSchemaGenerator
+}
+
+// Operation describes a single HTTP operation on a path
+type Operation struct {
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+	Parameters  []Parameter
+	RequestBody *RequestBody
+	Responses   map[string]Response // Keyed by status code ("200", "404", "default")
+}
+
+// Server describes a server the API is available on
+type Server struct {
+	URL         string
+	Description string
+}
+
+// component is a named schema in components/schemas
+type component struct {
+	name   string
+	schema schema.JSONSchemaGenerator
+}
+
+// Builder assembles an OpenAPI 3.1 document
+type Builder struct {
+	title         string
+	version       string
+	description   string
+	servers       []Server
+	components    []component
+	requestBodies map[string]RequestBody
+	responses     map[string]Response
+	paths         map[string]map[string]Operation
+}
+
+// NewOpenAPIBuilder creates an empty OpenAPI document builder
+func NewOpenAPIBuilder() *Builder {
+	return &Builder{
+		title:         "API",
+		version:       "1.0.0",
+		requestBodies: make(map[string]RequestBody),
+		responses:     make(map[string]Response),
+		paths:         make(map[string]map[string]Operation),
+	}
+}
+
+// Title sets info.title
+func (b *Builder) Title(title string) *Builder {
+	b.title = title
+	return b
+}
+
+// Version sets info.version (the version of the API, not of OpenAPI)
+func (b *Builder) Version(version string) *Builder {
+	b.version = version
+	return b
+}
+
+// Description sets info.description
+func (b *Builder) Description(description string) *Builder {
+	b.description = description
+	return b
+}
+
+// Server adds a server URL
+func (b *Builder) Server(url string, description ...string) *Builder {
+	server := Server{URL: url}
+	if len(description) > 0 {
+		server.Description = description[0]
+	}
+	b.servers = append(b.servers, server)
+	return b
+}
+
+// Component registers a named schema under components/schemas. Wherever the same
+// schema instance is used (in operations or as a nested object property or array
+// item), the document refers to it with a $ref instead of inlining it.
+func (b *Builder) Component(name string, s schema.JSONSchemaGenerator) *Builder {
+	for i, c := range b.components {
+		if c.name == name {
+			b.components[i].schema = s
+			return b
+		}
+	}
+	b.components = append(b.components, component{name: name, schema: s})
+	return b
+}
+
+// RequestBody registers a reusable request body under components/requestBodies
+func (b *Builder) RequestBody(name string, body RequestBody) *Builder {
+	b.requestBodies[name] = body
+	return b
+}
+
+// Response registers a reusable response under components/responses
+func (b *Builder) Response(name string, response Response) *Builder {
+	b.responses[name] = response
+	return b
+}
+
+// Operation adds an operation for an HTTP method on a path (e.g. "GET", "/users/{id}")
+func (b *Builder) Operation(method, path string, op Operation) *Builder {
+	if b.paths[path] == nil {
+		b.paths[path] = make(map[string]Operation)
+	}
+	b.paths[path][strings.ToLower(method)] = op
+	return b
+}
+
+// Build generates the OpenAPI document
+func (b *Builder) Build() map[string]interface{} {
+	info := map[string]interface{}{
+		"title":   b.title,
+		"version": b.version,
+	}
+	if b.description != "" {
+		info["description"] = b.description
+	}
+
+	doc := map[string]interface{}{
+		"openapi": Version,
+		"info":    info,
+	}
+
+	if len(b.servers) > 0 {
+		servers := make([]interface{}, 0, len(b.servers))
+		for _, server := range b.servers {
+			entry := map[string]interface{}{"url": server.URL}
+			if server.Description != "" {
+				entry["description"] = server.Description
+			}
+			servers = append(servers, entry)
+		}
+		doc["servers"] = servers
+	}
+
+	paths := make(map[string]interface{}, len(b.paths))
+	for path, operations := range b.paths {
+		item := make(map[string]interface{}, len(operations))
+		for method, op := range operations {
+			item[method] = b.operationJSON(op)
+		}
+		paths[path] = item
+	}
+	doc["paths"] = paths
+
+	components := make(map[string]interface{})
+	if len(b.components) > 0 {
+		schemas := make(map[string]interface{}, len(b.components))
+		for _, c := range b.components {
+			schemas[c.name] = b.schemaJSON(c.schema, c.name)
+		}
+		components["schemas"] = schemas
+	}
+	if len(b.requestBodies) > 0 {
+		bodies := make(map[string]interface{}, len(b.requestBodies))
+		for name, body := range b.requestBodies {
+			bodies[name] = b.requestBodyJSON(body)
+		}
+		components["requestBodies"] = bodies
+	}
+	if len(b.responses) > 0 {
+		responses := make(map[string]interface{}, len(b.responses))
+		for name, response := range b.responses {
+			responses[name] = b.responseJSON(response)
+		}
+		components["responses"] = responses
+	}
+	if len(components) > 0 {
+		doc["components"] = components
+	}
+
+	return doc
+}
+
+// JSON generates the OpenAPI document as indented JSON
+func (b *Builder) JSON() ([]byte, error) {
+	return json.MarshalIndent(b.Build(), "", "  ")
+}
+
+// operationJSON generates a single operation object
+func (b *Builder) operationJSON(op Operation) map[string]interface{} {
+	result := map[string]interface{}{}
+	if op.OperationID != "" {
+		result["operationId"] = op.OperationID
+	}
+	if op.Summary != "" {
+		result["summary"] = op.Summary
+	}
+	if op.Description != "" {
+		result["description"] = op.Description
+	}
+	if len(op.Tags) > 0 {
+		result["tags"] = op.Tags
+	}
+	if op.Deprecated {
+		result["deprecated"] = true
+	}
+
+	if len(op.Parameters) > 0 {
+		params := make([]interface{}, 0, len(op.Parameters))
+		for _, param := range op.Parameters {
+			entry := map[string]interface{}{
+				"name": param.Name,
+				"in":   param.In,
+			}
+			if param.Description != "" {
+				entry["description"] = param.Description
+			}
+			if param.Required || param.In == "path" {
+				entry["required"] = true
+			}
+			if param.Schema != nil {
+				entry["schema"] = b.schemaJSON(param.Schema, "")
+			}
+			params = append(params, entry)
+		}
+		result["parameters"] = params
+	}
+
+	if op.RequestBody != nil {
+		if op.RequestBody.Ref != "" {
+			result["requestBody"] = componentRef("requestBodies", op.RequestBody.Ref)
+		} else {
+			result["requestBody"] = b.requestBodyJSON(*op.RequestBody)
+		}
+	}
+
+	responses := make(map[string]interface{}, len(op.Responses))
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		response := op.Responses[code]
+		if response.Ref != "" {
+			responses[code] = componentRef("responses", response.Ref)
+		} else {
+			responses[code] = b.responseJSON(response)
+		}
+	}
+	if len(responses) == 0 {
+		// OpenAPI requires at least one response
+		responses["default"] = map[string]interface{}{"description": "Default response"}
+	}
+	result["responses"] = responses
+
+	return result
+}
+
+// requestBodyJSON generates a request body object
+func (b *Builder) requestBodyJSON(body RequestBody) map[string]interface{} {
+	result := map[string]interface{}{}
+	if body.Description != "" {
+		result["description"] = body.Description
+	}
+	if body.Required {
+		result["required"] = true
+	}
+	result["content"] = b.contentJSON(body.ContentType, body.Schema)
+	return result
+}
+
+// responseJSON generates a response object
+func (b *Builder) responseJSON(response Response) map[string]interface{} {
+	description := response.Description
+	if description == "" {
+		description = "Response"
+	}
+	result := map[string]interface{}{"description": description}
+	if response.Schema != nil {
+		result["content"] = b.contentJSON(response.ContentType, response.Schema)
+	}
+	return result
+}
+
+// contentJSON generates a content map with a single media type
+func (b *Builder) contentJSON(contentType string, s schema.JSONSchemaGenerator) map[string]interface{} {
+	if contentType == "" {
+		contentType = DefaultContentType
+	}
+	mediaType := map[string]interface{}{}
+	if s != nil {
+		mediaType["schema"] = b.schemaJSON(s, "")
+	}
+	return map[string]interface{}{contentType: mediaType}
+}
+
+// schemaJSON generates the JSON Schema for s, replacing registered components with
+// $refs. self is the name of the component being defined, which is always inlined.
+func (b *Builder) schemaJSON(s schema.JSONSchemaGenerator, self string) map[string]interface{} {
+	if name, ok := b.componentName(s); ok && name != self {
+		return componentRef("schemas", name)
+	}
+
+	result := s.JSON()
+	switch typed := s.(type) {
+	case *schema.ObjectSchema:
+		if properties, ok := result["properties"].(map[string]interface{}); ok {
+			for name, prop := range typed.GetProperties() {
+				if generator, ok := prop.Schema.(schema.JSONSchemaGenerator); ok {
+					properties[name] = b.schemaJSON(generator, "")
+				}
+			}
+		}
+	case *schema.ArraySchema:
+		if generator, ok := typed.GetItemSchema().(schema.JSONSchemaGenerator); ok {
+			result["items"] = b.schemaJSON(generator, "")
+		}
+	}
+	return result
+}
+
+// componentName returns the name under which a schema instance was registered
+func (b *Builder) componentName(s schema.JSONSchemaGenerator) (string, bool) {
+	for _, c := range b.components {
+		if c.schema == s {
+			return c.name, true
+		}
+	}
+	return "", false
+}
+
+// componentRef builds a $ref to a named component of the given kind
+func componentRef(kind, name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/" + kind + "/" + name}
+}
@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	address := schema.Object().
+		Property("city", schema.String())
+	user := schema.Object().
+		Property("id", schema.Int()).
+		Property("name", schema.String().MinLength(1)).
+		Property("address", address)
+	createUser := schema.Object().
+		Property("name", schema.String().MinLength(1))
+
+	doc := NewOpenAPIBuilder().
+		Title("Users API").
+		Version("2.0.0").
+		Server("https://api.example.com").
+		Component("User", user).
+		Component("Address", address).
+		Response("NotFound", Response{Description: "Not found"}).
+		Operation("POST", "/users", Operation{
+			OperationID: "createUser",
+			RequestBody: &RequestBody{Schema: createUser, Required: true},
+			Responses: map[string]Response{
+				"201": {Description: "Created", Schema: user},
+			},
+		}).
+		Operation("GET", "/users/{id}", Operation{
+			Parameters: []Parameter{{Name: "id", In: "path", Schema: schema.Int()}},
+			Responses: map[string]Response{
+				"200": {Description: "OK", Schema: schema.Array(user)},
+				"404": {Ref: "NotFound"},
+			},
+		}).
+		Build()
+
+	if doc["openapi"] != Version {
+		t.Errorf("expected openapi %s, got %v", Version, doc["openapi"])
+	}
+
+	paths := doc["paths"].(map[string]interface{})
+	post := paths["/users"].(map[string]interface{})["post"].(map[string]interface{})
+	created := post["responses"].(map[string]interface{})["201"].(map[string]interface{})
+	createdSchema := created["content"].(map[string]interface{})[DefaultContentType].(map[string]interface{})["schema"].(map[string]interface{})
+	if createdSchema["$ref"] != "#/components/schemas/User" {
+		t.Errorf("expected response to reference User component, got %v", createdSchema)
+	}
+	if post["requestBody"].(map[string]interface{})["required"] != true {
+		t.Error("expected required request body")
+	}
+
+	get := paths["/users/{id}"].(map[string]interface{})["get"].(map[string]interface{})
+	param := get["parameters"].([]interface{})[0].(map[string]interface{})
+	if param["required"] != true {
+		t.Error("path parameters must be required")
+	}
+	ok := get["responses"].(map[string]interface{})["200"].(map[string]interface{})
+	listSchema := ok["content"].(map[string]interface{})[DefaultContentType].(map[string]interface{})["schema"].(map[string]interface{})
+	if listSchema["items"].(map[string]interface{})["$ref"] != "#/components/schemas/User" {
+		t.Errorf("expected array items to reference User component, got %v", listSchema)
+	}
+	if get["responses"].(map[string]interface{})["404"].(map[string]interface{})["$ref"] != "#/components/responses/NotFound" {
+		t.Error("expected response ref")
+	}
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	userJSON := schemas["User"].(map[string]interface{})
+	if userJSON["type"] != "object" {
+		t.Errorf("expected User component to be inlined, got %v", userJSON)
+	}
+	nested := userJSON["properties"].(map[string]interface{})["address"].(map[string]interface{})
+	if nested["$ref"] != "#/components/schemas/Address" {
+		t.Errorf("expected nested property to reference Address component, got %v", nested)
+	}
+}
+
+func TestBuilder_JSON(t *testing.T) {
+	data, err := NewOpenAPIBuilder().Operation("get", "/health", Operation{}).JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("generated document is not valid JSON: %v", err)
+	}
+	responses := doc["paths"].(map[string]interface{})["/health"].(map[string]interface{})["get"].(map[string]interface{})["responses"]
+	if _, ok := responses.(map[string]interface{})["default"]; !ok {
+		t.Error("expected default response when none are declared")
+	}
+}
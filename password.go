@@ -0,0 +1,651 @@
+package schema
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nyxstack/i18n"
+)
+
+// CharacterClass is a kind of character a password policy can require
+type CharacterClass string
+
+const (
+	CharacterLowercase CharacterClass = "lowercase" // Lowercase letters
+	CharacterUppercase CharacterClass = "uppercase" // Uppercase letters
+	CharacterDigit     CharacterClass = "digit"     // Decimal digits
+	CharacterSymbol    CharacterClass = "symbol"    // Anything else: punctuation, spaces, emoji
+)
+
+// redactedValue replaces the password in validation errors, which are often logged
+const redactedValue = "[redacted]"
+
+// Default error messages for password validation
+var (
+	passwordRequiredError = i18n.S("value is required")
+	passwordTypeError     = i18n.S("value must be a string")
+	passwordEntropyError  = i18n.S("password is too easy to guess")
+	passwordCommonError   = i18n.S("password is too common")
+	passwordTermError     = i18n.S("password must not contain personal information")
+)
+
+// Default error message functions that take parameters
+func passwordClassError(class CharacterClass) i18n.TranslatedFunc {
+	switch class {
+	case CharacterLowercase:
+		return i18n.S("password must contain a lowercase letter")
+	case CharacterUppercase:
+		return i18n.S("password must contain an uppercase letter")
+	case CharacterDigit:
+		return i18n.S("password must contain a digit")
+	default:
+		return i18n.S("password must contain a symbol")
+	}
+}
+
+func passwordMinClassesError(min int) i18n.TranslatedFunc {
+	return i18n.F("password must contain at least %d kinds of characters (lowercase, uppercase, digits, symbols)", min)
+}
+
+func passwordRepeatedError(max int) i18n.TranslatedFunc {
+	return i18n.F("password must not repeat a character more than %d times in a row", max)
+}
+
+// PasswordTermsFunc returns terms that a password must not contain for one parse,
+// such as the user's name or email address taken from the Go context
+type PasswordTermsFunc func(ctx *ValidationContext) []string
+
+// PasswordPolicy is the machine-readable form of a password schema's rules, for
+// frontends that check passwords as they are typed. Zero values mean "no rule".
+type PasswordPolicy struct {
+	MinLength       int              `json:"minLength,omitempty"`
+	MaxLength       int              `json:"maxLength,omitempty"`
+	MinEntropy      float64          `json:"minEntropy,omitempty"`
+	RequiredClasses []CharacterClass `json:"requiredClasses,omitempty"`
+	MinClasses      int              `json:"minClasses,omitempty"`
+	MaxRepeated     int              `json:"maxRepeated,omitempty"`
+	DenyCommon      bool             `json:"denyCommon,omitempty"`
+	DeniedTerms     []string         `json:"deniedTerms,omitempty"`
+}
+
+// PasswordSchema validates passwords against a policy and returns them unchanged.
+// Errors never include the password itself.
+type PasswordSchema struct {
+	Schema
+	minLength   *int
+	maxLength   *int
+	minEntropy  float64
+	required    []CharacterClass
+	minClasses  int
+	maxRepeated int
+	denyCommon  bool
+	denyTerms   []string // Lowercase
+	termsFunc   PasswordTermsFunc
+	nullable    bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	minLengthError    ErrorMessage
+	maxLengthError    ErrorMessage
+	entropyError      ErrorMessage
+	classErrors       map[CharacterClass]ErrorMessage
+	minClassesError   ErrorMessage
+	repeatedError     ErrorMessage
+	commonError       ErrorMessage
+	termError         ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// Password creates a new password schema without rules, with optional type error message
+func Password(errorMessage ...interface{}) *PasswordSchema {
+	schema := &PasswordSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+			sensitive:  true,
+		},
+		classErrors: map[CharacterClass]ErrorMessage{},
+	}
+	if len(errorMessage) > 0 {
+		schema.typeMismatchError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *PasswordSchema) Clone() *PasswordSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.required = slices.Clone(s.required)
+	c.denyTerms = slices.Clone(s.denyTerms)
+	c.classErrors = maps.Clone(s.classErrors)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *PasswordSchema) Freeze() *PasswordSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *PasswordSchema) Title(title string) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *PasswordSchema) Description(description string) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *PasswordSchema) ReadOnly() *PasswordSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *PasswordSchema) WriteOnly() *PasswordSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *PasswordSchema) Sensitive() *PasswordSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *PasswordSchema) Deprecated(reason string) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *PasswordSchema) Meta(key string, value interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *PasswordSchema) AsWarning(codes ...ErrorCode) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *PasswordSchema) AsInfo(codes ...ErrorCode) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// MinLength sets the minimum length in characters, with optional custom error message
+func (s *PasswordSchema) MinLength(min int, errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.minLength = &min
+	if len(errorMessage) > 0 {
+		s.minLengthError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// MaxLength sets the maximum length in characters, with optional custom error message.
+// Note that bcrypt only uses the first 72 bytes of a password.
+func (s *PasswordSchema) MaxLength(max int, errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.maxLength = &max
+	if len(errorMessage) > 0 {
+		s.maxLengthError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// MinEntropy sets the minimum estimated entropy in bits, with optional custom error
+// message. See PasswordEntropy for how it is estimated.
+func (s *PasswordSchema) MinEntropy(bits float64, errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.minEntropy = bits
+	if len(errorMessage) > 0 {
+		s.entropyError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Require requires at least one character of the class, with optional custom error message
+func (s *PasswordSchema) Require(class CharacterClass, errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	if !containsCharacterClass(s.required, class) {
+		s.required = append(s.required, class)
+	}
+	if len(errorMessage) > 0 {
+		s.classErrors[class] = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// MinClasses requires characters from at least n of the four classes, e.g. MinClasses(3)
+// for "three of lowercase, uppercase, digits and symbols", with optional custom error message
+func (s *PasswordSchema) MinClasses(n int, errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.minClasses = n
+	if len(errorMessage) > 0 {
+		s.minClassesError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// MaxRepeated rejects passwords repeating a character more than n times in a row
+// ("aaa" with n=2), with optional custom error message
+func (s *PasswordSchema) MaxRepeated(n int, errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.maxRepeated = n
+	if len(errorMessage) > 0 {
+		s.repeatedError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// DenyCommon rejects passwords from a built-in list of the most common passwords,
+// compared case-insensitively and also with trailing digits and symbols removed
+// ("Password123!" matches "password"), with optional custom error message
+func (s *PasswordSchema) DenyCommon(errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.denyCommon = true
+	if len(errorMessage) > 0 {
+		s.commonError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// DenyTerms rejects passwords containing any of the terms, compared case-insensitively,
+// such as the product name. Terms shorter than three characters are ignored.
+func (s *PasswordSchema) DenyTerms(terms ...string) *PasswordSchema {
+	s.checkMutable()
+	s.denyTerms = append(s.denyTerms, lowerAll(terms)...)
+	return s
+}
+
+// DenyTermsFunc adds terms computed for each parse, typically the user's name and email
+// read from ctx.Ctx, that the password must not contain
+func (s *PasswordSchema) DenyTermsFunc(fn PasswordTermsFunc) *PasswordSchema {
+	s.checkMutable()
+	s.termsFunc = fn
+	return s
+}
+
+// TermError sets a custom error message for passwords containing a denied term
+func (s *PasswordSchema) TermError(message interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.termError = toErrorMessage(message)
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *PasswordSchema) Optional() *PasswordSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *PasswordSchema) Required(errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *PasswordSchema) Nullable() *PasswordSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *PasswordSchema) TypeError(message string) *PasswordSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *PasswordSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *PasswordSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *PasswordSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// Policy returns the rules of the schema in machine-readable form. Terms from
+// DenyTermsFunc are computed per parse and are not included.
+func (s *PasswordSchema) Policy() PasswordPolicy {
+	policy := PasswordPolicy{
+		MinEntropy:      s.minEntropy,
+		RequiredClasses: s.required,
+		MinClasses:      s.minClasses,
+		MaxRepeated:     s.maxRepeated,
+		DenyCommon:      s.denyCommon,
+		DeniedTerms:     s.denyTerms,
+	}
+	if s.minLength != nil {
+		policy.MinLength = *s.minLength
+	}
+	if s.maxLength != nil {
+		policy.MaxLength = *s.maxLength
+	}
+	return policy
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *PasswordSchema) Transform(fn TransformFunc) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *PasswordSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *PasswordSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *PasswordSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a password against the policy and returns it unchanged
+func (s *PasswordSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse checks the policy; Parse runs the refine/transform pipeline on top
+func (s *PasswordSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if s.Schema.required {
+			message := passwordRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	password, ok := value.(string)
+	if !ok {
+		message := passwordTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(redactedValue, message, CodeInvalidType)}}
+	}
+	if password == "" && s.Schema.required {
+		message := passwordRequiredError(ctx.Locale)
+		if !isEmptyErrorMessage(s.requiredError) {
+			message = resolveErrorMessage(s.requiredError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+	}
+
+	var errors []ValidationError
+	fail := func(custom ErrorMessage, message i18n.TranslatedFunc, code ErrorCode) {
+		text := message(ctx.Locale)
+		if !isEmptyErrorMessage(custom) {
+			text = resolveErrorMessage(custom, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(redactedValue, text, code))
+	}
+
+	length := utf8.RuneCountInString(password)
+	if s.minLength != nil && length < *s.minLength {
+		fail(s.minLengthError, stringMinLengthError(*s.minLength), CodeMinLength)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		fail(s.maxLengthError, stringMaxLengthError(*s.maxLength), CodeMaxLength)
+	}
+
+	present := passwordClasses(password)
+	for _, class := range s.required {
+		if !present[class] {
+			fail(s.classErrors[class], passwordClassError(class), CodeCharacterClass)
+		}
+	}
+	if s.minClasses > 0 && len(present) < s.minClasses {
+		fail(s.minClassesError, passwordMinClassesError(s.minClasses), CodeCharacterClasses)
+	}
+
+	if s.maxRepeated > 0 && longestRun(password) > s.maxRepeated {
+		fail(s.repeatedError, passwordRepeatedError(s.maxRepeated), CodeRepeated)
+	}
+
+	if s.minEntropy > 0 && PasswordEntropy(password) < s.minEntropy {
+		fail(s.entropyError, passwordEntropyError, CodeEntropy)
+	}
+
+	if s.denyCommon && isCommonPassword(password) {
+		fail(s.commonError, passwordCommonError, CodeCommon)
+	}
+
+	terms := s.denyTerms
+	if s.termsFunc != nil {
+		terms = append(lowerAll(s.termsFunc(ctx)), terms...)
+	}
+	lower := strings.ToLower(password)
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) >= 3 && strings.Contains(lower, term) {
+			fail(s.termError, passwordTermError, CodeDeniedTerm)
+			break
+		}
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errors}
+	}
+	return ParseResult{Valid: true, Value: password, Errors: nil}
+}
+
+// JSON generates JSON Schema representation. The policy is included as
+// "x-password-policy" so that frontends can apply the same rules.
+func (s *PasswordSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	schema["format"] = "password"
+	addOptionalField(schema, "minLength", s.minLength)
+	addOptionalField(schema, "maxLength", s.maxLength)
+	schema["x-password-policy"] = s.Policy()
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
+
+func containsCharacterClass(classes []CharacterClass, class CharacterClass) bool {
+	for _, c := range classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// characterClassOf returns the class of r
+func characterClassOf(r rune) CharacterClass {
+	switch {
+	case unicode.IsLower(r):
+		return CharacterLowercase
+	case unicode.IsUpper(r):
+		return CharacterUppercase
+	case unicode.IsDigit(r):
+		return CharacterDigit
+	default:
+		return CharacterSymbol
+	}
+}
+
+// passwordClasses returns the classes present in password
+func passwordClasses(password string) map[CharacterClass]bool {
+	present := map[CharacterClass]bool{}
+	for _, r := range password {
+		present[characterClassOf(r)] = true
+	}
+	return present
+}
+
+// longestRun returns the length of the longest run of one repeated character
+func longestRun(value string) int {
+	longest, run := 0, 0
+	prev := rune(-1)
+	for _, r := range value {
+		if r == prev {
+			run++
+		} else {
+			run = 1
+		}
+		prev = r
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+// PasswordEntropy estimates the entropy of a password in bits. Each character adds
+// log2 of the size of the alphabet the password draws from (26 for each ASCII letter
+// case, 10 for digits, 33 for ASCII symbols and 100 for other characters), except
+// that a character repeating the previous one or continuing a sequence ("abc", "321")
+// adds one bit. This rewards length and variety; it does not detect dictionary
+// words, which DenyCommon and DenyTerms cover.
+func PasswordEntropy(password string) float64 {
+	pool := 0
+	var seen [5]bool
+	for _, r := range password {
+		kind := 4
+		switch {
+		case r >= 'a' && r <= 'z':
+			kind = 0
+		case r >= 'A' && r <= 'Z':
+			kind = 1
+		case r >= '0' && r <= '9':
+			kind = 2
+		case r < utf8.RuneSelf:
+			kind = 3
+		}
+		if !seen[kind] {
+			seen[kind] = true
+			pool += [5]int{26, 26, 10, 33, 100}[kind]
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	perCharacter := math.Log2(float64(pool))
+	bits := 0.0
+	prev := rune(-1)
+	for _, r := range password {
+		if prev >= 0 && (r == prev || r == prev+1 || r == prev-1) {
+			bits++
+		} else {
+			bits += perCharacter
+		}
+		prev = r
+	}
+	return bits
+}
+
+// isCommonPassword reports whether password, or password without its trailing digits
+// and symbols, is in the common password list
+func isCommonPassword(password string) bool {
+	commonPasswordsOnce.Do(func() {
+		commonPasswordSet = map[string]bool{}
+		for _, p := range strings.Fields(commonPasswords) {
+			commonPasswordSet[p] = true
+		}
+	})
+	lower := strings.ToLower(password)
+	if commonPasswordSet[lower] {
+		return true
+	}
+	base := strings.TrimRightFunc(lower, func(r rune) bool {
+		return characterClassOf(r) == CharacterDigit || characterClassOf(r) == CharacterSymbol
+	})
+	return base != "" && base != lower && commonPasswordSet[base]
+}
+
+var (
+	commonPasswordsOnce sync.Once
+	commonPasswordSet   map[string]bool
+)
+
+// commonPasswords lists frequently used passwords from public breach corpora, lowercase
+const commonPasswords = "123456 password 12345678 qwerty 123456789 12345 1234 111111 1234567 dragon " +
+	"123123 baseball abc123 football monkey letmein 696969 shadow master 666666 qwertyuiop " +
+	"123321 mustang 1234567890 michael 654321 superman 1qaz2wsx 7777777 121212 000000 qazwsx " +
+	"123qwe killer trustno1 jordan jennifer zxcvbnm asdfgh hunter buster soccer harley batman " +
+	"andrew tigger sunshine iloveyou 2000 charlie robert thomas hockey ranger daniel starwars " +
+	"112233 george computer michelle jessica pepper 1111 zxcvbn 555555 11111111 131313 " +
+	"freedom 777777 pass maggie 159753 aaaaaa ginger princess joshua cheese amanda summer love " +
+	"ashley nicole chelsea biteme matthew access yankees 987654321 dallas austin thunder taylor " +
+	"matrix minecraft william corvette hello martin heather secret merlin diamond 1234qwer " +
+	"gfhjkm hammer silver 222222 88888888 anthony justin test bailey q1w2e3r4t5 patrick internet " +
+	"scooter orange 11111 golfer cookie richard samantha bigdog guitar jackson whatever mickey " +
+	"chicken sparky snoopy maverick phoenix camaro peanut morgan welcome falcon cowboy ferrari " +
+	"samsung andrea smokey steelers joseph mercedes dakota arsenal eagles melissa boomer booboo " +
+	"spider nascar monster tigers yellow xxxxxx 123123123 gateway marina diablo bulldog qwer1234 " +
+	"compaq purple hardcore banana junior hannah 123654 porsche lakers iceman money cowboys 987654 " +
+	"london tennis 999999 ncc1701 coffee scooby 0000 miller boston q1w2e3r4 brandon yamaha " +
+	"chester mother forever johnny edward 333333 oliver redsox player nikita knight fender barney " +
+	"midnight please brandy chicago badboy slayer rangers charles angel flower bigdaddy rabbit " +
+	"wizard jasper enter rachel chris steven winner adidas victoria natasha 1q2w3e4r jasmine " +
+	"winter prince marine ghbdtn fishing cocacola casper james 232323 raiders 888888 " +
+	"marlboro gandalf asdfasdf crystal 87654321 12344321 golf 8675309 admin administrator root " +
+	"changeme default guest login passw0rd p@ssw0rd p@ssword qwerty123 password1 welcome1 " +
+	"abcdef abcd1234 aa123456 1q2w3e 1q2w3e4r5t asdf asdfghjkl zaq12wsx qazwsxedc iloveu lovely " +
+	"babygirl football1 princess1 monkey1 sunshine1 letmein1 trustme secret1 shadow1 superman1 " +
+	"master1 hello1 charlie1 dragon1 baseball1 michael1 jordan23 liverpool chocolate butterfly"
@@ -0,0 +1,134 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPasswordSchema_Rules(t *testing.T) {
+	ctx := DefaultValidationContext()
+	tests := []struct {
+		name     string
+		schema   *PasswordSchema
+		password interface{}
+		errors   []string
+	}{
+		{"no rules", Password(), "x", nil},
+		{"empty", Password(), "", []string{" required"}},
+		{"nil", Password(), nil, []string{" required"}},
+		{"wrong type", Password(), 123456, []string{" invalid_type"}},
+		{"min length", Password().MinLength(8), "short", []string{" min_length"}},
+		{"min length runes", Password().MinLength(4), "пароль", nil},
+		{"max length", Password().MaxLength(4), "toolong", []string{" max_length"}},
+		{"lowercase", Password().Require(CharacterLowercase), "ABC123", []string{" character_class"}},
+		{"uppercase", Password().Require(CharacterUppercase), "abc123", []string{" character_class"}},
+		{"digit", Password().Require(CharacterDigit), "abcDEF", []string{" character_class"}},
+		{"symbol", Password().Require(CharacterSymbol), "abcDEF123", []string{" character_class"}},
+		{"all classes", Password().Require(CharacterLowercase).Require(CharacterUppercase).Require(CharacterDigit).Require(CharacterSymbol), "aB3$", nil},
+		{"two missing", Password().Require(CharacterDigit).Require(CharacterSymbol), "abc", []string{" character_class", " character_class"}},
+		{"min classes met", Password().MinClasses(3), "abcDEF123", nil},
+		{"min classes unmet", Password().MinClasses(3), "abcDEF", []string{" character_classes"}},
+		{"unicode classes", Password().MinClasses(2), "ÄÖü", nil},
+		{"repeated", Password().MaxRepeated(2), "paaassword", []string{" repeated"}},
+		{"repeated allowed", Password().MaxRepeated(2), "paassword", nil},
+		{"common", Password().DenyCommon(), "password", []string{" common"}},
+		{"common case", Password().DenyCommon(), "QWERTY", []string{" common"}},
+		{"common with suffix", Password().DenyCommon(), "Password123!", []string{" common"}},
+		{"common with prefix", Password().DenyCommon(), "1password", nil},
+		{"digits only common", Password().DenyCommon(), "123456", []string{" common"}},
+		{"uncommon", Password().DenyCommon(), "correct horse battery staple", nil},
+		{"denied term", Password().DenyTerms("Acme"), "IloveACME2024", []string{" denied_term"}},
+		{"short term ignored", Password().DenyTerms("ab"), "abcdef", nil},
+		{"entropy", Password().MinEntropy(40), "aaaaaaaaaaaa", []string{" entropy"}},
+		{"entropy met", Password().MinEntropy(40), "Tr0ub4dor&3x", nil},
+		{"several", Password().MinLength(10).DenyCommon().MinEntropy(40), "letmein", []string{" common", " entropy", " min_length"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.password, ctx)
+			if codes := errorKeys(result.Errors); len(codes) > 0 || len(tt.errors) > 0 {
+				if !reflect.DeepEqual(codes, tt.errors) {
+					t.Errorf("errors = %v, want %v", codes, tt.errors)
+				}
+			}
+			if result.Valid != (len(tt.errors) == 0) {
+				t.Errorf("Valid = %v", result.Valid)
+			}
+			if result.Valid && result.Value != tt.password {
+				t.Errorf("Value = %v, want %v", result.Value, tt.password)
+			}
+			// The password never appears in errors
+			if s, ok := tt.password.(string); ok && s != "" {
+				for _, err := range result.Errors {
+					if strings.Contains(err.Value, s) {
+						t.Errorf("error leaks the password: %+v", err)
+					}
+				}
+			}
+		})
+	}
+}
+
+type passwordUserKey struct{}
+
+func TestPasswordSchema_DenyTermsFunc(t *testing.T) {
+	schema := Password().DenyTermsFunc(func(ctx *ValidationContext) []string {
+		email, _ := ctx.Ctx.Value(passwordUserKey{}).(string)
+		local, _, _ := strings.Cut(email, "@")
+		return []string{local}
+	}).TermError("do not use your email address")
+
+	ctx := DefaultValidationContext()
+	ctx.Ctx = context.WithValue(context.Background(), passwordUserKey{}, "jdoe@example.com")
+
+	result := schema.Parse("JDoe-rocks-2024", ctx)
+	if result.Valid || result.Errors[0].Code != "denied_term" || result.Errors[0].Message != "do not use your email address" {
+		t.Errorf("expected denied_term, got %v", result.Errors)
+	}
+	if result := schema.Parse("an-unrelated-phrase", ctx); !result.Valid {
+		t.Errorf("expected valid, got %v", result.Errors)
+	}
+}
+
+func TestPasswordEntropy(t *testing.T) {
+	tests := []struct {
+		password string
+		min, max float64
+	}{
+		{"", 0, 0},
+		{"aaaaaaaa", 5, 12},  // one random character, then repeats
+		{"abcdefgh", 5, 12},  // a sequence
+		{"qzmvlxtr", 37, 38}, // 8 * log2(26)
+		{"qZ7!mV2@", 52, 53}, // 8 * log2(95)
+		{"пароль12", 48, 56}, // non-ASCII widens the alphabet
+	}
+	for _, tt := range tests {
+		if got := PasswordEntropy(tt.password); got < tt.min || got > tt.max {
+			t.Errorf("PasswordEntropy(%q) = %.1f, want between %v and %v", tt.password, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestPasswordSchema_Policy(t *testing.T) {
+	schema := Password().
+		MinLength(12).
+		MaxLength(64).
+		Require(CharacterDigit).
+		MinClasses(3).
+		MaxRepeated(3).
+		DenyCommon().
+		DenyTerms("acme")
+
+	data, err := json.Marshal(schema.JSON())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"format":"password","maxLength":64,"minLength":12,"type":"string","x-password-policy":{"minLength":12,"maxLength":64,"requiredClasses":["digit"],"minClasses":3,"maxRepeated":3,"denyCommon":true,"deniedTerms":["acme"]}}`
+	if string(data) != expected {
+		t.Errorf("JSON = %s\nwant   %s", data, expected)
+	}
+}
@@ -0,0 +1,66 @@
+package schema
+
+// PatchSchema returns a schema validating RFC 7396 JSON Merge Patch documents for
+// values of s, so PATCH endpoints can validate the request body with the schema of
+// the resource:
+//
+//   - every property is optional: an absent property is left unchanged, so it is not
+//     filled with its default either
+//   - null deletes a property, so it is accepted for the properties that are optional
+//     or nullable in s, and rejected (code "invalid_type") for the others
+//   - nested object properties are patches of their schema too; arrays and other
+//     values replace the current value and are validated by their schema as is
+//   - the rules that need the whole object are dropped: the min/max property counts,
+//     dependent properties, conditions added with When and the refinements and
+//     transforms of the object
+//
+// Additional properties are handled as in s. The patch does not validate the patched
+// resource: parse the result of applying it with s for that.
+func (s *ObjectSchema) PatchSchema() *ObjectSchema {
+	result := s.Partial()
+	result.deletable = make(map[string]bool, len(s.properties))
+	for name, prop := range result.properties {
+		if !s.properties[name].Required || isNullableSchema(prop.Schema) {
+			result.deletable[name] = true
+		}
+		if nested, ok := prop.Schema.(*ObjectSchema); ok {
+			prop.Schema = nested.PatchSchema()
+			result.properties[name] = prop
+		}
+	}
+
+	result.minProps, result.maxProps = nil, nil
+	result.dependentRequired = nil
+	result.conditions = nil
+	result.Schema.effects = nil
+	return result
+}
+
+// IsPatch returns whether the schema validates merge patches (see PatchSchema)
+func (s *ObjectSchema) IsPatch() bool {
+	return s.deletable != nil
+}
+
+// isNullableSchema reports whether a schema accepts null as a value
+func isNullableSchema(s Parseable) bool {
+	nullable, ok := s.(interface{ IsNullable() bool })
+	return ok && nullable.IsNullable()
+}
+
+// patchNull handles a null property of a merge patch, which deletes the property if
+// it may be deleted, and returns the error reported otherwise
+func (s *ObjectSchema) patchNull(name string, ctx *ValidationContext) []ValidationError {
+	if s.deletable[name] {
+		return nil
+	}
+	message := objectNullPropError(name)(ctx.Locale)
+	return []ValidationError{NewFieldError(Path{FieldSegment(name)}, nil, message, CodeInvalidType)}
+}
+
+// patchPropertyJSON allows null for the properties a merge patch may delete
+func (s *ObjectSchema) patchPropertyJSON(name string, prop Parseable, jsonSchema map[string]interface{}) map[string]interface{} {
+	if !s.deletable[name] || isNullableSchema(prop) {
+		return jsonSchema
+	}
+	return map[string]interface{}{"anyOf": []interface{}{jsonSchema, map[string]interface{}{"type": "null"}}}
+}
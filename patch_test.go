@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestObjectSchema_PatchSchema(t *testing.T) {
+	user := Object().
+		Property("name", String().MinLength(2)).
+		Property("nickname", String().Optional()).
+		Property("avatar", String().Nullable()).
+		Property("tags", Array(String()).MinItems(1).Optional()).
+		Property("address", Object().
+			Property("street", String()).
+			Property("zip", String().Pattern(`^[0-9]{5}$`).Optional())).
+		MinProperties(3).
+		DependentRequired(map[string][]string{"nickname": {"name"}})
+	patch := user.PatchSchema()
+
+	tests := []struct {
+		name   string
+		value  map[string]interface{}
+		want   map[string]interface{}
+		errors []string
+	}{
+		{
+			name:   "empty patch",
+			value:  map[string]interface{}{},
+			want:   map[string]interface{}{},
+			errors: []string{},
+		},
+		{
+			name:   "partial nested object",
+			value:  map[string]interface{}{"address": map[string]interface{}{"zip": "12345"}},
+			want:   map[string]interface{}{"address": map[string]interface{}{"zip": "12345"}},
+			errors: []string{},
+		},
+		{
+			name:   "null deletes optional and nullable properties",
+			value:  map[string]interface{}{"nickname": nil, "avatar": nil, "address": map[string]interface{}{"zip": nil}},
+			want:   map[string]interface{}{"nickname": nil, "avatar": nil, "address": map[string]interface{}{"zip": nil}},
+			errors: []string{},
+		},
+		{
+			name:   "null for a required property",
+			value:  map[string]interface{}{"name": nil, "address": map[string]interface{}{"street": nil}},
+			errors: []string{"address property_invalid", "address.street invalid_type", "name invalid_type"},
+		},
+		{
+			name:   "values are still validated",
+			value:  map[string]interface{}{"name": "A", "tags": []interface{}{}},
+			errors: []string{"name min_length", "name property_invalid", "tags min_items", "tags property_invalid"},
+		},
+		{
+			name:   "unknown property",
+			value:  map[string]interface{}{"admin": true},
+			errors: []string{"admin additional_property"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := patch.Parse(tt.value, DefaultValidationContext())
+			if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.errors) {
+				t.Fatalf("errors = %v, want %v", got, tt.errors)
+			}
+			if len(tt.errors) == 0 && !reflect.DeepEqual(result.Value, tt.want) {
+				t.Errorf("Value = %v, want %v", result.Value, tt.want)
+			}
+		})
+	}
+
+	if user.IsPatch() || !patch.IsPatch() {
+		t.Error("IsPatch should report the derived schema only")
+	}
+	if len(user.GetRequiredProperties()) != 3 {
+		t.Errorf("PatchSchema changed the receiver: required = %v", user.GetRequiredProperties())
+	}
+}
+
+func TestObjectSchema_PatchSchemaJSON(t *testing.T) {
+	patch := Object().
+		Property("name", String()).
+		Property("nickname", String().Optional()).
+		Property("avatar", String().Nullable()).
+		PatchSchema()
+
+	doc := patch.JSON()
+	if _, ok := doc["required"]; ok {
+		t.Errorf("required = %v, want none", doc["required"])
+	}
+	properties := doc["properties"].(map[string]interface{})
+	want := map[string]interface{}{
+		"name":     map[string]interface{}{"type": "string"},
+		"nickname": map[string]interface{}{"anyOf": []interface{}{map[string]interface{}{"type": "string"}, map[string]interface{}{"type": "null"}}},
+		"avatar":   map[string]interface{}{"type": []interface{}{"string", "null"}},
+	}
+	if !reflect.DeepEqual(normalizeJSONSchema(properties), normalizeJSONSchema(want)) {
+		t.Errorf("properties = %v, want %v", properties, want)
+	}
+}
+
+func TestObjectSchema_PatchSchemaSkipsDefaults(t *testing.T) {
+	user := Object().
+		Property("name", String()).
+		Property("role", String().Default("member")).
+		Property("addr", Object().
+			Property("city", String()).
+			Property("country", String().Default("US")))
+
+	// An absent property is left unchanged by the patch, so it must not get its default
+	result := user.PatchSchema().Parse(map[string]interface{}{"name": "bob", "addr": map[string]interface{}{"city": "x"}}, DefaultValidationContext())
+	want := map[string]interface{}{"name": "bob", "addr": map[string]interface{}{"city": "x"}}
+	if !result.Valid || !reflect.DeepEqual(result.Value, want) {
+		t.Errorf("Parse() = %v %v, want %v", result.Value, result.Errors, want)
+	}
+}
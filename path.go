@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPointerEscaper escapes property names for use as JSON Pointer reference tokens
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// PathSegment is one step of a Path: either an object property or an array index
+type PathSegment struct {
+	Field   string // Property name (empty for index segments)
+	Index   int    // Array index (only meaningful when IsIndex is true)
+	IsIndex bool
+}
+
+// FieldSegment creates a path segment for an object property
+func FieldSegment(name string) PathSegment {
+	return PathSegment{Field: name}
+}
+
+// IndexSegment creates a path segment for an array or tuple index
+func IndexSegment(index int) PathSegment {
+	return PathSegment{Index: index, IsIndex: true}
+}
+
+// String returns the property name, or the index in brackets (e.g. "[0]")
+func (s PathSegment) String() string {
+	if s.IsIndex {
+		return "[" + strconv.Itoa(s.Index) + "]"
+	}
+	return s.Field
+}
+
+// Path locates a value inside the input being validated. The empty path refers
+// to the input itself.
+type Path []PathSegment
+
+// Field returns a copy of the path extended with an object property
+func (p Path) Field(name string) Path {
+	return p.append(FieldSegment(name))
+}
+
+// Index returns a copy of the path extended with an array index
+func (p Path) Index(index int) Path {
+	return p.append(IndexSegment(index))
+}
+
+// append returns a copy of the path with seg added, never sharing the backing array
+func (p Path) append(seg PathSegment) Path {
+	result := make(Path, len(p), len(p)+1)
+	copy(result, p)
+	return append(result, seg)
+}
+
+// JSONPointer returns the path as an RFC 6901 JSON Pointer (e.g. "/items/0/name").
+// The empty path is the empty pointer "".
+func (p Path) JSONPointer() string {
+	var b strings.Builder
+	for _, seg := range p {
+		b.WriteByte('/')
+		if seg.IsIndex {
+			b.WriteString(strconv.Itoa(seg.Index))
+			continue
+		}
+		b.WriteString(jsonPointerEscaper.Replace(seg.Field))
+	}
+	return b.String()
+}
+
+// DotPath returns the path in dotted form with bracketed indexes (e.g. "items[0].name")
+func (p Path) DotPath() string {
+	var b strings.Builder
+	for i, seg := range p {
+		if !seg.IsIndex && i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg.String())
+	}
+	return b.String()
+}
+
+// String returns the dotted form of the path
+func (p Path) String() string {
+	return p.DotPath()
+}
+
+// MarshalJSON encodes the path as an array of property names and numeric indexes
+// (e.g. ["items", 0, "name"])
+func (p Path) MarshalJSON() ([]byte, error) {
+	segments := make([]interface{}, 0, len(p))
+	for _, seg := range p {
+		if seg.IsIndex {
+			segments = append(segments, seg.Index)
+		} else {
+			segments = append(segments, seg.Field)
+		}
+	}
+	return json.Marshal(segments)
+}
+
+// UnmarshalJSON decodes a path encoded by MarshalJSON
+func (p *Path) UnmarshalJSON(data []byte) error {
+	var segments []interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&segments); err != nil {
+		return err
+	}
+
+	path := make(Path, 0, len(segments))
+	for _, seg := range segments {
+		switch v := seg.(type) {
+		case string:
+			path = append(path, FieldSegment(v))
+		case json.Number:
+			index, err := strconv.Atoi(v.String())
+			if err != nil {
+				return fmt.Errorf("schema: invalid path index %s", v)
+			}
+			path = append(path, IndexSegment(index))
+		default:
+			return fmt.Errorf("schema: invalid path segment %v", seg)
+		}
+	}
+	*p = path
+	return nil
+}
+
+// pathHasPrefix reports whether path starts with prefix
+func pathHasPrefix(path, prefix Path) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, seg := range prefix {
+		if path[i] != seg {
+			return false
+		}
+	}
+	return true
+}
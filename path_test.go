@@ -0,0 +1,168 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPath_Formats(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        Path
+		jsonPointer string
+		dotPath     string
+	}{
+		{"empty", Path{}, "", ""},
+		{"field", Path{}.Field("name"), "/name", "name"},
+		{"nested", Path{}.Field("items").Index(0).Field("name"), "/items/0/name", "items[0].name"},
+		{"root index", Path{}.Index(2).Field("id"), "/2/id", "[2].id"},
+		{"nested index", Path{}.Field("matrix").Index(1).Index(3), "/matrix/1/3", "matrix[1][3]"},
+		{"escaped", Path{}.Field("a/b").Field("c~d"), "/a~1b/c~0d", "a/b.c~d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.path.JSONPointer(); got != tt.jsonPointer {
+				t.Errorf("JSONPointer() = %q, want %q", got, tt.jsonPointer)
+			}
+			if got := tt.path.DotPath(); got != tt.dotPath {
+				t.Errorf("DotPath() = %q, want %q", got, tt.dotPath)
+			}
+		})
+	}
+}
+
+func TestPath_JSON(t *testing.T) {
+	path := Path{}.Field("items").Index(0).Field("name")
+
+	data, err := json.Marshal(path)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `["items",0,"name"]` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	var decoded Path
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, path) {
+		t.Errorf("Unmarshal() = %v, want %v", decoded, path)
+	}
+
+	if data, _ := json.Marshal(NewPrimitiveError(1, "bad", "invalid")); !strings.Contains(string(data), `"path":[]`) {
+		t.Errorf("primitive error path should encode as [], got %s", data)
+	}
+}
+
+func TestPath_ParseErrors(t *testing.T) {
+	s := Object().
+		Property("name", String().MinLength(2)).
+		Property("tags", Array(Object().Property("label", String().MinLength(2))))
+
+	result := s.Parse(map[string]interface{}{
+		"name": "A",
+		"tags": []interface{}{
+			map[string]interface{}{"label": "ok"},
+			map[string]interface{}{"label": "x"},
+		},
+	}, DefaultValidationContext())
+	if result.Valid {
+		t.Fatal("expected invalid result")
+	}
+
+	pointers := map[string]bool{}
+	for _, err := range result.Errors {
+		pointers[err.Path.JSONPointer()] = true
+	}
+	for _, want := range []string{"/name", "/tags/1/label"} {
+		if !pointers[want] {
+			t.Errorf("missing error at %s, got %v", want, result.Errors)
+		}
+	}
+}
+
+func TestParseResult_Error(t *testing.T) {
+	s := Object().
+		Property("name", String()).
+		Property("address", Object().
+			Property("zip", String().MinLength(5)).
+			Property("city", String()))
+
+	valid := s.Parse(map[string]interface{}{
+		"name":    "Ada",
+		"address": map[string]interface{}{"zip": "12345", "city": "London"},
+	}, DefaultValidationContext())
+	if err := valid.Error(); err != nil {
+		t.Errorf("Error() = %v, want nil", err)
+	}
+
+	result := s.Parse(map[string]interface{}{
+		"address": map[string]interface{}{"zip": "1"},
+	}, DefaultValidationContext())
+	err := result.Error()
+	if err == nil {
+		t.Fatal("Error() = nil, want error")
+	}
+
+	message := err.Error()
+	for _, want := range []string{"validation errors:", "\n  name: ", "\n  address:\n", "\n    zip:", "\n    city: "} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Error() missing %q:\n%s", want, message)
+		}
+	}
+
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Error("expected errors.As to find a ValidationError")
+	}
+}
+
+func TestParseResult_ErrorHelpers(t *testing.T) {
+	s := Object().
+		Property("name", String().MinLength(3, "too short")).
+		Property("items", Array(Object().Property("sku", String())))
+
+	result := s.Parse(map[string]interface{}{
+		"name":  "Al",
+		"items": []interface{}{map[string]interface{}{}},
+	}, DefaultValidationContext())
+
+	flat := result.FlattenErrors()
+	if got := flat["/name"]; !reflect.DeepEqual(got[len(got)-1:], []string{"too short"}) {
+		t.Errorf("FlattenErrors()[/name] = %v", got)
+	}
+	if len(flat["/items/0/sku"]) != 1 {
+		t.Errorf("FlattenErrors() = %v, want an entry for /items/0/sku", flat)
+	}
+
+	for _, path := range []string{"/items/0/sku", "items[0].sku"} {
+		if err, ok := result.FirstErrorFor(path); !ok || err.Code != CodeRequired {
+			t.Errorf("FirstErrorFor(%q) = %v, %v", path, err, ok)
+		}
+	}
+	if _, ok := result.FirstErrorFor("missing"); ok {
+		t.Error("FirstErrorFor(missing) found an error")
+	}
+
+	list := result.FormatErrors(ErrorFormatList)
+	if lines := strings.Split(list, "\n"); len(lines) != len(result.Errors) || !strings.Contains(list, "name: too short") {
+		t.Errorf("FormatErrors(ErrorFormatList) =\n%s", list)
+	}
+	if tree := result.FormatErrors(ErrorFormatTree); tree != result.Error().Error() {
+		t.Errorf("FormatErrors(ErrorFormatTree) =\n%s", tree)
+	}
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal([]byte(result.FormatErrors(ErrorFormatJSON)), &decoded); err != nil || len(decoded) != len(result.Errors) {
+		t.Errorf("FormatErrors(ErrorFormatJSON) = %s (%v)", result.FormatErrors(ErrorFormatJSON), err)
+	}
+
+	valid := ParseResult{Valid: true}
+	if valid.FormatErrors(ErrorFormatList) != "" || valid.FormatErrors(ErrorFormatTree) != "" || valid.FormatErrors(ErrorFormatJSON) != "[]" {
+		t.Error("expected empty renderings without errors")
+	}
+}
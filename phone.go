@@ -0,0 +1,539 @@
+package schema
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// PhoneType restricts the kind of line a phone number belongs to
+type PhoneType int
+
+const (
+	PhoneTypeAny      PhoneType = iota // Accept any number (default)
+	PhoneTypeMobile                    // Accept mobile numbers only
+	PhoneTypeLandline                  // Accept fixed-line numbers only
+)
+
+// Default error messages for phone number validation
+var (
+	phoneRequiredError = i18n.S("value is required")
+	phoneTypeError     = i18n.S("value must be a string")
+	phoneFormatError   = i18n.S("value must be a valid phone number")
+	phoneMobileError   = i18n.S("value must be a mobile phone number")
+	phoneLandlineError = i18n.S("value must be a landline phone number")
+)
+
+// Default error message functions that take parameters
+func phoneRegionError(regions []string) i18n.TranslatedFunc {
+	return i18n.F("phone number must be from one of: %s", strings.Join(regions, ", "))
+}
+
+// phoneRegion describes the numbering plan of a region: its country calling code,
+// the length of national significant numbers, the national trunk prefix dropped in
+// international format and the leading digits of mobile and fixed-line numbers
+type phoneRegion struct {
+	code      string
+	minLength int
+	maxLength int
+	trunk     string
+	mobile    []string
+	landline  []string
+	nanp      bool // North American Numbering Plan: mobile and fixed lines share ranges
+}
+
+// phoneRegions holds the numbering plans Parse understands; numbers with other
+// calling codes are checked against E.164 only
+var phoneRegions = map[string]phoneRegion{
+	"US": {code: "1", minLength: 10, maxLength: 10, trunk: "1", nanp: true},
+	"CA": {code: "1", minLength: 10, maxLength: 10, trunk: "1", nanp: true},
+	"GB": {code: "44", minLength: 9, maxLength: 10, trunk: "0", mobile: []string{"71", "72", "73", "74", "75", "77", "78", "79"}, landline: []string{"1", "2"}},
+	"DE": {code: "49", minLength: 6, maxLength: 13, trunk: "0", mobile: []string{"15", "16", "17"}, landline: []string{"2", "3", "4", "5", "6", "7", "8", "9"}},
+	"FR": {code: "33", minLength: 9, maxLength: 9, trunk: "0", mobile: []string{"6", "7"}, landline: []string{"1", "2", "3", "4", "5", "9"}},
+	"ES": {code: "34", minLength: 9, maxLength: 9, mobile: []string{"6", "7"}, landline: []string{"8", "9"}},
+	"IT": {code: "39", minLength: 6, maxLength: 11, mobile: []string{"3"}, landline: []string{"0"}},
+	"NL": {code: "31", minLength: 9, maxLength: 9, trunk: "0", mobile: []string{"6"}, landline: []string{"1", "2", "3", "4", "5", "7"}},
+	"CH": {code: "41", minLength: 9, maxLength: 9, trunk: "0", mobile: []string{"75", "76", "77", "78", "79"}, landline: []string{"2", "3", "4", "5", "6", "8", "9"}},
+	"AU": {code: "61", minLength: 9, maxLength: 9, trunk: "0", mobile: []string{"4"}, landline: []string{"2", "3", "7", "8"}},
+	"JP": {code: "81", minLength: 9, maxLength: 10, trunk: "0", mobile: []string{"70", "80", "90"}, landline: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}},
+	"IN": {code: "91", minLength: 10, maxLength: 10, trunk: "0", mobile: []string{"6", "7", "8", "9"}, landline: []string{"1", "2", "3", "4", "5"}},
+}
+
+// PhoneSchema validates phone numbers and returns them in E.164 format ("+14155552671").
+// Numbers in international format ("+44 20 7946 0958", "0044 20 7946 0958") are always
+// accepted; numbers in national format ("020 7946 0958") need DefaultRegion.
+type PhoneSchema struct {
+	Schema
+	defaultRegion string
+	regions       []string
+	phoneType     PhoneType
+	nullable      bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	regionError       ErrorMessage
+	phoneTypeError    ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// Phone creates a new phone number schema
+func Phone(errorMessage ...interface{}) *PhoneSchema {
+	schema := &PhoneSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *PhoneSchema) Clone() *PhoneSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.regions = slices.Clone(s.regions)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *PhoneSchema) Freeze() *PhoneSchema {
+	s.freeze()
+	return s
+}
+
+// Title sets the title of the schema
+func (s *PhoneSchema) Title(title string) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *PhoneSchema) Description(description string) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *PhoneSchema) ReadOnly() *PhoneSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *PhoneSchema) WriteOnly() *PhoneSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *PhoneSchema) Sensitive() *PhoneSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *PhoneSchema) Deprecated(reason string) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *PhoneSchema) Meta(key string, value interface{}) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *PhoneSchema) AsWarning(codes ...ErrorCode) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *PhoneSchema) AsInfo(codes ...ErrorCode) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *PhoneSchema) Default(value interface{}) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *PhoneSchema) DefaultFunc(fn func() interface{}) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *PhoneSchema) Example(example string) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// DefaultRegion sets the region (ISO 3166-1 alpha-2, e.g. "DE") used to parse numbers
+// written without a country calling code
+func (s *PhoneSchema) DefaultRegion(region string) *PhoneSchema {
+	s.checkMutable()
+	s.defaultRegion = strings.ToUpper(region)
+	return s
+}
+
+// Regions restricts numbers to the given regions (ISO 3166-1 alpha-2). Regions sharing
+// a calling code, such as "US" and "CA" (+1), cannot be told apart: allowing one
+// allows numbers from all of them.
+func (s *PhoneSchema) Regions(regions ...string) *PhoneSchema {
+	s.checkMutable()
+	s.regions = make([]string, len(regions))
+	for i, region := range regions {
+		s.regions[i] = strings.ToUpper(region)
+	}
+	return s
+}
+
+// RegionError sets a custom error message for numbers outside Regions
+func (s *PhoneSchema) RegionError(message interface{}) *PhoneSchema {
+	s.checkMutable()
+	s.regionError = toErrorMessage(message)
+	return s
+}
+
+// Type restricts the kind of line, with optional custom error message. The line type
+// is only known for regions with built-in numbering plans; other numbers fail the
+// check. North American numbers count as both mobile and landline.
+func (s *PhoneSchema) Type(phoneType PhoneType, errorMessage ...interface{}) *PhoneSchema {
+	s.checkMutable()
+	s.phoneType = phoneType
+	if len(errorMessage) > 0 {
+		s.phoneTypeError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *PhoneSchema) Optional() *PhoneSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *PhoneSchema) Required(errorMessage ...interface{}) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *PhoneSchema) Nullable() *PhoneSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *PhoneSchema) TypeError(message string) *PhoneSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for invalid numbers
+func (s *PhoneSchema) FormatError(message string) *PhoneSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *PhoneSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *PhoneSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *PhoneSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetDefaultRegion returns the region used for numbers in national format
+func (s *PhoneSchema) GetDefaultRegion() string {
+	return s.defaultRegion
+}
+
+// GetRegions returns the allowed regions
+func (s *PhoneSchema) GetRegions() []string {
+	return s.regions
+}
+
+// GetPhoneType returns the required line type
+func (s *PhoneSchema) GetPhoneType() PhoneType {
+	return s.phoneType
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *PhoneSchema) Transform(fn TransformFunc) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *PhoneSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *PhoneSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *PhoneSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a phone number and returns it in E.164 format
+func (s *PhoneSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the phone number constraints; Parse runs the refine/transform pipeline on top
+func (s *PhoneSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := phoneRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	str, ok := value.(string)
+	if !ok {
+		message := phoneTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	number, ok := parsePhoneNumber(str, s.defaultRegion)
+	if !ok {
+		message := phoneFormatError(ctx.Locale)
+		if !isEmptyErrorMessage(s.formatError) {
+			message = resolveErrorMessage(s.formatError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+	}
+
+	var errors []ValidationError
+
+	if len(s.regions) > 0 && !number.inRegions(s.regions) {
+		message := phoneRegionError(s.regions)(ctx.Locale)
+		if !isEmptyErrorMessage(s.regionError) {
+			message = resolveErrorMessage(s.regionError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeRegion))
+	}
+
+	if s.phoneType != PhoneTypeAny && !number.isType(s.phoneType) {
+		message := phoneMobileError(ctx.Locale)
+		if s.phoneType == PhoneTypeLandline {
+			message = phoneLandlineError(ctx.Locale)
+		}
+		if !isEmptyErrorMessage(s.phoneTypeError) {
+			message = resolveErrorMessage(s.phoneTypeError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodePhoneType))
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errors}
+	}
+	return ParseResult{Valid: true, Value: "+" + number.code + number.national, Errors: nil}
+}
+
+// phoneNumber is a number split into its country calling code and national
+// significant number; regions lists the known regions using the calling code
+type phoneNumber struct {
+	code     string
+	national string
+	regions  []string
+}
+
+// parsePhoneNumber normalizes an international or national phone number. Spaces,
+// hyphens, dots, slashes and parentheses are ignored.
+func parsePhoneNumber(input string, defaultRegion string) (phoneNumber, bool) {
+	var digits strings.Builder
+	international := false
+	for i, r := range strings.TrimSpace(input) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			international = true
+		case r == ' ' || r == '-' || r == '.' || r == '/' || r == '(' || r == ')':
+		default:
+			return phoneNumber{}, false
+		}
+	}
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		international, number = true, number[2:]
+	}
+
+	if !international {
+		region, ok := phoneRegions[defaultRegion]
+		if !ok {
+			return phoneNumber{}, false
+		}
+		if region.trunk != "" {
+			number = strings.TrimPrefix(number, region.trunk)
+		}
+		number = region.code + number
+	}
+
+	// E.164: at most 15 digits, no leading zero in the calling code
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+		return phoneNumber{}, false
+	}
+
+	// Calling codes are prefix-free, so at most one length matches a known plan
+	for length := 1; length <= 3; length++ {
+		regions := phoneRegionsFor(number[:length])
+		if len(regions) == 0 {
+			continue
+		}
+		parsed := phoneNumber{code: number[:length], national: number[length:], regions: regions}
+		return parsed, parsed.valid()
+	}
+	return phoneNumber{national: number}, true
+}
+
+// phoneRegionsFor returns the known regions using a calling code, sorted
+func phoneRegionsFor(code string) []string {
+	var regions []string
+	for name, region := range phoneRegions {
+		if region.code == code {
+			regions = append(regions, name)
+		}
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// valid checks the national number against the numbering plan of its calling code
+func (n phoneNumber) valid() bool {
+	region := phoneRegions[n.regions[0]]
+	if len(n.national) < region.minLength || len(n.national) > region.maxLength {
+		return false
+	}
+	if region.trunk != "" && strings.HasPrefix(n.national, region.trunk) {
+		return false
+	}
+	// NANP area codes and exchanges start with 2-9
+	if region.nanp && (n.national[0] < '2' || n.national[3] < '2') {
+		return false
+	}
+	return true
+}
+
+// inRegions reports whether the number belongs to one of the regions
+func (n phoneNumber) inRegions(regions []string) bool {
+	for _, region := range regions {
+		for _, own := range n.regions {
+			if region == own {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isType reports whether the number is known to be of the given line type
+func (n phoneNumber) isType(phoneType PhoneType) bool {
+	if len(n.regions) == 0 {
+		return false
+	}
+	region := phoneRegions[n.regions[0]]
+	if region.nanp {
+		return true
+	}
+	mobile := hasAnyPrefix(n.national, region.mobile)
+	if phoneType == PhoneTypeMobile {
+		return mobile
+	}
+	// Mobile ranges can be nested in landline ones ("90" in Japan)
+	return !mobile && hasAnyPrefix(n.national, region.landline)
+}
+
+// hasAnyPrefix reports whether value starts with one of the prefixes
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON generates JSON Schema representation; "phone" is a custom format
+func (s *PhoneSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["format"] = "phone"
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
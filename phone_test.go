@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPhoneSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *PhoneSchema
+		value    interface{}
+		expected string // E.164 output, empty when invalid
+		code     ErrorCode
+	}{
+		{"e164", Phone(), "+14155552671", "+14155552671", ""},
+		{"formatted", Phone(), "+1 (415) 555-2671", "+14155552671", ""},
+		{"international prefix", Phone(), "0044 20 7946 0958", "+442079460958", ""},
+		{"dots", Phone(), "+33.6.12.34.56.78", "+33612345678", ""},
+		{"unknown calling code", Phone(), "+380 44 123 4567", "+380441234567", ""},
+		{"national needs region", Phone(), "020 7946 0958", "", "format"},
+		{"national gb", Phone().DefaultRegion("GB"), "020 7946 0958", "+442079460958", ""},
+		{"national de", Phone().DefaultRegion("de"), "030 1234567", "+49301234567", ""},
+		{"national us trunk", Phone().DefaultRegion("US"), "1-415-555-2671", "+14155552671", ""},
+		{"international ignores region", Phone().DefaultRegion("US"), "+49 30 1234567", "+49301234567", ""},
+		{"too long", Phone(), "+1234567890123456", "", "format"},
+		{"too short", Phone(), "+1234567", "", "format"},
+		{"letters", Phone(), "+1 415 CALL NOW", "", "format"},
+		{"extension", Phone(), "+14155552671 ext. 12", "", "format"},
+		{"nanp area code", Phone(), "+1 115 555 2671", "", "format"},
+		{"nanp length", Phone(), "+1 415 555 267", "", "format"},
+		{"trunk in international", Phone(), "+44 020 7946 0958", "", "format"},
+		{"fr length", Phone(), "+33 6 12 34 56", "", "format"},
+		{"not a string", Phone(), 4155552671, "", "invalid_type"},
+		{"region allowed", Phone().Regions("US", "DE"), "+49 151 23456789", "+4915123456789", ""},
+		{"region shared code", Phone().Regions("US"), "+1 613 555 0123", "+16135550123", ""},
+		{"region rejected", Phone().Regions("US", "DE"), "+44 7911 123456", "", "region"},
+		{"region unknown code", Phone().Regions("US"), "+380441234567", "", "region"},
+		{"mobile gb", Phone().Type(PhoneTypeMobile), "+44 7911 123456", "+447911123456", ""},
+		{"mobile rejects landline", Phone().Type(PhoneTypeMobile), "+44 20 7946 0958", "", "phone_type"},
+		{"landline de", Phone().Type(PhoneTypeLandline), "+49 30 1234567", "+49301234567", ""},
+		{"landline rejects mobile", Phone().Type(PhoneTypeLandline), "+49 170 1234567", "", "phone_type"},
+		{"landline jp nested mobile", Phone().Type(PhoneTypeLandline), "+81 90 1234 5678", "", "phone_type"},
+		{"nanp is both", Phone().Type(PhoneTypeMobile), "+1 415 555 2671", "+14155552671", ""},
+		{"type unknown code", Phone().Type(PhoneTypeMobile), "+380501234567", "", "phone_type"},
+		{"required nil", Phone(), nil, "", "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != (tt.expected != "") {
+				t.Fatalf("Parse(%v) = %v (%v)", tt.value, result.Valid, result.Errors)
+			}
+			if result.Valid && result.Value != tt.expected {
+				t.Errorf("Value = %v, want %v", result.Value, tt.expected)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	result := Phone().Regions("us", "ca").Type(PhoneTypeLandline).Parse("+44 7911 123456", ctx)
+	if codes := errorKeys(result.Errors); !reflect.DeepEqual(codes, []string{" phone_type", " region"}) {
+		t.Errorf("errors = %v", codes)
+	}
+	if result.Errors[0].Message != "phone number must be from one of: US, CA" {
+		t.Errorf("message = %q", result.Errors[0].Message)
+	}
+	if result := Phone().Optional().Parse(nil, ctx); !result.Valid {
+		t.Errorf("expected optional nil to be valid, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,85 @@
+package schema
+
+import "github.com/nyxstack/i18n"
+
+// objectNullPropError is reported in presence mode for an explicit null the property
+// schema does not allow
+func objectNullPropError(prop string) i18n.TranslatedFunc {
+	return i18n.F("property %s must not be null", prop)
+}
+
+// Presence tells whether a property was absent from the input, set to null or set to
+// a value. Parses in presence mode (see WithPresence) report it for every property of
+// the objects they parse in ParseResult.Present.
+type Presence int
+
+const (
+	PresenceAbsent Presence = iota // The property is missing
+	PresenceNull                   // The property is set to null
+	PresenceSet                    // The property is set to a value
+)
+
+// String returns the name of the presence ("absent", "null" or "set")
+func (p Presence) String() string {
+	switch p {
+	case PresenceNull:
+		return "null"
+	case PresenceSet:
+		return "set"
+	default:
+		return "absent"
+	}
+}
+
+// MarshalJSON encodes the presence as its name
+func (p Presence) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + p.String() + `"`), nil
+}
+
+// WithPresence enables presence mode, which tells a missing property from one set to
+// null, as PATCH handlers need to:
+//
+//   - Optional properties may be absent, Nullable properties may be null: an explicit
+//     null is rejected (code "invalid_type") unless the property schema is nullable
+//   - ParseResult.Present reports whether each property of the parsed object was
+//     absent, null or set, with the properties of nested objects under their dot path
+//     ("address.zip")
+//
+// Without presence mode, an optional property also accepts null.
+func (vc *ValidationContext) WithPresence() *ValidationContext {
+	vc.TrackPresence = true
+	return vc
+}
+
+// presenceOf returns the presence of a property in an object
+func presenceOf(object map[string]interface{}, name string) Presence {
+	value, ok := object[name]
+	switch {
+	case !ok:
+		return PresenceAbsent
+	case value == nil:
+		return PresenceNull
+	default:
+		return PresenceSet
+	}
+}
+
+// rejectsExplicitNull reports whether presence mode rejects a null for the schema:
+// optional schemas that are not nullable. Required schemas report the null themselves
+// and schemas without nullability (Null, Unknown, ...) decide for themselves.
+func rejectsExplicitNull(s Parseable) bool {
+	nullable, ok := s.(interface{ IsNullable() bool })
+	if !ok || nullable.IsNullable() {
+		return false
+	}
+	required, ok := s.(interface{ IsRequired() bool })
+	return ok && !required.IsRequired()
+}
+
+// addNestedPresence adds the presence of the properties of a nested object under the
+// path of the property holding it
+func addNestedPresence(present map[string]Presence, name string, nested map[string]Presence) {
+	for path, presence := range nested {
+		present[name+"."+path] = presence
+	}
+}
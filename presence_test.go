@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPresence(t *testing.T) {
+	patch := Object().
+		Property("name", String().MinLength(1).Optional()).
+		Property("nickname", String().Optional().Nullable()).
+		Property("address", Object().
+			Property("zip", String().Optional().Nullable()).
+			Optional())
+
+	tests := []struct {
+		name    string
+		body    string
+		valid   bool
+		present map[string]Presence
+		errors  []string
+	}{
+		{
+			name:    "absent properties",
+			body:    `{}`,
+			valid:   true,
+			present: map[string]Presence{"name": PresenceAbsent, "nickname": PresenceAbsent, "address": PresenceAbsent},
+		},
+		{
+			name:  "explicit null clears a nullable property",
+			body:  `{"nickname": null, "address": {"zip": null}}`,
+			valid: true,
+			present: map[string]Presence{
+				"name": PresenceAbsent, "nickname": PresenceNull, "address": PresenceSet, "address.zip": PresenceNull,
+			},
+		},
+		{
+			name:    "set properties",
+			body:    `{"name": "Ada", "address": {}}`,
+			valid:   true,
+			present: map[string]Presence{"name": PresenceSet, "nickname": PresenceAbsent, "address": PresenceSet, "address.zip": PresenceAbsent},
+		},
+		{
+			name:    "null for an optional property",
+			body:    `{"name": null}`,
+			valid:   false,
+			present: map[string]Presence{"name": PresenceNull, "nickname": PresenceAbsent, "address": PresenceAbsent},
+			errors:  []string{"name invalid_type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseBytes(patch, []byte(tt.body), DefaultValidationContext().WithPresence())
+			if result.Valid != tt.valid {
+				t.Fatalf("Valid = %v, want %v: %v", result.Valid, tt.valid, result.Errors)
+			}
+			if !reflect.DeepEqual(result.Present, tt.present) {
+				t.Errorf("Present = %v, want %v", result.Present, tt.present)
+			}
+			if !tt.valid {
+				if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.errors) {
+					t.Errorf("errors = %v, want %v", got, tt.errors)
+				}
+			}
+		})
+	}
+
+	// Without presence mode an optional property accepts null and nothing is reported
+	result := ParseBytes(patch, []byte(`{"name": null}`), DefaultValidationContext())
+	if !result.Valid || result.Present != nil {
+		t.Errorf("Parse = %v (%v), Present = %v", result.Valid, result.Errors, result.Present)
+	}
+}
+
+func TestPresenceString(t *testing.T) {
+	for presence, want := range map[Presence]string{PresenceAbsent: "absent", PresenceNull: "null", PresenceSet: "set"} {
+		if got := presence.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
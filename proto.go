@@ -0,0 +1,460 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ProtoOptions configures ExportProto
+type ProtoOptions struct {
+	Package   string // Proto package name (e.g. "users.v1")
+	GoPackage string // Value of option go_package, omitted when empty
+
+	// FieldNumbers pins field numbers by "Message.property" (nested messages use
+	// their full name, e.g. "User.Address.city"). Other fields are numbered in
+	// alphabetical order, skipping pinned numbers. Pin the numbers of published
+	// messages so that adding a property never renumbers existing fields.
+	FieldNumbers map[string]int
+}
+
+// ExportProto generates a proto3 file from named schemas so that services consuming
+// the same models over gRPC stay in sync with the validation rules. Each object schema
+// becomes a message and each top-level enum schema an enum:
+//
+//   - nested objects become nested messages and string enums nested enums
+//   - arrays become repeated fields and records/maps become map<string, V> fields
+//   - unions (OneOf/AnyOf) become oneof groups
+//   - optional or nullable scalars use proto3 optional
+//   - refs to other exported schemas use the referenced message
+//   - schemas accepting any value use google.protobuf.Value
+//
+// Constructs that proto3 cannot express, such as nested arrays or tuples, are
+// reported as errors.
+func ExportProto(schemas map[string]Parseable, opts ProtoOptions) ([]byte, error) {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := &protoWriter{opts: opts}
+	var body strings.Builder
+	for _, name := range names {
+		doc, err := jsonDocument(schemas[name])
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+		typeName := protoTypeName(name)
+		switch {
+		case jsonHasType(doc, "object"):
+			err = w.message(&body, typeName, doc, "")
+		case doc["enum"] != nil:
+			err = w.enum(&body, typeName, doc, "")
+		default:
+			err = fmt.Errorf("only object and enum schemas can be exported as top-level proto types")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString("syntax = \"proto3\";\n")
+	if opts.Package != "" {
+		fmt.Fprintf(&out, "\npackage %s;\n", opts.Package)
+	}
+	if w.usesStruct {
+		out.WriteString("\nimport \"google/protobuf/struct.proto\";\n")
+	}
+	if opts.GoPackage != "" {
+		fmt.Fprintf(&out, "\noption go_package = %q;\n", opts.GoPackage)
+	}
+	out.WriteString("\n")
+	out.WriteString(body.String())
+	return []byte(out.String()), nil
+}
+
+// protoIdentifier matches the names proto accepts for fields and enum values
+var protoIdentifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// protoWriter accumulates the state shared by all messages of one file
+type protoWriter struct {
+	opts       ProtoOptions
+	usesStruct bool // google.protobuf.Value is referenced
+}
+
+// protoField is one field of a message
+type protoField struct {
+	name     string
+	jsonName string
+	doc      map[string]interface{}
+	required bool
+}
+
+// message writes an object schema as a message, declaring nested types inside it
+func (w *protoWriter) message(b *strings.Builder, name string, doc map[string]interface{}, indent string) error {
+	writeProtoSeparator(b)
+	writeProtoComment(b, doc, indent)
+	fmt.Fprintf(b, "%smessage %s {\n", indent, protoLastName(name))
+
+	properties, _ := doc["properties"].(map[string]interface{})
+	required := map[string]bool{}
+	for _, r := range jsonStrings(doc["required"]) {
+		required[r] = true
+	}
+
+	fields := make([]protoField, 0, len(properties))
+	props := map[string]string{} // Property by field name
+	for _, prop := range sortedMapKeys(properties) {
+		field := protoFieldName(prop)
+		if !protoIdentifier.MatchString(field) {
+			return fmt.Errorf("%s: property %q is not a valid proto field name", name, prop)
+		}
+		if other, exists := props[field]; exists {
+			return fmt.Errorf("%s: properties %q and %q are both named %s in proto", name, other, prop, field)
+		}
+		props[field] = prop
+		propDoc, _ := properties[prop].(map[string]interface{})
+		fields = append(fields, protoField{name: field, jsonName: prop, doc: propDoc, required: required[prop]})
+	}
+
+	numbers := newProtoNumbering(name, w.opts.FieldNumbers)
+	var declarations, lines strings.Builder
+	inner := indent + "  "
+	for _, field := range fields {
+		if members := jsonUnionMembers(field.doc); members != nil {
+			fmt.Fprintf(&lines, "%soneof %s {\n", inner, field.name)
+			for i, member := range members {
+				typeName, err := w.fieldType(&declarations, name, fmt.Sprintf("%sOption%d", protoTypeName(field.jsonName), i+1), member, inner)
+				if err != nil {
+					return fmt.Errorf("%s.%s: %w", name, field.jsonName, err)
+				}
+				if strings.HasPrefix(typeName, "repeated ") || strings.HasPrefix(typeName, "map<") {
+					return fmt.Errorf("%s.%s: oneof members cannot be repeated or maps", name, field.jsonName)
+				}
+				memberName := field.name + "_" + protoFieldName(protoLastName(typeName))
+				fmt.Fprintf(&lines, "%s  %s %s = %d;\n", inner, typeName, memberName, numbers.next(field.jsonName+"."+memberName))
+			}
+			fmt.Fprintf(&lines, "%s}\n", inner)
+			continue
+		}
+
+		typeName, err := w.fieldType(&declarations, name, protoTypeName(field.jsonName), field.doc, inner)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, field.jsonName, err)
+		}
+		label := ""
+		if (!field.required || jsonHasType(field.doc, "null")) && protoIsScalar(typeName) {
+			label = "optional "
+		}
+		writeProtoComment(&lines, field.doc, inner)
+		fmt.Fprintf(&lines, "%s%s%s %s = %d", inner, label, typeName, field.name, numbers.next(field.jsonName))
+		if protoJSONName(field.name) != field.jsonName {
+			fmt.Fprintf(&lines, " [json_name = %q]", field.jsonName)
+		}
+		lines.WriteString(";\n")
+	}
+
+	b.WriteString(declarations.String())
+	if declarations.Len() > 0 && lines.Len() > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(lines.String())
+	fmt.Fprintf(b, "%s}\n", indent)
+	return nil
+}
+
+// fieldType returns the proto type of a field, writing nested message and enum
+// declarations for it into decls. nestedName is used for nested declarations.
+func (w *protoWriter) fieldType(decls *strings.Builder, parent, nestedName string, doc map[string]interface{}, indent string) (string, error) {
+	if ref, ok := doc["$ref"].(string); ok {
+		return protoTypeName(ref[strings.LastIndex(ref, "/")+1:]), nil
+	}
+	if jsonUnionMembers(doc) != nil {
+		return "", fmt.Errorf("unions are only supported as message fields")
+	}
+
+	switch {
+	case jsonHasType(doc, "object"):
+		properties, _ := doc["properties"].(map[string]interface{})
+		if values, ok := doc["additionalProperties"].(map[string]interface{}); ok && len(properties) == 0 {
+			valueType, err := w.fieldType(decls, parent, nestedName+"Value", values, indent)
+			if err != nil {
+				return "", err
+			}
+			if strings.HasPrefix(valueType, "repeated ") || strings.HasPrefix(valueType, "map<") {
+				return "", fmt.Errorf("map values cannot be repeated or maps")
+			}
+			return "map<string, " + valueType + ">", nil
+		}
+		full := parent + "." + nestedName
+		if err := w.message(decls, full, doc, indent); err != nil {
+			return "", err
+		}
+		return nestedName, nil
+
+	case jsonHasType(doc, "array"):
+		items, ok := doc["items"].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("tuples cannot be expressed in proto3")
+		}
+		itemType, err := w.fieldType(decls, parent, nestedName+"Item", items, indent)
+		if err != nil {
+			return "", err
+		}
+		if strings.HasPrefix(itemType, "repeated ") || strings.HasPrefix(itemType, "map<") {
+			return "", fmt.Errorf("nested arrays and arrays of maps cannot be expressed in proto3")
+		}
+		return "repeated " + itemType, nil
+
+	case doc["enum"] != nil && jsonHasType(doc, "string"):
+		if err := w.enum(decls, nestedName, doc, indent); err != nil {
+			return "", err
+		}
+		return nestedName, nil
+
+	case jsonHasType(doc, "string"):
+		if doc["contentEncoding"] != nil {
+			return "bytes", nil
+		}
+		return "string", nil
+
+	case jsonHasType(doc, "integer"):
+		switch doc["format"] {
+		case "int8", "int16", "int32":
+			return "int32", nil
+		case "uint8", "uint16", "uint32":
+			return "uint32", nil
+		case "uint64":
+			return "uint64", nil
+		}
+		return "int64", nil
+
+	case jsonHasType(doc, "number"):
+		if doc["format"] == "float" {
+			return "float", nil
+		}
+		return "double", nil
+
+	case jsonHasType(doc, "boolean"):
+		return "bool", nil
+
+	case doc["type"] == nil:
+		w.usesStruct = true
+		return "google.protobuf.Value", nil
+	}
+	return "", fmt.Errorf("type %v cannot be expressed in proto3", doc["type"])
+}
+
+// enum writes an enum schema. Names come from x-enum-varnames when present, and
+// integer enums keep their values; a zero UNSPECIFIED value is added when needed.
+func (w *protoWriter) enum(b *strings.Builder, name string, doc map[string]interface{}, indent string) error {
+	values, _ := doc["enum"].([]interface{})
+	labels := jsonStrings(doc["x-enum-varnames"])
+	prefix := strings.ToUpper(protoFieldName(name))
+
+	type enumValue struct {
+		name   string
+		number int
+	}
+	entries := make([]enumValue, 0, len(values)+1)
+	labelsByEntry := map[string]string{}
+	hasZero := false
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		label := fmt.Sprintf("%v", value)
+		if i < len(labels) && labels[i] != "" {
+			label = labels[i]
+		}
+		number := i + 1
+		if f, ok := value.(float64); ok {
+			if f != float64(int32(f)) {
+				return fmt.Errorf("enum value %v is not a valid proto enum number", value)
+			}
+			number = int(f)
+		}
+		hasZero = hasZero || number == 0
+		entry := prefix + "_" + strings.ToUpper(protoFieldName(label))
+		if !protoIdentifier.MatchString(entry) {
+			return fmt.Errorf("enum value %q is not a valid proto enum value name", label)
+		}
+		if other, exists := labelsByEntry[entry]; exists {
+			return fmt.Errorf("enum values %q and %q are both named %s in proto", other, label, entry)
+		}
+		labelsByEntry[entry] = label
+		entries = append(entries, enumValue{entry, number})
+	}
+	if !hasZero {
+		entries = append([]enumValue{{prefix + "_UNSPECIFIED", 0}}, entries...)
+	}
+
+	writeProtoSeparator(b)
+	writeProtoComment(b, doc, indent)
+	fmt.Fprintf(b, "%senum %s {\n", indent, name)
+	for _, entry := range entries {
+		fmt.Fprintf(b, "%s  %s = %d;\n", indent, entry.name, entry.number)
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+	return nil
+}
+
+// protoNumbering assigns field numbers within one message
+type protoNumbering struct {
+	message string
+	pinned  map[string]int
+	used    map[int]bool
+	free    int
+}
+
+func newProtoNumbering(message string, pinned map[string]int) *protoNumbering {
+	n := &protoNumbering{message: message, pinned: pinned, used: map[int]bool{}, free: 1}
+	prefix := message + "."
+	for key, number := range pinned {
+		if strings.HasPrefix(key, prefix) && !strings.Contains(key[len(prefix):], ".") {
+			n.used[number] = true
+		}
+	}
+	return n
+}
+
+// next returns the pinned number of a field or the next free one, skipping the
+// range 19000-19999 reserved by protobuf
+func (n *protoNumbering) next(field string) int {
+	if number, ok := n.pinned[n.message+"."+field]; ok {
+		return number
+	}
+	for n.used[n.free] || (n.free >= 19000 && n.free <= 19999) {
+		n.free++
+	}
+	n.used[n.free] = true
+	return n.free
+}
+
+// writeProtoSeparator separates a declaration from the previous one with a blank line
+func writeProtoSeparator(b *strings.Builder) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+}
+
+// writeProtoComment writes the title and description of a schema as comments
+func writeProtoComment(b *strings.Builder, doc map[string]interface{}, indent string) {
+	for _, key := range []string{"title", "description"} {
+		if text, ok := doc[key].(string); ok && text != "" {
+			for _, line := range strings.Split(text, "\n") {
+				fmt.Fprintf(b, "%s// %s\n", indent, line)
+			}
+		}
+	}
+}
+
+// protoTypeName converts a schema or property name to a PascalCase type name
+func protoTypeName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-' || r == '.' || r == ' ' || r == '/':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// protoFieldName converts a property name to snake_case
+func protoFieldName(name string) string {
+	return strings.ToLower(envSegment(name))
+}
+
+// protoJSONName returns the JSON name protoc derives from a snake_case field name
+func protoJSONName(field string) string {
+	parts := strings.Split(field, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// protoLastName returns the unqualified part of a nested type name
+func protoLastName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// protoIsScalar reports whether a proto type can be marked optional
+func protoIsScalar(typeName string) bool {
+	switch typeName {
+	case "string", "bytes", "bool", "int32", "int64", "uint32", "uint64", "float", "double":
+		return true
+	}
+	return false
+}
+
+// jsonDocument returns the JSON Schema of s with the uniform value types produced
+// by encoding/json (string slices become []interface{}, numbers float64)
+func jsonDocument(s Parseable) (map[string]interface{}, error) {
+	generator, ok := s.(JSONSchemaGenerator)
+	if !ok {
+		return nil, fmt.Errorf("%T does not generate JSON Schema", s)
+	}
+	data, err := json.Marshal(generator.JSON())
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// jsonHasType reports whether a JSON Schema document allows the given type
+func jsonHasType(doc map[string]interface{}, typeName string) bool {
+	switch t := doc["type"].(type) {
+	case string:
+		return t == typeName
+	case []interface{}:
+		for _, item := range t {
+			if item == typeName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonUnionMembers returns the non-null members of a oneOf or anyOf document
+func jsonUnionMembers(doc map[string]interface{}) []map[string]interface{} {
+	list, ok := doc["oneOf"].([]interface{})
+	if !ok {
+		if list, ok = doc["anyOf"].([]interface{}); !ok {
+			return nil
+		}
+	}
+	members := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if member, ok := item.(map[string]interface{}); ok && member["type"] != "null" {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+// jsonStrings converts a decoded JSON array of strings
+func jsonStrings(value interface{}) []string {
+	list, _ := value.([]interface{})
+	result := make([]string, len(list))
+	for i, item := range list {
+		result[i], _ = item.(string)
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportProto(t *testing.T) {
+	address := Object().
+		Property("city", String()).
+		Property("zipCode", String().Optional())
+	user := Object().
+		Property("id", Int64()).
+		Property("name", String().Description("Display name")).
+		Property("age", Int32().Optional()).
+		Property("tags", Array(String())).
+		Property("address", address).
+		Property("role", Enum("admin", "user")).
+		Property("scores", Record(String(), Number())).
+		Property("contact", OneOf(String(), Int())).
+		Property("extra", Any()).
+		Property("created_at", String()).
+		Property("manager", Ref("#/components/schemas/User", NewSchemaRegistry()))
+
+	out, err := ExportProto(map[string]Parseable{
+		"User":   user,
+		"status": Enum("active", "banned"),
+	}, ProtoOptions{
+		Package:      "users.v1",
+		GoPackage:    "example.com/users",
+		FieldNumbers: map[string]int{"User.name": 20},
+	})
+	if err != nil {
+		t.Fatalf("ExportProto() error = %v", err)
+	}
+	proto := string(out)
+
+	for _, want := range []string{
+		"syntax = \"proto3\";\n\npackage users.v1;\n\nimport \"google/protobuf/struct.proto\";\n\noption go_package = \"example.com/users\";\n",
+		"message User {\n  message Address {\n    string city = 1;\n    optional string zip_code = 2;\n  }\n",
+		"  enum Role {\n    ROLE_UNSPECIFIED = 0;\n    ROLE_ADMIN = 1;\n    ROLE_USER = 2;\n  }\n",
+		"  Address address = 1;\n",
+		"  optional int32 age = 2;\n",
+		"  oneof contact {\n    string contact_string = 3;\n    int64 contact_int64 = 4;\n  }\n",
+		"  string created_at = 5 [json_name = \"created_at\"];\n",
+		"  google.protobuf.Value extra = 6;\n",
+		"  int64 id = 7;\n",
+		"  User manager = 8;\n",
+		"  // Display name\n  string name = 20;\n",
+		"  Role role = 9;\n",
+		"  map<string, double> scores = 10;\n",
+		"  repeated string tags = 11;\n",
+		"enum Status {\n  STATUS_UNSPECIFIED = 0;\n  STATUS_ACTIVE = 1;\n  STATUS_BANNED = 2;\n}\n",
+	} {
+		if !strings.Contains(proto, want) {
+			t.Errorf("ExportProto() output missing %q\n%s", want, proto)
+		}
+	}
+
+	again, _ := ExportProto(map[string]Parseable{"User": user, "status": Enum("active", "banned")}, ProtoOptions{
+		Package:      "users.v1",
+		GoPackage:    "example.com/users",
+		FieldNumbers: map[string]int{"User.name": 20},
+	})
+	if string(again) != proto {
+		t.Error("ExportProto() output is not deterministic")
+	}
+}
+
+func TestExportProto_Unsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema Parseable
+		want   string
+	}{
+		{"top-level string", String(), "only object and enum schemas"},
+		{"nested array", Object().Property("grid", Array(Array(Int()))), "Grid.grid: nested arrays"},
+		{"tuple", Object().Property("point", Tuple(Float(), Float())), "tuples cannot be expressed"},
+		{"union in array", Object().Property("ids", Array(OneOf(String(), Int()))), "unions are only supported as message fields"},
+		{"invalid field name", Object().Property("2fa", Bool()), `Grid: property "2fa" is not a valid proto field name`},
+		{"invalid field character", Object().Property("o'brien", String()), `property "o'brien" is not a valid proto field name`},
+		{"duplicate field name", Object().Property("userName", String()).Property("user_name", String()), `properties "userName" and "user_name" are both named user_name`},
+		{"invalid enum value", Enum("it's"), `enum value "it's" is not a valid proto enum value name`},
+		{"duplicate enum value", Enum("dark-mode", "dark_mode"), `enum values "dark-mode" and "dark_mode" are both named GRID_DARK_MODE`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExportProto(map[string]Parseable{"Grid": tt.schema}, ProtoOptions{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ExportProto() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/nyxstack/i18n"
 )
@@ -44,6 +46,14 @@ type RecordSchema struct {
 	typeMismatchError ErrorMessage
 }
 
+// recordEntry is an entry of a parsed record: its key, the key as it appears in
+// paths, and its value
+type recordEntry struct {
+	key   interface{}
+	name  string
+	value interface{}
+}
+
 // Record creates a new record schema with key and value schemas
 func Record(keySchema, valueSchema Parseable, errorMessage ...interface{}) *RecordSchema {
 	schema := &RecordSchema{
@@ -60,28 +70,131 @@ func Record(keySchema, valueSchema Parseable, errorMessage ...interface{}) *Reco
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *RecordSchema) Clone() *RecordSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema and its key and value schemas immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *RecordSchema) Freeze() *RecordSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and its key and value schemas
+func (s *RecordSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.keySchema, s.valueSchema)
+}
+
+// Children returns the key schema and the value schema
+func (s *RecordSchema) Children() []SchemaChild {
+	var children []SchemaChild
+	if s.keySchema != nil {
+		children = append(children, SchemaChild{Segment: KeySegment, Schema: s.keySchema})
+	}
+	if s.valueSchema != nil {
+		children = append(children, SchemaChild{Segment: ValuesSegment, Schema: s.valueSchema})
+	}
+	return children
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *RecordSchema) Title(title string) *RecordSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *RecordSchema) Description(description string) *RecordSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *RecordSchema) ReadOnly() *RecordSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *RecordSchema) WriteOnly() *RecordSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *RecordSchema) Sensitive() *RecordSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *RecordSchema) Deprecated(reason string) *RecordSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *RecordSchema) Meta(key string, value interface{}) *RecordSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *RecordSchema) AsWarning(codes ...ErrorCode) *RecordSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *RecordSchema) AsInfo(codes ...ErrorCode) *RecordSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *RecordSchema) Default(value interface{}) *RecordSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *RecordSchema) DefaultFunc(fn func() interface{}) *RecordSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *RecordSchema) Example(example map[string]interface{}) *RecordSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
@@ -90,18 +203,33 @@ func (s *RecordSchema) Example(example map[string]interface{}) *RecordSchema {
 
 // Keys sets the schema for record keys
 func (s *RecordSchema) Keys(keySchema Parseable) *RecordSchema {
+	s.checkMutable()
 	s.keySchema = keySchema
 	return s
 }
 
+// KeyPattern requires every key to match the regular expression, with optional custom
+// error message. It replaces the key schema and panics if pattern is not a valid
+// regular expression.
+func (s *RecordSchema) KeyPattern(pattern string, errorMessage ...interface{}) *RecordSchema {
+	return s.Keys(String().Pattern(pattern, errorMessage...))
+}
+
+// KeysEnum requires every key to be one of keys. It replaces the key schema.
+func (s *RecordSchema) KeysEnum(keys ...string) *RecordSchema {
+	return s.Keys(Enum(keys...))
+}
+
 // Values sets the schema for record values
 func (s *RecordSchema) Values(valueSchema Parseable) *RecordSchema {
+	s.checkMutable()
 	s.valueSchema = valueSchema
 	return s
 }
 
 // MinProperties sets the minimum number of properties with optional custom error message
 func (s *RecordSchema) MinProperties(min int, errorMessage ...interface{}) *RecordSchema {
+	s.checkMutable()
 	s.minProps = &min
 	if len(errorMessage) > 0 {
 		s.minPropsError = toErrorMessage(errorMessage[0])
@@ -111,6 +239,7 @@ func (s *RecordSchema) MinProperties(min int, errorMessage ...interface{}) *Reco
 
 // MaxProperties sets the maximum number of properties with optional custom error message
 func (s *RecordSchema) MaxProperties(max int, errorMessage ...interface{}) *RecordSchema {
+	s.checkMutable()
 	s.maxProps = &max
 	if len(errorMessage) > 0 {
 		s.maxPropsError = toErrorMessage(errorMessage[0])
@@ -120,6 +249,7 @@ func (s *RecordSchema) MaxProperties(max int, errorMessage ...interface{}) *Reco
 
 // Size sets both min and max properties to the same value
 func (s *RecordSchema) Size(size int) *RecordSchema {
+	s.checkMutable()
 	s.minProps = &size
 	s.maxProps = &size
 	return s
@@ -129,12 +259,14 @@ func (s *RecordSchema) Size(size int) *RecordSchema {
 
 // Optional marks the schema as optional
 func (s *RecordSchema) Optional() *RecordSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *RecordSchema) Required(errorMessage ...interface{}) *RecordSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -144,6 +276,7 @@ func (s *RecordSchema) Required(errorMessage ...interface{}) *RecordSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *RecordSchema) Nullable() *RecordSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
@@ -152,18 +285,21 @@ func (s *RecordSchema) Nullable() *RecordSchema {
 
 // TypeError sets a custom error message for type mismatch validation
 func (s *RecordSchema) TypeError(message string) *RecordSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
 
 // KeyError sets a custom error message for key validation failures
 func (s *RecordSchema) KeyError(message string) *RecordSchema {
+	s.checkMutable()
 	s.keyError = toErrorMessage(message)
 	return s
 }
 
 // ValueError sets a custom error message for value validation failures
 func (s *RecordSchema) ValueError(message string) *RecordSchema {
+	s.checkMutable()
 	s.valueError = toErrorMessage(message)
 	return s
 }
@@ -205,10 +341,36 @@ func (s *RecordSchema) GetMaxProperties() *int {
 	return s.maxProps
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *RecordSchema) Transform(fn TransformFunc) *RecordSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *RecordSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *RecordSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *RecordSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *RecordSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses a record value, returning the final parsed value
 func (s *RecordSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the record constraints; Parse runs the refine/transform pipeline on top
+func (s *RecordSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
 	// Handle nil values
@@ -221,7 +383,7 @@ func (s *RecordSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := recordRequiredError(ctx.Locale)
@@ -231,37 +393,49 @@ func (s *RecordSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
 	}
 
 	// Type check - accept map or struct
-	var recordMap map[string]interface{}
+	var entries []recordEntry
+	var keyType reflect.Type // Integer key type of maps whose keys are kept
 	v := reflect.ValueOf(value)
 
 	switch v.Kind() {
 	case reflect.Map:
-		// Convert map to map[string]interface{}
-		recordMap = make(map[string]interface{})
-		for _, key := range v.MapKeys() {
-			keyStr := fmt.Sprintf("%v", key.Interface())
-			recordMap[keyStr] = v.MapIndex(key).Interface()
+		// Integer keys are kept, other keys are converted to strings
+		if kind := v.Type().Key().Kind(); kind >= reflect.Int && kind <= reflect.Uint64 {
+			keyType = v.Type().Key()
+		}
+		entries = make([]recordEntry, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().Interface()
+			name := fmt.Sprintf("%v", key)
+			if keyType == nil {
+				key = name
+			}
+			entries = append(entries, recordEntry{key: key, name: name, value: iter.Value().Interface()})
+		}
+		if ctx.FailFast || ctx.MaxErrors > 0 {
+			// An error limit keeps the same errors on every parse of the same input
+			slices.SortFunc(entries, func(a, b recordEntry) int { return strings.Compare(a.name, b.name) })
 		}
 	case reflect.Struct:
-		// Convert struct to map[string]interface{}
-		recordMap = make(map[string]interface{})
+		// Convert the exported fields to entries
 		structType := v.Type()
 		for i := 0; i < v.NumField(); i++ {
 			field := structType.Field(i)
 			if field.IsExported() { // Only exported fields
-				recordMap[field.Name] = v.Field(i).Interface()
+				entries = append(entries, recordEntry{key: field.Name, name: field.Name, value: v.Field(i).Interface()})
 			}
 		}
 	default:
@@ -272,21 +446,37 @@ func (s *RecordSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
-	// Now validate the record against all constraints
-	finalValue := make(map[string]interface{}, len(recordMap)) // This will be our parsed record
+	// Reject oversized and too deeply nested input before looking at its entries
+	if err, exceeded := ctx.checkCollectionSize(value, len(entries)); exceeded {
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{err}}
+	}
+	child, ok := ctx.descend(value)
+	if !ok {
+		return ctx.depthExceeded(value)
+	}
+	defer child.release()
+
+	// Now validate the record against all constraints. Records with integer keys parse
+	// to a map with the same key type, others to map[string]interface{}.
+	finalValue := make(map[string]interface{}, len(entries)) // This will be our parsed record
+	var typedValue reflect.Value
+	if keyType != nil {
+		typedValue = reflect.MakeMapWithSize(reflect.MapOf(keyType, reflect.TypeOf((*interface{})(nil)).Elem()), len(entries))
+	}
+	var warnings []ValidationError // Warnings of the values
 
 	// Validate size constraints
-	size := len(recordMap)
+	size := len(entries)
 	if s.minProps != nil && size < *s.minProps {
 		message := recordMinPropsError(*s.minProps)(ctx.Locale)
 		if !isEmptyErrorMessage(s.minPropsError) {
 			message = resolveErrorMessage(s.minPropsError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(recordMap, message, "min_properties"))
+		errors = append(errors, NewPrimitiveError(value, message, CodeMinProperties))
 	}
 
 	if s.maxProps != nil && size > *s.maxProps {
@@ -294,51 +484,68 @@ func (s *RecordSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		if !isEmptyErrorMessage(s.maxPropsError) {
 			message = resolveErrorMessage(s.maxPropsError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(recordMap, message, "max_properties"))
+		errors = append(errors, NewPrimitiveError(value, message, CodeMaxProperties))
 	}
 
 	// Validate each key-value pair
-	for key, val := range recordMap {
-		var finalKey string = key
+	for _, entry := range entries {
+		if ctx.stopCollecting(len(errors)) {
+			break
+		}
+		key, val := entry.name, entry.value
+		if err, exceeded := ctx.checkStringLength(entry.key, FieldSegment(key)); exceeded {
+			errors = append(errors, err)
+			continue
+		}
+		if err, exceeded := ctx.checkStringLength(val, FieldSegment(key)); exceeded {
+			errors = append(errors, err)
+			continue
+		}
+		var finalKey interface{} = entry.key
 		var finalVal interface{} = val
 
 		// Validate key using key schema
 		if s.keySchema != nil {
-			keyResult := s.keySchema.Parse(key, ctx)
+			keyResult := s.keySchema.Parse(entry.key, ctx)
 			if !keyResult.Valid {
 				// Key validation failed
 				message := recordKeyError(ctx.Locale)
 				if !isEmptyErrorMessage(s.keyError) {
 					message = resolveErrorMessage(s.keyError, ctx)
 				}
-				errors = append(errors, NewFieldError([]string{key}, key, message, "key_invalid"))
+				errors = append(errors, NewFieldError(Path{FieldSegment(key)}, entry.key, message, CodeKeyInvalid))
 				// Also add the specific key validation errors
 				for _, keyErr := range keyResult.Errors {
-					errors = append(errors, NewFieldError([]string{key + "_key"}, keyErr.Value, keyErr.Message, keyErr.Code))
+					errors = append(errors, nestedError(Path{FieldSegment(key + "_key")}, keyErr))
 				}
 				continue // Skip this key-value pair
 			} else {
 				// Use the parsed key
-				if parsedKey, ok := keyResult.Value.(string); ok {
-					finalKey = parsedKey
+				if keyType == nil {
+					if parsedKey, ok := keyResult.Value.(string); ok {
+						finalKey = parsedKey
+					}
+				} else if typedKey := reflect.New(keyType).Elem(); keyResult.Value != nil && bindValue(typedKey, keyResult.Value, nil) == nil {
+					finalKey = typedKey.Interface()
 				}
 			}
 		}
 
 		// Validate value using value schema
 		if s.valueSchema != nil {
-			valueResult := s.valueSchema.Parse(val, ctx)
+			valueResult := s.valueSchema.Parse(val, child.at(FieldSegment(key)))
+			warnings = nestedWarnings(warnings, FieldSegment(key), valueResult)
 			if !valueResult.Valid {
 				// Value validation failed
 				message := recordValueError(ctx.Locale)
 				if !isEmptyErrorMessage(s.valueError) {
 					message = resolveErrorMessage(s.valueError, ctx)
 				}
-				errors = append(errors, NewFieldError([]string{key}, val, message, "value_invalid"))
+				errors = append(errors, NewFieldError(Path{FieldSegment(key)}, val, message, CodeValueInvalid))
 				// Also add the specific value validation errors
 				for _, valErr := range valueResult.Errors {
 					// Prefix the path with the key
-					errors = append(errors, NewFieldError(append([]string{key}, valErr.Path...), valErr.Value, valErr.Message, valErr.Code))
+					errors = append(errors, nestedError(append(Path{FieldSegment(key)}, valErr.Path...), valErr))
 				}
 			} else {
 				// Use the parsed value
@@ -347,32 +554,47 @@ func (s *RecordSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		}
 
 		// Store the final key-value pair
-		finalValue[finalKey] = finalVal
+		if keyType != nil {
+			typedValue.SetMapIndex(reflect.ValueOf(finalKey), reflect.ValueOf(&finalVal).Elem())
+		} else {
+			finalValue[finalKey.(string)] = finalVal
+		}
 	}
 
+	var parsed interface{} = finalValue
+	if keyType != nil {
+		parsed = typedValue.Interface()
+	}
 	return ParseResult{
-		Valid:  len(errors) == 0,
-		Value:  finalValue,
-		Errors: errors,
+		Valid:    len(errors) == 0,
+		Value:    parsed,
+		Errors:   errors,
+		Warnings: warnings,
 	}
 }
 
 // JSON generates JSON Schema representation
 func (s *RecordSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *RecordSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	schema := baseJSONSchema("object")
 
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
 
 	// For records, we use additionalProperties to represent value schema
 	if s.valueSchema != nil {
-		if jsonSchema, ok := s.valueSchema.(interface{ JSON() map[string]interface{} }); ok {
-			schema["additionalProperties"] = jsonSchema.JSON()
+		if valueJSON, ok := childJSON(s.valueSchema, g); ok {
+			schema["additionalProperties"] = valueJSON
 		} else {
 			schema["additionalProperties"] = true
 		}
@@ -380,6 +602,19 @@ func (s *RecordSchema) JSON() map[string]interface{} {
 		schema["additionalProperties"] = true
 	}
 
+	// JSON object keys are strings: string key schemas become propertyNames, and
+	// integer key schemas are described by their decimal form
+	if keyJSON, ok := childJSON(s.keySchema, g); ok {
+		switch keyJSON["type"] {
+		case "string":
+			if len(keyJSON) > 1 {
+				schema["propertyNames"] = keyJSON
+			}
+		case "integer":
+			schema["propertyNames"] = map[string]interface{}{"type": "string", "pattern": "^-?[0-9]+$"}
+		}
+	}
+
 	// Add property count constraints
 	if s.minProps != nil {
 		schema["minProperties"] = *s.minProps
@@ -0,0 +1,137 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecordSchema_Keys(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *RecordSchema
+		value    interface{}
+		expected bool
+		want     interface{}
+	}{
+		{
+			name:     "key pattern",
+			schema:   Record(nil, Int()).KeyPattern(`^[a-z]+$`),
+			value:    map[string]interface{}{"apples": 3, "pears": 1},
+			expected: true,
+			want:     map[string]interface{}{"apples": 3, "pears": 1},
+		},
+		{
+			name:     "key pattern mismatch",
+			schema:   Record(nil, Int()).KeyPattern(`^[a-z]+$`),
+			value:    map[string]interface{}{"Apples": 3},
+			expected: false,
+		},
+		{
+			name:     "keys enum",
+			schema:   Record(nil, Bool()).KeysEnum("read", "write"),
+			value:    map[string]interface{}{"read": true},
+			expected: true,
+			want:     map[string]interface{}{"read": true},
+		},
+		{
+			name:     "key outside enum",
+			schema:   Record(nil, Bool()).KeysEnum("read", "write"),
+			value:    map[string]interface{}{"delete": true},
+			expected: false,
+		},
+		{
+			name:     "int keys are kept",
+			schema:   Record(Int().Min(0), String()),
+			value:    map[int]string{1: "one", 2: "two"},
+			expected: true,
+			want:     map[int]interface{}{1: "one", 2: "two"},
+		},
+		{
+			name:     "uint keys without key schema",
+			schema:   Record(nil, Int()),
+			value:    map[uint8]int{7: 49},
+			expected: true,
+			want:     map[uint8]interface{}{7: 49},
+		},
+		{
+			name:     "invalid int key",
+			schema:   Record(Int().Min(0), String()),
+			value:    map[int]string{-1: "minus one"},
+			expected: false,
+		},
+		{
+			name:     "string keys still stringified",
+			schema:   Record(String(), Int()),
+			value:    map[string]int{"a": 1},
+			expected: true,
+			want:     map[string]interface{}{"a": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) valid = %v, want %v: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.expected && !reflect.DeepEqual(result.Value, tt.want) {
+				t.Errorf("Parse(%v) = %#v, want %#v", tt.value, result.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordSchema_IntKeyErrorPath(t *testing.T) {
+	result := Record(Int(), String().MinLength(2)).Parse(map[int]string{42: "x"}, DefaultValidationContext())
+	if result.Valid {
+		t.Fatal("a one-character value should be rejected")
+	}
+	if got := result.Errors[0].Path.String(); got != "42" {
+		t.Errorf("error path = %q, want 42", got)
+	}
+}
+
+func TestRecordSchema_PropertyNamesJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *RecordSchema
+		want   map[string]interface{}
+	}{
+		{"key pattern", Record(nil, Int()).KeyPattern(`^[a-z]+$`), map[string]interface{}{"type": "string", "pattern": `^[a-z]+$`}},
+		{"keys enum", Record(nil, Int()).KeysEnum("a", "b"), map[string]interface{}{"type": "string", "enum": []interface{}{"a", "b"}}},
+		{"int keys", Record(Int(), Int()), map[string]interface{}{"type": "string", "pattern": "^-?[0-9]+$"}},
+		{"plain string keys", Record(String(), Int()), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := tt.schema.JSON()["propertyNames"].(map[string]interface{})
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("propertyNames = %v, want none", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(normalizeJSONSchema(got), normalizeJSONSchema(tt.want)) {
+				t.Errorf("propertyNames = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordSchema_ErrorLimitsAreDeterministic(t *testing.T) {
+	s := Record(String(), Int())
+	value := map[string]interface{}{}
+	for _, key := range []string{"d", "b", "e", "a", "c", "f", "h", "g"} {
+		value[key] = "not a number"
+	}
+
+	for run := 0; run < 20; run++ {
+		result := s.Parse(value, DefaultValidationContext().WithMaxErrors(1))
+		if len(result.Errors) == 0 || result.Errors[0].Path.DotPath() != "a" {
+			t.Fatalf("run %d: errors = %v, want the error of key a first", run, result.Errors)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// RedactedValue replaces sensitive values in the Value of errors
+const RedactedValue = "***"
+
+// redactable is implemented by the schemas that can be marked Sensitive
+type redactable interface {
+	IsSensitive() bool
+}
+
+// WithRedaction sets a function deciding which positions hold sensitive values, such
+// as the properties named "token" or "secret" whatever their schema. The values of
+// errors about those positions, and the positions nested in them, are replaced by
+// RedactedValue, as for Sensitive schemas.
+func (vc *ValidationContext) WithRedaction(redact func(path Path) bool) *ValidationContext {
+	vc.Redact = redact
+	return vc
+}
+
+// isSensitive reports whether schema is marked Sensitive
+func isSensitive(schema Parseable) bool {
+	r, ok := schema.(redactable)
+	return ok && r.IsSensitive()
+}
+
+// redactErrors masks the sensitive values in the errors and warnings of the result of
+// parsing value with schema, and returns value with its sensitive parts masked for
+// the message templates. Errors about a sensitive position, or a position nested in
+// one, get RedactedValue; errors about a value containing sensitive positions get it
+// with those masked. Only the values of the errors are looked at, and nothing is when
+// no schema nested in schema is Sensitive and ctx has no Redact function.
+func redactErrors(result ParseResult, ctx *ValidationContext, schema Parseable, value interface{}) interface{} {
+	if ctx.validityOnly || ctx.Redact == nil && !subtreeSensitive(schema) {
+		return value
+	}
+
+	r := redaction{ctx: ctx}
+	if ctx.Redact != nil {
+		r.path = ctx.path()
+	}
+	schemas := expandSchemas([]Parseable{schema})
+	if anySensitive(schemas) || redactsPrefix(ctx, r.path) {
+		maskErrors(result.Errors)
+		maskErrors(result.Warnings)
+		return RedactedValue
+	}
+
+	input := value
+	masked := map[string]string{} // Masked error values by path
+	for _, errors := range [][]ValidationError{result.Errors, result.Warnings} {
+		for i := range errors {
+			err := &errors[i]
+			if err.Value == RedactedValue {
+				continue
+			}
+			key := err.Path.String()
+			if text, done := masked[key]; done {
+				if text != "" {
+					err.Value = text
+				}
+				continue
+			}
+			nested, nestedSchemas, sensitive := r.follow(value, schemas, err.Path)
+			text := ""
+			if sensitive {
+				text = RedactedValue
+			} else if redacted, ok := r.value(nested, nestedSchemas, len(err.Path)); ok {
+				text = fmt.Sprintf("%v", redacted)
+				if len(err.Path) == 0 {
+					input = redacted
+				}
+			}
+			masked[key] = text
+			if text != "" {
+				err.Value = text
+			}
+		}
+	}
+	return input
+}
+
+// maskErrors replaces the values of errors by RedactedValue
+func maskErrors(errors []ValidationError) {
+	for i := range errors {
+		errors[i].Value = RedactedValue
+	}
+}
+
+// redactsPrefix reports whether ctx.Redact masks path or one of its ancestors
+func redactsPrefix(ctx *ValidationContext, path Path) bool {
+	if ctx.Redact == nil {
+		return false
+	}
+	for i := range path {
+		if ctx.Redact(path[:i+1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// redaction masks the sensitive positions of the value parsed with ctx
+type redaction struct {
+	ctx  *ValidationContext
+	path Path // Position of the value being looked at, from the root when ctx.Redact is set
+}
+
+// sensitive reports whether the position at r.path, with the expanded schemas, is
+// masked
+func (r *redaction) sensitive(schemas []Parseable) bool {
+	return anySensitive(schemas) || r.ctx.Redact != nil && r.ctx.Redact(r.path)
+}
+
+// follow returns the value at path in value and the expanded schemas applying to it,
+// or whether a position along path is masked. Properties are named by their property
+// name rather than their alias, as in errors.
+func (r *redaction) follow(value interface{}, schemas []Parseable, path Path) (interface{}, []Parseable, bool) {
+	base := len(r.path)
+	defer func() { r.path = r.path[:base] }()
+	for _, segment := range path {
+		value = childValue(value, schemas, segment, r.ctx)
+		schemas = expandSchemas(childSchemas(schemas, segment))
+		r.path = append(r.path, segment)
+		if r.sensitive(schemas) {
+			return nil, nil, true
+		}
+	}
+	return value, schemas, false
+}
+
+// value returns value with the positions nested in it that are masked replaced by
+// RedactedValue, and whether any was. Objects and arrays are copied when they
+// contain masked positions. depth is the nesting of value in the value of the
+// schema being redacted; positions deeper than ctx.MaxDepth were not parsed and are
+// masked as a whole.
+func (r *redaction) value(value interface{}, schemas []Parseable, depth int) (interface{}, bool) {
+	if value == nil || r.ctx.Redact == nil && !slices.ContainsFunc(schemas, subtreeSensitive) {
+		return value, false
+	}
+	if r.ctx.depth+depth > r.ctx.maxDepth() {
+		return RedactedValue, true
+	}
+
+	item := func(item interface{}, segment PathSegment) (interface{}, bool) {
+		children := expandSchemas(childSchemas(schemas, segment))
+		r.path = append(r.path, segment)
+		defer func() { r.path = r.path[:len(r.path)-1] }()
+		if r.sensitive(children) {
+			return RedactedValue, true
+		}
+		return r.value(item, children, depth+1)
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return value, false
+		}
+		var items []interface{}
+		for i := 0; i < v.Len(); i++ {
+			redacted, ok := item(v.Index(i).Interface(), IndexSegment(i))
+			if !ok {
+				continue
+			}
+			if items == nil {
+				items = make([]interface{}, v.Len())
+				for j := range items {
+					items[j] = v.Index(j).Interface()
+				}
+			}
+			items[i] = redacted
+		}
+		if items == nil {
+			return value, false
+		}
+		return items, true
+	case reflect.Map, reflect.Struct, reflect.Ptr:
+		object, ok := convertToMap(value, r.ctx.TagNames...)
+		if !ok {
+			return value, false
+		}
+		var result map[string]interface{}
+		for key, value := range object {
+			redacted, ok := item(value, FieldSegment(propertyNameIn(schemas, key)))
+			if !ok {
+				continue
+			}
+			if result == nil {
+				result = maps.Clone(object)
+			}
+			result[key] = redacted
+		}
+		if result == nil {
+			return value, false
+		}
+		return result, true
+	}
+	return value, false
+}
+
+// childValue returns the item or property at segment of value, or nil. Properties
+// are looked up by property name, then by the keys naming it.
+func childValue(value interface{}, schemas []Parseable, segment PathSegment, ctx *ValidationContext) interface{} {
+	if value == nil {
+		return nil
+	}
+	if segment.IsIndex {
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array || segment.Index >= v.Len() {
+			return nil
+		}
+		return v.Index(segment.Index).Interface()
+	}
+	object, ok := convertToMap(value, ctx.TagNames...)
+	if !ok {
+		return nil
+	}
+	if item, ok := object[segment.Field]; ok {
+		return item
+	}
+	for key, item := range object {
+		if propertyNameIn(schemas, key) == segment.Field {
+			return item
+		}
+	}
+	return nil
+}
+
+// propertyNameIn returns the property name of an input key in the first object schema
+// of schemas, or key
+func propertyNameIn(schemas []Parseable, key string) string {
+	for _, schema := range schemas {
+		if object, ok := schema.(*ObjectSchema); ok {
+			return object.propertyName(key)
+		}
+	}
+	return key
+}
+
+// anySensitive reports whether one of schemas is marked Sensitive
+func anySensitive(schemas []Parseable) bool {
+	return slices.ContainsFunc(schemas, isSensitive)
+}
+
+// Values of frozenState.sensitivity
+const (
+	sensitivityUnknown uint32 = iota
+	sensitivityNone
+	sensitivitySome
+)
+
+// subtreeSensitive reports whether schema or a schema nested in it is marked
+// Sensitive. The answer is kept by frozen schemas whose nested schemas are all
+// frozen, as it cannot change; Ref schemas are followed but never keep it, as their
+// registry may be changed.
+func subtreeSensitive(schema Parseable) bool {
+	state, cached := schema.(interface{ state() *frozenState })
+	if cached {
+		switch atomic.LoadUint32(&state.state().sensitivity) {
+		case sensitivityNone:
+			return false
+		case sensitivitySome:
+			return true
+		}
+	}
+
+	found, frozen := false, true
+	seen := map[Parseable]bool{}
+	var visit func(s Parseable)
+	visit = func(s Parseable) {
+		if found || s == nil || reflect.TypeOf(s).Comparable() && seen[s] {
+			return
+		}
+		if reflect.TypeOf(s).Comparable() {
+			seen[s] = true
+		}
+		if _, ref := s.(*RefSchema); ref {
+			frozen = false
+		} else if f, ok := s.(interface{ IsFrozen() bool }); !ok || !f.IsFrozen() {
+			frozen = false
+		}
+		if isSensitive(s) {
+			found = true
+			return
+		}
+		if composite, ok := s.(Composite); ok {
+			for _, child := range composite.Children() {
+				visit(child.Schema)
+			}
+		}
+	}
+	visit(schema)
+
+	if cached && frozen {
+		sensitivity := sensitivityNone
+		if found {
+			sensitivity = sensitivitySome
+		}
+		atomic.StoreUint32(&state.state().sensitivity, sensitivity)
+	}
+	return found
+}
+
+// expandSchemas returns schemas and, recursively, the schemas applying to the same
+// value as one of them, such as the members of a union
+func expandSchemas(schemas []Parseable) []Parseable {
+	expanded := make([]Parseable, 0, len(schemas))
+	var add func(schema Parseable)
+	add = func(schema Parseable) {
+		if schema == nil || reflect.TypeOf(schema).Comparable() && slices.Contains(expanded, schema) {
+			return
+		}
+		expanded = append(expanded, schema)
+		if _, object := schema.(*ObjectSchema); object {
+			return // Only nests schemas in its properties
+		}
+		if composite, ok := schema.(Composite); ok {
+			for _, child := range composite.Children() {
+				if child.Segment == "" {
+					add(child.Schema)
+				}
+			}
+		}
+	}
+	for _, schema := range schemas {
+		add(schema)
+	}
+	return expanded
+}
+
+// childSchemas returns the schemas of schemas applying to the values at segment: the
+// properties (by name or pattern), items, tuple positions and record or map
+// values
+func childSchemas(schemas []Parseable, segment PathSegment) []Parseable {
+	var children []Parseable
+	for _, schema := range schemas {
+		if object, ok := schema.(*ObjectSchema); ok {
+			if segment.IsIndex {
+				continue
+			}
+			if prop, ok := object.properties[segment.Field]; ok {
+				children = append(children, prop.Schema)
+			}
+			children = append(children, object.matchingPatternSchemas(segment.Field)...)
+			continue
+		}
+		composite, ok := schema.(Composite)
+		if !ok {
+			continue
+		}
+		for _, child := range composite.Children() {
+			if childMatches(child.Segment, segment) {
+				children = append(children, child.Schema)
+			}
+		}
+	}
+	return children
+}
+
+// childMatches reports whether a child with the Children segment applies to the
+// values at segment
+func childMatches(childSegment string, segment PathSegment) bool {
+	switch {
+	case childSegment == "" || childSegment == KeySegment || strings.HasPrefix(childSegment, "/"):
+		return false
+	case segment.IsIndex:
+		return childSegment == ItemsSegment || childSegment == segment.String()
+	default:
+		return childSegment == ValuesSegment || childSegment == segment.Field
+	}
+}
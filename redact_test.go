@@ -0,0 +1,168 @@
+package schema
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSensitive(t *testing.T) {
+	ctx := DefaultValidationContext()
+	secret := "hunter2"
+	login := Object().
+		Property("user", String()).
+		Property("password", String().MinLength(8).Sensitive()).
+		Refine(func(v interface{}) bool { return v.(map[string]interface{})["user"] != "root" }, "root cannot log in")
+
+	type credentials struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+	}
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		value  interface{}
+		want   map[string]string // Error value by path
+	}{
+		{"sensitive string", String().MinLength(8).Sensitive(), secret, map[string]string{"": "***"}},
+		{"password by default", Password().MinLength(8), secret, map[string]string{"": "***"}},
+		{"jwt by default", JWT(), secret, map[string]string{"": "***"}},
+		{"password format by default", String().MinLength(8).Password(), secret, map[string]string{"": "***"}},
+		{"password set with Format", String().Format(StringFormatPassword).MinLength(8), secret, map[string]string{"": "***"}},
+		{"plain string", String().MinLength(8), secret, map[string]string{"": secret}},
+		{"sensitive property", login, map[string]interface{}{"user": "ada", "password": secret}, map[string]string{"password": "***"}},
+		{"object with a sensitive property", login, map[string]interface{}{"user": "root", "password": "correct horse"}, map[string]string{"": "map[password:*** user:root]"}},
+		{"struct with a sensitive property", login, credentials{User: "root", Password: "correct horse"}, map[string]string{"": "map[password:*** user:root]"}},
+		{"nested", Array(login), []interface{}{map[string]interface{}{"user": "ada", "password": secret}}, map[string]string{"[0].password": "***", "[0]": "map[password:*** user:ada]"}},
+		{"record values", Record(String(), String().MinLength(8).Sensitive()), map[string]interface{}{"db": secret}, map[string]string{"db": "***"}},
+		{"union member", Union(Int(), Password().MinLength(8)), secret, map[string]string{"": "***"}},
+		{"sensitive object", Object().Property("pin", Int().Max(9999)).Sensitive(), map[string]interface{}{"pin": 12345}, map[string]string{"pin": "***"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid {
+				t.Fatal("Parse() is valid, want errors")
+			}
+			got := make(map[string]string)
+			for _, err := range result.Errors {
+				got[err.Path.String()] = err.Value
+			}
+			for path, want := range tt.want {
+				if got[path] != want {
+					t.Errorf("value at %q = %q, want %q (errors %v)", path, got[path], want, result.Errors)
+				}
+			}
+		})
+	}
+}
+
+func TestSensitive_Messages(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	result := String().Sensitive().MinLength(8, "{{.Value}} is too short").Parse("hunter2", ctx)
+	if result.Valid || result.Errors[0].Message != "*** is too short" {
+		t.Errorf("errors = %v, want the value masked in the message", result.Errors)
+	}
+
+	result = String().Sensitive().MaxLength(3).AsWarning().Parse("hunter2", ctx)
+	if !result.Valid || len(result.Warnings) != 1 || result.Warnings[0].Value != RedactedValue {
+		t.Errorf("warnings = %v, want the value masked", result.Warnings)
+	}
+}
+
+func TestWithRedaction(t *testing.T) {
+	ctx := DefaultValidationContext().WithRedaction(func(path Path) bool {
+		last := path[len(path)-1]
+		return last.Field == "token" || last.Field == "secrets"
+	})
+	request := Object().
+		Property("token", String().MinLength(32)).
+		Property("secrets", Object().Property("apiKey", String().MinLength(32))).
+		Property("note", String().MaxLength(3)).
+		Property("tags", Array(Object().Property("token", String().UUID())))
+
+	result := request.Parse(map[string]interface{}{
+		"token":   "abc",
+		"secrets": map[string]interface{}{"apiKey": "xyz"},
+		"note":    "visible",
+		"tags":    []interface{}{map[string]interface{}{"token": "t0"}},
+	}, ctx)
+	if result.Valid {
+		t.Fatal("Parse() is valid, want errors")
+	}
+	for _, err := range result.Errors {
+		for _, secret := range []string{"abc", "xyz", "t0"} {
+			if strings.Contains(err.Value, secret) {
+				t.Errorf("%s value = %q, want %s masked", err.Path, err.Value, secret)
+			}
+		}
+		if path := err.Path.String(); (path == "token" || path == "secrets.apiKey" || path == "tags[0].token") && err.Value != RedactedValue {
+			t.Errorf("%s value = %q, want %q", path, err.Value, RedactedValue)
+		}
+		if err.Path.String() == "note" && err.Value != "visible" {
+			t.Errorf("note value = %q, want it unmasked", err.Value)
+		}
+	}
+}
+
+func TestSensitive_NestedSchemaChanges(t *testing.T) {
+	ctx := DefaultValidationContext()
+	pin := Int()
+	account := Object().Property("pin", pin).Refine(func(interface{}) bool { return false }, "locked")
+	value := map[string]interface{}{"pin": 1234}
+
+	if result := account.Parse(value, ctx); result.Errors[0].Value != "map[pin:1234]" {
+		t.Errorf("value = %q, want it unmasked", result.Errors[0].Value)
+	}
+	pin.Sensitive()
+	if result := account.Parse(value, ctx); result.Errors[0].Value != "map[pin:***]" {
+		t.Errorf("value = %q, want the pin masked once it is Sensitive", result.Errors[0].Value)
+	}
+	account.Freeze()
+	if result := account.Parse(value, ctx); result.Errors[0].Value != "map[pin:***]" {
+		t.Errorf("frozen value = %q, want the pin masked", result.Errors[0].Value)
+	}
+}
+
+func TestRedaction_DeepInput(t *testing.T) {
+	var plain, secret *ArraySchema
+	plain = Array(Lazy(func() Parseable { return plain }))
+	secret = Array(Union(Lazy(func() Parseable { return secret }), Password().MinLength(20)))
+
+	// Far deeper than MaxDepth: only the levels that are parsed are looked at, so each
+	// parse takes milliseconds
+	var value interface{} = "hunter2"
+	for i := 0; i < 5000; i++ {
+		value = []interface{}{value}
+	}
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		ctx    *ValidationContext
+	}{
+		{"no sensitive schema", plain, DefaultValidationContext()},
+		{"redact function", plain, DefaultValidationContext().WithRedaction(func(path Path) bool { return false })},
+		{"sensitive schema", secret, DefaultValidationContext()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(value, tt.ctx)
+			if result.Valid {
+				t.Fatal("Parse() is valid, want errors")
+			}
+			if !slices.ContainsFunc(result.Errors, func(err ValidationError) bool { return err.Code == CodeMaxDepth }) {
+				t.Errorf("errors = %v, want max_depth", errorKeys(result.Errors))
+			}
+			for _, err := range result.Errors {
+				if strings.Contains(err.Value, "hunter2") && tt.schema == secret {
+					t.Errorf("%s value contains the password", err.Path)
+				}
+			}
+		})
+	}
+}
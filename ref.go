@@ -1,7 +1,10 @@
 package schema
 
 import (
+	"maps"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/nyxstack/i18n"
 )
@@ -28,35 +31,54 @@ var RefErrors = struct {
 	InvalidFormat: refInvalidFormatError,
 }
 
-// SchemaRegistry manages schema definitions for references
+// SchemaRegistry manages schema definitions for references. Names may be namespaced
+// and versioned with slashes ("users/v2/User"), aliases may point at other names, and
+// references to external documents are loaded through the registry's Loader (see
+// registry.go). A registry is safe for concurrent use.
 type SchemaRegistry struct {
+	mu          sync.RWMutex
 	definitions map[string]Parseable
-	resolving   map[string]bool // Track schemas currently being resolved to detect circular refs
+	aliases     map[string]string    // Alias name -> target name
+	loader      DocumentLoader       // Loads external documents, nil if unsupported
+	external    map[string]Parseable // Compiled external references by ref
 }
 
 // NewSchemaRegistry creates a new schema registry
 func NewSchemaRegistry() *SchemaRegistry {
 	return &SchemaRegistry{
 		definitions: make(map[string]Parseable),
-		resolving:   make(map[string]bool),
+		aliases:     make(map[string]string),
+		external:    make(map[string]Parseable),
 	}
 }
 
 // Define adds a schema definition to the registry
 func (r *SchemaRegistry) Define(name string, schema Parseable) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.definitions[name] = schema
 }
 
-// Get retrieves a schema definition by name
+// Get retrieves a schema definition by name, following aliases and resolving a
+// "latest" version segment ("users/latest/User") to the highest defined version
 func (r *SchemaRegistry) Get(name string) (Parseable, bool) {
-	schema, exists := r.definitions[name]
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	resolved, ok := r.resolveName(name)
+	if !ok {
+		return nil, false
+	}
+	schema, exists := r.definitions[resolved]
 	return schema, exists
 }
 
-// Clear removes all definitions
+// Clear removes all definitions, aliases and loaded external documents
 func (r *SchemaRegistry) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.definitions = make(map[string]Parseable)
-	r.resolving = make(map[string]bool)
+	r.aliases = make(map[string]string)
+	r.external = make(map[string]Parseable)
 }
 
 // RefSchema represents a JSON Schema reference ($ref)
@@ -64,6 +86,8 @@ type RefSchema struct {
 	ref      string
 	registry *SchemaRegistry
 	refError ErrorMessage
+	effects  effects
+	frozenState
 }
 
 // Ref creates a new reference schema that points to a definition in the registry
@@ -74,14 +98,78 @@ func Ref(ref string, registry *SchemaRegistry) *RefSchema {
 	}
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *RefSchema) Clone() *RefSchema {
+	c := *s
+	c.effects = slices.Clone(s.effects)
+	c.frozenState = frozenState{}
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *RefSchema) Freeze() *RefSchema {
+	s.freeze()
+	return s
+}
+
+// GetRef returns the reference, e.g. "#/user"
+func (s *RefSchema) GetRef() string {
+	return s.ref
+}
+
+// Children returns the referenced definition, when the reference is to a definition
+// of the registry that exists; external documents are not loaded
+func (s *RefSchema) Children() []SchemaChild {
+	if s.registry == nil || !strings.HasPrefix(s.ref, "#/") {
+		return nil
+	}
+	target, exists := s.registry.Get(s.ref[2:])
+	if !exists {
+		return nil
+	}
+	return sameValueChildren(target)
+}
+
 // RefError sets a custom error message for reference resolution failures
 func (s *RefSchema) RefError(err ErrorMessage) *RefSchema {
+	s.checkMutable()
 	s.refError = err
 	return s
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *RefSchema) Transform(fn TransformFunc) *RefSchema {
+	s.checkMutable()
+	s.effects = s.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *RefSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *RefSchema {
+	s.checkMutable()
+	s.effects = s.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *RefSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *RefSchema {
+	s.checkMutable()
+	s.effects = s.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Parse resolves the reference and validates using the referenced schema
 func (s *RefSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.effects.apply(ctx.startParse(s), s.parse(value, s.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the reference constraints; Parse runs the refine/transform pipeline on top
+func (s *RefSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	if isExternalRef(s.ref) {
+		return s.parseExternal(value, ctx)
+	}
+
 	// Validate reference format
 	if !strings.HasPrefix(s.ref, "#/") {
 		message := RefErrors.InvalidFormat(ctx.Locale)
@@ -91,15 +179,17 @@ func (s *RefSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 		return ParseResult{
 			Valid:  false,
 			Value:  value,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_ref_format")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidRefFormat)},
 		}
 	}
 
 	// Extract definition name (remove "#/" prefix)
 	defName := s.ref[2:]
 
-	// Check for circular reference
-	if s.registry.resolving[s.ref] {
+	// Check for circular reference: a chain of references that leads back to itself
+	// without ever reaching a concrete schema. Recursion through concrete schemas
+	// (e.g. a tree node referencing its own definition) is bounded by MaxDepth instead.
+	if s.isCircular() {
 		message := RefErrors.CircularRef(s.ref)(ctx.Locale)
 		if !isEmptyErrorMessage(s.refError) {
 			message = resolveErrorMessage(s.refError, ctx)
@@ -107,7 +197,7 @@ func (s *RefSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 		return ParseResult{
 			Valid:  false,
 			Value:  value,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "circular_ref")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeCircularRef)},
 		}
 	}
 
@@ -121,18 +211,84 @@ func (s *RefSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult
 		return ParseResult{
 			Valid:  false,
 			Value:  value,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "ref_not_found")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeRefNotFound)},
 		}
 	}
 
-	// Mark this reference as currently being resolved
-	s.registry.resolving[s.ref] = true
-	defer func() {
-		delete(s.registry.resolving, s.ref)
-	}()
+	child, ok := ctx.descend(value)
+	if !ok {
+		message := maxDepthError(ctx.maxDepth())(ctx.Locale)
+		if !isEmptyErrorMessage(s.refError) {
+			message = resolveErrorMessage(s.refError, ctx)
+		}
+		return ParseResult{
+			Valid:  false,
+			Value:  value,
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeMaxDepth)},
+		}
+	}
 
 	// Validate using the referenced schema
-	return referencedSchema.Parse(value, ctx)
+	defer child.release()
+	return referencedSchema.Parse(value, child)
+}
+
+// parseExternal validates using a schema loaded from an external document
+func (s *RefSchema) parseExternal(value interface{}, ctx *ValidationContext) ParseResult {
+	var target Parseable
+	var err error
+	if s.registry == nil {
+		err = errNoLoader
+	} else {
+		target, err = s.registry.loadExternal(s.ref)
+	}
+	if err != nil {
+		message := RefErrors.NotFound(s.ref)(ctx.Locale)
+		if !isEmptyErrorMessage(s.refError) {
+			message = resolveErrorMessage(s.refError, ctx)
+		}
+		return ParseResult{
+			Valid:  false,
+			Value:  value,
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeRefNotFound)},
+		}
+	}
+
+	child, ok := ctx.descend(value)
+	if !ok {
+		return ParseResult{
+			Valid:  false,
+			Value:  value,
+			Errors: []ValidationError{NewPrimitiveError(value, maxDepthError(ctx.maxDepth())(ctx.Locale), CodeMaxDepth)},
+		}
+	}
+	defer child.release()
+	return target.Parse(value, child)
+}
+
+// isCircular reports whether following this reference only leads through other references back to itself
+func (s *RefSchema) isCircular() bool {
+	seen := map[*RefSchema]bool{}
+	current := s
+	for current != nil && !seen[current] {
+		seen[current] = true
+		if !strings.HasPrefix(current.ref, "#/") || current.registry == nil {
+			return false
+		}
+		target, exists := current.registry.Get(current.ref[2:])
+		if !exists {
+			return false
+		}
+		next, ok := target.(*RefSchema)
+		if !ok {
+			return false
+		}
+		if next == s || next.ref == s.ref && next.registry == s.registry {
+			return true
+		}
+		current = next
+	}
+	return current != nil
 }
 
 // JSON generates JSON Schema for reference
@@ -147,6 +303,7 @@ type DefinitionSchema struct {
 	schema      Parseable
 	registry    *SchemaRegistry
 	definitions map[string]Parseable
+	frozenState
 }
 
 // WithDefinitions creates a schema wrapper that includes schema definitions
@@ -158,6 +315,35 @@ func WithDefinitions(schema Parseable, registry *SchemaRegistry) *DefinitionSche
 	}
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *DefinitionSchema) Clone() *DefinitionSchema {
+	c := *s
+	c.frozenState = frozenState{}
+	return &c
+}
+
+// Freeze makes the schema and the wrapped schema immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *DefinitionSchema) Freeze() *DefinitionSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and the wrapped schema
+func (s *DefinitionSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.frozenState.freeze()
+	freezeSchemas(s.schema)
+}
+
+// Children returns the wrapped schema
+func (s *DefinitionSchema) Children() []SchemaChild {
+	return sameValueChildren(s.schema)
+}
+
 // Parse validates using the main schema (definitions are just metadata)
 func (s *DefinitionSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
 	return s.schema.Parse(value, ctx)
@@ -165,11 +351,16 @@ func (s *DefinitionSchema) Parse(value interface{}, ctx *ValidationContext) Pars
 
 // JSON generates JSON Schema with definitions
 func (s *DefinitionSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *DefinitionSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	schema := map[string]interface{}{}
 
 	// Add the main schema
-	if mainSchema, ok := s.schema.(interface{ JSON() map[string]interface{} }); ok {
-		for k, v := range mainSchema.JSON() {
+	if mainJSON, ok := childJSON(s.schema, g); ok {
+		for k, v := range mainJSON {
 			schema[k] = v
 		}
 	}
@@ -177,9 +368,9 @@ func (s *DefinitionSchema) JSON() map[string]interface{} {
 	// Add definitions section
 	if len(s.definitions) > 0 {
 		definitions := make(map[string]interface{})
-		for name, defSchema := range s.definitions {
-			if jsonSchema, ok := defSchema.(interface{ JSON() map[string]interface{} }); ok {
-				definitions[name] = jsonSchema.JSON()
+		for _, name := range slices.Sorted(maps.Keys(s.definitions)) {
+			if defJSON, ok := childJSON(s.definitions[name], g); ok {
+				definitions[name] = defJSON
 			} else {
 				definitions[name] = map[string]interface{}{"type": "unknown"}
 			}
@@ -0,0 +1,368 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DocumentLoader loads an external JSON Schema document. location is the part of a
+// $ref before the '#', such as "common.json" or "https://example.com/schemas/user.json".
+type DocumentLoader func(location string) ([]byte, error)
+
+// Errors wrapped by RefError
+var (
+	ErrRefNotFound = errors.New("reference not found")
+	ErrRefCircular = errors.New("circular reference")
+)
+
+// errNoLoader is returned for external references in a registry without a loader
+var errNoLoader = errors.New("registry has no document loader for external references")
+
+// maxDocumentBytes caps the size of documents fetched by HTTPLoader
+const maxDocumentBytes = 10 << 20
+
+// versionSegment matches the version part of a versioned name ("v2", "v1.3")
+var versionSegment = regexp.MustCompile(`^v\d+(\.\d+)*$`)
+
+// RefError describes a reference that cannot be resolved, found by ResolveAll
+type RefError struct {
+	Definition string // Name of the definition or alias containing the reference
+	Ref        string // The unresolvable reference
+	Err        error  // ErrRefNotFound, ErrRefCircular or the error loading an external document
+}
+
+func (e *RefError) Error() string {
+	return fmt.Sprintf("schema: %s: reference %q: %v", e.Definition, e.Ref, e.Err)
+}
+
+func (e *RefError) Unwrap() error {
+	return e.Err
+}
+
+// SetLoader sets the loader used for references to external documents, such as
+// Ref("common.json#/definitions/Address", registry). Loaded documents are compiled
+// with CompileJSONSchema rules and cached by the registry.
+func (r *SchemaRegistry) SetLoader(loader DocumentLoader) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loader = loader
+}
+
+// Alias makes name resolve to target, which may itself be an alias or a versioned
+// name such as "users/latest/User"
+func (r *SchemaRegistry) Alias(name, target string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aliases[name] = target
+}
+
+// Names returns the names of all definitions in sorted order
+func (r *SchemaRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.definitions))
+	for name := range r.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Versions returns the defined versions of a name given without its version
+// segment, oldest first: with "users/v1/User" and "users/v2/User" defined,
+// Versions("users/User") returns both.
+func (r *SchemaRegistry) Versions(name string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.versions(name)
+}
+
+// Latest returns the highest defined version of a name given without its version
+// segment, such as "users/v2/User" for "users/User"
+func (r *SchemaRegistry) Latest(name string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions := r.versions(name)
+	if len(versions) == 0 {
+		return "", false
+	}
+	return versions[len(versions)-1], true
+}
+
+// versions lists the versioned definitions of name; the caller holds the lock
+func (r *SchemaRegistry) versions(name string) []string {
+	slash := strings.LastIndex(name, "/")
+	prefix, base := name[:slash+1], name[slash+1:]
+
+	var found []string
+	for defined := range r.definitions {
+		if !strings.HasPrefix(defined, prefix) || !strings.HasSuffix(defined, "/"+base) {
+			continue
+		}
+		version := strings.TrimSuffix(strings.TrimPrefix(defined, prefix), "/"+base)
+		if versionSegment.MatchString(version) {
+			found = append(found, defined)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return compareVersions(versionOf(found[i], prefix, base), versionOf(found[j], prefix, base)) < 0
+	})
+	return found
+}
+
+// resolveName follows aliases and "latest" version segments to a definition name;
+// the caller holds the lock. It fails on alias cycles.
+func (r *SchemaRegistry) resolveName(name string) (string, bool) {
+	for steps := 0; steps <= len(r.aliases); steps++ {
+		if target, ok := r.aliases[name]; ok {
+			name = target
+			continue
+		}
+		if strings.Contains("/"+name, "/latest/") {
+			versions := r.versions(strings.Replace(name, "latest/", "", 1))
+			if len(versions) == 0 {
+				return "", false
+			}
+			return versions[len(versions)-1], true
+		}
+		return name, true
+	}
+	return "", false
+}
+
+// loadExternal returns the schema an external reference points to, loading and
+// compiling its document on first use
+func (r *SchemaRegistry) loadExternal(ref string) (Parseable, error) {
+	r.mu.RLock()
+	cached, ok := r.external[ref]
+	loader := r.loader
+	r.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+	if loader == nil {
+		return nil, errNoLoader
+	}
+
+	location, fragment := ref, ""
+	if i := strings.Index(ref, "#"); i >= 0 {
+		location, fragment = ref[:i], ref[i+1:]
+	}
+	data, err := loader(location)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", location, err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("loading %s: invalid JSON Schema document: %w", location, err)
+	}
+	c := &jsonSchemaCompiler{root: doc, registry: NewSchemaRegistry(), compiled: make(map[string]bool)}
+	compiled, err := c.ref("#"+fragment, location)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.external[ref] = compiled
+	r.mu.Unlock()
+	return compiled, nil
+}
+
+// ResolveAll checks every definition and alias up front: local references must
+// point at a definition, external documents must load and compile, and references
+// must not form a loop that never reaches a concrete schema. Recursion through
+// concrete schemas (a tree node containing its own definition) is allowed.
+//
+// Problems are returned as *RefError values joined with errors.Join, so
+// errors.Is(err, ErrRefNotFound) reports whether any reference is dangling.
+func (r *SchemaRegistry) ResolveAll() error {
+	r.mu.RLock()
+	definitions := make(map[string]Parseable, len(r.definitions))
+	for name, s := range r.definitions {
+		definitions[name] = s
+	}
+	aliases := make(map[string]string, len(r.aliases))
+	for name, target := range r.aliases {
+		aliases[name] = target
+	}
+	r.mu.RUnlock()
+
+	var errs []error
+	for _, alias := range sortedMapKeys(aliases) {
+		if _, ok := r.Get(alias); !ok {
+			errs = append(errs, &RefError{Definition: alias, Ref: aliases[alias], Err: r.aliasProblem(alias)})
+		}
+	}
+
+	for _, name := range sortedMapKeys(definitions) {
+		s := definitions[name]
+		if ref, ok := s.(*RefSchema); ok && ref.isCircular() {
+			errs = append(errs, &RefError{Definition: name, Ref: ref.ref, Err: ErrRefCircular})
+			continue
+		}
+
+		doc, err := jsonDocument(s)
+		if err != nil {
+			continue // Schemas without JSON Schema output cannot be inspected
+		}
+		for _, ref := range collectRefs(doc) {
+			if err := r.checkRef(ref); err != nil {
+				errs = append(errs, &RefError{Definition: name, Ref: ref, Err: err})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkRef reports why a reference found in a definition cannot be resolved
+func (r *SchemaRegistry) checkRef(ref string) error {
+	if isExternalRef(ref) {
+		_, err := r.loadExternal(ref)
+		return err
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return fmt.Errorf("%w: must start with '#/'", ErrRefNotFound)
+	}
+	if _, ok := r.Get(ref[2:]); !ok {
+		return ErrRefNotFound
+	}
+	return nil
+}
+
+// aliasProblem distinguishes alias cycles from aliases to missing definitions
+func (r *SchemaRegistry) aliasProblem(alias string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	seen := map[string]bool{}
+	for name := alias; ; {
+		if seen[name] {
+			return ErrRefCircular
+		}
+		seen[name] = true
+		target, ok := r.aliases[name]
+		if !ok {
+			return ErrRefNotFound
+		}
+		name = target
+	}
+}
+
+// FileLoader returns a DocumentLoader reading documents from the file system, with
+// relative locations resolved against root. "file://" URLs are accepted too.
+func FileLoader(root string) DocumentLoader {
+	return func(location string) ([]byte, error) {
+		location = strings.TrimPrefix(location, "file://")
+		if strings.Contains(location, "://") {
+			return nil, fmt.Errorf("unsupported location %q", location)
+		}
+		if !filepath.IsAbs(location) {
+			location = filepath.Join(root, filepath.FromSlash(location))
+		}
+		return os.ReadFile(location)
+	}
+}
+
+// HTTPLoader returns a DocumentLoader fetching http and https URLs with client (or
+// http.DefaultClient when nil). Documents larger than 10 MiB are rejected.
+func HTTPLoader(client *http.Client) DocumentLoader {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(location string) ([]byte, error) {
+		if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+			return nil, fmt.Errorf("unsupported location %q", location)
+		}
+		resp, err := client.Get(location)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GET %s: %s", location, resp.Status)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentBytes+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxDocumentBytes {
+			return nil, fmt.Errorf("GET %s: document exceeds %d bytes", location, maxDocumentBytes)
+		}
+		return data, nil
+	}
+}
+
+// isExternalRef reports whether a reference points into another document: it has a
+// URL scheme, a location before its '#', or names a .json file
+func isExternalRef(ref string) bool {
+	return strings.Contains(ref, "://") || strings.Index(ref, "#") > 0 || strings.HasSuffix(ref, ".json")
+}
+
+// collectRefs returns the distinct $ref values in a JSON Schema document, sorted
+func collectRefs(doc interface{}) []string {
+	seen := map[string]bool{}
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if ref, ok := v["$ref"].(string); ok {
+				seen[ref] = true
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
+	return sortedMapKeys(seen)
+}
+
+// versionOf extracts the version segment of a versioned name
+func versionOf(name, prefix, base string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(name, prefix), "/"+base)
+}
+
+// compareVersions orders version segments numerically ("v2" < "v10", "v1" < "v1.1")
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// sortedMapKeys returns the keys of a string-keyed map in sorted order
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
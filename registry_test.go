@@ -0,0 +1,111 @@
+package schema
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchemaRegistry_Versions(t *testing.T) {
+	registry := NewSchemaRegistry()
+	registry.Define("users/v1/User", Object().Property("name", String()))
+	registry.Define("users/v2/User", Object().Property("fullName", String()))
+	registry.Define("users/v10/User", Object().Property("displayName", String()))
+	registry.Define("users/v2/Group", Object())
+	registry.Alias("User", "users/latest/User")
+
+	want := []string{"users/v1/User", "users/v2/User", "users/v10/User"}
+	if got := registry.Versions("users/User"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Versions() = %v, want %v", got, want)
+	}
+	if got, _ := registry.Latest("users/User"); got != "users/v10/User" {
+		t.Errorf("Latest() = %q, want users/v10/User", got)
+	}
+
+	ctx := DefaultValidationContext()
+	for _, ref := range []string{"#/users/v2/User", "#/users/latest/User", "#/User"} {
+		t.Run(ref, func(t *testing.T) {
+			value := map[string]interface{}{"displayName": "Ada"}
+			if ref == "#/users/v2/User" {
+				value = map[string]interface{}{"fullName": "Ada"}
+			}
+			if result := Ref(ref, registry).Parse(value, ctx); !result.Valid {
+				t.Errorf("Parse() errors = %v", result.Errors)
+			}
+		})
+	}
+}
+
+func TestSchemaRegistry_ExternalRefs(t *testing.T) {
+	dir := t.TempDir()
+	doc := `{"definitions": {"Address": {"type": "object", "properties": {"city": {"type": "string", "minLength": 1}}, "required": ["city"]}}}`
+	if err := os.WriteFile(filepath.Join(dir, "common.json"), []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewSchemaRegistry()
+	registry.SetLoader(FileLoader(dir))
+	address := Ref("common.json#/definitions/Address", registry)
+
+	ctx := DefaultValidationContext()
+	if result := address.Parse(map[string]interface{}{"city": "Paris"}, ctx); !result.Valid {
+		t.Errorf("Parse() errors = %v", result.Errors)
+	}
+	if result := address.Parse(map[string]interface{}{"city": 5}, ctx); result.Valid {
+		t.Error("Parse() should apply the external schema's constraints")
+	}
+
+	missing := Ref("missing.json#/definitions/Address", registry).Parse(map[string]interface{}{}, ctx)
+	if missing.Valid || missing.Errors[0].Code != "ref_not_found" {
+		t.Errorf("Parse() of a missing document = %v, want ref_not_found", missing.Errors)
+	}
+}
+
+func TestSchemaRegistry_ResolveAll(t *testing.T) {
+	registry := NewSchemaRegistry()
+	registry.Define("Node", Object().
+		Property("children", Array(Ref("#/Node", registry)).Optional()))
+	registry.Define("Order", Object().
+		Property("customer", Ref("#/Customer", registry)))
+	loop := Ref("#/Loop", registry)
+	registry.Define("Loop", loop)
+	registry.Alias("A", "B")
+	registry.Alias("B", "A")
+	registry.Alias("Tree", "Node")
+
+	err := registry.ResolveAll()
+	if err == nil {
+		t.Fatal("ResolveAll() should report problems")
+	}
+	if !errors.Is(err, ErrRefNotFound) || !errors.Is(err, ErrRefCircular) {
+		t.Errorf("ResolveAll() error = %v, want dangling and circular references", err)
+	}
+
+	var problems []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var refErr *RefError
+		if errors.As(e, &refErr) {
+			problems = append(problems, refErr.Definition+" "+refErr.Ref)
+		}
+	}
+	want := []string{"A B", "B A", "Loop #/Loop", "Order #/Customer"}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("ResolveAll() problems = %v, want %v", problems, want)
+	}
+
+	registry.Define("Customer", Object().Property("name", String()))
+	registry.Clear()
+	registry.Define("Node", Object().
+		Property("children", Array(Ref("#/Node", registry)).Optional()))
+	if err := registry.ResolveAll(); err != nil {
+		t.Errorf("ResolveAll() error = %v, want nil for recursion through a concrete schema", err)
+	}
+
+	registry.Define("Remote", Ref("common.json#/definitions/Address", registry))
+	if err := registry.ResolveAll(); err == nil || !strings.Contains(err.Error(), "no document loader") {
+		t.Errorf("ResolveAll() error = %v, want missing loader", err)
+	}
+}
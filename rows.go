@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RowError reports the validation errors of one database row
+type RowError struct {
+	Row    int                    // Position of the row in the result set, starting at 1
+	Values map[string]interface{} // Column values, as validated
+	Errors []ValidationError      // Errors, with paths starting at the column name
+}
+
+// Error implements error
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, ValidationErrors(e.Errors).Format(ErrorFormatList))
+}
+
+// ValidateRows reads every remaining row of rows and validates it against s, mapping
+// each column to the property of the same name. It returns the rows that failed, so
+// legacy tables can be audited against a new schema:
+//
+//	rows, err := db.Query("SELECT id, email, age FROM users")
+//	...
+//	defer rows.Close()
+//	failures, err := schema.ValidateRows(rows, userSchema)
+//
+// Driver values are converted as RowValues describes. The error is non-nil only when
+// reading the rows fails.
+func ValidateRows(rows *sql.Rows, s *ObjectSchema) ([]RowError, error) {
+	var failures []RowError
+	for row := 1; rows.Next(); row++ {
+		values, result, err := scanRow(rows, s)
+		if err != nil {
+			return failures, fmt.Errorf("row %d: %w", row, err)
+		}
+		if !result.Valid {
+			failures = append(failures, RowError{Row: row, Values: values, Errors: result.Errors})
+		}
+	}
+	return failures, rows.Err()
+}
+
+// ScanAndValidate scans the current row of rows, after a call to rows.Next, and
+// validates it against s. The parsed row is the Value of the result.
+func ScanAndValidate(rows *sql.Rows, s *ObjectSchema) (ParseResult, error) {
+	_, result, err := scanRow(rows, s)
+	return result, err
+}
+
+// scanRow scans the current row and validates it
+func scanRow(rows *sql.Rows, s *ObjectSchema) (map[string]interface{}, ParseResult, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, ParseResult{}, err
+	}
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, ParseResult{}, err
+	}
+	row := RowValues(s, columns, values)
+	return row, ValidateRow(s, row), nil
+}
+
+// ValidateRow validates the values of a row returned by RowValues. Coercion is enabled,
+// so numeric and boolean columns returned as text by the driver match their schemas.
+func ValidateRow(s *ObjectSchema, row map[string]interface{}) ParseResult {
+	return s.Parse(row, DefaultValidationContext().WithCoercion())
+}
+
+// RowValues maps the columns of a row to the values schemas validate, for drivers
+// without database/sql support such as pgx (columns from rows.FieldDescriptions(),
+// values from rows.Values()):
+//
+//   - NULL columns are omitted, so they fail required properties only
+//   - driver.Valuer values are replaced by their driver value
+//   - []byte becomes a string, encoded as the property expects for Binary properties
+//   - time.Time becomes a string in the format of Date properties (RFC 3339 otherwise)
+//   - [16]byte becomes a UUID string
+//
+// Other values, including int64, float64 and bool, are kept.
+func RowValues(s *ObjectSchema, columns []string, values []interface{}) map[string]interface{} {
+	properties := s.GetProperties()
+	row := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		if i >= len(values) {
+			break
+		}
+		if value := columnValue(properties[column].Schema, values[i]); value != nil {
+			row[column] = value
+		}
+	}
+	return row
+}
+
+// columnValue converts a driver value for the schema of its property (nil when the
+// column has no property)
+func columnValue(property Parseable, value interface{}) interface{} {
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			value = v
+		}
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		if binary, ok := property.(*BinarySchema); ok {
+			switch binary.format {
+			case BinaryFormatHex:
+				return hex.EncodeToString(v)
+			case BinaryFormatBase64URL:
+				return base64.URLEncoding.EncodeToString(v)
+			}
+			return base64.StdEncoding.EncodeToString(v)
+		}
+		return string(v)
+
+	case time.Time:
+		date, ok := property.(*DateSchema)
+		if !ok {
+			return v.Format(time.RFC3339Nano)
+		}
+		switch date.GetFormat() {
+		case FormatDate, FormatDateOnly:
+			return v.Format("2006-01-02")
+		case FormatTime, FormatTimeOnly:
+			return v.Format("15:04:05")
+		case FormatUnix:
+			return strconv.FormatInt(v.Unix(), 10)
+		}
+		return v.Format(time.RFC3339Nano)
+
+	case [16]byte:
+		h := hex.EncodeToString(v[:])
+		return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+	}
+	return value
+}
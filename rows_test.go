@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRows is a database/sql driver returning the rows of a fixed result set for
+// every query
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+type fakeDriver struct{ result fakeRows }
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return d, nil }
+func (d *fakeDriver) Prepare(string) (driver.Stmt, error) {
+	return d, nil
+}
+func (d *fakeDriver) Close() error              { return nil }
+func (d *fakeDriver) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+func (d *fakeDriver) NumInput() int             { return -1 }
+func (d *fakeDriver) Exec([]driver.Value) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+func (d *fakeDriver) Query([]driver.Value) (driver.Rows, error) {
+	result := d.result
+	return &result, nil
+}
+
+var fakeUsers = &fakeDriver{result: fakeRows{
+	columns: []string{"id", "email", "age", "born", "avatar"},
+	rows: [][]driver.Value{
+		{int64(1), []byte("ann@example.com"), int64(30), time.Date(1994, 5, 1, 0, 0, 0, 0, time.UTC), []byte{0xff}},
+		{int64(2), []byte("not-an-email"), []byte("12"), nil, nil},
+		{int64(3), nil, int64(45), time.Date(1979, 1, 2, 0, 0, 0, 0, time.UTC), nil},
+	},
+}}
+
+func init() {
+	sql.Register("schema-fake-users", fakeUsers)
+}
+
+func queryFakeUsers(t *testing.T) *sql.Rows {
+	t.Helper()
+	db, err := sql.Open("schema-fake-users", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query("SELECT * FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+var userRowSchema = Object().
+	Property("id", Int()).
+	Property("email", String().Email()).
+	Property("age", Int().Min(18)).
+	Property("born", Date().Optional()).
+	Property("avatar", Binary())
+
+func TestValidateRows(t *testing.T) {
+	failures, err := ValidateRows(queryFakeUsers(t), userRowSchema)
+	if err != nil {
+		t.Fatalf("ValidateRows() error = %v", err)
+	}
+
+	got := map[int][]string{}
+	for _, failure := range failures {
+		for _, e := range failure.Errors {
+			got[failure.Row] = append(got[failure.Row], e.Path.DotPath()+" "+string(e.Code))
+		}
+	}
+	want := map[int][]string{
+		2: {"age minimum", "email format"},
+		3: {"email required"},
+	}
+	for row, codes := range want {
+		for _, code := range codes {
+			if !strings.Contains(strings.Join(got[row], ","), code) {
+				t.Errorf("row %d errors = %v, want %q", row, got[row], code)
+			}
+		}
+	}
+	if _, ok := got[1]; ok {
+		t.Errorf("row 1 errors = %v, want none", got[1])
+	}
+	if len(failures) > 0 && !strings.HasPrefix(failures[0].Error(), "row 2: ") {
+		t.Errorf("RowError.Error() = %q", failures[0].Error())
+	}
+}
+
+func TestScanAndValidate(t *testing.T) {
+	rows := queryFakeUsers(t)
+	if !rows.Next() {
+		t.Fatal("no rows")
+	}
+	result, err := ScanAndValidate(rows, userRowSchema)
+	if err != nil || !result.Valid {
+		t.Fatalf("ScanAndValidate() = %v, %v", result.Errors, err)
+	}
+	row := result.Value.(map[string]interface{})
+	if row["email"] != "ann@example.com" {
+		t.Errorf("email = %#v", row["email"])
+	}
+}
+
+func TestRowValues(t *testing.T) {
+	s := Object().
+		Property("born", Date()).
+		Property("at", Time()).
+		Property("data", Hex()).
+		Property("name", String())
+	born := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	uuid := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
+	got := RowValues(s,
+		[]string{"born", "at", "data", "name", "id", "created", "deleted"},
+		[]interface{}{born, born, []byte{0xab}, []byte("Ann"), uuid, born, nil})
+	want := map[string]interface{}{
+		"born":    "2001-02-03",
+		"at":      "04:05:06",
+		"data":    "ab",
+		"name":    "Ann",
+		"id":      "123e4567-e89b-12d3-a456-426614174000",
+		"created": "2001-02-03T04:05:06Z",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RowValues() = %#v, want %#v", got, want)
+	}
+}
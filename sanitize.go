@@ -0,0 +1,123 @@
+package schema
+
+import (
+	"strings"
+)
+
+// collapseWhitespace replaces each run of whitespace with a single space and trims the ends
+func collapseWhitespace(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// stripHTML removes tags, comments, doctypes and processing instructions, and drops the
+// content of script and style elements. A "<" that does not start a tag ("a < b") is
+// kept. An unterminated tag or comment removes the rest of the input, so that no
+// partial markup survives.
+func stripHTML(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); {
+		if value[i] != '<' {
+			b.WriteByte(value[i])
+			i++
+			continue
+		}
+
+		rest := value[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return b.String()
+			}
+			i += 4 + end + 3
+		case startsHTMLTag(rest):
+			end := htmlTagEnd(rest)
+			if end < 0 {
+				return b.String()
+			}
+			i += end
+			if name := htmlTagName(rest[1:end]); name == "script" || name == "style" {
+				// Skip to the closing tag, which the next iteration removes
+				close := indexASCIIFold(value[i:], "</"+name)
+				if close < 0 {
+					return b.String()
+				}
+				i += close
+			}
+		default:
+			b.WriteByte('<')
+			i++
+		}
+	}
+	return b.String()
+}
+
+// startsHTMLTag reports whether s starts with "<" followed by a letter, "/" and a
+// letter, "!" or "?"
+func startsHTMLTag(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	switch c := s[1]; {
+	case isAlpha(s[1:2]), c == '!', c == '?':
+		return true
+	case c == '/':
+		return len(s) > 2 && isAlpha(s[2:3])
+	}
+	return false
+}
+
+// htmlTagEnd returns the index just past the ">" closing the tag at the start of s,
+// skipping ">" inside quoted attribute values, or -1 if the tag is not closed
+func htmlTagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// htmlTagName returns the lowercase name of an opening tag ("" for closing tags,
+// comments and doctypes)
+func htmlTagName(tag string) string {
+	end := 0
+	for end < len(tag) && isAlphanumeric(tag[end:end+1]) {
+		end++
+	}
+	return strings.ToLower(tag[:end])
+}
+
+// indexASCIIFold returns the index of the first occurrence of the ASCII string substr
+// in s, ignoring ASCII case, or -1
+func indexASCIIFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		match := true
+		for j := 0; j < len(substr); j++ {
+			if lowerASCII(s[i+j]) != lowerASCII(substr[j]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// lowerASCII lowercases an ASCII letter and returns other bytes unchanged
+func lowerASCII(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
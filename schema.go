@@ -1,13 +1,19 @@
 package schema
 
+import (
+	"maps"
+	"slices"
+)
+
 // Schema represents the base fields for all JSON Schema types
 type Schema struct {
 	// Core JSON Schema fields (private - use getters to access)
-	schemaType   string        // JSON Schema type
-	title        string        // Schema title
-	description  string        // Schema description
-	defaultValue interface{}   // Default value
-	examples     []interface{} // Example values
+	schemaType   string             // JSON Schema type
+	title        string             // Schema title
+	description  string             // Schema description
+	defaultValue interface{}        // Default value
+	defaultFunc  func() interface{} // Computes the default at parse time (overrides defaultValue)
+	examples     []interface{}      // Example values
 
 	// Schema composition
 	ref         string             // $ref
@@ -19,8 +25,40 @@ type Schema struct {
 	enum     []interface{} // enum values
 	constVal interface{}   // const value
 
+	// Annotations
+	readOnly  bool // Managed by the server, not accepted in writes
+	writeOnly bool // Accepted in writes, never returned (e.g. passwords)
+	sensitive bool // Masked in the values of errors (see Sensitive)
+
+	deprecated        bool   // Kept for compatibility, to be removed
+	deprecationReason string // Why the value is deprecated and what replaces it
+	meta              Meta   // Free-form annotations, emitted as x- keywords
+
+	// Severities of error codes reported as warnings or infos ("" for all codes)
+	severities map[ErrorCode]Severity
+
 	// Required flag (internal for builder logic)
 	required bool // Not serialized, used for validation
+
+	// Post-processing pipeline (Transform/Refine), run after validation succeeds
+	effects effects
+
+	// Set by Freeze; a frozen schema rejects modification
+	frozenState
+}
+
+// cloneBase returns an unfrozen copy of the base fields that shares no slices or
+// maps with s
+func (s *Schema) cloneBase() Schema {
+	c := *s
+	c.examples = slices.Clone(s.examples)
+	c.definitions = maps.Clone(s.definitions)
+	c.enum = slices.Clone(s.enum)
+	c.meta = maps.Clone(s.meta)
+	c.severities = maps.Clone(s.severities)
+	c.effects = slices.Clone(s.effects)
+	c.frozenState = frozenState{}
+	return c
 }
 
 // Base getters for all schema types
@@ -40,8 +78,25 @@ func (s *Schema) GetDescription() string {
 	return s.description
 }
 
-// GetDefault returns the default value
+// GetDefault returns the default value, calling the function set with DefaultFunc if any
 func (s *Schema) GetDefault() interface{} {
+	if s.defaultFunc != nil {
+		return s.defaultFunc()
+	}
+	return s.defaultValue
+}
+
+// HasDefaultFunc returns whether the default is computed at parse time
+func (s *Schema) HasDefaultFunc() bool {
+	return s.defaultFunc != nil
+}
+
+// staticDefault returns the default set with Default, for JSON Schema generation.
+// Defaults computed by DefaultFunc are not emitted because they differ per parse.
+func (s *Schema) staticDefault() interface{} {
+	if s.defaultFunc != nil {
+		return nil
+	}
 	return s.defaultValue
 }
 
@@ -80,6 +135,47 @@ func (s *Schema) GetConst() interface{} {
 	return s.constVal
 }
 
+// IsReadOnly returns whether the schema is marked read-only
+func (s *Schema) IsReadOnly() bool {
+	return s.readOnly
+}
+
+// IsWriteOnly returns whether the schema is marked write-only
+func (s *Schema) IsWriteOnly() bool {
+	return s.writeOnly
+}
+
+// IsSensitive returns whether the values of the schema are masked in errors
+func (s *Schema) IsSensitive() bool {
+	return s.sensitive
+}
+
+// IsDeprecated returns whether the schema is marked deprecated
+func (s *Schema) IsDeprecated() bool {
+	return s.deprecated
+}
+
+// GetDeprecationReason returns the reason given to Deprecated
+func (s *Schema) GetDeprecationReason() string {
+	return s.deprecationReason
+}
+
+// GetMeta returns the metadata set with Meta (nil if none)
+func (s *Schema) GetMeta() Meta {
+	return s.meta
+}
+
+// setMeta stores a metadata entry, copying the bag so that copies of the schema do
+// not share it
+func (s *Schema) setMeta(key string, value interface{}) {
+	meta := make(Meta, len(s.meta)+1)
+	for k, v := range s.meta {
+		meta[k] = v
+	}
+	meta[key] = value
+	s.meta = meta
+}
+
 // IsRequired returns whether the schema is required
 func (s *Schema) IsRequired() bool {
 	return s.required
@@ -0,0 +1,535 @@
+// Package schemagen generates Go code from schemas: a struct per object schema with
+// json tags, and a typed Parse function per schema that validates JSON input and
+// decodes the parsed value without reflection.
+//
+//	src, err := schemagen.Generate(map[string]schema.JSONSchemaGenerator{
+//	    "User": UserSchema,
+//	}, schemagen.Options{Package: "models"})
+//
+// For the example above the generated file declares
+//
+//	type User struct { ... }
+//	func ParseUser(data []byte) (User, error)
+//
+// ParseUser validates against the package-level variable UserSchema, which must be
+// declared in the same package (see Options.Schemas to use another expression).
+package schemagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nyxstack/schema"
+)
+
+// Options configures Generate
+type Options struct {
+	Package string // Package name of the generated file (required)
+
+	// Schemas maps a type name to the Go expression of the schema its Parse function
+	// validates against. Types without an entry use <Name>Schema.
+	Schemas map[string]string
+}
+
+// commonInitialisms are written in upper case in Go identifiers (userId → UserID)
+var commonInitialisms = map[string]bool{
+	"API": true, "CPU": true, "CSS": true, "DNS": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "SQL": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "URI": true, "URL": true,
+	"UUID": true, "XML": true,
+}
+
+// Generate returns gofmt-formatted Go source declaring a type and a Parse function
+// for each named schema. Object schemas become structs (nested objects become
+// separate structs named after their parent and property), string enums become
+// named string types with a constant per value, and optional or nullable
+// properties become pointers. Schemas without a Go equivalent, such as unions,
+// decode to interface{}.
+func Generate(types map[string]schema.JSONSchemaGenerator, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("schemagen: Options.Package is required")
+	}
+
+	g := &generator{declared: map[string]bool{}, decoders: map[string]string{}}
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parsers strings.Builder
+	for _, name := range names {
+		typeName := goName(name)
+		doc, err := document(types[name])
+		if err != nil {
+			return nil, fmt.Errorf("schemagen: schema %s: %w", name, err)
+		}
+		t, err := g.declare(typeName, doc)
+		if err != nil {
+			return nil, fmt.Errorf("schemagen: schema %s: %w", name, err)
+		}
+
+		schemaExpr := typeName + "Schema"
+		if expr, ok := opts.Schemas[name]; ok {
+			schemaExpr = expr
+		}
+		fmt.Fprintf(&parsers, "\n// Parse%s validates JSON data against %s and decodes the result into a %s\n", typeName, schemaExpr, typeName)
+		fmt.Fprintf(&parsers, "func Parse%s(data []byte) (%s, error) {\n", typeName, typeName)
+		fmt.Fprintf(&parsers, "\tvar zero %s\n", typeName)
+		parsers.WriteString("\tvar raw interface{}\n")
+		parsers.WriteString("\tif err := json.Unmarshal(data, &raw); err != nil {\n\t\treturn zero, err\n\t}\n")
+		fmt.Fprintf(&parsers, "\tresult := %s.Parse(raw, schema.DefaultValidationContext())\n", schemaExpr)
+		parsers.WriteString("\tif !result.Valid {\n\t\treturn zero, schema.ValidationErrors(result.Errors)\n\t}\n")
+		fmt.Fprintf(&parsers, "\treturn %s(result.Value)\n}\n", t.decoder)
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by schemagen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n", opts.Package)
+	if len(names) > 0 {
+		b.WriteString("\nimport (\n\t\"encoding/json\"\n\t\"fmt\"\n\n\t\"github.com/nyxstack/schema\"\n)\n")
+	}
+	for _, decl := range g.decls {
+		b.WriteString("\n" + decl)
+	}
+	b.WriteString(parsers.String())
+
+	decoderNames := make([]string, 0, len(g.decoders))
+	for name := range g.decoders {
+		decoderNames = append(decoderNames, name)
+	}
+	sort.Strings(decoderNames)
+	for _, name := range decoderNames {
+		b.WriteString("\n" + g.decoders[name])
+	}
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("schemagen: formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// goType is a Go type expression and the name of the generated function that
+// converts a parsed value to it
+type goType struct {
+	expr    string
+	decoder string
+	pointer bool // Whether the type already has a nil value (pointer, slice, map, interface)
+}
+
+// generator accumulates type declarations and decode functions
+type generator struct {
+	decls    []string // Type declarations, parents before the types nested in them
+	declared map[string]bool
+	decoders map[string]string // Decode function source by function name
+}
+
+// declare declares a named type for a top-level schema
+func (g *generator) declare(name string, doc map[string]interface{}) (goType, error) {
+	if isType(doc, "object") && doc["properties"] != nil {
+		return g.structType(name, doc)
+	}
+	if values, ok := stringEnum(doc); ok {
+		return g.enumType(name, values, doc)
+	}
+
+	t, err := g.typeOf(name, doc)
+	if err != nil {
+		return goType{}, err
+	}
+	if _, err := g.reserve(name); err != nil {
+		return goType{}, err
+	}
+	var decl strings.Builder
+	writeDoc(&decl, doc)
+	fmt.Fprintf(&decl, "type %s %s\n", name, t.expr)
+	g.decls = append(g.decls, decl.String())
+	g.decoders["decode"+name] = fmt.Sprintf("func decode%s(value interface{}) (%s, error) {\n\tv, err := %s(value)\n\treturn %s(v), err\n}\n", name, name, t.decoder, name)
+	return goType{expr: name, decoder: "decode" + name, pointer: t.pointer}, nil
+}
+
+// typeOf returns the Go type of a schema, declaring nested structs and enums named
+// after name
+func (g *generator) typeOf(name string, doc map[string]interface{}) (goType, error) {
+	if ref, ok := doc["$ref"].(string); ok {
+		target := goName(ref[strings.LastIndex(ref, "/")+1:])
+		// Refs are pointers so that types may refer to themselves
+		return g.pointerTo(goType{expr: target, decoder: "decode" + target}), nil
+	}
+	if doc["oneOf"] != nil || doc["anyOf"] != nil || doc["allOf"] != nil {
+		return g.scalar("interface{}", "Any"), nil
+	}
+	if values, ok := stringEnum(doc); ok {
+		return g.enumType(name, values, doc)
+	}
+
+	switch {
+	case isType(doc, "object"):
+		values, ok := doc["additionalProperties"].(map[string]interface{})
+		if doc["properties"] == nil {
+			if !ok {
+				return g.collection("map[string]", "Map", g.scalar("interface{}", "Any")), nil
+			}
+			elem, err := g.typeOf(name+"Value", values)
+			if err != nil {
+				return goType{}, err
+			}
+			return g.collection("map[string]", "Map", elem), nil
+		}
+		return g.structType(name, doc)
+
+	case isType(doc, "array"):
+		items, ok := doc["items"].(map[string]interface{})
+		if !ok {
+			return g.collection("[]", "Slice", g.scalar("interface{}", "Any")), nil
+		}
+		elem, err := g.typeOf(name+"Item", items)
+		if err != nil {
+			return goType{}, err
+		}
+		return g.collection("[]", "Slice", elem), nil
+
+	case isType(doc, "string"):
+		return g.scalar("string", "String"), nil
+
+	case isType(doc, "integer"):
+		switch format, _ := doc["format"].(string); format {
+		case "int8", "int16", "int32", "int64":
+			return g.scalar(format, goName(format)), nil
+		}
+		return g.scalar("int", "Int"), nil
+
+	case isType(doc, "number"):
+		return g.scalar("float64", "Float64"), nil
+
+	case isType(doc, "boolean"):
+		return g.scalar("bool", "Bool"), nil
+	}
+	return g.scalar("interface{}", "Any"), nil
+}
+
+// structType declares a struct for an object schema
+func (g *generator) structType(name string, doc map[string]interface{}) (goType, error) {
+	slot, err := g.reserve(name)
+	if err != nil {
+		return goType{}, err
+	}
+
+	properties, _ := doc["properties"].(map[string]interface{})
+	required := map[string]bool{}
+	list, _ := doc["required"].([]interface{})
+	for _, item := range list {
+		if prop, ok := item.(string); ok {
+			required[prop] = true
+		}
+	}
+
+	props := make([]string, 0, len(properties))
+	for prop := range properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	var fields, decode strings.Builder
+	fieldNames := map[string]string{}
+	for _, prop := range props {
+		propDoc, _ := properties[prop].(map[string]interface{})
+		fieldName := goName(prop)
+		if other, exists := fieldNames[fieldName]; exists {
+			return goType{}, fmt.Errorf("properties %q and %q both map to field %s", other, prop, fieldName)
+		}
+		fieldNames[fieldName] = prop
+
+		t, err := g.typeOf(name+fieldName, propDoc)
+		if err != nil {
+			return goType{}, fmt.Errorf("%s: %w", prop, err)
+		}
+		if (!required[prop] || isType(propDoc, "null")) && !t.pointer {
+			t = g.pointerTo(t)
+		}
+
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		if desc, ok := propDoc["description"].(string); ok && desc != "" {
+			fmt.Fprintf(&fields, "\t// %s\n", strings.ReplaceAll(desc, "\n", "\n\t// "))
+		}
+		fmt.Fprintf(&fields, "\t%s %s `json:%q`\n", fieldName, t.expr, tag)
+		fmt.Fprintf(&decode, "\tif raw, ok := m[%q]; ok && raw != nil {\n", prop)
+		fmt.Fprintf(&decode, "\t\tif v.%s, err = %s(raw); err != nil {\n", fieldName, t.decoder)
+		fmt.Fprintf(&decode, "\t\t\treturn v, fmt.Errorf(%q, err)\n\t\t}\n\t}\n", strings.ReplaceAll(prop, "%", "%%")+": %w")
+	}
+
+	var decl strings.Builder
+	writeDoc(&decl, doc)
+	fmt.Fprintf(&decl, "type %s struct {\n%s}\n", name, fields.String())
+	g.decls[slot] = decl.String()
+
+	var fn strings.Builder
+	fmt.Fprintf(&fn, "func decode%s(value interface{}) (%s, error) {\n", name, name)
+	fmt.Fprintf(&fn, "\tvar v %s\n", name)
+	fn.WriteString("\tm, ok := value.(map[string]interface{})\n")
+	fn.WriteString("\tif !ok {\n\t\treturn v, fmt.Errorf(\"expected object, got %T\", value)\n\t}\n")
+	if len(props) > 0 {
+		fn.WriteString("\tvar err error\n")
+	}
+	fn.WriteString(decode.String())
+	fn.WriteString("\treturn v, nil\n}\n")
+	g.decoders["decode"+name] = fn.String()
+
+	return goType{expr: name, decoder: "decode" + name}, nil
+}
+
+// enumType declares a named string type with a constant per enum value
+func (g *generator) enumType(name string, values []string, doc map[string]interface{}) (goType, error) {
+	slot, err := g.reserve(name)
+	if err != nil {
+		return goType{}, err
+	}
+	var decl strings.Builder
+	writeDoc(&decl, doc)
+	fmt.Fprintf(&decl, "type %s string\n\n", name)
+	fmt.Fprintf(&decl, "// %s values\nconst (\n", name)
+	for _, value := range values {
+		fmt.Fprintf(&decl, "\t%s%s %s = %q\n", name, goName(value), name, value)
+	}
+	decl.WriteString(")\n")
+	g.decls[slot] = decl.String()
+
+	g.scalar("string", "String")
+	g.decoders["decode"+name] = fmt.Sprintf("func decode%s(value interface{}) (%s, error) {\n\ts, err := decodeString(value)\n\treturn %s(s), err\n}\n", name, name, name)
+	return goType{expr: name, decoder: "decode" + name}, nil
+}
+
+// scalar returns a basic type, generating its decode function on first use
+func (g *generator) scalar(expr, suffix string) goType {
+	decoder := "decode" + suffix
+	if _, ok := g.decoders[decoder]; !ok {
+		g.decoders[decoder] = scalarDecoder(expr, decoder)
+		if expr != "int64" && strings.HasPrefix(expr, "int") {
+			g.scalar("int64", "Int64")
+		}
+	}
+	return goType{expr: expr, decoder: decoder, pointer: expr == "interface{}"}
+}
+
+// collection returns a slice or map of elem, generating its decode function
+func (g *generator) collection(prefix, kind string, elem goType) goType {
+	expr := prefix + elem.expr
+	decoder := "decode" + kind + strings.TrimPrefix(elem.decoder, "decode")
+	if _, ok := g.decoders[decoder]; !ok {
+		var fn strings.Builder
+		fmt.Fprintf(&fn, "func %s(value interface{}) (%s, error) {\n", decoder, expr)
+		if kind == "Slice" {
+			fn.WriteString("\titems, ok := value.([]interface{})\n")
+			fn.WriteString("\tif !ok {\n\t\treturn nil, fmt.Errorf(\"expected array, got %T\", value)\n\t}\n")
+			fmt.Fprintf(&fn, "\tresult := make(%s, len(items))\n", expr)
+			fn.WriteString("\tfor i, item := range items {\n\t\tif item == nil {\n\t\t\tcontinue\n\t\t}\n")
+			fmt.Fprintf(&fn, "\t\tv, err := %s(item)\n", elem.decoder)
+			fn.WriteString("\t\tif err != nil {\n\t\t\treturn nil, fmt.Errorf(\"[%d]: %w\", i, err)\n\t\t}\n")
+		} else {
+			fn.WriteString("\titems, ok := value.(map[string]interface{})\n")
+			fn.WriteString("\tif !ok {\n\t\treturn nil, fmt.Errorf(\"expected object, got %T\", value)\n\t}\n")
+			fmt.Fprintf(&fn, "\tresult := make(%s, len(items))\n", expr)
+			fn.WriteString("\tfor i, item := range items {\n\t\tif item == nil {\n\t\t\tcontinue\n\t\t}\n")
+			fmt.Fprintf(&fn, "\t\tv, err := %s(item)\n", elem.decoder)
+			fn.WriteString("\t\tif err != nil {\n\t\t\treturn nil, fmt.Errorf(\"%s: %w\", i, err)\n\t\t}\n")
+		}
+		fn.WriteString("\t\tresult[i] = v\n\t}\n\treturn result, nil\n}\n")
+		g.decoders[decoder] = fn.String()
+	}
+	return goType{expr: expr, decoder: decoder, pointer: true}
+}
+
+// pointerTo returns a pointer to t, generating its decode function
+func (g *generator) pointerTo(t goType) goType {
+	if strings.HasPrefix(t.expr, "*") {
+		return t
+	}
+	decoder := "decodePtr" + strings.TrimPrefix(t.decoder, "decode")
+	if _, ok := g.decoders[decoder]; !ok {
+		g.decoders[decoder] = fmt.Sprintf("func %s(value interface{}) (*%s, error) {\n\tv, err := %s(value)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn &v, nil\n}\n", decoder, t.expr, t.decoder)
+	}
+	return goType{expr: "*" + t.expr, decoder: decoder, pointer: true}
+}
+
+// reserve records a declared type name, rejecting duplicates, and returns the index
+// of the slot its declaration is written to
+func (g *generator) reserve(name string) (int, error) {
+	if g.declared[name] {
+		return 0, fmt.Errorf("type %s is declared twice; rename one of the schemas or properties", name)
+	}
+	g.declared[name] = true
+	g.decls = append(g.decls, "")
+	return len(g.decls) - 1, nil
+}
+
+// writeDoc writes the title and description of a schema as a doc comment
+func writeDoc(b *strings.Builder, doc map[string]interface{}) {
+	for _, key := range []string{"title", "description"} {
+		if text, ok := doc[key].(string); ok && text != "" {
+			fmt.Fprintf(b, "// %s\n", strings.ReplaceAll(text, "\n", "\n// "))
+		}
+	}
+}
+
+// scalarDecoder returns the decode function of a basic type
+func scalarDecoder(expr, name string) string {
+	switch expr {
+	case "string", "bool":
+		return fmt.Sprintf("func %s(value interface{}) (%s, error) {\n\tv, ok := value.(%s)\n\tif !ok {\n\t\treturn v, fmt.Errorf(\"expected %s, got %%T\", value)\n\t}\n\treturn v, nil\n}\n", name, expr, expr, expr)
+	case "interface{}":
+		return fmt.Sprintf("func %s(value interface{}) (interface{}, error) {\n\treturn value, nil\n}\n", name)
+	case "float64":
+		return fmt.Sprintf(`func %s(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	}
+	return 0, fmt.Errorf("expected number, got %%T", value)
+}
+`, name)
+	case "int64":
+		return fmt.Sprintf(`func %s(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		if v <= 1<<63-1 {
+			return int64(v), nil
+		}
+	case float64:
+		if float64(int64(v)) == v {
+			return int64(v), nil
+		}
+	}
+	return 0, fmt.Errorf("expected integer, got %%v", value)
+}
+`, name)
+	}
+	// Other integer types convert from int64
+	return fmt.Sprintf("func %s(value interface{}) (%s, error) {\n\tv, err := decodeInt64(value)\n\treturn %s(v), err\n}\n", name, expr, expr)
+}
+
+// document returns the JSON Schema of s with the value types produced by encoding/json
+func document(s schema.JSONSchemaGenerator) (map[string]interface{}, error) {
+	data, err := json.Marshal(s.JSON())
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// isType reports whether a JSON Schema document allows the given type
+func isType(doc map[string]interface{}, typeName string) bool {
+	switch t := doc["type"].(type) {
+	case string:
+		return t == typeName
+	case []interface{}:
+		for _, item := range t {
+			if item == typeName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringEnum returns the values of an enum schema whose values are all strings
+func stringEnum(doc map[string]interface{}) ([]string, bool) {
+	list, ok := doc["enum"].([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, false
+	}
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		if item == nil {
+			continue
+		}
+		value, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+// goName converts a schema or property name to an exported Go identifier, upper
+// casing common initialisms (user_id and userId both become UserID)
+func goName(name string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && len(word) > 0 &&
+			(unicode.IsLower(word[len(word)-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	var b strings.Builder
+	for _, w := range words {
+		if upper := strings.ToUpper(w); commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		rs := []rune(strings.ToLower(w))
+		rs[0] = unicode.ToUpper(rs[0])
+		b.WriteString(string(rs))
+	}
+	result := b.String()
+	if result == "" || unicode.IsDigit([]rune(result)[0]) {
+		result = "X" + result
+	}
+	return result
+}
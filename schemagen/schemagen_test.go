@@ -0,0 +1,94 @@
+package schemagen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+func TestGenerate(t *testing.T) {
+	address := schema.Object().
+		Property("city", schema.String()).
+		Property("zipCode", schema.String().Optional())
+
+	user := schema.Object().
+		Property("id", schema.Int().Min(1)).
+		Property("email", schema.String().Description("Login address")).
+		Property("nickname", schema.String().Optional().Nullable()).
+		Property("tags", schema.Array(schema.String())).
+		Property("address", address).
+		Property("previousAddresses", schema.Array(address)).
+		Property("role", schema.Enum("admin", "super-user")).
+		Property("labels", schema.Record(schema.String(), schema.Int32())).
+		Property("manager", schema.Ref("#/components/schemas/User", nil))
+
+	src, err := Generate(map[string]schema.JSONSchemaGenerator{
+		"User":   user,
+		"status": schema.Enum("on", "off"),
+	}, Options{Package: "models", Schemas: map[string]string{"status": "StatusSchema"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	// Compare with alignment collapsed, since gofmt aligns fields across the struct
+	code := strings.Join(strings.Fields(string(src)), " ")
+
+	for _, want := range []string{
+		"// Code generated by schemagen. DO NOT EDIT.\n\npackage models\n",
+		"type User struct {\n",
+		"Address UserAddress `json:\"address\"`",
+		"// Login address\n\tEmail string `json:\"email\"`",
+		"ID int `json:\"id\"`",
+		"Labels map[string]int32 `json:\"labels\"`",
+		"Manager *User `json:\"manager\"`",
+		"Nickname *string `json:\"nickname,omitempty\"`",
+		"PreviousAddresses []UserPreviousAddressesItem `json:\"previousAddresses\"`",
+		"Role UserRole `json:\"role\"`",
+		"type UserAddress struct {\n\tCity    string  `json:\"city\"`\n\tZipCode *string `json:\"zipCode,omitempty\"`\n}\n",
+		"const (\n\tUserRoleAdmin     UserRole = \"admin\"\n\tUserRoleSuperUser UserRole = \"super-user\"\n)\n",
+		"type Status string\n",
+		"func ParseUser(data []byte) (User, error) {\n",
+		"result := UserSchema.Parse(raw, schema.DefaultValidationContext())\n",
+		"func ParseStatus(data []byte) (Status, error) {\n",
+		"result := StatusSchema.Parse(raw, schema.DefaultValidationContext())\n",
+		"return zero, schema.ValidationErrors(result.Errors)\n",
+		"\t\tif v.Nickname, err = decodePtrString(raw); err != nil {\n\t\t\treturn v, fmt.Errorf(\"nickname: %w\", err)\n",
+	} {
+		if want = strings.Join(strings.Fields(want), " "); !strings.Contains(code, want) {
+			t.Errorf("Generate() output missing %q\n%s", want, src)
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	if _, err := Generate(nil, Options{}); err == nil {
+		t.Error("Generate() should require a package name")
+	}
+
+	_, err := Generate(map[string]schema.JSONSchemaGenerator{
+		"User":        schema.Object().Property("address", schema.Object().Property("city", schema.String())),
+		"UserAddress": schema.Object().Property("street", schema.String()),
+	}, Options{Package: "models"})
+	if err == nil || !strings.Contains(err.Error(), "UserAddress is declared twice") {
+		t.Errorf("Generate() error = %v, want duplicate type error", err)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"id", "ID"},
+		{"userId", "UserID"},
+		{"user_id", "UserID"},
+		{"HTTPServer", "HTTPServer"},
+		{"zip-code", "ZipCode"},
+		{"apiURL", "APIURL"},
+		{"2fa", "X2fa"},
+	}
+	for _, tt := range tests {
+		if got := goName(tt.in); got != tt.want {
+			t.Errorf("goName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Package schemaotel reports schema validation to OpenTelemetry. Hooks returns the
+// schema.Hooks that record the duration of every parse and the failures of every
+// field as metrics, and a span for each top-level parse:
+//
+//	hooks, err := schemaotel.Hooks()
+//	if err != nil {
+//	    return err
+//	}
+//	ctx := schema.DefaultValidationContext().WithContext(r.Context()).WithHooks(hooks)
+//	result := orderSchema.Parse(payload, ctx)
+//
+// Metrics carry the schema type and the path of the value as attributes, with array
+// indices replaced by "*" ("items[*].sku") to keep their cardinality bounded. Keys of
+// records and maps are kept, so schemas validating values with arbitrary keys should
+// be parsed without hooks or with WithoutPaths.
+package schemaotel
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nyxstack/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the meter and tracer of the package
+const instrumentationName = "github.com/nyxstack/schema/schemaotel"
+
+// Attribute keys of the metrics and spans
+const (
+	SchemaTypeKey = attribute.Key("schema.type")
+	SchemaPathKey = attribute.Key("schema.path")
+	ErrorCountKey = attribute.Key("schema.errors")
+)
+
+// config holds the options of Hooks
+type config struct {
+	meterProvider  metric.MeterProvider
+	tracerProvider trace.TracerProvider
+	paths          bool
+}
+
+// Option configures Hooks
+type Option func(*config)
+
+// WithMeterProvider sets the provider of the meter (the global provider by default)
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithTracerProvider sets the provider of the tracer (the global provider by default)
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithoutPaths leaves the path attribute out of the metrics, which are then
+// aggregated by schema type only
+func WithoutPaths() Option {
+	return func(c *config) {
+		c.paths = false
+	}
+}
+
+// Hooks creates the hooks recording parses with the meter and tracer of the providers:
+//
+//   - schema.parse.duration: histogram of the parse durations in seconds, by schema
+//     type and path
+//   - schema.parse.failures: counter of the failed parses, by schema type and path
+//   - a "schema.Parse" span for each parse of a top-level value, with the schema type
+//     and error count, and an error status when validation fails (schemas parsing the
+//     top-level value for another, such as the members of a union, get spans too)
+func Hooks(opts ...Option) (*schema.Hooks, error) {
+	c := config{meterProvider: otel.GetMeterProvider(), tracerProvider: otel.GetTracerProvider(), paths: true}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	meter := c.meterProvider.Meter(instrumentationName)
+	duration, err := meter.Float64Histogram("schema.parse.duration",
+		metric.WithDescription("Duration of schema parses"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	failures, err := meter.Int64Counter("schema.parse.failures",
+		metric.WithDescription("Schema parses that failed validation"),
+		metric.WithUnit("{parse}"))
+	if err != nil {
+		return nil, err
+	}
+	tracer := c.tracerProvider.Tracer(instrumentationName)
+
+	return &schema.Hooks{
+		OnParseEnd: func(ctx context.Context, schemaType string, path schema.Path, elapsed time.Duration, errorCount int) {
+			attrs := []attribute.KeyValue{SchemaTypeKey.String(schemaType)}
+			if c.paths {
+				attrs = append(attrs, SchemaPathKey.String(metricPath(path)))
+			}
+			set := metric.WithAttributes(attrs...)
+			duration.Record(ctx, elapsed.Seconds(), set)
+			if errorCount > 0 {
+				failures.Add(ctx, 1, set)
+			}
+
+			if len(path) > 0 {
+				return
+			}
+			end := time.Now()
+			_, span := tracer.Start(ctx, "schema.Parse",
+				trace.WithTimestamp(end.Add(-elapsed)),
+				trace.WithAttributes(SchemaTypeKey.String(schemaType), ErrorCountKey.Int(errorCount)))
+			if errorCount > 0 {
+				span.SetStatus(codes.Error, "validation failed")
+			}
+			span.End(trace.WithTimestamp(end))
+		},
+	}, nil
+}
+
+// metricPath renders a path with its array indices replaced by "*"
+func metricPath(path schema.Path) string {
+	var b strings.Builder
+	for _, segment := range path {
+		if segment.IsIndex {
+			b.WriteString("[*]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(segment.Field)
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package schemaotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nyxstack/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestHooks(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	spans := tracetest.NewSpanRecorder()
+	hooks, err := Hooks(
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order := schema.Object().
+		Property("id", schema.String()).
+		Property("items", schema.Array(schema.Object().Property("sku", schema.String().MinLength(2))))
+	ctx := schema.DefaultValidationContext().WithHooks(hooks)
+	order.Parse(map[string]interface{}{"id": "o-1", "items": []interface{}{
+		map[string]interface{}{"sku": "A1"},
+		map[string]interface{}{"sku": "B"},
+		map[string]interface{}{"sku": "C"},
+	}}, ctx)
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]uint64{}
+	failures := map[string]int64{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch agg := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				for _, point := range agg.DataPoints {
+					counts[attributeKey(point.Attributes)] = point.Count
+				}
+			case metricdata.Sum[int64]:
+				for _, point := range agg.DataPoints {
+					failures[attributeKey(point.Attributes)] = point.Value
+				}
+			}
+		}
+	}
+
+	wantCounts := map[string]uint64{"Object ": 1, "String id": 1, "Array items": 1, "Object items[*]": 3, "String items[*].sku": 3}
+	for key, want := range wantCounts {
+		if counts[key] != want {
+			t.Errorf("duration count of %q = %d, want %d (all: %v)", key, counts[key], want, counts)
+		}
+	}
+	wantFailures := map[string]int64{"Object ": 1, "Array items": 1, "Object items[*]": 2, "String items[*].sku": 2}
+	for key, want := range wantFailures {
+		if failures[key] != want {
+			t.Errorf("failures of %q = %d, want %d (all: %v)", key, failures[key], want, failures)
+		}
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans, want 1 for the top-level parse", len(ended))
+	}
+	if ended[0].Name() != "schema.Parse" || ended[0].Status().Code != codes.Error {
+		t.Errorf("span = %s with status %v", ended[0].Name(), ended[0].Status())
+	}
+}
+
+func TestWithoutPaths(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	hooks, err := Hooks(WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))), WithoutPaths())
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema.Record(schema.String(), schema.Int()).Parse(map[string]interface{}{"a": 1, "b": 2}, schema.DefaultValidationContext().WithHooks(hooks))
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatal(err)
+	}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if histogram, ok := m.Data.(metricdata.Histogram[float64]); ok {
+				for _, point := range histogram.DataPoints {
+					if _, ok := point.Attributes.Value(SchemaPathKey); ok {
+						t.Errorf("data point has a path: %v", point.Attributes)
+					}
+				}
+			}
+		}
+	}
+}
+
+// attributeKey renders the schema type and path of a data point as "Type path"
+func attributeKey(set attribute.Set) string {
+	schemaType, _ := set.Value(SchemaTypeKey)
+	path, _ := set.Value(SchemaPathKey)
+	return schemaType.AsString() + " " + path.AsString()
+}
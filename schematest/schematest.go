@@ -0,0 +1,162 @@
+// Package schematest helps property-test and fuzz schemas. FuzzSchema feeds a schema
+// boundary values, generated valid data and fuzzer-mutated JSON, and fails when Parse
+// panics or returns an inconsistent result.
+//
+//	func FuzzUser(f *testing.F) {
+//	    schematest.FuzzSchema(f, userSchema)
+//	}
+//
+// Run it with go test -fuzz=FuzzUser; without -fuzz the seed corpus runs as a
+// regular test.
+package schematest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+// NestingDepth is the depth of the nested arrays and objects in BoundaryValues
+const NestingDepth = 512
+
+// FuzzSchema adds a seed corpus for s to f and fuzzes s.Parse with JSON input. Input
+// that is not valid JSON is parsed as a string. Each input is checked with Check.
+func FuzzSchema(f *testing.F, s schema.Parseable) {
+	f.Helper()
+	for _, value := range BoundaryValues() {
+		if data, err := json.Marshal(value); err == nil {
+			f.Add(data)
+		}
+	}
+	for seed := int64(1); seed <= 5; seed++ {
+		if data, err := json.Marshal(schema.Generate(s, schema.GenOptions{Seed: seed})); err == nil {
+			f.Add(data)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			value = string(data)
+		}
+		Check(t, s, value)
+	})
+}
+
+// CheckBoundaries runs Check on s with every value of BoundaryValues
+func CheckBoundaries(t testing.TB, s schema.Parseable) {
+	t.Helper()
+	for _, value := range BoundaryValues() {
+		Check(t, s, value)
+	}
+}
+
+// Check parses value with s and fails t if Parse panics, or if the result is valid
+// with errors or invalid without any. It returns the result.
+func Check(t testing.TB, s schema.Parseable, value interface{}) (result schema.ParseResult) {
+	t.Helper()
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("Parse(%s) panicked: %v", describe(value), r)
+			}
+		}()
+		result = s.Parse(value, schema.DefaultValidationContext())
+	}()
+
+	if result.Valid && len(result.Errors) > 0 {
+		t.Errorf("Parse(%s) is valid but reported %d errors: %v", describe(value), len(result.Errors), result.Errors)
+	}
+	if !result.Valid && len(result.Errors) == 0 {
+		t.Errorf("Parse(%s) is invalid but reported no errors", describe(value))
+	}
+	for _, err := range result.Errors {
+		if err.Code == "" || err.Message == "" {
+			t.Errorf("Parse(%s) reported an error without code or message: %#v", describe(value), err)
+		}
+	}
+	return result
+}
+
+// BoundaryValues returns values at the edges of what schemas accept: empty, blank and
+// very long strings, control characters and invalid UTF-8, integer and float limits,
+// non-finite numbers, empty and deeply nested arrays and objects
+func BoundaryValues() []interface{} {
+	return []interface{}{
+		nil,
+		true,
+		false,
+		"",
+		" ",
+		"\t\n",
+		"\x00",
+		"\xff\xfe",
+		"héllo wörld 👋",
+		"‮",
+		strings.Repeat("a", 1<<16),
+		"0",
+		"-1",
+		"1e400",
+		"null",
+		0,
+		-1,
+		1,
+		math.MaxInt8 + 1,
+		math.MinInt8 - 1,
+		math.MaxInt32 + 1,
+		math.MinInt32 - 1,
+		int64(math.MaxInt64),
+		int64(math.MinInt64),
+		uint64(math.MaxUint64),
+		0.5,
+		-0.0,
+		math.SmallestNonzeroFloat64,
+		math.MaxFloat64,
+		-math.MaxFloat64,
+		float64(1 << 53),
+		math.Inf(1),
+		math.Inf(-1),
+		math.NaN(),
+		json.Number("1e400"),
+		json.Number("18446744073709551616"),
+		[]interface{}{},
+		[]interface{}{nil},
+		[]interface{}{"", 0, false, nil},
+		map[string]interface{}{},
+		map[string]interface{}{"": nil},
+		map[string]interface{}{"__proto__": map[string]interface{}{}},
+		NestedArrays(NestingDepth),
+		NestedObjects(NestingDepth),
+	}
+}
+
+// NestedArrays returns depth arrays nested inside each other
+func NestedArrays(depth int) interface{} {
+	var value interface{} = []interface{}{}
+	for i := 1; i < depth; i++ {
+		value = []interface{}{value}
+	}
+	return value
+}
+
+// NestedObjects returns depth objects nested inside each other under the key "a"
+func NestedObjects(depth int) interface{} {
+	var value interface{} = map[string]interface{}{}
+	for i := 1; i < depth; i++ {
+		value = map[string]interface{}{"a": value}
+	}
+	return value
+}
+
+// describe returns a short description of a value for failure messages
+func describe(value interface{}) string {
+	text := fmt.Sprintf("%#v", value)
+	if len(text) > 80 {
+		text = text[:77] + "..."
+	}
+	return text
+}
@@ -0,0 +1,67 @@
+package schematest
+
+import (
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+func userSchema() *schema.ObjectSchema {
+	return schema.Object().
+		Property("id", schema.UUID()).
+		Property("name", schema.String().MinLength(1).MaxLength(40).Trim()).
+		Property("email", schema.Email()).
+		Property("age", schema.Int().Min(0).Max(130).Optional()).
+		Property("score", schema.Number().Min(0).Optional()).
+		Property("tags", schema.Array(schema.String().Slug()).UniqueItems().Optional()).
+		Property("meta", schema.Record(schema.String(), schema.Any()).Optional())
+}
+
+func TestCheckBoundaries(t *testing.T) {
+	schemas := map[string]schema.Parseable{
+		"string":   schema.String().MinLength(1).Pattern(`^[a-z]+$`),
+		"int":      schema.Int().Min(0),
+		"int8":     schema.Int8(),
+		"uint64":   schema.Uint64(),
+		"number":   schema.Number().Finite(),
+		"bool":     schema.Bool(),
+		"nullable": schema.String().Nullable(),
+		"array":    schema.Array(schema.Int()).MaxItems(3),
+		"tuple":    schema.Tuple(schema.String(), schema.Int()),
+		"union":    schema.Union(schema.String(), schema.Int()),
+		"object":   userSchema(),
+		"strict":   userSchema().Strict(),
+		"record":   schema.Record(schema.String(), schema.Int()),
+		"any":      schema.Any(),
+		"date":     schema.Date(),
+		"url":      schema.URL(),
+		"ip":       schema.IP(),
+		"password": schema.Password(),
+	}
+	for name, s := range schemas {
+		t.Run(name, func(t *testing.T) {
+			CheckBoundaries(t, s)
+		})
+	}
+}
+
+func TestNested(t *testing.T) {
+	depth := 0
+	for value := NestedArrays(4); ; depth++ {
+		items := value.([]interface{})
+		if len(items) == 0 {
+			break
+		}
+		value = items[0]
+	}
+	if depth != 3 {
+		t.Errorf("NestedArrays(4) has %d levels below the outermost, want 3", depth)
+	}
+	if Check(t, schema.Object().Passthrough(), NestedObjects(4)).Valid != true {
+		t.Error("nested objects should pass a passthrough object")
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	FuzzSchema(f, userSchema())
+}
@@ -0,0 +1,744 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for semantic version validation
+var (
+	semverRequiredError   = i18n.S("value is required")
+	semverTypeError       = i18n.S("value must be a string")
+	semverFormatError     = i18n.S("value must be a valid semantic version")
+	semverPrereleaseError = i18n.S("prerelease versions are not allowed")
+)
+
+// Default error message functions that take parameters
+func semverMinError(min string) i18n.TranslatedFunc {
+	return i18n.F("version must be at least %s", min)
+}
+
+func semverMaxError(max string) i18n.TranslatedFunc {
+	return i18n.F("version must be at most %s", max)
+}
+
+func semverRangeError(constraint string) i18n.TranslatedFunc {
+	return i18n.F("version must satisfy %s", constraint)
+}
+
+// semverPattern is the regular expression recommended by semver.org, used for JSON Schema output
+const semverPattern = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
+
+// SemanticVersion is a parsed Semantic Versioning 2.0.0 version
+type SemanticVersion struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string // Dot-separated prerelease identifiers ("beta.2" → ["beta", "2"])
+	Build      []string // Dot-separated build metadata, ignored when comparing
+}
+
+// ParseSemanticVersion parses a version such as "1.4.0-rc.1+build.5" following
+// Semantic Versioning 2.0.0. A leading "v" is not accepted.
+func ParseSemanticVersion(version string) (SemanticVersion, error) {
+	var v SemanticVersion
+	rest := version
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		build := strings.Split(rest[i+1:], ".")
+		for _, id := range build {
+			if !validSemverIdentifier(id, false) {
+				return SemanticVersion{}, fmt.Errorf("invalid build metadata in %q", version)
+			}
+		}
+		v.Build, rest = build, rest[:i]
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		pre := strings.Split(rest[i+1:], ".")
+		for _, id := range pre {
+			if !validSemverIdentifier(id, true) {
+				return SemanticVersion{}, fmt.Errorf("invalid prerelease in %q", version)
+			}
+		}
+		v.Prerelease, rest = pre, rest[:i]
+	}
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return SemanticVersion{}, fmt.Errorf("version %q must have the form MAJOR.MINOR.PATCH", version)
+	}
+	numbers := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, ok := parseSemverNumber(part)
+		if !ok {
+			return SemanticVersion{}, fmt.Errorf("invalid version number %q in %q", part, version)
+		}
+		*numbers[i] = n
+	}
+	return v, nil
+}
+
+// parseSemverNumber parses a numeric identifier without leading zeros
+func parseSemverNumber(s string) (uint64, bool) {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	return n, err == nil
+}
+
+// validSemverIdentifier checks a prerelease or build identifier; numeric prerelease
+// identifiers must not have leading zeros
+func validSemverIdentifier(id string, prerelease bool) bool {
+	if id == "" {
+		return false
+	}
+	numeric := true
+	for _, r := range id {
+		switch {
+		case r >= '0' && r <= '9':
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+			numeric = false
+		default:
+			return false
+		}
+	}
+	return !prerelease || !numeric || id == "0" || id[0] != '0'
+}
+
+// String formats the version
+func (v SemanticVersion) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// IsPrerelease returns whether the version has prerelease identifiers
+func (v SemanticVersion) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Compare returns -1, 0 or 1 as v precedes, equals or follows other. Build metadata
+// is ignored, and a prerelease precedes its release (1.0.0-rc.1 < 1.0.0).
+func (v SemanticVersion) Compare(other SemanticVersion) int {
+	for _, pair := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		if c := compareSemverIdentifier(v.Prerelease[i], other.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.Prerelease) < len(other.Prerelease):
+		return -1
+	case len(v.Prerelease) > len(other.Prerelease):
+		return 1
+	}
+	return 0
+}
+
+// compareSemverIdentifier orders prerelease identifiers: numeric ones numerically and
+// before alphanumeric ones, alphanumeric ones in ASCII order
+func compareSemverIdentifier(a, b string) int {
+	na, aErr := strconv.ParseUint(a, 10, 64)
+	nb, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		if na < nb {
+			return -1
+		} else if na > nb {
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// semverComparator is a single primitive condition of a range, such as ">=1.2.0"
+type semverComparator struct {
+	op      string // One of "<", "<=", ">", ">=", "="
+	version SemanticVersion
+}
+
+// matches reports whether v satisfies the comparator
+func (c semverComparator) matches(v SemanticVersion) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return cmp == 0
+}
+
+// parseSemverRange parses a range such as ">=1.0 <2.0 || ^3.1". Comparators separated
+// by spaces must all match and alternatives separated by "||" are tried in turn.
+// Besides <, <=, >, >= and =, the npm-style ^ (compatible) and ~ (same minor) operators
+// are supported, and partial versions ("1.2", "1.x", "*") cover every matching version.
+func parseSemverRange(constraint string) ([][]semverComparator, error) {
+	var alternatives [][]semverComparator
+	for _, alternative := range strings.Split(constraint, "||") {
+		fields := strings.Fields(alternative)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty alternative in range %q", constraint)
+		}
+		var comparators []semverComparator
+		for _, field := range fields {
+			parsed, err := parseSemverComparator(field)
+			if err != nil {
+				return nil, err
+			}
+			comparators = append(comparators, parsed...)
+		}
+		alternatives = append(alternatives, comparators)
+	}
+	return alternatives, nil
+}
+
+// parseSemverComparator expands one range term into primitive comparators
+func parseSemverComparator(term string) ([]semverComparator, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, prefix) {
+			op, term = prefix, term[len(prefix):]
+			break
+		}
+	}
+	v, parts, err := parsePartialSemver(term)
+	if err != nil {
+		return nil, err
+	}
+
+	// next returns the first release after every version matching the first length
+	// parts; upper bounds use next(n) with prerelease "0" so that prereleases of the
+	// next release ("2.0.0-beta") stay out of the range
+	next := func(length int) SemanticVersion {
+		switch length {
+		case 1:
+			return SemanticVersion{Major: v.Major + 1}
+		case 2:
+			return SemanticVersion{Major: v.Major, Minor: v.Minor + 1}
+		}
+		return SemanticVersion{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+	below := func(length int) semverComparator {
+		bound := next(length)
+		bound.Prerelease = []string{"0"}
+		return semverComparator{"<", bound}
+	}
+
+	if parts == 0 {
+		// "*", "^*" and "~*" match everything; "<*" and ">*" match nothing
+		if op == "<" || op == ">" {
+			return []semverComparator{{"<", SemanticVersion{Prerelease: []string{"0"}}}}, nil
+		}
+		return nil, nil
+	}
+
+	switch op {
+	case "^":
+		// Everything up to the first non-zero part given must stay the same
+		length := 1
+		if v.Major == 0 && parts >= 2 {
+			length = 2
+			if v.Minor == 0 && parts == 3 {
+				length = 3
+			}
+		}
+		return []semverComparator{{">=", v}, below(length)}, nil
+	case "~":
+		return []semverComparator{{">=", v}, below(min(parts, 2))}, nil
+	}
+
+	if parts == 3 {
+		if op == "" {
+			op = "="
+		}
+		return []semverComparator{{op, v}}, nil
+	}
+	switch op {
+	case ">":
+		return []semverComparator{{">=", next(parts)}}, nil
+	case ">=":
+		return []semverComparator{{">=", v}}, nil
+	case "<":
+		return []semverComparator{{"<", SemanticVersion{Major: v.Major, Minor: v.Minor, Prerelease: []string{"0"}}}}, nil
+	case "<=":
+		return []semverComparator{below(parts)}, nil
+	}
+	return []semverComparator{{">=", v}, below(parts)}, nil
+}
+
+// parsePartialSemver parses a version in which trailing parts may be missing or
+// wildcards ("1", "1.2", "1.x", "*"), returning the number of parts given
+func parsePartialSemver(term string) (SemanticVersion, int, error) {
+	if v, err := ParseSemanticVersion(term); err == nil {
+		return v, 3, nil
+	}
+	if term == "" {
+		return SemanticVersion{}, 0, errors.New("missing version in range")
+	}
+	var v SemanticVersion
+	numbers := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	parts := strings.Split(term, ".")
+	if len(parts) > 3 {
+		return SemanticVersion{}, 0, fmt.Errorf("invalid version %q in range", term)
+	}
+	given := 0
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		n, ok := parseSemverNumber(part)
+		if !ok {
+			return SemanticVersion{}, 0, fmt.Errorf("invalid version %q in range", term)
+		}
+		*numbers[i] = n
+		given++
+	}
+	return v, given, nil
+}
+
+// SemverSchema validates semantic versions and returns the parsed SemanticVersion
+type SemverSchema struct {
+	Schema
+	min             *SemanticVersion
+	max             *SemanticVersion
+	constraint      string
+	ranges          [][]semverComparator
+	allowPrerelease bool
+	nullable        bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	formatError       ErrorMessage
+	minError          ErrorMessage
+	maxError          ErrorMessage
+	rangeError        ErrorMessage
+	prereleaseError   ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// Semver creates a new semantic version schema; prerelease versions are allowed by default
+func Semver(errorMessage ...interface{}) *SemverSchema {
+	schema := &SemverSchema{
+		Schema: Schema{
+			schemaType: "string",
+			required:   true, // Default to required
+		},
+		allowPrerelease: true,
+	}
+	if len(errorMessage) > 0 {
+		schema.formatError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *SemverSchema) Clone() *SemverSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.ranges = slices.Clone(s.ranges)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *SemverSchema) Freeze() *SemverSchema {
+	s.freeze()
+	return s
+}
+
+// mustParseSemanticVersion parses a version given while building a schema
+func mustParseSemanticVersion(version string) SemanticVersion {
+	v, err := ParseSemanticVersion(version)
+	if err != nil {
+		panic(fmt.Sprintf("schema: invalid version %q: %v", version, err))
+	}
+	return v
+}
+
+// Title sets the title of the schema
+func (s *SemverSchema) Title(title string) *SemverSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *SemverSchema) Description(description string) *SemverSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *SemverSchema) ReadOnly() *SemverSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *SemverSchema) WriteOnly() *SemverSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *SemverSchema) Sensitive() *SemverSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *SemverSchema) Deprecated(reason string) *SemverSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *SemverSchema) Meta(key string, value interface{}) *SemverSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *SemverSchema) AsWarning(codes ...ErrorCode) *SemverSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *SemverSchema) AsInfo(codes ...ErrorCode) *SemverSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *SemverSchema) Default(value interface{}) *SemverSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *SemverSchema) DefaultFunc(fn func() interface{}) *SemverSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *SemverSchema) Example(example string) *SemverSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Min sets the lowest allowed version (inclusive); panics if version is invalid
+func (s *SemverSchema) Min(version string, errorMessage ...interface{}) *SemverSchema {
+	s.checkMutable()
+	v := mustParseSemanticVersion(version)
+	s.min = &v
+	if len(errorMessage) > 0 {
+		s.minError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Max sets the highest allowed version (inclusive); panics if version is invalid
+func (s *SemverSchema) Max(version string, errorMessage ...interface{}) *SemverSchema {
+	s.checkMutable()
+	v := mustParseSemanticVersion(version)
+	s.max = &v
+	if len(errorMessage) > 0 {
+		s.maxError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Range requires the version to satisfy a constraint such as ">=1.0 <2.0", "^1.4" or
+// "~2.1 || >=3"; panics if the constraint is invalid
+func (s *SemverSchema) Range(constraint string, errorMessage ...interface{}) *SemverSchema {
+	s.checkMutable()
+	ranges, err := parseSemverRange(constraint)
+	if err != nil {
+		panic(fmt.Sprintf("schema: invalid version range %q: %v", constraint, err))
+	}
+	s.constraint = constraint
+	s.ranges = ranges
+	if len(errorMessage) > 0 {
+		s.rangeError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// AllowPrerelease sets whether versions such as "2.0.0-beta.1" are accepted (default true)
+func (s *SemverSchema) AllowPrerelease(allow bool, errorMessage ...interface{}) *SemverSchema {
+	s.checkMutable()
+	s.allowPrerelease = allow
+	if len(errorMessage) > 0 {
+		s.prereleaseError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Optional marks the schema as optional
+func (s *SemverSchema) Optional() *SemverSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *SemverSchema) Required(errorMessage ...interface{}) *SemverSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *SemverSchema) Nullable() *SemverSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *SemverSchema) TypeError(message string) *SemverSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// FormatError sets a custom error message for invalid versions
+func (s *SemverSchema) FormatError(message string) *SemverSchema {
+	s.checkMutable()
+	s.formatError = toErrorMessage(message)
+	return s
+}
+
+// Getters
+
+// IsRequired returns whether the schema is marked as required
+func (s *SemverSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *SemverSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *SemverSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetMin returns the lowest allowed version
+func (s *SemverSchema) GetMin() *SemanticVersion {
+	return s.min
+}
+
+// GetMax returns the highest allowed version
+func (s *SemverSchema) GetMax() *SemanticVersion {
+	return s.max
+}
+
+// GetRange returns the range constraint
+func (s *SemverSchema) GetRange() string {
+	return s.constraint
+}
+
+// IsPrereleaseAllowed returns whether prerelease versions are accepted
+func (s *SemverSchema) IsPrereleaseAllowed() bool {
+	return s.allowPrerelease
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *SemverSchema) Transform(fn TransformFunc) *SemverSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *SemverSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *SemverSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *SemverSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *SemverSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Parse validates a version string (or SemanticVersion) and returns the parsed SemanticVersion
+func (s *SemverSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the version constraints; Parse runs the refine/transform pipeline on top
+func (s *SemverSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		if s.Schema.required {
+			message := semverRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)}}
+		}
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Type check
+	var version SemanticVersion
+	switch v := value.(type) {
+	case string:
+		parsed, err := ParseSemanticVersion(v)
+		if err != nil {
+			message := semverFormatError(ctx.Locale)
+			if !isEmptyErrorMessage(s.formatError) {
+				message = resolveErrorMessage(s.formatError, ctx)
+			}
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeFormat)}}
+		}
+		version = parsed
+	case SemanticVersion:
+		version = v
+	default:
+		message := semverTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)}}
+	}
+
+	var errors []ValidationError
+
+	if !s.allowPrerelease && version.IsPrerelease() {
+		message := semverPrereleaseError(ctx.Locale)
+		if !isEmptyErrorMessage(s.prereleaseError) {
+			message = resolveErrorMessage(s.prereleaseError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodePrerelease))
+	}
+
+	if s.min != nil && version.Compare(*s.min) < 0 {
+		message := semverMinError(s.min.String())(ctx.Locale)
+		if !isEmptyErrorMessage(s.minError) {
+			message = resolveErrorMessage(s.minError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeMinVersion))
+	}
+
+	if s.max != nil && version.Compare(*s.max) > 0 {
+		message := semverMaxError(s.max.String())(ctx.Locale)
+		if !isEmptyErrorMessage(s.maxError) {
+			message = resolveErrorMessage(s.maxError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeMaxVersion))
+	}
+
+	if s.ranges != nil && !semverInRange(s.ranges, version) {
+		message := semverRangeError(s.constraint)(ctx.Locale)
+		if !isEmptyErrorMessage(s.rangeError) {
+			message = resolveErrorMessage(s.rangeError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(value, message, CodeVersionRange))
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Valid: false, Value: nil, Errors: errors}
+	}
+	return ParseResult{Valid: true, Value: version, Errors: nil}
+}
+
+// semverInRange reports whether v satisfies every comparator of some alternative
+func semverInRange(alternatives [][]semverComparator, v SemanticVersion) bool {
+	for _, comparators := range alternatives {
+		matched := true
+		for _, comparator := range comparators {
+			if !comparator.matches(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON generates JSON Schema representation; version bounds have no JSON Schema equivalent
+func (s *SemverSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("string")
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	schema["pattern"] = semverPattern
+	if s.nullable {
+		schema["type"] = []string{"string", "null"}
+	}
+	return schema
+}
@@ -0,0 +1,151 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSemanticVersion(t *testing.T) {
+	valid := map[string]SemanticVersion{
+		"0.0.0":                {},
+		"1.2.3":                {Major: 1, Minor: 2, Patch: 3},
+		"1.0.0-alpha.1":        {Major: 1, Prerelease: []string{"alpha", "1"}},
+		"1.0.0-0.3.7":          {Major: 1, Prerelease: []string{"0", "3", "7"}},
+		"1.0.0+20130313144700": {Major: 1, Build: []string{"20130313144700"}},
+		"1.0.0-rc-1+exp.sha.5": {Major: 1, Prerelease: []string{"rc-1"}, Build: []string{"exp", "sha", "5"}},
+	}
+	for input, want := range valid {
+		got, err := ParseSemanticVersion(input)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseSemanticVersion(%q) = %+v, %v; want %+v", input, got, err, want)
+		}
+		if got.String() != input {
+			t.Errorf("String() = %q, want %q", got.String(), input)
+		}
+	}
+
+	for _, input := range []string{"", "1", "1.2", "1.2.3.4", "v1.2.3", "01.2.3", "1.2.-3", "1.2.3-", "1.2.3-01", "1.2.3-a..b", "1.2.3+", "1.2.3-α"} {
+		if _, err := ParseSemanticVersion(input); err == nil {
+			t.Errorf("ParseSemanticVersion(%q) succeeded", input)
+		}
+	}
+}
+
+func TestSemanticVersion_Compare(t *testing.T) {
+	// Precedence example from semver.org, in ascending order
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, b := mustParseSemanticVersion(ordered[i-1]), mustParseSemanticVersion(ordered[i])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+	if mustParseSemanticVersion("1.0.0+a").Compare(mustParseSemanticVersion("1.0.0+b")) != 0 {
+		t.Error("expected build metadata to be ignored")
+	}
+}
+
+func TestSemverSchema(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *SemverSchema
+		value    interface{}
+		expected bool
+		code     ErrorCode
+	}{
+		{"valid", Semver(), "1.4.2", true, ""},
+		{"invalid", Semver(), "1.4", false, "format"},
+		{"not a string", Semver(), 1.4, false, "invalid_type"},
+		{"prerelease allowed", Semver(), "2.0.0-beta.1", true, ""},
+		{"prerelease rejected", Semver().AllowPrerelease(false), "2.0.0-beta.1", false, "prerelease"},
+		{"min", Semver().Min("1.2.0"), "1.2.0", true, ""},
+		{"below min", Semver().Min("1.2.0"), "1.1.9", false, "min_version"},
+		{"prerelease below min", Semver().Min("1.2.0"), "1.2.0-rc.1", false, "min_version"},
+		{"max", Semver().Max("2.0.0"), "2.0.0", true, ""},
+		{"above max", Semver().Max("2.0.0"), "2.0.1", false, "max_version"},
+		{"range", Semver().Range(">=1.0 <2.0"), "1.9.9", true, ""},
+		{"range upper", Semver().Range(">=1.0 <2.0"), "2.0.0", false, "version_range"},
+		{"range next prerelease", Semver().Range(">=1.0 <2.0"), "2.0.0-beta", false, "version_range"},
+		{"range alternative", Semver().Range("^1.2 || >=3"), "3.1.0", true, ""},
+		{"range gap", Semver().Range("^1.2 || >=3"), "2.5.0", false, "version_range"},
+		{"SemanticVersion input", Semver().Min("1.0.0"), SemanticVersion{Major: 1, Minor: 5}, true, ""},
+		{"required nil", Semver(), nil, false, "required"},
+		{"optional default", Semver().Optional().Default("1.0.0"), nil, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Fatalf("Parse(%v) = %v, want %v (%v)", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+			if tt.code != "" && result.Errors[0].Code != tt.code {
+				t.Errorf("code = %q, want %q", result.Errors[0].Code, tt.code)
+			}
+		})
+	}
+
+	result := Semver().Parse("3.1.4-rc.1+build.7", ctx)
+	want := SemanticVersion{Major: 3, Minor: 1, Patch: 4, Prerelease: []string{"rc", "1"}, Build: []string{"build", "7"}}
+	if !reflect.DeepEqual(result.Value, want) {
+		t.Errorf("Value = %#v, want %#v", result.Value, want)
+	}
+
+	result = Semver().Range("~1.4").Parse("1.5.0", ctx)
+	if result.Valid || result.Errors[0].Message != "version must satisfy ~1.4" {
+		t.Errorf("errors = %v", result.Errors)
+	}
+}
+
+func TestSemverRange(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{"1.2.3", []string{"1.2.3"}, []string{"1.2.4", "1.2.3-rc.1"}},
+		{"=1.2.3", []string{"1.2.3+build"}, []string{"1.2.2"}},
+		{"1.2", []string{"1.2.0", "1.2.99"}, []string{"1.3.0", "1.3.0-0", "1.1.9"}},
+		{"1.x", []string{"1.0.0", "1.9.0"}, []string{"2.0.0", "0.9.0"}},
+		{"*", []string{"0.0.1", "9.9.9", "1.0.0-alpha"}, nil},
+		{"^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0", "2.0.0-rc.1"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"^0.0", []string{"0.0.0", "0.0.9"}, []string{"0.1.0"}},
+		{"^0", []string{"0.9.0"}, []string{"1.0.0"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		{"~1", []string{"1.0.0", "1.9.9"}, []string{"2.0.0"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9", "1.3.0-rc.1"}},
+		{">1.2.3", []string{"1.2.4"}, []string{"1.2.3"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{"<1.2", []string{"1.1.9"}, []string{"1.2.0", "1.2.0-rc.1"}},
+		{"<2.0.0", []string{"1.9.9", "2.0.0-rc.1"}, []string{"2.0.0"}},
+		{">=1.0.0 <1.5.0 || 2.x", []string{"1.4.0", "2.3.0"}, []string{"1.5.0", "3.0.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			ranges, err := parseSemverRange(tt.constraint)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, version := range tt.matches {
+				if !semverInRange(ranges, mustParseSemanticVersion(version)) {
+					t.Errorf("expected %s to satisfy %s", version, tt.constraint)
+				}
+			}
+			for _, version := range tt.rejects {
+				if semverInRange(ranges, mustParseSemanticVersion(version)) {
+					t.Errorf("expected %s not to satisfy %s", version, tt.constraint)
+				}
+			}
+		})
+	}
+
+	for _, constraint := range []string{"", "||", ">=", "1.2.3.4", ">=abc", "1 ||"} {
+		if _, err := parseSemverRange(constraint); err == nil {
+			t.Errorf("parseSemverRange(%q) succeeded", constraint)
+		}
+	}
+}
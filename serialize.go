@@ -0,0 +1,928 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// MarshalSchema serializes a schema definition to JSON in an internal format that
+// UnmarshalSchema reads back, so schemas can be stored, shipped between services and
+// loaded at runtime. Unlike JSON Schema, every node names its Go schema kind:
+//
+//	{"kind": "object", "properties": {"name": {"kind": "string", "minLength": 1}}, "required": ["name"]}
+//
+// Supported kinds: string, int, number, bool, null, any, never, literal, array, tuple,
+// object, record, union, anyOf, allOf, not and conditional. Custom error messages are
+// not serialized; use message bundles to customize the messages of loaded schemas.
+// Schemas holding Go functions (transforms, refinements, default functions,
+// normalizers such as Trim, key transformers and unknown key handlers), object
+// conditions added with When, and references cannot be serialized and make
+// MarshalSchema return an error.
+func MarshalSchema(s Parseable) ([]byte, error) {
+	node, err := encodeSchema(s, "#")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(node)
+}
+
+// UnmarshalSchema builds a schema from the JSON produced by MarshalSchema
+func UnmarshalSchema(data []byte) (Parseable, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var node interface{}
+	if err := decoder.Decode(&node); err != nil {
+		return nil, fmt.Errorf("schema: invalid schema definition: %w", err)
+	}
+	return decodeSchema(node, "#")
+}
+
+// base returns the base fields of the schema embedding s
+func (s *Schema) base() *Schema {
+	return s
+}
+
+// embedsSchema is implemented by the schema types embedding Schema
+type embedsSchema interface {
+	base() *Schema
+}
+
+// encodeSchema returns the serialized node of s, located at path
+func encodeSchema(s Parseable, path string) (map[string]interface{}, error) {
+	var node map[string]interface{}
+	var err error
+	switch v := s.(type) {
+	case *StringSchema:
+		if len(v.normalizers) > 0 {
+			return nil, fmt.Errorf("schema: cannot serialize the schema at %s: it normalizes values with functions", path)
+		}
+		node = map[string]interface{}{"kind": "string"}
+		addOption(node, "coerce", v.coerce)
+		addOption(node, "minLength", v.minLength)
+		addOption(node, "maxLength", v.maxLength)
+		addOption(node, "lengthUnit", string(v.lengthUnit))
+		addOption(node, "pattern", v.pattern)
+		if v.format != nil {
+			node["format"] = string(*v.format)
+		}
+		addOption(node, "noControlChars", v.noControl)
+		addOption(node, "nonEmpty", v.nonEmpty)
+		addOption(node, "emptyIsValue", v.emptyIsValue)
+		addOption(node, "nullable", v.nullable)
+	case *IntSchema:
+		node = map[string]interface{}{"kind": "int"}
+		addOption(node, "coerce", v.coerce)
+		addOption(node, "minimum", v.minimum)
+		addOption(node, "maximum", v.maximum)
+		addOption(node, "multipleOf", v.multipleOf)
+		addOption(node, "nullable", v.nullable)
+	case *NumberSchema:
+		node = map[string]interface{}{"kind": "number"}
+		addOption(node, "coerce", v.coerce)
+		addOption(node, "minimum", v.minimum)
+		addOption(node, "maximum", v.maximum)
+		addOption(node, "exclusiveMinimum", v.exclusiveMinimum)
+		addOption(node, "exclusiveMaximum", v.exclusiveMaximum)
+		addOption(node, "multipleOf", v.multipleOf)
+		addOption(node, "precision", v.precision)
+		addOption(node, "finite", v.finite)
+		addOption(node, "nullable", v.nullable)
+	case *BoolSchema:
+		node = map[string]interface{}{"kind": "bool"}
+		addOption(node, "coerce", v.coerce)
+		addOption(node, "nullable", v.nullable)
+	case *NullSchema:
+		node = map[string]interface{}{"kind": "null"}
+	case *AnySchema:
+		node = map[string]interface{}{"kind": "any"}
+		addOption(node, "nullable", v.nullable)
+	case *NeverSchema:
+		node = map[string]interface{}{"kind": "never"}
+	case *LiteralSchema:
+		node = map[string]interface{}{"kind": "literal", "value": v.value}
+		addOption(node, "nullable", v.nullable)
+	case *ArraySchema:
+		node = map[string]interface{}{"kind": "array"}
+		if err = addSchemaOption(node, "items", v.itemSchema, path); err != nil {
+			return nil, err
+		}
+		addOption(node, "minItems", v.minItems)
+		addOption(node, "maxItems", v.maxItems)
+		addOption(node, "uniqueItems", v.uniqueItems)
+		addOption(node, "nullable", v.nullable)
+	case *TupleSchema:
+		node = map[string]interface{}{"kind": "tuple"}
+		if err = addSchemaList(node, "items", v.itemSchemas, path); err != nil {
+			return nil, err
+		}
+		addOption(node, "additionalItems", v.additionalItems)
+		if err = addSchemaOption(node, "rest", v.restSchema, path); err != nil {
+			return nil, err
+		}
+		addOption(node, "uniqueItems", v.uniqueItems)
+		addOption(node, "nullable", v.nullable)
+	case *ObjectSchema:
+		if node, err = encodeObject(v, path); err != nil {
+			return nil, err
+		}
+	case *RecordSchema:
+		node = map[string]interface{}{"kind": "record"}
+		if err = addSchemaOption(node, "keys", v.keySchema, path); err != nil {
+			return nil, err
+		}
+		if err = addSchemaOption(node, "values", v.valueSchema, path); err != nil {
+			return nil, err
+		}
+		addOption(node, "minProperties", v.minProps)
+		addOption(node, "maxProperties", v.maxProps)
+		addOption(node, "nullable", v.nullable)
+	case *UnionSchema:
+		node = map[string]interface{}{"kind": "union"}
+		if err = addSchemaList(node, "schemas", v.schemas, path); err != nil {
+			return nil, err
+		}
+		addOption(node, "allowNone", v.allowNone)
+		addOption(node, "fast", v.fast)
+		addOption(node, "nullable", v.nullable)
+	case *AnyOfSchema:
+		node = map[string]interface{}{"kind": "anyOf"}
+		if err = addSchemaList(node, "schemas", v.schemas, path); err != nil {
+			return nil, err
+		}
+		addOption(node, "nullable", v.nullable)
+	case *AllOfSchema:
+		node = map[string]interface{}{"kind": "allOf"}
+		if err = addSchemaList(node, "schemas", v.schemas, path); err != nil {
+			return nil, err
+		}
+		addOption(node, "nullable", v.nullable)
+	case *NotSchema:
+		node = map[string]interface{}{"kind": "not"}
+		if err = addSchemaOption(node, "schema", v.schema, path); err != nil {
+			return nil, err
+		}
+		addOption(node, "nullable", v.nullable)
+	case *ConditionalSchema:
+		node = map[string]interface{}{"kind": "conditional"}
+		if err = addSchemaOption(node, "if", v.ifSchema, path); err != nil {
+			return nil, err
+		}
+		if err = addSchemaOption(node, "then", v.thenSchema, path); err != nil {
+			return nil, err
+		}
+		if len(v.cases) > 0 {
+			cases := make([]interface{}, len(v.cases))
+			for i, c := range v.cases {
+				entry := map[string]interface{}{}
+				casePath := path + "/cases/" + strconv.Itoa(i)
+				if err = addSchemaOption(entry, "if", c.condition, casePath); err != nil {
+					return nil, err
+				}
+				if err = addSchemaOption(entry, "then", c.schema, casePath); err != nil {
+					return nil, err
+				}
+				cases[i] = entry
+			}
+			node["cases"] = cases
+		}
+		if err = addSchemaOption(node, "else", v.elseSchema, path); err != nil {
+			return nil, err
+		}
+		addOption(node, "nullable", v.nullable)
+	default:
+		return nil, fmt.Errorf("schema: cannot serialize the schema at %s: unsupported schema type %T", path, s)
+	}
+
+	if err := encodeBase(node, s.(embedsSchema).base(), path); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// encodeObject returns the serialized node of an object schema
+func encodeObject(s *ObjectSchema, path string) (map[string]interface{}, error) {
+	switch {
+	case s.keyTransform != nil:
+		return nil, fmt.Errorf("schema: cannot serialize the schema at %s: it transforms keys with a function", path)
+	case s.onUnknownKey != nil:
+		return nil, fmt.Errorf("schema: cannot serialize the schema at %s: it handles unknown keys with a function", path)
+	case len(s.conditions) > 0:
+		return nil, fmt.Errorf("schema: cannot serialize the schema at %s: it has conditions added with When", path)
+	}
+
+	node := map[string]interface{}{"kind": "object"}
+	properties := make(map[string]interface{}, len(s.properties))
+	for name, prop := range s.properties {
+		encoded, err := encodeSchema(prop.Schema, path+"/properties/"+jsonPointerEscaper.Replace(name))
+		if err != nil {
+			return nil, err
+		}
+		properties[name] = encoded
+	}
+	node["properties"] = properties
+	if len(s.requiredProps) > 0 {
+		node["required"] = s.requiredProps
+	}
+	if len(s.patternProps) > 0 {
+		patterns := make([]interface{}, len(s.patternProps))
+		for i, prop := range s.patternProps {
+			encoded, err := encodeSchema(prop.schema, path+"/patternProperties/"+strconv.Itoa(i))
+			if err != nil {
+				return nil, err
+			}
+			patterns[i] = map[string]interface{}{"pattern": prop.pattern, "schema": encoded}
+		}
+		node["patternProperties"] = patterns
+	}
+	if err := addSchemaOption(node, "propertyNames", s.propertyNames, path); err != nil {
+		return nil, err
+	}
+	addOption(node, "additionalProperties", s.additionalProps)
+	addOption(node, "stripUnknown", s.stripUnknown)
+	addOption(node, "collectPartial", s.collectPartial)
+	addOption(node, "skipDefaults", s.skipDefaults)
+	addOption(node, "minProperties", s.minProps)
+	addOption(node, "maxProperties", s.maxProps)
+	if len(s.aliases) > 0 {
+		node["aliases"] = s.aliases
+	}
+	if len(s.dependentRequired) > 0 {
+		node["dependentRequired"] = s.dependentRequired
+	}
+	if s.deletable != nil {
+		deletable := make([]string, 0, len(s.deletable))
+		for name := range s.deletable {
+			deletable = append(deletable, name)
+		}
+		sort.Strings(deletable)
+		node["deletable"] = deletable
+	}
+	addOption(node, "nullable", s.nullable)
+	return node, nil
+}
+
+// encodeBase adds the base fields of a schema to its node
+func encodeBase(node map[string]interface{}, s *Schema, path string) error {
+	if len(s.effects) > 0 {
+		return fmt.Errorf("schema: cannot serialize the schema at %s: it has transforms or refinements", path)
+	}
+	if s.defaultFunc != nil {
+		return fmt.Errorf("schema: cannot serialize the schema at %s: its default is computed by a function", path)
+	}
+	addOption(node, "optional", !s.required)
+	addOption(node, "title", s.title)
+	addOption(node, "description", s.description)
+	if s.defaultValue != nil {
+		node["default"] = s.defaultValue
+	}
+	if len(s.examples) > 0 {
+		node["examples"] = s.examples
+	}
+	if len(s.enum) > 0 {
+		node["enum"] = s.enum
+	}
+	if s.constVal != nil {
+		node["const"] = s.constVal
+	}
+	addOption(node, "readOnly", s.readOnly)
+	addOption(node, "writeOnly", s.writeOnly)
+	addOption(node, "sensitive", s.sensitive)
+	addOption(node, "deprecated", s.deprecated)
+	addOption(node, "deprecationReason", s.deprecationReason)
+	if len(s.meta) > 0 {
+		node["meta"] = s.meta
+	}
+	if len(s.severities) > 0 {
+		node["severities"] = s.severities
+	}
+	return nil
+}
+
+// addOption adds value to node under key unless it is a zero value
+func addOption[T comparable](node map[string]interface{}, key string, value T) {
+	var zero T
+	if value != zero {
+		node[key] = value
+	}
+}
+
+// addSchemaOption adds the serialized node of a nested schema, if not nil
+func addSchemaOption(node map[string]interface{}, key string, s Parseable, path string) error {
+	if s == nil {
+		return nil
+	}
+	encoded, err := encodeSchema(s, path+"/"+key)
+	if err != nil {
+		return err
+	}
+	node[key] = encoded
+	return nil
+}
+
+// addSchemaList adds the serialized nodes of a list of nested schemas
+func addSchemaList(node map[string]interface{}, key string, schemas []Parseable, path string) error {
+	encoded := make([]interface{}, len(schemas))
+	for i, s := range schemas {
+		var err error
+		if encoded[i], err = encodeSchema(s, path+"/"+key+"/"+strconv.Itoa(i)); err != nil {
+			return err
+		}
+	}
+	node[key] = encoded
+	return nil
+}
+
+// schemaNode is a decoded node of a serialized schema, with its location for errors
+type schemaNode struct {
+	fields map[string]interface{}
+	path   string
+}
+
+// decodeSchema builds the schema serialized in node, located at path
+func decodeSchema(raw interface{}, path string) (Parseable, error) {
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema: invalid schema at %s: expected an object", path)
+	}
+	n := schemaNode{fields: fields, path: path}
+	kind, err := n.string("kind")
+	if err != nil {
+		return nil, err
+	}
+
+	// numbers is how the numbers of the base fields' values are decoded
+	numbers := decodeAnyNumber
+	var s Parseable
+	switch kind {
+	case "string":
+		s, err = n.stringSchema()
+	case "int":
+		numbers = decodeIntNumber
+		s, err = n.intSchema()
+	case "number":
+		numbers = decodeFloatNumber
+		s, err = n.numberSchema()
+	case "bool":
+		b := Bool()
+		b.coerce, b.nullable = n.bool("coerce"), n.bool("nullable")
+		s = b
+	case "null":
+		s = Null()
+	case "any":
+		a := Any()
+		a.nullable = n.bool("nullable")
+		s = a
+	case "never":
+		s = Never()
+	case "literal":
+		value, ok := fields["value"]
+		if !ok {
+			return nil, fmt.Errorf("schema: invalid schema at %s: a literal needs a \"value\"", path)
+		}
+		l := Literal(decodeValue(value, decodeAnyNumber))
+		l.nullable = n.bool("nullable")
+		s = l
+	case "array":
+		s, err = n.arraySchema()
+	case "tuple":
+		s, err = n.tupleSchema()
+	case "object":
+		s, err = n.objectSchema()
+	case "record":
+		s, err = n.recordSchema()
+	case "union":
+		var schemas []Parseable
+		if schemas, err = n.schemaList("schemas"); err == nil {
+			u := Union(schemas...)
+			u.allowNone, u.fast, u.nullable = n.bool("allowNone"), n.bool("fast"), n.bool("nullable")
+			s = u
+		}
+	case "anyOf":
+		var schemas []Parseable
+		if schemas, err = n.schemaList("schemas"); err == nil {
+			a := AnyOf(schemas...)
+			a.nullable = n.bool("nullable")
+			s = a
+		}
+	case "allOf":
+		var schemas []Parseable
+		if schemas, err = n.schemaList("schemas"); err == nil {
+			a := AllOf(schemas...)
+			a.nullable = n.bool("nullable")
+			s = a
+		}
+	case "not":
+		var schema Parseable
+		if schema, err = n.requiredSchema("schema"); err == nil {
+			not := Not(schema)
+			not.nullable = n.bool("nullable")
+			s = not
+		}
+	case "conditional":
+		s, err = n.conditionalSchema()
+	default:
+		return nil, fmt.Errorf("schema: invalid schema at %s: unknown kind %q", path, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := n.decodeBase(s.(embedsSchema).base(), numbers); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (n schemaNode) stringSchema() (Parseable, error) {
+	s := String()
+	s.coerce, s.noControl, s.nullable = n.bool("coerce"), n.bool("noControlChars"), n.bool("nullable")
+	s.nonEmpty, s.emptyIsValue = n.bool("nonEmpty"), n.bool("emptyIsValue")
+	var err error
+	if s.minLength, err = n.int("minLength"); err != nil {
+		return nil, err
+	}
+	if s.maxLength, err = n.int("maxLength"); err != nil {
+		return nil, err
+	}
+	unit, err := n.string("lengthUnit")
+	if err != nil {
+		return nil, err
+	}
+	s.lengthUnit = LengthUnit(unit)
+	if _, ok := n.fields["pattern"]; ok {
+		pattern, err := n.string("pattern")
+		if err != nil {
+			return nil, err
+		}
+		if s.regex, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("schema: invalid \"pattern\" at %s: %w", n.path, err)
+		}
+		s.pattern = &pattern
+	}
+	if _, ok := n.fields["format"]; ok {
+		format, err := n.string("format")
+		if err != nil {
+			return nil, err
+		}
+		s.format = (*StringFormat)(&format)
+	}
+	return s, nil
+}
+
+func (n schemaNode) intSchema() (Parseable, error) {
+	s := Int()
+	s.coerce, s.nullable = n.bool("coerce"), n.bool("nullable")
+	var err error
+	if s.minimum, err = n.int("minimum"); err != nil {
+		return nil, err
+	}
+	if s.maximum, err = n.int("maximum"); err != nil {
+		return nil, err
+	}
+	if s.multipleOf, err = n.int("multipleOf"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (n schemaNode) numberSchema() (Parseable, error) {
+	s := Number()
+	s.coerce, s.finite, s.nullable = n.bool("coerce"), n.bool("finite"), n.bool("nullable")
+	for key, field := range map[string]**float64{
+		"minimum":          &s.minimum,
+		"maximum":          &s.maximum,
+		"exclusiveMinimum": &s.exclusiveMinimum,
+		"exclusiveMaximum": &s.exclusiveMaximum,
+		"multipleOf":       &s.multipleOf,
+	} {
+		var err error
+		if *field, err = n.float(key); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if s.precision, err = n.int("precision"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (n schemaNode) arraySchema() (Parseable, error) {
+	items, err := n.schema("items")
+	if err != nil {
+		return nil, err
+	}
+	s := Array(items)
+	s.uniqueItems, s.nullable = n.bool("uniqueItems"), n.bool("nullable")
+	if s.minItems, err = n.int("minItems"); err != nil {
+		return nil, err
+	}
+	if s.maxItems, err = n.int("maxItems"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (n schemaNode) tupleSchema() (Parseable, error) {
+	items, err := n.schemaList("items")
+	if err != nil {
+		return nil, err
+	}
+	s := Tuple(items...)
+	s.additionalItems, s.uniqueItems, s.nullable = n.bool("additionalItems"), n.bool("uniqueItems"), n.bool("nullable")
+	if s.restSchema, err = n.schema("rest"); err != nil {
+		return nil, err
+	}
+	if s.restSchema != nil {
+		s.additionalItems = true
+	}
+	return s, nil
+}
+
+func (n schemaNode) objectSchema() (Parseable, error) {
+	s := Object()
+	s.additionalProps, s.stripUnknown, s.collectPartial = n.bool("additionalProperties"), n.bool("stripUnknown"), n.bool("collectPartial")
+	s.skipDefaults = n.bool("skipDefaults")
+	s.nullable = n.bool("nullable")
+
+	required, err := n.strings("required")
+	if err != nil {
+		return nil, err
+	}
+	isRequired := make(map[string]bool, len(required))
+	for _, name := range required {
+		isRequired[name] = true
+	}
+	properties, err := n.object("properties")
+	if err != nil {
+		return nil, err
+	}
+	for name, raw := range properties {
+		prop, err := decodeSchema(raw, n.path+"/properties/"+jsonPointerEscaper.Replace(name))
+		if err != nil {
+			return nil, err
+		}
+		s.properties[name] = ObjectProperty{Schema: prop, Required: isRequired[name], Name: name}
+	}
+	s.requiredProps = append(s.requiredProps, required...)
+
+	if raw, ok := n.fields["patternProperties"]; ok {
+		patterns, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schema: invalid \"patternProperties\" at %s: must be an array", n.path)
+		}
+		for i, raw := range patterns {
+			fields, ok := raw.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("schema: invalid \"patternProperties\" at %s: entries must be objects", n.path)
+			}
+			entry := schemaNode{fields: fields, path: n.path + "/patternProperties/" + strconv.Itoa(i)}
+			pattern, err := entry.string("pattern")
+			if err != nil {
+				return nil, err
+			}
+			regex, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("schema: invalid \"pattern\" at %s: %w", entry.path, err)
+			}
+			prop, err := entry.requiredSchema("schema")
+			if err != nil {
+				return nil, err
+			}
+			s.patternProps = append(s.patternProps, objectPatternProperty{pattern: pattern, regex: regex, schema: prop})
+		}
+	}
+	if s.propertyNames, err = n.schema("propertyNames"); err != nil {
+		return nil, err
+	}
+	if s.minProps, err = n.int("minProperties"); err != nil {
+		return nil, err
+	}
+	if s.maxProps, err = n.int("maxProperties"); err != nil {
+		return nil, err
+	}
+
+	aliases, err := n.object("aliases")
+	if err != nil {
+		return nil, err
+	}
+	for alias, raw := range aliases {
+		name, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("schema: invalid \"aliases\" at %s: property names must be strings", n.path)
+		}
+		s.Alias(alias, name)
+	}
+	dependencies, err := n.object("dependentRequired")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dependent := schemaNode{fields: dependencies, path: n.path + "/dependentRequired"}
+		required, err := dependent.strings(name)
+		if err != nil {
+			return nil, err
+		}
+		s.addDependentRequired(name, required)
+	}
+
+	// Merge patch schemas list the properties null deletes, possibly none
+	if _, ok := n.fields["deletable"]; ok {
+		deletable, err := n.strings("deletable")
+		if err != nil {
+			return nil, err
+		}
+		s.deletable = make(map[string]bool, len(deletable))
+		for _, name := range deletable {
+			s.deletable[name] = true
+		}
+	}
+	return s, nil
+}
+
+func (n schemaNode) recordSchema() (Parseable, error) {
+	keys, err := n.schema("keys")
+	if err != nil {
+		return nil, err
+	}
+	values, err := n.schema("values")
+	if err != nil {
+		return nil, err
+	}
+	s := Record(keys, values)
+	s.nullable = n.bool("nullable")
+	if s.minProps, err = n.int("minProperties"); err != nil {
+		return nil, err
+	}
+	if s.maxProps, err = n.int("maxProperties"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (n schemaNode) conditionalSchema() (Parseable, error) {
+	ifSchema, err := n.schema("if")
+	if err != nil {
+		return nil, err
+	}
+	s := Conditional()
+	s.ifSchema = ifSchema
+	s.nullable = n.bool("nullable")
+	if s.thenSchema, err = n.schema("then"); err != nil {
+		return nil, err
+	}
+	if raw, ok := n.fields["cases"]; ok {
+		entries, ok := raw.([]interface{})
+		if !ok || ifSchema == nil {
+			return nil, fmt.Errorf("schema: invalid %q at %s: must be an array of cases following an if schema", "cases", n.path)
+		}
+		for i, entry := range entries {
+			fields, ok := entry.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("schema: invalid case at %s/cases/%d: expected an object", n.path, i)
+			}
+			caseNode := schemaNode{fields: fields, path: n.path + "/cases/" + strconv.Itoa(i)}
+			var c conditionalCase
+			if c.condition, err = caseNode.requiredSchema("if"); err != nil {
+				return nil, err
+			}
+			if c.schema, err = caseNode.schema("then"); err != nil {
+				return nil, err
+			}
+			s.cases = append(s.cases, c)
+		}
+	}
+	if s.elseSchema, err = n.schema("else"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// decodeBase sets the base fields of s, decoding the numbers in values with numbers
+func (n schemaNode) decodeBase(s *Schema, numbers numberDecoding) error {
+	var err error
+	s.required = !n.bool("optional")
+	if s.title, err = n.string("title"); err != nil {
+		return err
+	}
+	if s.description, err = n.string("description"); err != nil {
+		return err
+	}
+	if s.deprecationReason, err = n.string("deprecationReason"); err != nil {
+		return err
+	}
+	s.readOnly, s.writeOnly, s.deprecated = n.bool("readOnly"), n.bool("writeOnly"), n.bool("deprecated")
+	s.sensitive = n.bool("sensitive")
+
+	if value, ok := n.fields["default"]; ok {
+		s.defaultValue = decodeValue(value, numbers)
+	}
+	if value, ok := n.fields["const"]; ok {
+		s.constVal = decodeValue(value, numbers)
+	}
+	for _, key := range []string{"examples", "enum"} {
+		raw, ok := n.fields[key]
+		if !ok {
+			continue
+		}
+		values, ok := raw.([]interface{})
+		if !ok {
+			return fmt.Errorf("schema: invalid %q at %s: must be an array", key, n.path)
+		}
+		for i, value := range values {
+			values[i] = decodeValue(value, numbers)
+		}
+		if key == "enum" {
+			s.enum = values
+		} else {
+			s.examples = values
+		}
+	}
+
+	meta, err := n.object("meta")
+	if err != nil {
+		return err
+	}
+	for key, value := range meta {
+		s.setMeta(key, decodeValue(value, decodeAnyNumber))
+	}
+	severities, err := n.object("severities")
+	if err != nil {
+		return err
+	}
+	for code, raw := range severities {
+		name, _ := raw.(string)
+		var severity Severity
+		if err := severity.UnmarshalText([]byte(name)); err != nil {
+			return fmt.Errorf("schema: invalid \"severities\" at %s: %w", n.path, err)
+		}
+		if code == "" {
+			s.setSeverity(severity, nil)
+		} else {
+			s.setSeverity(severity, []ErrorCode{ErrorCode(code)})
+		}
+	}
+	return nil
+}
+
+// string returns the string under key, "" if absent
+func (n schemaNode) string(key string) (string, error) {
+	raw, ok := n.fields[key]
+	if !ok {
+		return "", nil
+	}
+	str, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("schema: invalid %q at %s: must be a string", key, n.path)
+	}
+	return str, nil
+}
+
+// strings returns the strings of the array under key
+func (n schemaNode) strings(key string) ([]string, error) {
+	raw, ok := n.fields[key]
+	if !ok {
+		return nil, nil
+	}
+	values, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema: invalid %q at %s: must be an array of strings", key, n.path)
+	}
+	strs := make([]string, len(values))
+	for i, value := range values {
+		if strs[i], ok = value.(string); !ok {
+			return nil, fmt.Errorf("schema: invalid %q at %s: must be an array of strings", key, n.path)
+		}
+	}
+	return strs, nil
+}
+
+// bool returns whether the option under key is true
+func (n schemaNode) bool(key string) bool {
+	b, _ := n.fields[key].(bool)
+	return b
+}
+
+// int returns the integer under key, nil if absent
+func (n schemaNode) int(key string) (*int, error) {
+	raw, ok := n.fields[key]
+	if !ok {
+		return nil, nil
+	}
+	number, ok := raw.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("schema: invalid %q at %s: must be an integer", key, n.path)
+	}
+	i, err := strconv.Atoi(number.String())
+	if err != nil {
+		return nil, fmt.Errorf("schema: invalid %q at %s: must be an integer", key, n.path)
+	}
+	return &i, nil
+}
+
+// float returns the number under key, nil if absent
+func (n schemaNode) float(key string) (*float64, error) {
+	raw, ok := n.fields[key]
+	if !ok {
+		return nil, nil
+	}
+	number, ok := raw.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("schema: invalid %q at %s: must be a number", key, n.path)
+	}
+	f, err := number.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("schema: invalid %q at %s: must be a number", key, n.path)
+	}
+	return &f, nil
+}
+
+// object returns the JSON object under key, nil if absent
+func (n schemaNode) object(key string) (map[string]interface{}, error) {
+	raw, ok := n.fields[key]
+	if !ok {
+		return nil, nil
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema: invalid %q at %s: must be an object", key, n.path)
+	}
+	return obj, nil
+}
+
+// schema returns the nested schema under key, nil if absent
+func (n schemaNode) schema(key string) (Parseable, error) {
+	raw, ok := n.fields[key]
+	if !ok {
+		return nil, nil
+	}
+	return decodeSchema(raw, n.path+"/"+key)
+}
+
+// requiredSchema returns the nested schema under key, which must be present
+func (n schemaNode) requiredSchema(key string) (Parseable, error) {
+	if _, ok := n.fields[key]; !ok {
+		return nil, fmt.Errorf("schema: invalid schema at %s: missing %q", n.path, key)
+	}
+	return n.schema(key)
+}
+
+// schemaList returns the nested schemas of the array under key
+func (n schemaNode) schemaList(key string) ([]Parseable, error) {
+	raw, ok := n.fields[key]
+	if !ok {
+		return nil, nil
+	}
+	values, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema: invalid %q at %s: must be an array of schemas", key, n.path)
+	}
+	schemas := make([]Parseable, len(values))
+	for i, value := range values {
+		var err error
+		if schemas[i], err = decodeSchema(value, n.path+"/"+key+"/"+strconv.Itoa(i)); err != nil {
+			return nil, err
+		}
+	}
+	return schemas, nil
+}
+
+// numberDecoding selects the Go type of the numbers of decoded values
+type numberDecoding int
+
+const (
+	decodeAnyNumber   numberDecoding = iota // int for whole numbers, float64 otherwise
+	decodeIntNumber                         // int, as IntSchema stores its values
+	decodeFloatNumber                       // float64, as NumberSchema stores its values
+)
+
+// decodeValue converts the json.Number values in a decoded value
+func decodeValue(value interface{}, numbers numberDecoding) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if numbers != decodeFloatNumber {
+			if i, err := strconv.Atoi(v.String()); err == nil {
+				return i
+			}
+		}
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i, item := range v {
+			v[i] = decodeValue(item, numbers)
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = decodeValue(item, numbers)
+		}
+	}
+	return value
+}
@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchemaSerialization(t *testing.T) {
+	user := Object().
+		Property("name", String().MinLength(2).MaxLength(50).Pattern(`^[A-Z]`).Title("Name")).
+		Property("email", String().Email().Optional()).
+		Property("age", Int().Min(0).Max(150).Default(18).Optional()).
+		Property("score", Number().Min(0).ExclusiveMax(1).Precision(2).Optional()).
+		Property("role", String().Enum([]string{"admin", "user"}).AsWarning(CodeEnum)).
+		Property("tags", Array(String()).MinItems(1).UniqueItems().Optional()).
+		Property("point", Tuple(Number(), Number()).Optional()).
+		Property("labels", Record(String(), String()).MaxProperties(3).Optional()).
+		Property("id", Union(Int(), String().UUID()).Optional()).
+		Property("legacy", Bool().Nullable().Optional().Deprecated("use role")).
+		Property("kind", Literal("user")).
+		Alias("mail", "email").
+		DependentRequired(map[string][]string{"score": {"age"}}).
+		PatternProperty(`^x-`, String()).
+		Meta("owner", "accounts")
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		valid  []interface{}
+		reject []interface{}
+	}{
+		{
+			name:   "object",
+			schema: user,
+			valid: []interface{}{
+				map[string]interface{}{"name": "Ann", "role": "admin", "kind": "user"},
+				map[string]interface{}{"name": "Ann", "role": "guest", "kind": "user", "mail": "a@b.co", "x-trace": "1"},
+				map[string]interface{}{"name": "Ann", "role": "user", "kind": "user", "age": 30, "score": 0.5, "id": 7},
+			},
+			reject: []interface{}{
+				map[string]interface{}{"name": "ann", "role": "user", "kind": "user"},
+				map[string]interface{}{"name": "Ann", "role": "user", "kind": "user", "score": 0.5},
+				map[string]interface{}{"name": "Ann", "role": "user", "kind": "user", "age": -1},
+				map[string]interface{}{"name": "Ann", "role": "user", "kind": "user", "x-trace": 1},
+				map[string]interface{}{"name": "Ann", "role": "user", "kind": "admin"},
+			},
+		},
+		{
+			name:   "int enum and default",
+			schema: Int().Enum([]int{1, 2, 3}).Default(2).Optional(),
+			valid:  []interface{}{1, 3, nil},
+			reject: []interface{}{4, 1.5},
+		},
+		{
+			name:   "combinators",
+			schema: AllOf(AnyOf(String(), Int()), Not(Literal("x"))),
+			valid:  []interface{}{"y", 1},
+			reject: []interface{}{"x", true},
+		},
+		{
+			name:   "conditional",
+			schema: Conditional(Object().Property("type", Literal("a")).AdditionalProperties(true)).Then(Object().Property("a", Int()).AdditionalProperties(true)),
+			valid:  []interface{}{map[string]interface{}{"type": "a", "a": 1}, map[string]interface{}{"type": "b"}},
+			reject: []interface{}{map[string]interface{}{"type": "a"}},
+		},
+		{
+			name:   "tuple rest",
+			schema: Tuple(String()).Rest(Int().Min(0)),
+			valid:  []interface{}{[]interface{}{"a"}, []interface{}{"a", 1, 2}},
+			reject: []interface{}{[]interface{}{"a", -1}, []interface{}{"a", "b"}},
+		},
+		{
+			name: "conditional cases",
+			schema: Conditional().
+				Case(FieldEquals("type", "a"), Object().Passthrough().Property("a", Int())).
+				Case(FieldEquals("type", "b"), Object().Passthrough().Property("b", String())).
+				Else(Never()),
+			valid:  []interface{}{map[string]interface{}{"type": "a", "a": 1}, map[string]interface{}{"type": "b", "b": "x"}},
+			reject: []interface{}{map[string]interface{}{"type": "b"}, map[string]interface{}{"type": "c"}},
+		},
+		{
+			name:   "merge patch",
+			schema: Object().Property("name", String()).Property("bio", String().Optional()).PatchSchema(),
+			valid:  []interface{}{map[string]interface{}{}, map[string]interface{}{"bio": nil}},
+			reject: []interface{}{map[string]interface{}{"name": nil}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := MarshalSchema(tt.schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := UnmarshalSchema(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, err := MarshalSchema(loaded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(again) != string(data) {
+				t.Errorf("round trip changed the definition:\n%s\n%s", data, again)
+			}
+
+			for _, value := range tt.valid {
+				if result := loaded.Parse(value, DefaultValidationContext()); !result.Valid {
+					t.Errorf("Parse(%v) errors = %v", value, result.Errors)
+				}
+			}
+			for _, value := range tt.reject {
+				if result := loaded.Parse(value, DefaultValidationContext()); result.Valid {
+					t.Errorf("Parse(%v) succeeded", value)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaSerialization_Errors(t *testing.T) {
+	marshal := []struct {
+		name   string
+		schema Parseable
+		want   string
+	}{
+		{"refinement", Object().Property("name", String().Refine(func(v interface{}) bool { return v != "" })), "#/properties/name: it has transforms or refinements"},
+		{"normalizer", Array(String().Trim()), "#/items: it normalizes values with functions"},
+		{"default func", Int().DefaultFunc(func() interface{} { return 1 }), "#: its default is computed by a function"},
+		{"reference", Array(Ref("#/definitions/user", NewSchemaRegistry())), "#/items: unsupported schema type *schema.RefSchema"},
+	}
+	for _, tt := range marshal {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MarshalSchema(tt.schema)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("MarshalSchema() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	unmarshal := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"invalid JSON", `{"kind":`, "invalid schema definition"},
+		{"unknown kind", `{"kind": "text"}`, `unknown kind "text"`},
+		{"nested", `{"kind": "array", "items": {"kind": "int", "minimum": "0"}}`, `invalid "minimum" at #/items`},
+		{"invalid pattern", `{"kind": "string", "pattern": "("}`, `invalid "pattern" at #`},
+		{"missing schema", `{"kind": "not"}`, `missing "schema"`},
+		{"severity", `{"kind": "string", "severities": {"": "fatal"}}`, `unknown severity "fatal"`},
+	}
+	for _, tt := range unmarshal {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalSchema([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("UnmarshalSchema() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/nyxstack/i18n"
+)
+
+func objectDeprecatedPropError(prop string) i18n.TranslatedFunc {
+	return i18n.F("property %s is deprecated", prop)
+}
+
+// Severity grades a validation error. Errors fail validation; warnings and infos are
+// reported in ParseResult.Warnings and do not.
+type Severity int
+
+const (
+	SeverityError   Severity = iota // Fails validation (the zero value)
+	SeverityWarning                 // A problem that does not fail validation, e.g. a soft limit
+	SeverityInfo                    // A notice, e.g. the use of a deprecated property
+)
+
+// String returns "error", "warning" or "info"
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return "error"
+}
+
+// MarshalText encodes the severity as its name
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity name
+func (s *Severity) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "error":
+		*s = SeverityError
+	case "warning":
+		*s = SeverityWarning
+	case "info":
+		*s = SeverityInfo
+	default:
+		return fmt.Errorf("schema: unknown severity %q", text)
+	}
+	return nil
+}
+
+// setSeverity reports the errors with the given codes, or all errors when no code is
+// given, with severity. The map is copied so that copies of the schema do not share it.
+func (s *Schema) setSeverity(severity Severity, codes []ErrorCode) {
+	severities := maps.Clone(s.severities)
+	if severities == nil {
+		severities = make(map[ErrorCode]Severity, len(codes))
+	}
+	if len(codes) == 0 {
+		codes = []ErrorCode{""}
+	}
+	for _, code := range codes {
+		severities[code] = severity
+	}
+	s.severities = severities
+}
+
+// GetSeverity returns the severity the schema reports errors with code at
+func (s *Schema) GetSeverity(code ErrorCode) Severity {
+	if severity, ok := s.severities[code]; ok {
+		return severity
+	}
+	return s.severities[""]
+}
+
+// graded is implemented by the schemas that support AsWarning and AsInfo
+type graded interface {
+	GetSeverity(code ErrorCode) Severity
+	hasSeverities() bool
+}
+
+// hasSeverities returns whether AsWarning or AsInfo was used
+func (s *Schema) hasSeverities() bool {
+	return len(s.severities) > 0
+}
+
+// splitWarnings moves the errors that schema reports as warnings or infos from the
+// errors of result to its warnings. A result left without errors is valid, with the
+// value the schema parsed if it returned one and the input otherwise.
+func splitWarnings(result ParseResult, schema Parseable, input interface{}) ParseResult {
+	grader, ok := schema.(graded)
+	if !ok || !grader.hasSeverities() {
+		return result
+	}
+
+	var errors []ValidationError
+	for _, err := range result.Errors {
+		if severity := grader.GetSeverity(err.Code); severity != SeverityError {
+			err.Severity = severity
+			result.Warnings = append(result.Warnings, err)
+			continue
+		}
+		errors = append(errors, err)
+	}
+	result.Errors = errors
+	if len(errors) == 0 {
+		result.Valid = true
+		result.PartialOK = false
+		if result.Value == nil {
+			result.Value = input
+		}
+	}
+	return result
+}
+
+// nestedWarnings appends the warnings of the result of a nested value to warnings,
+// with their paths prefixed by the position of the value
+func nestedWarnings(warnings []ValidationError, segment PathSegment, result ParseResult) []ValidationError {
+	for _, warning := range result.Warnings {
+		warning.Path = append(Path{segment}, warning.Path...)
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
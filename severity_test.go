@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSeverity(t *testing.T) {
+	user := Object().
+		Property("name", String().MaxLength(5).AsWarning("max_length").MinLength(2).Optional()).
+		Property("nick", String().Deprecated("use name").Optional()).
+		Property("tags", Array(String().MaxLength(3).AsInfo()).Optional())
+
+	tests := []struct {
+		name         string
+		schema       Parseable
+		value        interface{}
+		wantValid    bool
+		wantValue    interface{}
+		wantErrors   []string
+		wantWarnings []string
+	}{
+		{"soft limit", String().MaxLength(3).AsWarning("max_length"), "abcd", true, "abcd", nil, []string{" max_length"}},
+		{"other codes still fail", String().MaxLength(3).MinLength(2).AsWarning("max_length"), "a", false, nil, []string{" min_length"}, nil},
+		{"all codes", Int().Max(10).AsInfo(), 11, true, 11, nil, []string{" maximum"}},
+		{"within limits", String().MaxLength(3).AsWarning(), "abc", true, "abc", nil, nil},
+		{"nested warning", user, map[string]interface{}{"name": "Alexander"}, true, map[string]interface{}{"name": "Alexander"}, nil, []string{"name max_length"}},
+		{"nested error and warning", user, map[string]interface{}{"name": "Alexander", "tags": []interface{}{"a", "long"}}, true, nil, nil, []string{"name max_length", "tags[1] max_length"}},
+		{"hard error", user, map[string]interface{}{"name": "A"}, false, nil, []string{"name min_length", "name property_invalid"}, nil},
+		{"deprecated property", user, map[string]interface{}{"nick": "al"}, true, map[string]interface{}{"nick": "al"}, nil, []string{"nick deprecated"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, DefaultValidationContext())
+			if result.Valid != tt.wantValid {
+				t.Fatalf("valid = %v, errors = %v", result.Valid, result.Errors)
+			}
+			if tt.wantValue != nil && !reflect.DeepEqual(result.Value, tt.wantValue) {
+				t.Errorf("value = %v, want %v", result.Value, tt.wantValue)
+			}
+			if got := errorKeys(result.Errors); !sameKeys(got, tt.wantErrors) {
+				t.Errorf("errors = %v, want %v", got, tt.wantErrors)
+			}
+			if got := errorKeys(result.Warnings); !sameKeys(got, tt.wantWarnings) {
+				t.Errorf("warnings = %v, want %v", got, tt.wantWarnings)
+			}
+			for _, warning := range result.Warnings {
+				if warning.Severity == SeverityError {
+					t.Errorf("warning %v has error severity", warning)
+				}
+			}
+		})
+	}
+}
+
+func TestSeverity_JSON(t *testing.T) {
+	result := String().MaxLength(1).AsInfo().Parse("ab", DefaultValidationContext())
+	if len(result.Warnings) != 1 || result.Warnings[0].Severity != SeverityInfo {
+		t.Fatalf("warnings = %v", result.Warnings)
+	}
+
+	data, err := json.Marshal(result.Warnings[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ValidationError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Severity != SeverityInfo {
+		t.Errorf("decoded severity = %v from %s", decoded.Severity, data)
+	}
+
+	// Errors omit their severity
+	data, _ = json.Marshal(ValidationError{Message: "m", Code: "c"})
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields["severity"]; ok {
+		t.Errorf("error JSON = %s", data)
+	}
+}
+
+// sameKeys compares error keys, an empty list matching nil
+func sameKeys(got, want []string) bool {
+	return len(got) == 0 && len(want) == 0 || reflect.DeepEqual(got, want)
+}
@@ -0,0 +1,241 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SQLDialect selects the SQL flavor generated by ExportSQL
+type SQLDialect string
+
+// Supported SQL dialects
+const (
+	SQLDialectPostgres SQLDialect = "postgres"
+	SQLDialectMySQL    SQLDialect = "mysql"
+	SQLDialectSQLite   SQLDialect = "sqlite"
+)
+
+// ExportSQL generates a CREATE TABLE statement from an object schema, so storage can
+// be prototyped from the same definition that validates the input. Each property
+// becomes a column, in property name order:
+//
+//   - strings become VARCHAR(n) when limited by MaxLength and TEXT otherwise, with
+//     DATE, TIMESTAMP, TIME and UUID types for the matching formats
+//   - integers become SMALLINT, INTEGER or BIGINT according to their format, numbers
+//     limited with Precision NUMERIC(p, s) and other numbers floating-point types
+//   - booleans become BOOLEAN, and objects, arrays and unions JSON columns
+//   - required properties that do not accept null are NOT NULL
+//   - defaults become DEFAULT clauses
+//   - minimum/maximum, MinLength and enum/const constraints become CHECK constraints
+//
+// SQLite has no length-limited or temporal types, so it uses TEXT, INTEGER, REAL and
+// NUMERIC columns and relies on CHECK constraints.
+func ExportSQL(s *ObjectSchema, dialect SQLDialect, table string) (string, error) {
+	switch dialect {
+	case SQLDialectPostgres, SQLDialectMySQL, SQLDialectSQLite:
+	default:
+		return "", fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+	if table == "" {
+		return "", fmt.Errorf("table name is required")
+	}
+	doc, err := jsonDocument(s)
+	if err != nil {
+		return "", err
+	}
+	properties, _ := doc["properties"].(map[string]interface{})
+	if len(properties) == 0 {
+		return "", fmt.Errorf("table %s has no columns", table)
+	}
+	required := map[string]bool{}
+	for _, r := range jsonStrings(doc["required"]) {
+		required[r] = true
+	}
+
+	w := sqlWriter{dialect: dialect}
+	columns := make([]string, 0, len(properties))
+	for _, name := range sortedMapKeys(properties) {
+		propDoc, _ := properties[name].(map[string]interface{})
+		scale := -1
+		if number, ok := s.GetProperties()[name].Schema.(*NumberSchema); ok && number.GetPrecision() != nil {
+			scale = *number.GetPrecision()
+		}
+		columns = append(columns, w.column(name, propDoc, required[name] && !jsonNullable(propDoc), scale))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n", w.quote(table))
+	for i, column := range columns {
+		b.WriteString("  ")
+		b.WriteString(column)
+		if i < len(columns)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(");\n")
+	return b.String(), nil
+}
+
+// sqlWriter renders columns in one SQL dialect
+type sqlWriter struct {
+	dialect SQLDialect
+}
+
+// column renders the definition of a column. scale is the number of decimal places
+// of numbers limited with Precision, -1 for other columns.
+func (w sqlWriter) column(name string, doc map[string]interface{}, notNull bool, scale int) string {
+	column := w.quote(name)
+	parts := []string{column, w.columnType(doc, scale)}
+	if notNull {
+		parts = append(parts, "NOT NULL")
+	}
+	if value, ok := doc["default"]; ok && value != nil {
+		if literal, ok := w.literal(value); ok {
+			parts = append(parts, "DEFAULT "+literal)
+		}
+	}
+	if checks := w.checks(column, doc); len(checks) > 0 {
+		parts = append(parts, "CHECK ("+strings.Join(checks, " AND ")+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// columnType returns the SQL type storing the values of a document
+func (w sqlWriter) columnType(doc map[string]interface{}, scale int) string {
+	if members := jsonUnionMembers(doc); len(members) == 1 {
+		doc = members[0]
+	}
+	switch {
+	case doc["oneOf"] != nil || doc["anyOf"] != nil || doc["$ref"] != nil,
+		jsonHasType(doc, "object"), jsonHasType(doc, "array"), doc["type"] == nil && doc["enum"] == nil:
+		return w.pick("JSONB", "JSON", "TEXT")
+
+	case jsonHasType(doc, "string"):
+		switch doc["format"] {
+		case "date":
+			return w.pick("DATE", "DATE", "TEXT")
+		case "date-time":
+			return w.pick("TIMESTAMPTZ", "DATETIME", "TEXT")
+		case "time":
+			return w.pick("TIME", "TIME", "TEXT")
+		case "uuid":
+			return w.pick("UUID", "CHAR(36)", "TEXT")
+		}
+		if maxLength, ok := doc["maxLength"].(float64); ok && w.dialect != SQLDialectSQLite {
+			return fmt.Sprintf("VARCHAR(%d)", int(maxLength))
+		}
+		if values, ok := doc["enum"].([]interface{}); ok && w.dialect != SQLDialectSQLite {
+			longest := 1
+			for _, value := range jsonStrings(values) {
+				longest = max(longest, len([]rune(value)))
+			}
+			return fmt.Sprintf("VARCHAR(%d)", longest)
+		}
+		return "TEXT"
+
+	case jsonHasType(doc, "integer"):
+		if w.dialect == SQLDialectSQLite {
+			return "INTEGER"
+		}
+		switch doc["format"] {
+		case "int8", "int16", "uint8":
+			return "SMALLINT"
+		case "int32", "uint16":
+			return "INTEGER"
+		case "uint64":
+			return w.pick("NUMERIC(20, 0)", "BIGINT UNSIGNED", "INTEGER")
+		}
+		return "BIGINT"
+
+	case jsonHasType(doc, "number"):
+		if scale >= 0 {
+			return fmt.Sprintf("NUMERIC(%d, %d)", decimalPrecision(doc, scale), scale)
+		}
+		if doc["format"] == "float" {
+			return w.pick("REAL", "FLOAT", "REAL")
+		}
+		return w.pick("DOUBLE PRECISION", "DOUBLE", "REAL")
+
+	case jsonHasType(doc, "boolean"):
+		return w.pick("BOOLEAN", "BOOLEAN", "INTEGER")
+	}
+	return w.pick("JSONB", "JSON", "TEXT")
+}
+
+// checks returns the conditions of the CHECK constraint of a column
+func (w sqlWriter) checks(column string, doc map[string]interface{}) []string {
+	var checks []string
+	if values, ok := doc["enum"].([]interface{}); ok {
+		literals := make([]string, 0, len(values))
+		for _, value := range values {
+			if literal, ok := w.literal(value); ok {
+				literals = append(literals, literal)
+			}
+		}
+		if len(literals) > 0 {
+			checks = append(checks, fmt.Sprintf("%s IN (%s)", column, strings.Join(literals, ", ")))
+		}
+	}
+	if value, ok := doc["const"]; ok {
+		if literal, ok := w.literal(value); ok {
+			checks = append(checks, fmt.Sprintf("%s = %s", column, literal))
+		}
+	}
+	for _, bound := range []struct{ key, operator string }{
+		{"minimum", ">="}, {"exclusiveMinimum", ">"}, {"maximum", "<="}, {"exclusiveMaximum", "<"},
+	} {
+		if value, ok := doc[bound.key].(float64); ok {
+			checks = append(checks, fmt.Sprintf("%s %s %s", column, bound.operator, strconv.FormatFloat(value, 'f', -1, 64)))
+		}
+	}
+	if minLength, ok := doc["minLength"].(float64); ok && minLength > 0 {
+		checks = append(checks, fmt.Sprintf("%s(%s) >= %d", w.pick("char_length", "CHAR_LENGTH", "length"), column, int(minLength)))
+	}
+	if maxLength, ok := doc["maxLength"].(float64); ok && w.dialect == SQLDialectSQLite {
+		checks = append(checks, fmt.Sprintf("length(%s) <= %d", column, int(maxLength)))
+	}
+	return checks
+}
+
+// literal renders a string, number or boolean as an SQL literal
+func (w sqlWriter) literal(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		if w.dialect == SQLDialectMySQL {
+			v = strings.ReplaceAll(v, `\`, `\\`)
+		}
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'", true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		if w.dialect == SQLDialectSQLite {
+			if v {
+				return "1", true
+			}
+			return "0", true
+		}
+		return strings.ToUpper(strconv.FormatBool(v)), true
+	}
+	return "", false
+}
+
+// quote quotes an identifier
+func (w sqlWriter) quote(name string) string {
+	if w.dialect == SQLDialectMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// pick returns the value for the dialect of the writer
+func (w sqlWriter) pick(postgres, mysql, sqlite string) string {
+	switch w.dialect {
+	case SQLDialectMySQL:
+		return mysql
+	case SQLDialectSQLite:
+		return sqlite
+	}
+	return postgres
+}
@@ -0,0 +1,111 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportSQL(t *testing.T) {
+	users := Object().
+		Property("id", UUID()).
+		Property("name", String().MinLength(2).MaxLength(100)).
+		Property("email", String().Email().Nullable()).
+		Property("age", Int32().Min(0).Max(150).Optional()).
+		Property("balance", Number().Min(0).Max(99999).Precision(2)).
+		Property("role", Enum("admin", "member").Default("member")).
+		Property("active", Bool().Default(true)).
+		Property("tags", Array(String()).Optional()).
+		Property("created_at", DateTime())
+
+	tests := []struct {
+		dialect SQLDialect
+		want    string
+	}{
+		{SQLDialectPostgres, `CREATE TABLE "users" (
+  "active" BOOLEAN NOT NULL DEFAULT TRUE,
+  "age" INTEGER CHECK ("age" >= 0 AND "age" <= 150),
+  "balance" NUMERIC(7, 2) NOT NULL CHECK ("balance" >= 0 AND "balance" <= 99999),
+  "created_at" TIMESTAMPTZ NOT NULL,
+  "email" TEXT,
+  "id" UUID NOT NULL,
+  "name" VARCHAR(100) NOT NULL CHECK (char_length("name") >= 2),
+  "role" VARCHAR(6) NOT NULL DEFAULT 'member' CHECK ("role" IN ('admin', 'member')),
+  "tags" JSONB
+);
+`},
+		{SQLDialectMySQL, "CREATE TABLE `users` (\n" +
+			"  `active` BOOLEAN NOT NULL DEFAULT TRUE,\n" +
+			"  `age` INTEGER CHECK (`age` >= 0 AND `age` <= 150),\n" +
+			"  `balance` NUMERIC(7, 2) NOT NULL CHECK (`balance` >= 0 AND `balance` <= 99999),\n" +
+			"  `created_at` DATETIME NOT NULL,\n" +
+			"  `email` TEXT,\n" +
+			"  `id` CHAR(36) NOT NULL,\n" +
+			"  `name` VARCHAR(100) NOT NULL CHECK (CHAR_LENGTH(`name`) >= 2),\n" +
+			"  `role` VARCHAR(6) NOT NULL DEFAULT 'member' CHECK (`role` IN ('admin', 'member')),\n" +
+			"  `tags` JSON\n" +
+			");\n"},
+		{SQLDialectSQLite, `CREATE TABLE "users" (
+  "active" INTEGER NOT NULL DEFAULT 1,
+  "age" INTEGER CHECK ("age" >= 0 AND "age" <= 150),
+  "balance" NUMERIC(7, 2) NOT NULL CHECK ("balance" >= 0 AND "balance" <= 99999),
+  "created_at" TEXT NOT NULL,
+  "email" TEXT,
+  "id" TEXT NOT NULL,
+  "name" TEXT NOT NULL CHECK (length("name") >= 2 AND length("name") <= 100),
+  "role" TEXT NOT NULL DEFAULT 'member' CHECK ("role" IN ('admin', 'member')),
+  "tags" TEXT
+);
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.dialect), func(t *testing.T) {
+			got, err := ExportSQL(users, tt.dialect, "users")
+			if err != nil {
+				t.Fatalf("ExportSQL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExportSQL() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportSQL_Literals(t *testing.T) {
+	s := Object().
+		Property("note", String().Default(`it's a \ path`)).
+		Property("level", Int8().Optional())
+
+	got, err := ExportSQL(s, SQLDialectMySQL, "notes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"DEFAULT 'it''s a \\\\ path'", "`level` SMALLINT,"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ExportSQL() = %s, want it to contain %s", got, want)
+		}
+	}
+}
+
+func TestExportSQL_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  *ObjectSchema
+		dialect SQLDialect
+		table   string
+		want    string
+	}{
+		{"unknown dialect", Object().Property("id", Int()), "oracle", "t", `unsupported SQL dialect "oracle"`},
+		{"missing table", Object().Property("id", Int()), SQLDialectPostgres, "", "table name is required"},
+		{"no columns", Object(), SQLDialectPostgres, "t", "table t has no columns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExportSQL(tt.schema, tt.dialect, tt.table)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ExportSQL() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
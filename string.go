@@ -2,8 +2,12 @@ package schema
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
+	"unicode"
 
 	"github.com/nyxstack/i18n"
 )
@@ -26,6 +30,12 @@ const (
 	StringFormatPassword StringFormat = "password"
 	StringFormatBinary   StringFormat = "binary"
 	StringFormatByte     StringFormat = "byte"
+
+	// Formats outside the JSON Schema specification; JSON() also emits an equivalent pattern
+	StringFormatSlug         StringFormat = "slug"         // Lowercase words joined by hyphens: "hello-world-2"
+	StringFormatIdentifier   StringFormat = "identifier"   // ASCII identifier valid in Go and JavaScript: "userID", "_tmp"
+	StringFormatAlphanumeric StringFormat = "alphanumeric" // ASCII letters and digits only
+	StringFormatASCII        StringFormat = "ascii"        // ASCII characters only
 )
 
 // Default error messages for string validation
@@ -34,6 +44,8 @@ var (
 	stringTypeError     = i18n.S("value must be a string")
 	stringPatternError  = i18n.S("value format is invalid")
 	stringEnumError     = i18n.S("value must be one of the allowed values")
+	stringControlError  = i18n.S("value must not contain control characters")
+	stringNonEmptyError = i18n.S("value must not be empty")
 )
 
 // Default error message functions that take parameters
@@ -56,12 +68,20 @@ func stringConstError(value string) i18n.TranslatedFunc {
 // StringSchema represents a JSON Schema for string values
 type StringSchema struct {
 	Schema
+	coerce      bool                  // Convert compatible input types before validating
+	normalizers []func(string) string // Applied in order before any constraint is checked
 	// String-specific validation (private fields)
-	minLength *int
-	maxLength *int
-	pattern   *string
-	format    *StringFormat
-	nullable  bool
+	minLength      *int
+	maxLength      *int
+	lengthUnit     LengthUnit // How MinLength/MaxLength measure the value (default runes)
+	pattern        *string
+	regex          *regexp.Regexp // pattern, compiled once when set
+	format         *StringFormat
+	noControl      bool
+	nonEmpty       bool // Reject "", also when ValidationContext.EmptyIsPresent is set
+	emptyIsValue   bool // Validate "" like any other string, as JSON Schema does (set by Compile)
+	nullable       bool
+	constOverrides bool // Const and enum values skip the other constraints
 
 	// Error messages for validation failures (support i18n)
 	requiredError     ErrorMessage
@@ -69,6 +89,8 @@ type StringSchema struct {
 	maxLengthError    ErrorMessage
 	patternError      ErrorMessage
 	formatError       ErrorMessage
+	controlError      ErrorMessage
+	nonEmptyError     ErrorMessage
 	enumError         ErrorMessage
 	constError        ErrorMessage
 	typeMismatchError ErrorMessage
@@ -88,34 +110,117 @@ func String(errorMessage ...interface{}) *StringSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *StringSchema) Clone() *StringSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.normalizers = slices.Clone(s.normalizers)
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *StringSchema) Freeze() *StringSchema {
+	s.freeze()
+	return s
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *StringSchema) Title(title string) *StringSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *StringSchema) Description(description string) *StringSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *StringSchema) ReadOnly() *StringSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *StringSchema) WriteOnly() *StringSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *StringSchema) Sensitive() *StringSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *StringSchema) Deprecated(reason string) *StringSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *StringSchema) Meta(key string, value interface{}) *StringSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *StringSchema) AsWarning(codes ...ErrorCode) *StringSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *StringSchema) AsInfo(codes ...ErrorCode) *StringSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *StringSchema) Default(value interface{}) *StringSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *StringSchema) DefaultFunc(fn func() interface{}) *StringSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *StringSchema) Example(example string) *StringSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 // Enum sets the allowed enum values with optional custom error message
 func (s *StringSchema) Enum(values []string, errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
 	s.Schema.enum = make([]interface{}, len(values))
 	for i, v := range values {
 		s.Schema.enum[i] = v
@@ -128,6 +233,7 @@ func (s *StringSchema) Enum(values []string, errorMessage ...interface{}) *Strin
 
 // Const sets a constant value with optional custom error message
 func (s *StringSchema) Const(value string, errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
 	s.Schema.constVal = value
 	if len(errorMessage) > 0 {
 		s.constError = toErrorMessage(errorMessage[0])
@@ -135,16 +241,27 @@ func (s *StringSchema) Const(value string, errorMessage ...interface{}) *StringS
 	return s
 }
 
+// ConstOverrides gives the const and enum precedence over the other constraints: a
+// const or enum value is valid even if it fails them, and another value only reports
+// the const or enum error
+func (s *StringSchema) ConstOverrides(enabled bool) *StringSchema {
+	s.checkMutable()
+	s.constOverrides = enabled
+	return s
+}
+
 // Required/Optional/Nullable control
 
 // Optional marks the schema as optional
 func (s *StringSchema) Optional() *StringSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *StringSchema) Required(errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -154,12 +271,27 @@ func (s *StringSchema) Required(errorMessage ...interface{}) *StringSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *StringSchema) Nullable() *StringSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
+// Coerce enables conversion of compatible input types (e.g. strings from query
+// parameters or environment variables) before validation
+func (s *StringSchema) Coerce() *StringSchema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *StringSchema) coerces() bool {
+	return s.coerce
+}
+
 // TypeError sets a custom error message for type mismatch validation
 func (s *StringSchema) TypeError(message string) *StringSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -168,6 +300,7 @@ func (s *StringSchema) TypeError(message string) *StringSchema {
 
 // MinLength sets the minimum length constraint with optional custom error message
 func (s *StringSchema) MinLength(min int, errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
 	s.minLength = &min
 	if len(errorMessage) > 0 {
 		s.minLengthError = toErrorMessage(errorMessage[0])
@@ -177,6 +310,7 @@ func (s *StringSchema) MinLength(min int, errorMessage ...interface{}) *StringSc
 
 // MaxLength sets the maximum length constraint with optional custom error message
 func (s *StringSchema) MaxLength(max int, errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
 	s.maxLength = &max
 	if len(errorMessage) > 0 {
 		s.maxLengthError = toErrorMessage(errorMessage[0])
@@ -186,6 +320,7 @@ func (s *StringSchema) MaxLength(max int, errorMessage ...interface{}) *StringSc
 
 // Length sets both min and max length to the same value with optional custom error message
 func (s *StringSchema) Length(length int, errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
 	s.minLength = &length
 	s.maxLength = &length
 	if len(errorMessage) > 0 {
@@ -195,24 +330,67 @@ func (s *StringSchema) Length(length int, errorMessage ...interface{}) *StringSc
 	return s
 }
 
-// Pattern sets a regex pattern constraint with optional custom error message
+// NonEmpty rejects the empty string (code "min_length"), with optional custom error
+// message. Unlike Required, which is about missing values, it also applies to optional
+// strings and when ValidationContext.EmptyIsPresent makes "" a regular value.
+func (s *StringSchema) NonEmpty(errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
+	s.nonEmpty = true
+	if len(errorMessage) > 0 {
+		s.nonEmptyError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// LengthUnit sets how MinLength, MaxLength and Length measure the string:
+// LengthUnitRunes (default), LengthUnitBytes or LengthUnitGraphemes
+func (s *StringSchema) LengthUnit(unit LengthUnit) *StringSchema {
+	s.checkMutable()
+	s.lengthUnit = unit
+	return s
+}
+
+// Pattern sets a regex pattern constraint with optional custom error message.
+// The pattern is compiled once; Pattern panics if it is not a valid regular expression.
 func (s *StringSchema) Pattern(pattern string, errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("schema: invalid pattern %q: %v", pattern, err))
+	}
 	s.pattern = &pattern
+	s.regex = regex
 	if len(errorMessage) > 0 {
 		s.patternError = toErrorMessage(errorMessage[0])
 	}
 	return s
 }
 
-// Format sets the string format with optional custom error message
+// Format sets the string format with optional custom error message. The password
+// format also marks the schema as Sensitive.
 func (s *StringSchema) Format(format StringFormat, errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
 	s.format = &format
+	if format == StringFormatPassword {
+		s.Schema.sensitive = true
+	}
 	if len(errorMessage) > 0 {
 		s.formatError = toErrorMessage(errorMessage[0])
 	}
 	return s
 }
 
+// NoControlChars rejects control characters such as NUL, escape, tab and newline, with
+// optional custom error message. Use it for single-line values like names and titles.
+func (s *StringSchema) NoControlChars(errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
+	s.noControl = true
+	if len(errorMessage) > 0 {
+		s.controlError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
 // Getters for accessing private fields
 
 // IsRequired returns whether the schema is marked as required
@@ -230,6 +408,11 @@ func (s *StringSchema) IsNullable() bool {
 	return s.nullable
 }
 
+// IsConstOverrides returns whether the const and enum take precedence over the other constraints
+func (s *StringSchema) IsConstOverrides() bool {
+	return s.constOverrides
+}
+
 // GetMinLength returns the minimum length constraint
 func (s *StringSchema) GetMinLength() *int {
 	return s.minLength
@@ -240,6 +423,14 @@ func (s *StringSchema) GetMaxLength() *int {
 	return s.maxLength
 }
 
+// GetLengthUnit returns the unit used by the length constraints
+func (s *StringSchema) GetLengthUnit() LengthUnit {
+	if s.lengthUnit == "" {
+		return LengthUnitRunes
+	}
+	return s.lengthUnit
+}
+
 // GetPattern returns the pattern constraint
 func (s *StringSchema) GetPattern() *string {
 	return s.pattern
@@ -250,12 +441,20 @@ func (s *StringSchema) GetFormat() *StringFormat {
 	return s.format
 }
 
+// IsNoControlChars returns whether control characters are rejected
+func (s *StringSchema) IsNoControlChars() bool {
+	return s.noControl
+}
+
+// IsNonEmpty returns whether the empty string is rejected
+func (s *StringSchema) IsNonEmpty() bool {
+	return s.nonEmpty
+}
+
 // GetDefault returns the default value as a string
 func (s *StringSchema) GetDefaultString() *string {
-	if s.GetDefault() != nil {
-		if str, ok := s.GetDefault().(string); ok {
-			return &str
-		}
+	if str, ok := s.GetDefault().(string); ok {
+		return &str
 	}
 	return nil
 }
@@ -297,16 +496,119 @@ func (s *StringSchema) UUID() *StringSchema {
 	return s.Format(StringFormatUUID)
 }
 
-// Password sets the format to password
+// Password sets the format to password and marks the schema as Sensitive, so error
+// values never echo the input
 func (s *StringSchema) Password() *StringSchema {
 	return s.Format(StringFormatPassword)
 }
 
+// Slug sets the format to slug: lowercase ASCII letters and digits in hyphen-separated words
+func (s *StringSchema) Slug() *StringSchema {
+	return s.Format(StringFormatSlug)
+}
+
+// Identifier sets the format to identifier: an ASCII letter or underscore followed by
+// letters, digits and underscores, valid as a Go and JavaScript identifier (keywords
+// are not rejected)
+func (s *StringSchema) Identifier() *StringSchema {
+	return s.Format(StringFormatIdentifier)
+}
+
+// Alphanumeric sets the format to alphanumeric: ASCII letters and digits only
+func (s *StringSchema) Alphanumeric() *StringSchema {
+	return s.Format(StringFormatAlphanumeric)
+}
+
+// ASCII sets the format to ascii: characters U+0000 to U+007F only
+func (s *StringSchema) ASCII() *StringSchema {
+	return s.Format(StringFormatASCII)
+}
+
+// Normalization, applied in the order the methods are called before any constraint
+// is checked; the normalized string is the parsed value
+
+// Trim removes leading and trailing whitespace
+func (s *StringSchema) Trim() *StringSchema {
+	s.checkMutable()
+	s.normalizers = append(s.normalizers, strings.TrimSpace)
+	return s
+}
+
+// Lowercase converts the value to lowercase
+func (s *StringSchema) Lowercase() *StringSchema {
+	s.checkMutable()
+	s.normalizers = append(s.normalizers, strings.ToLower)
+	return s
+}
+
+// Uppercase converts the value to uppercase
+func (s *StringSchema) Uppercase() *StringSchema {
+	s.checkMutable()
+	s.normalizers = append(s.normalizers, strings.ToUpper)
+	return s
+}
+
+// NormalizeUnicode converts the value to a Unicode normalization form, so that for
+// example "é" typed as "e" plus a combining accent equals the precomposed "é" (NFC)
+func (s *StringSchema) NormalizeUnicode(form NormalizationForm) *StringSchema {
+	s.checkMutable()
+	s.normalizers = append(s.normalizers, func(value string) string {
+		return normalizeUnicode(value, form)
+	})
+	return s
+}
+
+// CollapseWhitespace replaces each run of whitespace with a single space and trims the ends
+func (s *StringSchema) CollapseWhitespace() *StringSchema {
+	s.checkMutable()
+	s.normalizers = append(s.normalizers, collapseWhitespace)
+	return s
+}
+
+// StripHTML removes HTML tags and comments, and the content of script and style elements.
+// Character references such as "&amp;" are left as they are.
+func (s *StringSchema) StripHTML() *StringSchema {
+	s.checkMutable()
+	s.normalizers = append(s.normalizers, stripHTML)
+	return s
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *StringSchema) Transform(fn TransformFunc) *StringSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *StringSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *StringSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *StringSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Validate validates a string value against this schema with context
 // Parse validates and parses a string value, returning the final parsed value
 func (s *StringSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the string constraints; Parse runs the refine/transform pipeline on top
+func (s *StringSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceString(value)
+	}
+
 	var errors []ValidationError
 
 	// Handle nil values
@@ -319,7 +621,7 @@ func (s *StringSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := stringRequiredError(ctx.Locale)
@@ -329,12 +631,12 @@ func (s *StringSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -350,15 +652,24 @@ func (s *StringSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
-	// Check required (empty string case)
-	if s.Schema.required && strValue == "" {
+	// Reject strings over the context's limit before normalizing them
+	if err, exceeded := ctx.checkStringLength(strValue); exceeded {
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{err}}
+	}
+
+	for _, normalize := range s.normalizers {
+		strValue = normalize(strValue)
+	}
+
+	// Check required (empty string case), unless the context treats "" as a value
+	if s.Schema.required && strValue == "" && !s.emptyIsPresent(ctx) {
 		// Check if we have a default value for empty strings
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 
 		message := stringRequiredError(ctx.Locale)
@@ -368,49 +679,62 @@ func (s *StringSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(strValue, message, "required")},
+			Errors: []ValidationError{NewPrimitiveError(strValue, message, CodeRequired)},
 		}
 	}
 
 	// If value is empty and not required, it's valid - return empty string or default
-	if strValue == "" && !s.Schema.required {
+	if strValue == "" && !s.Schema.required && !s.emptyIsPresent(ctx) {
 		if defaultVal := s.GetDefault(); defaultVal != nil {
 			// Return default instead of empty string
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
+		}
+		if s.nonEmpty {
+			return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{s.nonEmptyErr(ctx)}}
 		}
 		return ParseResult{Valid: true, Value: "", Errors: nil}
 	}
 
+	// With ConstOverrides, the const and enum alone decide
+	if s.constOverrides && (s.Schema.constVal != nil || len(s.Schema.enum) > 0) {
+		errors = append(errors, s.constEnumErrors(strValue, ctx)...)
+		return ParseResult{Valid: len(errors) == 0, Value: strValue, Errors: errors}
+	}
+
 	// Now validate the string value against all constraints
 	finalValue := strValue // This is our parsed value
 
-	// Check minimum length
-	if s.minLength != nil && len(strValue) < *s.minLength {
+	// Check length constraints in the configured unit
+	length := stringLength(strValue, s.lengthUnit)
+
+	// Check non-empty, then minimum length
+	if s.nonEmpty && strValue == "" {
+		errors = append(errors, s.nonEmptyErr(ctx))
+	} else if s.minLength != nil && length < *s.minLength {
 		message := stringMinLengthError(*s.minLength)(ctx.Locale)
 		if !isEmptyErrorMessage(s.minLengthError) {
 			message = resolveErrorMessage(s.minLengthError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(strValue, message, "min_length"))
+		errors = append(errors, NewPrimitiveError(strValue, message, CodeMinLength))
 	}
 
 	// Check maximum length
-	if s.maxLength != nil && len(strValue) > *s.maxLength {
+	if s.maxLength != nil && length > *s.maxLength {
 		message := stringMaxLengthError(*s.maxLength)(ctx.Locale)
 		if !isEmptyErrorMessage(s.maxLengthError) {
 			message = resolveErrorMessage(s.maxLengthError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(strValue, message, "max_length"))
+		errors = append(errors, NewPrimitiveError(strValue, message, CodeMaxLength))
 	}
 
 	// Check pattern
-	if s.pattern != nil {
-		matched, err := regexp.MatchString(*s.pattern, strValue)
-		if err != nil || !matched {
+	if s.regex != nil {
+		if !s.regex.MatchString(strValue) {
 			message := stringPatternError(ctx.Locale)
 			if !isEmptyErrorMessage(s.patternError) {
 				message = resolveErrorMessage(s.patternError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(strValue, message, "pattern"))
+			errors = append(errors, NewPrimitiveError(strValue, message, CodePattern))
 		}
 	}
 
@@ -421,10 +745,52 @@ func (s *StringSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			if !isEmptyErrorMessage(s.formatError) {
 				message = resolveErrorMessage(s.formatError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(strValue, message, "format"))
+			errors = append(errors, NewPrimitiveError(strValue, message, CodeFormat))
 		}
 	}
 
+	// Check control characters
+	if s.noControl && containsControlChar(strValue) {
+		message := stringControlError(ctx.Locale)
+		if !isEmptyErrorMessage(s.controlError) {
+			message = resolveErrorMessage(s.controlError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(strValue, message, CodeControlChars))
+	}
+
+	errors = append(errors, s.constEnumErrors(strValue, ctx)...)
+
+	// Return the input itself when it is unchanged, which saves boxing the string again
+	parsed := value
+	if original, ok := value.(string); !ok || original != finalValue {
+		parsed = finalValue
+	}
+	return ParseResult{
+		Valid:  len(errors) == 0,
+		Value:  parsed,
+		Errors: errors,
+	}
+}
+
+// emptyIsPresent reports whether "" is validated like any other string instead of
+// being treated as missing
+func (s *StringSchema) emptyIsPresent(ctx *ValidationContext) bool {
+	return s.emptyIsValue || ctx.EmptyIsPresent
+}
+
+// nonEmptyErr returns the error of the empty string with NonEmpty
+func (s *StringSchema) nonEmptyErr(ctx *ValidationContext) ValidationError {
+	message := stringNonEmptyError(ctx.Locale)
+	if !isEmptyErrorMessage(s.nonEmptyError) {
+		message = resolveErrorMessage(s.nonEmptyError, ctx)
+	}
+	return NewPrimitiveError("", message, CodeMinLength)
+}
+
+// constEnumErrors checks the value against the enum and const
+func (s *StringSchema) constEnumErrors(strValue string, ctx *ValidationContext) []ValidationError {
+	var errors []ValidationError
+
 	// Check enum
 	if len(s.Schema.enum) > 0 {
 		valid := false
@@ -439,7 +805,7 @@ func (s *StringSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 			if !isEmptyErrorMessage(s.enumError) {
 				message = resolveErrorMessage(s.enumError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(strValue, message, "enum"))
+			errors = append(errors, NewPrimitiveError(strValue, message, CodeEnum))
 		}
 	}
 
@@ -449,91 +815,121 @@ func (s *StringSchema) Parse(value interface{}, ctx *ValidationContext) ParseRes
 		if !isEmptyErrorMessage(s.constError) {
 			message = resolveErrorMessage(s.constError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(strValue, message, "const"))
+		errors = append(errors, NewPrimitiveError(strValue, message, CodeConst))
 	}
+	return errors
+}
 
-	return ParseResult{
-		Valid:  len(errors) == 0,
-		Value:  finalValue,
-		Errors: errors,
+// Validate reports the constraints that contradict each other, so that values are
+// rejected whatever they are: a minimum length above the maximum length, or a const or enum value that
+// fails the other constraints (unless ConstOverrides is set) or a const outside the
+// enum. It returns nil for a consistent schema.
+func (s *StringSchema) Validate() error {
+	var issues []error
+	if s.minLength != nil && s.maxLength != nil && *s.minLength > *s.maxLength {
+		issues = append(issues, contradiction("minLength %d is greater than maxLength %d", *s.minLength, *s.maxLength))
+	}
+	if s.nonEmpty && s.maxLength != nil && *s.maxLength == 0 {
+		issues = append(issues, contradiction("NonEmpty contradicts maxLength 0"))
 	}
+
+	without := s.Clone()
+	without.Schema.constVal, without.Schema.enum, without.Schema.effects = nil, nil, nil
+	issues = append(issues, constEnumContradictions(s.Schema.constVal, s.Schema.enum, s.constOverrides, without)...)
+	return errors.Join(issues...)
 }
 
 // MarshalJSON implements json.Marshaler to properly serialize StringSchema for JSON schema generation
 func (s *StringSchema) MarshalJSON() ([]byte, error) {
 	type jsonStringSchema struct {
 		Schema
-		MinLength *int          `json:"minLength,omitempty"`
-		MaxLength *int          `json:"maxLength,omitempty"`
-		Pattern   *string       `json:"pattern,omitempty"`
-		Format    *StringFormat `json:"format,omitempty"`
-		Nullable  bool          `json:"nullable,omitempty"`
+		MinLength  *int          `json:"minLength,omitempty"`
+		MaxLength  *int          `json:"maxLength,omitempty"`
+		LengthUnit LengthUnit    `json:"lengthUnit,omitempty"`
+		Pattern    *string       `json:"pattern,omitempty"`
+		Format     *StringFormat `json:"format,omitempty"`
+		Nullable   bool          `json:"nullable,omitempty"`
 	}
 
 	return json.Marshal(jsonStringSchema{
-		Schema:    s.Schema,
-		MinLength: s.minLength,
-		MaxLength: s.maxLength,
-		Pattern:   s.pattern,
-		Format:    s.format,
-		Nullable:  s.nullable,
+		Schema:     s.Schema,
+		MinLength:  s.minLength,
+		MaxLength:  s.maxLength,
+		LengthUnit: s.lengthUnit,
+		Pattern:    s.pattern,
+		Format:     s.format,
+		Nullable:   s.nullable,
 	})
 }
 
+// Compiled format validators, shared by all string schemas
+var (
+	// Simple email validation regex
+	formatEmailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	// Basic URL validation - starts with http/https or is a valid URI
+	formatURLRegex = regexp.MustCompile(`^https?://[^\s/$.?#].[^\s]*$|^[a-zA-Z][a-zA-Z0-9+.-]*:[^\s]*$`)
+	// UUID v4 format validation
+	formatUUIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[1-5][0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`)
+	// ISO 8601 date-time format (basic validation)
+	formatDateTimeRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d{3})?([+-]\d{2}:\d{2}|Z)$`)
+	// ISO 8601 date format
+	formatDateRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	// ISO 8601 time format
+	formatTimeRegex = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}(\.\d{3})?$`)
+	// Basic hostname validation
+	formatHostnameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+// formatPatterns holds the formats outside the JSON Schema specification that can be
+// expressed as a pattern, so that JSON() stays meaningful to other validators
+var formatPatterns = map[StringFormat]*regexp.Regexp{
+	StringFormatSlug:         regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`),
+	StringFormatIdentifier:   regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`),
+	StringFormatAlphanumeric: regexp.MustCompile(`^[A-Za-z0-9]+$`),
+	StringFormatASCII:        regexp.MustCompile(`^[\x00-\x7F]*$`),
+}
+
 // validateFormat validates a string against a specific format
 func (s *StringSchema) validateFormat(value string, format StringFormat) bool {
 	switch format {
 	case StringFormatEmail:
-		// Simple email validation regex
-		emailRegex := `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
-		matched, _ := regexp.MatchString(emailRegex, value)
-		return matched
+		return formatEmailRegex.MatchString(value)
 	case StringFormatURI, StringFormatURL:
-		// Basic URL validation - starts with http/https or is a valid URI
-		urlRegex := `^https?://[^\s/$.?#].[^\s]*$|^[a-zA-Z][a-zA-Z0-9+.-]*:[^\s]*$`
-		matched, _ := regexp.MatchString(urlRegex, value)
-		return matched
+		return formatURLRegex.MatchString(value)
 	case StringFormatUUID:
-		// UUID v4 format validation
-		uuidRegex := `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[1-5][0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`
-		matched, _ := regexp.MatchString(uuidRegex, value)
-		return matched
+		return formatUUIDRegex.MatchString(value)
 	case StringFormatDateTime:
-		// ISO 8601 date-time format (basic validation)
-		dateTimeRegex := `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d{3})?([+-]\d{2}:\d{2}|Z)$`
-		matched, _ := regexp.MatchString(dateTimeRegex, value)
-		return matched
+		return formatDateTimeRegex.MatchString(value)
 	case StringFormatDate:
-		// ISO 8601 date format
-		dateRegex := `^\d{4}-\d{2}-\d{2}$`
-		matched, _ := regexp.MatchString(dateRegex, value)
-		return matched
+		return formatDateRegex.MatchString(value)
 	case StringFormatTime:
-		// ISO 8601 time format
-		timeRegex := `^\d{2}:\d{2}:\d{2}(\.\d{3})?$`
-		matched, _ := regexp.MatchString(timeRegex, value)
-		return matched
+		return formatTimeRegex.MatchString(value)
 	case StringFormatIPv4:
-		// IPv4 format validation
-		ipv4Regex := `^((25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$`
-		matched, _ := regexp.MatchString(ipv4Regex, value)
-		return matched
+		_, ok := parseIPVersion(value, IPVersion4)
+		return ok
 	case StringFormatIPv6:
-		// IPv6 format validation (simplified)
-		ipv6Regex := `^([0-9a-fA-F]{1,4}:){7}[0-9a-fA-F]{1,4}$|^::1$|^::$`
-		matched, _ := regexp.MatchString(ipv6Regex, value)
-		return matched
+		_, ok := parseIPVersion(value, IPVersion6)
+		return ok
 	case StringFormatHostname:
-		// Basic hostname validation
-		hostnameRegex := `^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`
-		matched, _ := regexp.MatchString(hostnameRegex, value)
-		return matched
+		return formatHostnameRegex.MatchString(value)
+	case StringFormatSlug, StringFormatIdentifier, StringFormatAlphanumeric, StringFormatASCII:
+		return formatPatterns[format].MatchString(value)
 	default:
 		// For custom formats or unsupported formats, assume valid
 		return true
 	}
 }
 
+// containsControlChar reports whether str contains a Unicode control character (category Cc)
+func containsControlChar(str string) bool {
+	for _, r := range str {
+		if unicode.IsControl(r) {
+			return true
+		}
+	}
+	return false
+}
+
 // JSON generates JSON Schema representation
 func (s *StringSchema) JSON() map[string]interface{} {
 	schema := baseJSONSchema("string")
@@ -541,17 +937,29 @@ func (s *StringSchema) JSON() map[string]interface{} {
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
 
 	// Add string-specific fields
-	addOptionalField(schema, "minLength", s.minLength)
+	if s.nonEmpty && (s.minLength == nil || *s.minLength < 1) {
+		schema["minLength"] = 1
+	} else {
+		addOptionalField(schema, "minLength", s.minLength)
+	}
 	addOptionalField(schema, "maxLength", s.maxLength)
+	if s.lengthUnit != "" && s.lengthUnit != LengthUnitRunes && (s.minLength != nil || s.maxLength != nil) {
+		// JSON Schema lengths count code points; record the unit actually enforced
+		schema["x-length-unit"] = string(s.lengthUnit)
+	}
 	addOptionalField(schema, "pattern", s.pattern)
 	if s.format != nil {
 		schema["format"] = string(*s.format)
+		if regex, ok := formatPatterns[*s.format]; ok && s.pattern == nil {
+			schema["pattern"] = regex.String()
+		}
 	}
 
 	// Add nullable if true
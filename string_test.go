@@ -577,16 +577,14 @@ func TestStringSchema_ComplexConstraints(t *testing.T) {
 	})
 
 	t.Run("const with other constraints", func(t *testing.T) {
-		// Note: Current implementation validates all constraints including const
-		// This test documents the current behavior rather than ideal behavior
+		// Without ConstOverrides, a const value must also satisfy the other constraints
 		schema := String().
 			MinLength(10). // This will fail for "Hi"
 			Const("Hi")    // Const value is "Hi" (2 chars)
 
 		result := schema.Parse("Hi", ctx)
-		// Current implementation validates all constraints, so this will fail min length
 		if result.Valid {
-			t.Error("Current implementation: const value also validates other constraints, so 'Hi' fails min length")
+			t.Error("Expected the const value to fail min length")
 		}
 
 		result = schema.Parse("Hello", ctx)
@@ -621,12 +619,13 @@ func TestStringSchema_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("invalid regex pattern", func(t *testing.T) {
-		schema := String().Pattern("[") // Invalid regex
-
-		result := schema.Parse("test", ctx)
-		if result.Valid {
-			t.Error("Expected invalid result for invalid regex pattern")
-		}
+		// Patterns are compiled once when set, so an invalid regex fails fast
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for invalid regex pattern")
+			}
+		}()
+		String().Pattern("[") // Invalid regex
 	})
 
 	t.Run("empty enum array", func(t *testing.T) {
@@ -643,30 +642,70 @@ func TestStringSchema_EdgeCases(t *testing.T) {
 	t.Run("unicode strings", func(t *testing.T) {
 		schema := String().MinLength(2).MaxLength(5)
 
-		// Test unicode characters - Go uses rune count, not byte count for len()
+		// Lengths count runes by default, not bytes
 		unicodeTests := []struct {
 			value    string
 			expected bool
 		}{
-			{"🚀🌟", false},     // 2 unicode chars but longer byte representation may cause issues
-			{"café", true},    // 4 chars with accent
-			{"测试", false},     // 2 Chinese characters but longer byte representation
+			{"🚀🌟", true},      // 2 runes, 8 bytes
+			{"café", true},    // 4 runes, 5 bytes
+			{"测试", true},      // 2 runes, 6 bytes
 			{"ab", true},      // 2 ASCII chars
 			{"hello", true},   // 5 ASCII chars
 			{"abcdef", false}, // 6 ASCII chars (above max)
+			{"😀", false},      // 1 rune (below min)
 		}
 
 		for _, tt := range unicodeTests {
 			result := schema.Parse(tt.value, ctx)
 			if result.Valid != tt.expected {
-				// Note: This documents current behavior with unicode handling
-				t.Logf("Unicode string '%s' (len=%d runes=%d): expected valid=%v, got %v",
+				t.Errorf("Unicode string '%s' (len=%d runes=%d): expected valid=%v, got %v",
 					tt.value, len(tt.value), len([]rune(tt.value)), tt.expected, result.Valid)
 			}
 		}
 	})
 }
 
+func TestStringSchema_LengthUnit(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		unit     LengthUnit
+		value    string
+		expected int
+	}{
+		{"runes ascii", LengthUnitRunes, "hello", 5},
+		{"runes accented", LengthUnitRunes, "café", 4},
+		{"runes combining mark", LengthUnitRunes, "cafe\u0301", 5},
+		{"bytes", LengthUnitBytes, "测试", 6},
+		{"graphemes combining mark", LengthUnitGraphemes, "cafe\u0301", 4},
+		{"graphemes flag", LengthUnitGraphemes, "🇩🇪🇫🇷", 2},
+		{"graphemes zwj family", LengthUnitGraphemes, "👨\u200D👩\u200D👧", 1},
+		{"graphemes skin tone", LengthUnitGraphemes, "👍🏽!", 2},
+		{"graphemes crlf", LengthUnitGraphemes, "a\r\nb", 3},
+		{"graphemes spacing mark", LengthUnitGraphemes, "\u0915\u093F\u0B95\u0BCA", 2},
+		{"graphemes hangul jamo", LengthUnitGraphemes, "\u1100\u1161\u11A8\u1100\u1161", 2},
+		{"graphemes hangul syllables", LengthUnitGraphemes, "\uD55C\uAD6D\uAC00\u11A8", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := String().LengthUnit(tt.unit).Length(tt.expected)
+			if result := schema.Parse(tt.value, ctx); !result.Valid {
+				t.Errorf("Parse(%q) in %s: expected length %d, got errors %v", tt.value, tt.unit, tt.expected, result.Errors)
+			}
+			if result := schema.Length(tt.expected+1).Parse(tt.value, ctx); result.Valid {
+				t.Errorf("Parse(%q) in %s: expected length %d to be rejected", tt.value, tt.unit, tt.expected+1)
+			}
+		})
+	}
+
+	if unit := String().GetLengthUnit(); unit != LengthUnitRunes {
+		t.Errorf("default GetLengthUnit() = %q, want %q", unit, LengthUnitRunes)
+	}
+}
+
 func TestStringSchema_DefaultValueHandling(t *testing.T) {
 	ctx := DefaultValidationContext()
 
@@ -728,12 +767,12 @@ func TestStringSchema_FormatValidationEdgeCases(t *testing.T) {
 		{
 			StringFormatIPv4,
 			[]string{"192.168.1.1", "0.0.0.0", "255.255.255.255"},
-			[]string{"192.168.1.256", "192.168.1", "not.an.ip"},
+			[]string{"192.168.1.256", "192.168.1", "not.an.ip", "::ffff:192.168.1.1"},
 		},
 		{
 			StringFormatIPv6,
-			[]string{"2001:0db8:85a3:0000:0000:8a2e:0370:7334", "::1", "::"},
-			[]string{"2001:0db8:85a3::8a2e::7334", "not:an:ipv6"},
+			[]string{"2001:0db8:85a3:0000:0000:8a2e:0370:7334", "::1", "::", "2001:db8::1", "fe80::1:2", "::ffff:192.168.1.1"},
+			[]string{"2001:0db8:85a3::8a2e::7334", "not:an:ipv6", "192.168.1.1"},
 		},
 		{
 			StringFormatHostname,
@@ -888,3 +927,187 @@ func TestStringSchema_ChainedDefaults(t *testing.T) {
 		}
 	})
 }
+
+func TestStringSchema_IdentifierFormats(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	formatTests := []struct {
+		schema       *StringSchema
+		validCases   []string
+		invalidCases []string
+	}{
+		{
+			String().Slug(),
+			[]string{"hello", "hello-world", "release-2-0", "a1"},
+			[]string{"Hello", "hello--world", "-hello", "hello-", "hello_world", "héllo", "hello world"},
+		},
+		{
+			String().Identifier(),
+			[]string{"userID", "_tmp", "x", "snake_case_2"},
+			[]string{"2fast", "kebab-case", "$jq", "naïve", "has space"},
+		},
+		{
+			String().Alphanumeric(),
+			[]string{"abc123", "ABC", "007"},
+			[]string{"abc-123", "abc_123", "ünïcode", "a b"},
+		},
+		{
+			String().ASCII(),
+			[]string{"plain text", "tab\tand~symbols!"},
+			[]string{"café", "emoji 🙂"},
+		},
+	}
+
+	for _, tt := range formatTests {
+		t.Run(string(*tt.schema.GetFormat()), func(t *testing.T) {
+			for _, valid := range tt.validCases {
+				if result := tt.schema.Parse(valid, ctx); !result.Valid {
+					t.Errorf("Expected %q to be valid, got %v", valid, result.Errors)
+				}
+			}
+			for _, invalid := range tt.invalidCases {
+				result := tt.schema.Parse(invalid, ctx)
+				if result.Valid || result.Errors[0].Code != "format" {
+					t.Errorf("Expected %q to fail with format, got %v", invalid, result.Errors)
+				}
+			}
+		})
+	}
+
+	// Non-standard formats also emit a pattern other validators understand
+	json := String().Slug().JSON()
+	if json["format"] != "slug" || json["pattern"] != "^[a-z0-9]+(-[a-z0-9]+)*$" {
+		t.Errorf("unexpected JSON %v", json)
+	}
+	json = String().Slug().Pattern("^post-").JSON()
+	if json["pattern"] != "^post-" {
+		t.Errorf("explicit pattern should win, got %v", json["pattern"])
+	}
+}
+
+func TestStringSchema_NoControlChars(t *testing.T) {
+	ctx := DefaultValidationContext()
+	schema := String().NoControlChars()
+
+	for _, valid := range []string{"Jane Doe", "Zoë 🙂"} {
+		if result := schema.Parse(valid, ctx); !result.Valid {
+			t.Errorf("Expected %q to be valid, got %v", valid, result.Errors)
+		}
+	}
+	for _, invalid := range []string{"line\nbreak", "tab\tbed", "nul\x00byte", "esc\x1b[31m", "c1\u0085"} {
+		result := schema.Parse(invalid, ctx)
+		if result.Valid || result.Errors[0].Code != "control_chars" {
+			t.Errorf("Expected %q to fail with control_chars, got %v", invalid, result.Errors)
+		}
+	}
+
+	result := String().NoControlChars("single line only").Parse("a\nb", ctx)
+	if result.Valid || result.Errors[0].Message != "single line only" {
+		t.Errorf("Expected custom message, got %v", result.Errors)
+	}
+}
+
+func TestStringSchema_NonEmpty(t *testing.T) {
+	legacy := DefaultValidationContext()
+	presence := DefaultValidationContext().WithEmptyIsPresent()
+
+	tests := []struct {
+		name   string
+		schema *StringSchema
+		ctx    *ValidationContext
+		value  interface{}
+		code   ErrorCode // Empty when valid
+	}{
+		{"required rejects empty by default", String(), legacy, "", CodeRequired},
+		{"required accepts empty when present", String(), presence, "", ""},
+		{"required still rejects nil", String(), presence, nil, CodeRequired},
+		{"non-empty rejects empty", String().NonEmpty(), presence, "", CodeMinLength},
+		{"non-empty optional rejects empty", String().Optional().NonEmpty(), legacy, "", CodeMinLength},
+		{"non-empty optional accepts nil", String().Optional().NonEmpty(), legacy, nil, ""},
+		{"non-empty accepts text", String().NonEmpty(), presence, "a", ""},
+		{"trimmed blank is empty", String().Trim().NonEmpty(), presence, "   ", CodeMinLength},
+		{"empty checked against constraints", String().Email(), presence, "", CodeFormat},
+		{"empty optional default not used", String().Optional().Default("x"), presence, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, tt.ctx)
+			if tt.code == "" {
+				if !result.Valid {
+					t.Fatalf("Parse(%q) errors = %v, want valid", tt.value, result.Errors)
+				}
+				if result.Value != tt.value {
+					t.Errorf("Parse(%q) = %v, want it unchanged", tt.value, result.Value)
+				}
+				return
+			}
+			if result.Valid || result.Errors[0].Code != tt.code {
+				t.Errorf("Parse(%q) errors = %v, want %s", tt.value, result.Errors, tt.code)
+			}
+		})
+	}
+
+	result := String().NonEmpty("say something").Parse("", presence)
+	if result.Valid || result.Errors[0].Message != "say something" {
+		t.Errorf("Expected custom message, got %v", result.Errors)
+	}
+	if got := String().NonEmpty().JSON()["minLength"]; got != 1 {
+		t.Errorf("JSON minLength = %v, want 1", got)
+	}
+	if err := String().NonEmpty().MaxLength(0).Validate(); err == nil {
+		t.Error("Validate() = nil, want the NonEmpty and maxLength 0 contradiction")
+	}
+}
+
+func TestStringSchema_Normalization(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	tests := []struct {
+		name     string
+		schema   *StringSchema
+		input    string
+		expected interface{}
+		code     ErrorCode
+	}{
+		{"trim", String().Trim(), "  hello \n", "hello", ""},
+		{"lowercase", String().Lowercase(), "Hello", "hello", ""},
+		{"uppercase", String().Uppercase(), "eur", "EUR", ""},
+		{"collapse", String().CollapseWhitespace(), "  many   spaced\t\nwords ", "many spaced words", ""},
+		{"nfc", String().NormalizeUnicode(NFC), "Jose\u0301", "Jos\u00e9", ""},
+		{"nfkc", String().NormalizeUnicode(NFKC), "\uff2a\uff4f\uff45", "Joe", ""},
+		{"strip html", String().StripHTML(), "<p>Hello <b>world</b></p>", "Hello world", ""},
+		{"strip script", String().StripHTML(), "hi<script>alert('<b>x</b>')</script>!", "hi!", ""},
+		{"strip style", String().StripHTML(), "<STYLE>p{}</STYLE >text", "text", ""},
+		{"strip comment", String().StripHTML(), "a<!-- <b> -->b", "ab", ""},
+		{"quoted attribute", String().StripHTML(), `<a title="1 > 0">link</a>`, "link", ""},
+		{"less than kept", String().StripHTML(), "1 < 2 & 3 <= 4", "1 < 2 & 3 <= 4", ""},
+		{"entities kept", String().StripHTML(), "Tom &amp; Jerry", "Tom &amp; Jerry", ""},
+		{"unterminated tag", String().StripHTML(), "safe<img src=x onerror=alert(1)", "safe", ""},
+		{"pipeline order", String().StripHTML().CollapseWhitespace(), "<p>one</p>\n<p>two</p>", "one two", ""},
+		{"constraints see normalized value", String().Trim().MaxLength(3), "  abc  ", "abc", ""},
+		{"pattern after lowercase", String().Lowercase().Slug(), "Hello-World", "hello-world", ""},
+		{"enum after trim", String().Trim().Enum([]string{"a", "b"}), " a ", "a", ""},
+		{"blank is required", String().Trim(), "   ", nil, "required"},
+		{"blank optional", String().Trim().Optional(), "   ", "", ""},
+		{"html only is required", String().StripHTML(), "<br/>", nil, "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.input, ctx)
+			if tt.code != "" {
+				if result.Valid || result.Errors[0].Code != tt.code {
+					t.Fatalf("Expected %s error, got %v", tt.code, result.Errors)
+				}
+				return
+			}
+			if !result.Valid {
+				t.Fatalf("Expected valid result, got %v", result.Errors)
+			}
+			if result.Value != tt.expected {
+				t.Errorf("Value = %q, want %q", result.Value, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,526 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct tag keys recognised by FromStruct
+//
+//	type SignupRequest struct {
+//	    Email    string   `json:"email" schema:"required,format=email"`
+//	    Username string   `json:"username" schema:"required,min=3,max=50,pattern=^[a-z0-9_]+$"`
+//	    Age      *int     `json:"age" schema:"min=13,max=120"`
+//	    Tags     []string `json:"tags" schema:"max=10"`
+//	    Role     string   `json:"role" schema:"enum=admin|member,default=member"`
+//	}
+//
+// Supported options: name, required, optional, nullable, min, max, pattern, format,
+// enum (values separated by "|"), default, title, description. The name option
+// replaces the property name of the json tag (`schema:"name=email,required"`). A tag of "-"
+// skips validation of the field. min/max apply to the length of strings, the
+// bounds of numbers and the item count of slices. Since options are comma
+// separated, patterns cannot contain commas.
+const structTagName = "schema"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// structTagOptions holds the parsed options of a single `schema` struct tag
+type structTagOptions struct {
+	field       string // The Go name of the field, for the messages of invalid options
+	skip        bool
+	name        string
+	required    bool
+	optional    bool
+	nullable    bool
+	min         *string
+	max         *string
+	pattern     string
+	format      string
+	enum        []string
+	defaultVal  *string
+	title       string
+	description string
+}
+
+// parseStructTag parses a `schema:"..."` struct tag into its options
+func parseStructTag(tag string) structTagOptions {
+	var opts structTagOptions
+	if tag == "-" {
+		opts.skip = true
+		return opts
+	}
+
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "name":
+			opts.name = value
+		case "required":
+			opts.required = true
+		case "optional":
+			opts.optional = true
+		case "nullable":
+			opts.nullable = true
+		case "min":
+			opts.min = &value
+		case "max":
+			opts.max = &value
+		case "pattern":
+			opts.pattern = value
+		case "format":
+			opts.format = value
+		case "enum":
+			opts.enum = strings.Split(value, "|")
+		case "default":
+			opts.defaultVal = &value
+		case "title":
+			opts.title = value
+		case "description":
+			opts.description = value
+		}
+	}
+	return opts
+}
+
+// FromStruct builds an ObjectSchema by reflecting over a Go struct (or pointer to struct).
+// Property names follow the json tag of each exported field, constraints are read from the
+// `schema` tag. Nested structs, slices, arrays, maps and pointers are handled recursively,
+// and the fields of embedded structs are promoted as with encoding/json.
+// FromStruct panics if v is not a struct or pointer to struct, and on min, max, enum
+// and default options that are not numbers or booleans of the type of their field.
+func FromStruct(v interface{}) *ObjectSchema {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("schema: FromStruct expects a struct, got %T", v))
+	}
+	return structSchema(t, map[reflect.Type]*ObjectSchema{})
+}
+
+// structSchema builds an object schema for a struct type
+func structSchema(t reflect.Type, building map[reflect.Type]*ObjectSchema) *ObjectSchema {
+	obj := Object()
+	building[t] = obj
+	defer delete(building, t)
+
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Embedded structs without an explicit name are flattened, as with encoding/json
+		if jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ","); field.Anonymous && jsonName == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, field)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		opts := parseStructTag(field.Tag.Get(structTagName))
+		opts.field = t.Name() + "." + field.Name
+
+		name := field.Name
+		if jsonTag := field.Tag.Get("json"); jsonTag != "" {
+			jsonName, _, _ := strings.Cut(jsonTag, ",")
+			if jsonName == "-" {
+				continue
+			}
+			if jsonName != "" {
+				name = jsonName
+			}
+		}
+		if opts.name != "" {
+			name = opts.name
+		}
+
+		if opts.skip {
+			// Keep the property known so strict objects still accept it, but don't validate it
+			obj.OptionalProperty(name, Any())
+			continue
+		}
+
+		fieldSchema := typeSchema(field.Type, opts, building)
+		if opts.required && !opts.optional {
+			obj.RequiredProperty(name, fieldSchema)
+		} else {
+			obj.OptionalProperty(name, fieldSchema)
+		}
+	}
+
+	// Promoted fields never replace the fields of the struct itself. The fields of a
+	// nil embedded pointer are absent, so they are optional.
+	for _, field := range embedded {
+		ft, pointer := field.Type, field.Type.Kind() == reflect.Ptr
+		if pointer {
+			ft = ft.Elem()
+		}
+		if building[ft] != nil {
+			continue
+		}
+		inner := structSchema(ft, building)
+		for _, name := range sortedPropertyNames(inner.properties) {
+			if _, exists := obj.properties[name]; exists {
+				continue
+			}
+			if prop := inner.properties[name]; prop.Required && !pointer {
+				obj.RequiredProperty(name, prop.Schema)
+			} else {
+				obj.OptionalProperty(name, prop.Schema)
+			}
+		}
+	}
+	return obj
+}
+
+// typeSchema builds the schema for a Go type and applies the tag options to it
+func typeSchema(t reflect.Type, opts structTagOptions, building map[reflect.Type]*ObjectSchema) Parseable {
+	// Pointers are nullable: a nil pointer is a valid "absent" value
+	if t.Kind() == reflect.Ptr {
+		opts.nullable = true
+		return typeSchema(t.Elem(), opts, building)
+	}
+
+	var result Parseable
+	switch {
+	case t == timeType:
+		result = DateTime()
+	case t.Kind() == reflect.String:
+		str := String()
+		if opts.pattern != "" {
+			str.Pattern(opts.pattern)
+		}
+		if opts.format != "" {
+			str.Format(StringFormat(opts.format))
+		}
+		if len(opts.enum) > 0 {
+			str.Enum(opts.enum)
+		}
+		if opts.defaultVal != nil {
+			str.Default(*opts.defaultVal)
+		}
+		result = str
+	case t.Kind() == reflect.Bool:
+		b := Bool()
+		if opts.defaultVal != nil {
+			val, err := strconv.ParseBool(*opts.defaultVal)
+			if err != nil {
+				opts.invalid("default", *opts.defaultVal, err)
+			}
+			b.Default(val)
+		}
+		result = b
+	case isUintKind(t.Kind()):
+		result = uintSchema(t, opts)
+	case isIntKind(t.Kind()):
+		result = intSchema(t, opts)
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		n := Number()
+		if opts.defaultVal != nil {
+			val, err := strconv.ParseFloat(*opts.defaultVal, 64)
+			if err != nil {
+				opts.invalid("default", *opts.defaultVal, err)
+			}
+			n.Default(val)
+		}
+		result = n
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		// []byte is encoded as base64 by encoding/json
+		result = Base64()
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		result = Array(typeSchema(t.Elem(), structTagOptions{required: true}, building))
+	case t.Kind() == reflect.Map:
+		result = Record(String(), typeSchema(t.Elem(), structTagOptions{required: true}, building))
+	case t.Kind() == reflect.Struct:
+		if obj, ok := building[t]; ok {
+			// Self-referential struct: refer back to the schema being built
+			result = Lazy(func() Parseable { return obj })
+		} else {
+			result = structSchema(t, building)
+		}
+	default:
+		result = Any()
+	}
+
+	applyStructTagOptions(result, opts)
+	return result
+}
+
+// applyStructTagOptions applies the generic tag options through the setter interfaces
+func applyStructTagOptions(s Parseable, opts structTagOptions) {
+	if opts.title != "" {
+		if setter, ok := s.(SetTitle); ok {
+			setter.SetTitle(opts.title)
+		}
+	}
+	if opts.description != "" {
+		if setter, ok := s.(SetDescription); ok {
+			setter.SetDescription(opts.description)
+		}
+	}
+
+	if opts.required && !opts.optional {
+		if setter, ok := s.(SetRequired); ok {
+			setter.SetRequired()
+		}
+	} else if setter, ok := s.(SetOptional); ok {
+		setter.SetOptional()
+	}
+
+	if opts.nullable {
+		if setter, ok := s.(SetNullable); ok {
+			setter.SetNullable()
+		}
+	}
+
+	if opts.min != nil {
+		applyStructTagBound(s, opts, *opts.min, true)
+	}
+	if opts.max != nil {
+		applyStructTagBound(s, opts, *opts.max, false)
+	}
+}
+
+// applyStructTagBound applies a min or max option to whichever bound the schema
+// supports. It panics when the bound is not a number of the kind the schema expects.
+func applyStructTagBound(s Parseable, opts structTagOptions, raw string, isMin bool) {
+	option := "max"
+	if isMin {
+		option = "min"
+	}
+	atoi := func() int {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			opts.invalid(option, raw, err)
+		}
+		return n
+	}
+	parseFloat := func() float64 {
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			opts.invalid(option, raw, err)
+		}
+		return f
+	}
+
+	if isMin {
+		switch setter := s.(type) {
+		case SetMinLength:
+			setter.SetMinLength(atoi())
+		case SetMinimum:
+			setter.SetMinimum(atoi())
+		case SetMinimumFloat:
+			setter.SetMinimumFloat(parseFloat())
+		case SetMinItems:
+			setter.SetMinItems(atoi())
+		}
+		return
+	}
+	switch setter := s.(type) {
+	case SetMaxLength:
+		setter.SetMaxLength(atoi())
+	case SetMaximum:
+		setter.SetMaximum(atoi())
+	case SetMaximumFloat:
+		setter.SetMaximumFloat(parseFloat())
+	case SetMaxItems:
+		setter.SetMaxItems(atoi())
+	}
+}
+
+// invalid panics on a tag option whose value cannot be parsed for the type of the field
+func (o structTagOptions) invalid(option, raw string, err error) {
+	if numErr, ok := err.(*strconv.NumError); ok {
+		err = numErr.Err
+	}
+	panic(fmt.Sprintf("schema: invalid %s=%s in the schema tag of %s: %v", option, raw, o.field, err))
+}
+
+// uintSchema builds the unsigned schema matching the width of an unsigned integer type
+func uintSchema(t reflect.Type, opts structTagOptions) Parseable {
+	var values []uint64
+	for _, e := range opts.enum {
+		val, err := strconv.ParseUint(e, 10, t.Bits())
+		if err != nil {
+			opts.invalid("enum", e, err)
+		}
+		values = append(values, val)
+	}
+	var defaultVal interface{}
+	if opts.defaultVal != nil {
+		val, err := strconv.ParseUint(*opts.defaultVal, 10, t.Bits())
+		if err != nil {
+			opts.invalid("default", *opts.defaultVal, err)
+		}
+		defaultVal = val
+	}
+
+	switch t.Kind() {
+	case reflect.Uint8:
+		s := Uint8()
+		if len(values) > 0 {
+			s.Enum(uintsOf[uint8](values))
+		}
+		if defaultVal != nil {
+			s.Default(defaultVal)
+		}
+		return s
+	case reflect.Uint16:
+		s := Uint16()
+		if len(values) > 0 {
+			s.Enum(uintsOf[uint16](values))
+		}
+		if defaultVal != nil {
+			s.Default(defaultVal)
+		}
+		return s
+	case reflect.Uint32:
+		s := Uint32()
+		if len(values) > 0 {
+			s.Enum(uintsOf[uint32](values))
+		}
+		if defaultVal != nil {
+			s.Default(defaultVal)
+		}
+		return s
+	case reflect.Uint64:
+		s := Uint64()
+		if len(values) > 0 {
+			s.Enum(values)
+		}
+		if defaultVal != nil {
+			s.Default(defaultVal)
+		}
+		return s
+	default:
+		s := Uint()
+		if len(values) > 0 {
+			s.Enum(uintsOf[uint](values))
+		}
+		if defaultVal != nil {
+			s.Default(defaultVal)
+		}
+		return s
+	}
+}
+
+// intSchema builds the schema of a signed integer type, bounded to the range of its
+// width, with the enum and default options parsed at that width
+func intSchema(t reflect.Type, opts structTagOptions) Parseable {
+	var values []int64
+	for _, e := range opts.enum {
+		val, err := strconv.ParseInt(e, 10, t.Bits())
+		if err != nil {
+			opts.invalid("enum", e, err)
+		}
+		values = append(values, val)
+	}
+	var defaultVal interface{}
+	if opts.defaultVal != nil {
+		val, err := strconv.ParseInt(*opts.defaultVal, 10, t.Bits())
+		if err != nil {
+			opts.invalid("default", *opts.defaultVal, err)
+		}
+		defaultVal = val
+	}
+
+	switch t.Kind() {
+	case reflect.Int8:
+		s := Int8()
+		if len(values) > 0 {
+			s.Enum(intsOf[int8](values))
+		}
+		if defaultVal != nil {
+			s.Default(defaultVal)
+		}
+		return s
+	case reflect.Int16:
+		s := Int16()
+		if len(values) > 0 {
+			s.Enum(intsOf[int16](values))
+		}
+		if defaultVal != nil {
+			s.Default(defaultVal)
+		}
+		return s
+	case reflect.Int32:
+		s := Int32()
+		if len(values) > 0 {
+			s.Enum(intsOf[int32](values))
+		}
+		if defaultVal != nil {
+			s.Default(defaultVal)
+		}
+		return s
+	case reflect.Int64:
+		s := Int64()
+		if len(values) > 0 {
+			s.Enum(values)
+		}
+		if defaultVal != nil {
+			s.Default(defaultVal)
+		}
+		return s
+	default:
+		s := Int()
+		if len(values) > 0 {
+			s.Enum(intsOf[int](values))
+		}
+		if defaultVal != nil {
+			s.Default(int(defaultVal.(int64)))
+		}
+		return s
+	}
+}
+
+// intsOf converts parsed enum values to the width of the target schema
+func intsOf[T int | int8 | int16 | int32](values []int64) []T {
+	out := make([]T, len(values))
+	for i, v := range values {
+		out[i] = T(v)
+	}
+	return out
+}
+
+// uintsOf converts parsed enum values to the width of the target schema
+func uintsOf[T uint | uint8 | uint16 | uint32](values []uint64) []T {
+	out := make([]T, len(values))
+	for i, v := range values {
+		out[i] = T(v)
+	}
+	return out
+}
+
+// isIntKind reports whether the kind is a signed or unsigned integer
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return isUintKind(k)
+}
+
+// isUintKind reports whether the kind is an unsigned integer
+func isUintKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,312 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+type structTestAddress struct {
+	Street string `json:"street" schema:"required,min=1"`
+	Zip    string `json:"zip" schema:"pattern=^[0-9]{5}$"`
+}
+
+type structTestUser struct {
+	Email    string             `json:"email" schema:"required,format=email"`
+	Username string             `json:"username" schema:"required,min=3,max=50"`
+	Age      *int               `json:"age" schema:"min=13,max=120"`
+	Role     string             `json:"role" schema:"enum=admin|member"`
+	Tags     []string           `json:"tags" schema:"max=3"`
+	Address  *structTestAddress `json:"address"`
+	Meta     map[string]int     `json:"meta,omitempty"`
+	Internal string             `json:"-"`
+	Note     string             `json:"note" schema:"-"`
+}
+
+func TestFromStruct_Shape(t *testing.T) {
+	s := FromStruct(structTestUser{})
+
+	props := s.GetProperties()
+	for _, name := range []string{"email", "username", "age", "role", "tags", "address", "meta", "note"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("expected property %q", name)
+		}
+	}
+	if _, ok := props["Internal"]; ok {
+		t.Error("json:\"-\" field should not be a property")
+	}
+
+	required := s.GetRequiredProperties()
+	if len(required) != 2 || required[0] != "email" || required[1] != "username" {
+		t.Errorf("unexpected required properties: %v", required)
+	}
+
+	username := props["username"].Schema.(*StringSchema)
+	if username.GetMinLength() == nil || *username.GetMinLength() != 3 {
+		t.Error("expected username minLength 3")
+	}
+	if !props["age"].Schema.(*IntSchema).IsNullable() {
+		t.Error("pointer field should be nullable")
+	}
+	if _, ok := props["address"].Schema.(*ObjectSchema); !ok {
+		t.Error("nested struct should be an object schema")
+	}
+}
+
+func TestFromStruct_Parse(t *testing.T) {
+	ctx := DefaultValidationContext()
+	s := FromStruct(&structTestUser{})
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{"valid map", map[string]interface{}{
+			"email":    "john@example.com",
+			"username": "john",
+			"age":      30.0,
+			"tags":     []interface{}{"a", "b"},
+			"address":  map[string]interface{}{"street": "Main St", "zip": "12345"},
+		}, true},
+		{"missing required", map[string]interface{}{"username": "john"}, false},
+		{"invalid email", map[string]interface{}{"email": "nope", "username": "john"}, false},
+		{"age below minimum", map[string]interface{}{"email": "john@example.com", "username": "john", "age": 5}, false},
+		{"bad enum", map[string]interface{}{"email": "john@example.com", "username": "john", "role": "root"}, false},
+		{"too many tags", map[string]interface{}{"email": "john@example.com", "username": "john", "tags": []interface{}{"a", "b", "c", "d"}}, false},
+		{"invalid nested zip", map[string]interface{}{
+			"email":    "john@example.com",
+			"username": "john",
+			"address":  map[string]interface{}{"street": "Main St", "zip": "abc"},
+		}, false},
+		{"null pointer field", map[string]interface{}{"email": "john@example.com", "username": "john", "age": nil}, true},
+		{"struct value", structTestUser{Email: "john@example.com", Username: "john", Role: "admin", Internal: "x"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Parse(tt.value, ctx)
+			if result.Valid != tt.expected {
+				t.Errorf("FromStruct.Parse(%v) = %v, want %v. Errors: %v", tt.value, result.Valid, tt.expected, result.Errors)
+			}
+		})
+	}
+}
+
+type structTestBase struct {
+	ID      string `json:"id" schema:"required,min=3"`
+	Created string `json:"created"`
+}
+
+type structTestAudit struct {
+	By string `json:"by" schema:"required"`
+}
+
+type structTestAccount struct {
+	structTestBase
+	*structTestAudit
+	Name    string `json:"name" schema:"required"`
+	Created int    `json:"created"` // Shadows the promoted field
+}
+
+func TestFromStruct_Embedded(t *testing.T) {
+	ctx := DefaultValidationContext()
+	s := FromStruct(structTestAccount{})
+
+	if got, want := sortedPropertyNames(s.properties), []string{"by", "created", "id", "name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("properties = %v, want %v", got, want)
+	}
+	if _, ok := s.properties["created"].Schema.(*IntSchema); !ok {
+		t.Errorf("created = %T, want the field of the struct itself", s.properties["created"].Schema)
+	}
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  []string
+	}{
+		{"own struct", structTestAccount{structTestBase: structTestBase{ID: "acc-1"}, Name: "ada"}, []string{}},
+		{"embedded pointer", structTestAccount{structTestBase: structTestBase{ID: "acc-1"}, structTestAudit: &structTestAudit{By: "root"}, Name: "ada"}, []string{}},
+		{"promoted constraint", structTestAccount{structTestBase: structTestBase{ID: "a"}, Name: "ada"}, []string{"id min_length", "id property_invalid"}},
+		{"promoted required", map[string]interface{}{"name": "ada"}, []string{"id required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorKeys(s.Parse(tt.value, ctx).Errors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type structTestNode struct {
+	Name     string           `json:"name" schema:"required,min=1"`
+	Next     *structTestNode  `json:"next"`
+	Children []structTestNode `json:"children"`
+}
+
+func TestFromStruct_Recursive(t *testing.T) {
+	ctx := DefaultValidationContext()
+	s := FromStruct(structTestNode{})
+
+	valid := map[string]interface{}{
+		"name": "root",
+		"next": map[string]interface{}{"name": "second", "next": nil},
+		"children": []interface{}{
+			map[string]interface{}{"name": "child"},
+		},
+	}
+	if result := s.Parse(valid, ctx); !result.Valid {
+		t.Errorf("expected valid recursive value, got %v", result.Errors)
+	}
+
+	invalid := map[string]interface{}{
+		"name": "root",
+		"next": map[string]interface{}{"name": ""},
+	}
+	if result := s.Parse(invalid, ctx); result.Valid {
+		t.Error("expected nested recursive value to be validated")
+	}
+}
+
+func TestFromStruct_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for non-struct input")
+		}
+	}()
+	FromStruct(42)
+}
+
+func TestFromStruct_InvalidTagOptions(t *testing.T) {
+	type BadMin struct {
+		Name string `json:"name" schema:"min=three"`
+	}
+	type BadMax struct {
+		Score float64 `json:"score" schema:"max=high"`
+	}
+	type BadEnum struct {
+		Level int8 `json:"level" schema:"enum=1|200"`
+	}
+	type BadDefault struct {
+		Port uint16 `json:"port" schema:"default=-1"`
+	}
+	type BadBool struct {
+		Debug bool `json:"debug" schema:"default=yes"`
+	}
+
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{BadMin{}, "schema: invalid min=three in the schema tag of BadMin.Name: invalid syntax"},
+		{BadMax{}, "schema: invalid max=high in the schema tag of BadMax.Score: invalid syntax"},
+		{BadEnum{}, "schema: invalid enum=200 in the schema tag of BadEnum.Level: value out of range"},
+		{BadDefault{}, "schema: invalid default=-1 in the schema tag of BadDefault.Port: invalid syntax"},
+		{BadBool{}, "schema: invalid default=yes in the schema tag of BadBool.Debug: invalid syntax"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			defer func() {
+				if got := recover(); got != tt.want {
+					t.Errorf("panic = %v, want %q", got, tt.want)
+				}
+			}()
+			FromStruct(tt.value)
+		})
+	}
+}
+
+func TestFromStruct_Unsigned(t *testing.T) {
+	type Server struct {
+		Port    uint16 `json:"port" schema:"required,min=1"`
+		Workers uint   `json:"workers" schema:"default=4"`
+		Mode    uint8  `json:"mode" schema:"enum=1|2"`
+	}
+	s := FromStruct(Server{})
+	ctx := DefaultValidationContext()
+
+	result := s.Parse(Server{Port: 8080, Mode: 2}, ctx)
+	if !result.Valid {
+		t.Fatalf("expected struct with unsigned fields to be valid, got %v", result.Errors)
+	}
+	result = s.Parse(map[string]interface{}{"port": 0.0, "mode": 3.0}, ctx)
+	codes := map[ErrorCode]bool{}
+	for _, err := range result.Errors {
+		codes[err.Code] = true
+	}
+	if result.Valid || !codes["minimum"] || !codes["enum"] {
+		t.Errorf("expected minimum and enum errors, got %v", result.Errors)
+	}
+	if result := s.Parse(map[string]interface{}{"port": -1.0}, ctx); result.Valid {
+		t.Error("expected negative port to be rejected")
+	}
+	value := s.Parse(map[string]interface{}{"port": 80.0, "workers": nil}, ctx).Value.(map[string]interface{})
+	if value["workers"] != uint(4) {
+		t.Errorf("workers default = %#v, want uint(4)", value["workers"])
+	}
+}
+
+func TestFromStruct_Signed(t *testing.T) {
+	type Sensor struct {
+		Level  int8  `json:"level" schema:"default=-1"`
+		Offset int16 `json:"offset" schema:"min=-10"`
+		Code   int32 `json:"code" schema:"enum=1|2"`
+		Total  int64 `json:"total"`
+	}
+	s := FromStruct(Sensor{})
+	ctx := DefaultValidationContext()
+
+	if result := s.Parse(Sensor{Level: 3, Offset: -2, Code: 1, Total: 1 << 40}, ctx); !result.Valid {
+		t.Fatalf("expected struct with signed fields to be valid, got %v", result.Errors)
+	}
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{"level": 1.0, "offset": 0.0, "code": 2.0, "total": 0.0}
+	}
+	for field, value := range map[string]interface{}{
+		"level":  200.0,
+		"offset": 40000.0,
+		"code":   3.0,
+		"total":  1e19,
+	} {
+		input := valid()
+		input[field] = value
+		if result := s.Parse(input, ctx); result.Valid {
+			t.Errorf("expected %s = %v to be rejected", field, value)
+		}
+	}
+	input := valid()
+	input["offset"] = -11.0
+	if result := s.Parse(input, ctx); result.Valid {
+		t.Error("expected offset below the min option to be rejected")
+	}
+	input = valid()
+	delete(input, "level")
+	value := s.Parse(input, ctx).Value.(map[string]interface{})
+	if value["level"] != int8(-1) {
+		t.Errorf("level default = %#v, want int8(-1)", value["level"])
+	}
+}
+
+func TestFromStruct_NameOption(t *testing.T) {
+	type Contact struct {
+		Email string `json:"email" schema:"name=emailAddress,required"`
+		Phone string `schema:"name=phone"`
+	}
+
+	s := FromStruct(Contact{})
+	props := s.GetProperties()
+	for _, name := range []string{"emailAddress", "phone"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("expected property %q", name)
+		}
+	}
+	if _, ok := props["email"]; ok {
+		t.Error("the name option should replace the json name")
+	}
+
+	ctx := DefaultValidationContext().WithTagNames("schema", "json")
+	if result := s.Parse(Contact{Email: "ada@example.com", Phone: "1"}, ctx); !result.Valid {
+		t.Errorf("struct named by the schema tag rejected: %v", result.Errors)
+	}
+}
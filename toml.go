@@ -0,0 +1,178 @@
+package schema
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/nyxstack/i18n"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+func tomlDecodeError(err error) i18n.TranslatedFunc {
+	return i18n.F("invalid TOML: %v", err)
+}
+
+// ParseTOML decodes a TOML document and validates it against s. Integers decode as
+// int, and dates and times are kept as strings (RFC 3339 for offset date-times) so
+// Date, DateTime and Time schemas can validate them.
+//
+// Errors carry the Location of the offending key in the document, prefixed with
+// ctx.Source, so they print as "config.toml:12:3: value must be at least 1". A document
+// that cannot be decoded fails with code "invalid_toml".
+func ParseTOML(s Parseable, data []byte, ctx *ValidationContext) ParseResult {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		var loc *Location
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			line, column := decodeErr.Position()
+			loc = &Location{Line: line, Column: column}
+		}
+		return documentError(tomlDecodeError(err)(ctx.Locale), CodeInvalidTOML, loc, ctx.Source)
+	}
+
+	result := s.Parse(normalizeTOML(doc), ctx)
+	return tomlLocations(data).attach(result, ctx.Source)
+}
+
+// ParseTOML decodes a TOML document and validates it against the object schema
+func (s *ObjectSchema) ParseTOML(data []byte, ctx *ValidationContext) ParseResult {
+	return ParseTOML(s, data, ctx)
+}
+
+// normalizeTOML converts decoded TOML values to the types used by the rest of the package
+func normalizeTOML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeTOML(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeTOML(item)
+		}
+		return v
+	case int64:
+		if v >= math.MinInt && v <= math.MaxInt {
+			return int(v)
+		}
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case toml.LocalDate:
+		return v.String()
+	case toml.LocalTime:
+		return v.String()
+	case toml.LocalDateTime:
+		return v.String()
+	}
+	return value
+}
+
+// tomlLocations records the position of every key in a TOML document
+func tomlLocations(data []byte) sourceLocations {
+	locs := sourceLocations{}
+	arrayTables := map[string]int{} // Number of [[tables]] seen per path
+	var table Path
+
+	// resolve turns dotted keys into a path, stepping into the last element of any
+	// array of tables along the way
+	resolve := func(base Path, keys []string) Path {
+		path := append(Path{}, base...)
+		for _, key := range keys {
+			path = path.Field(key)
+			if count, ok := arrayTables[path.JSONPointer()]; ok {
+				path = path.Index(count - 1)
+			}
+		}
+		return path
+	}
+
+	var p unstable.Parser
+	p.Reset(data)
+	for p.NextExpression() {
+		expr := p.Expression()
+		switch expr.Kind {
+		case unstable.Table:
+			keys, first := tomlKeys(expr)
+			table = resolve(nil, keys)
+			locs.add(table, tomlNodeLocation(data, first))
+
+		case unstable.ArrayTable:
+			keys, first := tomlKeys(expr)
+			array := resolve(nil, keys[:len(keys)-1]).Field(keys[len(keys)-1])
+			count := arrayTables[array.JSONPointer()]
+			arrayTables[array.JSONPointer()] = count + 1
+			table = array.Index(count)
+			locs.add(array, tomlNodeLocation(data, first))
+			locs.add(table, tomlNodeLocation(data, first))
+
+		case unstable.KeyValue:
+			tomlKeyValueLocations(data, locs, table, expr)
+		}
+	}
+	return locs
+}
+
+// tomlKeyValueLocations records the position of a key/value pair below table,
+// including the keys of inline tables and the elements of arrays in its value
+func tomlKeyValueLocations(data []byte, locs sourceLocations, table Path, expr *unstable.Node) {
+	keys, first := tomlKeys(expr)
+	loc := tomlNodeLocation(data, first)
+	path := append(Path{}, table...)
+	for _, key := range keys {
+		path = path.Field(key)
+		locs.add(path, loc)
+	}
+	tomlValueLocations(data, locs, path, expr.Value(), loc)
+}
+
+// tomlValueLocations records the positions inside an inline table or array value
+func tomlValueLocations(data []byte, locs sourceLocations, path Path, value *unstable.Node, fallback Location) {
+	switch value.Kind {
+	case unstable.InlineTable:
+		it := value.Children()
+		for it.Next() {
+			if child := it.Node(); child.Kind == unstable.KeyValue {
+				tomlKeyValueLocations(data, locs, path, child)
+			}
+		}
+	case unstable.Array:
+		it := value.Children()
+		for i := 0; it.Next(); i++ {
+			item := it.Node()
+			loc := fallback
+			if item.Raw.Length > 0 {
+				loc = tomlNodeLocation(data, item)
+			}
+			locs.add(path.Index(i), loc)
+			tomlValueLocations(data, locs, path.Index(i), item, loc)
+		}
+	}
+}
+
+// tomlKeys returns the parts of the key of a table or key/value expression and its
+// first key node
+func tomlKeys(expr *unstable.Node) ([]string, *unstable.Node) {
+	var keys []string
+	var first *unstable.Node
+	it := expr.Key()
+	for it.Next() {
+		if first == nil {
+			first = it.Node()
+		}
+		keys = append(keys, string(it.Node().Data))
+	}
+	return keys, first
+}
+
+// tomlNodeLocation returns the position at which a node starts
+func tomlNodeLocation(data []byte, n *unstable.Node) Location {
+	if n == nil {
+		return Location{}
+	}
+	return offsetLocation(data, int(n.Raw.Offset))
+}
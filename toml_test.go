@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	config := Object().
+		Property("title", String()).
+		Property("released", Date()).
+		Property("server", Object().
+			Property("port", Int().Min(1)).
+			Property("hosts", Array(String().MinLength(1)))).
+		Property("plugins", Array(Object().Property("name", String()).Property("weight", Int().Min(0))).Optional())
+
+	data := []byte(`title = "demo"
+released = 2024-05-01
+
+[server]
+port = 8080
+hosts = ["a", "b"]
+
+[[plugins]]
+name = "auth"
+weight = 1
+
+[[plugins]]
+name = "cache"
+weight = 2
+`)
+
+	ctx := DefaultValidationContext().WithSource("config.toml")
+	result := config.ParseTOML(data, ctx)
+	if !result.Valid {
+		t.Fatalf("expected valid, got %v", result.Errors)
+	}
+	value := result.Value.(map[string]interface{})
+	if value["released"] != "2024-05-01" {
+		t.Errorf("released = %#v, want the date string", value["released"])
+	}
+	if port := value["server"].(map[string]interface{})["port"]; port != 8080 {
+		t.Errorf("port = %#v, want int 8080", port)
+	}
+
+	tests := []struct {
+		name     string
+		data     string
+		location string
+		message  string
+	}{
+		{"table key", "title = \"demo\"\nreleased = 2024-05-01\n\n[server]\n  port = 0\n  hosts = [\"a\"]\n", "config.toml:5:3", "config.toml:5:3: value must be at least 1"},
+		{"array element", "title = \"demo\"\nreleased = 2024-05-01\n[server]\nport = 1\nhosts = [\"a\", \"\"]\n", "config.toml:5:15", ""},
+		{"array of tables", "title = \"demo\"\nreleased = 2024-05-01\n[server]\nport = 1\nhosts = []\n[[plugins]]\nname = \"a\"\nweight = 1\n[[plugins]]\nname = \"b\"\nweight = -1\n", "config.toml:11:1", ""},
+		{"dotted key", "title = \"demo\"\nreleased = 2024-05-01\nserver.port = -2\nserver.hosts = []\n", "config.toml:3:1", ""},
+		{"syntax error", "title = \n", "config.toml:1:9", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseTOML(config, []byte(tt.data), ctx)
+			if result.Valid {
+				t.Fatal("expected invalid result")
+			}
+			last := result.Errors[len(result.Errors)-1]
+			if last.Location == nil || last.Location.String() != tt.location {
+				t.Fatalf("location = %v, want %s (%v)", last.Location, tt.location, last)
+			}
+			if tt.message != "" && last.Error() != tt.message {
+				t.Errorf("Error() = %q, want %q", last.Error(), tt.message)
+			}
+		})
+	}
+}
+
+func TestParseINI(t *testing.T) {
+	config := Object().
+		Property("name", String()).
+		Property("debug", Bool()).
+		Property("server", Object().
+			Property("port", Int().Min(1)).
+			Property("tls", Object().Property("enabled", Bool()).Optional())).
+		Property("hosts", Array(String()).Optional())
+
+	data := []byte(`; global settings
+name = "demo app"
+debug: true
+hosts[] = a
+hosts[] = b
+
+[server]
+port = 8080
+
+[server.tls]
+enabled = false
+`)
+
+	result := config.ParseINI(data, DefaultValidationContext())
+	if !result.Valid {
+		t.Fatalf("expected valid, got %v", result.Errors)
+	}
+	want := map[string]interface{}{
+		"name":  "demo app",
+		"debug": true,
+		"hosts": []interface{}{"a", "b"},
+		"server": map[string]interface{}{
+			"port": 8080,
+			"tls":  map[string]interface{}{"enabled": false},
+		},
+	}
+	if !reflect.DeepEqual(result.Value, want) {
+		t.Errorf("value = %#v, want %#v", result.Value, want)
+	}
+
+	ctx := DefaultValidationContext().WithSource("app.ini")
+	result = ParseINI(config, []byte("name = x\ndebug = no\n[server]\n  port = 0\n"), ctx)
+	if result.Valid {
+		t.Fatal("expected invalid result")
+	}
+	for _, err := range result.Errors {
+		if err.Path.DotPath() == "server.port" && err.Location.String() != "app.ini:4:3" {
+			t.Errorf("server.port location = %v, want app.ini:4:3", err.Location)
+		}
+	}
+
+	result = ParseINI(config, []byte("name = x\n[server\n"), ctx)
+	if result.Valid || result.Errors[0].Code != "invalid_ini" || result.Errors[0].Location.String() != "app.ini:2:1" {
+		t.Errorf("expected invalid_ini at app.ini:2:1, got %v", result.Errors)
+	}
+}
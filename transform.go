@@ -32,6 +32,36 @@ type TransformSchema struct {
 	transformError ErrorMessage `json:"-"`
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *TransformSchema) Clone() *TransformSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema and its input and output schemas immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *TransformSchema) Freeze() *TransformSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and its input and output schemas
+func (s *TransformSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.inputSchema, s.outputSchema)
+}
+
+// Children returns the input schema and the output schema, which apply to the value
+// before and after the transformation
+func (s *TransformSchema) Children() []SchemaChild {
+	return sameValueChildren(s.inputSchema, s.outputSchema)
+}
+
 // Transform creates a new transform schema
 func Transform(
 	inputSchema Parseable,
@@ -59,18 +89,75 @@ func Transform(
 
 // Title sets the title of the transform schema
 func (s *TransformSchema) Title(title string) *TransformSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the transform schema
 func (s *TransformSchema) Description(description string) *TransformSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *TransformSchema) ReadOnly() *TransformSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *TransformSchema) WriteOnly() *TransformSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *TransformSchema) Sensitive() *TransformSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *TransformSchema) Deprecated(reason string) *TransformSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *TransformSchema) Meta(key string, value interface{}) *TransformSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *TransformSchema) AsWarning(codes ...ErrorCode) *TransformSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *TransformSchema) AsInfo(codes ...ErrorCode) *TransformSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Required marks the schema as required with optional custom error message
 func (s *TransformSchema) Required(errorMessage ...interface{}) *TransformSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = parseErrorMessageToErrorMessage(errorMessage...)
@@ -80,30 +167,68 @@ func (s *TransformSchema) Required(errorMessage ...interface{}) *TransformSchema
 
 // Optional marks the schema as optional
 func (s *TransformSchema) Optional() *TransformSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Nullable marks the schema as nullable
 func (s *TransformSchema) Nullable() *TransformSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
 // Default sets a default value for the schema
 func (s *TransformSchema) Default(value interface{}) *TransformSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+func (s *TransformSchema) DefaultFunc(fn func() interface{}) *TransformSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // WithTransformError sets a custom error message for transformation failures
 func (s *TransformSchema) WithTransformError(errorMessage ...interface{}) *TransformSchema {
+	s.checkMutable()
 	s.transformError = parseErrorMessageToErrorMessage(errorMessage...)
 	return s
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *TransformSchema) Transform(fn TransformFunc) *TransformSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *TransformSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *TransformSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *TransformSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *TransformSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Parse validates input, transforms it, then validates output
 func (s *TransformSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the transform constraints; Parse runs the refine/transform pipeline on top
+func (s *TransformSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	// Handle nil values
 	if value == nil {
 		if s.nullable {
@@ -112,29 +237,29 @@ func (s *TransformSchema) Parse(value interface{}, ctx *ValidationContext) Parse
 		if s.Schema.required {
 			// Check if we have a default value
 			if defaultVal := s.GetDefault(); defaultVal != nil {
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// Required field is missing
 			message := transformRequiredError(ctx.Locale)
 			if s.requiredError != nil {
-				message = s.requiredError.Resolve(ctx)
+				message = resolveErrorMessage(s.requiredError, ctx)
 			}
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Use default value if available for optional fields
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional and no default, return nil
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
 	}
 
-	// Step 1: Validate and parse input against input schema
-	inputResult := s.inputSchema.Parse(value, ctx)
+	// Step 1: Validate and parse input against input schema; the transform needs its value
+	inputResult := s.inputSchema.Parse(value, ctx.withValues())
 	if !inputResult.Valid {
 		// Prefix input validation errors
 		var prefixedErrors []ValidationError
@@ -144,6 +269,9 @@ func (s *TransformSchema) Parse(value interface{}, ctx *ValidationContext) Parse
 				Value:   err.Value,
 				Message: "input validation: " + err.Message,
 				Code:    "input_" + err.Code,
+				Params:  err.Params,
+
+				rendered: err.rendered,
 			})
 		}
 		return ParseResult{
@@ -153,18 +281,23 @@ func (s *TransformSchema) Parse(value interface{}, ctx *ValidationContext) Parse
 		}
 	}
 
+	// Only the input constraints apply when checking a schema (see Lint)
+	if ctx.structural {
+		return inputResult
+	}
+
 	// Step 2: Transform the validated input value
 	transformed, transformErr := s.transformFunc(inputResult.Value)
 	if transformErr != nil {
 		message := transformFailedError(transformErr)(ctx.Locale)
 		if s.transformError != nil {
-			message = s.transformError.Resolve(ctx)
+			message = resolveErrorMessage(s.transformError, ctx)
 		}
 
 		return ParseResult{
 			Valid:  false,
 			Value:  value,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "transform")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeTransform)},
 		}
 	}
 
@@ -179,6 +312,9 @@ func (s *TransformSchema) Parse(value interface{}, ctx *ValidationContext) Parse
 				Value:   err.Value,
 				Message: "output validation: " + err.Message,
 				Code:    "output_" + err.Code,
+				Params:  err.Params,
+
+				rendered: err.rendered,
 			})
 		}
 		return ParseResult{
@@ -198,19 +334,24 @@ func (s *TransformSchema) Parse(value interface{}, ctx *ValidationContext) Parse
 
 // JSON returns the JSON representation of the transform schema
 func (s *TransformSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *TransformSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	result := make(map[string]interface{})
 
 	// Set basic properties
 	result["type"] = "transform"
 
 	// Add input schema
-	if inputJSON, ok := s.inputSchema.(interface{ JSON() map[string]interface{} }); ok {
-		result["inputSchema"] = inputJSON.JSON()
+	if inputJSON, ok := childJSON(s.inputSchema, g); ok {
+		result["inputSchema"] = inputJSON
 	}
 
 	// Add output schema
-	if outputJSON, ok := s.outputSchema.(interface{ JSON() map[string]interface{} }); ok {
-		result["outputSchema"] = outputJSON.JSON()
+	if outputJSON, ok := childJSON(s.outputSchema, g); ok {
+		result["outputSchema"] = outputJSON
 	}
 
 	// Add metadata
@@ -220,12 +361,13 @@ func (s *TransformSchema) JSON() map[string]interface{} {
 	if s.description != "" {
 		result["description"] = s.description
 	}
+	addAnnotations(result, &s.Schema)
 
 	// Add schema flags
 	if s.nullable {
 		result["nullable"] = true
 	}
-	if defaultVal := s.GetDefault(); defaultVal != nil {
+	if defaultVal := s.staticDefault(); defaultVal != nil {
 		result["default"] = defaultVal
 	}
 
@@ -0,0 +1,520 @@
+// Package tsgen generates TypeScript declarations and Zod schemas from the schema
+// definitions used for validation, so frontend clients get types that mirror the
+// Go-side rules exactly.
+//
+//	types := map[string]schema.JSONSchemaGenerator{
+//	    "User": userSchema,
+//	    "Role": roleSchema,
+//	}
+//	dts, err := tsgen.Declarations(types) // user.d.ts
+//	zod, err := tsgen.Zod(types)          // user.zod.ts
+//
+// Properties that are not required become optional (name?: T), nullable schemas
+// become T | null, and refs point at the declaration named by the last segment of
+// the reference, so "#/components/schemas/User" refers to User.
+package tsgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nyxstack/schema"
+)
+
+// identifier matches property names that need no quotes in TypeScript
+var identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// Declarations generates a .d.ts file with one exported declaration per schema.
+// Object schemas become interfaces and all other schemas type aliases.
+func Declarations(types map[string]schema.JSONSchemaGenerator) ([]byte, error) {
+	docs, names, err := documents(types)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		doc := docs[name]
+		writeDoc(&b, doc, "")
+		if isType(doc, "object") && !isType(doc, "null") && doc["properties"] != nil {
+			fmt.Fprintf(&b, "export interface %s %s\n", name, objectType(doc, ""))
+		} else {
+			fmt.Fprintf(&b, "export type %s = %s;\n", name, tsType(doc, ""))
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// Zod generates a TypeScript module with one exported Zod schema per schema and a
+// type inferred from it under the same name. Refs are wrapped in z.lazy so
+// declarations may refer to each other in any order, including recursively.
+func Zod(types map[string]schema.JSONSchemaGenerator) ([]byte, error) {
+	docs, names, err := documents(types)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("import { z } from \"zod\";\n")
+	for _, name := range names {
+		b.WriteString("\n")
+		writeDoc(&b, docs[name], "")
+		fmt.Fprintf(&b, "export const %s = %s;\n", name, zodType(docs[name], ""))
+		fmt.Fprintf(&b, "export type %s = z.infer<typeof %s>;\n", name, name)
+	}
+	return []byte(b.String()), nil
+}
+
+// documents converts each schema to its JSON Schema with the value types produced by
+// encoding/json and returns the names in sorted order
+func documents(types map[string]schema.JSONSchemaGenerator) (map[string]map[string]interface{}, []string, error) {
+	docs := make(map[string]map[string]interface{}, len(types))
+	names := make([]string, 0, len(types))
+	for name, s := range types {
+		if !identifier.MatchString(name) {
+			return nil, nil, fmt.Errorf("tsgen: %q is not a valid TypeScript identifier", name)
+		}
+		data, err := json.Marshal(s.JSON())
+		if err != nil {
+			return nil, nil, fmt.Errorf("tsgen: schema %s: %w", name, err)
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, nil, fmt.Errorf("tsgen: schema %s: %w", name, err)
+		}
+		docs[name] = doc
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return docs, names, nil
+}
+
+// tsType returns the TypeScript type of a schema
+func tsType(doc map[string]interface{}, indent string) string {
+	base := tsBaseType(doc, indent)
+	if isType(doc, "null") && base != "null" && base != "unknown" {
+		return union(base, "null")
+	}
+	return base
+}
+
+// tsBaseType returns the TypeScript type of a schema ignoring a nullable type
+func tsBaseType(doc map[string]interface{}, indent string) string {
+	if ref, ok := doc["$ref"].(string); ok {
+		return refName(ref)
+	}
+	if value, ok := doc["const"]; ok {
+		return literal(value)
+	}
+	if values, ok := doc["enum"].([]interface{}); ok {
+		literals := make([]string, len(values))
+		for i, value := range values {
+			literals[i] = literal(value)
+		}
+		return union(literals...)
+	}
+	if members := members(doc, "oneOf", "anyOf"); members != nil {
+		types := make([]string, len(members))
+		for i, member := range members {
+			types[i] = tsType(member, indent)
+		}
+		return union(types...)
+	}
+	if members := members(doc, "allOf"); members != nil {
+		types := make([]string, len(members))
+		for i, member := range members {
+			types[i] = wrap(tsType(member, indent))
+		}
+		return strings.Join(types, " & ")
+	}
+
+	switch {
+	case isType(doc, "object"):
+		values, ok := doc["additionalProperties"].(map[string]interface{})
+		if ok && doc["properties"] == nil {
+			return fmt.Sprintf("Record<string, %s>", tsType(values, indent))
+		}
+		return objectType(doc, indent)
+	case isType(doc, "array"):
+		if items, ok := doc["items"].([]interface{}); ok {
+			types := make([]string, len(items))
+			for i, item := range items {
+				item, _ := item.(map[string]interface{})
+				types[i] = tsType(item, indent)
+			}
+			if rest, ok := doc["additionalItems"].(map[string]interface{}); ok {
+				types = append(types, "..."+wrap(tsType(rest, indent))+"[]")
+			}
+			return "[" + strings.Join(types, ", ") + "]"
+		}
+		if items, ok := doc["items"].(map[string]interface{}); ok {
+			return wrap(tsType(items, indent)) + "[]"
+		}
+		return "unknown[]"
+	case isType(doc, "string"):
+		return "string"
+	case isType(doc, "integer"), isType(doc, "number"):
+		return "number"
+	case isType(doc, "boolean"):
+		return "boolean"
+	case isType(doc, "null"):
+		return "null"
+	}
+	return "unknown"
+}
+
+// objectType returns an object type literal, used as the body of interfaces too
+func objectType(doc map[string]interface{}, indent string) string {
+	properties, _ := doc["properties"].(map[string]interface{})
+	values, hasValues := doc["additionalProperties"].(map[string]interface{})
+	if len(properties) == 0 && !hasValues {
+		if doc["additionalProperties"] == false {
+			return "Record<string, never>"
+		}
+		return "Record<string, unknown>"
+	}
+
+	required := requiredSet(doc)
+	inner := indent + "  "
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, name := range sortedKeys(properties) {
+		prop, _ := properties[name].(map[string]interface{})
+		writeDoc(&b, prop, inner)
+		optional := ""
+		if !required[name] {
+			optional = "?"
+		}
+		fmt.Fprintf(&b, "%s%s%s: %s;\n", inner, propertyName(name), optional, tsType(prop, inner))
+	}
+	if hasValues {
+		fmt.Fprintf(&b, "%s[key: string]: %s;\n", inner, tsType(values, inner))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// zodType returns the Zod expression validating a schema
+func zodType(doc map[string]interface{}, indent string) string {
+	expr := zodBaseType(doc, indent)
+	if isType(doc, "null") && expr != "z.null()" && expr != "z.unknown()" {
+		expr += ".nullable()"
+	}
+	if value, ok := doc["default"]; ok && value != nil {
+		expr += ".default(" + literal(value) + ")"
+	}
+	return expr
+}
+
+// zodBaseType returns the Zod expression for a schema ignoring nullability and defaults
+func zodBaseType(doc map[string]interface{}, indent string) string {
+	if ref, ok := doc["$ref"].(string); ok {
+		return "z.lazy(() => " + refName(ref) + ")"
+	}
+	if value, ok := doc["const"]; ok {
+		return "z.literal(" + literal(value) + ")"
+	}
+	if values, ok := doc["enum"].([]interface{}); ok {
+		return zodEnum(values)
+	}
+	if members := members(doc, "oneOf", "anyOf"); members != nil {
+		if len(members) == 1 {
+			return zodType(members[0], indent)
+		}
+		types := make([]string, len(members))
+		for i, member := range members {
+			types[i] = zodType(member, indent)
+		}
+		return "z.union([" + strings.Join(types, ", ") + "])"
+	}
+	if members := members(doc, "allOf"); members != nil {
+		expr := zodType(members[0], indent)
+		for _, member := range members[1:] {
+			expr = "z.intersection(" + expr + ", " + zodType(member, indent) + ")"
+		}
+		return expr
+	}
+
+	switch {
+	case isType(doc, "object"):
+		return zodObject(doc, indent)
+	case isType(doc, "array"):
+		if items, ok := doc["items"].([]interface{}); ok {
+			types := make([]string, len(items))
+			for i, item := range items {
+				item, _ := item.(map[string]interface{})
+				types[i] = zodType(item, indent)
+			}
+			expr := "z.tuple([" + strings.Join(types, ", ") + "])"
+			if rest, ok := doc["additionalItems"].(map[string]interface{}); ok {
+				expr += ".rest(" + zodType(rest, indent) + ")"
+			}
+			return expr
+		}
+		items, _ := doc["items"].(map[string]interface{})
+		expr := "z.array(z.unknown())"
+		if items != nil {
+			expr = "z.array(" + zodType(items, indent) + ")"
+		}
+		return expr + bounds(doc, "minItems", ".min", "maxItems", ".max")
+	case isType(doc, "string"):
+		expr := "z.string()"
+		switch doc["format"] {
+		case "email":
+			expr += ".email()"
+		case "uuid":
+			expr += ".uuid()"
+		case "uri", "url":
+			expr += ".url()"
+		case "date-time":
+			expr += ".datetime({ offset: true })"
+		case "date":
+			expr += ".date()"
+		case "time":
+			expr += ".time()"
+		}
+		expr += bounds(doc, "minLength", ".min", "maxLength", ".max")
+		if pattern, ok := doc["pattern"].(string); ok {
+			expr += ".regex(new RegExp(" + literal(pattern) + "))"
+		}
+		return expr
+	case isType(doc, "integer"), isType(doc, "number"):
+		expr := "z.number()"
+		if isType(doc, "integer") {
+			expr += ".int()"
+		}
+		expr += bounds(doc, "minimum", ".gte", "maximum", ".lte")
+		expr += bounds(doc, "exclusiveMinimum", ".gt", "exclusiveMaximum", ".lt")
+		if step, ok := doc["multipleOf"]; ok {
+			expr += ".multipleOf(" + literal(step) + ")"
+		}
+		return expr
+	case isType(doc, "boolean"):
+		return "z.boolean()"
+	case isType(doc, "null"):
+		return "z.null()"
+	}
+	return "z.unknown()"
+}
+
+// zodObject returns the Zod expression for an object schema
+func zodObject(doc map[string]interface{}, indent string) string {
+	properties, _ := doc["properties"].(map[string]interface{})
+	values, hasValues := doc["additionalProperties"].(map[string]interface{})
+	if len(properties) == 0 && hasValues {
+		return "z.record(z.string(), " + zodType(values, indent) + ")"
+	}
+
+	required := requiredSet(doc)
+	inner := indent + "  "
+	var b strings.Builder
+	b.WriteString("z.object({")
+	if len(properties) > 0 {
+		b.WriteString("\n")
+		for _, name := range sortedKeys(properties) {
+			prop, _ := properties[name].(map[string]interface{})
+			expr := zodType(prop, inner)
+			if !required[name] {
+				expr += ".optional()"
+			}
+			fmt.Fprintf(&b, "%s%s: %s,\n", inner, propertyName(name), expr)
+		}
+		b.WriteString(indent)
+	}
+	b.WriteString("})")
+
+	switch {
+	case hasValues:
+		b.WriteString(".catchall(" + zodType(values, indent) + ")")
+	case doc["additionalProperties"] == false:
+		b.WriteString(".strict()")
+	default:
+		b.WriteString(".passthrough()")
+	}
+	return b.String()
+}
+
+// zodEnum returns z.enum for string values and a union of literals otherwise
+func zodEnum(values []interface{}) string {
+	literals := make([]string, 0, len(values))
+	allStrings := true
+	for _, value := range values {
+		if _, ok := value.(string); !ok {
+			allStrings = false
+		}
+		literals = append(literals, literal(value))
+	}
+	switch {
+	case len(literals) == 1:
+		return "z.literal(" + literals[0] + ")"
+	case allStrings:
+		return "z.enum([" + strings.Join(literals, ", ") + "])"
+	}
+	for i, l := range literals {
+		literals[i] = "z.literal(" + l + ")"
+	}
+	return "z.union([" + strings.Join(literals, ", ") + "])"
+}
+
+// bounds appends Zod range checks for the lower and upper bound keywords present in doc
+func bounds(doc map[string]interface{}, lowerKey, lowerMethod, upperKey, upperMethod string) string {
+	var b strings.Builder
+	if value, ok := doc[lowerKey].(float64); ok {
+		b.WriteString(lowerMethod + "(" + literal(value) + ")")
+	}
+	if value, ok := doc[upperKey].(float64); ok {
+		b.WriteString(upperMethod + "(" + literal(value) + ")")
+	}
+	return b.String()
+}
+
+// writeDoc writes the title and description of a schema as a JSDoc comment
+func writeDoc(b *strings.Builder, doc map[string]interface{}, indent string) {
+	var lines []string
+	for _, key := range []string{"title", "description"} {
+		if text, ok := doc[key].(string); ok && text != "" {
+			lines = append(lines, strings.Split(text, "\n")...)
+		}
+	}
+	if deprecated, _ := doc["deprecated"].(bool); deprecated {
+		lines = append(lines, "@deprecated")
+	}
+	switch len(lines) {
+	case 0:
+	case 1:
+		fmt.Fprintf(b, "%s/** %s */\n", indent, escapeComment(lines[0]))
+	default:
+		fmt.Fprintf(b, "%s/**\n", indent)
+		for _, line := range lines {
+			fmt.Fprintf(b, "%s * %s\n", indent, escapeComment(line))
+		}
+		fmt.Fprintf(b, "%s */\n", indent)
+	}
+}
+
+// escapeComment prevents text from closing a JSDoc comment
+func escapeComment(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
+
+// isType reports whether a JSON Schema document allows the given type
+func isType(doc map[string]interface{}, typeName string) bool {
+	switch t := doc["type"].(type) {
+	case string:
+		return t == typeName
+	case []interface{}:
+		for _, item := range t {
+			if item == typeName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// members returns the member schemas of the first combinator keyword present in doc
+func members(doc map[string]interface{}, keywords ...string) []map[string]interface{} {
+	for _, keyword := range keywords {
+		list, ok := doc[keyword].([]interface{})
+		if !ok {
+			continue
+		}
+		result := make([]map[string]interface{}, 0, len(list))
+		for _, item := range list {
+			if member, ok := item.(map[string]interface{}); ok {
+				result = append(result, member)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// requiredSet returns the required property names of an object schema
+func requiredSet(doc map[string]interface{}) map[string]bool {
+	required := map[string]bool{}
+	list, _ := doc["required"].([]interface{})
+	for _, name := range list {
+		if name, ok := name.(string); ok {
+			required[name] = true
+		}
+	}
+	return required
+}
+
+// union joins types with |, dropping duplicates
+func union(types ...string) string {
+	seen := make(map[string]bool, len(types))
+	unique := make([]string, 0, len(types))
+	for _, t := range types {
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+	return strings.Join(unique, " | ")
+}
+
+// wrap parenthesizes union and intersection types used as array elements or
+// intersection members
+func wrap(t string) string {
+	depth := 0
+	for i := 0; i < len(t); i++ {
+		switch t[i] {
+		case '{', '[', '(', '<':
+			depth++
+		case '}', ']', ')', '>':
+			depth--
+		case '|', '&':
+			if depth == 0 {
+				return "(" + t + ")"
+			}
+		case '"':
+			// Skip string literals, which may contain any of the characters above
+			for i++; i < len(t) && t[i] != '"'; i++ {
+				if t[i] == '\\' {
+					i++
+				}
+			}
+		}
+	}
+	return t
+}
+
+// refName returns the declaration name a $ref points at
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// propertyName quotes property names that are not valid identifiers
+func propertyName(name string) string {
+	if identifier.MatchString(name) {
+		return name
+	}
+	return literal(name)
+}
+
+// literal formats a value as a TypeScript/JavaScript literal
+func literal(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "undefined"
+	}
+	return string(data)
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,112 @@
+package tsgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nyxstack/schema"
+)
+
+func testTypes() map[string]schema.JSONSchemaGenerator {
+	address := schema.Object().
+		Property("city", schema.String().MinLength(1)).
+		Property("zip-code", schema.String().Optional())
+
+	user := schema.Object().
+		Property("id", schema.Int().Min(1)).
+		Property("email", schema.String().Email().Description("Login address")).
+		Property("nickname", schema.String().Optional().Nullable()).
+		Property("tags", schema.Array(schema.String()).MaxItems(5)).
+		Property("address", address).
+		Property("role", schema.Enum("admin", "user")).
+		Property("contact", schema.OneOf(schema.String(), schema.Int())).
+		Property("labels", schema.Record(schema.String(), schema.String())).
+		Property("manager", schema.Ref("#/components/schemas/User", schema.NewSchemaRegistry()))
+
+	return map[string]schema.JSONSchemaGenerator{
+		"User":   user,
+		"Status": schema.Enum("active", "banned"),
+		"Pair":   schema.Tuple(schema.String(), schema.Int()),
+		"Sum":    schema.Tuple(schema.String()).Rest(schema.Int()),
+	}
+}
+
+func TestDeclarations(t *testing.T) {
+	out, err := Declarations(testTypes())
+	if err != nil {
+		t.Fatalf("Declarations() error = %v", err)
+	}
+	dts := string(out)
+
+	for _, want := range []string{
+		"export type Pair = [string, number];\n",
+		"export type Sum = [string, ...number[]];\n",
+		"export type Status = \"active\" | \"banned\";\n",
+		"export interface User {\n",
+		"  address: {\n    city: string;\n    \"zip-code\"?: string;\n  };\n",
+		"  contact: string | number;\n",
+		"  /** Login address */\n  email: string;\n",
+		"  id: number;\n",
+		"  labels: Record<string, string>;\n",
+		"  manager: User;\n",
+		"  nickname?: string | null;\n",
+		"  role: \"admin\" | \"user\";\n",
+		"  tags: string[];\n",
+	} {
+		if !strings.Contains(dts, want) {
+			t.Errorf("Declarations() output missing %q\n%s", want, dts)
+		}
+	}
+}
+
+func TestZod(t *testing.T) {
+	out, err := Zod(testTypes())
+	if err != nil {
+		t.Fatalf("Zod() error = %v", err)
+	}
+	zod := string(out)
+
+	for _, want := range []string{
+		"import { z } from \"zod\";\n",
+		"export const Pair = z.tuple([z.string(), z.number().int()]);\nexport type Pair = z.infer<typeof Pair>;\n",
+		"export const Sum = z.tuple([z.string()]).rest(z.number().int());\n",
+		"export const Status = z.enum([\"active\", \"banned\"]);\n",
+		"    city: z.string().min(1),\n",
+		"    \"zip-code\": z.string().optional(),\n",
+		"  contact: z.union([z.string(), z.number().int()]),\n",
+		"  email: z.string().email(),\n",
+		"  id: z.number().int().gte(1),\n",
+		"  labels: z.record(z.string(), z.string()),\n",
+		"  manager: z.lazy(() => User),\n",
+		"  nickname: z.string().nullable().optional(),\n",
+		"  tags: z.array(z.string()).max(5),\n",
+	} {
+		if !strings.Contains(zod, want) {
+			t.Errorf("Zod() output missing %q\n%s", want, zod)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"string", "string"},
+		{"string | null", "(string | null)"},
+		{"{\n  a: string | null;\n}", "{\n  a: string | null;\n}"},
+		{"Record<string, A | B>", "Record<string, A | B>"},
+		{"\"a|b\"", "\"a|b\""},
+	}
+	for _, tt := range tests {
+		if got := wrap(tt.in); got != tt.want {
+			t.Errorf("wrap(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestInvalidName(t *testing.T) {
+	_, err := Declarations(map[string]schema.JSONSchemaGenerator{"my-type": schema.String()})
+	if err == nil {
+		t.Error("Declarations() should reject names that are not identifiers")
+	}
+}
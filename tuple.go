@@ -2,8 +2,9 @@ package schema
 
 import (
 	"encoding/json"
-	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 
 	"github.com/nyxstack/i18n"
 )
@@ -33,6 +34,7 @@ type TupleSchema struct {
 	// Tuple-specific validation
 	itemSchemas     []Parseable // Schemas for each position (order matters)
 	additionalItems bool        // Allow additional items beyond defined positions
+	restSchema      Parseable   // Schema of the additional items, nil to accept them unvalidated
 	uniqueItems     bool        // Items must be unique
 	nullable        bool        // Allow null values
 
@@ -57,48 +59,167 @@ func Tuple(itemSchemas ...Parseable) *TupleSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *TupleSchema) Clone() *TupleSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.itemSchemas = slices.Clone(s.itemSchemas)
+	return &c
+}
+
+// Freeze makes the schema and its item schemas immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *TupleSchema) Freeze() *TupleSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and its item schemas
+func (s *TupleSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.itemSchemas...)
+	freezeSchemas(s.restSchema)
+}
+
+// Children returns the schema of each position, followed by the rest schema
+func (s *TupleSchema) Children() []SchemaChild {
+	children := make([]SchemaChild, len(s.itemSchemas))
+	for i, item := range s.itemSchemas {
+		children[i] = SchemaChild{Segment: "[" + strconv.Itoa(i) + "]", Schema: item}
+	}
+	if s.restSchema != nil {
+		children = append(children, SchemaChild{Segment: ItemsSegment, Schema: s.restSchema})
+	}
+	return children
+}
+
 // Core fluent API methods
 
 // Title sets the title of the schema
 func (s *TupleSchema) Title(title string) *TupleSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *TupleSchema) Description(description string) *TupleSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *TupleSchema) ReadOnly() *TupleSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *TupleSchema) WriteOnly() *TupleSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *TupleSchema) Sensitive() *TupleSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *TupleSchema) Deprecated(reason string) *TupleSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *TupleSchema) Meta(key string, value interface{}) *TupleSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *TupleSchema) AsWarning(codes ...ErrorCode) *TupleSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *TupleSchema) AsInfo(codes ...ErrorCode) *TupleSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *TupleSchema) Default(value interface{}) *TupleSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *TupleSchema) DefaultFunc(fn func() interface{}) *TupleSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *TupleSchema) Example(example []interface{}) *TupleSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
 
 // Tuple-specific validation
 
-// AllowAdditionalItems allows extra items beyond the defined positions
+// AllowAdditionalItems allows extra items beyond the defined positions, accepted as-is
 func (s *TupleSchema) AllowAdditionalItems() *TupleSchema {
+	s.checkMutable()
+	s.additionalItems = true
+	s.restSchema = nil
+	return s
+}
+
+// Rest allows any number of extra items beyond the defined positions, each of which
+// must be valid for schema, e.g. Tuple(String()).Rest(Int()) for ["sum", 1, 2, 3]
+func (s *TupleSchema) Rest(schema Parseable) *TupleSchema {
+	s.checkMutable()
 	s.additionalItems = true
+	s.restSchema = schema
 	return s
 }
 
 // Strict requires exact length matching (default behavior)
 func (s *TupleSchema) Strict() *TupleSchema {
+	s.checkMutable()
 	s.additionalItems = false
+	s.restSchema = nil
 	return s
 }
 
 // UniqueItems requires all items to be unique with optional custom error message
 func (s *TupleSchema) UniqueItems(errorMessage ...interface{}) *TupleSchema {
+	s.checkMutable()
 	s.uniqueItems = true
 	if len(errorMessage) > 0 {
 		s.uniqueItemsError = toErrorMessage(errorMessage[0])
@@ -110,12 +231,14 @@ func (s *TupleSchema) UniqueItems(errorMessage ...interface{}) *TupleSchema {
 
 // Optional marks the schema as optional
 func (s *TupleSchema) Optional() *TupleSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *TupleSchema) Required(errorMessage ...interface{}) *TupleSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -125,6 +248,7 @@ func (s *TupleSchema) Required(errorMessage ...interface{}) *TupleSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *TupleSchema) Nullable() *TupleSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
@@ -133,18 +257,21 @@ func (s *TupleSchema) Nullable() *TupleSchema {
 
 // TypeError sets a custom error message for type mismatch validation
 func (s *TupleSchema) TypeError(message string) *TupleSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
 
 // LengthError sets a custom error message for length validation
 func (s *TupleSchema) LengthError(message string) *TupleSchema {
+	s.checkMutable()
 	s.lengthError = toErrorMessage(message)
 	return s
 }
 
 // ItemError sets a custom error message for item validation failures
 func (s *TupleSchema) ItemError(message string) *TupleSchema {
+	s.checkMutable()
 	s.itemError = toErrorMessage(message)
 	return s
 }
@@ -181,6 +308,11 @@ func (s *TupleSchema) AllowsAdditionalItems() bool {
 	return s.additionalItems
 }
 
+// GetRest returns the schema of the items beyond the defined positions, or nil
+func (s *TupleSchema) GetRest() Parseable {
+	return s.restSchema
+}
+
 // IsUniqueItems returns whether items must be unique
 func (s *TupleSchema) IsUniqueItems() bool {
 	return s.uniqueItems
@@ -217,10 +349,36 @@ func getTupleComparableKey(item interface{}) interface{} {
 	}
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *TupleSchema) Transform(fn TransformFunc) *TupleSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *TupleSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *TupleSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *TupleSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *TupleSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses a tuple value, returning the final parsed value
 func (s *TupleSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the tuple constraints; Parse runs the refine/transform pipeline on top
+func (s *TupleSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
 	// Handle nil values
@@ -233,7 +391,7 @@ func (s *TupleSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := tupleRequiredError(ctx.Locale)
@@ -243,12 +401,12 @@ func (s *TupleSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -265,10 +423,20 @@ func (s *TupleSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "invalid_type")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
 		}
 	}
 
+	// Reject oversized and too deeply nested input before looking at its items
+	if err, exceeded := ctx.checkCollectionSize(value, v.Len()); exceeded {
+		return ParseResult{Valid: false, Value: nil, Errors: []ValidationError{err}}
+	}
+	child, ok := ctx.descend(value)
+	if !ok {
+		return ctx.depthExceeded(value)
+	}
+	defer child.release()
+
 	// Convert to []interface{}
 	tupleValue = make([]interface{}, v.Len())
 	for i := 0; i < v.Len(); i++ {
@@ -284,7 +452,7 @@ func (s *TupleSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.lengthError) {
 			message = resolveErrorMessage(s.lengthError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(tupleValue, message, "tuple_length"))
+		errors = append(errors, NewPrimitiveError(tupleValue, message, CodeTupleLength))
 	}
 
 	if s.additionalItems && actualLength < expectedLength {
@@ -292,17 +460,31 @@ func (s *TupleSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.lengthError) {
 			message = resolveErrorMessage(s.lengthError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(tupleValue, message, "min_length"))
+		errors = append(errors, NewPrimitiveError(tupleValue, message, CodeMinLength))
 	}
 
 	// Prepare final value array
 	finalValue := make([]interface{}, len(tupleValue))
 
-	// Validate each item at its position using the corresponding schema
+	// Validate each item at its position using the corresponding schema, collecting
+	// the warnings of the items
+	var warnings []ValidationError
 	for i, item := range tupleValue {
+		if ctx.stopCollecting(len(errors)) {
+			break
+		}
+		if err, exceeded := ctx.checkStringLength(item, IndexSegment(i)); exceeded {
+			errors = append(errors, err)
+			continue
+		}
+		itemSchema := s.restSchema
 		if i < len(s.itemSchemas) {
-			// Validate using position-specific schema
-			itemResult := s.itemSchemas[i].Parse(item, ctx)
+			itemSchema = s.itemSchemas[i]
+		}
+		if itemSchema != nil {
+			// Validate using position-specific schema, or the rest schema past the positions
+			itemResult := itemSchema.Parse(item, child.at(IndexSegment(i)))
+			warnings = nestedWarnings(warnings, IndexSegment(i), itemResult)
 			if !itemResult.Valid {
 				// Create error for this item
 				message := tupleItemError(i)(ctx.Locale)
@@ -310,11 +492,11 @@ func (s *TupleSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 					message = resolveErrorMessage(s.itemError, ctx)
 				}
 				// Add the main item error
-				errors = append(errors, NewFieldError([]string{fmt.Sprintf("[%d]", i)}, item, message, "item_invalid"))
+				errors = append(errors, NewFieldError(Path{IndexSegment(i)}, item, message, CodeItemInvalid))
 				// Also add the specific validation errors for this item
 				for _, itemErr := range itemResult.Errors {
 					// Prefix the path with tuple index
-					errors = append(errors, NewFieldError(append([]string{fmt.Sprintf("[%d]", i)}, itemErr.Path...), itemErr.Value, itemErr.Message, itemErr.Code))
+					errors = append(errors, nestedError(append(Path{IndexSegment(i)}, itemErr.Path...), itemErr))
 				}
 			} else {
 				// Use the parsed value from item validation
@@ -332,24 +514,31 @@ func (s *TupleSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		if !isEmptyErrorMessage(s.uniqueItemsError) {
 			message = resolveErrorMessage(s.uniqueItemsError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(tupleValue, message, "unique_items"))
+		errors = append(errors, NewPrimitiveError(tupleValue, message, CodeUniqueItems))
 	}
 
 	return ParseResult{
-		Valid:  len(errors) == 0,
-		Value:  finalValue,
-		Errors: errors,
+		Valid:    len(errors) == 0,
+		Value:    finalValue,
+		Errors:   errors,
+		Warnings: warnings,
 	}
 }
 
 // JSON generates JSON Schema representation
 func (s *TupleSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *TupleSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	schema := baseJSONSchema("array")
 
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 	addOptionalArray(schema, "enum", s.GetEnum())
 	addOptionalField(schema, "const", s.GetConst())
@@ -358,8 +547,8 @@ func (s *TupleSchema) JSON() map[string]interface{} {
 	if len(s.itemSchemas) > 0 {
 		items := make([]interface{}, len(s.itemSchemas))
 		for i, itemSchema := range s.itemSchemas {
-			if jsonSchema, ok := itemSchema.(interface{ JSON() map[string]interface{} }); ok {
-				items[i] = jsonSchema.JSON()
+			if itemJSON, ok := childJSON(itemSchema, g); ok {
+				items[i] = itemJSON
 			} else {
 				items[i] = map[string]interface{}{"type": "unknown"}
 			}
@@ -367,8 +556,16 @@ func (s *TupleSchema) JSON() map[string]interface{} {
 		schema["items"] = items
 	}
 
-	// Add additionalItems
-	schema["additionalItems"] = s.additionalItems
+	// Add additionalItems, the schema of the rest items when there is one
+	if s.restSchema != nil {
+		if restJSON, ok := childJSON(s.restSchema, g); ok {
+			schema["additionalItems"] = restJSON
+		} else {
+			schema["additionalItems"] = map[string]interface{}{"type": "unknown"}
+		}
+	} else {
+		schema["additionalItems"] = s.additionalItems
+	}
 
 	// Add uniqueItems if true
 	if s.uniqueItems {
@@ -397,6 +594,7 @@ func (s *TupleSchema) MarshalJSON() ([]byte, error) {
 		Schema
 		ItemSchemas     []Parseable `json:"itemSchemas"`
 		AdditionalItems bool        `json:"additionalItems"`
+		Rest            Parseable   `json:"rest,omitempty"`
 		UniqueItems     bool        `json:"uniqueItems,omitempty"`
 		Nullable        bool        `json:"nullable,omitempty"`
 	}
@@ -405,6 +603,7 @@ func (s *TupleSchema) MarshalJSON() ([]byte, error) {
 		Schema:          s.Schema,
 		ItemSchemas:     s.itemSchemas,
 		AdditionalItems: s.additionalItems,
+		Rest:            s.restSchema,
 		UniqueItems:     s.uniqueItems,
 		Nullable:        s.nullable,
 	})
@@ -0,0 +1,630 @@
+package schema
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for uint validation
+var (
+	uintRequiredError = i18n.S("value is required")
+	uintTypeError     = i18n.S("value must be a non-negative integer")
+	uintEnumError     = i18n.S("value must be one of the allowed values")
+)
+
+// Default error message functions that take parameters
+func uintMinimumError(min uint) i18n.TranslatedFunc {
+	return i18n.F("value must be at least %d", min)
+}
+
+func uintMaximumError(max uint) i18n.TranslatedFunc {
+	return i18n.F("value must be at most %d", max)
+}
+
+func uintMultipleOfError(multiple uint) i18n.TranslatedFunc {
+	return i18n.F("value must be a multiple of %d", multiple)
+}
+
+func uintConstError(value uint) i18n.TranslatedFunc {
+	return i18n.F("value must be exactly: %d", value)
+}
+
+// UintSchema represents a JSON Schema for uint values. It accepts every Go integer
+// type and whole floats as long as the value is not negative and fits in a uint.
+type UintSchema struct {
+	Schema
+	coerce bool // Convert compatible input types before validating
+	// Uint-specific validation (private fields)
+	minimum    *uint
+	maximum    *uint
+	multipleOf *uint
+	nullable   bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	minimumError      ErrorMessage
+	maximumError      ErrorMessage
+	multipleOfError   ErrorMessage
+	enumError         ErrorMessage
+	constError        ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// Uint creates a new uint schema with optional type error message
+func Uint(errorMessage ...interface{}) *UintSchema {
+	schema := &UintSchema{
+		Schema: Schema{
+			schemaType: "integer",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.typeMismatchError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *UintSchema) Clone() *UintSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *UintSchema) Freeze() *UintSchema {
+	s.freeze()
+	return s
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *UintSchema) Title(title string) *UintSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *UintSchema) Description(description string) *UintSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *UintSchema) ReadOnly() *UintSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *UintSchema) WriteOnly() *UintSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *UintSchema) Sensitive() *UintSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *UintSchema) Deprecated(reason string) *UintSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *UintSchema) Meta(key string, value interface{}) *UintSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *UintSchema) AsWarning(codes ...ErrorCode) *UintSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *UintSchema) AsInfo(codes ...ErrorCode) *UintSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *UintSchema) Default(value interface{}) *UintSchema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *UintSchema) DefaultFunc(fn func() interface{}) *UintSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *UintSchema) Example(example uint) *UintSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Enum sets the allowed enum values with optional custom error message
+func (s *UintSchema) Enum(values []uint, errorMessage ...interface{}) *UintSchema {
+	s.checkMutable()
+	s.Schema.enum = make([]interface{}, len(values))
+	for i, v := range values {
+		s.Schema.enum[i] = v
+	}
+	if len(errorMessage) > 0 {
+		s.enumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Const sets a constant value with optional custom error message
+func (s *UintSchema) Const(value uint, errorMessage ...interface{}) *UintSchema {
+	s.checkMutable()
+	s.Schema.constVal = value
+	if len(errorMessage) > 0 {
+		s.constError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Required/Optional/Nullable control
+
+// Optional marks the schema as optional
+func (s *UintSchema) Optional() *UintSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *UintSchema) Required(errorMessage ...interface{}) *UintSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *UintSchema) Nullable() *UintSchema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// Coerce enables conversion of compatible input types (e.g. strings from query
+// parameters or environment variables) before validation
+func (s *UintSchema) Coerce() *UintSchema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *UintSchema) coerces() bool {
+	return s.coerce
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *UintSchema) TypeError(message string) *UintSchema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// Uint-specific fluent API methods
+
+// Min sets the minimum value constraint with optional custom error message
+func (s *UintSchema) Min(min uint, errorMessage ...interface{}) *UintSchema {
+	s.checkMutable()
+	s.minimum = &min
+	if len(errorMessage) > 0 {
+		s.minimumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Max sets the maximum value constraint with optional custom error message
+func (s *UintSchema) Max(max uint, errorMessage ...interface{}) *UintSchema {
+	s.checkMutable()
+	s.maximum = &max
+	if len(errorMessage) > 0 {
+		s.maximumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Range sets both minimum and maximum values with optional custom error message
+func (s *UintSchema) Range(min, max uint, errorMessage ...interface{}) *UintSchema {
+	s.checkMutable()
+	s.minimum = &min
+	s.maximum = &max
+	if len(errorMessage) > 0 {
+		s.minimumError = toErrorMessage(errorMessage[0])
+		s.maximumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// MultipleOf sets the multiple constraint with optional custom error message
+func (s *UintSchema) MultipleOf(multiple uint, errorMessage ...interface{}) *UintSchema {
+	s.checkMutable()
+	s.multipleOf = &multiple
+	if len(errorMessage) > 0 {
+		s.multipleOfError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Positive requires the value to be at least 1, with optional custom error message
+func (s *UintSchema) Positive(errorMessage ...interface{}) *UintSchema {
+	return s.Min(1, errorMessage...)
+}
+
+// Port requires a valid TCP/UDP port number (1-65535), with optional custom error message
+func (s *UintSchema) Port(errorMessage ...interface{}) *UintSchema {
+	return s.Range(1, 65535, errorMessage...)
+}
+
+// Getters for accessing private fields
+
+// IsRequired returns whether the schema is marked as required
+func (s *UintSchema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *UintSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *UintSchema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetMinimum returns the minimum value constraint
+func (s *UintSchema) GetMinimum() *uint {
+	return s.minimum
+}
+
+// GetMaximum returns the maximum value constraint
+func (s *UintSchema) GetMaximum() *uint {
+	return s.maximum
+}
+
+// GetMultipleOf returns the multiple constraint
+func (s *UintSchema) GetMultipleOf() *uint {
+	return s.multipleOf
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *UintSchema) Transform(fn TransformFunc) *UintSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *UintSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *UintSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *UintSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *UintSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Validation
+
+// Parse validates and parses a uint value, returning the final parsed value
+func (s *UintSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the uint constraints; Parse runs the refine/transform pipeline on top
+func (s *UintSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceUint(value)
+	}
+
+	var errors []ValidationError
+
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			// For nullable schemas, nil is a valid value
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if s.Schema.required {
+			// Check if we have a default value to use instead
+			if defaultVal := s.GetDefault(); defaultVal != nil {
+				// Use default value and re-parse it
+				return s.parse(defaultVal, ctx)
+			}
+			// No default, required field is missing
+			message := uintRequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{
+				Valid:  false,
+				Value:  nil,
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
+			}
+		}
+		// Optional field, use default if available
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		// Optional field with no default
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Negative, fractional and out-of-range values are type errors
+	n, ok := toUint64(value)
+	if !ok || n > math.MaxUint {
+		message := uintTypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{
+			Valid:  false,
+			Value:  nil,
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
+		}
+	}
+	uintValue := uint(n)
+
+	// Check minimum
+	if s.minimum != nil && uintValue < *s.minimum {
+		message := uintMinimumError(*s.minimum)(ctx.Locale)
+		if !isEmptyErrorMessage(s.minimumError) {
+			message = resolveErrorMessage(s.minimumError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(uintValue, message, CodeMinimum))
+	}
+
+	// Check maximum
+	if s.maximum != nil && uintValue > *s.maximum {
+		message := uintMaximumError(*s.maximum)(ctx.Locale)
+		if !isEmptyErrorMessage(s.maximumError) {
+			message = resolveErrorMessage(s.maximumError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(uintValue, message, CodeMaximum))
+	}
+
+	// Check multipleOf
+	if s.multipleOf != nil && *s.multipleOf != 0 && uintValue%*s.multipleOf != 0 {
+		message := uintMultipleOfError(*s.multipleOf)(ctx.Locale)
+		if !isEmptyErrorMessage(s.multipleOfError) {
+			message = resolveErrorMessage(s.multipleOfError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(uintValue, message, CodeMultipleOf))
+	}
+
+	// Check enum
+	if len(s.Schema.enum) > 0 {
+		valid := false
+		for _, enumValue := range s.Schema.enum {
+			if enumValue == uintValue {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			message := uintEnumError(ctx.Locale)
+			if !isEmptyErrorMessage(s.enumError) {
+				message = resolveErrorMessage(s.enumError, ctx)
+			}
+			errors = append(errors, NewPrimitiveError(uintValue, message, CodeEnum))
+		}
+	}
+
+	// Check const
+	if s.Schema.constVal != nil {
+		if constValue, ok := s.Schema.constVal.(uint); ok && constValue != uintValue {
+			message := uintConstError(constValue)(ctx.Locale)
+			if !isEmptyErrorMessage(s.constError) {
+				message = resolveErrorMessage(s.constError, ctx)
+			}
+			errors = append(errors, NewPrimitiveError(uintValue, message, CodeConst))
+		}
+	}
+
+	return ParseResult{
+		Valid:  len(errors) == 0,
+		Value:  uintValue,
+		Errors: errors,
+	}
+}
+
+// JSON generates JSON Schema representation; the minimum defaults to 0
+func (s *UintSchema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("integer")
+
+	// Add base schema fields
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	addOptionalArray(schema, "enum", s.GetEnum())
+	addOptionalField(schema, "const", s.GetConst())
+
+	// Add uint-specific fields
+	schema["minimum"] = 0
+	if s.minimum != nil {
+		schema["minimum"] = *s.minimum
+	}
+	if s.maximum != nil {
+		schema["maximum"] = *s.maximum
+	}
+	if s.multipleOf != nil {
+		schema["multipleOf"] = *s.multipleOf
+	}
+
+	// Add nullable if true
+	if s.nullable {
+		schema["type"] = []string{"integer", "null"}
+	}
+
+	return schema
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize UintSchema for JSON schema generation
+func (s *UintSchema) MarshalJSON() ([]byte, error) {
+	type jsonUintSchema struct {
+		Schema
+		Minimum    *uint `json:"minimum,omitempty"`
+		Maximum    *uint `json:"maximum,omitempty"`
+		MultipleOf *uint `json:"multipleOf,omitempty"`
+		Nullable   bool  `json:"nullable,omitempty"`
+	}
+
+	return json.Marshal(jsonUintSchema{
+		Schema:     s.Schema,
+		Minimum:    s.minimum,
+		Maximum:    s.maximum,
+		MultipleOf: s.multipleOf,
+		Nullable:   s.nullable,
+	})
+}
+
+// Interface implementations for UintSchema
+
+// SetTitle implements SetTitle interface
+func (s *UintSchema) SetTitle(title string) {
+	s.Title(title)
+}
+
+// SetDescription implements SetDescription interface
+func (s *UintSchema) SetDescription(description string) {
+	s.Description(description)
+}
+
+// SetRequired implements SetRequired interface
+func (s *UintSchema) SetRequired() {
+	s.Required()
+}
+
+// SetOptional implements SetOptional interface
+func (s *UintSchema) SetOptional() {
+	s.Optional()
+}
+
+// SetMinimum implements SetMinimum interface; negative or out-of-range bounds are ignored
+func (s *UintSchema) SetMinimum(min int) {
+	if min >= 0 && uint64(min) <= math.MaxUint {
+		s.Min(uint(min))
+	}
+}
+
+// SetMaximum implements SetMaximum interface; negative or out-of-range bounds are ignored
+func (s *UintSchema) SetMaximum(max int) {
+	if max >= 0 && uint64(max) <= math.MaxUint {
+		s.Max(uint(max))
+	}
+}
+
+// SetNullable implements SetNullable interface
+func (s *UintSchema) SetNullable() {
+	s.Nullable()
+}
+
+// SetDefault implements SetDefault interface
+func (s *UintSchema) SetDefault(value interface{}) {
+	s.Default(value)
+}
+
+// SetExample implements SetExample interface
+func (s *UintSchema) SetExample(example interface{}) {
+	if val, ok := example.(uint); ok {
+		s.Example(val)
+	}
+}
+
+// toUint64 converts a Go integer or whole float to uint64, reporting false for
+// negative, fractional and non-numeric values
+func toUint64(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case uint:
+		return uint64(v), true
+	case uint8:
+		return uint64(v), true
+	case uint16:
+		return uint64(v), true
+	case uint32:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	case int:
+		return uint64(v), v >= 0
+	case int8:
+		return uint64(v), v >= 0
+	case int16:
+		return uint64(v), v >= 0
+	case int32:
+		return uint64(v), v >= 0
+	case int64:
+		return uint64(v), v >= 0
+	case float32:
+		return floatToUint64(float64(v))
+	case float64:
+		return floatToUint64(v)
+	}
+
+	// Named integer types such as `type Port uint16`
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr:
+		return rv.Uint(), true
+	case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+		return uint64(rv.Int()), rv.Int() >= 0
+	}
+	return 0, false
+}
+
+// floatToUint64 converts a whole, non-negative float below 2^64 to uint64
+func floatToUint64(f float64) (uint64, bool) {
+	if f < 0 || f >= 1<<64 || f != math.Trunc(f) {
+		return 0, false
+	}
+	return uint64(f), true
+}
@@ -0,0 +1,584 @@
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Default error messages for uint64 validation
+var (
+	uint64RequiredError = i18n.S("value is required")
+	uint64TypeError     = i18n.S("value must be an unsigned 64-bit integer")
+	uint64EnumError     = i18n.S("value must be one of the allowed values")
+)
+
+// Default error message functions that take parameters
+func uint64MinimumError(min uint64) i18n.TranslatedFunc {
+	return i18n.F("value must be at least %d", min)
+}
+
+func uint64MaximumError(max uint64) i18n.TranslatedFunc {
+	return i18n.F("value must be at most %d", max)
+}
+
+func uint64MultipleOfError(multiple uint64) i18n.TranslatedFunc {
+	return i18n.F("value must be a multiple of %d", multiple)
+}
+
+func uint64ConstError(value uint64) i18n.TranslatedFunc {
+	return i18n.F("value must be exactly: %d", value)
+}
+
+// Uint64Schema represents a JSON Schema for uint64 values. It accepts every Go integer
+// type and whole floats as long as the value is not negative and fits in 64 bits.
+type Uint64Schema struct {
+	Schema
+	coerce bool // Convert compatible input types before validating
+	// Uint64-specific validation (private fields)
+	minimum    *uint64
+	maximum    *uint64
+	multipleOf *uint64
+	nullable   bool
+
+	// Error messages for validation failures (support i18n)
+	requiredError     ErrorMessage
+	minimumError      ErrorMessage
+	maximumError      ErrorMessage
+	multipleOfError   ErrorMessage
+	enumError         ErrorMessage
+	constError        ErrorMessage
+	typeMismatchError ErrorMessage
+}
+
+// Uint64 creates a new uint64 schema with optional type error message
+func Uint64(errorMessage ...interface{}) *Uint64Schema {
+	schema := &Uint64Schema{
+		Schema: Schema{
+			schemaType: "integer",
+			required:   true, // Default to required
+		},
+	}
+	if len(errorMessage) > 0 {
+		schema.typeMismatchError = toErrorMessage(errorMessage[0])
+	}
+	return schema
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *Uint64Schema) Clone() *Uint64Schema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *Uint64Schema) Freeze() *Uint64Schema {
+	s.freeze()
+	return s
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *Uint64Schema) Title(title string) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *Uint64Schema) Description(description string) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *Uint64Schema) ReadOnly() *Uint64Schema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *Uint64Schema) WriteOnly() *Uint64Schema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *Uint64Schema) Sensitive() *Uint64Schema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *Uint64Schema) Deprecated(reason string) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *Uint64Schema) Meta(key string, value interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *Uint64Schema) AsWarning(codes ...ErrorCode) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *Uint64Schema) AsInfo(codes ...ErrorCode) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
+// Default sets the default value
+func (s *Uint64Schema) Default(value interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *Uint64Schema) DefaultFunc(fn func() interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
+	return s
+}
+
+// Example adds an example value
+func (s *Uint64Schema) Example(example uint64) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Enum sets the allowed enum values with optional custom error message
+func (s *Uint64Schema) Enum(values []uint64, errorMessage ...interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.enum = make([]interface{}, len(values))
+	for i, v := range values {
+		s.Schema.enum[i] = v
+	}
+	if len(errorMessage) > 0 {
+		s.enumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Const sets a constant value with optional custom error message
+func (s *Uint64Schema) Const(value uint64, errorMessage ...interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.constVal = value
+	if len(errorMessage) > 0 {
+		s.constError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Required/Optional/Nullable control
+
+// Optional marks the schema as optional
+func (s *Uint64Schema) Optional() *Uint64Schema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the schema as required (default behavior) with optional custom error message
+func (s *Uint64Schema) Required(errorMessage ...interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.required = true
+	if len(errorMessage) > 0 {
+		s.requiredError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Nullable marks the schema as nullable (allows nil values)
+func (s *Uint64Schema) Nullable() *Uint64Schema {
+	s.checkMutable()
+	s.nullable = true
+	return s
+}
+
+// Coerce enables conversion of compatible input types (e.g. strings from query
+// parameters or environment variables) before validation
+func (s *Uint64Schema) Coerce() *Uint64Schema {
+	s.checkMutable()
+	s.coerce = true
+	return s
+}
+
+// coerces returns whether the schema converts compatible input types before validating
+func (s *Uint64Schema) coerces() bool {
+	return s.coerce
+}
+
+// TypeError sets a custom error message for type mismatch validation
+func (s *Uint64Schema) TypeError(message string) *Uint64Schema {
+	s.checkMutable()
+	s.typeMismatchError = toErrorMessage(message)
+	return s
+}
+
+// Uint64-specific fluent API methods
+
+// Min sets the minimum value constraint with optional custom error message
+func (s *Uint64Schema) Min(min uint64, errorMessage ...interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.minimum = &min
+	if len(errorMessage) > 0 {
+		s.minimumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Max sets the maximum value constraint with optional custom error message
+func (s *Uint64Schema) Max(max uint64, errorMessage ...interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.maximum = &max
+	if len(errorMessage) > 0 {
+		s.maximumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Range sets both minimum and maximum values with optional custom error message
+func (s *Uint64Schema) Range(min, max uint64, errorMessage ...interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.minimum = &min
+	s.maximum = &max
+	if len(errorMessage) > 0 {
+		s.minimumError = toErrorMessage(errorMessage[0])
+		s.maximumError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// MultipleOf sets the multiple constraint with optional custom error message
+func (s *Uint64Schema) MultipleOf(multiple uint64, errorMessage ...interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.multipleOf = &multiple
+	if len(errorMessage) > 0 {
+		s.multipleOfError = toErrorMessage(errorMessage[0])
+	}
+	return s
+}
+
+// Positive requires the value to be at least 1, with optional custom error message
+func (s *Uint64Schema) Positive(errorMessage ...interface{}) *Uint64Schema {
+	return s.Min(1, errorMessage...)
+}
+
+// Port requires a valid TCP/UDP port number (1-65535), with optional custom error message
+func (s *Uint64Schema) Port(errorMessage ...interface{}) *Uint64Schema {
+	return s.Range(1, 65535, errorMessage...)
+}
+
+// Getters for accessing private fields
+
+// IsRequired returns whether the schema is marked as required
+func (s *Uint64Schema) IsRequired() bool {
+	return s.Schema.required
+}
+
+// IsOptional returns whether the schema is marked as optional
+func (s *Uint64Schema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// IsNullable returns whether the schema allows nil values
+func (s *Uint64Schema) IsNullable() bool {
+	return s.nullable
+}
+
+// GetMinimum returns the minimum value constraint
+func (s *Uint64Schema) GetMinimum() *uint64 {
+	return s.minimum
+}
+
+// GetMaximum returns the maximum value constraint
+func (s *Uint64Schema) GetMaximum() *uint64 {
+	return s.maximum
+}
+
+// GetMultipleOf returns the multiple constraint
+func (s *Uint64Schema) GetMultipleOf() *uint64 {
+	return s.multipleOf
+}
+
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *Uint64Schema) Transform(fn TransformFunc) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *Uint64Schema) Refine(fn RefineFunc, errorMessage ...interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *Uint64Schema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *Uint64Schema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
+// Validation
+
+// Parse validates and parses a uint64 value, returning the final parsed value
+func (s *Uint64Schema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the uint64 constraints; Parse runs the refine/transform pipeline on top
+func (s *Uint64Schema) parse(value interface{}, ctx *ValidationContext) ParseResult {
+	// Convert compatible input types first when coercion is enabled
+	if s.coerce || ctx.Coerce {
+		value = coerceUint(value)
+	}
+
+	var errors []ValidationError
+
+	// Handle nil values
+	if value == nil {
+		if s.nullable {
+			// For nullable schemas, nil is a valid value
+			return ParseResult{Valid: true, Value: nil, Errors: nil}
+		}
+		if s.Schema.required {
+			// Check if we have a default value to use instead
+			if defaultVal := s.GetDefault(); defaultVal != nil {
+				// Use default value and re-parse it
+				return s.parse(defaultVal, ctx)
+			}
+			// No default, required field is missing
+			message := uint64RequiredError(ctx.Locale)
+			if !isEmptyErrorMessage(s.requiredError) {
+				message = resolveErrorMessage(s.requiredError, ctx)
+			}
+			return ParseResult{
+				Valid:  false,
+				Value:  nil,
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
+			}
+		}
+		// Optional field, use default if available
+		if defaultVal := s.GetDefault(); defaultVal != nil {
+			return s.parse(defaultVal, ctx)
+		}
+		// Optional field with no default
+		return ParseResult{Valid: true, Value: nil, Errors: nil}
+	}
+
+	// Negative, fractional and out-of-range values are type errors
+	n, ok := toUint64(value)
+	if !ok {
+		message := uint64TypeError(ctx.Locale)
+		if !isEmptyErrorMessage(s.typeMismatchError) {
+			message = resolveErrorMessage(s.typeMismatchError, ctx)
+		}
+		return ParseResult{
+			Valid:  false,
+			Value:  nil,
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeInvalidType)},
+		}
+	}
+	uint64Value := uint64(n)
+
+	// Check minimum
+	if s.minimum != nil && uint64Value < *s.minimum {
+		message := uint64MinimumError(*s.minimum)(ctx.Locale)
+		if !isEmptyErrorMessage(s.minimumError) {
+			message = resolveErrorMessage(s.minimumError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(uint64Value, message, CodeMinimum))
+	}
+
+	// Check maximum
+	if s.maximum != nil && uint64Value > *s.maximum {
+		message := uint64MaximumError(*s.maximum)(ctx.Locale)
+		if !isEmptyErrorMessage(s.maximumError) {
+			message = resolveErrorMessage(s.maximumError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(uint64Value, message, CodeMaximum))
+	}
+
+	// Check multipleOf
+	if s.multipleOf != nil && *s.multipleOf != 0 && uint64Value%*s.multipleOf != 0 {
+		message := uint64MultipleOfError(*s.multipleOf)(ctx.Locale)
+		if !isEmptyErrorMessage(s.multipleOfError) {
+			message = resolveErrorMessage(s.multipleOfError, ctx)
+		}
+		errors = append(errors, NewPrimitiveError(uint64Value, message, CodeMultipleOf))
+	}
+
+	// Check enum
+	if len(s.Schema.enum) > 0 {
+		valid := false
+		for _, enumValue := range s.Schema.enum {
+			if enumValue == uint64Value {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			message := uint64EnumError(ctx.Locale)
+			if !isEmptyErrorMessage(s.enumError) {
+				message = resolveErrorMessage(s.enumError, ctx)
+			}
+			errors = append(errors, NewPrimitiveError(uint64Value, message, CodeEnum))
+		}
+	}
+
+	// Check const
+	if s.Schema.constVal != nil {
+		if constValue, ok := s.Schema.constVal.(uint64); ok && constValue != uint64Value {
+			message := uint64ConstError(constValue)(ctx.Locale)
+			if !isEmptyErrorMessage(s.constError) {
+				message = resolveErrorMessage(s.constError, ctx)
+			}
+			errors = append(errors, NewPrimitiveError(uint64Value, message, CodeConst))
+		}
+	}
+
+	return ParseResult{
+		Valid:  len(errors) == 0,
+		Value:  uint64Value,
+		Errors: errors,
+	}
+}
+
+// JSON generates JSON Schema representation; the minimum defaults to 0
+func (s *Uint64Schema) JSON() map[string]interface{} {
+	schema := baseJSONSchema("integer")
+
+	// Add base schema fields
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
+	addOptionalArray(schema, "examples", s.GetExamples())
+	addOptionalArray(schema, "enum", s.GetEnum())
+	addOptionalField(schema, "const", s.GetConst())
+
+	// Add uint64-specific fields
+	schema["minimum"] = 0
+	if s.minimum != nil {
+		schema["minimum"] = *s.minimum
+	}
+	if s.maximum != nil {
+		schema["maximum"] = *s.maximum
+	}
+	if s.multipleOf != nil {
+		schema["multipleOf"] = *s.multipleOf
+	}
+
+	// Add format to indicate the integer width
+	schema["format"] = "uint64"
+
+	// Add nullable if true
+	if s.nullable {
+		schema["type"] = []string{"integer", "null"}
+	}
+
+	return schema
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize Uint64Schema for JSON schema generation
+func (s *Uint64Schema) MarshalJSON() ([]byte, error) {
+	type jsonUint64Schema struct {
+		Schema
+		Minimum    *uint64 `json:"minimum,omitempty"`
+		Maximum    *uint64 `json:"maximum,omitempty"`
+		MultipleOf *uint64 `json:"multipleOf,omitempty"`
+		Format     string  `json:"format"`
+		Nullable   bool    `json:"nullable,omitempty"`
+	}
+
+	return json.Marshal(jsonUint64Schema{
+		Schema:     s.Schema,
+		Minimum:    s.minimum,
+		Maximum:    s.maximum,
+		MultipleOf: s.multipleOf,
+		Format:     "uint64",
+		Nullable:   s.nullable,
+	})
+}
+
+// Interface implementations for Uint64Schema
+
+// SetTitle implements SetTitle interface
+func (s *Uint64Schema) SetTitle(title string) {
+	s.Title(title)
+}
+
+// SetDescription implements SetDescription interface
+func (s *Uint64Schema) SetDescription(description string) {
+	s.Description(description)
+}
+
+// SetRequired implements SetRequired interface
+func (s *Uint64Schema) SetRequired() {
+	s.Required()
+}
+
+// SetOptional implements SetOptional interface
+func (s *Uint64Schema) SetOptional() {
+	s.Optional()
+}
+
+// SetMinimum implements SetMinimum interface; negative bounds are ignored
+func (s *Uint64Schema) SetMinimum(min int) {
+	if min >= 0 {
+		s.Min(uint64(min))
+	}
+}
+
+// SetMaximum implements SetMaximum interface; negative bounds are ignored
+func (s *Uint64Schema) SetMaximum(max int) {
+	if max >= 0 {
+		s.Max(uint64(max))
+	}
+}
+
+// SetNullable implements SetNullable interface
+func (s *Uint64Schema) SetNullable() {
+	s.Nullable()
+}
+
+// SetDefault implements SetDefault interface
+func (s *Uint64Schema) SetDefault(value interface{}) {
+	s.Default(value)
+}
+
+// SetExample implements SetExample interface
+func (s *Uint64Schema) SetExample(example interface{}) {
+	if val, ok := example.(uint64); ok {
+		s.Example(val)
+	}
+}
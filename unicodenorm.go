@@ -0,0 +1,196 @@
+package schema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// NormalizationForm selects a Unicode normalization form (UAX #15)
+type NormalizationForm string
+
+const (
+	NFC  NormalizationForm = "NFC"  // Canonical decomposition, then canonical composition
+	NFD  NormalizationForm = "NFD"  // Canonical decomposition
+	NFKC NormalizationForm = "NFKC" // Compatibility decomposition, then canonical composition
+	NFKD NormalizationForm = "NFKD" // Compatibility decomposition
+)
+
+// Hangul syllable constants from the Unicode standard, section 3.12
+const (
+	hangulSBase  = 0xAC00
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulSCount = hangulLCount * hangulNCount
+)
+
+// unicodeNormData holds the parsed normalization tables
+type unicodeNormData struct {
+	combiningClass map[rune]uint8
+	canonical      map[rune][]rune
+	compatibility  map[rune][]rune
+	compositions   map[[2]rune]rune
+}
+
+var (
+	unicodeNormOnce   sync.Once
+	unicodeNormTables unicodeNormData
+)
+
+// normData parses the generated tables the first time they are needed
+func normData() *unicodeNormData {
+	unicodeNormOnce.Do(func() {
+		unicodeNormTables.combiningClass = map[rune]uint8{}
+		for _, entry := range strings.Fields(unicodeCombiningClasses) {
+			codes, class, _ := strings.Cut(entry, ":")
+			first, last, isRange := strings.Cut(codes, "-")
+			if !isRange {
+				last = first
+			}
+			value, _ := strconv.ParseUint(class, 10, 8)
+			for r := parseCodePoint(first); r <= parseCodePoint(last); r++ {
+				unicodeNormTables.combiningClass[r] = uint8(value)
+			}
+		}
+		unicodeNormTables.canonical = parseDecompositions(unicodeCanonicalDecompositions)
+		unicodeNormTables.compatibility = parseDecompositions(unicodeCompatibilityDecompositions)
+		unicodeNormTables.compositions = map[[2]rune]rune{}
+		for composite, pair := range parseDecompositions(unicodeCompositions) {
+			unicodeNormTables.compositions[[2]rune{pair[0], pair[1]}] = composite
+		}
+	})
+	return &unicodeNormTables
+}
+
+// parseDecompositions parses a "code=code+code" table
+func parseDecompositions(table string) map[rune][]rune {
+	decompositions := map[rune][]rune{}
+	for _, entry := range strings.Fields(table) {
+		code, sequence, _ := strings.Cut(entry, "=")
+		parts := strings.Split(sequence, "+")
+		runes := make([]rune, len(parts))
+		for i, part := range parts {
+			runes[i] = parseCodePoint(part)
+		}
+		decompositions[parseCodePoint(code)] = runes
+	}
+	return decompositions
+}
+
+func parseCodePoint(hex string) rune {
+	value, _ := strconv.ParseUint(hex, 16, 32)
+	return rune(value)
+}
+
+// normalizeUnicode returns str in the given normalization form. Invalid UTF-8 is
+// replaced with U+FFFD.
+func normalizeUnicode(str string, form NormalizationForm) string {
+	// ASCII is invariant under every form
+	ascii := true
+	for i := 0; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return str
+	}
+
+	data := normData()
+	runes := data.decompose(str, form == NFKC || form == NFKD)
+	if form == NFC || form == NFKC {
+		runes = data.compose(runes)
+	}
+	return string(runes)
+}
+
+// decompose applies the full canonical or compatibility decomposition, then puts
+// combining marks in canonical order
+func (d *unicodeNormData) decompose(str string, compatibility bool) []rune {
+	runes := make([]rune, 0, len(str))
+	for _, r := range str {
+		if s := r - hangulSBase; s >= 0 && s < hangulSCount {
+			runes = append(runes, hangulLBase+s/hangulNCount, hangulVBase+(s%hangulNCount)/hangulTCount)
+			if t := s % hangulTCount; t != 0 {
+				runes = append(runes, hangulTBase+t)
+			}
+			continue
+		}
+		if compatibility {
+			if decomposition, ok := d.compatibility[r]; ok {
+				runes = append(runes, decomposition...)
+				continue
+			}
+		}
+		if decomposition, ok := d.canonical[r]; ok {
+			runes = append(runes, decomposition...)
+			continue
+		}
+		runes = append(runes, r)
+	}
+
+	// Sort each run of combining marks by class, keeping the order of equal classes
+	for start := 0; start < len(runes); {
+		if d.combiningClass[runes[start]] == 0 {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && d.combiningClass[runes[end]] != 0 {
+			end++
+		}
+		marks := runes[start:end]
+		sort.SliceStable(marks, func(i, j int) bool {
+			return d.combiningClass[marks[i]] < d.combiningClass[marks[j]]
+		})
+		start = end
+	}
+	return runes
+}
+
+// compose applies the canonical composition algorithm to decomposed, ordered runes
+func (d *unicodeNormData) compose(runes []rune) []rune {
+	result := runes[:0]
+	starter := -1
+	var lastClass uint8
+	for _, r := range runes {
+		class := d.combiningClass[r]
+		if starter >= 0 {
+			// r is blocked from the starter by an intervening mark of the same or higher class
+			adjacent := starter == len(result)-1
+			if adjacent || lastClass != 0 && lastClass < class {
+				if composite, ok := d.composePair(result[starter], r); ok {
+					result[starter] = composite
+					continue
+				}
+			}
+		}
+		if class == 0 {
+			starter = len(result)
+		}
+		lastClass = class
+		result = append(result, r)
+	}
+	return result
+}
+
+// composePair returns the primary composite of a and b, if any
+func (d *unicodeNormData) composePair(a, b rune) (rune, bool) {
+	// Hangul: leading consonant + vowel, then LV syllable + trailing consonant
+	if l, v := a-hangulLBase, b-hangulVBase; l >= 0 && l < hangulLCount && v >= 0 && v < hangulVCount {
+		return hangulSBase + (l*hangulVCount+v)*hangulTCount, true
+	}
+	if s, t := a-hangulSBase, b-hangulTBase; s >= 0 && s < hangulSCount && s%hangulTCount == 0 && t > 0 && t < hangulTCount {
+		return a + t, true
+	}
+	composite, ok := d.compositions[[2]rune{a, b}]
+	return composite, ok
+}
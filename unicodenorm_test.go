@@ -0,0 +1,38 @@
+package schema
+
+import "testing"
+
+func TestNormalizeUnicode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		nfc   string
+		nfd   string
+		nfkc  string
+		nfkd  string
+	}{
+		{"ascii", "plain", "plain", "plain", "plain", "plain"},
+		{"precomposed", "\u00e9", "\u00e9", "e\u0301", "\u00e9", "e\u0301"},
+		{"decomposed", "e\u0301", "\u00e9", "e\u0301", "\u00e9", "e\u0301"},
+		{"mark order", "a\u0301\u0323", "\u1ea1\u0301", "a\u0323\u0301", "\u1ea1\u0301", "a\u0323\u0301"},
+		{"two marks", "a\u0323\u0302", "\u1ead", "a\u0323\u0302", "\u1ead", "a\u0323\u0302"},
+		{"singleton", "\u212b", "\u00c5", "A\u030a", "\u00c5", "A\u030a"},
+		{"composition exclusion", "\u0958", "\u0915\u093c", "\u0915\u093c", "\u0915\u093c", "\u0915\u093c"},
+		{"ligature", "\ufb01le", "\ufb01le", "\ufb01le", "file", "file"},
+		{"fullwidth", "\uff21\uff11", "\uff21\uff11", "\uff21\uff11", "A1", "A1"},
+		{"superscript", "x\u00b2", "x\u00b2", "x\u00b2", "x2", "x2"},
+		{"hangul syllable", "\ud55c", "\ud55c", "\u1112\u1161\u11ab", "\ud55c", "\u1112\u1161\u11ab"},
+		{"hangul jamo", "\u1112\u1161\u11ab", "\ud55c", "\u1112\u1161\u11ab", "\ud55c", "\u1112\u1161\u11ab"},
+		{"invalid utf-8", "a\xffb", "a\ufffdb", "a\ufffdb", "a\ufffdb", "a\ufffdb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for form, expected := range map[NormalizationForm]string{NFC: tt.nfc, NFD: tt.nfd, NFKC: tt.nfkc, NFKD: tt.nfkd} {
+				if got := normalizeUnicode(tt.input, form); got != expected {
+					t.Errorf("%s(%+q) = %+q, want %+q", form, tt.input, got, expected)
+				}
+			}
+		})
+	}
+}
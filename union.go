@@ -3,6 +3,7 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/nyxstack/i18n"
 )
@@ -20,6 +21,7 @@ type UnionSchema struct {
 	schemas   []Parseable // The schemas to validate against
 	nullable  bool        // Allow null values
 	allowNone bool        // Allow values that match none of the schemas
+	fast      bool        // Accept the first matching schema without checking the others
 
 	// Error messages for validation failures (support i18n)
 	requiredError      ErrorMessage
@@ -40,6 +42,36 @@ func Union(schemas ...Parseable) *UnionSchema {
 	return schema
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+// Nested schemas are shared.
+func (s *UnionSchema) Clone() *UnionSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	c.schemas = slices.Clone(s.schemas)
+	return &c
+}
+
+// Freeze makes the schema and its schemas immutable: any later modification
+// panics. A frozen schema is safe to share and to use from several goroutines.
+func (s *UnionSchema) Freeze() *UnionSchema {
+	s.freeze()
+	return s
+}
+
+// freeze freezes the schema and its schemas
+func (s *UnionSchema) freeze() {
+	if s.frozen {
+		return
+	}
+	s.Schema.freeze()
+	freezeSchemas(s.schemas...)
+}
+
+// Children returns the schemas of the union, which apply to the value itself
+func (s *UnionSchema) Children() []SchemaChild {
+	return sameValueChildren(s.schemas...)
+}
+
 // OneOf is an alias for Union for JSON Schema compatibility
 func OneOf(schemas ...Parseable) *UnionSchema {
 	return Union(schemas...)
@@ -49,24 +81,91 @@ func OneOf(schemas ...Parseable) *UnionSchema {
 
 // Title sets the title of the schema
 func (s *UnionSchema) Title(title string) *UnionSchema {
+	s.checkMutable()
 	s.Schema.title = title
 	return s
 }
 
 // Description sets the description of the schema
 func (s *UnionSchema) Description(description string) *UnionSchema {
+	s.checkMutable()
 	s.Schema.description = description
 	return s
 }
 
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *UnionSchema) ReadOnly() *UnionSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *UnionSchema) WriteOnly() *UnionSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *UnionSchema) Sensitive() *UnionSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *UnionSchema) Deprecated(reason string) *UnionSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *UnionSchema) Meta(key string, value interface{}) *UnionSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// AsWarning reports the errors with the given codes (e.g. "max_length"), or all errors
+// when no code is given, as warnings that do not fail validation
+func (s *UnionSchema) AsWarning(codes ...ErrorCode) *UnionSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityWarning, codes)
+	return s
+}
+
+// AsInfo reports the errors with the given codes, or all errors when no code is
+// given, as informational notices that do not fail validation
+func (s *UnionSchema) AsInfo(codes ...ErrorCode) *UnionSchema {
+	s.checkMutable()
+	s.Schema.setSeverity(SeverityInfo, codes)
+	return s
+}
+
 // Default sets the default value
 func (s *UnionSchema) Default(value interface{}) *UnionSchema {
+	s.checkMutable()
 	s.Schema.defaultValue = value
+	s.Schema.defaultFunc = nil
+	return s
+}
+
+// DefaultFunc sets a function that computes the default value each time one is needed
+// (e.g. the current time or a fresh ID), instead of a value captured when the schema is built
+func (s *UnionSchema) DefaultFunc(fn func() interface{}) *UnionSchema {
+	s.checkMutable()
+	s.Schema.defaultFunc = fn
 	return s
 }
 
 // Example adds an example value
 func (s *UnionSchema) Example(example interface{}) *UnionSchema {
+	s.checkMutable()
 	s.Schema.examples = append(s.Schema.examples, example)
 	return s
 }
@@ -75,6 +174,7 @@ func (s *UnionSchema) Example(example interface{}) *UnionSchema {
 
 // Add appends additional schemas to the union
 func (s *UnionSchema) Add(schemas ...Parseable) *UnionSchema {
+	s.checkMutable()
 	s.schemas = append(s.schemas, schemas...)
 	return s
 }
@@ -88,12 +188,14 @@ func (s *UnionSchema) Schemas() []Parseable {
 
 // Optional marks the schema as optional
 func (s *UnionSchema) Optional() *UnionSchema {
+	s.checkMutable()
 	s.Schema.required = false
 	return s
 }
 
 // Required marks the schema as required (default behavior) with optional custom error message
 func (s *UnionSchema) Required(errorMessage ...interface{}) *UnionSchema {
+	s.checkMutable()
 	s.Schema.required = true
 	if len(errorMessage) > 0 {
 		s.requiredError = toErrorMessage(errorMessage[0])
@@ -103,32 +205,46 @@ func (s *UnionSchema) Required(errorMessage ...interface{}) *UnionSchema {
 
 // Nullable marks the schema as nullable (allows nil values)
 func (s *UnionSchema) Nullable() *UnionSchema {
+	s.checkMutable()
 	s.nullable = true
 	return s
 }
 
 // AllowNone allows values that don't match any schema (makes union more permissive)
 func (s *UnionSchema) AllowNone() *UnionSchema {
+	s.checkMutable()
 	s.allowNone = true
 	return s
 }
 
+// Fast makes the union accept the first schema, in order, that the value matches,
+// instead of checking that exactly one matches. Values that match several schemas are
+// then valid, as with AnyOf, so the JSON Schema uses anyOf.
+func (s *UnionSchema) Fast() *UnionSchema {
+	s.checkMutable()
+	s.fast = true
+	return s
+}
+
 // Error customization
 
 // NoMatchError sets a custom error message when no schemas match
 func (s *UnionSchema) NoMatchError(message string) *UnionSchema {
+	s.checkMutable()
 	s.noMatchError = toErrorMessage(message)
 	return s
 }
 
 // MultipleMatchError sets a custom error message when multiple schemas match
 func (s *UnionSchema) MultipleMatchError(message string) *UnionSchema {
+	s.checkMutable()
 	s.multipleMatchError = toErrorMessage(message)
 	return s
 }
 
 // TypeError sets a custom error message for type mismatch validation
 func (s *UnionSchema) TypeError(message string) *UnionSchema {
+	s.checkMutable()
 	s.typeMismatchError = toErrorMessage(message)
 	return s
 }
@@ -150,19 +266,50 @@ func (s *UnionSchema) IsNullable() bool {
 	return s.nullable
 }
 
+// IsFast returns whether the union accepts the first matching schema
+func (s *UnionSchema) IsFast() bool {
+	return s.fast
+}
+
 // GetSchemaCount returns the number of schemas in the union
 func (s *UnionSchema) GetSchemaCount() int {
 	return len(s.schemas)
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *UnionSchema) Transform(fn TransformFunc) *UnionSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *UnionSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *UnionSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *UnionSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *UnionSchema {
+	s.checkMutable()
+	s.Schema.effects = s.Schema.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Validation
 
 // Parse validates and parses a union value, returning the final parsed value
 func (s *UnionSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.Schema.effects.apply(ctx.startParse(s), s.parse(value, s.Schema.effects.valueContext(ctx)), ctx, s, value)
+}
+
+// parse applies the union constraints; Parse runs the refine/transform pipeline on top
+func (s *UnionSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
-	// Handle nil values
-	if value == nil {
+	// Handle nil values, unless one of the schemas is a null schema that validates them
+	if value == nil && !hasNullSchema(s.schemas) {
 		if s.nullable {
 			// For nullable schemas, nil is a valid value
 			return ParseResult{Valid: true, Value: nil, Errors: nil}
@@ -171,7 +318,7 @@ func (s *UnionSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			// Check if we have a default value to use instead
 			if defaultVal := s.GetDefault(); defaultVal != nil {
 				// Use default value and re-parse it
-				return s.Parse(defaultVal, ctx)
+				return s.parse(defaultVal, ctx)
 			}
 			// No default, required field is missing
 			message := unionRequiredError(ctx.Locale)
@@ -181,34 +328,48 @@ func (s *UnionSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			return ParseResult{
 				Valid:  false,
 				Value:  nil,
-				Errors: []ValidationError{NewPrimitiveError(value, message, "required")},
+				Errors: []ValidationError{NewPrimitiveError(value, message, CodeRequired)},
 			}
 		}
 		// Optional field, use default if available
 		if defaultVal := s.GetDefault(); defaultVal != nil {
-			return s.Parse(defaultVal, ctx)
+			return s.parse(defaultVal, ctx)
 		}
 		// Optional field with no default
 		return ParseResult{Valid: true, Value: nil, Errors: nil}
 	}
 
-	// Validate against each schema in the union
+	// Validate against each schema in the union that can accept the value's kind
+	kind := kindOfValue(value)
 	var validResults []ParseResult
 	var allErrors []ValidationError
 
 	for i, schema := range s.schemas {
+		if !acceptsKind(schema, kind, ctx) {
+			continue
+		}
 		result := schema.Parse(value, ctx)
 		if result.Valid {
+			if s.fast {
+				return result
+			}
 			validResults = append(validResults, result)
+			if len(validResults) > 1 {
+				// A second match decides the result; the other schemas need not be tried
+				break
+			}
 		} else {
 			// Collect errors from failed schemas for debugging
 			for _, err := range result.Errors {
 				// Add context about which schema failed
 				contextualErr := ValidationError{
-					Path:    append([]string{fmt.Sprintf("schema_%d", i)}, err.Path...),
+					Path:    append(Path{FieldSegment(fmt.Sprintf("schema_%d", i))}, err.Path...),
 					Value:   err.Value,
 					Message: err.Message,
 					Code:    err.Code,
+					Params:  err.Params,
+
+					rendered: err.rendered,
 				}
 				allErrors = append(allErrors, contextualErr)
 			}
@@ -227,7 +388,7 @@ func (s *UnionSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 			message = resolveErrorMessage(s.noMatchError, ctx)
 		}
 		// Return the original value with no match error, plus all schema errors for context
-		errors = append(errors, NewPrimitiveError(value, message, "no_match"))
+		errors = append(errors, NewPrimitiveError(value, message, CodeNoMatch))
 		// Also include all the individual schema errors for debugging
 		errors = append(errors, allErrors...)
 		return ParseResult{
@@ -246,7 +407,7 @@ func (s *UnionSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 		return ParseResult{
 			Valid:  false,
 			Value:  nil,
-			Errors: []ValidationError{NewPrimitiveError(value, message, "multiple_match")},
+			Errors: []ValidationError{NewPrimitiveError(value, message, CodeMultipleMatch)},
 		}
 	}
 
@@ -254,33 +415,117 @@ func (s *UnionSchema) Parse(value interface{}, ctx *ValidationContext) ParseResu
 	return validResults[0]
 }
 
+// valueKind is the kind of a decoded JSON value, used to skip union schemas that
+// cannot accept it
+type valueKind uint8
+
+const (
+	kindOther valueKind = iota // Any other Go type; every schema is tried
+	kindString
+	kindNumber
+	kindBool
+	kindObject
+	kindArray
+)
+
+// kindOfValue returns the kind of a value as decoded by encoding/json
+func kindOfValue(value interface{}) valueKind {
+	switch value.(type) {
+	case string:
+		return kindString
+	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
+		return kindNumber
+	case bool:
+		return kindBool
+	case map[string]interface{}:
+		return kindObject
+	case []interface{}:
+		return kindArray
+	}
+	return kindOther
+}
+
+// hasNullSchema returns whether one of the schemas has the JSON type null, as Null()
+// and Literal(nil) do
+func hasNullSchema(schemas []Parseable) bool {
+	for _, schema := range schemas {
+		if typed, ok := schema.(interface{ GetType() string }); ok && typed.GetType() == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+// coercing is implemented by schemas that can convert input of other types
+type coercing interface {
+	coerces() bool
+}
+
+// acceptsKind returns whether the schema may accept a value of the kind. Schemas are
+// only ruled out by their JSON type, and not when they convert their input.
+func acceptsKind(schema Parseable, kind valueKind, ctx *ValidationContext) bool {
+	if kind == kindOther || ctx.Coerce {
+		return true
+	}
+	if c, ok := schema.(coercing); ok && c.coerces() {
+		return true
+	}
+	typed, ok := schema.(interface{ GetType() string })
+	if !ok {
+		return true
+	}
+	switch typed.GetType() {
+	case "string":
+		return kind == kindString
+	case "integer", "number":
+		return kind == kindNumber
+	case "boolean":
+		return kind == kindBool
+	case "object":
+		return kind == kindObject
+	case "array":
+		return kind == kindArray
+	}
+	return true
+}
+
 // JSON generates JSON Schema representation
 func (s *UnionSchema) JSON() map[string]interface{} {
+	return rootJSON(s.generateJSON)
+}
+
+// generateJSON generates the JSON Schema within the generation g
+func (s *UnionSchema) generateJSON(g *jsonGeneration) map[string]interface{} {
 	schema := make(map[string]interface{})
 
 	// Generate oneOf array with all schemas
 	oneOfSchemas := make([]interface{}, len(s.schemas))
 	for i, subSchema := range s.schemas {
-		if jsonSchema, ok := subSchema.(interface{ JSON() map[string]interface{} }); ok {
-			oneOfSchemas[i] = jsonSchema.JSON()
+		if subJSON, ok := childJSON(subSchema, g); ok {
+			oneOfSchemas[i] = subJSON
 		} else {
 			// Fallback for schemas that don't implement JSON method
 			oneOfSchemas[i] = map[string]interface{}{"type": "unknown"}
 		}
 	}
-	schema["oneOf"] = oneOfSchemas
+	keyword := "oneOf"
+	if s.fast {
+		keyword = "anyOf"
+	}
+	schema[keyword] = oneOfSchemas
 
 	// Add base schema fields
 	addTitle(schema, s.GetTitle())
 	addDescription(schema, s.GetDescription())
-	addOptionalField(schema, "default", s.GetDefault())
+	addAnnotations(schema, &s.Schema)
+	addOptionalField(schema, "default", s.staticDefault())
 	addOptionalArray(schema, "examples", s.GetExamples())
 
 	// Add nullable if true
 	if s.nullable {
 		// Add null to the oneOf array
 		oneOfSchemas = append(oneOfSchemas, map[string]interface{}{"type": "null"})
-		schema["oneOf"] = oneOfSchemas
+		schema[keyword] = oneOfSchemas
 	}
 
 	return schema
@@ -293,6 +538,7 @@ func (s *UnionSchema) MarshalJSON() ([]byte, error) {
 		Schemas   []Parseable `json:"schemas"`
 		Nullable  bool        `json:"nullable,omitempty"`
 		AllowNone bool        `json:"allowNone,omitempty"`
+		Fast      bool        `json:"fast,omitempty"`
 	}
 
 	return json.Marshal(jsonUnionSchema{
@@ -300,5 +546,6 @@ func (s *UnionSchema) MarshalJSON() ([]byte, error) {
 		Schemas:   s.schemas,
 		Nullable:  s.nullable,
 		AllowNone: s.allowNone,
+		Fast:      s.fast,
 	})
 }
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnionSchema_Dispatch(t *testing.T) {
+	union := func() *UnionSchema {
+		return Union(String().MinLength(3), Int().Min(0), Object().Property("id", Int()))
+	}
+
+	tests := []struct {
+		name   string
+		schema *UnionSchema
+		value  interface{}
+		ctx    *ValidationContext
+		want   []string
+	}{
+		{"string", union(), "abc", nil, []string{}},
+		{"number", union(), float64(7), nil, []string{}},
+		{"object", union(), map[string]interface{}{"id": float64(1)}, nil, []string{}},
+		{"only the string schema is tried", union(), "ab", nil, []string{" no_match", "schema_0 min_length"}},
+		{"only the int schema is tried", union(), float64(-1), nil, []string{" no_match", "schema_1 minimum"}},
+		{"no schema accepts the kind", union(), true, nil, []string{" no_match"}},
+		{"other Go types try every schema", Union(String(), Int()), struct{}{}, nil, []string{" no_match", "schema_0 invalid_type", "schema_1 invalid_type"}},
+		{"coerced schemas are tried", Union(Bool(), Int().Coerce()), "42", nil, []string{}},
+		{"coercing context tries every schema", Union(Bool(), Int()), "true", DefaultValidationContext().WithCoercion(), []string{}},
+		{"multiple matches", Union(Int(), Number()), float64(1), nil, []string{" multiple_match"}},
+		{"fast accepts the first match", Union(Int(), Number()).Fast(), float64(1), nil, []string{}},
+		{"fast reports failures", Union(Int(), Number()).Fast(), "1", nil, []string{" no_match"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = DefaultValidationContext()
+			}
+			result := tt.schema.Parse(tt.value, ctx)
+			if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnionSchema_FastJSON(t *testing.T) {
+	if _, ok := Union(String(), Int()).JSON()["oneOf"]; !ok {
+		t.Error("union should generate oneOf")
+	}
+	if _, ok := Union(String(), Int()).Fast().JSON()["anyOf"]; !ok {
+		t.Error("fast union should generate anyOf")
+	}
+}
+
+func BenchmarkUnionParse(b *testing.B) {
+	union := Union(
+		Object().Property("kind", Literal("circle")).Property("radius", Number()),
+		String().MinLength(1),
+		Int(),
+		Array(Int()),
+	)
+	ctx := DefaultValidationContext()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		union.Parse(float64(i), ctx)
+	}
+}
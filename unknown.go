@@ -0,0 +1,166 @@
+package schema
+
+import (
+	"encoding/json"
+)
+
+// Untrusted is the parsed value of an Unknown schema: the input, accepted as it is
+// without validation. Narrow it with a schema before using it.
+type Untrusted struct {
+	value interface{}
+}
+
+// Raw returns the unvalidated value
+func (u Untrusted) Raw() interface{} {
+	return u.value
+}
+
+// Narrow validates the value against schema, returning the schema's result
+func (u Untrusted) Narrow(schema Parseable, ctx *ValidationContext) ParseResult {
+	return schema.Parse(u.value, ctx)
+}
+
+// MarshalJSON encodes the underlying value
+func (u Untrusted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.value)
+}
+
+// UnknownSchema represents a schema that accepts any value, nil included, like Any,
+// but marks the parsed value as untrusted: it is returned as an Untrusted, so code
+// using it has to narrow it with a schema first
+type UnknownSchema struct {
+	Schema
+}
+
+// Unknown creates a new schema that accepts any value as an Untrusted
+func Unknown() *UnknownSchema {
+	return &UnknownSchema{
+		Schema: Schema{
+			schemaType: "", // No specific type, as for Any
+			required:   false,
+		},
+	}
+}
+
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *UnknownSchema) Clone() *UnknownSchema {
+	c := *s
+	c.Schema = s.Schema.cloneBase()
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *UnknownSchema) Freeze() *UnknownSchema {
+	s.freeze()
+	return s
+}
+
+// Core fluent API methods
+
+// Title sets the title of the schema
+func (s *UnknownSchema) Title(title string) *UnknownSchema {
+	s.checkMutable()
+	s.Schema.title = title
+	return s
+}
+
+// Description sets the description of the schema
+func (s *UnknownSchema) Description(description string) *UnknownSchema {
+	s.checkMutable()
+	s.Schema.description = description
+	return s
+}
+
+// ReadOnly marks the value as read-only: documented in responses, dropped from writes
+func (s *UnknownSchema) ReadOnly() *UnknownSchema {
+	s.checkMutable()
+	s.Schema.readOnly = true
+	s.Schema.writeOnly = false
+	return s
+}
+
+// WriteOnly marks the value as write-only: accepted in writes, never returned
+func (s *UnknownSchema) WriteOnly() *UnknownSchema {
+	s.checkMutable()
+	s.Schema.writeOnly = true
+	s.Schema.readOnly = false
+	return s
+}
+
+// Sensitive masks the value in errors (see RedactedValue), so secrets do not reach logs
+func (s *UnknownSchema) Sensitive() *UnknownSchema {
+	s.checkMutable()
+	s.Schema.sensitive = true
+	return s
+}
+
+// Deprecated marks the value as deprecated, with an optional reason such as its replacement
+func (s *UnknownSchema) Deprecated(reason string) *UnknownSchema {
+	s.checkMutable()
+	s.Schema.deprecated = true
+	s.Schema.deprecationReason = reason
+	return s
+}
+
+// Meta sets a metadata entry, emitted as an "x-" keyword in JSON Schema
+func (s *UnknownSchema) Meta(key string, value interface{}) *UnknownSchema {
+	s.checkMutable()
+	s.Schema.setMeta(key, value)
+	return s
+}
+
+// Example adds an example value
+func (s *UnknownSchema) Example(example interface{}) *UnknownSchema {
+	s.checkMutable()
+	s.Schema.examples = append(s.Schema.examples, example)
+	return s
+}
+
+// Required/Optional control
+
+// Optional marks the property as optional in an object (default behavior)
+func (s *UnknownSchema) Optional() *UnknownSchema {
+	s.checkMutable()
+	s.Schema.required = false
+	return s
+}
+
+// Required marks the property as required in an object; nil is still accepted
+func (s *UnknownSchema) Required() *UnknownSchema {
+	s.checkMutable()
+	s.Schema.required = true
+	return s
+}
+
+// Getters for accessing private fields
+
+// IsOptional returns whether the schema is marked as optional
+func (s *UnknownSchema) IsOptional() bool {
+	return !s.Schema.required
+}
+
+// Validation
+
+// Parse accepts the value and returns it as an Untrusted
+func (s *UnknownSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return ParseResult{Valid: true, Value: Untrusted{value: value}, Errors: nil}
+}
+
+// JSON generates JSON Schema representation: the empty schema, which matches every value
+func (s *UnknownSchema) JSON() map[string]interface{} {
+	schema := make(map[string]interface{})
+
+	// Add base schema fields
+	addTitle(schema, s.GetTitle())
+	addDescription(schema, s.GetDescription())
+	addAnnotations(schema, &s.Schema)
+	addOptionalArray(schema, "examples", s.GetExamples())
+
+	return schema
+}
+
+// MarshalJSON implements json.Marshaler to properly serialize UnknownSchema for JSON schema generation
+func (s *UnknownSchema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Schema)
+}
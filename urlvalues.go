@@ -0,0 +1,142 @@
+package schema
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseURLValues validates query string or form values against an object schema.
+// Keys are mapped onto the schema's structure:
+//
+//	tags[]=a&tags[]=b      appends to the array property tags
+//	tags=a&tags=b          repeated keys also fill array properties
+//	user.name=bob          sets name in the nested object user
+//	user[name]=bob         the same, in bracket notation
+//	items[0][sku]=x        indexes into arrays of objects
+//
+// Scalars are coerced to the type of their property schema ("42" -> 42), as if
+// ctx had coercion enabled. A nil ctx uses DefaultValidationContext.
+func ParseURLValues(s *ObjectSchema, values url.Values, ctx *ValidationContext) ParseResult {
+	if ctx == nil {
+		ctx = DefaultValidationContext()
+	}
+	coercing := *ctx
+	coercing.Coerce = true
+	return s.Parse(urlValuesToMap(s, values), &coercing)
+}
+
+// urlValuesToMap builds the nested value described by the keys of values, using s
+// to decide where arrays are expected. Keys are processed in sorted order so that
+// conflicting keys resolve deterministically.
+func urlValuesToMap(s *ObjectSchema, values url.Values) map[string]interface{} {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var root interface{} = map[string]interface{}{}
+	for _, key := range keys {
+		root = setURLValue(root, s, splitURLKey(key), values[key])
+	}
+	return root.(map[string]interface{})
+}
+
+// setURLValue stores vals at the position described by segments inside node, whose
+// expected schema is target, and returns the updated node
+func setURLValue(node interface{}, target Parseable, segments []string, vals []string) interface{} {
+	if len(segments) == 0 {
+		if _, isArray := target.(*ArraySchema); isArray || len(vals) > 1 {
+			list, _ := node.([]interface{})
+			for _, v := range vals {
+				list = append(list, v)
+			}
+			return list
+		}
+		if len(vals) == 0 {
+			return node
+		}
+		return vals[0]
+	}
+
+	segment, rest := segments[0], segments[1:]
+	item := urlItemSchema(target)
+
+	// "[]" appends to an array
+	if segment == "" {
+		list, _ := node.([]interface{})
+		if len(rest) == 0 {
+			for _, v := range vals {
+				list = append(list, v)
+			}
+			return list
+		}
+		return append(list, setURLValue(nil, item, rest, vals))
+	}
+
+	// A numeric segment indexes into an array
+	if index, err := strconv.Atoi(segment); err == nil && index >= 0 && item != nil {
+		list, _ := node.([]interface{})
+		for len(list) <= index {
+			list = append(list, nil)
+		}
+		list[index] = setURLValue(list[index], item, rest, vals)
+		return list
+	}
+
+	object, ok := node.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+	}
+	object[segment] = setURLValue(object[segment], urlPropertySchema(target, segment), rest, vals)
+	return object
+}
+
+// urlPropertySchema returns the schema of a named child of target, if known
+func urlPropertySchema(target Parseable, name string) Parseable {
+	switch t := target.(type) {
+	case *ObjectSchema:
+		return t.properties[name].Schema
+	case *RecordSchema:
+		return t.valueSchema
+	}
+	return nil
+}
+
+// urlItemSchema returns the item schema of target if it is an array schema
+func urlItemSchema(target Parseable) Parseable {
+	if array, ok := target.(*ArraySchema); ok {
+		if array.itemSchema == nil {
+			return Any()
+		}
+		return array.itemSchema
+	}
+	return nil
+}
+
+// splitURLKey splits a key such as "user.tags[]" or "items[0][name]" into its
+// segments; "[]" produces an empty segment
+func splitURLKey(key string) []string {
+	var segments []string
+	for key != "" {
+		if key[0] == '[' {
+			end := strings.IndexByte(key, ']')
+			if end < 0 {
+				return append(segments, key)
+			}
+			segments = append(segments, key[1:end])
+			key = strings.TrimPrefix(key[end+1:], ".")
+			continue
+		}
+
+		end := strings.IndexAny(key, ".[")
+		if end < 0 {
+			return append(segments, key)
+		}
+		segments = append(segments, key[:end])
+		key = strings.TrimPrefix(key[end:], ".")
+	}
+	return segments
+}
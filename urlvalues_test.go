@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseURLValues(t *testing.T) {
+	s := Object().
+		Property("q", String()).
+		Property("page", Int().Optional()).
+		Property("tags", Array(String()).Optional()).
+		Property("user", Object().
+			Property("name", String()).
+			Property("admin", Bool().Optional()).
+			Optional()).
+		Property("items", Array(Object().Property("sku", String()).Property("qty", Int())).Optional())
+
+	tests := []struct {
+		name  string
+		query string
+		want  map[string]interface{}
+	}{
+		{"scalar coercion", "q=go&page=2", map[string]interface{}{"q": "go", "page": 2}},
+		{"bracket array", "q=go&tags[]=a&tags[]=b", map[string]interface{}{"q": "go", "tags": []interface{}{"a", "b"}}},
+		{"repeated keys", "q=go&tags=a&tags=b", map[string]interface{}{"q": "go", "tags": []interface{}{"a", "b"}}},
+		{"single value array", "q=go&tags=a", map[string]interface{}{"q": "go", "tags": []interface{}{"a"}}},
+		{"dot nesting", "q=go&user.name=bob&user.admin=true", map[string]interface{}{
+			"q": "go", "user": map[string]interface{}{"name": "bob", "admin": true},
+		}},
+		{"bracket nesting", "q=go&user[name]=bob", map[string]interface{}{
+			"q": "go", "user": map[string]interface{}{"name": "bob"},
+		}},
+		{"indexed objects", "q=go&items[0][sku]=a&items[0][qty]=1&items[1].sku=b&items[1].qty=2", map[string]interface{}{
+			"q": "go", "items": []interface{}{
+				map[string]interface{}{"sku": "a", "qty": 1},
+				map[string]interface{}{"sku": "b", "qty": 2},
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := ParseURLValues(s, values, nil)
+			if !result.Valid {
+				t.Fatalf("expected valid, got %v", result.Errors)
+			}
+			if !reflect.DeepEqual(result.Value, tt.want) {
+				t.Errorf("value = %#v, want %#v", result.Value, tt.want)
+			}
+		})
+	}
+
+	values, _ := url.ParseQuery("q=go&items[0][sku]=a&items[0][qty]=many")
+	result := ParseURLValues(s, values, nil)
+	if result.Valid {
+		t.Fatal("expected invalid qty to fail")
+	}
+	if path := result.Errors[len(result.Errors)-1].Path.DotPath(); path != "items[0].qty" {
+		t.Errorf("error path = %s, want items[0].qty", path)
+	}
+}
+
+func TestSplitURLKey(t *testing.T) {
+	tests := map[string][]string{
+		"name":           {"name"},
+		"tags[]":         {"tags", ""},
+		"user.name":      {"user", "name"},
+		"user[name]":     {"user", "name"},
+		"items[0][sku]":  {"items", "0", "sku"},
+		"items[0].sku":   {"items", "0", "sku"},
+		"a.b[c].d[]":     {"a", "b", "c", "d", ""},
+		"broken[bracket": {"broken", "[bracket"},
+	}
+	for key, want := range tests {
+		if got := splitURLKey(key); !reflect.DeepEqual(got, want) {
+			t.Errorf("splitURLKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
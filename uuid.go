@@ -3,6 +3,7 @@ package schema
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/nyxstack/i18n"
@@ -75,6 +76,8 @@ type UUIDSchema struct {
 	formatError    ErrorMessage
 	versionError   ErrorMessage
 	caseError      ErrorMessage
+	effects        effects
+	frozenState
 }
 
 // UUID creates a new UUID schema
@@ -86,26 +89,45 @@ func UUID() *UUIDSchema {
 	}
 }
 
+// Clone returns an unfrozen copy of the schema that can be modified without affecting s.
+func (s *UUIDSchema) Clone() *UUIDSchema {
+	c := *s
+	c.effects = slices.Clone(s.effects)
+	c.frozenState = frozenState{}
+	return &c
+}
+
+// Freeze makes the schema immutable: any later modification panics. A frozen
+// schema is safe to share and to use from several goroutines.
+func (s *UUIDSchema) Freeze() *UUIDSchema {
+	s.freeze()
+	return s
+}
+
 // Version specifies the required UUID version
 func (s *UUIDSchema) Version(version UUIDVersion) *UUIDSchema {
+	s.checkMutable()
 	s.version = version
 	return s
 }
 
 // Format specifies the required UUID format
 func (s *UUIDSchema) Format(format UUIDFormat) *UUIDSchema {
+	s.checkMutable()
 	s.format = format
 	return s
 }
 
 // CaseSensitive enables case-sensitive validation
 func (s *UUIDSchema) CaseSensitive() *UUIDSchema {
+	s.checkMutable()
 	s.caseSensitive = true
 	return s
 }
 
 // Lowercase forces UUID to be lowercase
 func (s *UUIDSchema) Lowercase() *UUIDSchema {
+	s.checkMutable()
 	s.forceLowercase = true
 	s.forceUppercase = false
 	return s
@@ -113,6 +135,7 @@ func (s *UUIDSchema) Lowercase() *UUIDSchema {
 
 // Uppercase forces UUID to be uppercase
 func (s *UUIDSchema) Uppercase() *UUIDSchema {
+	s.checkMutable()
 	s.forceUppercase = true
 	s.forceLowercase = false
 	return s
@@ -120,18 +143,21 @@ func (s *UUIDSchema) Uppercase() *UUIDSchema {
 
 // FormatError sets custom error message for format validation
 func (s *UUIDSchema) FormatError(err ErrorMessage) *UUIDSchema {
+	s.checkMutable()
 	s.formatError = err
 	return s
 }
 
 // VersionError sets custom error message for version validation
 func (s *UUIDSchema) VersionError(err ErrorMessage) *UUIDSchema {
+	s.checkMutable()
 	s.versionError = err
 	return s
 }
 
 // CaseError sets custom error message for case validation
 func (s *UUIDSchema) CaseError(err ErrorMessage) *UUIDSchema {
+	s.checkMutable()
 	s.caseError = err
 	return s
 }
@@ -144,8 +170,34 @@ var uuidPatterns = map[UUIDFormat]*regexp.Regexp{
 	UUIDFormatURN:        regexp.MustCompile(`^urn:uuid:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
 }
 
+// Transform appends a transformation of the parsed value (applied in order with Refine)
+func (s *UUIDSchema) Transform(fn TransformFunc) *UUIDSchema {
+	s.checkMutable()
+	s.effects = s.effects.withTransform(fn)
+	return s
+}
+
+// Refine appends a custom predicate the parsed value must satisfy, with optional custom error message
+func (s *UUIDSchema) Refine(fn RefineFunc, errorMessage ...interface{}) *UUIDSchema {
+	s.checkMutable()
+	s.effects = s.effects.withRefine(fn, errorMessage...)
+	return s
+}
+
+// RefineCtx appends a validator that receives the parse's Go context, with optional custom error message
+func (s *UUIDSchema) RefineCtx(fn RefineCtxFunc, errorMessage ...interface{}) *UUIDSchema {
+	s.checkMutable()
+	s.effects = s.effects.withRefineCtx(fn, errorMessage...)
+	return s
+}
+
 // Parse validates a UUID value
 func (s *UUIDSchema) Parse(value interface{}, ctx *ValidationContext) ParseResult {
+	return s.effects.apply(ctx.startParse(s), s.parse(value, ctx), ctx, s, value)
+}
+
+// parse applies the UUID constraints; Parse runs the refine/transform pipeline on top
+func (s *UUIDSchema) parse(value interface{}, ctx *ValidationContext) ParseResult {
 	var errors []ValidationError
 
 	// Convert to string
@@ -155,7 +207,7 @@ func (s *UUIDSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 		if !isEmptyErrorMessage(s.formatError) {
 			message = resolveErrorMessage(s.formatError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(value, message, "format"))
+		errors = append(errors, NewPrimitiveError(value, message, CodeFormat))
 		return ParseResult{Valid: false, Value: value, Errors: errors}
 	}
 
@@ -167,7 +219,7 @@ func (s *UUIDSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 		if !isEmptyErrorMessage(s.formatError) {
 			message = resolveErrorMessage(s.formatError, ctx)
 		}
-		errors = append(errors, NewPrimitiveError(uuidStr, message, "format"))
+		errors = append(errors, NewPrimitiveError(uuidStr, message, CodeFormat))
 		return ParseResult{Valid: false, Value: value, Errors: errors}
 	}
 
@@ -179,7 +231,7 @@ func (s *UUIDSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.versionError) {
 				message = resolveErrorMessage(s.versionError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(uuidStr, message, "version"))
+			errors = append(errors, NewPrimitiveError(uuidStr, message, CodeVersion))
 		}
 	}
 
@@ -196,7 +248,7 @@ func (s *UUIDSchema) Parse(value interface{}, ctx *ValidationContext) ParseResul
 			if !isEmptyErrorMessage(s.caseError) {
 				message = resolveErrorMessage(s.caseError, ctx)
 			}
-			errors = append(errors, NewPrimitiveError(uuidStr, message, "case"))
+			errors = append(errors, NewPrimitiveError(uuidStr, message, CodeCase))
 		}
 	}
 
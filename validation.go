@@ -3,27 +3,124 @@ package schema
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nyxstack/i18n"
 )
 
+// DefaultMaxDepth is the recursion limit used when ValidationContext.MaxDepth is not set
+const DefaultMaxDepth = 100
+
+func limitStringLengthError(max int) i18n.TranslatedFunc {
+	return i18n.F("string is longer than the limit of %d bytes", max)
+}
+
+func limitCollectionSizeError(max int) i18n.TranslatedFunc {
+	return i18n.F("collection has more than the limit of %d elements", max)
+}
+
 // ValidationContext contains locale and other context information for validation
 type ValidationContext struct {
 	Locale string
 	Ctx    context.Context
+	Coerce bool // Convert compatible input types (e.g. "42" -> 42) in all primitive schemas
+
+	// MaxDepth is the maximum nesting of objects, arrays, records, maps and tuples in
+	// the input, and of Lazy/Ref resolutions (0 uses DefaultMaxDepth). Deeper input is
+	// rejected (code "max_depth") instead of being followed.
+	MaxDepth int
+
+	// FailFast stops validation at the first failing constraint, item or property of
+	// each schema. The failure is still reported together with the nested errors
+	// that explain it.
+	FailFast bool
+
+	// MaxErrors caps the number of errors collected by a parse (0 means no limit).
+	// Arrays, objects, records, maps and tuples stop validating further elements once
+	// the limit is reached, so the limit also bounds the work spent on input that is
+	// invalid everywhere. With FailFast or MaxErrors, the properties of objects and the
+	// entries of records are validated in sorted key order, so the same errors are kept
+	// on every parse of the same input.
+	MaxErrors int
+
+	// MaxStringLength rejects strings longer than this many bytes (code "too_long")
+	// before any other check (0 means no limit). It applies to the values and keys of
+	// objects, arrays, records, maps and tuples and to values parsed by String schemas.
+	MaxStringLength int
+
+	// MaxCollectionSize rejects objects, arrays, records, maps and tuples with more
+	// elements (code "too_large") before any element is validated (0 means no limit).
+	MaxCollectionSize int
+
+	// Source names the document being parsed (usually a file name). It prefixes the
+	// locations that ParseTOML, ParseINI and the other document parsers attach to errors.
+	Source string
+
+	// Now returns the current time for relative constraints such as DateSchema.Past
+	// (nil uses time.Now). Tests can set it to a fixed clock.
+	Now func() time.Time
+
+	// Messages replaces the built-in error messages by code for this validation, taking
+	// precedence over the messages of RegisterMessages (see WithMessages)
+	Messages Messages
+
+	// Cache stores the results of Cached schemas, so values they have already parsed
+	// are not validated again (see WithCache)
+	Cache ResultCache
+
+	// Hooks receives telemetry about every schema parsed (see WithHooks)
+	Hooks *Hooks
+
+	// TrackPresence tells missing properties from properties set to null (see WithPresence)
+	TrackPresence bool
+
+	// EmptyIsPresent makes Required only about missing and nil values: String and Binary
+	// schemas then validate "" like any other value instead of rejecting it as missing
+	// (see WithEmptyIsPresent). Use StringSchema.NonEmpty to reject it.
+	EmptyIsPresent bool
+
+	// TagNames lists the struct tags naming the fields of structs parsed by object
+	// schemas, in order of precedence (nil uses the json tag). A field without any of
+	// the tags keeps its Go name.
+	TagNames []string
+
+	// Redact masks the values of errors about the positions it returns true for, in
+	// addition to the values of Sensitive schemas (see WithRedaction)
+	Redact func(path Path) bool
+
+	depth int // Current nesting of collections and Lazy/Ref resolutions
+
+	parent     *ValidationContext // Context of the enclosing collection, set by descend
+	segment    PathSegment        // Position in the enclosing collection, set by at
+	hasSegment bool
+	root       interface{} // Value of the outermost collection, set by descend
+	hasRoot    bool
+
+	validityOnly bool // Set by IsValid: objects and arrays skip building their parsed value
+	hasValues    bool // Set by WithValue: results may depend on the values, so Cached schemas skip the cache
+	structural   bool // Set by Lint: refinements, transforms and callbacks are skipped, only constraints apply
+
+	explain *explainRecorder // Set by Explain: schemas record their parses
 }
 
 // DefaultValidationContext returns a context with English locale
 func DefaultValidationContext() *ValidationContext {
 	return &ValidationContext{
-		Locale: "en",
-		Ctx:    context.Background(),
+		Locale:   "en",
+		Ctx:      context.Background(),
+		MaxDepth: DefaultMaxDepth,
 	}
 }
 
 // NewValidationContext creates a validation context with specified locale
 func NewValidationContext(locale string) *ValidationContext {
 	return &ValidationContext{
-		Locale: locale,
-		Ctx:    context.Background(),
+		Locale:   locale,
+		Ctx:      context.Background(),
+		MaxDepth: DefaultMaxDepth,
 	}
 }
 
@@ -33,6 +130,295 @@ func (vc *ValidationContext) WithContext(ctx context.Context) *ValidationContext
 	return vc
 }
 
+// WithValue makes val available under key to the RefineCtx validators of the parse,
+// such as the current user or the limits of a tenant, by wrapping the Go context with
+// context.WithValue. Call it after WithContext, which replaces the Go context.
+func (vc *ValidationContext) WithValue(key, val interface{}) *ValidationContext {
+	vc.Ctx = context.WithValue(vc.goContext(), key, val)
+	vc.hasValues = true
+	return vc
+}
+
+// Value returns the value set with WithValue for key, or carried by the Go context
+func (vc *ValidationContext) Value(key interface{}) interface{} {
+	return vc.goContext().Value(key)
+}
+
+// ContextValue returns the value of type T stored under key in the Go context received
+// by a RefineCtx validator, and whether there was one:
+//
+//	limit, ok := schema.ContextValue[int](ctx, maxItemsKey{})
+func ContextValue[T any](ctx context.Context, key interface{}) (T, bool) {
+	value, ok := ctx.Value(key).(T)
+	return value, ok
+}
+
+// WithTagNames sets the struct tags naming the fields of parsed structs, in order of precedence
+func (vc *ValidationContext) WithTagNames(tagNames ...string) *ValidationContext {
+	vc.TagNames = tagNames
+	return vc
+}
+
+// WithMaxDepth sets the maximum nesting of collections and recursive (Lazy/Ref) schema resolutions
+func (vc *ValidationContext) WithMaxDepth(maxDepth int) *ValidationContext {
+	vc.MaxDepth = maxDepth
+	return vc
+}
+
+// WithCoercion enables input coercion for every primitive schema parsed with this context
+func (vc *ValidationContext) WithCoercion() *ValidationContext {
+	vc.Coerce = true
+	return vc
+}
+
+// WithEmptyIsPresent makes Required only reject missing and nil values, so that String
+// and Binary schemas validate "" like any other value (see EmptyIsPresent)
+func (vc *ValidationContext) WithEmptyIsPresent() *ValidationContext {
+	vc.EmptyIsPresent = true
+	return vc
+}
+
+// WithFailFast stops validation at the first error instead of collecting all of them
+func (vc *ValidationContext) WithFailFast() *ValidationContext {
+	vc.FailFast = true
+	return vc
+}
+
+// WithMaxErrors caps the number of errors collected by a parse (0 means no limit)
+func (vc *ValidationContext) WithMaxErrors(maxErrors int) *ValidationContext {
+	vc.MaxErrors = maxErrors
+	return vc
+}
+
+// stopCollecting reports whether a schema that has collected count errors should
+// skip its remaining constraints and elements
+func (vc *ValidationContext) stopCollecting(count int) bool {
+	if vc == nil || count == 0 {
+		return false
+	}
+	return vc.FailFast || (vc.MaxErrors > 0 && count >= vc.MaxErrors)
+}
+
+// limitErrors trims errors according to FailFast and MaxErrors. With FailFast the
+// first error is kept along with the errors reported at or below its path (other
+// errors of the same primitive value are dropped).
+func (vc *ValidationContext) limitErrors(errors []ValidationError) []ValidationError {
+	if vc == nil || len(errors) <= 1 {
+		return errors
+	}
+	if vc.FailFast {
+		first := errors[0]
+		limited := errors[:1]
+		for _, err := range errors[1:] {
+			if pathHasPrefix(err.Path, first.Path) && (len(first.Path) > 0 || len(err.Path) > 0) {
+				limited = append(limited, err)
+			}
+		}
+		errors = limited
+	}
+	if vc.MaxErrors > 0 && len(errors) > vc.MaxErrors {
+		errors = errors[:vc.MaxErrors]
+	}
+	return errors
+}
+
+// WithMaxStringLength rejects strings longer than maxLength bytes (0 means no limit)
+func (vc *ValidationContext) WithMaxStringLength(maxLength int) *ValidationContext {
+	vc.MaxStringLength = maxLength
+	return vc
+}
+
+// WithMaxCollectionSize rejects collections with more than maxSize elements (0 means no limit)
+func (vc *ValidationContext) WithMaxCollectionSize(maxSize int) *ValidationContext {
+	vc.MaxCollectionSize = maxSize
+	return vc
+}
+
+// WithSource sets the name of the document being parsed, used in error locations
+func (vc *ValidationContext) WithSource(source string) *ValidationContext {
+	vc.Source = source
+	return vc
+}
+
+// WithClock sets the function returning the current time for relative date constraints
+func (vc *ValidationContext) WithClock(now func() time.Time) *ValidationContext {
+	vc.Now = now
+	return vc
+}
+
+// now returns the current time according to the context's clock
+func (vc *ValidationContext) now() time.Time {
+	if vc.Now != nil {
+		return vc.Now()
+	}
+	return time.Now()
+}
+
+// maxDepth returns the effective recursion limit
+func (vc *ValidationContext) maxDepth() int {
+	if vc.MaxDepth <= 0 {
+		return DefaultMaxDepth
+	}
+	return vc.MaxDepth
+}
+
+// keepValues reports whether objects and arrays build their parsed value
+func (vc *ValidationContext) keepValues() bool {
+	return vc == nil || !vc.validityOnly
+}
+
+// withValues returns the context for a parse whose value is needed, such as the
+// input of a transform or refinement
+func (vc *ValidationContext) withValues() *ValidationContext {
+	if vc.keepValues() {
+		return vc
+	}
+	child := *vc
+	child.validityOnly = false
+	return &child
+}
+
+// validityContexts recycles the contexts of IsValid
+var validityContexts = sync.Pool{
+	New: func() interface{} { return new(ValidationContext) },
+}
+
+// IsValid reports whether value satisfies s. It stops at the first error and does
+// not build parsed objects and arrays (except where a Transform or Refine needs
+// them), so it allocates far less than Parse; use Parse when the parsed value or
+// the errors are needed. A nil ctx uses DefaultValidationContext.
+func IsValid(s Parseable, value interface{}, ctx *ValidationContext) bool {
+	vc := validityContexts.Get().(*ValidationContext)
+	if ctx != nil {
+		*vc = *ctx
+	} else {
+		*vc = ValidationContext{Locale: "en", Ctx: context.Background(), MaxDepth: DefaultMaxDepth}
+	}
+	vc.FailFast = true
+	vc.validityOnly = true
+
+	valid := s.Parse(value, vc).Valid
+
+	*vc = ValidationContext{}
+	validityContexts.Put(vc)
+	return valid
+}
+
+// childContexts recycles the contexts of nested parses
+var childContexts = sync.Pool{
+	New: func() interface{} { return new(ValidationContext) },
+}
+
+// descend returns a copy of the context one recursion level deeper for the elements
+// of value, or false if the recursion limit has been reached. The first descent
+// records value as the root document. The receiver is never modified, so a context
+// can safely be shared between concurrent parses. The copy must be given back with
+// release once the nested parse has returned.
+func (vc *ValidationContext) descend(value interface{}) (*ValidationContext, bool) {
+	if vc.depth >= vc.maxDepth() {
+		return vc, false
+	}
+	child := childContexts.Get().(*ValidationContext)
+	*child = *vc
+	child.depth++
+	child.parent = vc
+	child.segment = PathSegment{}
+	child.hasSegment = false
+	if !vc.hasRoot {
+		child.root, child.hasRoot = value, true
+	}
+	return child, true
+}
+
+// at sets the position of the element about to be parsed with a context returned by
+// descend, for the error messages rendered while parsing it
+func (vc *ValidationContext) at(segment PathSegment) *ValidationContext {
+	vc.segment = segment
+	vc.hasSegment = true
+	return vc
+}
+
+// Root returns the document being parsed, as passed to Parse (objects converted to
+// maps), so validators of nested values can check them against the rest of the
+// document. It is nil for the context given to Parse, except in the RefineCtx
+// validators of the root value (see ValidationContextFrom).
+func (vc *ValidationContext) Root() interface{} {
+	return vc.root
+}
+
+// Path returns the position of the value being parsed in the root document
+func (vc *ValidationContext) Path() Path {
+	return vc.path()
+}
+
+// path returns the position of the value being parsed, from the positions set with at
+func (vc *ValidationContext) path() Path {
+	var path Path
+	for c := vc; c != nil; c = c.parent {
+		if c.hasSegment {
+			path = append(path, c.segment)
+		}
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// release recycles a context returned by descend
+func (vc *ValidationContext) release() {
+	*vc = ValidationContext{}
+	childContexts.Put(vc)
+}
+
+// depthExceeded is the result for a collection nested deeper than MaxDepth
+func (vc *ValidationContext) depthExceeded(value interface{}) ParseResult {
+	message := maxDepthError(vc.maxDepth())(vc.Locale)
+	return ParseResult{
+		Valid: false,
+		Value: nil,
+		Errors: []ValidationError{{Path: Path{}, Value: limitedValue(value), Message: message, Code: CodeMaxDepth,
+			Params: map[string]interface{}{"max": vc.maxDepth()}}},
+	}
+}
+
+// checkCollectionSize returns the error for a collection of size elements when it
+// exceeds MaxCollectionSize
+func (vc *ValidationContext) checkCollectionSize(value interface{}, size int) (ValidationError, bool) {
+	if vc.MaxCollectionSize <= 0 || size <= vc.MaxCollectionSize {
+		return ValidationError{}, false
+	}
+	message := limitCollectionSizeError(vc.MaxCollectionSize)(vc.Locale)
+	return ValidationError{Path: Path{}, Value: limitedValue(value), Message: message, Code: CodeTooLarge,
+		Params: map[string]interface{}{"max": vc.MaxCollectionSize}}, true
+}
+
+// checkStringLength returns the error at path for a string value longer than
+// MaxStringLength; other values pass
+func (vc *ValidationContext) checkStringLength(value interface{}, path ...PathSegment) (ValidationError, bool) {
+	str, ok := value.(string)
+	if !ok || vc.MaxStringLength <= 0 || len(str) <= vc.MaxStringLength {
+		return ValidationError{}, false
+	}
+	message := limitStringLengthError(vc.MaxStringLength)(vc.Locale)
+	return ValidationError{Path: append(Path{}, path...), Value: limitedValue(str), Message: message, Code: CodeTooLong,
+		Params: map[string]interface{}{"max": vc.MaxStringLength}}, true
+}
+
+// limitedValue describes a value rejected by a limit without formatting all of it
+func limitedValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if len(v) > 32 {
+			return strings.ToValidUTF8(v[:32], "") + "..."
+		}
+		return v
+	case []interface{}:
+		return fmt.Sprintf("[%d items]", len(v))
+	case map[string]interface{}:
+		return fmt.Sprintf("{%d properties}", len(v))
+	}
+	return fmt.Sprintf("%T", value)
+}
+
 // Parseable interface that all schemas should implement
 type Parseable interface {
 	Parse(value interface{}, ctx *ValidationContext) ParseResult
@@ -40,16 +426,40 @@ type Parseable interface {
 
 // ValidationError represents a validation error with details
 type ValidationError struct {
-	Path    []string `json:"path"`    // Path to the field (empty for primitive values)
-	Value   string   `json:"value"`   // String representation of the invalid value
-	Message string   `json:"message"` // Human-readable error message
-	Code    string   `json:"code"`    // Machine-readable error code
+	Path    Path      `json:"path"`    // Path to the field (empty for primitive values)
+	Value   string    `json:"value"`   // String representation of the invalid value
+	Message string    `json:"message"` // Human-readable error message
+	Code    ErrorCode `json:"code"`    // Machine-readable error code
+
+	// Params holds the constraint values the error is about, such as "min" and "max"
+	// for bounds and "expected" for types (see ErrorCodeInfo.Params)
+	Params map[string]interface{} `json:"params,omitempty"`
+
+	// Severity is SeverityError for the errors of ParseResult.Errors, and
+	// SeverityWarning or SeverityInfo for those of ParseResult.Warnings
+	Severity Severity `json:"severity,omitempty"`
+
+	// Location of the value in the source document, when parsed from one
+	Location *Location `json:"location,omitempty"`
+
+	rendered bool // The message is final: rendered, or replaced from the message bundles
+}
+
+// Error returns the message, prefixed with the source location or dotted path when present
+func (e ValidationError) Error() string {
+	if e.Location != nil {
+		return e.Location.String() + ": " + e.Message
+	}
+	if len(e.Path) > 0 {
+		return e.Path.DotPath() + ": " + e.Message
+	}
+	return e.Message
 }
 
 // NewPrimitiveError creates a validation error for primitive value validation
-func NewPrimitiveError(value interface{}, message, code string) ValidationError {
+func NewPrimitiveError(value interface{}, message string, code ErrorCode) ValidationError {
 	return ValidationError{
-		Path:    []string{}, // Empty path for primitive values
+		Path:    Path{}, // Empty path for primitive values
 		Value:   fmt.Sprintf("%v", value),
 		Message: message,
 		Code:    code,
@@ -57,7 +467,7 @@ func NewPrimitiveError(value interface{}, message, code string) ValidationError
 }
 
 // NewFieldError creates a validation error for object field validation
-func NewFieldError(path []string, value interface{}, message, code string) ValidationError {
+func NewFieldError(path Path, value interface{}, message string, code ErrorCode) ValidationError {
 	return ValidationError{
 		Path:    path,
 		Value:   fmt.Sprintf("%v", value),
@@ -66,11 +476,120 @@ func NewFieldError(path []string, value interface{}, message, code string) Valid
 	}
 }
 
+// nestedError returns the error of a nested value reported at path, keeping its code
+// and params
+func nestedError(path Path, err ValidationError) ValidationError {
+	return ValidationError{Path: path, Value: err.Value, Message: err.Message, Code: err.Code, Params: err.Params, rendered: err.rendered}
+}
+
+// ValidationErrors is a list of validation errors that can be returned as an error
+type ValidationErrors []ValidationError
+
+// Error renders the errors as a tree grouped by path:
+//
+//	2 validation errors:
+//	  name: field is required
+//	  address:
+//	    zip: string must be at least 5 characters
+func (e ValidationErrors) Error() string {
+	root := &errorTreeNode{}
+	for _, err := range e {
+		root.insert(err.Path, err.Message)
+	}
+
+	var b strings.Builder
+	if len(e) == 1 {
+		b.WriteString("1 validation error:")
+	} else {
+		fmt.Fprintf(&b, "%d validation errors:", len(e))
+	}
+	for _, message := range root.messages {
+		b.WriteString("\n  " + message)
+	}
+	for _, child := range root.children {
+		child.write(&b, 1)
+	}
+	return b.String()
+}
+
+// Unwrap returns the individual errors, so errors.As can match a ValidationError
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// errorTreeNode groups error messages by path segment for ValidationErrors.Error
+type errorTreeNode struct {
+	label    string
+	messages []string
+	children []*errorTreeNode
+}
+
+// insert adds a message at path below the node, creating nodes in first-seen order
+func (n *errorTreeNode) insert(path Path, message string) {
+	node := n
+	for _, seg := range path {
+		label := seg.String()
+		var next *errorTreeNode
+		for _, child := range node.children {
+			if child.label == label {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			next = &errorTreeNode{label: label}
+			node.children = append(node.children, next)
+		}
+		node = next
+	}
+	node.messages = append(node.messages, message)
+}
+
+// write renders the node and its descendants at the given indentation level
+func (n *errorTreeNode) write(b *strings.Builder, level int) {
+	indent := strings.Repeat("  ", level)
+	if len(n.children) == 0 && len(n.messages) == 1 {
+		b.WriteString("\n" + indent + n.label + ": " + n.messages[0])
+		return
+	}
+	b.WriteString("\n" + indent + n.label + ":")
+	for _, message := range n.messages {
+		b.WriteString("\n" + indent + "  " + message)
+	}
+	for _, child := range n.children {
+		child.write(b, level+1)
+	}
+}
+
 // ParseResult contains parsing and validation results with the final parsed value
 type ParseResult struct {
 	Valid  bool              `json:"valid"`
 	Value  interface{}       `json:"value"` // The final parsed/transformed value
 	Errors []ValidationError `json:"errors"`
+
+	// PartialOK reports that Value holds the successfully parsed part of an invalid
+	// value (see ObjectSchema.CollectPartial)
+	PartialOK bool `json:"partialOk,omitempty"`
+
+	// Warnings holds the problems that do not fail validation: errors of constraints
+	// marked with AsWarning or AsInfo, and uses of deprecated properties
+	Warnings []ValidationError `json:"warnings,omitempty"`
+
+	// Present reports, in presence mode, whether each property of the parsed object was
+	// absent, null or set (see WithPresence)
+	Present map[string]Presence `json:"present,omitempty"`
+}
+
+// Error returns the validation errors as a ValidationErrors, or nil if the value is valid
+func (r ParseResult) Error() error {
+	if r.Valid && len(r.Errors) == 0 {
+		return nil
+	}
+	return ValidationErrors(r.Errors)
 }
 
 // ValidationResult contains validation results (deprecated, use ParseResult)
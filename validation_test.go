@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsValid(t *testing.T) {
+	order := benchmarkOrderSchema()
+	var valid, invalid interface{}
+	json.Unmarshal(benchmarkOrder, &valid)
+	json.Unmarshal([]byte(strings.Replace(string(benchmarkOrder), `"quantity": 5`, `"quantity": 0`, 1)), &invalid)
+
+	// Refinements and transforms still see the parsed value of nested objects and arrays
+	trimmed := Object().
+		Property("items", Array(Object().Property("sku", String().Trim()))).
+		Refine(func(value interface{}) bool {
+			for _, item := range value.(map[string]interface{})["items"].([]interface{}) {
+				if strings.TrimSpace(item.(map[string]interface{})["sku"].(string)) != item.(map[string]interface{})["sku"] {
+					return false
+				}
+			}
+			return true
+		})
+	padded := map[string]interface{}{"items": []interface{}{map[string]interface{}{"sku": " A-1 "}}}
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		value  interface{}
+		ctx    *ValidationContext
+		want   bool
+	}{
+		{"valid", order, valid, nil, true},
+		{"invalid nested item", order, invalid, nil, false},
+		{"wrong type", order, "order", DefaultValidationContext(), false},
+		{"refine on parsed value", trimmed, padded, nil, true},
+		{"array of objects", Array(order).MinItems(1), []interface{}{valid, valid}, nil, true},
+		{"coercion from context", Int().Min(1), "5", DefaultValidationContext().WithCoercion(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValid(tt.schema, tt.value, tt.ctx); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = DefaultValidationContext()
+			}
+			if got := tt.schema.Parse(tt.value, ctx).Valid; got != tt.want {
+				t.Errorf("Parse().Valid = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// benchmarkOrders returns a decoded batch of orders, an object and array heavy payload
+func benchmarkOrders(b *testing.B) (*ArraySchema, interface{}) {
+	var order interface{}
+	if err := json.Unmarshal(benchmarkOrder, &order); err != nil {
+		b.Fatal(err)
+	}
+	orders := make([]interface{}, 100)
+	for i := range orders {
+		orders[i] = order
+	}
+	return Array(benchmarkOrderSchema()), orders
+}
+
+func BenchmarkParseOrders(b *testing.B) {
+	s, orders := benchmarkOrders(b)
+	ctx := DefaultValidationContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if result := s.Parse(orders, ctx); !result.Valid {
+			b.Fatal(result.Errors)
+		}
+	}
+}
+
+func BenchmarkIsValidOrders(b *testing.B) {
+	s, orders := benchmarkOrders(b)
+	ctx := DefaultValidationContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !IsValid(s, orders, ctx) {
+			b.Fatal("orders should be valid")
+		}
+	}
+}
+
+func TestValidationLimits(t *testing.T) {
+	nestedSchema := Parseable(Int())
+	var nestedValue interface{} = 1
+	for i := 0; i < 6; i++ {
+		nestedSchema = Array(nestedSchema)
+		nestedValue = []interface{}{nestedValue}
+	}
+	items := make([]interface{}, 11)
+	props := make(map[string]interface{}, 11)
+	for i := range items {
+		items[i] = i
+		props[strings.Repeat("k", i+1)] = i
+	}
+	long := strings.Repeat("x", 40)
+
+	limited := func() *ValidationContext {
+		return DefaultValidationContext().WithMaxDepth(4).WithMaxCollectionSize(10).WithMaxStringLength(32)
+	}
+
+	tests := []struct {
+		name   string
+		schema Parseable
+		value  interface{}
+		want   []string
+	}{
+		{"nesting within limit", Array(Array(Int())), []interface{}{[]interface{}{1}}, []string{}},
+		{"nesting too deep", nestedSchema, nestedValue, []string{
+			"[0] item_invalid", "[0][0] item_invalid", "[0][0][0] item_invalid", "[0][0][0][0] item_invalid", "[0][0][0][0] max_depth"}},
+		{"array too large", Array(Int()), items, []string{" too_large"}},
+		{"object too large", Object().Passthrough(), props, []string{" too_large"}},
+		{"record too large", Record(String(), Int()), props, []string{" too_large"}},
+		{"tuple too large", Tuple(Int()).AllowAdditionalItems(), items, []string{" too_large"}},
+		{"string too long", String(), long, []string{" too_long"}},
+		{"property too long", Object().Property("name", String()), map[string]interface{}{"name": long}, []string{"name too_long"}},
+		{"key too long", Object().Passthrough(), map[string]interface{}{long: 1}, []string{long + " too_long"}},
+		{"item too long", Array(String()), []interface{}{"ok", long}, []string{"[1] too_long"}},
+		{"record value too long", Record(String(), String()), map[string]interface{}{"a": long}, []string{"a too_long"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Parse(tt.value, limited())
+			if got := errorKeys(result.Errors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+			// Without limits the same input is accepted
+			if result := tt.schema.Parse(tt.value, DefaultValidationContext()); !result.Valid {
+				t.Errorf("unexpected errors without limits: %v", result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Path segments of the children that are not properties or tuple positions
+const (
+	ItemsSegment  = "[]"    // The items of an array
+	ValuesSegment = "*"     // The values of a record or map
+	KeySegment    = "{key}" // The keys of a record or map, and the property names of an object
+)
+
+// SchemaChild is a schema nested in another one, with the path segment of the values
+// it applies to: a property name, "/pattern/" for pattern properties, "[0]" for a
+// tuple position, ItemsSegment, ValuesSegment or KeySegment. The segment is empty for
+// the schemas that apply to the value of their parent itself, such as the members of
+// a union or the definition a reference points to.
+type SchemaChild struct {
+	Segment string
+	Schema  Parseable
+}
+
+// Composite is implemented by the schemas that contain other schemas
+type Composite interface {
+	Children() []SchemaChild
+}
+
+// Walk calls fn for s and, depth-first, for every schema nested in it, with the path
+// of the values each schema applies to (nil for s). When fn returns false, the
+// schemas nested in the one it was called with are skipped. Recursive schemas are
+// followed until they lead back to a schema being walked, so Walk always terminates.
+func Walk(s Parseable, fn func(path []string, s Parseable) bool) {
+	walk(s, nil, fn, map[Parseable]bool{})
+}
+
+// walk walks s at path, skipping the schemas among ancestors
+func walk(s Parseable, path []string, fn func(path []string, s Parseable) bool, ancestors map[Parseable]bool) {
+	if !fn(path, s) {
+		return
+	}
+	composite, ok := s.(Composite)
+	if !ok {
+		return
+	}
+	if reflect.TypeOf(s).Comparable() {
+		ancestors[s] = true
+		defer delete(ancestors, s)
+	}
+	for _, child := range composite.Children() {
+		if child.Schema == nil || reflect.TypeOf(child.Schema).Comparable() && ancestors[child.Schema] {
+			continue
+		}
+		childPath := path
+		if child.Segment != "" {
+			childPath = append(slices.Clip(path), child.Segment)
+		}
+		walk(child.Schema, childPath, fn, ancestors)
+	}
+}
+
+// sameValueChildren returns the non-nil schemas as children applying to the value of
+// their parent
+func sameValueChildren(schemas ...Parseable) []SchemaChild {
+	children := make([]SchemaChild, 0, len(schemas))
+	for _, schema := range schemas {
+		if schema != nil {
+			children = append(children, SchemaChild{Schema: schema})
+		}
+	}
+	return children
+}
@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	registry := NewSchemaRegistry()
+	registry.Define("node", Object().
+		Property("id", String().Format(StringFormatUUID)).
+		Property("children", Array(Ref("#/node", registry)).Optional()))
+
+	s := Object().
+		Property("email", String().Format(StringFormatEmail)).
+		Property("tags", Array(String()).Optional()).
+		Property("pair", Tuple(String(), Int())).
+		Property("labels", Record(String().MinLength(1), String())).
+		Property("contact", Union(String().Format(StringFormatEmail), Int())).
+		Property("tree", Ref("#/node", registry)).
+		PatternProperty("^x-", Any())
+
+	var paths []string
+	var formats []string
+	Walk(s, func(path []string, child Parseable) bool {
+		paths = append(paths, strings.Join(path, "."))
+		if str, ok := child.(*StringSchema); ok && str.GetFormat() != nil {
+			formats = append(formats, strings.Join(path, ".")+"="+string(*str.GetFormat()))
+		}
+		return true
+	})
+
+	wantPaths := []string{
+		"",
+		"contact", "contact", "contact",
+		"email",
+		"labels", "labels.{key}", "labels.*",
+		"pair", "pair.[0]", "pair.[1]",
+		"tags", "tags.[]",
+		"tree", "tree", "tree.children", "tree.children.[]", "tree.id",
+		"/^x-/",
+	}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("paths = %q\nwant %q", paths, wantPaths)
+	}
+	wantFormats := []string{"contact=email", "email=email", "tree.id=uuid"}
+	if !reflect.DeepEqual(formats, wantFormats) {
+		t.Errorf("formats = %q, want %q", formats, wantFormats)
+	}
+
+	// Returning false skips the nested schemas
+	var visited []string
+	Walk(s, func(path []string, child Parseable) bool {
+		visited = append(visited, strings.Join(path, "."))
+		return len(path) == 0
+	})
+	if len(visited) != 8 {
+		t.Errorf("visited = %q, want the root and its 7 direct children", visited)
+	}
+}
+
+func TestWalk_Lazy(t *testing.T) {
+	var category *ObjectSchema
+	category = Object().
+		Property("name", String()).
+		Property("parent", Lazy(func() Parseable { return category }).Nullable())
+
+	count := 0
+	Walk(category, func(path []string, child Parseable) bool {
+		count++
+		return true
+	})
+	// The object, name, parent (Lazy); the Lazy leads back to the object
+	if count != 3 {
+		t.Errorf("visited %d schemas, want 3", count)
+	}
+}
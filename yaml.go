@@ -0,0 +1,209 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nyxstack/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+func yamlDecodeError(err error) i18n.TranslatedFunc {
+	return i18n.F("invalid YAML: %v", err)
+}
+
+// ParseYAML decodes a YAML document and validates it against s. Anchors, aliases and
+// merge keys (<<) are resolved, mapping keys of any scalar type are converted to
+// strings, and timestamps are kept as strings so Date and DateTime schemas can
+// validate them. Errors carry the Location of the offending value, as with ParseTOML.
+// A document that cannot be decoded fails with code "invalid_yaml".
+func ParseYAML(s Parseable, data []byte, ctx *ValidationContext) ParseResult {
+	value, locs, err := decodeYAML(data)
+	if err != nil {
+		return documentError(yamlDecodeError(err)(ctx.Locale), CodeInvalidYAML, nil, ctx.Source)
+	}
+	return locs.attach(s.Parse(value, ctx), ctx.Source)
+}
+
+// ParseYAML decodes a YAML document and validates it against the object schema
+func (s *ObjectSchema) ParseYAML(data []byte, ctx *ValidationContext) ParseResult {
+	return ParseYAML(s, data, ctx)
+}
+
+// yamlDecoder converts YAML nodes into values and records their locations
+type yamlDecoder struct {
+	lines []int // Offset of the start of each line
+	locs  sourceLocations
+}
+
+// decodeYAML decodes the first document in data into maps, slices and scalars
+func decodeYAML(data []byte) (interface{}, sourceLocations, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	d := &yamlDecoder{lines: []int{0}, locs: sourceLocations{}}
+	for i, b := range data {
+		if b == '\n' {
+			d.lines = append(d.lines, i+1)
+		}
+	}
+	value, err := d.value(&doc, Path{}, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, d.locs, nil
+}
+
+// location converts the line and column of a node into a Location
+func (d *yamlDecoder) location(n *yaml.Node) Location {
+	loc := Location{Line: n.Line, Column: n.Column}
+	if n.Line >= 1 && n.Line <= len(d.lines) {
+		loc.Offset = d.lines[n.Line-1] + n.Column - 1
+	}
+	return loc
+}
+
+// value converts the YAML node at path into the values produced by encoding/json,
+// except that integers are decoded as int
+func (d *yamlDecoder) value(n *yaml.Node, path Path, depth int) (interface{}, error) {
+	if n.Kind != yaml.DocumentNode {
+		d.locs.add(path, d.location(n))
+	}
+	if depth > DefaultMaxDepth {
+		return nil, fmt.Errorf("line %d: document is nested too deeply", n.Line)
+	}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return d.value(n.Content[0], path, depth+1)
+
+	case yaml.AliasNode:
+		return d.value(n.Alias, path, depth+1)
+
+	case yaml.SequenceNode:
+		items := make([]interface{}, len(n.Content))
+		for i, child := range n.Content {
+			item, err := d.value(child, path.Index(i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+
+	case yaml.MappingNode:
+		result := make(map[string]interface{}, len(n.Content)/2)
+		var merges []*yaml.Node
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			keyNode, valueNode := n.Content[i], n.Content[i+1]
+			if keyNode.Kind == yaml.ScalarNode && keyNode.ShortTag() == "!!merge" {
+				merges = append(merges, valueNode)
+				continue
+			}
+			key, err := d.key(keyNode, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			value, err := d.value(valueNode, path.Field(key), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+		}
+		// Merged mappings never override keys set explicitly
+		for _, merge := range merges {
+			if err := d.merge(result, merge, path, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+
+	case yaml.ScalarNode:
+		return yamlScalar(n)
+	}
+	return nil, fmt.Errorf("line %d: unsupported YAML node", n.Line)
+}
+
+// merge adds the keys of a merged mapping (or sequence of mappings) that are not
+// already present in result, the mapping at path
+func (d *yamlDecoder) merge(result map[string]interface{}, n *yaml.Node, path Path, depth int) error {
+	source := n
+	if source.Kind == yaml.AliasNode {
+		source = source.Alias
+	}
+	if source.Kind == yaml.SequenceNode {
+		for _, item := range source.Content {
+			if err := d.merge(result, item, path, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	value, err := d.value(source, path, depth)
+	if err != nil {
+		return err
+	}
+	merged, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("line %d: merge value must be a mapping", n.Line)
+	}
+	for key, val := range merged {
+		if _, exists := result[key]; !exists {
+			result[key] = val
+		}
+	}
+	return nil
+}
+
+// key converts a mapping key to a string. Scalar keys keep their source text
+// (so 1 becomes "1" and true becomes "true"); other keys are formatted with fmt.
+func (d *yamlDecoder) key(n *yaml.Node, depth int) (string, error) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n.Kind == yaml.ScalarNode {
+		return n.Value, nil
+	}
+	value, err := d.value(n, nil, depth)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// yamlScalar decodes a scalar according to its resolved tag
+func yamlScalar(n *yaml.Node) (interface{}, error) {
+	switch n.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		err := n.Decode(&b)
+		return b, err
+	case "!!int":
+		var i int64
+		if err := n.Decode(&i); err != nil {
+			// Integers beyond int64 are decoded as floats
+			var f float64
+			err := n.Decode(&f)
+			return f, err
+		}
+		if i >= math.MinInt && i <= math.MaxInt {
+			return int(i), nil
+		}
+		return i, nil
+	case "!!float":
+		var f float64
+		err := n.Decode(&f)
+		return f, err
+	default:
+		// Strings, timestamps, binary and custom tags keep their text
+		return n.Value, nil
+	}
+}
@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	ctx := DefaultValidationContext()
+
+	container := Object().
+		Property("name", String()).
+		Property("image", String()).
+		Property("replicas", Int().Min(1)).
+		Property("env", Record(String(), String()).Optional()).
+		Property("ports", Array(Int()).Optional())
+	deployment := Object().
+		Property("created", Date()).
+		Property("containers", Array(container))
+
+	data := []byte(`
+created: 2024-05-01
+defaults: &defaults
+  image: nginx:1.25
+  replicas: 2
+containers:
+  - <<: *defaults
+    name: web
+    ports: [80, 443]
+  - <<: *defaults
+    name: worker
+    replicas: 5
+    env:
+      1: one
+      true: yes
+`)
+
+	result := Object().Passthrough().
+		Property("created", Date()).
+		Property("containers", Array(container)).
+		ParseYAML(data, ctx)
+	if !result.Valid {
+		t.Fatalf("expected valid, got %v", result.Errors)
+	}
+
+	value := result.Value.(map[string]interface{})
+	if value["created"] != "2024-05-01" {
+		t.Errorf("created = %#v, want the date string", value["created"])
+	}
+	containers := value["containers"].([]interface{})
+	web := containers[0].(map[string]interface{})
+	worker := containers[1].(map[string]interface{})
+	if web["image"] != "nginx:1.25" || web["replicas"] != 2 {
+		t.Errorf("merged defaults not applied: %v", web)
+	}
+	if worker["replicas"] != 5 {
+		t.Errorf("explicit keys should override merged ones, got %v", worker["replicas"])
+	}
+	if !reflect.DeepEqual(worker["env"], map[string]interface{}{"1": "one", "true": "yes"}) {
+		t.Errorf("non-string keys = %#v", worker["env"])
+	}
+
+	invalid := []byte("created: 2024-05-01\ncontainers:\n  - name: x\n    image: y\n    replicas: 0\n")
+	result = ParseYAML(deployment, invalid, DefaultValidationContext().WithSource("deploy.yaml"))
+	if result.Valid {
+		t.Fatal("expected replicas: 0 to fail")
+	}
+	last := result.Errors[len(result.Errors)-1]
+	if last.Location == nil || last.Location.String() != "deploy.yaml:5:15" || last.Location.Offset != bytes.Index(invalid, []byte("0\n")) {
+		t.Errorf("replicas location = %+v, want deploy.yaml:5:15", last.Location)
+	}
+
+	result = ParseYAML(deployment, []byte("containers: [unclosed"), ctx)
+	if result.Valid || result.Errors[0].Code != "invalid_yaml" {
+		t.Errorf("expected invalid_yaml, got %v", result.Errors)
+	}
+}